@@ -8,6 +8,8 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
 )
 
 type Config struct {
@@ -20,16 +22,28 @@ type Config struct {
 	PostgresUser     string `mapstructure:"postgres_user"`
 	PostgresPassword string `mapstructure:"postgres_password"`
 
+	MessageBroker string           `mapstructure:"message_broker"`
+	NATS          queue.NATSConfig `mapstructure:"nats"`
+
 	RabbitmqHost     string `mapstructure:"rabbitmq_host"`
 	RabbitmqPort     int    `mapstructure:"rabbitmq_port"`
 	RabbitmqUser     string `mapstructure:"rabbitmq_user"`
 	RabbitmqPassword string `mapstructure:"rabbitmq_password"`
 
-	QueueName        string `mapstructure:"main_queue"`
+	QueueName string `mapstructure:"main_queue"`
+	// DLQQueue is the dead letter queue reported by GetQueueStats; empty leaves it out.
+	DLQQueue string `mapstructure:"dlq_queue"`
+	// DelayQueue holds jobs waiting out a retry delay before they return to the main queue; it is
+	// reported by GetQueueStats like DLQQueue.
+	DelayQueue       string `mapstructure:"delay_queue"`
 	MaxRetryAttempts int    `mapstructure:"max_retry_attempts"`
 
 	BatchSize    int `mapstructure:"batch_size"`
 	BatchDelayMs int `mapstructure:"batch_delay_ms"`
+
+	// PublishWorkers is how many batches are published concurrently while the next pages are
+	// read from the database.
+	PublishWorkers int `mapstructure:"publish_workers"`
 }
 
 func loadConfig() Config {
@@ -65,5 +79,44 @@ func loadConfig() Config {
 	config.RabbitmqPassword = os.ExpandEnv(config.RabbitmqPassword)
 	config.RabbitmqPort, _ = strconv.Atoi(os.ExpandEnv(fmt.Sprintf("%d", config.RabbitmqPort)))
 
+	config.NATS.URL = os.ExpandEnv(config.NATS.URL)
+
 	return config
 }
+
+// validate applies the defaults of unset keys and reports them, together with every invalid key.
+func (c *Config) validate() *configcheck.Report {
+	report := &configcheck.Report{}
+
+	configcheck.Default(report, "orchestrator.server_port", &c.ServerPost, 50051)
+
+	report.Required("orchestrator.postgres_host", c.PostgresHost)
+	report.Required("orchestrator.postgres_db", c.PostgresDB)
+	report.Required("orchestrator.postgres_user", c.PostgresUser)
+	configcheck.Default(report, "orchestrator.postgres_port", &c.PostgresPort, 5432)
+	configcheck.Range(report, "orchestrator.postgres_port", c.PostgresPort, 1, 65535)
+
+	configcheck.Default(report, "orchestrator.message_broker", &c.MessageBroker, queue.BrokerRabbitMQ)
+	report.OneOf("orchestrator.message_broker", c.MessageBroker, queue.BrokerRabbitMQ, queue.BrokerNATS)
+	if c.MessageBroker == queue.BrokerNATS {
+		report.Required("orchestrator.nats.url", c.NATS.URL)
+		report.Required("orchestrator.nats.stream_name", c.NATS.StreamName)
+		report.Required("orchestrator.nats.consumer_name", c.NATS.ConsumerName)
+	} else {
+		report.Required("orchestrator.rabbitmq_host", c.RabbitmqHost)
+		report.Required("orchestrator.rabbitmq_user", c.RabbitmqUser)
+		configcheck.Default(report, "orchestrator.rabbitmq_port", &c.RabbitmqPort, 5672)
+		configcheck.Range(report, "orchestrator.rabbitmq_port", c.RabbitmqPort, 1, 65535)
+	}
+
+	report.Required("orchestrator.main_queue", c.QueueName)
+	configcheck.Default(report, "orchestrator.max_retry_attempts", &c.MaxRetryAttempts, 5)
+	configcheck.Default(report, "orchestrator.batch_size", &c.BatchSize, 1000)
+	if c.BatchDelayMs < 0 {
+		report.Errorf("orchestrator.batch_delay_ms", "must not be negative, got %d", c.BatchDelayMs)
+	}
+	configcheck.Default(report, "orchestrator.publish_workers", &c.PublishWorkers, 4)
+	configcheck.Range(report, "orchestrator.publish_workers", c.PublishWorkers, 1, 64)
+
+	return report
+}
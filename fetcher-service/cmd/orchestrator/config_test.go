@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+)
+
+func TestValidateReportsEveryProblemAtOnce(t *testing.T) {
+	c := Config{
+		PostgresHost:   "postgres",
+		PostgresPort:   70000,
+		MessageBroker:  queue.BrokerNATS,
+		NATS:           queue.NATSConfig{URL: "nats://nats:4222"},
+		BatchDelayMs:   -5,
+		PublishWorkers: 100,
+	}
+
+	report := c.validate()
+
+	assert.Equal(t, []configcheck.Issue{
+		{Key: "orchestrator.postgres_db", Message: "is required"},
+		{Key: "orchestrator.postgres_user", Message: "is required"},
+		{Key: "orchestrator.postgres_port", Message: "must be between 1 and 65535, got 70000"},
+		{Key: "orchestrator.nats.stream_name", Message: "is required"},
+		{Key: "orchestrator.nats.consumer_name", Message: "is required"},
+		{Key: "orchestrator.main_queue", Message: "is required"},
+		{Key: "orchestrator.batch_delay_ms", Message: "must not be negative, got -5"},
+		{Key: "orchestrator.publish_workers", Message: "must be between 1 and 64, got 100"},
+	}, report.Errors)
+	assert.Equal(t, []configcheck.Issue{
+		{Key: "orchestrator.server_port", Message: "50051"},
+		{Key: "orchestrator.max_retry_attempts", Message: "5"},
+		{Key: "orchestrator.batch_size", Message: "1000"},
+	}, report.Defaults)
+}
+
+func TestValidateDefaultsRabbitMQ(t *testing.T) {
+	c := Config{
+		PostgresHost: "postgres",
+		PostgresDB:   "hotels",
+		PostgresUser: "hotels",
+		RabbitmqHost: "rabbitmq",
+		RabbitmqUser: "guest",
+		QueueName:    "hotel.queue",
+	}
+
+	report := c.validate()
+
+	assert.NoError(t, report.Err())
+	assert.Equal(t, queue.BrokerRabbitMQ, c.MessageBroker)
+	assert.Equal(t, 5672, c.RabbitmqPort)
+	assert.Equal(t, 4, c.PublishWorkers)
+	assert.Equal(t, []configcheck.Issue{
+		{Key: "orchestrator.server_port", Message: "50051"},
+		{Key: "orchestrator.postgres_port", Message: "5432"},
+		{Key: "orchestrator.message_broker", Message: queue.BrokerRabbitMQ},
+		{Key: "orchestrator.rabbitmq_port", Message: "5672"},
+		{Key: "orchestrator.max_retry_attempts", Message: "5"},
+		{Key: "orchestrator.batch_size", Message: "1000"},
+		{Key: "orchestrator.publish_workers", Message: "4"},
+	}, report.Defaults)
+}
+
+func TestValidateRejectsUnknownBroker(t *testing.T) {
+	c := Config{MessageBroker: "kafka"}
+
+	report := c.validate()
+
+	assert.Contains(t, report.Errors, configcheck.Issue{
+		Key:     "orchestrator.message_broker",
+		Message: `must be one of rabbitmq, nats, got "kafka"`,
+	})
+	assert.Contains(t, report.Errors, configcheck.Issue{Key: "orchestrator.rabbitmq_host", Message: "is required"})
+}
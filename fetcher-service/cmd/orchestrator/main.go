@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"os/signal"
@@ -13,11 +14,12 @@ import (
 
 	"github.com/common-nighthawk/go-figure"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/grpcjson"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/infrastructure/queue"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestrator"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
 	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/grpcjson"
 	"github.com/victoragudo/hotel-management-system/pkg/logger"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -28,68 +30,75 @@ func main() {
 	config := loadConfig()
 
 	applicationLogger := logger.SetupLogger("info")
+	config.validate().Enforce(applicationLogger)
 
-	rabbitMQAddress := fmt.Sprintf("amqp://%s:%s@%s:%d/", config.RabbitmqUser, config.RabbitmqPassword, config.RabbitmqHost, config.RabbitmqPort)
-	applicationLogger.Info(rabbitMQAddress)
-	amqpConnection, err := amqp.Dial(rabbitMQAddress)
-	if err != nil {
-		applicationLogger.Error("Failed to connect to RabbitMQ", "error", err)
+	if err := run(config, applicationLogger); err != nil {
+		applicationLogger.Error("Orchestrator failed", "broker", config.MessageBroker, "error", err)
 		os.Exit(1)
 	}
-	defer func(conn *amqp.Connection) {
-		err := conn.Close()
-		if err != nil {
-			applicationLogger.Error("Failed to close RabbitMQ connection", "error", err)
-		}
-	}(amqpConnection)
+}
 
-	amqpChannel, err := amqpConnection.Channel()
+// run sets up the orchestrator and serves until shutdown. It returns its errors rather than
+// exiting so the broker connection and channel are closed on every path.
+func run(config Config, applicationLogger *slog.Logger) error {
+	publisher, err := newPublisher(config, applicationLogger)
 	if err != nil {
-		applicationLogger.Error("Failed to open a channel", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create message publisher: %w", err)
 	}
-
-	defer func(ch *amqp.Channel) {
-		err := ch.Close()
-		if err != nil {
-			applicationLogger.Error("Failed to close RabbitMQ channel", "error", err)
-		}
-	}(amqpChannel)
-
-	rabbitMQPublisher, _ := queue.NewMQPublisher(
-		amqpConnection,
-		amqpChannel,
-		config.QueueName,
-	)
+	defer publisher.Close()
 
 	connectionString := fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=disable", config.PostgresHost, config.PostgresPort, config.PostgresDB, config.PostgresUser, config.PostgresPassword)
 	db, err := database.GormOpen(connectionString)
 	if err != nil {
-		applicationLogger.Error("db connect failed", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("db connect failed: %w", err)
 	}
 
 	if err := database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}); err != nil {
-		applicationLogger.Error("db migrations failed", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("db migrations failed: %w", err)
 	}
 
 	server := &OrchestratorGRPCServer{
-		config:            config,
-		logger:            applicationLogger,
-		rabbitMQPublisher: rabbitMQPublisher,
-		db:                db,
+		config:    config,
+		logger:    applicationLogger,
+		publisher: publisher,
+		db:        db,
 	}
 
 	if err := server.Start(); err != nil {
-		applicationLogger.Error("Failed to start orchestrator server", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to start orchestrator server: %w", err)
+	}
+	return nil
+}
+
+func newPublisher(config Config, applicationLogger *slog.Logger) (queue.PublisherPort, error) {
+	switch config.MessageBroker {
+	case queue.BrokerNATS:
+		applicationLogger.Info("Using NATS JetStream message broker", "url", config.NATS.URL, "stream", config.NATS.StreamName)
+		return queue.NewNATSPublisher(&config.NATS, config.QueueName)
+	case queue.BrokerRabbitMQ:
+		rabbitMQAddress := fmt.Sprintf("amqp://%s:%s@%s:%d/", config.RabbitmqUser, config.RabbitmqPassword, config.RabbitmqHost, config.RabbitmqPort)
+		applicationLogger.Info(rabbitMQAddress)
+		amqpConnection, err := amqp.Dial(rabbitMQAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		}
+
+		amqpChannel, err := amqpConnection.Channel()
+		if err != nil {
+			_ = amqpConnection.Close()
+			return nil, fmt.Errorf("failed to open a channel: %w", err)
+		}
+
+		return queue.NewMQPublisher(amqpConnection, amqpChannel, config.QueueName)
+	default:
+		return nil, fmt.Errorf("unsupported message broker: %s", config.MessageBroker)
 	}
 }
 
 func (s *OrchestratorGRPCServer) Start() error {
 	grpcjson.Register()
 	figure.NewFigure("ORCHESTRATOR", "", true).Print()
+	s.logger.Info("Starting orchestrator", buildinfo.Get("orchestrator").LogAttrs()...)
 	fmt.Println("gRPC server started at ", s.config.ServerHost, ":", s.config.ServerPost)
 	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.config.ServerHost, s.config.ServerPost))
 	if err != nil {
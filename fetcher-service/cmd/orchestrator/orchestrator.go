@@ -4,23 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/infrastructure/queue"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/pkg/constants"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestrator"
-	constants2 "github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
 	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 type OrchestratorGRPCServer struct {
 	orchestrator.UnimplementedOrchestratorServiceServer
-	config            Config
-	logger            *slog.Logger
-	rabbitMQPublisher *queue.RabbitMQPublisher
-	db                *gorm.DB
+	config    Config
+	logger    *slog.Logger
+	publisher queue.PublisherPort
+	db        *gorm.DB
+
+	// lastEnqueueAt is the unix time of the last successfully published batch.
+	lastEnqueueAt atomic.Int64
 }
 
 func (s *OrchestratorGRPCServer) ProcessFetchRequest(ctx context.Context, fetchRequest *orchestrator.FetchRequest) (*orchestrator.FetchResponse, error) {
@@ -61,12 +68,54 @@ func (s *OrchestratorGRPCServer) ProcessFetchRequest(ctx context.Context, fetchR
 
 func (s *OrchestratorGRPCServer) GetHealthStatus(_ context.Context, _ *orchestrator.HealthRequest) (*orchestrator.HealthResponse, error) {
 	return &orchestrator.HealthResponse{
-		Status: "healthy",
+		Healthy:    true,
+		Status:     "healthy",
+		Components: buildinfo.Get("orchestrator").Map(),
+		Timestamp:  time.Now().Unix(),
 	}, nil
 }
 
+// GetQueueStats reports how many jobs wait in the main, delay and dead letter queues. A delay
+// queue or DLQ that cannot be inspected is left out rather than failing the call.
+func (s *OrchestratorGRPCServer) GetQueueStats(ctx context.Context, _ *orchestrator.QueueStatsRequest) (*orchestrator.QueueStatsResponse, error) {
+	mainDepth, err := s.publisher.QueueDepth(ctx, s.config.QueueName)
+	if err != nil {
+		s.logger.Warn("Failed to inspect main queue", "queue", s.config.QueueName, "error", err)
+		return nil, status.Errorf(codes.Unavailable, "failed to inspect main queue: %v", err)
+	}
+
+	response := &orchestrator.QueueStatsResponse{
+		MainQueue:     s.config.QueueName,
+		MainDepth:     mainDepth,
+		LastEnqueueAt: s.lastEnqueueAt.Load(),
+		Timestamp:     time.Now().Unix(),
+	}
+
+	if s.config.DelayQueue != "" {
+		delayDepth, err := s.publisher.QueueDepth(ctx, s.config.DelayQueue)
+		if err != nil {
+			s.logger.Warn("Failed to inspect delay queue", "queue", s.config.DelayQueue, "error", err)
+		} else {
+			response.DelayQueue = s.config.DelayQueue
+			response.DelayDepth = delayDepth
+		}
+	}
+
+	if s.config.DLQQueue != "" {
+		dlqDepth, err := s.publisher.QueueDepth(ctx, s.config.DLQQueue)
+		if err != nil {
+			s.logger.Warn("Failed to inspect dead letter queue", "queue", s.config.DLQQueue, "error", err)
+		} else {
+			response.DlqQueue = s.config.DLQQueue
+			response.DlqDepth = dlqDepth
+		}
+	}
+
+	return response, nil
+}
+
 // enqueueJobs enqueues jobs for processing based on the specified fetch type and hotel ID, using batching for database queries.
-// It publishes job information to RabbitMQ and handles retries in case of failures. Returns the count of jobs enqueued,
+// It publishes job information to the message broker and handles retries in case of failures. Returns the count of jobs enqueued,
 // details of the jobs enqueued, and any error encountered during the operation.
 func (s *OrchestratorGRPCServer) enqueueJobs(ctx context.Context, messageType orchestrator.MessageType) (int, []*orchestrator.JobInfo, error) {
 	messageTypeStr := "hotel"
@@ -97,138 +146,192 @@ func (s *OrchestratorGRPCServer) enqueueJobs(ctx context.Context, messageType or
 	return jobsTotal, jobInfos, nil
 }
 
-// processBatch handles the common batch processing logic for querying hotel ID and publishing jobs.
-// It returns the total number of jobs processed and any error encountered.
+// processBatch queries the records to refresh page by page and publishes a job per record.
+// It returns the number of jobs published and the first error encountered.
 func (s *OrchestratorGRPCServer) processBatch(ctx context.Context, messageTypeStr string, collectJobInfos bool) (int, []*orchestrator.JobInfo, error) {
 	batchSize := s.config.BatchSize
 	if batchSize <= 0 {
 		batchSize = 1000
 	}
+	return s.publishPages(ctx, func(ctx context.Context, lastHotelID int64) ([]queue.Message, []*orchestrator.JobInfo, int64, error) {
+		return s.fetchJobs(ctx, messageTypeStr, lastHotelID, batchSize, collectJobInfos)
+	})
+}
+
+// pageFetcher reads the page of jobs after lastHotelID. It returns the last hotel ID of the
+// page, and no jobs once every record was read.
+type pageFetcher func(ctx context.Context, lastHotelID int64) ([]queue.Message, []*orchestrator.JobInfo, int64, error)
+
+// publishPages reads pages in order, since each continues after the last hotel ID of the
+// previous one, and hands them to PublishWorkers goroutines that publish them concurrently.
+// It returns the number of jobs published, the job infos of the pages read and the first error
+// encountered.
+func (s *OrchestratorGRPCServer) publishPages(ctx context.Context, fetchPage pageFetcher) (int, []*orchestrator.JobInfo, error) {
 	batchDelay := time.Duration(s.config.BatchDelayMs) * time.Millisecond
+	publishWorkers := max(s.config.PublishWorkers, 1)
+
+	// A failed publish cancels the remaining pages; its error is the cause.
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var jobsTotal atomic.Int64
+	batches := make(chan []queue.Message)
+	var publishers sync.WaitGroup
+	for range publishWorkers {
+		publishers.Go(func() {
+			for jobs := range batches {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := s.publisher.PublishWithRetry(ctx, jobs, s.config.MaxRetryAttempts); err != nil {
+					cancel(err)
+					continue
+				}
+				jobsTotal.Add(int64(len(jobs)))
+				s.lastEnqueueAt.Store(time.Now().Unix())
+			}
+		})
+	}
 
 	var lastHotelID int64 = 0
-	jobsTotal := 0
 	var jobInfos []*orchestrator.JobInfo
-	if collectJobInfos {
-		jobInfos = make([]*orchestrator.JobInfo, 0)
-	}
 
+	var err error
 	for {
+		var (
+			jobs          []queue.Message
+			batchJobInfos []*orchestrator.JobInfo
+		)
+		jobs, batchJobInfos, lastHotelID, err = fetchPage(ctx, lastHotelID)
+		if err != nil || len(jobs) == 0 {
+			break
+		}
+		jobInfos = append(jobInfos, batchJobInfos...)
+
 		select {
+		case batches <- jobs:
 		case <-ctx.Done():
-			return jobsTotal, jobInfos, ctx.Err()
-		default:
 		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		time.Sleep(batchDelay)
+	}
 
-		var (
-			records             []database.IDWithHotelID
-			missingTranslations []database.HotelMissingLang
-			missingReviews      []database.IDWithHotelID
-			err                 error
-		)
+	close(batches)
+	publishers.Wait()
+	if cause := context.Cause(ctx); cause != nil {
+		err = cause
+	}
+	return int(jobsTotal.Load()), jobInfos, err
+}
 
-		switch messageTypeStr {
-		case constants.MessageTypeUpdateHotel:
-			records, err = database.QueryHotelIDsByID(ctx, s.db, lastHotelID, batchSize)
-		case constants.MessageTypeUpdateReview:
-			records, err = database.QueryReviewIDsByID(ctx, s.db, lastHotelID, batchSize)
-		case constants.MessageTypeUpdateTranslation:
-			records, err = database.QueryTranslationIDsByID(ctx, s.db, lastHotelID, batchSize)
-		case constants.MessageTypeFetchTranslation:
-			missingTranslations, err = database.GetHotelsWithMissingTranslationsRaw(ctx, s.db, lastHotelID, batchSize)
-		case constants.MessageTypeFetchReview:
-			missingReviews, err = database.GetMissingReviewsFromHotelID(ctx, s.db, lastHotelID, batchSize)
-		default:
-			records, err = database.QueryHotelIDsByID(ctx, s.db, lastHotelID, batchSize)
-		}
+// fetchJobs reads the page of records after lastHotelID and builds their jobs. It returns the
+// last hotel ID of the page, and no jobs once every record was read.
+func (s *OrchestratorGRPCServer) fetchJobs(ctx context.Context, messageTypeStr string, lastHotelID int64, batchSize int, collectJobInfos bool) ([]queue.Message, []*orchestrator.JobInfo, int64, error) {
+	var (
+		records             []database.IDWithHotelID
+		missingTranslations []database.HotelMissingLang
+		missingReviews      []database.IDWithHotelID
+		jobInfos            []*orchestrator.JobInfo
+		err                 error
+	)
+
+	switch messageTypeStr {
+	case constants.MessageTypeUpdateHotel:
+		records, err = database.QueryHotelIDsByID(ctx, s.db, lastHotelID, batchSize)
+	case constants.MessageTypeUpdateReview:
+		records, err = database.QueryReviewIDsByID(ctx, s.db, lastHotelID, batchSize)
+	case constants.MessageTypeUpdateTranslation:
+		records, err = database.QueryTranslationIDsByID(ctx, s.db, lastHotelID, batchSize)
+	case constants.MessageTypeFetchTranslation:
+		missingTranslations, err = database.GetHotelsWithMissingTranslationsRaw(ctx, s.db, lastHotelID, batchSize)
+	case constants.MessageTypeFetchReview:
+		missingReviews, err = database.GetMissingReviewsFromHotelID(ctx, s.db, lastHotelID, batchSize)
+	default:
+		records, err = database.QueryHotelIDsByID(ctx, s.db, lastHotelID, batchSize)
+	}
 
-		if err != nil {
-			return jobsTotal, jobInfos, err
+	if err != nil {
+		return nil, nil, lastHotelID, err
+	}
+
+	var jobs []queue.Message
+
+	if messageTypeStr == constants.MessageTypeFetchTranslation {
+		if len(missingTranslations) == 0 {
+			return nil, nil, lastHotelID, nil
 		}
 
-		var jobs []queue.Message
+		lastHotelID = missingTranslations[len(missingTranslations)-1].HotelID
+		jobs = make([]queue.Message, 0, len(missingTranslations))
 
-		if messageTypeStr == constants.MessageTypeFetchTranslation {
-			if len(missingTranslations) == 0 {
-				break
-			}
+		for _, missingTranslation := range missingTranslations {
+			jobs = append(jobs, messages.NewTranslationFetch(missingTranslation.HotelID, missingTranslation.MissingLang))
 
-			lastHotelID = missingTranslations[len(missingTranslations)-1].HotelID
-			jobs = make([]queue.Message, 0, len(missingTranslations))
-
-			for _, missingTranslation := range missingTranslations {
-				messageID := fmt.Sprintf("%d_%s", missingTranslation.HotelID, missingTranslation.MissingLang)
-				jobs = append(jobs, queue.Message{ID: messageID, Type: messageTypeStr, Data: map[string]any{
-					constants2.HotelId: strconv.FormatInt(missingTranslation.HotelID, 10),
-					constants2.Lang:    missingTranslation.MissingLang,
-				}})
-
-				if collectJobInfos {
-					jobInfos = append(jobInfos, &orchestrator.JobInfo{
-						HotelId:     int32(missingTranslation.HotelID),
-						MessageType: orchestrator.MessageType_FETCH_MISSING_TRANSLATIONS,
-						Status:      orchestrator.JobStatus_JOB_STATUS_PENDING,
-					})
-				}
-			}
-		} else if messageTypeStr == constants.MessageTypeFetchReview {
-			if len(missingReviews) == 0 {
-				break
+			if collectJobInfos {
+				jobInfos = append(jobInfos, &orchestrator.JobInfo{
+					HotelId:     int32(missingTranslation.HotelID),
+					MessageType: orchestrator.MessageType_FETCH_MISSING_TRANSLATIONS,
+					Status:      orchestrator.JobStatus_JOB_STATUS_PENDING,
+				})
 			}
+		}
+	} else if messageTypeStr == constants.MessageTypeFetchReview {
+		if len(missingReviews) == 0 {
+			return nil, nil, lastHotelID, nil
+		}
 
-			lastHotelID = missingReviews[len(missingReviews)-1].HotelID
-			jobs = make([]queue.Message, 0, len(missingReviews))
+		lastHotelID = missingReviews[len(missingReviews)-1].HotelID
+		jobs = make([]queue.Message, 0, len(missingReviews))
 
-			for _, missingReview := range missingReviews {
-				jobs = append(jobs, queue.Message{ID: missingReview.ID, Type: messageTypeStr, Data: map[string]any{
-					constants2.HotelId: strconv.FormatInt(missingReview.HotelID, 10),
-				}})
+		for _, missingReview := range missingReviews {
+			jobs = append(jobs, messages.NewReviewFetch(missingReview.ID, missingReview.HotelID))
 
-				if collectJobInfos {
-					jobInfos = append(jobInfos, &orchestrator.JobInfo{
-						HotelId:     int32(missingReview.HotelID),
-						MessageType: orchestrator.MessageType_FETCH_MISSING_REVIEWS,
-						Status:      orchestrator.JobStatus_JOB_STATUS_PENDING,
-					})
-				}
+			if collectJobInfos {
+				jobInfos = append(jobInfos, &orchestrator.JobInfo{
+					HotelId:     int32(missingReview.HotelID),
+					MessageType: orchestrator.MessageType_FETCH_MISSING_REVIEWS,
+					Status:      orchestrator.JobStatus_JOB_STATUS_PENDING,
+				})
 			}
-		} else {
-			if len(records) == 0 {
-				break
+		}
+	} else {
+		if len(records) == 0 {
+			return nil, nil, lastHotelID, nil
+		}
+
+		lastHotelID = records[len(records)-1].HotelID
+		jobs = make([]queue.Message, 0, len(records))
+
+		for _, record := range records {
+			switch messageTypeStr {
+			case constants.MessageTypeUpdateReview:
+				jobs = append(jobs, messages.NewReviewUpdate(record.ID, record.HotelID))
+			case constants.MessageTypeUpdateTranslation:
+				jobs = append(jobs, messages.NewTranslationUpdate(record.ID, record.HotelID))
+			default:
+				jobs = append(jobs, messages.NewHotelUpdate(record.ID, record.HotelID))
 			}
 
-			lastHotelID = records[len(records)-1].HotelID
-			jobs = make([]queue.Message, 0, len(records))
-
-			for _, record := range records {
-				jobs = append(jobs, queue.Message{ID: record.ID, Type: messageTypeStr, Data: map[string]any{
-					constants2.HotelId: strconv.FormatInt(record.HotelID, 10),
-				}})
-
-				if collectJobInfos {
-					var messageType orchestrator.MessageType
-					switch messageTypeStr {
-					case constants.MessageTypeUpdateHotel:
-						messageType = orchestrator.MessageType_UPDATE_HOTEL
-					case constants.MessageTypeUpdateReview:
-						messageType = orchestrator.MessageType_UPDATE_REVIEW
-					case constants.MessageTypeUpdateTranslation:
-						messageType = orchestrator.MessageType_UPDATE_TRANSLATION
-					case constants.MessageTypeFetchReview:
-						messageType = orchestrator.MessageType_FETCH_MISSING_REVIEWS
-					}
-					jobInfos = append(jobInfos, &orchestrator.JobInfo{HotelId: int32(record.HotelID), MessageType: messageType, Status: orchestrator.JobStatus_JOB_STATUS_PENDING})
+			if collectJobInfos {
+				var messageType orchestrator.MessageType
+				switch messageTypeStr {
+				case constants.MessageTypeUpdateHotel:
+					messageType = orchestrator.MessageType_UPDATE_HOTEL
+				case constants.MessageTypeUpdateReview:
+					messageType = orchestrator.MessageType_UPDATE_REVIEW
+				case constants.MessageTypeUpdateTranslation:
+					messageType = orchestrator.MessageType_UPDATE_TRANSLATION
+				case constants.MessageTypeFetchReview:
+					messageType = orchestrator.MessageType_FETCH_MISSING_REVIEWS
 				}
+				jobInfos = append(jobInfos, &orchestrator.JobInfo{HotelId: int32(record.HotelID), MessageType: messageType, Status: orchestrator.JobStatus_JOB_STATUS_PENDING})
 			}
 		}
-
-		if err := s.rabbitMQPublisher.PublishWithRetry(ctx, jobs, s.config.MaxRetryAttempts); err != nil {
-			return jobsTotal, jobInfos, err
-		}
-		jobsTotal += len(jobs)
-		time.Sleep(batchDelay)
 	}
-	return jobsTotal, jobInfos, nil
+
+	return jobs, jobInfos, lastHotelID, nil
 }
 
 // runOnce orchestrates hotel update processing and missing translations processing in batch mode, querying the database and publishing jobs to RabbitMQ.
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// depthPublisher reports fixed queue depths; a queue missing from depths fails to inspect.
+type depthPublisher struct {
+	queue.PublisherPort
+	depths map[string]int64
+}
+
+func (p *depthPublisher) QueueDepth(_ context.Context, queueName string) (int64, error) {
+	depth, ok := p.depths[queueName]
+	if !ok {
+		return 0, errors.New("queue not found")
+	}
+	return depth, nil
+}
+
+func newQueueStatsServer(config Config, depths map[string]int64) *OrchestratorGRPCServer {
+	return &OrchestratorGRPCServer{
+		config:    config,
+		logger:    slog.New(slog.DiscardHandler),
+		publisher: &depthPublisher{depths: depths},
+	}
+}
+
+func TestGetQueueStatsReportsMainDelayAndDLQDepths(t *testing.T) {
+	server := newQueueStatsServer(
+		Config{QueueName: "hotel_jobs", DelayQueue: "hotel_jobs.delay", DLQQueue: "hotel.dlq.queue"},
+		map[string]int64{"hotel_jobs": 4_200, "hotel_jobs.delay": 35, "hotel.dlq.queue": 7},
+	)
+	server.lastEnqueueAt.Store(1_790_000_000)
+
+	response, err := server.GetQueueStats(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hotel_jobs", response.MainQueue)
+	assert.Equal(t, int64(4_200), response.MainDepth)
+	assert.Equal(t, "hotel_jobs.delay", response.DelayQueue)
+	assert.Equal(t, int64(35), response.DelayDepth)
+	assert.Equal(t, "hotel.dlq.queue", response.DlqQueue)
+	assert.Equal(t, int64(7), response.DlqDepth)
+	assert.Equal(t, int64(1_790_000_000), response.LastEnqueueAt)
+	assert.NotZero(t, response.Timestamp)
+}
+
+func TestGetQueueStatsLeavesOutQueuesThatCannotBeInspected(t *testing.T) {
+	server := newQueueStatsServer(
+		Config{QueueName: "hotel_jobs", DelayQueue: "hotel_jobs.delay", DLQQueue: "hotel.dlq.queue"},
+		map[string]int64{"hotel_jobs": 12},
+	)
+
+	response, err := server.GetQueueStats(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), response.MainDepth)
+	assert.Empty(t, response.DelayQueue)
+	assert.Zero(t, response.DelayDepth)
+	assert.Empty(t, response.DlqQueue)
+	assert.Zero(t, response.DlqDepth)
+}
+
+func TestGetQueueStatsSkipsUnconfiguredQueues(t *testing.T) {
+	server := newQueueStatsServer(Config{QueueName: "hotel_jobs"}, map[string]int64{"hotel_jobs": 3, "": 99})
+
+	response, err := server.GetQueueStats(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, response.DelayQueue)
+	assert.Empty(t, response.DlqQueue)
+}
+
+func TestGetQueueStatsFailsWhenMainQueueCannotBeInspected(t *testing.T) {
+	server := newQueueStatsServer(Config{QueueName: "hotel_jobs"}, nil)
+
+	_, err := server.GetQueueStats(context.Background(), nil)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
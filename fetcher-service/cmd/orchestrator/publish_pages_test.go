@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestrator"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+)
+
+// recordingPublisher keeps the IDs of the messages it publishes and tracks how many batches
+// were published at once. It fails a batch holding failOn.
+type recordingPublisher struct {
+	queue.PublisherPort
+	delay  time.Duration
+	failOn string
+
+	mu          sync.Mutex
+	published   []string
+	inFlight    int
+	maxInFlight int
+}
+
+func (p *recordingPublisher) PublishWithRetry(_ context.Context, jobs []queue.Message, _ int) error {
+	p.mu.Lock()
+	p.inFlight++
+	p.maxInFlight = max(p.maxInFlight, p.inFlight)
+	p.mu.Unlock()
+
+	time.Sleep(p.delay)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	for _, job := range jobs {
+		if job.ID == p.failOn {
+			return errors.New("broker unavailable")
+		}
+	}
+	for _, job := range jobs {
+		p.published = append(p.published, job.ID)
+	}
+	return nil
+}
+
+// hotelPages serves pages of pageSize update jobs for hotels 1 to hotels, continuing after the
+// hotel ID it is given, and records the hotel IDs it was asked to continue from.
+type hotelPages struct {
+	hotels   int64
+	pageSize int64
+	fail     error
+
+	mu    sync.Mutex
+	after []int64
+}
+
+func (p *hotelPages) fetch(_ context.Context, lastHotelID int64) ([]queue.Message, []*orchestrator.JobInfo, int64, error) {
+	p.mu.Lock()
+	p.after = append(p.after, lastHotelID)
+	p.mu.Unlock()
+
+	if p.fail != nil && lastHotelID > 0 {
+		return nil, nil, lastHotelID, p.fail
+	}
+	var (
+		jobs     []queue.Message
+		jobInfos []*orchestrator.JobInfo
+	)
+	last := min(lastHotelID+p.pageSize, p.hotels)
+	for hotelID := lastHotelID + 1; hotelID <= last; hotelID++ {
+		jobs = append(jobs, messages.NewHotelUpdate(fmt.Sprintf("row-%d", hotelID), hotelID))
+		jobInfos = append(jobInfos, &orchestrator.JobInfo{HotelId: int32(hotelID), MessageType: orchestrator.MessageType_UPDATE_HOTEL})
+	}
+	return jobs, jobInfos, max(last, lastHotelID), nil
+}
+
+func newPublishPagesServer(publishWorkers int, publisher *recordingPublisher) *OrchestratorGRPCServer {
+	return &OrchestratorGRPCServer{
+		config:    Config{PublishWorkers: publishWorkers, MaxRetryAttempts: 1},
+		logger:    slog.New(slog.DiscardHandler),
+		publisher: publisher,
+	}
+}
+
+func TestPublishPagesPublishesEveryJobWhateverThePoolSize(t *testing.T) {
+	for _, publishWorkers := range []int{1, 2, 4, 16} {
+		t.Run(fmt.Sprintf("%d workers", publishWorkers), func(t *testing.T) {
+			publisher := &recordingPublisher{delay: time.Millisecond}
+			pages := &hotelPages{hotels: 1_050, pageSize: 100}
+			server := newPublishPagesServer(publishWorkers, publisher)
+
+			jobsTotal, jobInfos, err := server.publishPages(context.Background(), pages.fetch)
+			require.NoError(t, err)
+
+			assert.Equal(t, 1_050, jobsTotal)
+			assert.Len(t, jobInfos, 1_050)
+			assert.Len(t, publisher.published, 1_050)
+			var expected []string
+			for hotelID := 1; hotelID <= 1_050; hotelID++ {
+				expected = append(expected, fmt.Sprintf("row-%d", hotelID))
+			}
+			assert.ElementsMatch(t, expected, publisher.published, "every job is published once")
+			assert.Equal(t, []int64{0, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1_000, 1_050}, pages.after, "pages continue after the previous one")
+			assert.LessOrEqual(t, publisher.maxInFlight, publishWorkers)
+			assert.NotZero(t, server.lastEnqueueAt.Load())
+		})
+	}
+}
+
+func TestPublishPagesPublishesConcurrently(t *testing.T) {
+	publisher := &recordingPublisher{delay: 20 * time.Millisecond}
+	pages := &hotelPages{hotels: 40, pageSize: 5}
+	server := newPublishPagesServer(4, publisher)
+
+	jobsTotal, _, err := server.publishPages(context.Background(), pages.fetch)
+	require.NoError(t, err)
+
+	assert.Equal(t, 40, jobsTotal)
+	assert.Greater(t, publisher.maxInFlight, 1, "batches are published while the next pages are read")
+	assert.LessOrEqual(t, publisher.maxInFlight, 4)
+}
+
+func TestPublishPagesStopsOnThePublishError(t *testing.T) {
+	publisher := &recordingPublisher{failOn: "row-301"}
+	pages := &hotelPages{hotels: 10_000, pageSize: 100}
+	server := newPublishPagesServer(2, publisher)
+
+	jobsTotal, _, err := server.publishPages(context.Background(), pages.fetch)
+	require.EqualError(t, err, "broker unavailable")
+
+	assert.Equal(t, len(publisher.published), jobsTotal, "only published jobs are counted")
+	assert.NotContains(t, publisher.published, "row-301")
+	assert.Less(t, len(pages.after), 100, "the remaining pages are not read")
+}
+
+func TestPublishPagesPublishesDispatchedPagesBeforeReturningAReadError(t *testing.T) {
+	publisher := &recordingPublisher{delay: time.Millisecond}
+	pages := &hotelPages{hotels: 1_000, pageSize: 100, fail: errors.New("connection reset")}
+	server := newPublishPagesServer(4, publisher)
+
+	jobsTotal, _, err := server.publishPages(context.Background(), pages.fetch)
+	require.EqualError(t, err, "connection reset")
+
+	assert.Equal(t, 100, jobsTotal)
+	assert.Len(t, publisher.published, 100)
+}
+
+func TestPublishPagesStopsWhenTheContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	publisher := &recordingPublisher{}
+	pages := &hotelPages{hotels: 1_000, pageSize: 100}
+	server := newPublishPagesServer(2, publisher)
+	cancel()
+
+	jobsTotal, _, err := server.publishPages(ctx, pages.fetch)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, jobsTotal)
+	assert.Empty(t, publisher.published)
+}
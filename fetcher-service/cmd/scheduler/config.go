@@ -1,12 +1,17 @@
 package main
 
 import (
+	"os"
 	"strings"
 
 	"github.com/spf13/viper"
 	gotenv "github.com/subosito/gotenv"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
 )
 
+// maxIntervalMinutes is one week; longer intervals are almost certainly a unit mistake.
+const maxIntervalMinutes = 7 * 24 * 60
+
 type Config struct {
 	IntervalsInMinutes struct {
 		UpdateHotels             uint64 `mapstructure:"update_hotels"`
@@ -17,6 +22,11 @@ type Config struct {
 	} `mapstructure:"intervals_in_minutes"`
 	OrchestratorGrpcHost string `mapstructure:"orchestrator_grpc_host"`
 	OrchestratorGrpcPort uint16 `mapstructure:"orchestrator_grpc_port"`
+	GrpcHost             string `mapstructure:"grpc_host"`
+	GrpcPort             uint16 `mapstructure:"grpc_port"`
+	RedisHost            string `mapstructure:"redis_host"`
+	RedisPort            int    `mapstructure:"redis_port"`
+	RedisPassword        string `mapstructure:"redis_password"`
 }
 
 func loadConfig() Config {
@@ -48,6 +58,30 @@ func loadConfig() Config {
 	// Override config values with environment variables if running in Docker
 	config.OrchestratorGrpcHost = viper.GetString("scheduler.orchestrator_grpc_host")
 	config.OrchestratorGrpcPort = uint16(viper.GetInt("scheduler.orchestrator_grpc_port"))
+	config.RedisHost = os.ExpandEnv(config.RedisHost)
+	config.RedisPassword = os.ExpandEnv(config.RedisPassword)
 
 	return config
 }
+
+// validate applies the defaults of unset keys and reports them, together with every invalid key.
+func (c *Config) validate() *configcheck.Report {
+	report := &configcheck.Report{}
+
+	intervals := c.IntervalsInMinutes
+	configcheck.Range(report, "scheduler.intervals_in_minutes.update_hotels", intervals.UpdateHotels, 1, maxIntervalMinutes)
+	configcheck.Range(report, "scheduler.intervals_in_minutes.update_reviews", intervals.UpdateReviews, 1, maxIntervalMinutes)
+	configcheck.Range(report, "scheduler.intervals_in_minutes.update_translations", intervals.UpdateTranslations, 1, maxIntervalMinutes)
+	configcheck.Range(report, "scheduler.intervals_in_minutes.fetch_missing_translations", intervals.FetchMissingTranslations, 1, maxIntervalMinutes)
+	configcheck.Range(report, "scheduler.intervals_in_minutes.fetch_missing_reviews", intervals.FetchMissingReviews, 1, maxIntervalMinutes)
+
+	report.Required("scheduler.orchestrator_grpc_host", c.OrchestratorGrpcHost)
+	configcheck.Default(report, "scheduler.orchestrator_grpc_port", &c.OrchestratorGrpcPort, 50051)
+	configcheck.Default(report, "scheduler.grpc_port", &c.GrpcPort, 50052)
+
+	report.Required("scheduler.redis_host", c.RedisHost)
+	configcheck.Default(report, "scheduler.redis_port", &c.RedisPort, 6379)
+	configcheck.Range(report, "scheduler.redis_port", c.RedisPort, 1, 65535)
+
+	return report
+}
@@ -10,6 +10,7 @@ func main() {
 	config := loadConfig()
 
 	applicationLogger := logger.SetupLogger("info")
+	config.validate().Enforce(applicationLogger)
 
 	jobScheduler, err := NewScheduler(config, applicationLogger)
 	if err != nil {
@@ -17,5 +18,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	jobScheduler.Start()
+	if err := jobScheduler.Start(); err != nil {
+		applicationLogger.Error("Failed to start scheduler", "error", err)
+		os.Exit(1)
+	}
 }
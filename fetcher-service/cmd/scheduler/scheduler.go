@@ -4,26 +4,59 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/common-nighthawk/go-figure"
 	"github.com/google/uuid"
 	"github.com/jasonlvhit/gocron"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/grpcjson"
+	"github.com/redis/go-redis/v9"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestrator"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/proto/scheduler"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/pkg/grpcjson"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// triggerHistorySize is how many trigger times are kept per message type.
+	triggerHistorySize         = 100
+	defaultTriggerHistoryLimit = 10
+	triggerHistoryTimeout      = 2 * time.Second
+)
+
+// scheduledMessageTypes are the message types triggered on an interval.
+var scheduledMessageTypes = []scheduler.MessageType{
+	scheduler.MessageType_UPDATE_HOTEL,
+	scheduler.MessageType_UPDATE_REVIEW,
+	scheduler.MessageType_UPDATE_TRANSLATION,
+	scheduler.MessageType_FETCH_MISSING_TRANSLATIONS,
+	scheduler.MessageType_FETCH_MISSING_REVIEWS,
+}
+
 type Scheduler struct {
+	scheduler.UnimplementedSchedulerServiceServer
 	config             Config
 	orchestratorServer orchestrator.OrchestratorServiceClient
 	scheduler          *gocron.Scheduler
+	redisClient        *redis.Client
 	logger             *slog.Logger
+	isPaused           int32
+	pauseMu            sync.Mutex
+	pauseReason        string
+	pausedAt           int64
+	lastRun            int64
 }
 
 func NewScheduler(config Config, logger *slog.Logger) (*Scheduler, error) {
@@ -35,10 +68,16 @@ func NewScheduler(config Config, logger *slog.Logger) (*Scheduler, error) {
 		return nil, fmt.Errorf("failed to connect to orchestrator: %w", err)
 	}
 
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", config.RedisHost, config.RedisPort),
+		Password: config.RedisPassword,
+	})
+
 	s := &Scheduler{
 		config:             config,
 		orchestratorServer: orchestrator.NewOrchestratorServiceClient(grpcConnection),
 		scheduler:          gocron.NewScheduler(),
+		redisClient:        redisClient,
 		logger:             logger,
 	}
 
@@ -49,9 +88,26 @@ func NewScheduler(config Config, logger *slog.Logger) (*Scheduler, error) {
 	return s, nil
 }
 
-func (s *Scheduler) Start() {
+func (s *Scheduler) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.config.GrpcHost, s.config.GrpcPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.ForceServerCodec(grpcjson.Codec{}))
+	reflection.Register(grpcServer)
+	scheduler.RegisterSchedulerServiceServer(grpcServer, s)
+
+	go func() {
+		s.logger.Info(fmt.Sprintf("Starting gRPC server at %s", listener.Addr().String()))
+		if err := grpcServer.Serve(listener); err != nil {
+			s.logger.Error("gRPC server failed", "error", err)
+		}
+	}()
+
 	s.scheduler.Start()
 	figure.NewFigure("SCHEDULER", "", true).Print()
+	s.logger.Info("Starting scheduler", buildinfo.Get("scheduler").LogAttrs()...)
 	s.logger.Info(fmt.Sprintf("Scheduler started, dialing at --> %s:%d", s.config.OrchestratorGrpcHost, s.config.OrchestratorGrpcPort))
 
 	stop := make(chan os.Signal, 1)
@@ -60,9 +116,166 @@ func (s *Scheduler) Start() {
 
 	s.logger.Info("Shutting down scheduler")
 	s.scheduler.Clear()
+	grpcServer.GracefulStop()
+	if err := s.redisClient.Close(); err != nil {
+		s.logger.Warn("Failed to close redis client", "error", err)
+	}
+
+	return nil
+}
+
+// Pause stops scheduled and on-demand triggers until Resume is called, e.g. during database maintenance.
+func (s *Scheduler) Pause(reason string) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	if atomic.CompareAndSwapInt32(&s.isPaused, 0, 1) {
+		s.pauseReason = reason
+		s.pausedAt = time.Now().Unix()
+		s.logger.Info("Scheduler paused", "reason", reason)
+	}
+}
+
+func (s *Scheduler) Resume() {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	if atomic.CompareAndSwapInt32(&s.isPaused, 1, 0) {
+		s.logger.Info("Scheduler resumed", "paused_reason", s.pauseReason, "paused_for", time.Since(time.Unix(s.pausedAt, 0)).String())
+		s.pauseReason = ""
+		s.pausedAt = 0
+	}
+}
+
+func (s *Scheduler) pauseState() *scheduler.PauseStateResponse {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	return &scheduler.PauseStateResponse{
+		Paused:   atomic.LoadInt32(&s.isPaused) == 1,
+		Reason:   s.pauseReason,
+		PausedAt: s.pausedAt,
+	}
+}
+
+func (s *Scheduler) PauseScheduler(_ context.Context, pauseRequest *scheduler.PauseRequest) (*scheduler.PauseStateResponse, error) {
+	s.Pause(pauseRequest.Reason)
+	return s.pauseState(), nil
+}
+
+func (s *Scheduler) ResumeScheduler(_ context.Context, _ *scheduler.ResumeRequest) (*scheduler.PauseStateResponse, error) {
+	s.Resume()
+	return s.pauseState(), nil
+}
+
+// GetScheduleStatus reports whether scheduled triggers are active, when the last one succeeded,
+// the last trigger time of each message type, and the configured intervals alongside the build
+// metadata of this instance.
+func (s *Scheduler) GetScheduleStatus(ctx context.Context, statusRequest *scheduler.ScheduleStatusRequest) (*scheduler.ScheduleStatusResponse, error) {
+	pauseState := s.pauseState()
+
+	scheduleInfo := buildinfo.Get("scheduler").Map()
+	scheduleInfo["paused"] = strconv.FormatBool(pauseState.Paused)
+	scheduleInfo["paused_reason"] = pauseState.Reason
+	scheduleInfo["update_hotels_interval"] = strconv.FormatUint(s.config.IntervalsInMinutes.UpdateHotels, 10)
+	scheduleInfo["update_reviews_interval"] = strconv.FormatUint(s.config.IntervalsInMinutes.UpdateReviews, 10)
+	scheduleInfo["update_translations_interval"] = strconv.FormatUint(s.config.IntervalsInMinutes.UpdateTranslations, 10)
+	scheduleInfo["fetch_missing_translations_interval"] = strconv.FormatUint(s.config.IntervalsInMinutes.FetchMissingTranslations, 10)
+	scheduleInfo["fetch_missing_reviews_interval"] = strconv.FormatUint(s.config.IntervalsInMinutes.FetchMissingReviews, 10)
+	for _, messageType := range scheduledMessageTypes {
+		lastTriggered, err := s.TriggerHistory(ctx, messageType.String(), 1)
+		if err != nil {
+			s.logger.Warn("Failed to read trigger history", "type", messageType.String(), "error", err)
+			continue
+		}
+		if len(lastTriggered) > 0 {
+			scheduleInfo[strings.ToLower(messageType.String())+"_last_triggered_at"] = strconv.FormatInt(lastTriggered[0].Unix(), 10)
+		}
+	}
+
+	return &scheduler.ScheduleStatusResponse{
+		RequestId:    statusRequest.RequestId,
+		Active:       !pauseState.Paused,
+		LastRun:      atomic.LoadInt64(&s.lastRun),
+		ScheduleInfo: scheduleInfo,
+	}, nil
+}
+
+func (s *Scheduler) GetSchedulerHistory(ctx context.Context, historyRequest *scheduler.HistoryRequest) (*scheduler.HistoryResponse, error) {
+	if historyRequest.MessageType == scheduler.MessageType_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "message_type is required")
+	}
+
+	limit := int(historyRequest.Limit)
+	if limit <= 0 {
+		limit = defaultTriggerHistoryLimit
+	}
+
+	history, err := s.TriggerHistory(ctx, historyRequest.MessageType.String(), limit)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	triggeredAt := make([]int64, len(history))
+	for i, t := range history {
+		triggeredAt[i] = t.Unix()
+	}
+
+	return &scheduler.HistoryResponse{
+		MessageType: historyRequest.MessageType,
+		TriggeredAt: triggeredAt,
+	}, nil
+}
+
+// TriggerHistory returns up to limit of the latest trigger times of messageType, newest first.
+// The history lives in Redis, so it survives scheduler restarts.
+func (s *Scheduler) TriggerHistory(ctx context.Context, messageType string, limit int) ([]time.Time, error) {
+	if limit > triggerHistorySize {
+		limit = triggerHistorySize
+	}
+
+	values, err := s.redisClient.LRange(ctx, triggerHistoryKey(messageType), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trigger history: %w", err)
+	}
+
+	history := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		timestamp, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			s.logger.Warn("Invalid trigger history entry", "type", messageType, "value", value)
+			continue
+		}
+		history = append(history, time.Unix(timestamp, 0))
+	}
+
+	return history, nil
+}
+
+func (s *Scheduler) recordTrigger(messageType scheduler.MessageType, triggeredAt time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), triggerHistoryTimeout)
+	defer cancel()
+
+	key := triggerHistoryKey(messageType.String())
+	_, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, triggeredAt.Unix())
+		pipe.LTrim(ctx, key, 0, triggerHistorySize-1)
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("Failed to record trigger history", "type", messageType.String(), "error", err)
+	}
+}
+
+func triggerHistoryKey(messageType string) string {
+	return "scheduler:" + messageType + ":history"
 }
 
 func (s *Scheduler) TriggerFetch(ctx context.Context, triggerRequest *scheduler.TriggerRequest) (*scheduler.TriggerResponse, error) {
+	if atomic.LoadInt32(&s.isPaused) == 1 {
+		return nil, status.Error(codes.Unavailable, "scheduler is paused")
+	}
+
 	var messageType orchestrator.MessageType
 	switch triggerRequest.MessageType {
 	case scheduler.MessageType_UPDATE_HOTEL:
@@ -124,7 +337,12 @@ func (s *Scheduler) triggerFetch(ctx context.Context, scheduleType string, messa
 	}, nil
 }
 
-func (s *Scheduler) trigger(messageType scheduler.MessageType) {
+func (s *Scheduler) trigger(messageType scheduler.MessageType) bool {
+	if atomic.LoadInt32(&s.isPaused) == 1 {
+		s.logger.Debug("Scheduler is paused, skipping trigger", "type", messageType.String())
+		return false
+	}
+
 	ctx := context.Background()
 	triggerRequest := &scheduler.TriggerRequest{
 		RequestId:   uuid.New().String(),
@@ -136,12 +354,19 @@ func (s *Scheduler) trigger(messageType scheduler.MessageType) {
 	_, err := s.TriggerFetch(ctx, triggerRequest)
 	if err != nil {
 		s.logger.Error("Scheduled failed", "error", err)
+		return false
 	}
+	now := time.Now()
+	atomic.StoreInt64(&s.lastRun, now.Unix())
+	s.recordTrigger(messageType, now)
+	return true
 }
 
 func (s *Scheduler) setupSchedules() error {
 	err := s.scheduler.Every(s.config.IntervalsInMinutes.UpdateHotels).Minutes().Do(func() {
-		s.trigger(scheduler.MessageType_UPDATE_HOTEL)
+		if !s.trigger(scheduler.MessageType_UPDATE_HOTEL) {
+			return
+		}
 		s.logger.Info(
 			"Triggered update hotels",
 			"timestamp", time.Now().Unix(),
@@ -153,7 +378,9 @@ func (s *Scheduler) setupSchedules() error {
 	}
 
 	err = s.scheduler.Every(s.config.IntervalsInMinutes.UpdateReviews).Minutes().Do(func() {
-		s.trigger(scheduler.MessageType_UPDATE_REVIEW)
+		if !s.trigger(scheduler.MessageType_UPDATE_REVIEW) {
+			return
+		}
 		s.logger.Info(
 			"Triggered update reviews",
 			"timestamp", time.Now().Unix(),
@@ -165,7 +392,9 @@ func (s *Scheduler) setupSchedules() error {
 	}
 
 	err = s.scheduler.Every(s.config.IntervalsInMinutes.UpdateTranslations).Minutes().Do(func() {
-		s.trigger(scheduler.MessageType_UPDATE_TRANSLATION)
+		if !s.trigger(scheduler.MessageType_UPDATE_TRANSLATION) {
+			return
+		}
 		s.logger.Info(
 			"Triggered update translations",
 			"timestamp", time.Now().Unix(),
@@ -177,7 +406,9 @@ func (s *Scheduler) setupSchedules() error {
 	}
 
 	err = s.scheduler.Every(s.config.IntervalsInMinutes.FetchMissingTranslations).Minutes().Do(func() {
-		s.trigger(scheduler.MessageType_FETCH_MISSING_TRANSLATIONS)
+		if !s.trigger(scheduler.MessageType_FETCH_MISSING_TRANSLATIONS) {
+			return
+		}
 		s.logger.Info(
 			"Triggered missing translations",
 			"timestamp", time.Now().Unix(),
@@ -189,7 +420,9 @@ func (s *Scheduler) setupSchedules() error {
 	}
 
 	err = s.scheduler.Every(s.config.IntervalsInMinutes.FetchMissingReviews).Minutes().Do(func() {
-		s.trigger(scheduler.MessageType_FETCH_MISSING_REVIEWS)
+		if !s.trigger(scheduler.MessageType_FETCH_MISSING_REVIEWS) {
+			return
+		}
 		s.logger.Info(
 			"Triggered missing reviews",
 			"timestamp", time.Now().Unix(),
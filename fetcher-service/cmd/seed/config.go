@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/subosito/gotenv"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+)
+
+// Config holds the stores the seed command writes to. They are read from the worker section, so
+// seeded data lands where the worker would have written it.
+type Config struct {
+	PostgresHost     string `mapstructure:"postgres_host"`
+	PostgresPort     int    `mapstructure:"postgres_port"`
+	PostgresDB       string `mapstructure:"postgres_db"`
+	PostgresUser     string `mapstructure:"postgres_user"`
+	PostgresPassword string `mapstructure:"postgres_password"`
+
+	RedisHost     string `mapstructure:"redis_host"`
+	RedisPort     int    `mapstructure:"redis_port"`
+	RedisPassword string `mapstructure:"redis_password"`
+
+	FacilityAliases  map[string]string         `mapstructure:"-"`
+	SourcePriorities entities.SourcePriorities `mapstructure:"-"`
+}
+
+func loadConfig() Config {
+	var err error
+	if err = gotenv.Load("../.env"); err != nil {
+		_ = gotenv.Load()
+	}
+
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("..")
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err != nil {
+		panic(err)
+	}
+
+	var config Config
+	if err := viper.UnmarshalKey("worker", &config); err != nil {
+		panic(err)
+	}
+	if err := viper.UnmarshalKey("facility_aliases", &config.FacilityAliases); err != nil {
+		panic(err)
+	}
+	if err := viper.UnmarshalKey("source_priorities", &config.SourcePriorities); err != nil {
+		panic(err)
+	}
+
+	config.PostgresUser = os.ExpandEnv(config.PostgresUser)
+	config.PostgresHost = os.ExpandEnv(config.PostgresHost)
+	config.PostgresPassword = os.ExpandEnv(config.PostgresPassword)
+	config.PostgresPort, _ = strconv.Atoi(os.ExpandEnv(fmt.Sprintf("%d", config.PostgresPort)))
+
+	config.RedisHost = os.ExpandEnv(config.RedisHost)
+	config.RedisPassword = os.ExpandEnv(config.RedisPassword)
+	return config
+}
+
+// validate applies the defaults of unset keys and reports them, together with every invalid key.
+func (c *Config) validate() *configcheck.Report {
+	report := &configcheck.Report{}
+
+	report.Required("worker.postgres_host", c.PostgresHost)
+	report.Required("worker.postgres_db", c.PostgresDB)
+	report.Required("worker.postgres_user", c.PostgresUser)
+	configcheck.Default(report, "worker.postgres_port", &c.PostgresPort, 5432)
+	configcheck.Range(report, "worker.postgres_port", c.PostgresPort, 1, 65535)
+
+	report.Required("worker.redis_host", c.RedisHost)
+	configcheck.Default(report, "worker.redis_port", &c.RedisPort, 6379)
+	configcheck.Range(report, "worker.redis_port", c.RedisPort, 1, 65535)
+
+	if _, err := facilities.New(c.FacilityAliases); err != nil {
+		report.Errorf("facility_aliases", "%v", err)
+	}
+	if err := c.SourcePriorities.Validate(); err != nil {
+		report.Errorf("source_priorities", "%v", err)
+	}
+
+	return report
+}
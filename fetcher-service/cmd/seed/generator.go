@@ -0,0 +1,365 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+)
+
+const (
+	// seedHotelIDBase keeps seeded hotel ids clear of the ids the Cupid API hands out.
+	seedHotelIDBase = 900_000_000
+	// reviewIDsPerHotel spaces the review ids of consecutive hotels.
+	reviewIDsPerHotel = 100
+	// reviewHistory is how far back seeded review dates go.
+	reviewHistory = 2 * 365 * 24 * time.Hour
+)
+
+type city struct {
+	Name        string
+	State       string
+	Country     string
+	Latitude    float64
+	Longitude   float64
+	AirportCode string
+	PhonePrefix string
+	PostalCode  string
+	Streets     []string
+}
+
+var seedCities = []city{
+	{"Paris", "Île-de-France", "fr", 48.8566, 2.3522, "CDG", "+331", "750", []string{"Rue de Rivoli", "Boulevard Haussmann", "Rue Saint-Honoré", "Avenue de l'Opéra"}},
+	{"Lyon", "Auvergne-Rhône-Alpes", "fr", 45.7640, 4.8357, "LYS", "+334", "690", []string{"Rue de la République", "Quai Saint-Antoine", "Cours Lafayette"}},
+	{"Madrid", "Comunidad de Madrid", "es", 40.4168, -3.7038, "MAD", "+3491", "280", []string{"Gran Vía", "Calle de Alcalá", "Paseo del Prado", "Calle Mayor"}},
+	{"Barcelona", "Catalunya", "es", 41.3874, 2.1686, "BCN", "+3493", "080", []string{"La Rambla", "Passeig de Gràcia", "Carrer de Balmes"}},
+	{"London", "England", "gb", 51.5072, -0.1276, "LHR", "+4420", "WC2", []string{"Strand", "Oxford Street", "Piccadilly", "Kensington High Street"}},
+	{"Rome", "Lazio", "it", 41.9028, 12.4964, "FCO", "+3906", "001", []string{"Via del Corso", "Via Nazionale", "Via Veneto"}},
+	{"Berlin", "Berlin", "de", 52.5200, 13.4050, "BER", "+4930", "101", []string{"Friedrichstraße", "Unter den Linden", "Kurfürstendamm"}},
+	{"Amsterdam", "Noord-Holland", "nl", 52.3676, 4.9041, "AMS", "+3120", "101", []string{"Damrak", "Prinsengracht", "Leidsestraat"}},
+	{"Lisbon", "Lisboa", "pt", 38.7223, -9.1393, "LIS", "+35121", "110", []string{"Avenida da Liberdade", "Rua Augusta", "Rua do Carmo"}},
+	{"New York", "New York", "us", 40.7128, -74.0060, "JFK", "+1212", "100", []string{"Broadway", "Fifth Avenue", "Lexington Avenue", "West 44th Street"}},
+	{"Miami", "Florida", "us", 25.7617, -80.1918, "MIA", "+1305", "331", []string{"Collins Avenue", "Ocean Drive", "Brickell Avenue"}},
+	{"Tokyo", "Tokyo", "jp", 35.6762, 139.6503, "HND", "+813", "100", []string{"Chuo-dori", "Omotesando", "Meiji-dori"}},
+}
+
+var (
+	namePrefixes = []string{"Grand", "Royal", "The", "Park", "Boutique", "Palace", "Central"}
+	nameCores    = []string{"Riverside", "Plaza", "Garden", "Harbour", "Majestic", "Crown", "Meridian", "Opera", "Station", "Atlas", "Lumière", "Regent"}
+	nameSuffixes = []string{"Hotel", "Suites", "Inn", "Residence", "Hotel & Spa", "Lodge"}
+
+	chains     = []string{"Marriott", "Hilton", "Accor", "NH Hotels", "Meliá", "Radisson"}
+	hotelTypes = []string{"Hotel", "Boutique hotel", "Aparthotel", "Guesthouse", "Resort"}
+	parkings   = []string{"Free parking", "Paid parking", "No parking", "Street parking"}
+
+	// facilityNames are spelled the way the Cupid API spells them, so they go through the same
+	// normalization as fetched hotels.
+	facilityNames = []string{
+		"Free WiFi", "WiFi", "Free parking", "Private parking", "Outdoor swimming pool", "Indoor pool",
+		"Fitness centre", "Spa and wellness centre", "Restaurant", "Bar", "Room service",
+		"Airport shuttle", "Breakfast available", "Air conditioning", "24-hour front desk",
+		"Pets allowed", "Non-smoking rooms", "Laundry service", "Business centre", "Lift",
+		"Facilities for disabled guests", "Family rooms", "Baggage storage", "EV charging station",
+		"Rooftop terrace", "Garden",
+	}
+
+	roomNames  = []string{"Standard Double Room", "Superior Twin Room", "Deluxe King Room", "Junior Suite", "Family Room", "Single Room", "Executive Suite"}
+	bedTypes   = []string{"Double bed", "Twin bed", "King bed", "Queen bed", "Single bed", "Sofa bed"}
+	roomExtras = []string{"Flat-screen TV", "Minibar", "Safe", "Hairdryer", "Coffee machine", "Desk", "Bathrobe", "Balcony"}
+
+	reviewerNames     = []string{"Anna", "Marc", "Lucía", "Tom", "Sofia", "Pierre", "Yuki", "Daniel", "Emma", "Javier", "Chloé", "Liam"}
+	reviewerCountries = []string{"fr", "es", "gb", "de", "it", "us", "nl", "jp", "pt"}
+	reviewerTypes     = []string{"couple", "family", "solo", "business", "group"}
+	reviewLanguages   = []string{"en", "en", "en", "es", "fr", "de"}
+	reviewHeadlines   = []string{"Great location", "Lovely stay", "Good value for money", "Would come back", "Not as expected", "Friendly staff", "Perfect for a weekend"}
+	reviewPros        = []string{"Very clean rooms and helpful staff.", "Close to the main sights.", "Excellent breakfast.", "Quiet at night.", "Comfortable beds."}
+	reviewCons        = []string{"Small bathroom.", "WiFi was slow.", "Breakfast was expensive.", "Noisy street.", "Nothing to complain about."}
+
+	policyTypes = []string{"Cancellation", "Children", "Pets", "Payment", "Internet"}
+)
+
+// translatedText holds the descriptions written in every seeded translation language.
+var translatedText = map[string]struct {
+	Description   string
+	ImportantInfo string
+	Parking       string
+}{
+	"en": {"%s is a %d-star %s in the heart of %s, a short walk from %s.", "Guests must present a valid ID at check-in.", "Parking available on request"},
+	"es": {"%s es un %[3]s de %[2]d estrellas en el corazón de %[4]s, a poca distancia de %[5]s.", "Los huéspedes deben presentar un documento de identidad al registrarse.", "Aparcamiento bajo petición"},
+	"fr": {"%s est un %[3]s %[2]d étoiles au cœur de %[4]s, à quelques pas de %[5]s.", "Une pièce d'identité est exigée à l'arrivée.", "Parking disponible sur demande"},
+}
+
+// generator produces realistic fake Cupid API responses. The same seed and reference time
+// always produce the same hotels.
+type generator struct {
+	rng      *rand.Rand
+	now      time.Time
+	taxonomy *facilities.Taxonomy
+}
+
+func newGenerator(seed uint64, now time.Time, taxonomy *facilities.Taxonomy) *generator {
+	return &generator{
+		rng:      rand.New(rand.NewPCG(seed, seed)),
+		now:      now.UTC().Truncate(24 * time.Hour),
+		taxonomy: taxonomy,
+	}
+}
+
+// seededHotel is one generated hotel with its reviews and a translation per language.
+type seededHotel struct {
+	Hotel        *dto.HotelAPIResponse
+	Reviews      dto.ReviewDataList
+	Translations map[string]*dto.TranslationAPIResponse
+}
+
+// hotel generates the index-th hotel.
+func (g *generator) hotel(index int, languages []string) *seededHotel {
+	c := pick(g.rng, seedCities)
+	hotelID := int64(seedHotelIDBase + index)
+	name := fmt.Sprintf("%s %s %s", pick(g.rng, namePrefixes), pick(g.rng, nameCores), pick(g.rng, nameSuffixes))
+	stars := int32(2 + g.rng.IntN(4))
+	street := pick(g.rng, c.Streets)
+	hotelTypeIndex := g.rng.IntN(len(hotelTypes))
+	hotelType := hotelTypes[hotelTypeIndex]
+
+	hotel := &dto.HotelAPIResponse{
+		HotelID:     hotelID,
+		CupidID:     int(hotelID),
+		HotelType:   hotelType,
+		HotelTypeID: 200 + hotelTypeIndex,
+		Latitude:    round(c.Latitude+g.jitter(0.04), 6),
+		Longitude:   round(c.Longitude+g.jitter(0.06), 6),
+		HotelName:   name,
+		Phone:       fmt.Sprintf("%s%07d", c.PhonePrefix, g.rng.IntN(10_000_000)),
+		Email:       fmt.Sprintf("reservations@%s.example.com", strings.ReplaceAll(facilities.Slugify(name), "_", "-")),
+		Address: dto.Address{
+			Address:    fmt.Sprintf("%d %s", 1+g.rng.IntN(200), street),
+			City:       c.Name,
+			State:      c.State,
+			Country:    c.Country,
+			PostalCode: fmt.Sprintf("%s%02d", c.PostalCode, g.rng.IntN(100)),
+		},
+		Stars:       stars,
+		AirportCode: c.AirportCode,
+		Parking:     pick(g.rng, parkings),
+		Checkin: dto.CheckinInfo{
+			CheckinStart: fmt.Sprintf("%02d:00", 13+g.rng.IntN(4)),
+			CheckinEnd:   pick(g.rng, []string{"22:00", "23:00", "23:59", "00:00"}),
+			Checkout:     fmt.Sprintf("%02d:00", 10+g.rng.IntN(3)),
+			Instructions: []string{"Please inform the property of your expected arrival time."},
+		},
+		GroupRoomMin: 5 + g.rng.IntN(6),
+		ChildAllowed: g.rng.IntN(10) > 1,
+		PetsAllowed:  g.rng.IntN(3) == 0,
+	}
+	if g.rng.IntN(3) > 0 {
+		hotel.ChainID = 1 + g.rng.IntN(len(chains))
+		hotel.Chain = chains[hotel.ChainID-1]
+	}
+	if g.rng.IntN(2) == 0 {
+		hotel.Fax = fmt.Sprintf("%s%07d", c.PhonePrefix, g.rng.IntN(10_000_000))
+	}
+
+	english := translatedText["en"]
+	hotel.Description = fmt.Sprintf(english.Description, name, stars, strings.ToLower(hotelType), c.Name, street)
+	hotel.MarkdownDescription = fmt.Sprintf("## %s\n\n%s", name, hotel.Description)
+	hotel.ImportantInfo = english.ImportantInfo
+
+	hotel.Photos = g.photos(hotelID, 0, 3+g.rng.IntN(6))
+	hotel.MainImageTh = hotel.Photos[0].URL
+	hotel.Facilities = g.facilities()
+	hotel.NormalizeFacilities(g.taxonomy)
+	hotel.Policies = g.policies(hotel.PetsAllowed, hotel.ChildAllowed)
+	hotel.Rooms = g.rooms(hotelID)
+
+	reviews := g.reviews(hotelID, 1+g.rng.IntN(15))
+	hotel.ReviewCount = len(reviews)
+	hotel.Rating = averageRating(reviews)
+
+	translations := make(map[string]*dto.TranslationAPIResponse, len(languages))
+	for _, language := range languages {
+		translations[language] = g.translation(hotel, language, street)
+	}
+
+	return &seededHotel{Hotel: hotel, Reviews: reviews, Translations: translations}
+}
+
+func (g *generator) photos(hotelID int64, room, count int) []dto.Photo {
+	photos := make([]dto.Photo, 0, count)
+	for i := range count {
+		key := fmt.Sprintf("hms-%d-%d-%d", hotelID, room, i)
+		photos = append(photos, dto.Photo{
+			URL:              fmt.Sprintf("https://picsum.photos/seed/%s/640/480", key),
+			HDURL:            fmt.Sprintf("https://picsum.photos/seed/%s/1920/1440", key),
+			ImageDescription: pick(g.rng, []string{"Facade", "Lobby", "Room", "Bathroom", "Pool", "View"}),
+			MainPhoto:        i == 0,
+			Score:            round(3+2*g.rng.Float64(), 2),
+			ClassID:          1 + g.rng.IntN(10),
+			ClassOrder:       i,
+		})
+	}
+	return photos
+}
+
+func (g *generator) facilities() []dto.Facility {
+	count := 5 + g.rng.IntN(10)
+	list := make([]dto.Facility, 0, count)
+	for _, i := range g.rng.Perm(len(facilityNames))[:count] {
+		list = append(list, dto.Facility{FacilityID: 100 + i, Name: facilityNames[i]})
+	}
+	return list
+}
+
+func (g *generator) policies(petsAllowed, childAllowed bool) []dto.Policy {
+	policies := make([]dto.Policy, 0, len(policyTypes))
+	for i, policyType := range policyTypes {
+		policies = append(policies, dto.Policy{
+			PolicyType:   policyType,
+			Name:         policyType + " policy",
+			Description:  fmt.Sprintf("%s conditions vary by room type and rate.", policyType),
+			ChildAllowed: yesNo(childAllowed),
+			PetsAllowed:  yesNo(petsAllowed),
+			Parking:      "",
+			ID:           i + 1,
+		})
+	}
+	return policies
+}
+
+func (g *generator) rooms(hotelID int64) []dto.Room {
+	count := 2 + g.rng.IntN(4)
+	rooms := make([]dto.Room, 0, count)
+	for i, n := range g.rng.Perm(len(roomNames))[:count] {
+		maxAdults := 1 + g.rng.IntN(4)
+		maxChildren := g.rng.IntN(3)
+		amenities := make([]dto.Amenity, 0, 4)
+		for sort, j := range g.rng.Perm(len(roomExtras))[:4] {
+			amenities = append(amenities, dto.Amenity{AmenitiesID: 300 + j, Name: roomExtras[j], Sort: sort})
+		}
+		rooms = append(rooms, dto.Room{
+			ID:             int(hotelID%1_000_000)*10 + i,
+			RoomName:       roomNames[n],
+			Description:    fmt.Sprintf("%s with %s.", roomNames[n], strings.ToLower(strings.Join([]string{amenities[0].Name, amenities[1].Name}, " and "))),
+			RoomSizeSquare: float32(14 + g.rng.IntN(40)),
+			RoomSizeUnit:   "m2",
+			HotelID:        fmt.Sprintf("%d", hotelID),
+			MaxAdults:      maxAdults,
+			MaxChildren:    maxChildren,
+			MaxOccupancy:   maxAdults + maxChildren,
+			BedRelation:    "and",
+			BedTypes:       []dto.BedType{{Quantity: 1 + g.rng.IntN(2), BedType: pick(g.rng, bedTypes), BedSize: fmt.Sprintf("%dx200 cm", 90+20*g.rng.IntN(5)), ID: 1 + g.rng.IntN(20)}},
+			RoomAmenities:  amenities,
+			Photos:         g.photos(hotelID, i+1, 1+g.rng.IntN(3)),
+			Views:          []any{},
+		})
+	}
+	return rooms
+}
+
+func (g *generator) reviews(hotelID int64, count int) dto.ReviewDataList {
+	reviews := make(dto.ReviewDataList, 0, count)
+	for i := range count {
+		date := g.now.Add(-time.Duration(g.rng.Int64N(int64(reviewHistory))))
+		reviews = append(reviews, &dto.ReviewAPIResponse{
+			ReviewID:        hotelID*reviewIDsPerHotel + int64(i),
+			AverageScore:    int32(4 + g.rng.IntN(7)),
+			Country:         pick(g.rng, reviewerCountries),
+			Type:            pick(g.rng, reviewerTypes),
+			Name:            pick(g.rng, reviewerNames),
+			Date:            date.Format("2006-01-02 15:04:05"),
+			Headline:        pick(g.rng, reviewHeadlines),
+			Language:        pick(g.rng, reviewLanguages),
+			Pros:            pick(g.rng, reviewPros),
+			Cons:            pick(g.rng, reviewCons),
+			Source:          seedSource,
+			ScoreLocation:   g.categoryScore(),
+			ScoreService:    g.categoryScore(),
+			ScoreValue:      g.categoryScore(),
+			ScoreFacilities: g.categoryScore(),
+		})
+	}
+	return reviews
+}
+
+// categoryScore returns 0, meaning not rated, for one review category in five.
+func (g *generator) categoryScore() int32 {
+	if g.rng.IntN(5) == 0 {
+		return 0
+	}
+	return int32(4 + g.rng.IntN(7))
+}
+
+// translation returns the hotel as the Cupid API would return it in language.
+func (g *generator) translation(hotel *dto.HotelAPIResponse, language, street string) *dto.TranslationAPIResponse {
+	text := translatedText[language]
+	description := fmt.Sprintf(text.Description, hotel.HotelName, hotel.Stars, strings.ToLower(hotel.HotelType), hotel.Address.City, street)
+	return &dto.TranslationAPIResponse{
+		HotelID:             hotel.HotelID,
+		CupidID:             hotel.CupidID,
+		MainImageTh:         hotel.MainImageTh,
+		HotelType:           hotel.HotelType,
+		HotelTypeID:         hotel.HotelTypeID,
+		Chain:               hotel.Chain,
+		ChainID:             hotel.ChainID,
+		Latitude:            hotel.Latitude,
+		Longitude:           hotel.Longitude,
+		HotelName:           hotel.HotelName,
+		Phone:               hotel.Phone,
+		Fax:                 hotel.Fax,
+		Email:               hotel.Email,
+		Address:             hotel.Address,
+		Stars:               int8(hotel.Stars),
+		AirportCode:         hotel.AirportCode,
+		Rating:              hotel.Rating,
+		ReviewCount:         hotel.ReviewCount,
+		Checkin:             hotel.Checkin,
+		Parking:             text.Parking,
+		GroupRoomMin:        hotel.GroupRoomMin,
+		ChildAllowed:        hotel.ChildAllowed,
+		PetsAllowed:         hotel.PetsAllowed,
+		Photos:              hotel.Photos,
+		Description:         description,
+		MarkdownDescription: fmt.Sprintf("## %s\n\n%s", hotel.HotelName, description),
+		ImportantInfo:       text.ImportantInfo,
+		Facilities:          hotel.Facilities,
+		Policies:            hotel.Policies,
+		Rooms:               hotel.Rooms,
+	}
+}
+
+// jitter returns a uniform offset in [-spread, spread].
+func (g *generator) jitter(spread float64) float64 {
+	return (2*g.rng.Float64() - 1) * spread
+}
+
+// averageRating converts the review scores, out of 10, to a rating out of 5.
+func averageRating(reviews dto.ReviewDataList) float64 {
+	if len(reviews) == 0 {
+		return 0
+	}
+	total := 0
+	for _, review := range reviews {
+		total += int(review.AverageScore)
+	}
+	return round(float64(total)/float64(len(reviews))/2, 1)
+}
+
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.IntN(len(values))]
+}
+
+func round(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,109 @@
+// Command seed fills a local environment with realistic fake hotels, so the system can be run
+// without access to the Cupid API.
+//
+//	go run ./cmd/seed -count 200 -seed 42 -sync
+//	go run ./cmd/seed -wipe -sync
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/logger"
+)
+
+func main() {
+	count := flag.Int("count", 50, "number of hotels to generate")
+	seed := flag.Uint64("seed", 1, "random seed; the same seed generates the same hotels")
+	wipe := flag.Bool("wipe", false, "empty the hotel tables and the hotel keys in Redis first; only seeds when -count is also given")
+	force := flag.Bool("force", false, "overwrite hotel fields written by higher-priority sources such as the workers")
+	sync := flag.Bool("sync", false, "run a full search-service sync afterwards to populate Typesense")
+	searchURL := flag.String("search-url", "http://localhost:8080", "search-service base URL used by -sync")
+	apiKey := flag.String("api-key", os.Getenv("SEARCH_API_KEY"), "API key sent to the search service by -sync")
+	flag.Parse()
+
+	countSet := false
+	flag.Visit(func(f *flag.Flag) {
+		countSet = countSet || f.Name == "count"
+	})
+
+	config := loadConfig()
+	applicationLogger := logger.SetupLogger("info")
+	config.validate().Enforce(applicationLogger)
+
+	if *count < 0 {
+		applicationLogger.Error("count must not be negative", "count", *count)
+		os.Exit(1)
+	}
+
+	connectionString := fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=disable", config.PostgresHost, config.PostgresPort, config.PostgresDB, config.PostgresUser, config.PostgresPassword)
+	db, err := database.GormOpen(connectionString)
+	if err != nil {
+		applicationLogger.Error("db connect failed", "error", err.Error())
+		os.Exit(1)
+	}
+
+	if err := database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}, &entities.HotelChange{}); err != nil {
+		applicationLogger.Error("db migrations failed", "error", err.Error())
+		os.Exit(1)
+	}
+
+	repository, err := adapter.NewGormRepository(db)
+	if err != nil {
+		applicationLogger.Error("Failed to create repository", "error", err.Error())
+		os.Exit(1)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", config.RedisHost, config.RedisPort),
+		Password: config.RedisPassword,
+	})
+	defer func() {
+		_ = redisClient.Close()
+	}()
+
+	taxonomy, _ := facilities.New(config.FacilityAliases)
+	s := &seeder{
+		db:     db,
+		repo:   repository,
+		redis:  redisClient,
+		logger: applicationLogger,
+		write: entities.SourceWrite{
+			Source:     entities.DataSourceImport,
+			Priorities: config.SourcePriorities,
+			Force:      *force,
+		},
+	}
+	ctx := context.Background()
+
+	if *wipe {
+		if err := s.wipe(ctx); err != nil {
+			applicationLogger.Error("Wipe failed", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if !*wipe || countSet {
+		applicationLogger.Info("Seeding hotels", "count", *count, "seed", *seed)
+		if err := s.seed(ctx, newGenerator(*seed, time.Now(), taxonomy), *count, constants.Languages); err != nil {
+			applicationLogger.Error("Seeding failed", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if *sync {
+		if err := s.triggerSync(ctx, *searchURL, *apiKey, *wipe); err != nil {
+			applicationLogger.Error("Sync failed", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/database/migration"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/gorm"
+)
+
+const (
+	// seedSource marks seeded hotels, reviews and translations.
+	seedSource = "seed"
+
+	// seededUpdateDelay keeps the scheduler from asking the Cupid API for seeded hotels, which it
+	// does not know.
+	seededUpdateDelay = 10 * 365 * 24 * time.Hour
+
+	syncTimeout = 10 * time.Minute
+
+	wipeScanCount = 500
+)
+
+// wipedKeyPatterns match the Redis keys holding hotel data or derived from it: the worker's
+// fetch cache and scheduler history, and the search service's caches, job records, suggestion
+// vocabulary, trending queries, favorites, usage counters and sync progress. -wipe deletes only
+// these, so leases, locks, the fleet pause flag, the shared circuit breaker, search tuning and
+// the keys of other applications sharing the Redis database survive it.
+var wipedKeyPatterns = []string{
+	"hotel_data_*", "reviews_data_*", "translations_data_*", "scheduler:*",
+	"hotel:*", "search:*", "facilities:*", "suggestions_vocabulary", "trending_queries:*",
+	"favorites:*", "usage:*", "sync:progress:*", "sync:stats:*",
+	"index_backfill:*", "index_reconcile:*", "review_archive:*", "review_dedup:*", "facility_backfill:*",
+}
+
+// wipedTables returns the tables -wipe empties: every table the migrations create, so tables
+// added by later migrations are wiped too. The migrations table is kept.
+func wipedTables() ([]string, error) {
+	tables, err := migration.NewDefaultMigrationRunner().Tables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration tables: %w", err)
+	}
+	return tables, nil
+}
+
+type seeder struct {
+	db     *gorm.DB
+	repo   ports.RepositoryPort
+	redis  *redis.Client
+	logger *slog.Logger
+
+	// write attributes seeded hotels to the import source, so they never overwrite fields a
+	// worker or the search-service fallback fetched unless forced.
+	write entities.SourceWrite
+}
+
+// seed generates count hotels and stores them the way the worker stores fetched hotels.
+func (s *seeder) seed(ctx context.Context, generator *generator, count int, languages []string) error {
+	reviews := 0
+	for i := range count {
+		hotel := generator.hotel(i, languages)
+		if err := s.store(ctx, hotel, languages); err != nil {
+			return fmt.Errorf("failed to store hotel %d: %w", hotel.Hotel.HotelID, err)
+		}
+		reviews += len(hotel.Reviews)
+	}
+
+	if err := s.deferUpdates(ctx); err != nil {
+		return err
+	}
+
+	s.logger.Info("Seeded hotels", "hotels", count, "reviews", reviews, "translations", count*len(languages))
+	return nil
+}
+
+func (s *seeder) store(ctx context.Context, hotel *seededHotel, languages []string) error {
+	hotelData, err := hotel.Hotel.ToHotelData()
+	if err != nil {
+		return fmt.Errorf("failed to convert hotel data: %w", err)
+	}
+	hotelData.Source = seedSource
+	if _, err := s.repo.UpsertHotel(ctx, hotelData, s.write); err != nil {
+		return fmt.Errorf("failed to persist hotel data: %w", err)
+	}
+
+	reviews, err := hotel.Reviews.ToReviewDataList(hotelData.HotelID)
+	if err != nil {
+		return fmt.Errorf("failed to convert reviews: %w", err)
+	}
+	for _, review := range reviews {
+		if existing, err := s.repo.GetReviewByReviewID(ctx, review.ReviewID); err == nil && existing != nil && existing.ID != "" {
+			review.ID = existing.ID
+			if err := s.repo.UpdateReview(ctx, review); err != nil {
+				return fmt.Errorf("failed to update review %d: %w", review.ReviewID, err)
+			}
+		} else if err := s.repo.CreateReview(ctx, review); err != nil {
+			return fmt.Errorf("failed to create review %d: %w", review.ReviewID, err)
+		}
+	}
+
+	for _, language := range languages {
+		translation, err := hotel.Translations[language].ToHotelTranslations(language)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s translation: %w", language, err)
+		}
+		translation.Source = seedSource
+		if err := s.repo.UpsertHotelTranslations(ctx, translation); err != nil {
+			return fmt.Errorf("failed to persist %s translation: %w", language, err)
+		}
+	}
+
+	return nil
+}
+
+// deferUpdates moves the next update of seeded rows out of the scheduler's reach. The create
+// hooks set it to now.
+func (s *seeder) deferUpdates(ctx context.Context) error {
+	nextUpdateAt := time.Now().Add(seededUpdateDelay)
+	for _, model := range []any{&entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}} {
+		err := s.db.WithContext(ctx).Model(model).
+			Where("source = ?", seedSource).
+			UpdateColumn("next_update_at", nextUpdateAt).Error
+		if err != nil {
+			return fmt.Errorf("failed to defer updates of seeded rows: %w", err)
+		}
+	}
+	return nil
+}
+
+// wipe empties the hotel tables and the service's Redis keys, then checks that nothing is left.
+func (s *seeder) wipe(ctx context.Context) error {
+	tables, err := wipedTables()
+	if err != nil {
+		return err
+	}
+	if err := s.db.WithContext(ctx).Exec("TRUNCATE TABLE " + strings.Join(tables, ", ")).Error; err != nil {
+		return fmt.Errorf("failed to truncate tables: %w", err)
+	}
+	for _, table := range tables {
+		var rows int64
+		if err := s.db.WithContext(ctx).Table(table).Count(&rows).Error; err != nil {
+			return fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		if rows > 0 {
+			return fmt.Errorf("%s still has %d rows after wipe", table, rows)
+		}
+	}
+
+	keys, err := s.wipeRedis(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Wiped hotel data", "tables", tables, "redis_keys", keys)
+	return nil
+}
+
+// wipeRedis deletes the keys matching wipedKeyPatterns and returns how many it deleted.
+func (s *seeder) wipeRedis(ctx context.Context) (int, error) {
+	deleted := 0
+	for _, pattern := range wipedKeyPatterns {
+		keys, err := s.scanKeys(ctx, pattern)
+		if err != nil {
+			return deleted, err
+		}
+		for start := 0; start < len(keys); start += wipeScanCount {
+			batch := keys[start:min(start+wipeScanCount, len(keys))]
+			if err := s.redis.Del(ctx, batch...).Err(); err != nil {
+				return deleted, fmt.Errorf("failed to delete redis keys matching %s: %w", pattern, err)
+			}
+			deleted += len(batch)
+		}
+
+		left, err := s.scanKeys(ctx, pattern)
+		if err != nil {
+			return deleted, err
+		}
+		if len(left) > 0 {
+			return deleted, fmt.Errorf("redis still has %d keys matching %s after wipe", len(left), pattern)
+		}
+	}
+	return deleted, nil
+}
+
+func (s *seeder) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iterator := s.redis.Scan(ctx, 0, pattern, wipeScanCount).Iterator()
+	for iterator.Next(ctx) {
+		keys = append(keys, iterator.Val())
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan redis keys matching %s: %w", pattern, err)
+	}
+	return keys, nil
+}
+
+type syncResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Data    struct {
+		TotalHotels   int `json:"total_hotels"`
+		IndexedHotels int `json:"indexed_hotels"`
+		FailedHotels  int `json:"failed_hotels"`
+	} `json:"data"`
+}
+
+// triggerSync runs a full sync of the search service, which indexes the hotels in Typesense and
+// rebuilds the suggestion vocabulary in Redis. With clearIndex the index is emptied first.
+func (s *seeder) triggerSync(ctx context.Context, searchURL, apiKey string, clearIndex bool) error {
+	body, err := json.Marshal(map[string]any{
+		"fullSync":         true,
+		"clearIndexFirst":  clearIndex,
+		"updateCacheAfter": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(searchURL, "/")+"/api/v1/admin/sync", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		request.Header.Set("X-API-Key", apiKey)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to trigger sync: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	var result syncResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode sync response (HTTP %d): %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK || !result.Success {
+		return fmt.Errorf("sync failed (HTTP %d): %s", response.StatusCode, result.Error)
+	}
+
+	s.logger.Info("Search index synced",
+		"total_hotels", result.Data.TotalHotels,
+		"indexed_hotels", result.Data.IndexedHotels,
+		"failed_hotels", result.Data.FailedHotels)
+	return nil
+}
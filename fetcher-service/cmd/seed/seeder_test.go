@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+)
+
+// testPostgresDSNEnv names the database the wipe test runs against. It is skipped without one.
+const testPostgresDSNEnv = "HMS_TEST_POSTGRES_DSN"
+
+// recordingRepository keeps what the seeder stores.
+type recordingRepository struct {
+	ports.RepositoryPort
+	hotels       []*entities.HotelData
+	reviews      []*entities.ReviewData
+	translations []*entities.HotelTranslation
+}
+
+func (r *recordingRepository) UpsertHotel(_ context.Context, hotel *entities.HotelData, _ entities.SourceWrite) (*entities.HotelData, error) {
+	r.hotels = append(r.hotels, hotel)
+	return hotel, nil
+}
+
+func (r *recordingRepository) GetReviewByReviewID(context.Context, int64) (*entities.ReviewData, error) {
+	return nil, nil
+}
+
+func (r *recordingRepository) CreateReview(_ context.Context, review *entities.ReviewData) error {
+	r.reviews = append(r.reviews, review)
+	return nil
+}
+
+func (r *recordingRepository) UpsertHotelTranslations(_ context.Context, translation *entities.HotelTranslation) error {
+	r.translations = append(r.translations, translation)
+	return nil
+}
+
+func newTestGenerator(t *testing.T) *generator {
+	t.Helper()
+	taxonomy, err := facilities.New(nil)
+	require.NoError(t, err)
+	return newGenerator(42, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), taxonomy)
+}
+
+func TestStorePersistsGeneratedHotel(t *testing.T) {
+	repository := &recordingRepository{}
+	s := &seeder{repo: repository, logger: slog.New(slog.DiscardHandler)}
+	languages := []string{"es", "fr"}
+	hotel := newTestGenerator(t).hotel(0, languages)
+
+	require.NoError(t, s.store(context.Background(), hotel, languages))
+
+	require.Len(t, repository.hotels, 1)
+	stored := repository.hotels[0]
+	assert.Equal(t, hotel.Hotel.HotelID, stored.HotelID)
+	assert.Equal(t, hotel.Hotel.HotelName, stored.Name)
+	assert.Equal(t, hotel.Hotel.Rating, stored.Rating)
+	assert.Equal(t, seedSource, stored.Source)
+	assert.NotEmpty(t, stored.Photos)
+
+	require.Len(t, repository.reviews, len(hotel.Reviews))
+	for i, review := range repository.reviews {
+		assert.Equal(t, hotel.Hotel.HotelID, review.HotelID)
+		assert.Equal(t, int64(hotel.Reviews[i].ReviewID), review.ReviewID)
+	}
+
+	require.Len(t, repository.translations, len(languages))
+	for i, translation := range repository.translations {
+		assert.Equal(t, hotel.Hotel.HotelID, translation.HotelID)
+		assert.Equal(t, languages[i], translation.Lang)
+		assert.Equal(t, hotel.Translations[languages[i]].HotelName, translation.Name)
+		assert.Equal(t, seedSource, translation.Source)
+	}
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	first := newTestGenerator(t).hotel(3, []string{"es"})
+	second := newTestGenerator(t).hotel(3, []string{"es"})
+	assert.Equal(t, first, second)
+}
+
+func TestWipedTablesCoverEveryMigrationTable(t *testing.T) {
+	tables, err := wipedTables()
+	require.NoError(t, err)
+	assert.Subset(t, tables, []string{"hotels", "reviews", "translations", "sync_runs", "review_fetch_states", "reviews_archive", "hotel_changes", "api_usage_daily"})
+	assert.NotContains(t, tables, "schema_migrations")
+}
+
+func TestWipeRedisDeletesOnlyHotelKeys(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	s := &seeder{redis: client, logger: slog.New(slog.DiscardHandler)}
+
+	wiped := []string{"hotel:1", "search:abc", "hotel_data_m1", "reviews_data_m2", "suggestions_vocabulary", "facilities:counts", "sync:progress:run", "review_dedup:job:1", "favorites:user"}
+	kept := []string{"sync:leader", "worker:paused", "worker:cupid_circuit_open", "hotel_lock_1", "hotel_lease:1", "search_config:tuning", "other-app:session"}
+	for _, key := range append(append([]string{}, wiped...), kept...) {
+		require.NoError(t, server.Set(key, "value"))
+	}
+	for i := range 3 * wipeScanCount {
+		require.NoError(t, server.Set(fmt.Sprintf("search:%d", i), "value"))
+	}
+
+	deleted, err := s.wipeRedis(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, len(wiped)+3*wipeScanCount, deleted)
+	assert.ElementsMatch(t, kept, server.Keys())
+}
+
+func TestWipeEmptiesTheSeededStores(t *testing.T) {
+	dsn := os.Getenv(testPostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresDSNEnv)
+	}
+	db, err := database.GormOpen(dsn)
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("seed_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE SCHEMA "+schema).Error)
+	require.NoError(t, db.Exec("SET search_path TO "+schema).Error)
+	t.Cleanup(func() {
+		_ = db.Exec("DROP SCHEMA " + schema + " CASCADE").Error
+		_ = sqlDB.Close()
+	})
+	require.NoError(t, database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}, &entities.HotelChange{}))
+
+	repository, err := adapter.NewGormRepository(db)
+	require.NoError(t, err)
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	s := &seeder{db: db, repo: repository, redis: client, logger: slog.New(slog.DiscardHandler)}
+	ctx := context.Background()
+
+	languages := []string{"es"}
+	generator := newTestGenerator(t)
+	require.NoError(t, s.seed(ctx, generator, 3, languages))
+
+	expected := newTestGenerator(t).hotel(0, languages)
+	var stored entities.HotelData
+	require.NoError(t, db.Where("hotel_id = ?", expected.Hotel.HotelID).First(&stored).Error)
+	assert.Equal(t, expected.Hotel.HotelName, stored.Name)
+	assert.Equal(t, seedSource, stored.Source)
+	assert.True(t, stored.NextUpdateAt.After(time.Now().Add(seededUpdateDelay/2)))
+	var reviews int64
+	require.NoError(t, db.Model(&entities.ReviewData{}).Where("hotel_id = ?", expected.Hotel.HotelID).Count(&reviews).Error)
+	assert.Equal(t, int64(len(expected.Reviews)), reviews)
+
+	require.NoError(t, server.Set("hotel:1", "cached"))
+	require.NoError(t, server.Set("sync:leader", "replica-1"))
+
+	require.NoError(t, s.wipe(ctx))
+
+	tables, err := wipedTables()
+	require.NoError(t, err)
+	for _, table := range tables {
+		var rows int64
+		require.NoError(t, db.Table(table).Count(&rows).Error)
+		assert.Zero(t, rows, table)
+	}
+	assert.Equal(t, []string{"sync:leader"}, server.Keys())
+}
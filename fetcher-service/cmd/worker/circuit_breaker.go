@@ -0,0 +1,31 @@
+package main
+
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultSharedBreakerKey = "worker:cupid_circuit_open"
+
+	// circuitOpenRequeueDelay is how long a message that met an open Cupid API circuit is held
+	// before it is requeued, so the worker does not spin through the queue while the API is down.
+	circuitOpenRequeueDelay = 5 * time.Second
+)
+
+// requeueOnOpenCircuit returns a message to the queue instead of dead-lettering it: the
+// request was never sent, and the message succeeds once the circuit closes. The delay ends
+// early when the worker shuts down.
+func (messageProcessor *MessageProcessor) requeueOnOpenCircuit(msg amqp.Delivery, processErr error) {
+	messageProcessor.logger.Warn("Cupid API circuit is open, requeueing message",
+		"delay", circuitOpenRequeueDelay,
+		"error", processErr)
+	messageProcessor.metrics.CircuitOpenRequeue()
+
+	select {
+	case <-messageProcessor.consumeCtx.Done():
+	case <-time.After(circuitOpenRequeueDelay):
+	}
+	_ = msg.Nack(false, true)
+}
@@ -2,12 +2,19 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
 )
 
 type EntityTTLConfig struct {
@@ -22,6 +29,29 @@ type TTLConfig struct {
 	Translations EntityTTLConfig `mapstructure:"translations"`
 }
 
+// HotelChangesConfig selects the hotel fields whose changes are written to hotel_changes.
+type HotelChangesConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	TrackedFields []string `mapstructure:"tracked_fields"`
+}
+
+// RemovedHotelsConfig controls hotels the Cupid API answers 404 or 410 for.
+type RemovedHotelsConfig struct {
+	// RecheckDays is how long a removed hotel waits before it is fetched again.
+	RecheckDays int `mapstructure:"recheck_days"`
+}
+
+// SharedCircuitBreakerConfig shares the Cupid API circuit breaker across the fleet through
+// Redis, so one worker tripping it stops the others too.
+type SharedCircuitBreakerConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Key is the Redis key holding the worker that opened the circuit.
+	Key string `mapstructure:"key"`
+	// TTLSeconds is how long an open circuit is shared, unless the worker that opened it closes
+	// it first.
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+}
+
 type Config struct {
 	PostgresHost     string `mapstructure:"postgres_host"`
 	PostgresPort     int    `mapstructure:"postgres_port"`
@@ -29,14 +59,26 @@ type Config struct {
 	PostgresUser     string `mapstructure:"postgres_user"`
 	PostgresPassword string `mapstructure:"postgres_password"`
 
+	MessageBroker string           `mapstructure:"message_broker"`
+	NATS          queue.NATSConfig `mapstructure:"nats"`
+
 	RabbitmqHost     string `mapstructure:"rabbitmq_host"`
 	RabbitmqPort     int    `mapstructure:"rabbitmq_port"`
 	RabbitmqUser     string `mapstructure:"rabbitmq_user"`
 	RabbitmqPassword string `mapstructure:"rabbitmq_password"`
 
+	// RabbitmqManagementPort is the port of the RabbitMQ management API, used to report the
+	// queue depth.
+	RabbitmqManagementPort int `mapstructure:"rabbitmq_management_port"`
+
 	MainQueue        string `mapstructure:"main_queue"`
 	MaxRetryAttempts int    `mapstructure:"max_retry_attempts"`
 
+	// DLQExchange and DLQQueue receive the messages that fail processing on RabbitMQ. The
+	// worker declares both at startup.
+	DLQExchange string `mapstructure:"dlq_exchange"`
+	DLQQueue    string `mapstructure:"dlq_queue"`
+
 	RedisHost     string `mapstructure:"redis_host"`
 	RedisPort     int    `mapstructure:"redis_port"`
 	RedisPassword string `mapstructure:"redis_password"`
@@ -49,8 +91,53 @@ type Config struct {
 	CupidMaxRetryAttempts int    `mapstructure:"cupid_max_retry_attempts"`
 	APITimeoutSeconds     int    `mapstructure:"api_timeout_seconds"`
 
+	// Per-operation Cupid API timeouts, retries included. Zero falls back to
+	// api_timeout_seconds.
+	HotelFetchTimeoutSeconds       int `mapstructure:"hotel_fetch_timeout_seconds"`
+	ReviewFetchTimeoutSeconds      int `mapstructure:"review_fetch_timeout_seconds"`
+	TranslationFetchTimeoutSeconds int `mapstructure:"translation_fetch_timeout_seconds"`
+
+	CupidMaxResponseBytes int64 `mapstructure:"cupid_max_response_bytes"`
+
 	CircuitBreakerMaxFailures  int `mapstructure:"circuit_breaker_max_failures"`
 	CircuitBreakerResetSeconds int `mapstructure:"circuit_breaker_reset_seconds"`
+
+	SharedCircuitBreaker SharedCircuitBreakerConfig `mapstructure:"shared_circuit_breaker"`
+
+	HealthPort          int  `mapstructure:"health_port"`
+	MetricsPort         int  `mapstructure:"metrics_port"`
+	DrainTimeoutSeconds int  `mapstructure:"drain_timeout_seconds"`
+	EnablePprof         bool `mapstructure:"enable_pprof"`
+
+	// PauseFlagKey is the Redis key that pauses every worker while it holds true.
+	PauseFlagKey     string `mapstructure:"pause_flag_key"`
+	PausePollSeconds int    `mapstructure:"pause_poll_seconds"`
+
+	HotelChanges HotelChangesConfig `mapstructure:"hotel_changes"`
+
+	RemovedHotels RemovedHotelsConfig `mapstructure:"removed_hotels"`
+
+	// MaxMessageAgeSeconds is how long a message may wait in the queue before its processing
+	// is logged as a sign of a stuck or overwhelmed queue.
+	MaxMessageAgeSeconds int `mapstructure:"max_message_age_seconds"`
+
+	// MaxReviewsPerHotel caps the reviews fetched for a hotel, which otherwise follows the
+	// review count the Cupid API reports for it.
+	MaxReviewsPerHotel int `mapstructure:"max_reviews_per_hotel"`
+
+	// EnableGeoEnrichment looks up the attractions around each fetched hotel in OpenStreetMap.
+	EnableGeoEnrichment         bool   `mapstructure:"enable_geo_enrichment"`
+	OverpassAPIURL              string `mapstructure:"overpass_api_url"`
+	GeoEnrichmentRadiusMeters   int    `mapstructure:"geo_enrichment_radius_meters"`
+	GeoEnrichmentTimeoutSeconds int    `mapstructure:"geo_enrichment_timeout_seconds"`
+
+	// FacilityAliases extends the facility taxonomy, mapping raw facility names to slugs. It is
+	// read from the top-level facility_aliases key shared with the search service.
+	FacilityAliases map[string]string `mapstructure:"-"`
+
+	// SourcePriorities ranks the sources writing hotels, lowest first. It is read from the
+	// top-level source_priorities key shared with the search service.
+	SourcePriorities entities.SourcePriorities `mapstructure:"-"`
 }
 
 func loadConfig() Config {
@@ -74,6 +161,12 @@ func loadConfig() Config {
 	if err := viper.UnmarshalKey("worker", &config); err != nil {
 		panic(err)
 	}
+	if err := viper.UnmarshalKey("facility_aliases", &config.FacilityAliases); err != nil {
+		panic(err)
+	}
+	if err := viper.UnmarshalKey("source_priorities", &config.SourcePriorities); err != nil {
+		panic(err)
+	}
 
 	config.PostgresUser = os.ExpandEnv(config.PostgresUser)
 	config.PostgresHost = os.ExpandEnv(config.PostgresHost)
@@ -85,11 +178,114 @@ func loadConfig() Config {
 	config.RabbitmqPassword = os.ExpandEnv(config.RabbitmqPassword)
 	config.RabbitmqPort, _ = strconv.Atoi(os.ExpandEnv(fmt.Sprintf("%d", config.RabbitmqPort)))
 
-	config.CupidAPIKey = os.ExpandEnv(config.CupidAPIKey)
+	config.NATS.URL = os.ExpandEnv(config.NATS.URL)
 
-	fmt.Println(config.CupidAPIKey)
+	config.CupidAPIKey = os.ExpandEnv(config.CupidAPIKey)
 
 	config.RedisHost = os.ExpandEnv(config.RedisHost)
 	config.RedisPassword = os.ExpandEnv(config.RedisPassword)
 	return config
 }
+
+// validate applies the defaults of unset keys and reports them, together with every invalid key.
+func (c *Config) validate() *configcheck.Report {
+	report := &configcheck.Report{}
+
+	report.Required("worker.postgres_host", c.PostgresHost)
+	report.Required("worker.postgres_db", c.PostgresDB)
+	report.Required("worker.postgres_user", c.PostgresUser)
+	configcheck.Default(report, "worker.postgres_port", &c.PostgresPort, 5432)
+	configcheck.Range(report, "worker.postgres_port", c.PostgresPort, 1, 65535)
+
+	configcheck.Default(report, "worker.message_broker", &c.MessageBroker, queue.BrokerRabbitMQ)
+	report.OneOf("worker.message_broker", c.MessageBroker, queue.BrokerRabbitMQ, queue.BrokerNATS)
+	if c.MessageBroker == queue.BrokerNATS {
+		report.Required("worker.nats.url", c.NATS.URL)
+		report.Required("worker.nats.stream_name", c.NATS.StreamName)
+		report.Required("worker.nats.consumer_name", c.NATS.ConsumerName)
+	} else {
+		report.Required("worker.rabbitmq_host", c.RabbitmqHost)
+		report.Required("worker.rabbitmq_user", c.RabbitmqUser)
+		configcheck.Default(report, "worker.rabbitmq_port", &c.RabbitmqPort, 5672)
+		configcheck.Range(report, "worker.rabbitmq_port", c.RabbitmqPort, 1, 65535)
+		configcheck.Default(report, "worker.dlq_exchange", &c.DLQExchange, defaultDLQExchange)
+		configcheck.Default(report, "worker.dlq_queue", &c.DLQQueue, defaultDLQQueue)
+	}
+	configcheck.Range(report, "worker.rabbitmq_management_port", c.RabbitmqManagementPort, 0, 65535)
+
+	report.Required("worker.main_queue", c.MainQueue)
+	configcheck.Default(report, "worker.max_retry_attempts", &c.MaxRetryAttempts, 5)
+	configcheck.Default(report, "worker.prefetch_count", &c.PrefetchCount, 1)
+
+	report.Required("worker.redis_host", c.RedisHost)
+	configcheck.Default(report, "worker.redis_port", &c.RedisPort, 6379)
+	configcheck.Range(report, "worker.redis_port", c.RedisPort, 1, 65535)
+
+	for _, entity := range []struct {
+		name string
+		ttl  EntityTTLConfig
+	}{{"hotels", c.TTL.Hotels}, {"reviews", c.TTL.Reviews}, {"translations", c.TTL.Translations}} {
+		key := "worker.ttl." + entity.name
+		configcheck.Range(report, key+".lock_seconds", entity.ttl.LockSeconds, 1, math.MaxInt32)
+		configcheck.Range(report, key+".cache_seconds", entity.ttl.CacheSeconds, 1, math.MaxInt32)
+		configcheck.Range(report, key+".next_update_seconds", entity.ttl.NextUpdateSeconds, 1, math.MaxInt32)
+	}
+
+	report.Required("worker.cupid_api_url", c.CupidAPIURL)
+	report.Required("worker.cupid_api_key", c.CupidAPIKey)
+	configcheck.Default(report, "worker.cupid_max_retry_attempts", &c.CupidMaxRetryAttempts, 3)
+	configcheck.Default(report, "worker.api_timeout_seconds", &c.APITimeoutSeconds, 30)
+	configcheck.Range(report, "worker.hotel_fetch_timeout_seconds", c.HotelFetchTimeoutSeconds, 0, math.MaxInt32)
+	configcheck.Range(report, "worker.review_fetch_timeout_seconds", c.ReviewFetchTimeoutSeconds, 0, math.MaxInt32)
+	configcheck.Range(report, "worker.translation_fetch_timeout_seconds", c.TranslationFetchTimeoutSeconds, 0, math.MaxInt32)
+	if c.CupidMaxResponseBytes < 0 {
+		report.Errorf("worker.cupid_max_response_bytes", "must not be negative, got %d", c.CupidMaxResponseBytes)
+	}
+
+	configcheck.Default(report, "worker.circuit_breaker_max_failures", &c.CircuitBreakerMaxFailures, 5)
+	configcheck.Default(report, "worker.circuit_breaker_reset_seconds", &c.CircuitBreakerResetSeconds, 60)
+	if c.SharedCircuitBreaker.Enabled {
+		configcheck.Default(report, "worker.shared_circuit_breaker.key", &c.SharedCircuitBreaker.Key, defaultSharedBreakerKey)
+		configcheck.Default(report, "worker.shared_circuit_breaker.ttl_seconds", &c.SharedCircuitBreaker.TTLSeconds, 2*c.CircuitBreakerResetSeconds)
+	}
+
+	configcheck.Range(report, "worker.health_port", c.HealthPort, 0, 65535)
+	configcheck.Range(report, "worker.metrics_port", c.MetricsPort, 0, 65535)
+	configcheck.Default(report, "worker.drain_timeout_seconds", &c.DrainTimeoutSeconds, 30)
+	configcheck.Default(report, "worker.pause_flag_key", &c.PauseFlagKey, defaultPauseFlagKey)
+	configcheck.Default(report, "worker.pause_poll_seconds", &c.PausePollSeconds, int(defaultPausePollInterval/time.Second))
+
+	if c.HotelChanges.Enabled {
+		if len(c.HotelChanges.TrackedFields) == 0 {
+			c.HotelChanges.TrackedFields = entities.TrackableHotelFields
+			report.Defaults = append(report.Defaults, configcheck.Issue{
+				Key:     "worker.hotel_changes.tracked_fields",
+				Message: strings.Join(entities.TrackableHotelFields, ", "),
+			})
+		}
+		for _, field := range c.HotelChanges.TrackedFields {
+			report.OneOf("worker.hotel_changes.tracked_fields", field, entities.TrackableHotelFields...)
+		}
+	}
+
+	configcheck.Default(report, "worker.removed_hotels.recheck_days", &c.RemovedHotels.RecheckDays, defaultRemovedHotelRecheckDays)
+	configcheck.Default(report, "worker.max_message_age_seconds", &c.MaxMessageAgeSeconds, defaultMaxMessageAgeSeconds)
+	configcheck.Default(report, "worker.max_reviews_per_hotel", &c.MaxReviewsPerHotel, defaultMaxReviewsPerHotel)
+	configcheck.Range(report, "worker.max_reviews_per_hotel", c.MaxReviewsPerHotel, 1, math.MaxInt32)
+
+	if c.EnableGeoEnrichment {
+		configcheck.Default(report, "worker.overpass_api_url", &c.OverpassAPIURL, adapter.DefaultOverpassURL)
+		configcheck.Default(report, "worker.geo_enrichment_radius_meters", &c.GeoEnrichmentRadiusMeters, defaultGeoEnrichmentRadiusMeters)
+		configcheck.Range(report, "worker.geo_enrichment_radius_meters", c.GeoEnrichmentRadiusMeters, 1, maxGeoEnrichmentRadiusMeters)
+		configcheck.Default(report, "worker.geo_enrichment_timeout_seconds", &c.GeoEnrichmentTimeoutSeconds, 10)
+	}
+
+	if _, err := facilities.New(c.FacilityAliases); err != nil {
+		report.Errorf("facility_aliases", "%v", err)
+	}
+	if err := c.SourcePriorities.Validate(); err != nil {
+		report.Errorf("source_priorities", "%v", err)
+	}
+
+	return report
+}
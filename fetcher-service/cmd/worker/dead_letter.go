@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+)
+
+const (
+	defaultDLQExchange = "hotel.dlq"
+	defaultDLQQueue    = "hotel.dlq.queue"
+
+	deadLetterPublishTimeout = 5 * time.Second
+)
+
+// newDeadLetterPublisher opens a connection of its own to declare the dead letter exchange and
+// queue and publish to them, so dead lettering does not depend on the consumer's channel.
+func (messageProcessor *MessageProcessor) newDeadLetterPublisher() (queue.PublisherPort, error) {
+	config := messageProcessor.config
+	address := fmt.Sprintf("amqp://%s:%s@%s:%d/", config.RabbitmqUser, config.RabbitmqPassword, config.RabbitmqHost, config.RabbitmqPort)
+	amqpConnection, err := amqp.Dial(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	amqpChannel, err := amqpConnection.Channel()
+	if err != nil {
+		_ = amqpConnection.Close()
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
+	}
+
+	return queue.NewMQDeadLetterPublisher(amqpConnection, amqpChannel, config.DLQExchange, config.DLQQueue)
+}
+
+// deadLetter publishes a message that failed processing to the dead letter exchange with the
+// failure, and acks it once the broker confirms the dead letter. When the publish fails or is
+// not confirmed the message is requeued, so it is never dropped. Without a dead letter
+// publisher the message is nacked without requeue and left to the broker's own dead letter
+// configuration.
+func (messageProcessor *MessageProcessor) deadLetter(msg amqp.Delivery, processErr error) {
+	reason := dlqReason(processErr)
+	messageProcessor.logger.Warn("Message discarded and sent to Dead Letter Queue (DLQ)",
+		"message_id", loggedBody(msg.Body),
+		"routing_key", msg.RoutingKey,
+		"reason", reason,
+		"error", processErr)
+	messageProcessor.metrics.DLQMessage()
+
+	if messageProcessor.deadLetters == nil {
+		_ = msg.Nack(false, false)
+		return
+	}
+
+	id := msg.MessageId
+	if envelope, err := messages.Decode(msg.Body); err == nil {
+		id = envelope.ID
+	}
+	letter := messages.NewDeadLetter(id, msg.Body, processErr, reason, deliveryAttempts(msg), time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterPublishTimeout)
+	defer cancel()
+	if err := messageProcessor.deadLetters.PublishBatch(ctx, []queue.Message{letter}); err != nil {
+		messageProcessor.logger.Error("Failed to publish message to dead letter exchange, requeueing it",
+			"exchange", messageProcessor.config.DLQExchange,
+			"error", err)
+		_ = msg.Nack(false, true)
+		return
+	}
+
+	_ = msg.Ack(false)
+}
+
+// deliveryAttempts counts the deliveries of msg. Quorum queues report earlier deliveries in
+// x-delivery-count; classic queues only flag a redelivery.
+func deliveryAttempts(msg amqp.Delivery) int {
+	switch count := msg.Headers["x-delivery-count"].(type) {
+	case int64:
+		return int(count) + 1
+	case int32:
+		return int(count) + 1
+	}
+	if msg.Redelivered {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/mocks"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+	"go.uber.org/mock/gomock"
+)
+
+func newDeadLetterTestProcessor(t *testing.T) (*MessageProcessor, *mocks.MockPublisherPort) {
+	t.Helper()
+	deadLetters := mocks.NewMockPublisherPort(gomock.NewController(t))
+	messageProcessor := newDrainTestProcessor(t, &fakeConsumer{}, &slowLock{}, 0)
+	messageProcessor.config.DLQExchange = defaultDLQExchange
+	messageProcessor.deadLetters = deadLetters
+	return messageProcessor, deadLetters
+}
+
+func TestDeadLetterAcksOnceThePublishIsConfirmed(t *testing.T) {
+	messageProcessor, deadLetters := newDeadLetterTestProcessor(t)
+	acknowledger := newFakeAcknowledger()
+	delivery := hotelDelivery(t, acknowledger, 1, 42)
+
+	deadLetters.EXPECT().PublishBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, letters []queue.Message) error {
+		require.Len(t, letters, 1)
+		assert.Equal(t, constants.MessageTypeDeadLetter, letters[0].Type)
+		var payload messages.DeadLetterPayload
+		require.NoError(t, json.Unmarshal(letters[0].Payload, &payload))
+		assert.Equal(t, "hotel not found", payload.Error)
+		assert.JSONEq(t, string(delivery.Body), string(payload.Body))
+		assert.Empty(t, acknowledger.ackedTags(), "the original is not acked before the dead letter is confirmed")
+		return nil
+	})
+
+	messageProcessor.deadLetter(delivery, errors.New("hotel not found"))
+
+	assert.Equal(t, []uint64{1}, acknowledger.ackedTags())
+	assert.Empty(t, acknowledger.nackedTags())
+}
+
+func TestDeadLetterRequeuesWhenThePublishFails(t *testing.T) {
+	messageProcessor, deadLetters := newDeadLetterTestProcessor(t)
+	acknowledger := newFakeAcknowledger()
+
+	deadLetters.EXPECT().PublishBatch(gomock.Any(), gomock.Any()).Return(errors.New("broker nacked the publish with delivery tag 7"))
+
+	messageProcessor.deadLetter(hotelDelivery(t, acknowledger, 1, 42), errors.New("hotel not found"))
+
+	assert.Empty(t, acknowledger.ackedTags())
+	assert.Equal(t, []uint64{1}, acknowledger.requeuedTags(), "a message whose dead letter is not confirmed is not dropped")
+}
+
+func TestDeadLetterWithoutPublisherLeavesTheMessageToTheBroker(t *testing.T) {
+	messageProcessor := newDrainTestProcessor(t, &fakeConsumer{}, &slowLock{}, 0)
+	acknowledger := newFakeAcknowledger()
+
+	messageProcessor.deadLetter(hotelDelivery(t, acknowledger, 1, 42), errors.New("hotel not found"))
+
+	assert.Equal(t, []uint64{1}, acknowledger.nackedTags())
+	assert.Empty(t, acknowledger.requeuedTags())
+}
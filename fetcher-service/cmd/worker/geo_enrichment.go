@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+)
+
+const (
+	defaultGeoEnrichmentRadiusMeters = 500
+	// maxGeoEnrichmentRadiusMeters keeps the Overpass queries cheap enough for its public
+	// instances.
+	maxGeoEnrichmentRadiusMeters = 5000
+)
+
+// enrichNearbyAttractions stores the attractions around the hotel. Enrichment is best effort:
+// a failure keeps the attractions found last time and does not fail the message.
+func (messageProcessor *MessageProcessor) enrichNearbyAttractions(hotel *entities.HotelData) {
+	if messageProcessor.geoEnrichment == nil || hotel.Latitude == 0 && hotel.Longitude == 0 {
+		return
+	}
+
+	attractions, err := messageProcessor.geoEnrichment.GetNearbyAttractions(messageProcessor.ctx,
+		hotel.Latitude, hotel.Longitude, messageProcessor.config.GeoEnrichmentRadiusMeters)
+	if err != nil {
+		messageProcessor.logger.Warn("Failed to fetch nearby attractions", "hotel_id", hotel.HotelID, "error", err)
+		return
+	}
+
+	if err := messageProcessor.gormRepo.UpdateNearbyAttractions(messageProcessor.ctx, hotel.HotelID, attractions); err != nil {
+		messageProcessor.logger.Warn("Failed to save nearby attractions", "hotel_id", hotel.HotelID, "error", err)
+		return
+	}
+	messageProcessor.logger.Debug("Stored nearby attractions", "hotel_id", hotel.HotelID, "count", len(attractions))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+)
+
+type healthResponse struct {
+	Status   string `json:"status"`
+	Draining bool   `json:"draining"`
+	InFlight int64  `json:"in_flight"`
+	// OldestProcessedMessageAge is the longest, in seconds, a message processed in the last
+	// minute waited in the queue.
+	OldestProcessedMessageAge float64 `json:"oldest_processed_message_age"`
+	Version                   string  `json:"version"`
+	Commit                    string  `json:"commit"`
+	BuildTime                 string  `json:"build_time"`
+}
+
+func (messageProcessor *MessageProcessor) startHealthServer() {
+	if messageProcessor.config.HealthPort == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", messageProcessor.handleHealth)
+	mux.HandleFunc("/debug/info", messageProcessor.handleDebugInfo)
+	if messageProcessor.config.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	messageProcessor.healthServer = &http.Server{
+		Addr:              fmt.Sprintf(":%d", messageProcessor.config.HealthPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		messageProcessor.logger.Info("Starting worker health listener", "port", messageProcessor.config.HealthPort)
+		if err := messageProcessor.healthServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			messageProcessor.logger.Error("Worker health listener failed", "error", err)
+		}
+	}()
+}
+
+// handleHealth reports 503 while draining so orchestration can wait for the worker to finish.
+func (messageProcessor *MessageProcessor) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	response := healthResponse{
+		Status:                    "ok",
+		Draining:                  messageProcessor.draining.Load(),
+		InFlight:                  messageProcessor.inFlight.Load(),
+		OldestProcessedMessageAge: messageProcessor.messageAges.oldest(time.Now()).Seconds(),
+		Version:                   buildinfo.Version,
+		Commit:                    buildinfo.Commit,
+		BuildTime:                 buildinfo.BuildTime,
+	}
+
+	statusCode := http.StatusOK
+	if response.Draining {
+		response.Status = "draining"
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleDebugInfo is only served on the health port, which is not exposed outside the cluster.
+func (messageProcessor *MessageProcessor) handleDebugInfo(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(buildinfo.Collect("worker", messageProcessor.config))
+}
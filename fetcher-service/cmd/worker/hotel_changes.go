@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+// recordHotelChanges writes the tracked fields the upsert changed to hotel_changes and logs
+// them. A failed write is only logged: the audit trail must not fail the hotel update.
+func (messageProcessor *MessageProcessor) recordHotelChanges(message messages.Envelope, previous, current *entities.HotelData) {
+	if !messageProcessor.config.HotelChanges.Enabled {
+		return
+	}
+
+	changes := entities.DiffHotel(previous, current, messageProcessor.config.HotelChanges.TrackedFields)
+	if len(changes) == 0 {
+		return
+	}
+
+	messageProcessor.logger.Info("Hotel fields changed",
+		"hotel_id", current.HotelID,
+		"message_id", message.ID,
+		"changes", changes)
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		messageProcessor.logger.Warn("Failed to encode hotel changes", "hotel_id", current.HotelID, "error", err)
+		return
+	}
+
+	change := &entities.HotelChange{
+		HotelID:         current.HotelID,
+		Changes:         data,
+		SourceMessageID: message.ID,
+		ChangedAt:       time.Now().UTC(),
+	}
+	if err := messageProcessor.gormRepo.CreateHotelChange(messageProcessor.ctx, change); err != nil {
+		messageProcessor.logger.Warn("Failed to record hotel changes", "hotel_id", current.HotelID, "error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+// changeRecordingRepository keeps the hotel changes written to it.
+type changeRecordingRepository struct {
+	ports.RepositoryPort
+	changes []*entities.HotelChange
+}
+
+func (r *changeRecordingRepository) CreateHotelChange(_ context.Context, change *entities.HotelChange) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+func newChangesTestProcessor(t *testing.T, config HotelChangesConfig) (*MessageProcessor, *changeRecordingRepository) {
+	t.Helper()
+	repository := &changeRecordingRepository{}
+	return &MessageProcessor{
+		config:   Config{HotelChanges: config},
+		logger:   slog.New(slog.DiscardHandler),
+		gormRepo: repository,
+		ctx:      context.Background(),
+	}, repository
+}
+
+func TestRecordHotelChangesWritesOneRecordPerUpsert(t *testing.T) {
+	messageProcessor, repository := newChangesTestProcessor(t, HotelChangesConfig{
+		Enabled:       true,
+		TrackedFields: []string{"rating", "star_rating", "name"},
+	})
+	previous := &entities.HotelData{HotelID: 42, Name: "Harbour Hotel", Rating: 4.5, StarRating: 4}
+	current := &entities.HotelData{HotelID: 42, Name: "Harbour Hotel", Rating: 3.1, StarRating: 3}
+
+	messageProcessor.recordHotelChanges(messages.Envelope{ID: "message-1"}, previous, current)
+
+	require.Len(t, repository.changes, 1)
+	change := repository.changes[0]
+	assert.Equal(t, int64(42), change.HotelID)
+	assert.Equal(t, "message-1", change.SourceMessageID)
+	assert.False(t, change.ChangedAt.IsZero())
+	assert.Equal(t, "UTC", change.ChangedAt.Location().String())
+	assert.JSONEq(t, `{"rating":{"old":4.5,"new":3.1},"star_rating":{"old":4,"new":3}}`, string(change.Changes))
+}
+
+func TestRecordHotelChangesSkipsUnchangedHotels(t *testing.T) {
+	messageProcessor, repository := newChangesTestProcessor(t, HotelChangesConfig{
+		Enabled:       true,
+		TrackedFields: entities.TrackableHotelFields,
+	})
+	hotel := &entities.HotelData{HotelID: 42, Name: "Harbour Hotel", Rating: 4.5}
+
+	messageProcessor.recordHotelChanges(messages.Envelope{ID: "message-1"}, hotel, &entities.HotelData{HotelID: 42, Name: "Harbour Hotel", Rating: 4.5, Description: "New"})
+	messageProcessor.recordHotelChanges(messages.Envelope{ID: "message-2"}, nil, hotel)
+
+	assert.Empty(t, repository.changes)
+}
+
+func TestRecordHotelChangesDisabled(t *testing.T) {
+	messageProcessor, repository := newChangesTestProcessor(t, HotelChangesConfig{TrackedFields: entities.TrackableHotelFields})
+
+	messageProcessor.recordHotelChanges(messages.Envelope{ID: "message-1"},
+		&entities.HotelData{HotelID: 42, Rating: 4.5}, &entities.HotelData{HotelID: 42, Rating: 3.1})
+
+	assert.Empty(t, repository.changes)
+}
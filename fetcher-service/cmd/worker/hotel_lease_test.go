@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+// countingCupidAPI counts hotel fetches, each taking delay.
+type countingCupidAPI struct {
+	ports.APIClientPort
+	delay   time.Duration
+	fetches atomic.Int32
+	err     error
+}
+
+func (c *countingCupidAPI) FetchHotelData(_ context.Context, hotelID int64) (*dto.HotelAPIResponse, error) {
+	c.fetches.Add(1)
+	time.Sleep(c.delay)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &dto.HotelAPIResponse{HotelID: hotelID, HotelName: "Harbour Hotel"}, nil
+}
+
+// discardingRepository accepts every hotel upsert.
+type discardingRepository struct {
+	ports.RepositoryPort
+}
+
+func (discardingRepository) UpsertHotel(context.Context, *entities.HotelData, entities.SourceWrite) (*entities.HotelData, error) {
+	return nil, nil
+}
+
+// newLeaseTestProcessor returns a worker sharing the Redis of server with the other workers of
+// a test.
+func newLeaseTestProcessor(t *testing.T, server *miniredis.Miniredis, workerID string, cupidAPI ports.APIClientPort) *MessageProcessor {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	redisCache := adapter.NewRedisCacheAdapter(server.Addr(), "", 0)
+	redisLock := adapter.NewRedisLockAdapter(server.Addr(), "", 0)
+	t.Cleanup(func() {
+		_ = redisCache.Close()
+		_ = redisLock.Close()
+	})
+
+	config := Config{}
+	config.TTL.Hotels = EntityTTLConfig{CacheSeconds: 60, NextUpdateSeconds: 3600}
+
+	return &MessageProcessor{
+		config:     config,
+		logger:     slog.New(slog.DiscardHandler),
+		cupidAPI:   cupidAPI,
+		gormRepo:   discardingRepository{},
+		redisCache: redisCache,
+		redisLock:  redisLock,
+		workerID:   workerID,
+		facilities: facilities.Default(),
+		metrics:    worker.NewWorkerMetrics(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func TestHotelLeaseFetchesOnceForConcurrentWorkers(t *testing.T) {
+	server := miniredis.RunT(t)
+	cupidAPI := &countingCupidAPI{delay: 200 * time.Millisecond}
+	workers := []*MessageProcessor{
+		newLeaseTestProcessor(t, server, "worker-a", cupidAPI),
+		newLeaseTestProcessor(t, server, "worker-b", cupidAPI),
+	}
+
+	message := messages.NewHotelUpdate("row-7", 7)
+	errs := make([]error, len(workers))
+	var wg sync.WaitGroup
+	for i, messageProcessor := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = messageProcessor.processHotelMessage(context.Background(), message, 7)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), cupidAPI.fetches.Load(), "only the lease holder calls the Cupid API")
+	assert.True(t, server.Exists("hotel_data_row-7"), "the lease holder populates the cache")
+	assert.False(t, server.Exists("hotel_lease:7"), "the lease is released after the fetch")
+}
+
+func TestHotelLeaseSkipsFetchWhenCached(t *testing.T) {
+	server := miniredis.RunT(t)
+	cupidAPI := &countingCupidAPI{}
+	messageProcessor := newLeaseTestProcessor(t, server, "worker-a", cupidAPI)
+	message := messages.NewHotelUpdate("row-7", 7)
+
+	require.NoError(t, messageProcessor.processHotelMessage(context.Background(), message, 7))
+	require.NoError(t, messageProcessor.processHotelMessage(context.Background(), message, 7))
+
+	assert.Equal(t, int32(1), cupidAPI.fetches.Load())
+}
+
+func TestHotelLeaseReleasedWhenFetchFails(t *testing.T) {
+	server := miniredis.RunT(t)
+	cupidAPI := &countingCupidAPI{err: errors.New("connection reset")}
+	messageProcessor := newLeaseTestProcessor(t, server, "worker-a", cupidAPI)
+
+	err := messageProcessor.processHotelMessage(context.Background(), messages.NewHotelUpdate("row-7", 7), 7)
+
+	assert.ErrorContains(t, err, "connection reset")
+	assert.False(t, server.Exists("hotel_lease:7"), "a failed fetch does not keep other workers waiting")
+}
+
+func TestWaitForCachedResult(t *testing.T) {
+	server := miniredis.RunT(t)
+	messageProcessor := newLeaseTestProcessor(t, server, "worker-a", &countingCupidAPI{})
+	ctx := context.Background()
+
+	t.Run("returns the value once it is cached", func(t *testing.T) {
+		time.AfterFunc(100*time.Millisecond, func() {
+			_ = server.Set("hotel_data_row-1", `{"hotel_id":1}`)
+		})
+		cached, ok := messageProcessor.waitForCachedResult(ctx, "hotel_data_row-1", 2*time.Second)
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{"hotel_id": float64(1)}, cached)
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		start := time.Now()
+		_, ok := messageProcessor.waitForCachedResult(ctx, "hotel_data_row-2", 600*time.Millisecond)
+		assert.False(t, ok)
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, ok := messageProcessor.waitForCachedResult(cancelled, "hotel_data_row-3", time.Minute)
+		assert.False(t, ok)
+	})
+}
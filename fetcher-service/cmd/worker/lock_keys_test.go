@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// translationLangRepository stores every translation row in lang.
+type translationLangRepository struct {
+	ports.RepositoryPort
+	lang string
+}
+
+func (r translationLangRepository) GetLangById(context.Context, string) string { return r.lang }
+
+func delivery(t *testing.T, message messages.Envelope) amqp.Delivery {
+	t.Helper()
+	body, err := json.Marshal(message)
+	require.NoError(t, err)
+	return amqp.Delivery{Body: body}
+}
+
+func TestLockKeyOfEachMessageType(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  messages.Envelope
+		expected string
+	}{
+		{name: "update hotel", message: messages.NewHotelUpdate("hotel-row", 7), expected: "hotel_lock_7"},
+		{name: "update review", message: messages.NewReviewUpdate("review-row", 7), expected: "reviews_lock_7"},
+		{name: "fetch review", message: messages.NewReviewFetch("hotel-row", 7), expected: "reviews_lock_7"},
+		{name: "update translation", message: messages.NewTranslationUpdate("translation-row", 7), expected: "translations_lock_7_es"},
+		{name: "fetch translation", message: messages.NewTranslationFetch(7, "fr"), expected: "translations_lock_7_fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lock := &slowLock{started: make(chan string, 1)}
+			messageProcessor := newDrainTestProcessor(t, &fakeConsumer{}, lock, 5)
+			messageProcessor.gormRepo = translationLangRepository{lang: "es"}
+
+			require.NoError(t, messageProcessor.processMessage(delivery(t, tt.message)))
+
+			assert.Equal(t, []string{tt.expected}, lock.keys(), "the lock covers the resource, not the message row")
+		})
+	}
+}
+
+// blockingReviewsAPI returns one review per hotel once released, and tracks how many fetches
+// run at once.
+type blockingReviewsAPI struct {
+	ports.APIClientPort
+	release    chan struct{}
+	entered    chan int64
+	mu         sync.Mutex
+	running    int
+	maxRunning int
+	fetches    int
+}
+
+func (a *blockingReviewsAPI) FetchHotelReviews(_ context.Context, hotelID int64, _ *dto.ReviewFetchOptions) (*dto.ReviewDataList, error) {
+	a.mu.Lock()
+	a.running++
+	a.fetches++
+	a.maxRunning = max(a.maxRunning, a.running)
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.running--
+		a.mu.Unlock()
+	}()
+
+	a.entered <- hotelID
+	<-a.release
+	return &dto.ReviewDataList{{ReviewID: hotelID*100 + 1, AverageScore: 8, Name: "Ana", Date: "2024-05-12 00:00:00", Headline: "Lovely stay"}}, nil
+}
+
+func (a *blockingReviewsAPI) counts() (fetches, maxRunning int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fetches, a.maxRunning
+}
+
+// newWorkerTestRepository returns a repository backed by a SQLite database storing hotels 7
+// and 8, each with one review upstream.
+func newWorkerTestRepository(t *testing.T) (ports.RepositoryPort, *gorm.DB) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate", filepath.Join(t.TempDir(), "worker.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard, DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	require.NoError(t, db.Migrator().CreateTable(&entities.HotelData{}, &entities.ReviewData{}, &entities.ReviewFetchState{}))
+	for _, hotelID := range []int64{7, 8} {
+		require.NoError(t, db.Create(&entities.HotelData{HotelID: hotelID, CupidID: hotelID, Name: "Harbour Hotel", ReviewCount: 1}).Error)
+	}
+	repository, err := adapter.NewGormRepository(db)
+	require.NoError(t, err)
+	return repository, db
+}
+
+// newSerializationTestProcessor returns a worker locking through the Redis of server, which it
+// shares with the other workers of a test.
+func newSerializationTestProcessor(t *testing.T, server *miniredis.Miniredis, repository ports.RepositoryPort, cupidAPI ports.APIClientPort) *MessageProcessor {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	redisLock := adapter.NewRedisLockAdapter(server.Addr(), "", 0)
+	t.Cleanup(func() {
+		cancel()
+		_ = redisLock.Close()
+	})
+
+	config := Config{MaxReviewsPerHotel: 500}
+	config.TTL.Hotels = EntityTTLConfig{LockSeconds: 60}
+	config.TTL.Reviews = EntityTTLConfig{LockSeconds: 60}
+	return &MessageProcessor{
+		config:     config,
+		logger:     slog.New(slog.DiscardHandler),
+		cupidAPI:   cupidAPI,
+		gormRepo:   repository,
+		redisCache: missingCache{},
+		redisLock:  redisLock,
+		metrics:    worker.NewWorkerMetrics(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func TestMessagesOfOneHotelAreSerialized(t *testing.T) {
+	server := miniredis.RunT(t)
+	cupidAPI := &blockingReviewsAPI{release: make(chan struct{}), entered: make(chan int64, 4)}
+	repository, db := newWorkerTestRepository(t)
+	workerA := newSerializationTestProcessor(t, server, repository, cupidAPI)
+	workerB := newSerializationTestProcessor(t, server, repository, cupidAPI)
+
+	// Worker A fetches the reviews of hotel 7 and holds its lock until the API answers.
+	fetchDone := make(chan error, 1)
+	go func() { fetchDone <- workerA.processMessage(delivery(t, messages.NewReviewFetch("hotel-row", 7))) }()
+	require.Equal(t, int64(7), <-cupidAPI.entered)
+
+	// Worker B's update of the same hotel's reviews, a different message row, does not run
+	// alongside it.
+	updateDone := make(chan error, 1)
+	go func() { updateDone <- workerB.processMessage(delivery(t, messages.NewReviewUpdate("review-row", 7))) }()
+	select {
+	case err := <-updateDone:
+		require.NoError(t, err, "the conflicting message is skipped")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the conflicting message waited for the lock")
+	}
+	fetches, _ := cupidAPI.counts()
+	assert.Equal(t, 1, fetches)
+
+	// Another hotel's reviews are not held up.
+	otherDone := make(chan error, 1)
+	go func() { otherDone <- workerB.processMessage(delivery(t, messages.NewReviewFetch("hotel-row", 8))) }()
+	require.Equal(t, int64(8), <-cupidAPI.entered)
+
+	close(cupidAPI.release)
+	require.NoError(t, <-fetchDone)
+	require.NoError(t, <-otherDone)
+	_, maxRunning := cupidAPI.counts()
+	assert.Equal(t, 2, maxRunning, "only the fetches of different hotels overlapped")
+
+	// The lock is released once the message is processed.
+	go func() { updateDone <- workerB.processMessage(delivery(t, messages.NewReviewUpdate("review-row", 7))) }()
+	require.Equal(t, int64(7), <-cupidAPI.entered)
+	require.NoError(t, <-updateDone)
+	fetches, _ = cupidAPI.counts()
+	assert.Equal(t, 3, fetches)
+
+	var reviews int64
+	require.NoError(t, db.Model(&entities.ReviewData{}).Where(constants.HotelId+" = ?", 7).Count(&reviews).Error)
+	assert.Equal(t, int64(1), reviews, "the review is stored once")
+}
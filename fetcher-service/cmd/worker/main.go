@@ -13,6 +13,7 @@ import (
 func main() {
 	config := loadConfig()
 	applicationLogger := logger.SetupLogger("info")
+	config.validate().Enforce(applicationLogger)
 
 	connectionString := fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=disable", config.PostgresHost, config.PostgresPort, config.PostgresDB, config.PostgresUser, config.PostgresPassword)
 	db, err := database.GormOpen(connectionString)
@@ -21,7 +22,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}); err != nil {
+	if err := database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}, &entities.HotelChange{}, &entities.ReviewFetchState{}); err != nil {
 		applicationLogger.Error("db migrations failed", "error", err.Error())
 		os.Exit(1)
 	}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultMaxMessageAgeSeconds = 300
+	// messageAgeWindowSeconds is how far back the health endpoint looks for the oldest message.
+	messageAgeWindowSeconds = 60
+)
+
+// messageAgeWindow keeps the oldest message age processed in each of the last
+// messageAgeWindowSeconds seconds.
+type messageAgeWindow struct {
+	mu      sync.Mutex
+	buckets [messageAgeWindowSeconds]messageAgeBucket
+}
+
+type messageAgeBucket struct {
+	second int64
+	oldest time.Duration
+}
+
+func (w *messageAgeWindow) record(now time.Time, age time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	second := now.Unix()
+	bucket := &w.buckets[second%messageAgeWindowSeconds]
+	if bucket.second != second {
+		*bucket = messageAgeBucket{second: second}
+	}
+	bucket.oldest = max(bucket.oldest, age)
+}
+
+// oldest returns the oldest message age processed in the window ending at now.
+func (w *messageAgeWindow) oldest(now time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var oldest time.Duration
+	for _, bucket := range w.buckets {
+		if now.Unix()-bucket.second < messageAgeWindowSeconds {
+			oldest = max(oldest, bucket.oldest)
+		}
+	}
+	return oldest
+}
+
+// observeMessageAge records how long the message waited in the queue since it was published,
+// warning when it waited longer than MaxMessageAgeSeconds. Messages published without a
+// timestamp are ignored.
+func (messageProcessor *MessageProcessor) observeMessageAge(msg amqp.Delivery, messageType string) {
+	if msg.Timestamp.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	age := max(now.Sub(msg.Timestamp), 0)
+	messageProcessor.metrics.ObserveQueueAge(messageType, age)
+	messageProcessor.messageAges.record(now, age)
+
+	if age > time.Duration(messageProcessor.config.MaxMessageAgeSeconds)*time.Second {
+		messageProcessor.logger.Warn("Message waited too long in the queue",
+			"fetch_type", messageType,
+			"age", age.Round(time.Second),
+			"max_age_seconds", messageProcessor.config.MaxMessageAgeSeconds)
+	}
+}
@@ -2,45 +2,83 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/common-nighthawk/go-figure"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/infrastructure/queue"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
-	"github.com/victoragudo/hotel-management-system/fetcher-service/pkg/constants"
-	constants2 "github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"github.com/victoragudo/hotel-management-system/pkg/phone"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
 	"gorm.io/gorm"
 )
 
 type MessageProcessor struct {
-	config           Config
-	logger           *slog.Logger
-	cupidAPI         ports.APIClientPort
-	gormRepo         ports.RepositoryPort
-	redisCache       ports.CachePort
-	redisLock        ports.LockPort
-	shutdownChan     chan os.Signal
-	ctx              context.Context
-	cancel           context.CancelFunc
-	db               *gorm.DB
-	rabbitMQConsumer *queue.RabbitMQConsumer
+	config        Config
+	logger        *slog.Logger
+	cupidAPI      ports.APIClientPort
+	gormRepo      ports.RepositoryPort
+	redisCache    ports.CachePort
+	redisLock     ports.LockPort
+	breakerState  ports.BreakerStatePort
+	geoEnrichment ports.GeoEnrichmentService
+	facilities    *facilities.Taxonomy
+	workerID      string
+	shutdownChan  chan os.Signal
+	ctx           context.Context
+	cancel        context.CancelFunc
+	consumeCtx    context.Context
+	consumeCancel context.CancelFunc
+	consumeDone   chan struct{}
+	draining      atomic.Bool
+	consuming     atomic.Bool
+	inFlight      atomic.Int64
+	pause         *pauseState
+	startedAt     time.Time
+	healthServer  *http.Server
+	metricsServer *http.Server
+	metrics       *worker.WorkerMetrics
+	db            *gorm.DB
+	consumer      queue.ConsumerPort
+	deadLetters   queue.PublisherPort
+	messageAges   messageAgeWindow
 }
 
-type queueMessage struct {
-	ID          string         `json:"id"`
-	MessageType string         `json:"type"`
-	Data        map[string]any `json:"data"`
-}
+const (
+	// hotelLeaseTTL bounds how long other workers defer to the worker fetching a hotel.
+	hotelLeaseTTL          = 60 * time.Second
+	hotelLeaseWaitTimeout  = 5 * time.Second
+	hotelLeasePollInterval = 500 * time.Millisecond
+
+	// retryBudgetFactor leaves room for the backoff delays on top of the API timeout of each
+	// retry.
+	retryBudgetFactor = 1.5
+)
+
+// Entity types label the worker metrics.
+const (
+	entityHotels       = "hotels"
+	entityReviews      = "reviews"
+	entityTranslations = "translations"
+)
 
 func (messageProcessor *MessageProcessor) getTTLConfigForEntity(messageType string) EntityTTLConfig {
 	switch messageType {
@@ -56,16 +94,31 @@ func (messageProcessor *MessageProcessor) getTTLConfigForEntity(messageType stri
 	}
 }
 
+// retryBudget bounds the time the Cupid API calls of a message spend retrying, so a hotel that
+// keeps failing does not hold the worker for every backoff of every attempt.
+func (messageProcessor *MessageProcessor) retryBudget() time.Duration {
+	apiTimeout := time.Duration(messageProcessor.config.APITimeoutSeconds) * time.Second
+	return time.Duration(float64(messageProcessor.config.CupidMaxRetryAttempts) * float64(apiTimeout) * retryBudgetFactor)
+}
+
 func NewMessageProcessor(config Config, db *gorm.DB, applicationLogger *slog.Logger) (*MessageProcessor, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	consumeCtx, consumeCancel := context.WithCancel(ctx)
 
 	server := &MessageProcessor{
-		config:       config,
-		db:           db,
-		logger:       applicationLogger,
-		shutdownChan: make(chan os.Signal, 1),
-		ctx:          ctx,
-		cancel:       cancel,
+		config:        config,
+		db:            db,
+		logger:        applicationLogger,
+		shutdownChan:  make(chan os.Signal, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+		consumeCtx:    consumeCtx,
+		consumeCancel: consumeCancel,
+		consumeDone:   make(chan struct{}),
+		workerID:      newWorkerID(),
+		metrics:       worker.NewWorkerMetrics(),
+		pause:         newPauseState(),
+		startedAt:     time.Now(),
 	}
 
 	if err := server.initializeServices(); err != nil {
@@ -77,9 +130,14 @@ func NewMessageProcessor(config Config, db *gorm.DB, applicationLogger *slog.Log
 
 func (messageProcessor *MessageProcessor) initializeServices() error {
 	apiConfig := &adapter.APIConfig{
-		BaseURL:       messageProcessor.config.CupidAPIURL,
-		APIKey:        messageProcessor.config.CupidAPIKey,
-		Timeout:       time.Duration(messageProcessor.config.APITimeoutSeconds) * time.Second,
+		BaseURL: messageProcessor.config.CupidAPIURL,
+		APIKey:  messageProcessor.config.CupidAPIKey,
+		Timeout: time.Duration(messageProcessor.config.APITimeoutSeconds) * time.Second,
+		TimeoutConfig: adapter.TimeoutConfig{
+			HotelFetch:       time.Duration(messageProcessor.config.HotelFetchTimeoutSeconds) * time.Second,
+			ReviewFetch:      time.Duration(messageProcessor.config.ReviewFetchTimeoutSeconds) * time.Second,
+			TranslationFetch: time.Duration(messageProcessor.config.TranslationFetchTimeoutSeconds) * time.Second,
+		},
 		RateLimit:     10.0,
 		BurstLimit:    20,
 		MaxRetries:    messageProcessor.config.CupidMaxRetryAttempts,
@@ -90,27 +148,72 @@ func (messageProcessor *MessageProcessor) initializeServices() error {
 			Interval:    60 * time.Second,
 			Timeout:     time.Duration(messageProcessor.config.CircuitBreakerResetSeconds) * time.Second,
 		},
+		OnResponse:       messageProcessor.metrics.ObserveCupidAPICall,
+		MaxResponseBytes: messageProcessor.config.CupidMaxResponseBytes,
+	}
+
+	redisAddr := fmt.Sprintf("%s:%d", messageProcessor.config.RedisHost, messageProcessor.config.RedisPort)
+	if sharedBreaker := messageProcessor.config.SharedCircuitBreaker; sharedBreaker.Enabled {
+		messageProcessor.breakerState = adapter.NewRedisBreakerStateAdapter(redisAddr, messageProcessor.config.RedisPassword, 0, sharedBreaker.Key)
+		apiConfig.SharedBreaker = messageProcessor.breakerState
+		apiConfig.SharedBreakerTTL = time.Duration(sharedBreaker.TTLSeconds) * time.Second
+		apiConfig.BreakerOwner = messageProcessor.workerID
+		apiConfig.OnSharedBreakerError = func(err error) {
+			messageProcessor.logger.Warn("Shared circuit breaker unavailable, using the local one", "error", err)
+		}
 	}
 	messageProcessor.cupidAPI = adapter.NewCupidAPIAdapter(apiConfig)
 
+	if messageProcessor.config.EnableGeoEnrichment {
+		messageProcessor.geoEnrichment = adapter.NewOverpassAdapter(adapter.OverpassConfig{
+			URL:     messageProcessor.config.OverpassAPIURL,
+			Timeout: time.Duration(messageProcessor.config.GeoEnrichmentTimeoutSeconds) * time.Second,
+		})
+	}
+
 	var err error
+	messageProcessor.facilities, err = facilities.New(messageProcessor.config.FacilityAliases)
+	if err != nil {
+		return fmt.Errorf("failed to build facility taxonomy: %w", err)
+	}
+
 	messageProcessor.gormRepo, err = adapter.NewGormRepository(messageProcessor.db)
 	if err != nil {
 		return fmt.Errorf("failed to create GORM repository: %w", err)
 	}
 
-	redisAddr := fmt.Sprintf("%s:%d", messageProcessor.config.RedisHost, messageProcessor.config.RedisPort)
 	messageProcessor.redisCache = adapter.NewRedisCacheAdapter(redisAddr, messageProcessor.config.RedisPassword, 0)
 	messageProcessor.redisLock = adapter.NewRedisLockAdapter(redisAddr, messageProcessor.config.RedisPassword, 0)
 
-	rabbitMQConfig := queue.NewRabbitMQConfigFromWorkerConfig(
-		messageProcessor.config.RabbitmqHost,
-		messageProcessor.config.RabbitmqUser,
-		messageProcessor.config.RabbitmqPassword,
-		messageProcessor.config.MainQueue,
-		messageProcessor.config.RabbitmqPort, messageProcessor.config.PrefetchCount, messageProcessor.config.MaxRetryAttempts,
-	)
-	messageProcessor.rabbitMQConsumer = queue.NewRabbitMQConsumer(rabbitMQConfig, messageProcessor.logger)
+	switch messageProcessor.config.MessageBroker {
+	case queue.BrokerNATS:
+		natsConsumer, err := queue.NewNATSConsumer(
+			&messageProcessor.config.NATS,
+			messageProcessor.config.MainQueue,
+			messageProcessor.config.PrefetchCount, messageProcessor.config.MaxRetryAttempts,
+			messageProcessor.logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create NATS consumer: %w", err)
+		}
+		messageProcessor.consumer = natsConsumer
+	case queue.BrokerRabbitMQ:
+		rabbitMQConfig := queue.NewRabbitMQConfigFromWorkerConfig(
+			messageProcessor.config.RabbitmqHost,
+			messageProcessor.config.RabbitmqUser,
+			messageProcessor.config.RabbitmqPassword,
+			messageProcessor.config.MainQueue,
+			messageProcessor.config.RabbitmqPort, messageProcessor.config.PrefetchCount, messageProcessor.config.MaxRetryAttempts,
+		)
+		messageProcessor.consumer = queue.NewRabbitMQConsumer(rabbitMQConfig, messageProcessor.logger)
+
+		messageProcessor.deadLetters, err = messageProcessor.newDeadLetterPublisher()
+		if err != nil {
+			return fmt.Errorf("failed to set up dead letter queue: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported message broker: %s", messageProcessor.config.MessageBroker)
+	}
 
 	return nil
 }
@@ -118,61 +221,299 @@ func (messageProcessor *MessageProcessor) initializeServices() error {
 func (messageProcessor *MessageProcessor) Start() error {
 	signal.Notify(messageProcessor.shutdownChan, syscall.SIGINT, syscall.SIGTERM)
 
+	messageProcessor.startHealthServer()
+	messageProcessor.startMetricsServer()
+	go messageProcessor.pollPauseFlag()
+
 	go func() {
+		defer close(messageProcessor.consumeDone)
 		figure.NewFigure("WORKER", "", true).Print()
-		messageProcessor.logger.Info("Starting message consumption")
+		messageProcessor.logger.Info("Starting message consumption", buildinfo.Get("worker").LogAttrs()...)
 		if err := messageProcessor.consumeMessages(); err != nil {
 			messageProcessor.logger.Error("Message consumption failed", "error", err)
 		}
 	}()
 
 	<-messageProcessor.shutdownChan
-	messageProcessor.logger.Info("Received shutdown signal, starting graceful shutdown")
+	messageProcessor.logger.Info("Received shutdown signal, draining in-flight messages")
+
+	messageProcessor.drain()
 
 	return messageProcessor.shutdown()
 }
 
+// drain stops new deliveries and waits for the in-flight message to be acked or nacked.
+// It gives up after the drain timeout or when a second shutdown signal arrives.
+func (messageProcessor *MessageProcessor) drain() {
+	messageProcessor.draining.Store(true)
+
+	messageProcessor.stopConsuming()
+	messageProcessor.consumeCancel()
+
+	drainTimeout := time.Duration(messageProcessor.config.DrainTimeoutSeconds) * time.Second
+	if drainTimeout <= 0 {
+		drainTimeout = 30 * time.Second
+	}
+
+	select {
+	case <-messageProcessor.consumeDone:
+		messageProcessor.logger.Info("In-flight messages drained")
+	case <-time.After(drainTimeout):
+		messageProcessor.logger.Warn("Drain timeout reached, forcing shutdown",
+			"drain_timeout", drainTimeout,
+			"in_flight", messageProcessor.inFlight.Load())
+	case <-messageProcessor.shutdownChan:
+		messageProcessor.logger.Warn("Received second shutdown signal, forcing shutdown",
+			"in_flight", messageProcessor.inFlight.Load())
+	}
+}
+
+// consumeMessages consumes until the worker shuts down, stopping the consumer while paused.
 func (messageProcessor *MessageProcessor) consumeMessages() error {
-	messages, err := messageProcessor.rabbitMQConsumer.Consume()
-	if err != nil {
-		return fmt.Errorf("failed to start consuming messages: %w", err)
+	for {
+		if !messageProcessor.waitWhilePaused() {
+			return nil
+		}
+
+		messages, err := messageProcessor.consumer.Consume()
+		if err != nil {
+			return fmt.Errorf("failed to start consuming messages: %w", err)
+		}
+		messageProcessor.consuming.Store(true)
+		messageProcessor.metrics.SetPaused(false)
+
+		paused, err := messageProcessor.consumeUntilPaused(messages)
+		if !paused {
+			return err
+		}
+
+		messageProcessor.metrics.SetPaused(true)
+		messageProcessor.logger.Info("Consumption paused")
 	}
+}
 
+// waitWhilePaused blocks until consumption is resumed. It returns false when the worker shuts
+// down first.
+func (messageProcessor *MessageProcessor) waitWhilePaused() bool {
 	for {
+		paused, changed := messageProcessor.pause.current()
+		if !paused {
+			return true
+		}
+
 		select {
-		case <-messageProcessor.ctx.Done():
-			return nil
+		case <-messageProcessor.consumeCtx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// consumeUntilPaused handles deliveries one at a time, so a pause only takes effect once the
+// message being processed is acked or nacked. Deliveries prefetched before the consumer was
+// stopped are requeued rather than processed. It reports whether it returned because of a pause.
+func (messageProcessor *MessageProcessor) consumeUntilPaused(messages <-chan amqp.Delivery) (bool, error) {
+	for {
+		paused, changed := messageProcessor.pause.current()
+		if paused {
+			messageProcessor.stopConsuming()
+			messageProcessor.requeueRemaining(messages)
+			return true, nil
+		}
+
+		select {
+		case <-messageProcessor.consumeCtx.Done():
+			return false, nil
+		case <-changed:
 		case msg, ok := <-messages:
 			if !ok {
-				return fmt.Errorf("message channel closed")
+				if messageProcessor.draining.Load() {
+					return false, nil
+				}
+				return false, fmt.Errorf("message channel closed")
 			}
 
-			if err := messageProcessor.processMessage(msg); err != nil {
-				messageProcessor.logger.Error("Failed to process message", "error", err)
-				messageProcessor.logger.Warn("Message discarded and sent to Dead Letter Queue (DLQ)",
-					"message_id", string(msg.Body),
-					"routing_key", msg.RoutingKey,
-					"error", err)
-				_ = msg.Nack(false, false)
-			} else {
-				_ = msg.Ack(false)
+			if messageProcessor.draining.Load() {
+				_ = msg.Nack(false, true)
+				continue
 			}
+
+			messageProcessor.handleDelivery(msg)
 		}
 	}
 }
 
-func (messageProcessor *MessageProcessor) processMessage(msg amqp.Delivery) error {
-	var message queueMessage
-	if err := json.Unmarshal(msg.Body, &message); err != nil {
-		return fmt.Errorf("failed to unmarshal message: %w", err)
+// requeueRemaining returns the deliveries left in messages to the queue until the broker
+// closes it after the consumer was stopped.
+func (messageProcessor *MessageProcessor) requeueRemaining(messages <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-messageProcessor.consumeCtx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			_ = msg.Nack(false, true)
+		}
 	}
+}
+
+// stopConsuming stops the consumer once, whether it is called on pause or on shutdown.
+func (messageProcessor *MessageProcessor) stopConsuming() {
+	if !messageProcessor.consuming.CompareAndSwap(true, false) {
+		return
+	}
+	if err := messageProcessor.consumer.StopConsuming(); err != nil {
+		messageProcessor.logger.Warn("Failed to stop consuming", "error", err)
+	}
+}
+
+func (messageProcessor *MessageProcessor) handleDelivery(msg amqp.Delivery) {
+	messageProcessor.inFlight.Add(1)
+	defer messageProcessor.inFlight.Add(-1)
+
+	err := messageProcessor.processMessage(msg)
+	switch {
+	case err == nil:
+		_ = msg.Ack(false)
+	case errors.Is(err, ports.ErrCircuitOpen):
+		messageProcessor.requeueOnOpenCircuit(msg, err)
+	default:
+		messageProcessor.logger.Error("Failed to process message", "error", err)
+		messageProcessor.deadLetter(msg, err)
+	}
+}
+
+// dlqReason classifies why a message was dead-lettered so rejected envelopes can be told apart
+// from failed jobs.
+func dlqReason(err error) string {
+	switch {
+	case errors.Is(err, messages.ErrUnsupportedVersion):
+		return "unsupported_version"
+	case errors.Is(err, messages.ErrInvalidMessage):
+		return "invalid_message"
+	case errors.Is(err, ports.ErrRetryBudgetExceeded):
+		return "retry_budget_exceeded"
+	case errors.Is(err, errProcessingPanic):
+		return "panic"
+	default:
+		return "processing_failed"
+	}
+}
+
+// errProcessingPanic marks a message whose processing panicked. It is dead-lettered like any
+// other permanent failure rather than taking the worker and its prefetched messages down.
+var errProcessingPanic = errors.New("panic while processing message")
+
+// recoverPanic turns a panic of processMessage into errProcessingPanic. It must be deferred
+// directly.
+func (messageProcessor *MessageProcessor) recoverPanic(msg amqp.Delivery, err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	messageProcessor.logger.Error("Recovered from panic while processing message",
+		"message", loggedBody(msg.Body),
+		"message_bytes", len(msg.Body),
+		"routing_key", msg.RoutingKey,
+		"panic", recovered,
+		"stack", string(debug.Stack()))
+	*err = fmt.Errorf("%w: %v", errProcessingPanic, recovered)
+}
+
+// maxLoggedBodyBytes caps the message bodies written to the logs. Bodies can be large and
+// carry guest data, and their id and type fit well within it.
+const maxLoggedBodyBytes = 256
+
+// loggedBody is the start of a message body, for logs.
+func loggedBody(body []byte) string {
+	if len(body) <= maxLoggedBodyBytes {
+		return string(body)
+	}
+	return strings.ToValidUTF8(string(body[:maxLoggedBodyBytes]), "") + "…"
+}
+
+func (messageProcessor *MessageProcessor) processMessage(msg amqp.Delivery) (err error) {
+	startTime := time.Now()
+	messageType, status := "unknown", worker.MessageStatusSkipped
+	defer func() {
+		if err != nil {
+			status = worker.MessageStatusError
+		}
+		messageProcessor.metrics.ObserveMessage(messageType, status, time.Since(startTime))
+	}()
+	defer messageProcessor.recoverPanic(msg, &err)
+
+	message, err := messages.Decode(msg.Body)
+	if err != nil {
+		return err
+	}
+	messageType = message.Type
+	messageProcessor.observeMessageAge(msg, messageType)
 
 	messageProcessor.logger.Info("Processing job",
 		"id", message.ID,
-		"fetch_type", message.MessageType)
+		"fetch_type", message.Type)
+
+	// Locks cover the logical resource a message writes to. Message IDs are row IDs of
+	// different tables, so locking on them would let e.g. fetch_review and update_review for
+	// the same hotel run concurrently against the same review rows.
+	var lockKey string
+	var process func(ctx context.Context) error
+	switch message.Type {
+	case constants.MessageTypeUpdateHotel:
+		payload, err := messages.DecodePayload[messages.HotelUpdatePayload](message)
+		if err != nil {
+			return err
+		}
+		lockKey = fmt.Sprintf("hotel_lock_%d", payload.HotelID)
+		process = func(ctx context.Context) error {
+			return messageProcessor.processHotelMessage(ctx, message, payload.HotelID)
+		}
+	case constants.MessageTypeUpdateReview:
+		payload, err := messages.DecodePayload[messages.ReviewUpdatePayload](message)
+		if err != nil {
+			return err
+		}
+		lockKey = fmt.Sprintf("reviews_lock_%d", payload.HotelID)
+		process = func(ctx context.Context) error {
+			return messageProcessor.processReviewsMessage(ctx, message, payload.HotelID)
+		}
+	case constants.MessageTypeFetchReview:
+		payload, err := messages.DecodePayload[messages.ReviewFetchPayload](message)
+		if err != nil {
+			return err
+		}
+		lockKey = fmt.Sprintf("reviews_lock_%d", payload.HotelID)
+		process = func(ctx context.Context) error {
+			return messageProcessor.processReviewsMessage(ctx, message, payload.HotelID)
+		}
+	case constants.MessageTypeUpdateTranslation:
+		payload, err := messages.DecodePayload[messages.TranslationUpdatePayload](message)
+		if err != nil {
+			return err
+		}
+		lang := messageProcessor.gormRepo.GetLangById(messageProcessor.ctx, payload.TranslationRowID)
+		lockKey = fmt.Sprintf("translations_lock_%d_%s", payload.HotelID, lang)
+		process = func(ctx context.Context) error {
+			return messageProcessor.processTranslationsMessage(ctx, message, payload.HotelID, lang)
+		}
+	case constants.MessageTypeFetchTranslation:
+		payload, err := messages.DecodePayload[messages.TranslationFetchPayload](message)
+		if err != nil {
+			return err
+		}
+		lockKey = fmt.Sprintf("translations_lock_%d_%s", payload.HotelID, payload.Lang)
+		process = func(ctx context.Context) error {
+			return messageProcessor.processTranslationsMessage(ctx, message, payload.HotelID, payload.Lang)
+		}
+	default:
+		messageProcessor.logger.Warn("Unknown fetch_type, skipping", "fetch_type", message.Type)
+		return nil
+	}
 
-	lockKey := fmt.Sprintf("hotel_lock_%s", message.ID)
-	entityTTL := messageProcessor.getTTLConfigForEntity(message.MessageType)
+	entityTTL := messageProcessor.getTTLConfigForEntity(message.Type)
 	lockTTL := time.Duration(entityTTL.LockSeconds) * time.Second
 	locked, err := messageProcessor.redisLock.Acquire(messageProcessor.ctx, lockKey, lockTTL)
 	if err != nil {
@@ -180,7 +521,7 @@ func (messageProcessor *MessageProcessor) processMessage(msg amqp.Delivery) erro
 		return fmt.Errorf("failed to acquire lock: %w", err)
 	}
 	if !locked {
-		messageProcessor.logger.Warn(fmt.Sprintf("%s is already being processed, skipping id %s", message.MessageType, message.ID))
+		messageProcessor.logger.Warn(fmt.Sprintf("%s is already being processed, skipping id %s", message.Type, message.ID))
 		return nil
 	}
 
@@ -190,58 +531,82 @@ func (messageProcessor *MessageProcessor) processMessage(msg amqp.Delivery) erro
 		}
 	}()
 
-	var processErr error
-	switch message.MessageType {
-	case constants.MessageTypeUpdateHotel:
-		processErr = messageProcessor.processHotelMessage(message)
-	case constants.MessageTypeUpdateReview, constants.MessageTypeFetchReview:
-		processErr = messageProcessor.processReviewsMessage(message)
-	case constants.MessageTypeUpdateTranslation, constants.MessageTypeFetchTranslation:
-		processErr = messageProcessor.processTranslationsMessage(message)
-	default:
-		messageProcessor.logger.Warn("Unknown fetch_type, skipping", "fetch_type", message.MessageType)
-		return nil
-	}
-	if processErr != nil {
-		return fmt.Errorf("failed to process %s job: %w", message.MessageType, processErr)
+	// The Cupid API calls of the message share one retry budget.
+	if err := process(ports.WithRetryBudget(messageProcessor.ctx, messageProcessor.retryBudget())); err != nil {
+		return fmt.Errorf("failed to process %s job: %w", message.Type, err)
 	}
+	status = worker.MessageStatusSuccess
 
 	messageProcessor.logger.Info("Successfully processed job",
 		"id", message.ID,
-		"fetch_type", message.MessageType)
+		"fetch_type", message.Type)
 
 	return nil
 }
 
-func (messageProcessor *MessageProcessor) processHotelMessage(message queueMessage) error {
+func (messageProcessor *MessageProcessor) processHotelMessage(ctx context.Context, message messages.Envelope, hotelId int64) error {
 	cacheKey := fmt.Sprintf("hotel_data_%s", message.ID)
 
 	var cachedData any
-	found, err := messageProcessor.redisCache.Get(messageProcessor.ctx, cacheKey, &cachedData)
+	found, err := messageProcessor.redisCache.Get(ctx, cacheKey, &cachedData)
 	if err == nil && found {
+		messageProcessor.metrics.CacheHit(entityHotels)
 		messageProcessor.logger.Info("Using cached hotel data", "id", message.ID)
 		return nil
 	}
+	messageProcessor.metrics.CacheMiss(entityHotels)
+
+	// The same hotel can be queued by several batches at once; only the lease holder calls the
+	// Cupid API, the others wait for it to populate the cache.
+	leaseKey := fmt.Sprintf("hotel_lease:%d", hotelId)
+	leased, err := messageProcessor.redisLock.AcquireLease(ctx, leaseKey, messageProcessor.workerID, hotelLeaseTTL)
+	if err != nil {
+		messageProcessor.logger.Warn("Failed to acquire hotel lease, fetching anyway", "hotel_id", hotelId, "error", err)
+	} else if !leased {
+		if _, ok := messageProcessor.waitForCachedResult(ctx, cacheKey, hotelLeaseWaitTimeout); ok {
+			messageProcessor.logger.Info("Hotel data populated by lease holder", "id", message.ID, "hotel_id", hotelId)
+			return nil
+		}
+		messageProcessor.logger.Warn("Timed out waiting for lease holder, fetching hotel data", "id", message.ID, "hotel_id", hotelId)
+	} else {
+		defer func() {
+			if err := messageProcessor.redisLock.ReleaseLease(ctx, leaseKey, messageProcessor.workerID); err != nil {
+				messageProcessor.logger.Warn("Failed to release hotel lease", "hotel_id", hotelId, "error", err)
+			}
+		}()
+	}
 
-	hotelId := messageProcessor.gormRepo.GetHotelIdByPk(messageProcessor.ctx, message.ID)
-	hotelAPIResponse, err := messageProcessor.cupidAPI.FetchHotelData(messageProcessor.ctx, hotelId)
+	hotelAPIResponse, err := messageProcessor.cupidAPI.FetchHotelData(ctx, hotelId)
+	if errors.Is(err, ports.ErrHotelRemoved) {
+		return messageProcessor.markHotelRemoved(message, hotelId, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to fetch hotel data: %w", err)
 	}
 
+	hotelAPIResponse.NormalizeFacilities(messageProcessor.facilities)
 	hotelData, err := hotelAPIResponse.ToHotelData()
 	if err != nil {
 		return fmt.Errorf("failed to convert hotel data: %w", err)
 	}
+	messageProcessor.logInvalidContactNumbers(hotelId, hotelAPIResponse)
 
-	hotelTTL := messageProcessor.getTTLConfigForEntity(message.MessageType)
+	hotelTTL := messageProcessor.getTTLConfigForEntity(message.Type)
 	hotelData.NextUpdateAt = time.Now().Add(time.Duration(hotelTTL.NextUpdateSeconds) * time.Second)
 
-	if err := messageProcessor.gormRepo.UpsertHotel(messageProcessor.ctx, hotelData); err != nil {
+	upsertStart := time.Now()
+	previousHotel, err := messageProcessor.gormRepo.UpsertHotel(ctx, hotelData, entities.SourceWrite{
+		Source:     entities.DataSourceCupidFetcher,
+		Priorities: messageProcessor.config.SourcePriorities,
+	})
+	if err != nil {
 		return fmt.Errorf("failed to persist hotel data: %w", err)
 	}
+	messageProcessor.metrics.ObserveUpsert(entityHotels, time.Since(upsertStart))
+	messageProcessor.recordHotelChanges(message, previousHotel, hotelData)
+	messageProcessor.enrichNearbyAttractions(hotelData)
 
-	if err := messageProcessor.redisCache.Set(messageProcessor.ctx, cacheKey, hotelAPIResponse, time.Duration(hotelTTL.CacheSeconds)*time.Second); err != nil {
+	if err := messageProcessor.redisCache.Set(ctx, cacheKey, hotelAPIResponse, time.Duration(hotelTTL.CacheSeconds)*time.Second); err != nil {
 		messageProcessor.logger.Warn("Failed to cache hotel data", "error", err)
 	}
 
@@ -249,44 +614,56 @@ func (messageProcessor *MessageProcessor) processHotelMessage(message queueMessa
 	return nil
 }
 
-func (messageProcessor *MessageProcessor) processReviewsMessage(message queueMessage) error {
+// logInvalidContactNumbers reports the phone and fax numbers that cannot be normalized to E.164.
+// They are stored raw only, so phone filters do not match them.
+func (messageProcessor *MessageProcessor) logInvalidContactNumbers(hotelId int64, hotelAPIResponse *dto.HotelAPIResponse) {
+	for field, number := range map[string]string{"phone": hotelAPIResponse.Phone, "fax": hotelAPIResponse.Fax} {
+		if number != "" && !phone.Valid(number, hotelAPIResponse.Address.Country) {
+			messageProcessor.logger.Debug("Contact number is not a valid phone number, excluded from phone filters",
+				"hotel_id", hotelId, "field", field, "number", number, "country", hotelAPIResponse.Address.Country)
+		}
+	}
+}
+
+// waitForCachedResult polls cacheKey until it is populated, timeout elapses or ctx is done.
+func (messageProcessor *MessageProcessor) waitForCachedResult(ctx context.Context, cacheKey string, timeout time.Duration) (any, bool) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(hotelLeasePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			return nil, false
+		case <-ticker.C:
+			var cached any
+			found, err := messageProcessor.redisCache.Get(waitCtx, cacheKey, &cached)
+			if err == nil && found {
+				return cached, true
+			}
+		}
+	}
+}
+
+func (messageProcessor *MessageProcessor) processReviewsMessage(ctx context.Context, message messages.Envelope, hotelId int64) error {
 	cacheKey := fmt.Sprintf("reviews_data_%s", message.ID)
 	var cached any
-	found, err := messageProcessor.redisCache.Get(messageProcessor.ctx, cacheKey, &cached)
+	found, err := messageProcessor.redisCache.Get(ctx, cacheKey, &cached)
 	if err == nil && found {
+		messageProcessor.metrics.CacheHit(entityReviews)
 		messageProcessor.logger.Info("Using cached reviews", "id", message.ID)
 		return nil
 	}
+	messageProcessor.metrics.CacheMiss(entityReviews)
 
-	var hotelId int64
-	var reviewCount int64
-
-	if message.MessageType == constants.MessageTypeFetchReview {
-		if message.Data == nil {
-			return fmt.Errorf("message data is nil")
-		}
-		hotelIdStr := message.Data[constants2.HotelId].(string)
-		hotelIdParsed, err := strconv.ParseInt(hotelIdStr, 10, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse hotel_id: %w", err)
-		}
-		hotelId = hotelIdParsed
-		reviewCount = 10
-	} else {
-		hotelId = messageProcessor.gormRepo.GetHotelIdFromReviewByPk(messageProcessor.ctx, message.ID)
-		if hotelId == 0 {
-			return nil
-		}
-
-		reviewCount = messageProcessor.gormRepo.ReviewCountByHotelId(messageProcessor.ctx, hotelId)
-		if reviewCount == 0 {
-			return nil
-		}
+	fetchOptions, target, ok, err := messageProcessor.reviewFetchOptions(message.Type, hotelId)
+	if err != nil || !ok {
+		return err
 	}
 
-	fetchedReviews, err := messageProcessor.cupidAPI.FetchHotelReviews(messageProcessor.ctx, hotelId, &dto.ReviewFetchOptions{
-		ReviewCount: reviewCount,
-	})
+	fetchedReviews, err := messageProcessor.cupidAPI.FetchHotelReviews(ctx, hotelId, &fetchOptions)
 	if err != nil {
 		return fmt.Errorf("failed to fetch reviews: %w", err)
 	}
@@ -296,22 +673,53 @@ func (messageProcessor *MessageProcessor) processReviewsMessage(message queueMes
 		return fmt.Errorf("failed to convert reviews: %w", err)
 	}
 
-	reviewsTTL := messageProcessor.getTTLConfigForEntity("reviews")
+	unparsedDates := 0
+	for _, review := range mappedReviews {
+		if review.HasUnparsedDate() {
+			unparsedDates++
+			messageProcessor.logger.Warn("Unparseable review date", "hotel_id", hotelId, "review_id", review.ReviewID, "raw_date", review.RawDate)
+		}
+	}
+	if unparsedDates > 0 {
+		messageProcessor.logger.Warn("Reviews stored without a parsed date", "hotel_id", hotelId, "count", unparsedDates, "total", len(mappedReviews))
+	}
+
+	reviewsTTL := messageProcessor.getTTLConfigForEntity(entityReviews)
+	upsertStart := time.Now()
+	deduplicated := 0
 	for _, review := range mappedReviews {
 		review.NextUpdateAt = time.Now().Add(time.Duration(reviewsTTL.NextUpdateSeconds) * time.Second)
-		if existing, err := messageProcessor.gormRepo.GetReviewByReviewID(messageProcessor.ctx, review.ReviewID); err == nil && existing != nil && existing.ID != "" {
+		if existing, err := messageProcessor.gormRepo.GetReviewByReviewID(ctx, review.ReviewID); err == nil && existing != nil && existing.ID != "" {
 			review.ID = existing.ID
-			if err := messageProcessor.gormRepo.UpdateReview(messageProcessor.ctx, review); err != nil {
+			if err := messageProcessor.gormRepo.UpdateReview(ctx, review); err != nil {
 				return fmt.Errorf("failed to update review %d: %w", review.ReviewID, err)
 			}
+		} else if duplicate := messageProcessor.findDuplicateReview(review); duplicate != nil {
+			// The same review under another review id: the stored row adopts the new id and source,
+			// unless the id is still held by a row the review dedupe task soft-deleted.
+			if merged, err := messageProcessor.gormRepo.IsReviewMerged(ctx, review.ReviewID); err != nil || merged {
+				deduplicated++
+				continue
+			}
+			review.ID = duplicate.ID
+			review.CreatedAt = duplicate.CreatedAt
+			if err := messageProcessor.gormRepo.UpdateReview(ctx, review); err != nil {
+				return fmt.Errorf("failed to update duplicate review %d: %w", review.ReviewID, err)
+			}
+			deduplicated++
 		} else {
-			if err := messageProcessor.gormRepo.CreateReview(messageProcessor.ctx, review); err != nil {
+			if err := messageProcessor.gormRepo.CreateReview(ctx, review); err != nil {
 				return fmt.Errorf("failed to create review %d: %w", review.ReviewID, err)
 			}
 		}
 	}
+	messageProcessor.metrics.ObserveUpsert(entityReviews, time.Since(upsertStart))
+	if deduplicated > 0 {
+		messageProcessor.logger.Info("Merged reviews already stored under another review id", "hotel_id", hotelId, "count", deduplicated)
+	}
+	messageProcessor.recordReviewFetch(hotelId, target, fetchOptions, len(*fetchedReviews))
 
-	if err := messageProcessor.redisCache.Set(messageProcessor.ctx, cacheKey, fetchedReviews, time.Duration(reviewsTTL.CacheSeconds)*time.Second); err != nil {
+	if err := messageProcessor.redisCache.Set(ctx, cacheKey, fetchedReviews, time.Duration(reviewsTTL.CacheSeconds)*time.Second); err != nil {
 		messageProcessor.logger.Warn("Failed to cache reviews", "error", err)
 	}
 
@@ -320,33 +728,35 @@ func (messageProcessor *MessageProcessor) processReviewsMessage(message queueMes
 	return nil
 }
 
-func (messageProcessor *MessageProcessor) processTranslationsMessage(message queueMessage) error {
+// findDuplicateReview returns the stored review of the same hotel with the same content, or nil.
+func (messageProcessor *MessageProcessor) findDuplicateReview(review *entities.ReviewData) *entities.ReviewData {
+	existing, err := messageProcessor.gormRepo.GetReviewByFingerprint(messageProcessor.ctx, review.HotelID, review.ContentFingerprint())
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			messageProcessor.logger.Warn("Failed to look up review by fingerprint", "hotel_id", review.HotelID, "review_id", review.ReviewID, "error", err)
+		}
+		return nil
+	}
+	return existing
+}
+
+func (messageProcessor *MessageProcessor) processTranslationsMessage(ctx context.Context, message messages.Envelope, hotelId int64, lang string) error {
 	cacheKey := fmt.Sprintf("translations_data_%s", message.ID)
 
 	var cachedData any
-	found, err := messageProcessor.redisCache.Get(messageProcessor.ctx, cacheKey, &cachedData)
+	found, err := messageProcessor.redisCache.Get(ctx, cacheKey, &cachedData)
 	if err == nil && found {
+		messageProcessor.metrics.CacheHit(entityTranslations)
 		messageProcessor.logger.Info("Using cached translations data", "id", message.ID)
 		return nil
 	}
-
-	if message.Data == nil {
-		return fmt.Errorf("message data is nil")
-	}
-
-	hotelId := message.Data[constants2.HotelId].(string)
-	lang := ""
-	if message.MessageType == constants.MessageTypeFetchTranslation {
-		lang = message.Data[constants2.Lang].(string)
-	} else if message.MessageType == constants.MessageTypeUpdateTranslation {
-		lang = messageProcessor.gormRepo.GetLangById(messageProcessor.ctx, message.ID)
-	}
+	messageProcessor.metrics.CacheMiss(entityTranslations)
 
 	if lang == "" {
 		return fmt.Errorf("lang is empty")
 	}
 
-	translationsAPIResponse, err := messageProcessor.cupidAPI.FetchTranslations(messageProcessor.ctx, hotelId, &dto.TranslationFetchOptions{
+	translationsAPIResponse, err := messageProcessor.cupidAPI.FetchTranslations(ctx, strconv.FormatInt(hotelId, 10), &dto.TranslationFetchOptions{
 		Lang: lang,
 	})
 	if err != nil {
@@ -358,14 +768,16 @@ func (messageProcessor *MessageProcessor) processTranslationsMessage(message que
 		return fmt.Errorf("failed to convert translations data: %w", err)
 	}
 
-	translationsTTL := messageProcessor.getTTLConfigForEntity("translations")
+	translationsTTL := messageProcessor.getTTLConfigForEntity(entityTranslations)
 	translationsData.NextUpdateAt = time.Now().Add(time.Duration(translationsTTL.NextUpdateSeconds) * time.Second)
 
-	if err := messageProcessor.gormRepo.UpsertHotelTranslations(messageProcessor.ctx, translationsData); err != nil {
+	upsertStart := time.Now()
+	if err := messageProcessor.gormRepo.UpsertHotelTranslations(ctx, translationsData); err != nil {
 		return fmt.Errorf("failed to persist translations data: %w", err)
 	}
+	messageProcessor.metrics.ObserveUpsert(entityTranslations, time.Since(upsertStart))
 
-	if err := messageProcessor.redisCache.Set(messageProcessor.ctx, cacheKey, translationsAPIResponse, time.Duration(translationsTTL.CacheSeconds)*time.Second); err != nil {
+	if err := messageProcessor.redisCache.Set(ctx, cacheKey, translationsAPIResponse, time.Duration(translationsTTL.CacheSeconds)*time.Second); err != nil {
 		messageProcessor.logger.Warn("Failed to cache translations data", "error", err)
 	}
 	messageProcessor.logger.Info(fmt.Sprintf("Successfully processed and persisted translations data: id --> %s, lang --> %s next_update_at --> %s", message.ID, lang, translationsData.NextUpdateAt.Format(time.RFC3339)))
@@ -377,8 +789,24 @@ func (messageProcessor *MessageProcessor) shutdown() error {
 	messageProcessor.logger.Info("Shutting down worker server")
 	messageProcessor.cancel()
 
-	if messageProcessor.rabbitMQConsumer != nil {
-		_ = messageProcessor.rabbitMQConsumer.Close()
+	if messageProcessor.healthServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = messageProcessor.healthServer.Shutdown(ctx)
+	}
+
+	if messageProcessor.metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = messageProcessor.metricsServer.Shutdown(ctx)
+	}
+
+	if messageProcessor.consumer != nil {
+		_ = messageProcessor.consumer.Close()
+	}
+
+	if messageProcessor.deadLetters != nil {
+		messageProcessor.deadLetters.Close()
 	}
 
 	if messageProcessor.redisCache != nil {
@@ -389,6 +817,25 @@ func (messageProcessor *MessageProcessor) shutdown() error {
 		_ = messageProcessor.redisLock.Close()
 	}
 
+	if messageProcessor.breakerState != nil {
+		if cupidAPI, ok := messageProcessor.cupidAPI.(*adapter.CupidAPIAdapter); ok {
+			cupidAPI.WaitForBreakerState()
+		}
+		_ = messageProcessor.breakerState.Close()
+	}
+
+	if sqlDB, err := messageProcessor.db.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+
 	messageProcessor.logger.Info("Worker server shutdown complete")
 	return nil
 }
+
+func newWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "worker"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+// fakeAcknowledger records how each delivery was settled.
+type fakeAcknowledger struct {
+	mu       sync.Mutex
+	acked    []uint64
+	nacked   []uint64
+	requeued []uint64
+	settled  chan uint64
+}
+
+func newFakeAcknowledger() *fakeAcknowledger {
+	return &fakeAcknowledger{settled: make(chan uint64, 16)}
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, _ bool) error {
+	a.mu.Lock()
+	a.acked = append(a.acked, tag)
+	a.mu.Unlock()
+	a.settled <- tag
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, _, requeue bool) error {
+	a.mu.Lock()
+	a.nacked = append(a.nacked, tag)
+	if requeue {
+		a.requeued = append(a.requeued, tag)
+	}
+	a.mu.Unlock()
+	a.settled <- tag
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func (a *fakeAcknowledger) ackedTags() []uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]uint64(nil), a.acked...)
+}
+
+func (a *fakeAcknowledger) nackedTags() []uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]uint64(nil), a.nacked...)
+}
+
+func (a *fakeAcknowledger) requeuedTags() []uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]uint64(nil), a.requeued...)
+}
+
+// fakeConsumer hands out one delivery channel and closes it when consuming stops, as the
+// broker does once the consumer is cancelled.
+type fakeConsumer struct {
+	deliveries chan amqp.Delivery
+	stopOnce   sync.Once
+}
+
+func (c *fakeConsumer) Consume() (<-chan amqp.Delivery, error) { return c.deliveries, nil }
+func (c *fakeConsumer) StopConsuming() error {
+	c.stopOnce.Do(func() { close(c.deliveries) })
+	return nil
+}
+func (c *fakeConsumer) Close() error       { return nil }
+func (c *fakeConsumer) HealthCheck() error { return nil }
+
+// slowLock holds every lock acquisition for delay, standing in for a slow message. It never
+// grants the lock, so the message is skipped and acked once the delay elapses.
+type slowLock struct {
+	delay    time.Duration
+	started  chan string
+	mu       sync.Mutex
+	acquired []string
+}
+
+func (l *slowLock) Acquire(ctx context.Context, key string, _ time.Duration) (bool, error) {
+	l.mu.Lock()
+	l.acquired = append(l.acquired, key)
+	l.mu.Unlock()
+	l.started <- key
+	time.Sleep(l.delay)
+	return false, nil
+}
+
+func (l *slowLock) Release(context.Context, string) error { return nil }
+func (l *slowLock) AcquireLease(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+func (l *slowLock) ReleaseLease(context.Context, string, string) error { return nil }
+func (l *slowLock) Close() error                                       { return nil }
+
+func (l *slowLock) keys() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.acquired...)
+}
+
+func newDrainTestProcessor(t *testing.T, consumer *fakeConsumer, lock *slowLock, drainTimeoutSeconds int) *MessageProcessor {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumeCtx, consumeCancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+
+	return &MessageProcessor{
+		config:        Config{DrainTimeoutSeconds: drainTimeoutSeconds},
+		logger:        slog.New(slog.DiscardHandler),
+		redisLock:     lock,
+		consumer:      consumer,
+		shutdownChan:  make(chan os.Signal, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+		consumeCtx:    consumeCtx,
+		consumeCancel: consumeCancel,
+		consumeDone:   make(chan struct{}),
+		metrics:       worker.NewWorkerMetrics(),
+		pause:         newPauseState(),
+	}
+}
+
+func hotelDelivery(t *testing.T, acknowledger amqp.Acknowledger, tag uint64, hotelID int64) amqp.Delivery {
+	t.Helper()
+	body, err := json.Marshal(messages.NewHotelUpdate("row", hotelID))
+	require.NoError(t, err)
+	return amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: tag, Body: body}
+}
+
+func startConsuming(messageProcessor *MessageProcessor) {
+	go func() {
+		defer close(messageProcessor.consumeDone)
+		_ = messageProcessor.consumeMessages()
+	}()
+}
+
+func TestDrainCompletesInFlightMessage(t *testing.T) {
+	acknowledger := newFakeAcknowledger()
+	consumer := &fakeConsumer{deliveries: make(chan amqp.Delivery, 2)}
+	lock := &slowLock{delay: 200 * time.Millisecond, started: make(chan string, 2)}
+	messageProcessor := newDrainTestProcessor(t, consumer, lock, 5)
+
+	consumer.deliveries <- hotelDelivery(t, acknowledger, 1, 101)
+	consumer.deliveries <- hotelDelivery(t, acknowledger, 2, 102)
+	startConsuming(messageProcessor)
+
+	<-lock.started
+	messageProcessor.drain()
+
+	assert.True(t, messageProcessor.draining.Load())
+	assert.Equal(t, []uint64{1}, acknowledger.ackedTags())
+	assert.Equal(t, []string{"hotel_lock_101"}, lock.keys())
+	assert.Zero(t, messageProcessor.inFlight.Load())
+}
+
+func TestDrainStopsOnSecondSignal(t *testing.T) {
+	acknowledger := newFakeAcknowledger()
+	consumer := &fakeConsumer{deliveries: make(chan amqp.Delivery, 1)}
+	lock := &slowLock{delay: 2 * time.Second, started: make(chan string, 1)}
+	messageProcessor := newDrainTestProcessor(t, consumer, lock, 30)
+
+	consumer.deliveries <- hotelDelivery(t, acknowledger, 1, 101)
+	startConsuming(messageProcessor)
+
+	<-lock.started
+	messageProcessor.shutdownChan <- os.Interrupt
+
+	start := time.Now()
+	messageProcessor.drain()
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, acknowledger.ackedTags())
+	assert.Equal(t, int64(1), messageProcessor.inFlight.Load())
+}
+
+func TestDLQReason(t *testing.T) {
+	_, unsupported := messages.Decode([]byte(`{"version":9,"id":"row-1","type":"update_hotel","payload":{}}`))
+	_, invalid := messages.Decode([]byte(`{"version":1,"type":"update_hotel"}`))
+
+	assert.Equal(t, "unsupported_version", dlqReason(unsupported))
+	assert.Equal(t, "invalid_message", dlqReason(invalid))
+	assert.Equal(t, "panic", dlqReason(fmt.Errorf("%w: nil map", errProcessingPanic)))
+	assert.Equal(t, "processing_failed", dlqReason(errors.New("connection reset")))
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/victoragudo/hotel-management-system/pkg/queue"
+)
+
+const (
+	queueDepthInterval    = 30 * time.Second
+	queueDepthTimeout     = 5 * time.Second
+	defaultManagementPort = 15672
+)
+
+func (messageProcessor *MessageProcessor) startMetricsServer() {
+	if messageProcessor.config.MetricsPort == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(messageProcessor.metrics.Registry, promhttp.HandlerOpts{}))
+	// The metrics port is not exposed outside the cluster, so the admin endpoints share it.
+	mux.HandleFunc("/pause", messageProcessor.handlePause)
+	mux.HandleFunc("/resume", messageProcessor.handleResume)
+	mux.HandleFunc("/status", messageProcessor.handleStatus)
+
+	messageProcessor.metricsServer = &http.Server{
+		Addr:              fmt.Sprintf(":%d", messageProcessor.config.MetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		messageProcessor.logger.Info("Starting worker metrics listener", "port", messageProcessor.config.MetricsPort)
+		if err := messageProcessor.metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			messageProcessor.logger.Error("Worker metrics listener failed", "error", err)
+		}
+	}()
+
+	if messageProcessor.config.MessageBroker == queue.BrokerRabbitMQ {
+		go messageProcessor.pollQueueDepth(messageProcessor.ctx)
+	}
+}
+
+// pollQueueDepth reads the main queue depth from the RabbitMQ management API until ctx is done.
+func (messageProcessor *MessageProcessor) pollQueueDepth(ctx context.Context) {
+	client := &http.Client{Timeout: queueDepthTimeout}
+	ticker := time.NewTicker(queueDepthInterval)
+	defer ticker.Stop()
+
+	for {
+		depth, err := messageProcessor.fetchQueueDepth(ctx, client)
+		if err != nil {
+			messageProcessor.logger.Warn("Failed to read queue depth", "queue", messageProcessor.config.MainQueue, "error", err)
+		} else {
+			messageProcessor.metrics.SetQueueDepth(depth)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (messageProcessor *MessageProcessor) fetchQueueDepth(ctx context.Context, client *http.Client) (int, error) {
+	port := messageProcessor.config.RabbitmqManagementPort
+	if port == 0 {
+		port = defaultManagementPort
+	}
+	endpoint := fmt.Sprintf("http://%s:%d/api/queues/%s/%s",
+		messageProcessor.config.RabbitmqHost, port, url.PathEscape("/"), url.PathEscape(messageProcessor.config.MainQueue))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.SetBasicAuth(messageProcessor.config.RabbitmqUser, messageProcessor.config.RabbitmqPassword)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("management API request failed: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("management API returned status %d", response.StatusCode)
+	}
+
+	var queueInfo struct {
+		Messages int `json:"messages"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&queueInfo); err != nil {
+		return 0, fmt.Errorf("failed to decode queue info: %w", err)
+	}
+
+	return queueInfo.Messages, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grantingLock grants every lock, so messages are processed.
+type grantingLock struct{ slowLock }
+
+func (l *grantingLock) Acquire(context.Context, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func TestPanickingMessageIsDeadLetteredAndTheWorkerContinues(t *testing.T) {
+	acknowledger := newFakeAcknowledger()
+	consumer := &fakeConsumer{deliveries: make(chan amqp.Delivery, 3)}
+	messageProcessor := newDrainTestProcessor(t, consumer, nil, 5)
+	messageProcessor.redisLock = &grantingLock{}
+	var logs bytes.Buffer
+	messageProcessor.logger = slog.New(slog.NewJSONHandler(&logs, nil))
+
+	// The processor has no cache, so processing a hotel panics on a nil interface.
+	panicking := hotelDelivery(t, acknowledger, 1, 101)
+	panicking.Body = append(panicking.Body[:len(panicking.Body)-1], []byte(`,"guest_note":"`+strings.Repeat("x", 4096)+`"}`)...)
+	consumer.deliveries <- panicking
+	consumer.deliveries <- hotelDelivery(t, acknowledger, 2, 102)
+	consumer.deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 3, Body: []byte(`{"version":1,"id":"row-3","type":"unknown_type","payload":{}}`)}
+	startConsuming(messageProcessor)
+
+	for tag := uint64(1); tag <= 3; tag++ {
+		waitSettled(t, acknowledger, tag)
+	}
+	assert.Equal(t, []uint64{1, 2}, acknowledger.nackedTags(), "panicking messages are dead-lettered")
+	assert.Empty(t, acknowledger.requeuedTags())
+	assert.Equal(t, []uint64{3}, acknowledger.ackedTags(), "the worker keeps consuming after a panic")
+
+	var recovered map[string]any
+	for line := range strings.Lines(logs.String()) {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["msg"] == "Recovered from panic while processing message" {
+			recovered = entry
+			break
+		}
+	}
+	require.NotNil(t, recovered, "the panic is logged")
+	assert.Contains(t, recovered["panic"], "nil pointer dereference")
+	assert.NotEmpty(t, recovered["stack"])
+	assert.LessOrEqual(t, len(recovered["message"].(string)), maxLoggedBodyBytes+len("…"), "the logged body is truncated")
+	assert.EqualValues(t, len(panicking.Body), recovered["message_bytes"])
+	assert.NotContains(t, logs.String(), strings.Repeat("x", maxLoggedBodyBytes), "no log carries the full body")
+}
+
+func TestMalformedMessageDataIsDeadLettered(t *testing.T) {
+	bodies := map[string]string{
+		"hotel id as an object":   `{"id":"row-1","type":"update_hotel","data":{"hotel_id":{"value":7}}}`,
+		"hotel id not a number":   `{"id":"row-1","type":"update_hotel","data":{"hotel_id":"seven"}}`,
+		"hotel id with fraction":  `{"id":"row-1","type":"update_hotel","data":{"hotel_id":7.5}}`,
+		"hotel id missing":        `{"id":"row-1","type":"update_hotel","data":{"lang":"es"}}`,
+		"data missing":            `{"id":"row-1","type":"update_hotel"}`,
+		"language not a string":   `{"id":"row-1","type":"fetch_translation","data":{"hotel_id":7,"lang":["es"]}}`,
+		"data not an object":      `{"id":"row-1","type":"update_hotel","data":[7]}`,
+		"body not a json message": `hotel 7`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			acknowledger := newFakeAcknowledger()
+			consumer := &fakeConsumer{deliveries: make(chan amqp.Delivery, 2)}
+			messageProcessor := newDrainTestProcessor(t, consumer, &slowLock{started: make(chan string, 2)}, 5)
+
+			consumer.deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 1, Body: []byte(body)}
+			consumer.deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 2, Body: []byte(`{"version":1,"id":"row-2","type":"unknown_type","payload":{}}`)}
+			startConsuming(messageProcessor)
+
+			waitSettled(t, acknowledger, 1)
+			waitSettled(t, acknowledger, 2)
+			assert.Equal(t, []uint64{1}, acknowledger.nackedTags())
+			assert.Empty(t, acknowledger.requeuedTags())
+			assert.Equal(t, []uint64{2}, acknowledger.ackedTags())
+		})
+	}
+}
+
+func TestLoggedBody(t *testing.T) {
+	assert.Equal(t, `{"id":"row-1"}`, loggedBody([]byte(`{"id":"row-1"}`)))
+
+	long := strings.Repeat("é", maxLoggedBodyBytes)
+	logged := loggedBody([]byte(long))
+	assert.True(t, strings.HasSuffix(logged, "…"))
+	assert.Equal(t, strings.Repeat("é", maxLoggedBodyBytes/2)+"…", logged, "characters are not split")
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPauseFlagKey      = "worker:paused"
+	defaultPausePollInterval = 10 * time.Second
+
+	pauseScopeFleet = "fleet"
+)
+
+// pauseState tracks whether consumption is paused, either on this worker through the admin
+// endpoints or on the whole fleet through the Redis flag. Consumption resumes once neither
+// is set.
+type pauseState struct {
+	mu      sync.Mutex
+	local   bool
+	fleet   bool
+	since   time.Time
+	changed chan struct{}
+}
+
+func newPauseState() *pauseState {
+	return &pauseState{changed: make(chan struct{})}
+}
+
+// current returns whether consumption is paused and a channel closed on the next change.
+func (p *pauseState) current() (bool, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.local || p.fleet, p.changed
+}
+
+func (p *pauseState) setLocal(paused bool) {
+	p.set(&p.local, paused)
+}
+
+func (p *pauseState) setFleet(paused bool) {
+	p.set(&p.fleet, paused)
+}
+
+func (p *pauseState) set(flag *bool, paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if *flag == paused {
+		return
+	}
+
+	wasPaused := p.local || p.fleet
+	*flag = paused
+	isPaused := p.local || p.fleet
+	if wasPaused == isPaused {
+		return
+	}
+
+	if isPaused {
+		p.since = time.Now()
+	} else {
+		p.since = time.Time{}
+	}
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+func (p *pauseState) snapshot() (local, fleet bool, since time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.local, p.fleet, p.since
+}
+
+type workerStatusResponse struct {
+	Status        string     `json:"status"`
+	PausedLocally bool       `json:"paused_locally"`
+	PausedByFleet bool       `json:"paused_by_fleet"`
+	PausedSince   *time.Time `json:"paused_since,omitempty"`
+	InFlight      int64      `json:"in_flight"`
+	StartedAt     time.Time  `json:"started_at"`
+	UptimeSeconds int64      `json:"uptime_seconds"`
+}
+
+// pollPauseFlag follows the fleet pause flag in Redis until the worker shuts down. A failed
+// read keeps the last known state.
+func (messageProcessor *MessageProcessor) pollPauseFlag() {
+	interval := time.Duration(messageProcessor.config.PausePollSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultPausePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var paused bool
+		if _, err := messageProcessor.redisCache.Get(messageProcessor.ctx, messageProcessor.pauseFlagKey(), &paused); err != nil {
+			messageProcessor.logger.Warn("Failed to read fleet pause flag", "key", messageProcessor.pauseFlagKey(), "error", err)
+		} else {
+			messageProcessor.pause.setFleet(paused)
+		}
+
+		select {
+		case <-messageProcessor.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (messageProcessor *MessageProcessor) pauseFlagKey() string {
+	if messageProcessor.config.PauseFlagKey == "" {
+		return defaultPauseFlagKey
+	}
+	return messageProcessor.config.PauseFlagKey
+}
+
+// handlePause stops pulling new deliveries. The message being processed is finished first.
+// With scope=fleet the Redis flag is set instead, pausing every worker on its next poll.
+func (messageProcessor *MessageProcessor) handlePause(w http.ResponseWriter, r *http.Request) {
+	messageProcessor.setPaused(w, r, true)
+}
+
+// handleResume restarts consumption. With scope=fleet the Redis flag is cleared instead.
+func (messageProcessor *MessageProcessor) handleResume(w http.ResponseWriter, r *http.Request) {
+	messageProcessor.setPaused(w, r, false)
+}
+
+func (messageProcessor *MessageProcessor) setPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch scope := r.URL.Query().Get("scope"); scope {
+	case "":
+		messageProcessor.pause.setLocal(paused)
+	case pauseScopeFleet:
+		if err := messageProcessor.redisCache.Set(r.Context(), messageProcessor.pauseFlagKey(), paused, 0); err != nil {
+			messageProcessor.logger.Error("Failed to set fleet pause flag", "paused", paused, "error", err)
+			http.Error(w, "failed to set fleet pause flag", http.StatusBadGateway)
+			return
+		}
+		messageProcessor.pause.setFleet(paused)
+	default:
+		http.Error(w, "scope must be empty or fleet", http.StatusBadRequest)
+		return
+	}
+
+	messageProcessor.logger.Info("Consumption pause changed", "paused", paused, "scope", r.URL.Query().Get("scope"))
+	messageProcessor.handleStatus(w, r)
+}
+
+func (messageProcessor *MessageProcessor) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	local, fleet, since := messageProcessor.pause.snapshot()
+	response := workerStatusResponse{
+		Status:        "active",
+		PausedLocally: local,
+		PausedByFleet: fleet,
+		InFlight:      messageProcessor.inFlight.Load(),
+		StartedAt:     messageProcessor.startedAt,
+		UptimeSeconds: int64(time.Since(messageProcessor.startedAt).Seconds()),
+	}
+
+	switch {
+	case messageProcessor.draining.Load():
+		response.Status = "draining"
+	case local || fleet:
+		response.Status = "paused"
+		response.PausedSince = &since
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
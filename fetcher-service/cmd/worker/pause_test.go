@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+)
+
+// resumableConsumer hands out a new delivery channel on every Consume, as a new consumer tag
+// does on the broker. Stopping it delivers the prefetched messages and closes the channel.
+type resumableConsumer struct {
+	mu         sync.Mutex
+	deliveries chan amqp.Delivery
+	prefetched []amqp.Delivery
+	consumed   chan chan amqp.Delivery
+	stops      int
+}
+
+func newResumableConsumer() *resumableConsumer {
+	return &resumableConsumer{consumed: make(chan chan amqp.Delivery, 4)}
+}
+
+func (c *resumableConsumer) Consume() (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deliveries = make(chan amqp.Delivery, 8)
+	c.consumed <- c.deliveries
+	return c.deliveries, nil
+}
+
+func (c *resumableConsumer) StopConsuming() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, msg := range c.prefetched {
+		c.deliveries <- msg
+	}
+	c.prefetched = nil
+	close(c.deliveries)
+	c.stops++
+	return nil
+}
+
+func (c *resumableConsumer) Close() error       { return nil }
+func (c *resumableConsumer) HealthCheck() error { return nil }
+
+func (c *resumableConsumer) stopCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stops
+}
+
+// nextConsume waits for the worker to start consuming and returns the delivery channel.
+func (c *resumableConsumer) nextConsume(t *testing.T) chan amqp.Delivery {
+	t.Helper()
+	select {
+	case deliveries := <-c.consumed:
+		return deliveries
+	case <-time.After(2 * time.Second):
+		t.Fatal("the worker did not start consuming")
+		return nil
+	}
+}
+
+func newPauseTestProcessor(t *testing.T, consumer *resumableConsumer) (*MessageProcessor, *slowLock) {
+	t.Helper()
+	lock := &slowLock{started: make(chan string, 16)}
+	messageProcessor := newDrainTestProcessor(t, nil, lock, 5)
+	messageProcessor.consumer = consumer
+	t.Cleanup(messageProcessor.consumeCancel)
+	return messageProcessor, lock
+}
+
+// waitSettled waits for the delivery with tag to be acked or nacked.
+func waitSettled(t *testing.T, acknowledger *fakeAcknowledger, tag uint64) {
+	t.Helper()
+	select {
+	case settled := <-acknowledger.settled:
+		require.Equal(t, tag, settled)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("delivery %d was not settled", tag)
+	}
+}
+
+func TestPauseStopsConsumingAndResumeRestartsIt(t *testing.T) {
+	acknowledger := newFakeAcknowledger()
+	consumer := newResumableConsumer()
+	messageProcessor, lock := newPauseTestProcessor(t, consumer)
+	startConsuming(messageProcessor)
+
+	deliveries := consumer.nextConsume(t)
+	deliveries <- hotelDelivery(t, acknowledger, 1, 101)
+	waitSettled(t, acknowledger, 1)
+
+	consumer.mu.Lock()
+	consumer.prefetched = []amqp.Delivery{hotelDelivery(t, acknowledger, 2, 102)}
+	consumer.mu.Unlock()
+	messageProcessor.pause.setLocal(true)
+	waitSettled(t, acknowledger, 2)
+
+	assert.Equal(t, 1, consumer.stopCount())
+	assert.Equal(t, []uint64{2}, acknowledger.nackedTags(), "a prefetched delivery is requeued while paused")
+	select {
+	case <-consumer.consumed:
+		t.Fatal("the worker consumed while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	messageProcessor.pause.setLocal(false)
+	deliveries = consumer.nextConsume(t)
+	deliveries <- hotelDelivery(t, acknowledger, 3, 103)
+	waitSettled(t, acknowledger, 3)
+
+	assert.Equal(t, []uint64{1, 3}, acknowledger.ackedTags())
+	assert.Equal(t, []string{"hotel_lock_101", "hotel_lock_103"}, lock.keys())
+}
+
+func TestPauseLetsTheInFlightMessageFinish(t *testing.T) {
+	acknowledger := newFakeAcknowledger()
+	consumer := newResumableConsumer()
+	messageProcessor, lock := newPauseTestProcessor(t, consumer)
+	lock.delay = 200 * time.Millisecond
+	startConsuming(messageProcessor)
+
+	deliveries := consumer.nextConsume(t)
+	deliveries <- hotelDelivery(t, acknowledger, 1, 101)
+	<-lock.started
+	messageProcessor.pause.setLocal(true)
+	assert.Equal(t, int64(1), messageProcessor.inFlight.Load())
+
+	waitSettled(t, acknowledger, 1)
+	assert.Equal(t, []uint64{1}, acknowledger.ackedTags())
+	require.Eventually(t, func() bool { return consumer.stopCount() == 1 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPauseOnlyResumesOnceBothScopesAreCleared(t *testing.T) {
+	pause := newPauseState()
+
+	pause.setLocal(true)
+	pause.setFleet(true)
+	pause.setLocal(false)
+	paused, changed := pause.current()
+	assert.True(t, paused)
+
+	pause.setFleet(false)
+	paused, _ = pause.current()
+	assert.False(t, paused)
+	select {
+	case <-changed:
+	default:
+		t.Fatal("resuming did not signal a change")
+	}
+}
+
+func TestPauseEndpoints(t *testing.T) {
+	server := miniredis.RunT(t)
+	messageProcessor, _ := newPauseTestProcessor(t, newResumableConsumer())
+	messageProcessor.redisCache = adapter.NewRedisCacheAdapter(server.Addr(), "", 0)
+	messageProcessor.startedAt = time.Now().Add(-time.Minute)
+	messageProcessor.inFlight.Store(2)
+
+	serve := func(handler http.HandlerFunc, method, target string) (*httptest.ResponseRecorder, workerStatusResponse) {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(method, target, nil))
+
+		var status workerStatusResponse
+		if recorder.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+		}
+		return recorder, status
+	}
+	pause, resume := messageProcessor.handlePause, messageProcessor.handleResume
+
+	recorder, status := serve(messageProcessor.handleStatus, http.MethodGet, "/status")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "active", status.Status)
+	assert.Equal(t, int64(2), status.InFlight)
+	assert.GreaterOrEqual(t, status.UptimeSeconds, int64(60))
+	assert.Nil(t, status.PausedSince)
+
+	recorder, _ = serve(pause, http.MethodGet, "/pause")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+
+	recorder, _ = serve(pause, http.MethodPost, "/pause?scope=region")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	_, status = serve(pause, http.MethodPost, "/pause")
+	assert.Equal(t, "paused", status.Status)
+	assert.True(t, status.PausedLocally)
+	assert.NotNil(t, status.PausedSince)
+	assert.False(t, server.Exists(defaultPauseFlagKey), "a local pause leaves the fleet flag alone")
+
+	_, status = serve(pause, http.MethodPost, "/pause?scope=fleet")
+	assert.True(t, status.PausedByFleet)
+	flag, err := server.Get(defaultPauseFlagKey)
+	require.NoError(t, err)
+	assert.Equal(t, "true", flag)
+
+	_, status = serve(resume, http.MethodPost, "/resume")
+	assert.Equal(t, "paused", status.Status, "the fleet flag still pauses the worker")
+
+	_, status = serve(resume, http.MethodPost, "/resume?scope=fleet")
+	assert.Equal(t, "active", status.Status)
+	flag, err = server.Get(defaultPauseFlagKey)
+	require.NoError(t, err)
+	assert.Equal(t, "false", flag)
+}
+
+func TestPauseFlagPausesTheFleet(t *testing.T) {
+	server := miniredis.RunT(t)
+	workers := make([]*MessageProcessor, 2)
+	for i := range workers {
+		workers[i], _ = newPauseTestProcessor(t, newResumableConsumer())
+		workers[i].config.PausePollSeconds = 1
+		workers[i].redisCache = adapter.NewRedisCacheAdapter(server.Addr(), "", 0)
+	}
+	require.NoError(t, server.Set(defaultPauseFlagKey, "true"))
+
+	for _, messageProcessor := range workers {
+		go messageProcessor.pollPauseFlag()
+	}
+	for _, messageProcessor := range workers {
+		require.Eventually(t, func() bool {
+			paused, _ := messageProcessor.pause.current()
+			return paused
+		}, 3*time.Second, 10*time.Millisecond)
+	}
+
+	require.NoError(t, server.Set(defaultPauseFlagKey, "false"))
+	for _, messageProcessor := range workers {
+		require.Eventually(t, func() bool {
+			paused, _ := messageProcessor.pause.current()
+			return !paused
+		}, 3*time.Second, 10*time.Millisecond)
+	}
+
+	server.SetError("READONLY")
+	time.Sleep(1200 * time.Millisecond)
+	paused, _ := workers[0].pause.current()
+	assert.False(t, paused, "a failed read keeps the last known state")
+}
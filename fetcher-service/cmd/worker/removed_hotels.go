@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+const defaultRemovedHotelRecheckDays = 30
+
+// markHotelRemoved handles a hotel the Cupid API no longer serves. The hotel is marked
+// removed_upstream and its next update pushed back by the recheck period, so the scheduler
+// stops enqueueing it every cycle, and the message counts as handled. A hotel that is not
+// stored is left alone.
+func (messageProcessor *MessageProcessor) markHotelRemoved(message messages.Envelope, hotelID int64, fetchErr error) error {
+	recheck := time.Duration(messageProcessor.config.RemovedHotels.RecheckDays) * 24 * time.Hour
+	nextUpdateAt := time.Now().Add(recheck)
+
+	previous, err := messageProcessor.gormRepo.MarkHotelRemoved(messageProcessor.ctx, hotelID, entities.HotelStatusRemovedUpstream, nextUpdateAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark hotel removed: %w", err)
+	}
+	if previous == nil {
+		messageProcessor.logger.Warn("Hotel removed upstream is not stored", "hotel_id", hotelID, "error", fetchErr)
+		return nil
+	}
+
+	current := *previous
+	current.Status = entities.HotelStatusRemovedUpstream
+	messageProcessor.recordHotelChanges(message, previous, &current)
+
+	messageProcessor.logger.Info("Hotel removed upstream, marked removed_upstream",
+		"hotel_id", hotelID,
+		"previous_status", previous.Status,
+		"next_update_at", nextUpdateAt.Format(time.RFC3339),
+		"error", fetchErr)
+	return nil
+}
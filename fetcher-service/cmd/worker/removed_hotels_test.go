@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/mocks"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/adapter"
+	"github.com/victoragudo/hotel-management-system/pkg/api-models/cupidtest"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHotelRemovedUpstreamIsMarkedAndAcked(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, `{"error":"property not found"}`, status)
+			}))
+			t.Cleanup(server.Close)
+
+			repository, db := newWorkerTestRepository(t)
+			require.NoError(t, db.Migrator().CreateTable(&entities.HotelChange{}))
+
+			acknowledger := newFakeAcknowledger()
+			consumer := &fakeConsumer{deliveries: make(chan amqp.Delivery, 2)}
+			messageProcessor := newDrainTestProcessor(t, consumer, nil, 5)
+			messageProcessor.redisLock = &grantingLock{}
+			messageProcessor.redisCache = missingCache{}
+			messageProcessor.gormRepo = repository
+			messageProcessor.cupidAPI = adapter.NewCupidAPIAdapter(&adapter.APIConfig{
+				BaseURL:        server.URL,
+				APIKey:         cupidtest.APIKey,
+				Timeout:        5 * time.Second,
+				RateLimit:      1000,
+				BurstLimit:     100,
+				CircuitBreaker: &adapter.CircuitBreakerConfig{},
+			})
+			messageProcessor.config.RemovedHotels.RecheckDays = 30
+			messageProcessor.config.HotelChanges = HotelChangesConfig{Enabled: true, TrackedFields: entities.TrackableHotelFields}
+			messageProcessor.config.DLQExchange = defaultDLQExchange
+			// The dead-letter publisher expects no call.
+			messageProcessor.deadLetters = mocks.NewMockPublisherPort(gomock.NewController(t))
+
+			before := time.Now()
+			consumer.deliveries <- hotelDelivery(t, acknowledger, 1, 7)
+			// Hotel 9 is not stored; its message is handled too.
+			consumer.deliveries <- hotelDelivery(t, acknowledger, 2, 9)
+			startConsuming(messageProcessor)
+			waitSettled(t, acknowledger, 1)
+			waitSettled(t, acknowledger, 2)
+
+			assert.Equal(t, []uint64{1, 2}, acknowledger.ackedTags(), "the message is handled")
+			assert.Empty(t, acknowledger.nackedTags(), "the message is not dead-lettered")
+
+			var hotel entities.HotelData
+			require.NoError(t, db.Where(constants.HotelId+" = ?", 7).First(&hotel).Error)
+			assert.Equal(t, entities.HotelStatusRemovedUpstream, hotel.Status)
+			assert.Equal(t, "Harbour Hotel", hotel.Name, "the hotel's data is kept")
+			recheck := 30 * 24 * time.Hour
+			assert.WithinRange(t, hotel.NextUpdateAt, before.Add(recheck), time.Now().Add(recheck), "the hotel is not fetched again before the recheck period")
+
+			var changes []entities.HotelChange
+			require.NoError(t, db.Find(&changes).Error)
+			require.Len(t, changes, 1, "one change is recorded, for the stored hotel")
+			assert.Equal(t, int64(7), changes[0].HotelID)
+			assert.Equal(t, "row", changes[0].SourceMessageID)
+			var fields map[string]entities.FieldChange
+			require.NoError(t, json.Unmarshal(changes[0].Changes, &fields))
+			assert.Equal(t, map[string]entities.FieldChange{"status": {Old: "active", New: entities.HotelStatusRemovedUpstream}}, fields)
+
+			var stored int64
+			require.NoError(t, db.Model(&entities.HotelData{}).Where(constants.HotelId+" = ?", 9).Count(&stored).Error)
+			assert.Zero(t, stored, "a hotel that is not stored is not created")
+		})
+	}
+}
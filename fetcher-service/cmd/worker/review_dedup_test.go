@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+	"gorm.io/gorm"
+)
+
+// reviewStore keeps reviews by row id the way the gorm repository stores them: the fingerprint
+// is computed on every write, and merged reviews are soft-deleted.
+type reviewStore struct {
+	reviewStateRepository
+	reviews map[string]*entities.ReviewData
+	merged  map[int64]bool
+	nextID  int
+}
+
+func newReviewStore(hotelID, reviewCount int64, stored ...*entities.ReviewData) *reviewStore {
+	store := &reviewStore{
+		reviewStateRepository: reviewStateRepository{reviewCounts: map[int64]int64{hotelID: reviewCount}, states: map[int64]*entities.ReviewFetchState{}},
+		reviews:               map[string]*entities.ReviewData{},
+		merged:                map[int64]bool{},
+	}
+	for _, review := range stored {
+		review.Fingerprint = review.ContentFingerprint()
+		store.reviews[review.ID] = review
+	}
+	return store
+}
+
+func (s *reviewStore) CreateReview(_ context.Context, review *entities.ReviewData) error {
+	s.nextID++
+	review.ID = fmt.Sprintf("created-%d", s.nextID)
+	review.Fingerprint = review.ContentFingerprint()
+	s.reviews[review.ID] = review
+	return nil
+}
+
+func (s *reviewStore) UpdateReview(_ context.Context, review *entities.ReviewData) error {
+	review.Fingerprint = review.ContentFingerprint()
+	s.reviews[review.ID] = review
+	return nil
+}
+
+func (s *reviewStore) GetReviewByReviewID(_ context.Context, reviewID int64) (*entities.ReviewData, error) {
+	for _, review := range s.reviews {
+		if review.ReviewID == reviewID {
+			return review, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *reviewStore) GetReviewByFingerprint(_ context.Context, hotelID int64, fingerprint string) (*entities.ReviewData, error) {
+	for _, review := range s.reviews {
+		if review.HotelID == hotelID && review.Fingerprint == fingerprint {
+			return review, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *reviewStore) IsReviewMerged(_ context.Context, reviewID int64) (bool, error) {
+	return s.merged[reviewID], nil
+}
+
+// missingCache never holds an entry.
+type missingCache struct {
+	ports.CachePort
+}
+
+func (missingCache) Get(context.Context, string, any) (bool, error)        { return false, nil }
+func (missingCache) Set(context.Context, string, any, time.Duration) error { return nil }
+
+// reviewsAPI returns the same reviews for every hotel.
+type reviewsAPI struct {
+	ports.APIClientPort
+	reviews dto.ReviewDataList
+}
+
+func (a *reviewsAPI) FetchHotelReviews(context.Context, int64, *dto.ReviewFetchOptions) (*dto.ReviewDataList, error) {
+	return &a.reviews, nil
+}
+
+func newReviewIngestionProcessor(store *reviewStore, reviews dto.ReviewDataList) *MessageProcessor {
+	return &MessageProcessor{
+		config:     Config{MaxReviewsPerHotel: 500},
+		logger:     slog.New(slog.DiscardHandler),
+		gormRepo:   store,
+		redisCache: missingCache{},
+		cupidAPI:   &reviewsAPI{reviews: reviews},
+		metrics:    worker.NewWorkerMetrics(),
+		ctx:        context.Background(),
+	}
+}
+
+func storedReview(id string, reviewID int64, name, headline string) *entities.ReviewData {
+	return &entities.ReviewData{
+		ID:           id,
+		HotelID:      1641879,
+		ReviewID:     reviewID,
+		AverageScore: 8,
+		Country:      "es",
+		Type:         "couple",
+		Name:         name,
+		Date:         time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
+		Headline:     headline,
+		Pros:         "Great location",
+		Source:       "booking",
+		CreatedAt:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestReviewIngestionReusesTheStoredRowOfAReimportedReview(t *testing.T) {
+	store := newReviewStore(1641879, 1, storedReview("row-1", 100, "Ana", "Lovely stay"))
+	reimported := dto.ReviewDataList{{
+		ReviewID:     200,
+		AverageScore: 8,
+		Country:      "ES",
+		Type:         "Couple",
+		Name:         "  ana ",
+		Date:         "2024-05-12 00:00:00",
+		Headline:     "LOVELY   stay",
+		Pros:         "great location",
+		Source:       "expedia",
+	}}
+	messageProcessor := newReviewIngestionProcessor(store, reimported)
+
+	require.NoError(t, messageProcessor.processReviewsMessage(context.Background(), messages.NewReviewFetch("hotel-row", 1641879), 1641879))
+
+	require.Len(t, store.reviews, 1, "no duplicate is inserted")
+	review := store.reviews["row-1"]
+	assert.Equal(t, int64(200), review.ReviewID, "the stored row adopts the new review id")
+	assert.Equal(t, "expedia", review.Source)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), review.CreatedAt)
+}
+
+func TestReviewIngestionKeepsReviewsOfDifferentGuestsWithoutText(t *testing.T) {
+	store := newReviewStore(1641879, 2, storedReview("row-1", 100, "Ana", ""))
+	store.reviews["row-1"].Pros = ""
+	store.reviews["row-1"].Fingerprint = store.reviews["row-1"].ContentFingerprint()
+	sameDay := dto.ReviewDataList{
+		{ReviewID: 100, AverageScore: 8, Country: "es", Type: "couple", Name: "Ana", Date: "2024-05-12 00:00:00", Source: "booking"},
+		{ReviewID: 101, AverageScore: 6, Country: "fr", Type: "family", Name: "Luc", Date: "2024-05-12 00:00:00", Source: "booking"},
+		{ReviewID: 102, AverageScore: 9, Country: "es", Type: "couple", Name: "Ana", Date: "2024-05-12 00:00:00", Source: "booking"},
+	}
+	messageProcessor := newReviewIngestionProcessor(store, sameDay)
+
+	require.NoError(t, messageProcessor.processReviewsMessage(context.Background(), messages.NewReviewFetch("hotel-row", 1641879), 1641879))
+
+	assert.Len(t, store.reviews, 3, "reviews of other guests or with other scores are not merged")
+}
+
+func TestReviewIngestionSkipsReviewsTheDedupeTaskMerged(t *testing.T) {
+	store := newReviewStore(1641879, 1, storedReview("row-1", 100, "Ana", "Lovely stay"))
+	store.merged[200] = true
+	reimported := dto.ReviewDataList{{ReviewID: 200, AverageScore: 8, Country: "es", Type: "couple", Name: "Ana", Date: "2024-05-12 00:00:00", Headline: "Lovely stay", Pros: "Great location", Source: "expedia"}}
+	messageProcessor := newReviewIngestionProcessor(store, reimported)
+
+	require.NoError(t, messageProcessor.processReviewsMessage(context.Background(), messages.NewReviewFetch("hotel-row", 1641879), 1641879))
+
+	require.Len(t, store.reviews, 1)
+	assert.Equal(t, int64(100), store.reviews["row-1"].ReviewID, "the kept review keeps its review id")
+	assert.Equal(t, "booking", store.reviews["row-1"].Source)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxReviewsPerHotel = 500
+	// defaultMissingReviewCount is requested for a hotel whose reviews are missing and whose
+	// review count is unknown because the hotel itself is not stored yet.
+	defaultMissingReviewCount = 10
+)
+
+// reviewFetchTarget is how many reviews a hotel should hold: the review count the Cupid API
+// reported for it, capped at maxReviews.
+func reviewFetchTarget(reviewCount int64, maxReviews int) int {
+	return int(min(reviewCount, int64(maxReviews)))
+}
+
+// planReviewFetch returns the reviews to request for a hotel that should hold target reviews.
+// A previous fetch for the same target that came back short resumes after the reviews it got;
+// otherwise every review is requested from the start.
+func planReviewFetch(target int, state *entities.ReviewFetchState) dto.ReviewFetchOptions {
+	if state != nil && state.Target == target && state.Shortfall > 0 && state.Fetched < target {
+		return dto.ReviewFetchOptions{ReviewCount: int64(state.Shortfall), Offset: int64(state.Fetched)}
+	}
+	return dto.ReviewFetchOptions{ReviewCount: int64(target)}
+}
+
+// reviewFetchState records a fetch planned with options that returned that many reviews. A
+// resumed fetch that comes back short means the hotel holds fewer reviews upstream than the
+// stored offset assumed, for instance because some were removed. Resuming past them again would
+// skip the newest reviews on every later cycle, so the offset is reset and the next cycle pages
+// from the newest review.
+func reviewFetchState(hotelID int64, target int, options dto.ReviewFetchOptions, returned int) *entities.ReviewFetchState {
+	fetched := int(options.Offset) + returned
+	if options.Offset > 0 && int64(returned) < options.ReviewCount {
+		fetched = 0
+	}
+	return &entities.ReviewFetchState{
+		HotelID:   hotelID,
+		Target:    target,
+		Requested: int(options.ReviewCount),
+		Returned:  returned,
+		Fetched:   fetched,
+		Shortfall: max(target-fetched, 0),
+	}
+}
+
+// reviewFetchOptions decides how many reviews to request for the hotel. ok is false when there
+// is nothing to fetch: the hotel has no reviews upstream, or an update targets a hotel that is
+// not stored.
+func (messageProcessor *MessageProcessor) reviewFetchOptions(messageType string, hotelId int64) (options dto.ReviewFetchOptions, target int, ok bool, err error) {
+	reviewCount, err := messageProcessor.gormRepo.HotelReviewCount(messageProcessor.ctx, hotelId)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if messageType != constants.MessageTypeFetchReview {
+			return dto.ReviewFetchOptions{}, 0, false, nil
+		}
+		reviewCount = defaultMissingReviewCount
+	case err != nil:
+		return dto.ReviewFetchOptions{}, 0, false, fmt.Errorf("failed to read hotel review count: %w", err)
+	}
+
+	target = reviewFetchTarget(reviewCount, messageProcessor.config.MaxReviewsPerHotel)
+	if target <= 0 {
+		return dto.ReviewFetchOptions{}, 0, false, nil
+	}
+
+	state, err := messageProcessor.gormRepo.GetReviewFetchState(messageProcessor.ctx, hotelId)
+	if err != nil {
+		messageProcessor.logger.Warn("Failed to read review fetch state, fetching every review", "hotel_id", hotelId, "error", err)
+	}
+	return planReviewFetch(target, state), target, true, nil
+}
+
+// recordReviewFetch stores how many reviews a fetch returned. A shortfall is retried from the
+// reviews already fetched on the hotel's next review cycle.
+func (messageProcessor *MessageProcessor) recordReviewFetch(hotelId int64, target int, options dto.ReviewFetchOptions, returned int) {
+	state := reviewFetchState(hotelId, target, options, returned)
+	if state.Shortfall > 0 {
+		messageProcessor.logger.Info("Cupid API returned fewer reviews than requested",
+			"hotel_id", hotelId,
+			"target", target,
+			"requested", options.ReviewCount,
+			"offset", options.Offset,
+			"returned", returned,
+			"shortfall", state.Shortfall)
+	}
+	if err := messageProcessor.gormRepo.SaveReviewFetchState(messageProcessor.ctx, state); err != nil {
+		messageProcessor.logger.Warn("Failed to save review fetch state", "hotel_id", hotelId, "error", err)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/gorm"
+)
+
+// reviewStateRepository keeps the review counts of stored hotels and their review fetch states.
+type reviewStateRepository struct {
+	ports.RepositoryPort
+	reviewCounts map[int64]int64
+	states       map[int64]*entities.ReviewFetchState
+}
+
+func (r *reviewStateRepository) HotelReviewCount(_ context.Context, hotelId int64) (int64, error) {
+	count, ok := r.reviewCounts[hotelId]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return count, nil
+}
+
+func (r *reviewStateRepository) GetReviewFetchState(_ context.Context, hotelId int64) (*entities.ReviewFetchState, error) {
+	return r.states[hotelId], nil
+}
+
+func (r *reviewStateRepository) SaveReviewFetchState(_ context.Context, state *entities.ReviewFetchState) error {
+	r.states[state.HotelID] = state
+	return nil
+}
+
+func newReviewDepthTestProcessor(reviewCounts map[int64]int64) (*MessageProcessor, *reviewStateRepository) {
+	repository := &reviewStateRepository{reviewCounts: reviewCounts, states: map[int64]*entities.ReviewFetchState{}}
+	return &MessageProcessor{
+		config:   Config{MaxReviewsPerHotel: 500},
+		logger:   slog.New(slog.DiscardHandler),
+		gormRepo: repository,
+		ctx:      context.Background(),
+	}, repository
+}
+
+// upstreamReviews returns how many reviews a fetch with options gets from a hotel holding
+// available reviews, the way the Cupid adapter emulates the offset.
+func upstreamReviews(options dto.ReviewFetchOptions, available int) int {
+	return max(min(int(options.Offset+options.ReviewCount), available)-int(options.Offset), 0)
+}
+
+func TestReviewFetchTargetCapsTheReviewCount(t *testing.T) {
+	assert.Equal(t, 500, reviewFetchTarget(900, 500))
+	assert.Equal(t, 120, reviewFetchTarget(120, 500))
+	assert.Equal(t, 0, reviewFetchTarget(0, 500))
+}
+
+func TestPlanReviewFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *entities.ReviewFetchState
+		expected dto.ReviewFetchOptions
+	}{
+		{"first fetch", nil, dto.ReviewFetchOptions{ReviewCount: 100}},
+		{"shortfall resumes after the fetched reviews", &entities.ReviewFetchState{Target: 100, Fetched: 60, Shortfall: 40}, dto.ReviewFetchOptions{ReviewCount: 40, Offset: 60}},
+		{"complete fetch starts over", &entities.ReviewFetchState{Target: 100, Fetched: 100}, dto.ReviewFetchOptions{ReviewCount: 100}},
+		{"changed target starts over", &entities.ReviewFetchState{Target: 80, Fetched: 60, Shortfall: 20}, dto.ReviewFetchOptions{ReviewCount: 100}},
+		{"reset offset starts over", &entities.ReviewFetchState{Target: 100, Fetched: 0, Shortfall: 100}, dto.ReviewFetchOptions{ReviewCount: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, planReviewFetch(100, tt.state))
+		})
+	}
+}
+
+func TestReviewFetchOptionsFollowTheHotelReviewCount(t *testing.T) {
+	messageProcessor, _ := newReviewDepthTestProcessor(map[int64]int64{1: 900, 2: 35, 3: 0})
+
+	tests := []struct {
+		name        string
+		messageType string
+		hotelID     int64
+		expected    dto.ReviewFetchOptions
+		ok          bool
+	}{
+		{"capped review count", constants.MessageTypeUpdateReview, 1, dto.ReviewFetchOptions{ReviewCount: 500}, true},
+		{"review count under the cap", constants.MessageTypeFetchReview, 2, dto.ReviewFetchOptions{ReviewCount: 35}, true},
+		{"hotel without reviews", constants.MessageTypeFetchReview, 3, dto.ReviewFetchOptions{}, false},
+		{"missing reviews of an unstored hotel", constants.MessageTypeFetchReview, 4, dto.ReviewFetchOptions{ReviewCount: defaultMissingReviewCount}, true},
+		{"update of an unstored hotel", constants.MessageTypeUpdateReview, 4, dto.ReviewFetchOptions{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, _, ok, err := messageProcessor.reviewFetchOptions(tt.messageType, tt.hotelID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
+
+func TestReviewFetchShortfallIsRetriedOnTheNextCycle(t *testing.T) {
+	messageProcessor, repository := newReviewDepthTestProcessor(map[int64]int64{1: 100})
+
+	options, target, ok, err := messageProcessor.reviewFetchOptions(constants.MessageTypeUpdateReview, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	messageProcessor.recordReviewFetch(1, target, options, 60)
+
+	state := repository.states[1]
+	assert.Equal(t, 100, state.Requested)
+	assert.Equal(t, 60, state.Returned)
+	assert.Equal(t, 60, state.Fetched)
+	assert.Equal(t, 40, state.Shortfall)
+
+	options, target, _, err = messageProcessor.reviewFetchOptions(constants.MessageTypeUpdateReview, 1)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ReviewFetchOptions{ReviewCount: 40, Offset: 60}, options)
+	messageProcessor.recordReviewFetch(1, target, options, 40)
+
+	state = repository.states[1]
+	assert.Equal(t, 100, state.Fetched)
+	assert.Zero(t, state.Shortfall)
+
+	options, _, _, err = messageProcessor.reviewFetchOptions(constants.MessageTypeUpdateReview, 1)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ReviewFetchOptions{ReviewCount: 100}, options, "a complete hotel refetches from the newest review")
+}
+
+func TestReviewFetchResetsTheOffsetWhenAResumedPageComesBackShort(t *testing.T) {
+	messageProcessor, repository := newReviewDepthTestProcessor(map[int64]int64{1: 100})
+	available := 60
+
+	var offsets []int64
+	for range 4 {
+		options, target, ok, err := messageProcessor.reviewFetchOptions(constants.MessageTypeUpdateReview, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		offsets = append(offsets, options.Offset)
+		messageProcessor.recordReviewFetch(1, target, options, upstreamReviews(options, available))
+
+		// Reviews removed upstream leave the hotel with fewer than the stored offset.
+		available = 50
+	}
+
+	assert.Equal(t, []int64{0, 60, 0, 50}, offsets, "every other cycle fetches from the newest review")
+	state := repository.states[1]
+	assert.Zero(t, state.Fetched)
+	assert.Equal(t, 100, state.Shortfall)
+}
@@ -4,15 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sony/gobreaker"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	apimodels "github.com/victoragudo/hotel-management-system/pkg/api-models"
+	"github.com/victoragudo/hotel-management-system/pkg/httpclient"
 	"golang.org/x/time/rate"
 )
 
@@ -24,11 +30,45 @@ type CupidAPIAdapter struct {
 	circuitBreaker *gobreaker.CircuitBreaker
 	retryConfig    *retryConfig
 	timeout        time.Duration
+	timeouts       TimeoutConfig
 	maxRetries     int
 	retryInterval  time.Duration
 	headers        map[string]string
+	onResponse     func(statusCode int)
+
+	maxResponseBytes int64
+
+	sharedBreaker        ports.BreakerStatePort
+	sharedBreakerTTL     time.Duration
+	breakerOwner         string
+	onSharedBreakerError func(err error)
+
+	// breakerState is the latest local circuit state and breakerStateVersion counts its changes.
+	// publishedVersion is the last version shared, under publishMu, which keeps publishes in
+	// order.
+	breakerStateMu      sync.Mutex
+	breakerState        gobreaker.State
+	breakerStateVersion uint64
+	publishMu           sync.Mutex
+	publishedVersion    uint64
+	publishing          sync.WaitGroup
+
+	// failureScore is the weighted count of consecutive failures the circuit breaker trips on.
+	failureMu    sync.Mutex
+	failureScore float64
 }
 
+const (
+	// defaultMaxConsecutiveFailures is the failure score that opens the circuit.
+	defaultMaxConsecutiveFailures = 5
+	// sharedBreakerTimeout bounds each read or write of the shared circuit state.
+	sharedBreakerTimeout = 2 * time.Second
+	// timeoutFailureWeight is what a timed out request adds to the failure score. A slow
+	// response is more often one heavy hotel than an API outage, so it weighs less than an
+	// HTTP 5xx or a connection error.
+	timeoutFailureWeight = 0.5
+)
+
 type retryConfig struct {
 	MaxRetries    int
 	BaseDelay     time.Duration
@@ -38,16 +78,43 @@ type retryConfig struct {
 	RetryableCode []int
 }
 
+// TimeoutConfig bounds each kind of operation, retries included. A zero value falls back to
+// APIConfig.Timeout.
+type TimeoutConfig struct {
+	HotelFetch       time.Duration
+	ReviewFetch      time.Duration
+	TranslationFetch time.Duration
+}
+
 type APIConfig struct {
 	BaseURL        string
 	APIKey         string
 	Timeout        time.Duration
+	TimeoutConfig  TimeoutConfig
 	RateLimit      float64
 	BurstLimit     int
 	MaxRetries     int
 	RetryInterval  time.Duration
 	Headers        map[string]string
 	CircuitBreaker *CircuitBreakerConfig
+
+	// MaxResponseBytes caps the size of a response body; zero uses
+	// httpclient.DefaultMaxResponseBytes. An oversized response fails as a retryable error.
+	MaxResponseBytes int64
+
+	// OnResponse is called after every HTTP attempt with the response status code, or 0 when
+	// no response was received.
+	OnResponse func(statusCode int)
+
+	// SharedBreaker, when set, shares the circuit state with the other workers. When this
+	// worker's circuit opens it is published under BreakerOwner for SharedBreakerTTL, and the
+	// other workers fail fast with ports.ErrCircuitOpen until it expires or this worker's
+	// half-open probes close it. Without Redis the local circuit breaker still applies, and
+	// OnSharedBreakerError is called with the error.
+	SharedBreaker        ports.BreakerStatePort
+	SharedBreakerTTL     time.Duration
+	BreakerOwner         string
+	OnSharedBreakerError func(err error)
 }
 
 type CircuitBreakerConfig struct {
@@ -58,29 +125,34 @@ type CircuitBreakerConfig struct {
 }
 
 func NewCupidAPIAdapter(config *APIConfig) *CupidAPIAdapter {
-	client := &http.Client{
-		Timeout: config.Timeout,
-		Transport: &http.Transport{
-			MaxIdleConns:       100,
-			IdleConnTimeout:    90 * time.Second,
-			DisableCompression: false,
-		},
-	}
+	// Each attempt is bounded by the longest timeout so an operation allowed more time than
+	// the global timeout is not cut short by the client.
+	clientTimeout := max(config.Timeout, config.TimeoutConfig.HotelFetch, config.TimeoutConfig.ReviewFetch, config.TimeoutConfig.TranslationFetch)
+	client := httpclient.NewClient(clientTimeout, httpclient.DefaultTransportConfig())
 
 	rateLimiter := rate.NewLimiter(rate.Limit(config.RateLimit), config.BurstLimit)
 
+	adapter := &CupidAPIAdapter{}
+
 	cbSettings := gobreaker.Settings{
-		Name:          "cupid-api",
-		MaxRequests:   config.CircuitBreaker.MaxRequests,
-		Interval:      config.CircuitBreaker.Interval,
-		Timeout:       config.CircuitBreaker.Timeout,
-		ReadyToTrip:   config.CircuitBreaker.ReadyToTrip,
-		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {},
+		Name:        "cupid-api",
+		MaxRequests: config.CircuitBreaker.MaxRequests,
+		Interval:    config.CircuitBreaker.Interval,
+		Timeout:     config.CircuitBreaker.Timeout,
+		ReadyToTrip: config.CircuitBreaker.ReadyToTrip,
+		IsSuccessful: func(err error) bool {
+			adapter.recordOutcome(err)
+			return err == nil
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			adapter.resetFailureScore()
+			adapter.publishBreakerState(to)
+		},
 	}
 
 	if cbSettings.ReadyToTrip == nil {
 		cbSettings.ReadyToTrip = func(counts gobreaker.Counts) bool {
-			return counts.ConsecutiveFailures >= 5
+			return adapter.currentFailureScore() >= defaultMaxConsecutiveFailures
 		}
 	}
 
@@ -93,26 +165,172 @@ func NewCupidAPIAdapter(config *APIConfig) *CupidAPIAdapter {
 		RetryableCode: []int{429, 500, 502, 503, 504},
 	}
 
-	return &CupidAPIAdapter{
-		client:         client,
-		baseURL:        config.BaseURL,
-		apiKey:         config.APIKey,
-		rateLimiter:    rateLimiter,
-		circuitBreaker: gobreaker.NewCircuitBreaker(cbSettings),
-		retryConfig:    retryConfig,
-		timeout:        config.Timeout,
-		maxRetries:     config.MaxRetries,
-		retryInterval:  config.RetryInterval,
-		headers:        config.Headers,
+	adapter.client = client
+	adapter.baseURL = config.BaseURL
+	adapter.apiKey = config.APIKey
+	adapter.rateLimiter = rateLimiter
+	adapter.circuitBreaker = gobreaker.NewCircuitBreaker(cbSettings)
+	adapter.retryConfig = retryConfig
+	adapter.timeout = config.Timeout
+	adapter.timeouts = config.TimeoutConfig
+	adapter.maxRetries = config.MaxRetries
+	adapter.retryInterval = config.RetryInterval
+	adapter.headers = config.Headers
+	adapter.onResponse = config.OnResponse
+	adapter.maxResponseBytes = config.MaxResponseBytes
+	adapter.sharedBreaker = config.SharedBreaker
+	adapter.sharedBreakerTTL = config.SharedBreakerTTL
+	adapter.breakerOwner = config.BreakerOwner
+	adapter.onSharedBreakerError = config.OnSharedBreakerError
+
+	return adapter
+}
+
+// publishBreakerState shares an opened circuit with the other workers, and clears it once
+// this worker's probes closed it again. The circuit breaker calls it with its mutex held, so
+// the Redis write runs in the background rather than stalling every request of this worker.
+func (c *CupidAPIAdapter) publishBreakerState(to gobreaker.State) {
+	if c.sharedBreaker == nil {
+		return
+	}
+
+	c.breakerStateMu.Lock()
+	c.breakerState = to
+	c.breakerStateVersion++
+	c.breakerStateMu.Unlock()
+
+	c.publishing.Add(1)
+	go func() {
+		defer c.publishing.Done()
+		c.flushBreakerState()
+	}()
+}
+
+// flushBreakerState publishes the latest circuit state unless it already was. Publishes run one
+// at a time and always send the latest state, so a quick open and close cannot reach Redis in
+// the reverse order.
+func (c *CupidAPIAdapter) flushBreakerState() {
+	c.publishMu.Lock()
+	defer c.publishMu.Unlock()
+
+	c.breakerStateMu.Lock()
+	to, version := c.breakerState, c.breakerStateVersion
+	c.breakerStateMu.Unlock()
+	if version == c.publishedVersion {
+		return
+	}
+	c.publishedVersion = version
+
+	ctx, cancel := context.WithTimeout(context.Background(), sharedBreakerTimeout)
+	defer cancel()
+
+	var err error
+	switch to {
+	case gobreaker.StateOpen:
+		err = c.sharedBreaker.Open(ctx, c.breakerOwner, c.sharedBreakerTTL)
+	case gobreaker.StateClosed:
+		err = c.sharedBreaker.Clear(ctx, c.breakerOwner)
+	}
+	if err != nil {
+		c.reportSharedBreakerError(fmt.Errorf("failed to publish circuit state %s: %w", to, err))
+	}
+}
+
+// WaitForBreakerState waits for the circuit state changes being published, so the shared state
+// is not left behind when the worker shuts down.
+func (c *CupidAPIAdapter) WaitForBreakerState() {
+	c.publishing.Wait()
+}
+
+// checkSharedBreaker fails fast while another worker holds the shared circuit open. It is
+// skipped unless the local circuit is closed: an open one rejects requests by itself, and a
+// half-open one must let its probes through to find out whether the API recovered.
+func (c *CupidAPIAdapter) checkSharedBreaker(ctx context.Context) error {
+	if c.sharedBreaker == nil || c.circuitBreaker.State() != gobreaker.StateClosed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sharedBreakerTimeout)
+	defer cancel()
+
+	owner, err := c.sharedBreaker.Owner(ctx)
+	if err != nil {
+		c.reportSharedBreakerError(fmt.Errorf("failed to read circuit state: %w", err))
+		return nil
+	}
+	// A circuit this worker opened is left behind only by a clear that failed, and expires.
+	if owner != "" && owner != c.breakerOwner {
+		return fmt.Errorf("%w: opened by %s", ports.ErrCircuitOpen, owner)
+	}
+	return nil
+}
+
+func (c *CupidAPIAdapter) reportSharedBreakerError(err error) {
+	if c.onSharedBreakerError != nil {
+		c.onSharedBreakerError(err)
 	}
 }
 
+// withOperationTimeout bounds an operation by its own timeout, or by the global one when it
+// has none.
+func (c *CupidAPIAdapter) withOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = c.timeout
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (c *CupidAPIAdapter) recordOutcome(err error) {
+	c.failureMu.Lock()
+	defer c.failureMu.Unlock()
+
+	switch {
+	case err == nil:
+		c.failureScore = 0
+	case isTimeoutError(err):
+		c.failureScore += timeoutFailureWeight
+	default:
+		c.failureScore++
+	}
+}
+
+func (c *CupidAPIAdapter) resetFailureScore() {
+	c.failureMu.Lock()
+	defer c.failureMu.Unlock()
+	c.failureScore = 0
+}
+
+func (c *CupidAPIAdapter) currentFailureScore() float64 {
+	c.failureMu.Lock()
+	defer c.failureMu.Unlock()
+	return c.failureScore
+}
+
+// isTimeoutError reports whether err is a deadline or client timeout rather than a response
+// from the API.
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 func (c *CupidAPIAdapter) FetchHotelData(ctx context.Context, hotelId int64) (*dto.HotelAPIResponse, error) {
+	ctx, cancel := c.withOperationTimeout(ctx, c.timeouts.HotelFetch)
+	defer cancel()
+
 	url := fmt.Sprintf("%s/property/%d", c.baseURL, hotelId)
 
 	var response dto.HotelAPIResponse
 	err := c.makeRequest(ctx, http.MethodGet, url, nil, &response)
 	if err != nil {
+		if isRemovedStatus(err) {
+			return nil, fmt.Errorf("failed to fetch hotel data for ID %d: %w: %w", hotelId, ports.ErrHotelRemoved, err)
+		}
 		return nil, fmt.Errorf("failed to fetch hotel data for ID %d: %w", hotelId, err)
 	}
 
@@ -120,12 +338,21 @@ func (c *CupidAPIAdapter) FetchHotelData(ctx context.Context, hotelId int64) (*d
 }
 
 func (c *CupidAPIAdapter) FetchHotelReviews(ctx context.Context, hotelID int64, options *dto.ReviewFetchOptions) (*dto.ReviewDataList, error) {
+	ctx, cancel := c.withOperationTimeout(ctx, c.timeouts.ReviewFetch)
+	defer cancel()
+
 	reviewCount := int64(50)
+	var offset int64
 	if options != nil && options.ReviewCount > 0 {
 		reviewCount = options.ReviewCount
 	}
+	if options != nil && options.Offset > 0 {
+		offset = options.Offset
+	}
 
-	url := fmt.Sprintf("%s/property/reviews/%d/%d", c.baseURL, hotelID, reviewCount)
+	// The reviews endpoint only takes a count, so an offset is applied by asking for the
+	// reviews up to offset+count and dropping the first offset.
+	url := fmt.Sprintf("%s/property/reviews/%d/%d", c.baseURL, hotelID, offset+reviewCount)
 
 	var reviewDataList dto.ReviewDataList
 	err := c.makeRequest(ctx, "GET", url, nil, &reviewDataList)
@@ -133,6 +360,11 @@ func (c *CupidAPIAdapter) FetchHotelReviews(ctx context.Context, hotelID int64,
 		return nil, fmt.Errorf("failed to fetch reviews for hotel ID %d: %w", hotelID, err)
 	}
 
+	if offset >= int64(len(reviewDataList)) {
+		reviewDataList = dto.ReviewDataList{}
+	} else {
+		reviewDataList = reviewDataList[offset:]
+	}
 	return &reviewDataList, nil
 }
 
@@ -142,6 +374,9 @@ func (c *CupidAPIAdapter) FetchTranslations(ctx context.Context, hotelID string,
 		return nil, fmt.Errorf("lang is required")
 	}
 
+	ctx, cancel := c.withOperationTimeout(ctx, c.timeouts.TranslationFetch)
+	defer cancel()
+
 	url := fmt.Sprintf("%s/property/%s/lang/%s", c.baseURL, hotelID, options.Lang)
 
 	var response dto.TranslationAPIResponse
@@ -154,12 +389,16 @@ func (c *CupidAPIAdapter) FetchTranslations(ctx context.Context, hotelID string,
 }
 
 func (c *CupidAPIAdapter) makeRequest(ctx context.Context, method, url string, body any, response any) error {
-	return c.executeWithRetry(ctx, func() error {
+	return c.executeWithRetry(ctx, func(ctx context.Context) error {
 		return c.performRequest(ctx, method, url, body, response)
 	})
 }
 
 func (c *CupidAPIAdapter) performRequest(ctx context.Context, method, url string, body any, response any) error {
+	if err := c.checkSharedBreaker(ctx); err != nil {
+		return err
+	}
+
 	err := c.rateLimiter.Wait(ctx)
 	if err != nil {
 		return fmt.Errorf("rate limiter error: %w", err)
@@ -168,15 +407,18 @@ func (c *CupidAPIAdapter) performRequest(ctx context.Context, method, url string
 	result, err := c.circuitBreaker.Execute(func() (any, error) {
 		result, httpErr := c.doHTTPRequest(ctx, method, url, body, response)
 
-		// If it's a 404 error, we don't want it to count as a circuit breaker failure,
-		// So we return a success result but with the 404 error wrapped in a special way
-		if c.is404Error(httpErr) {
+		// If it's a 404 or 410 error, we don't want it to count as a circuit breaker failure,
+		// So we return a success result but with the error wrapped in a special way
+		if isRemovedStatus(httpErr) {
 			return &notFoundResult{err: httpErr}, nil
 		}
 
 		return result, httpErr
 	})
 
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return fmt.Errorf("%w: %w", ports.ErrCircuitOpen, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -198,6 +440,26 @@ type notFoundResult struct {
 	err error
 }
 
+// httpStatusError is a response the API answered with an error status.
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP error %d: %s", e.StatusCode, e.Body)
+}
+
+// isRemovedStatus reports whether the API answered that the hotel is not, or no longer, in
+// the catalog.
+func isRemovedStatus(err error) bool {
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone
+}
+
 func (c *CupidAPIAdapter) doHTTPRequest(ctx context.Context, method, url string, requestBody any, response any) (any, error) {
 	var bodyReader io.Reader
 
@@ -223,19 +485,21 @@ func (c *CupidAPIAdapter) doHTTPRequest(ctx context.Context, method, url string,
 
 	httpResponse, err := c.client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %s", err.Error())
+		c.observeResponse(0)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
+	c.observeResponse(httpResponse.StatusCode)
+	httpclient.LimitBody(httpResponse, c.maxResponseBytes)
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(httpResponse.Body)
 
 	if httpResponse.StatusCode >= 400 {
-		body, _ := io.ReadAll(httpResponse.Body)
-		return nil, fmt.Errorf("HTTP error %d: %s", httpResponse.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: httpResponse.StatusCode, Body: httpclient.ErrorBody(httpResponse.Body)}
 	}
 
 	if response != nil {
-		err = json.NewDecoder(httpResponse.Body).Decode(response)
+		err = apimodels.Decode(httpResponse.Body, response)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
@@ -244,25 +508,54 @@ func (c *CupidAPIAdapter) doHTTPRequest(ctx context.Context, method, url string,
 	return response, nil
 }
 
-func (c *CupidAPIAdapter) executeWithRetry(ctx context.Context, operation func() error) error {
+func (c *CupidAPIAdapter) observeResponse(statusCode int) {
+	if c.onResponse != nil {
+		c.onResponse(statusCode)
+	}
+}
+
+// executeWithRetry runs operation until it succeeds, fails for good or runs out of attempts.
+// Retries draw from the retry budget of ctx, if any: a retry is not started when its delay
+// would end past the budget, and it is cut short when the budget runs out while it is sent.
+func (c *CupidAPIAdapter) executeWithRetry(ctx context.Context, operation func(ctx context.Context) error) error {
 	var lastErr error
+	start := time.Now()
+	retryDeadline, hasRetryBudget := ports.RetryDeadline(ctx)
+	budgetExceeded := func(attempt int) error {
+		return fmt.Errorf("%w after %d attempts in %s: %w", ports.ErrRetryBudgetExceeded, attempt, time.Since(start).Round(time.Millisecond), lastErr)
+	}
 
 	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
 		if attempt > 0 {
 			delay := c.calculateRetryDelay(attempt)
+			if hasRetryBudget && time.Now().Add(delay).After(retryDeadline) {
+				return budgetExceeded(attempt)
+			}
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-time.After(delay):
 			}
+			if hasRetryBudget {
+				if !time.Now().Before(retryDeadline) {
+					return budgetExceeded(attempt)
+				}
+				attemptCtx, cancel = context.WithDeadline(ctx, retryDeadline)
+			}
 		}
 
-		err := operation()
+		err := operation(attemptCtx)
+		budgetRanOut := attemptCtx.Err() != nil && ctx.Err() == nil
+		cancel()
 		if err == nil {
 			return nil
 		}
 
 		lastErr = err
+		if budgetRanOut {
+			return budgetExceeded(attempt + 1)
+		}
 		if !c.isRetryableError(err) {
 			break
 		}
@@ -286,34 +579,13 @@ func (c *CupidAPIAdapter) calculateRetryDelay(attempt int) time.Duration {
 	return delay
 }
 
-func (c *CupidAPIAdapter) is404Error(err error) bool {
+func (c *CupidAPIAdapter) isRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
 
-	errStr := err.Error()
-
-	// Check if it's a 404 HTTP error
-	if strings.Contains(errStr, "HTTP error") {
-		// Extract status code from error message "HTTP error %d: %s"
-		parts := strings.Split(errStr, ":")
-		if len(parts) >= 1 {
-			httpPart := strings.TrimSpace(parts[0])
-			// Extract the number after "HTTP error"
-			if strings.HasPrefix(httpPart, "HTTP error ") {
-				statusStr := strings.TrimPrefix(httpPart, "HTTP error ")
-				if statusCode, parseErr := strconv.Atoi(statusStr); parseErr == nil {
-					return statusCode == 404
-				}
-			}
-		}
-	}
-
-	return false
-}
-
-func (c *CupidAPIAdapter) isRetryableError(err error) bool {
-	if err == nil {
+	// Retrying within the operation would only hit the open circuit again.
+	if errors.Is(err, ports.ErrCircuitOpen) {
 		return false
 	}
 
@@ -329,8 +601,8 @@ func (c *CupidAPIAdapter) isRetryableError(err error) bool {
 			if strings.HasPrefix(httpPart, "HTTP error ") {
 				statusStr := strings.TrimPrefix(httpPart, "HTTP error ")
 				if statusCode, parseErr := strconv.Atoi(statusStr); parseErr == nil {
-					// Don't retry 404 Not Found errors
-					if statusCode == 404 {
+					// Don't retry 404 Not Found or 410 Gone errors
+					if statusCode == http.StatusNotFound || statusCode == http.StatusGone {
 						return false
 					}
 					// Only retry specific HTTP status codes
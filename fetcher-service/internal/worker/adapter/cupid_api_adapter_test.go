@@ -0,0 +1,377 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	apimodels "github.com/victoragudo/hotel-management-system/pkg/api-models"
+	"github.com/victoragudo/hotel-management-system/pkg/api-models/cupidtest"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/httpclient"
+)
+
+// newContractCupidAPI returns an adapter reading the recorded fixtures of dir, decoding them
+// strictly so fields the API models do not know fail the test.
+func newContractCupidAPI(t *testing.T, dir string) *CupidAPIAdapter {
+	t.Helper()
+	t.Setenv(apimodels.StrictDecodeEnv, "true")
+	server := cupidtest.NewServer(t, dir)
+	return NewCupidAPIAdapter(&APIConfig{
+		BaseURL:        server.URL,
+		APIKey:         cupidtest.APIKey,
+		Timeout:        5 * time.Second,
+		RateLimit:      1000,
+		BurstLimit:     100,
+		CircuitBreaker: &CircuitBreakerConfig{},
+	})
+}
+
+// rawFixtureField returns a top-level field of a recorded fixture as raw JSON.
+func rawFixtureField(t *testing.T, fixture, field string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(cupidtest.Dir(), fixture))
+	require.NoError(t, err)
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &raw))
+	return string(raw[field])
+}
+
+func TestCupidContractFetchHotelData(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	response, err := cupidAPI.FetchHotelData(context.Background(), 1641879)
+	require.NoError(t, err)
+	response.NormalizeFacilities(facilities.Default())
+
+	h, err := response.ToHotelData()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1641879), h.HotelID, "hotel_id")
+	assert.Equal(t, int64(1641879), h.CupidID, "cupid_id")
+	assert.Equal(t, int64(204), h.HotelTypeID, "hotel_type_id")
+	assert.Equal(t, "Hotels", h.HotelType, "hotel_type")
+	assert.Equal(t, "Hotel Le Marais", h.Name, "hotel_name")
+	assert.Equal(t, "<p>A boutique hotel in the heart of the Marais.</p>", h.Description, "description")
+	assert.Equal(t, "A boutique hotel in the heart of the Marais.", h.MarkdownDescription, "markdown_description")
+	assert.Equal(t, "Adults only. Guests must be 18 or older to check in.", h.ImportantInfo, "important_info")
+	assert.Equal(t, 8.6, h.Rating, "rating")
+	assert.Equal(t, int32(4), h.StarRating, "stars")
+	assert.Equal(t, 48.86486, h.Latitude, "latitude")
+	assert.Equal(t, 2.37089, h.Longitude, "longitude")
+	assert.Equal(t, "Europe/Paris", h.Timezone, "timezone from latitude and longitude")
+	assert.Equal(t, "https://static.cupid.travel/hotels/thumbnail/1641879/main.jpg", h.MainImageTh, "main_image_th")
+	assert.Equal(t, "Independent", h.Chain, "chain")
+	assert.Equal(t, int32(0), h.ChainID, "chain_id")
+	assert.Equal(t, "CDG", h.AirportCode, "airport_code")
+	assert.Equal(t, int32(1240), h.ReviewCount, "review_count")
+	assert.Equal(t, "Paid parking nearby", h.Parking, "parking")
+	assert.True(t, h.ChildAllowed, "child_allowed")
+	assert.False(t, h.PetsAllowed, "pets_allowed")
+
+	assert.JSONEq(t, `{"cupid":"1641879"}`, string(h.SourceMappings), "cupid_id")
+	assert.JSONEq(t, `{"address":"31 Rue de la Folie Méricourt","city":"Paris","state":"","country":"fr","postal_code":"75011"}`,
+		string(h.Address), "address")
+	assert.JSONEq(t, `{"phone":"REDACTED","fax":"REDACTED","email":"REDACTED"}`, string(h.ContactInfo), "contact")
+	assert.JSONEq(t, `[
+		{"facility_id":47,"name":"Free WiFi","slug":"wifi"},
+		{"facility_id":107,"name":"Fitness centre","slug":"fitness_center"},
+		{"facility_id":3,"name":"24-hour front desk","slug":"front_desk_24h"},
+		{"facility_id":999,"name":"Rooftop terrace","slug":"rooftop_terrace"}
+	]`, string(h.Facilities), "facilities")
+	assert.JSONEq(t, `["wifi","fitness_center","front_desk_24h","rooftop_terrace"]`, string(h.Amenities), "facilities")
+	assert.JSONEq(t, `{
+		"policy_0": {"type":"pets","name":"Pets","description":"Pets are not allowed.","child_allowed":"","pets_allowed":"N","parking":"","id":51},
+		"policy_1": {"type":"children","name":"Children and extra beds","description":"Children of all ages are welcome.","child_allowed":"Y","pets_allowed":"","parking":"","id":52}
+	}`, string(h.Policies), "policies")
+	assert.JSONEq(t, rawFixtureField(t, "property_1641879.json", "checkin"), string(h.Checkin), "checkin")
+	assert.JSONEq(t, `5`, string(h.GroupRoomMin), "group_room_min")
+	assert.JSONEq(t, rawFixtureField(t, "property_1641879.json", "photos"), string(h.Photos), "photos")
+	assert.JSONEq(t, rawFixtureField(t, "property_1641879.json", "rooms"), string(h.Rooms), "rooms")
+}
+
+func TestCupidContractFetchHotelDataEdgeCases(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	response, err := cupidAPI.FetchHotelData(context.Background(), 317597)
+	require.NoError(t, err)
+	response.NormalizeFacilities(facilities.Default())
+
+	h, err := response.ToHotelData()
+	require.NoError(t, err)
+
+	assert.Equal(t, "Casa do Largo", h.Name)
+	assert.Equal(t, "Europe/Lisbon", h.Timezone)
+	assert.Nil(t, h.GroupRoomMin, "a null group_room_min is not stored")
+	assert.Nil(t, h.Rooms, "empty rooms are not stored")
+	assert.Nil(t, h.Photos)
+	assert.Nil(t, h.Facilities)
+	assert.Empty(t, h.Amenities)
+	assert.Empty(t, h.Policies)
+	assert.Nil(t, h.Checkin, "a check-in without times is not stored")
+}
+
+func TestCupidContractFetchHotelDataRemoved(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	_, err := cupidAPI.FetchHotelData(context.Background(), 42)
+	assert.True(t, errors.Is(err, ports.ErrHotelRemoved), "got %v", err)
+}
+
+func TestCupidContractFetchHotelReviews(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	responses, err := cupidAPI.FetchHotelReviews(context.Background(), 1641879, &dto.ReviewFetchOptions{ReviewCount: 10})
+	require.NoError(t, err)
+	reviews, err := responses.ToReviewDataList(1641879)
+	require.NoError(t, err)
+	require.Len(t, reviews, 3)
+
+	first := reviews[0]
+	assert.Equal(t, int64(1641879), first.HotelID, "hotel_id")
+	assert.Equal(t, int64(88120031), first.ReviewID, "review_id")
+	assert.Equal(t, int32(9), first.AverageScore, "average_score")
+	assert.Equal(t, "gb", first.Country, "country")
+	assert.Equal(t, "couple", first.Type, "type")
+	assert.Equal(t, "Emma", first.Name, "name")
+	assert.Equal(t, time.Date(2024, 3, 15, 10, 22, 31, 0, time.UTC), first.Date, "date")
+	assert.Equal(t, "Lovely stay", first.Headline, "headline")
+	assert.Equal(t, "en", first.Language, "language")
+	assert.Equal(t, "Great location and friendly staff.", first.Pros, "pros")
+	assert.Equal(t, "Small room.", first.Cons, "cons")
+	assert.Equal(t, "Nuitee", first.Source, "source")
+	assert.Equal(t, []int32{10, 9, 8, 8},
+		[]int32{first.ScoreLocation, first.ScoreService, first.ScoreValue, first.ScoreFacilities}, "category scores")
+
+	assert.Equal(t, time.Date(2023, 11, 2, 0, 0, 0, 0, time.UTC), reviews[1].Date, "date without a time")
+	assert.True(t, reviews[2].HasUnparsedDate(), "an unknown date format keeps its raw value only")
+
+	page, err := cupidAPI.FetchHotelReviews(context.Background(), 1641879, &dto.ReviewFetchOptions{ReviewCount: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, *page, 1)
+	assert.Equal(t, int64(88120032), (*page)[0].ReviewID, "offset skips the most recent reviews")
+
+	none, err := cupidAPI.FetchHotelReviews(context.Background(), 317597, nil)
+	require.NoError(t, err)
+	assert.Empty(t, *none)
+}
+
+func TestCupidContractFetchTranslations(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	response, err := cupidAPI.FetchTranslations(context.Background(), "1641879", &dto.TranslationFetchOptions{Lang: "fr"})
+	require.NoError(t, err)
+
+	translation, err := response.ToHotelTranslations("fr")
+	require.NoError(t, err)
+
+	assert.Equal(t, "fr", translation.Lang, "lang")
+	assert.Equal(t, int64(1641879), translation.HotelID, "hotel_id")
+	assert.Equal(t, "Hôtel Le Marais", translation.Name, "hotel_name")
+	assert.Equal(t, "<p>Un hôtel de charme au cœur du Marais.</p>", translation.Description, "description")
+	assert.Equal(t, "Un hôtel de charme au cœur du Marais.", translation.MarkdownDescription, "markdown_description")
+	assert.Equal(t, "Réservé aux adultes.", translation.ImportantInfo, "important_info")
+	assert.Equal(t, "Parking payant à proximité", translation.Parking, "parking")
+	assert.Contains(t, string(translation.Facilities), `"name":"WiFi gratuit"`, "facilities")
+	assert.Contains(t, string(translation.Policies), "Les animaux ne sont pas admis.", "policies")
+	assert.JSONEq(t, rawFixtureField(t, "translation_1641879_fr.json", "photos"), string(translation.Photos), "photos")
+	assert.JSONEq(t, rawFixtureField(t, "translation_1641879_fr.json", "rooms"), string(translation.Rooms), "rooms")
+
+	_, err = cupidAPI.FetchTranslations(context.Background(), "1641879", &dto.TranslationFetchOptions{Lang: "es"})
+	assert.Error(t, err, "no recorded translation")
+}
+
+func TestCupidContractFieldRename(t *testing.T) {
+	dir := cupidtest.RenameField(t, cupidtest.Dir(), "reviews_1641879.json", "average_score", "score")
+	cupidAPI := newContractCupidAPI(t, dir)
+
+	_, err := cupidAPI.FetchHotelReviews(context.Background(), 1641879, &dto.ReviewFetchOptions{ReviewCount: 10})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "score"`)
+}
+
+func newHardeningTestAdapter(t *testing.T, handler http.HandlerFunc, maxResponseBytes int64) *CupidAPIAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewCupidAPIAdapter(&APIConfig{
+		BaseURL:          server.URL,
+		APIKey:           cupidtest.APIKey,
+		Timeout:          300 * time.Millisecond,
+		RateLimit:        1000,
+		BurstLimit:       100,
+		MaxResponseBytes: maxResponseBytes,
+		CircuitBreaker:   &CircuitBreakerConfig{},
+	})
+}
+
+func TestCupidAPIRejectsOversizedResponses(t *testing.T) {
+	cupidAPI := newHardeningTestAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"hotel_id":1641879,"description":"`+strings.Repeat("x", 4096)+`"}`)
+	}, 1024)
+
+	_, err := cupidAPI.FetchHotelData(context.Background(), 1641879)
+	assert.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+	assert.True(t, cupidAPI.isRetryableError(err), "an oversized response is retried")
+}
+
+func TestCupidAPIAbortsSlowResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "slow headers",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			},
+		},
+		{
+			name: "slow body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.WriteString(w, `{"hotel_id":`)
+				for {
+					if _, err := io.WriteString(w, " "); err != nil {
+						return
+					}
+					w.(http.Flusher).Flush()
+					select {
+					case <-r.Context().Done():
+						return
+					case <-time.After(20 * time.Millisecond):
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupidAPI := newHardeningTestAdapter(t, tt.handler, 0)
+
+			start := time.Now()
+			_, err := cupidAPI.FetchHotelData(context.Background(), 1641879)
+			assert.Error(t, err)
+			assert.Less(t, time.Since(start), 2*time.Second, "the request is bounded by the client timeout")
+			assert.True(t, cupidAPI.isRetryableError(err), "a timed out request is retried")
+		})
+	}
+}
+
+func TestCupidAPIErrorBodyIsTruncated(t *testing.T) {
+	cupidAPI := newHardeningTestAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = io.WriteString(w, strings.Repeat("x", 100_000))
+	}, 0)
+
+	_, err := cupidAPI.FetchHotelData(context.Background(), 1641879)
+	var statusErr *httpStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.Len(t, statusErr.Body, 512)
+	assert.True(t, cupidAPI.isRetryableError(err))
+}
+
+func TestFetchHotelReviewsEmulatesTheOffset(t *testing.T) {
+	var requestedPath string
+	cupidAPI := newHardeningTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		_, _ = io.WriteString(w, `[{"review_id":1},{"review_id":2},{"review_id":3},{"review_id":4},{"review_id":5}]`)
+	}, 0)
+
+	reviews, err := cupidAPI.FetchHotelReviews(context.Background(), 1641879, &dto.ReviewFetchOptions{ReviewCount: 3, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "/property/reviews/1641879/5", requestedPath, "the offset is added to the requested count")
+	require.Len(t, *reviews, 3)
+	assert.Equal(t, int64(3), (*reviews)[0].ReviewID, "the first offset reviews are dropped")
+
+	reviews, err = cupidAPI.FetchHotelReviews(context.Background(), 1641879, &dto.ReviewFetchOptions{ReviewCount: 10, Offset: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "/property/reviews/1641879/15", requestedPath)
+	assert.Empty(t, *reviews, "an offset past the upstream reviews returns none")
+}
+
+func newRetryTestAdapter(t *testing.T, handler http.HandlerFunc) *CupidAPIAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewCupidAPIAdapter(&APIConfig{
+		BaseURL:        server.URL,
+		APIKey:         cupidtest.APIKey,
+		Timeout:        5 * time.Second,
+		RateLimit:      1000,
+		BurstLimit:     100,
+		MaxRetries:     5,
+		RetryInterval:  20 * time.Millisecond,
+		CircuitBreaker: &CircuitBreakerConfig{ReadyToTrip: func(gobreaker.Counts) bool { return false }},
+	})
+}
+
+func TestRetryBudgetIsSharedByTheCallsOfAMessage(t *testing.T) {
+	var requests atomic.Int32
+	cupidAPI := newRetryTestAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ctx := ports.WithRetryBudget(context.Background(), 150*time.Millisecond)
+
+	_, err := cupidAPI.FetchHotelData(ctx, 1641879)
+	require.ErrorIs(t, err, ports.ErrRetryBudgetExceeded)
+	assert.Greater(t, requests.Load(), int32(1), "the first call retries within the budget")
+
+	deadline, ok := ports.RetryDeadline(ctx)
+	require.True(t, ok)
+	time.Sleep(time.Until(deadline))
+	requests.Store(0)
+	_, err = cupidAPI.FetchHotelReviews(ctx, 1641879, &dto.ReviewFetchOptions{ReviewCount: 10})
+	require.ErrorIs(t, err, ports.ErrRetryBudgetExceeded)
+	assert.Equal(t, int32(1), requests.Load(), "a later call of the message is not retried once the budget is spent")
+}
+
+func TestRetryBudgetCutsTheLastAttemptShort(t *testing.T) {
+	var requests atomic.Int32
+	cupidAPI := newRetryTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		<-r.Context().Done()
+	})
+	ctx := ports.WithRetryBudget(context.Background(), 200*time.Millisecond)
+
+	start := time.Now()
+	_, err := cupidAPI.FetchHotelData(ctx, 1641879)
+	require.ErrorIs(t, err, ports.ErrRetryBudgetExceeded)
+	assert.Less(t, time.Since(start), time.Second, "a retry does not outlast the budget by an API timeout")
+}
+
+func TestRetriesAreUnboundedWithoutABudget(t *testing.T) {
+	var requests atomic.Int32
+	cupidAPI := newRetryTestAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := cupidAPI.FetchHotelData(context.Background(), 1641879)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ports.ErrRetryBudgetExceeded)
+	assert.Equal(t, int32(6), requests.Load(), "every retry is attempted")
+}
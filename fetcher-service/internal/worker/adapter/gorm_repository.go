@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
 	"github.com/victoragudo/hotel-management-system/pkg/constants"
 	"github.com/victoragudo/hotel-management-system/pkg/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormRepository struct {
@@ -19,20 +22,120 @@ func NewGormRepository(database *gorm.DB) (ports.RepositoryPort, error) {
 	return &GormRepository{db: database}, nil
 }
 
-func (r *GormRepository) UpsertHotel(ctx context.Context, hotel *entities.HotelData) error {
-	var existingHotel entities.HotelData
-	err := r.db.WithContext(ctx).Where(constants.HotelId+" = ?", hotel.HotelID).First(&existingHotel).Error
+// UpsertHotel stores hotel, merging it into the stored row of the hotel when there is one, and
+// returns that row as it was before, or nil for a new hotel. The row is locked from the read to
+// the write, so the field groups a higher-priority source writes in between are not overwritten
+// with the values read before it.
+func (r *GormRepository) UpsertHotel(ctx context.Context, hotel *entities.HotelData, write entities.SourceWrite) (*entities.HotelData, error) {
+	var existingHotel *entities.HotelData
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if existingHotel, err = lockHotel(tx, hotel.HotelID); err != nil {
+			return err
+		}
+
+		if existingHotel == nil {
+			if _, err := hotel.MergeSources(nil, write, time.Now()); err != nil {
+				return err
+			}
+			// The search-service fallback may store the hotel concurrently; the unique hotel_id
+			// index turns the insert that loses the race into a no-op, and the hotel is merged
+			// into the row the fallback stored instead.
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: constants.HotelId}}, DoNothing: true}).Create(hotel)
+			if result.Error != nil || result.RowsAffected > 0 {
+				return result.Error
+			}
+			if existingHotel, err = lockHotel(tx, hotel.HotelID); err != nil {
+				return err
+			}
+			if existingHotel == nil {
+				return fmt.Errorf("hotel %d conflicted on insert but is not stored", hotel.HotelID)
+			}
+		}
+
+		hotel.ID = existingHotel.ID
+		hotel.CreatedAt = existingHotel.CreatedAt
+		hotel.ArchivedReviewCount = existingHotel.ArchivedReviewCount
+		// Geo enrichment runs after the upsert and may be disabled; keep what it found last.
+		hotel.NearbyAttractions = existingHotel.NearbyAttractions
+
+		if _, err := hotel.MergeSources(existingHotel, write, time.Now()); err != nil {
+			return err
+		}
+
+		// Keep the ids other sources registered for this hotel.
+		sourceMappings := existingHotel.GetSourceMappings()
+		maps.Copy(sourceMappings, hotel.GetSourceMappings())
+		if err := hotel.SetSourceMappings(sourceMappings); err != nil {
+			return err
+		}
+
+		return tx.Save(hotel).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existingHotel, nil
+}
 
+// lockHotel returns the stored row of hotelID, or nil when there is none. The row stays locked
+// until the transaction of tx ends.
+func lockHotel(tx *gorm.DB, hotelID int64) (*entities.HotelData, error) {
+	var stored entities.HotelData
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(constants.HotelId+" = ?", hotelID).First(&stored).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return r.db.WithContext(ctx).Create(hotel).Error
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
+	return &stored, nil
+}
 
-	hotel.ID = existingHotel.ID
-	hotel.CreatedAt = existingHotel.CreatedAt
-	return r.db.WithContext(ctx).Save(hotel).Error
+func (r *GormRepository) CreateHotelChange(ctx context.Context, change *entities.HotelChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+// MarkHotelRemoved updates the columns directly: the update hooks of HotelData would reset the
+// status to active.
+func (r *GormRepository) MarkHotelRemoved(ctx context.Context, hotelID int64, status string, nextUpdateAt time.Time) (*entities.HotelData, error) {
+	var existingHotel entities.HotelData
+	err := r.db.WithContext(ctx).Where(constants.HotelId+" = ?", hotelID).First(&existingHotel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Where("id = ?", existingHotel.ID).
+		UpdateColumns(map[string]any{
+			"status":         status,
+			"next_update_at": nextUpdateAt,
+			"updated_at":     time.Now(),
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	return &existingHotel, nil
+}
+
+// UpdateNearbyAttractions updates the column directly, like MarkHotelRemoved, so the update
+// hooks of HotelData do not run.
+func (r *GormRepository) UpdateNearbyAttractions(ctx context.Context, hotelID int64, attractions []entities.Attraction) error {
+	var hotel entities.HotelData
+	if err := hotel.SetNearbyAttractions(attractions); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Where(constants.HotelId+" = ?", hotelID).
+		UpdateColumns(map[string]any{
+			"nearby_attractions": hotel.NearbyAttractions,
+			"updated_at":         time.Now(),
+		}).Error
 }
 
 func (r *GormRepository) UpsertHotelTranslations(ctx context.Context, translations *entities.HotelTranslation) error {
@@ -40,10 +143,18 @@ func (r *GormRepository) UpsertHotelTranslations(ctx context.Context, translatio
 	err := r.db.WithContext(ctx).Where(fmt.Sprintf("%s = ? AND %s = ?", constants.HotelId, constants.Lang), translations.HotelID, translations.Lang).First(&existingTranslations).Error
 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return r.db.WithContext(ctx).Create(translations).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		err = r.db.WithContext(ctx).Create(translations).Error
+		if err == nil || !r.isDuplicateKey(err) {
+			return err
+		}
+
+		if err := r.db.WithContext(ctx).Where(fmt.Sprintf("%s = ? AND %s = ?", constants.HotelId, constants.Lang), translations.HotelID, translations.Lang).First(&existingTranslations).Error; err != nil {
+			return err
 		}
-		return err
 	}
 
 	translations.ID = existingTranslations.ID
@@ -51,8 +162,29 @@ func (r *GormRepository) UpsertHotelTranslations(ctx context.Context, translatio
 	return r.db.WithContext(ctx).Save(translations).Error
 }
 
+// CreateReview inserts the review, falling back to an update when another worker inserted the
+// same review_id between the caller's lookup and this insert.
 func (r *GormRepository) CreateReview(ctx context.Context, review *entities.ReviewData) error {
-	return r.db.WithContext(ctx).Create(review).Error
+	err := r.db.WithContext(ctx).Create(review).Error
+	if err == nil || !r.isDuplicateKey(err) {
+		return err
+	}
+
+	existing, findErr := r.GetReviewByReviewID(ctx, review.ReviewID)
+	if findErr != nil {
+		return err
+	}
+
+	review.ID = existing.ID
+	review.CreatedAt = existing.CreatedAt
+	return r.UpdateReview(ctx, review)
+}
+
+func (r *GormRepository) isDuplicateKey(err error) bool {
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
 }
 
 func (r *GormRepository) UpdateReview(ctx context.Context, review *entities.ReviewData) error {
@@ -65,6 +197,25 @@ func (r *GormRepository) GetReviewByReviewID(ctx context.Context, reviewID int64
 	return &e, err
 }
 
+// GetReviewByFingerprint returns the hotel's review with the content fingerprint.
+func (r *GormRepository) GetReviewByFingerprint(ctx context.Context, hotelID int64, fingerprint string) (*entities.ReviewData, error) {
+	var e entities.ReviewData
+	err := r.db.WithContext(ctx).
+		Where(constants.HotelId+" = ? AND "+constants.Fingerprint+" = ?", hotelID, fingerprint).
+		Order("created_at ASC").
+		First(&e).Error
+	return &e, err
+}
+
+// IsReviewMerged reports whether the review id belongs to a review soft-deleted as a duplicate.
+func (r *GormRepository) IsReviewMerged(ctx context.Context, reviewID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.ReviewData{}).
+		Where(constants.ReviewId+" = ? AND deleted_at IS NOT NULL", reviewID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (r *GormRepository) GetHotelIdByPk(ctx context.Context, id string) int64 {
 	var hotelId int64
 	err := r.db.WithContext(ctx).Model(&entities.HotelData{}).
@@ -77,13 +228,33 @@ func (r *GormRepository) GetHotelIdByPk(ctx context.Context, id string) int64 {
 	return hotelId
 }
 
-func (r *GormRepository) ReviewCountByHotelId(ctx context.Context, hotelId int64) int64 {
-	var count int64
-	err := r.db.WithContext(ctx).Model(&entities.ReviewData{}).Where("hotel_id = ?", hotelId).Count(&count)
-	if err.RowsAffected == 0 {
-		return 0
+func (r *GormRepository) HotelReviewCount(ctx context.Context, hotelId int64) (int64, error) {
+	var reviewCount int64
+	err := r.db.WithContext(ctx).Model(&entities.HotelData{}).
+		Where(constants.HotelId+" = ?", hotelId).
+		Select("review_count").
+		First(&reviewCount).Error
+	if err != nil {
+		return 0, err
 	}
-	return count
+	return reviewCount, nil
+}
+
+func (r *GormRepository) GetReviewFetchState(ctx context.Context, hotelId int64) (*entities.ReviewFetchState, error) {
+	var state entities.ReviewFetchState
+	err := r.db.WithContext(ctx).Where(constants.HotelId+" = ?", hotelId).First(&state).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *GormRepository) SaveReviewFetchState(ctx context.Context, state *entities.ReviewFetchState) error {
+	state.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(state).Error
 }
 
 func (r *GormRepository) GetHotelIdByTranslationId(ctx context.Context, id string) int64 {
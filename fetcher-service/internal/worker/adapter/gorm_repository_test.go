@@ -0,0 +1,199 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	importWrite  = entities.SourceWrite{Source: entities.DataSourceImport}
+	fetcherWrite = entities.SourceWrite{Source: entities.DataSourceCupidFetcher}
+)
+
+// newSQLiteGormRepository backs the repository with a SQLite database holding the hotels and
+// reviews tables and their unique hotel_id and review_id indexes. SQLite has no row locks; immediate transactions serialize the
+// writers instead.
+func newSQLiteGormRepository(t *testing.T) (*GormRepository, *gorm.DB) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate", filepath.Join(t.TempDir(), "hotels.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard, DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().CreateTable(&entities.HotelData{}, &entities.ReviewData{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return &GormRepository{db: db}, db
+}
+
+func newSourceHotel(hotelID int64, name, mappingSource, mappingID string) *entities.HotelData {
+	hotel := &entities.HotelData{HotelID: hotelID, CupidID: hotelID, Name: name}
+	_ = hotel.SetSourceMappings(map[string]string{mappingSource: mappingID})
+	return hotel
+}
+
+func loadHotel(t *testing.T, db *gorm.DB, hotelID int64) entities.HotelData {
+	t.Helper()
+	var rows []entities.HotelData
+	require.NoError(t, db.Where(constants.HotelId+" = ?", hotelID).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	return rows[0]
+}
+
+func assertGroupsWrittenBy(t *testing.T, hotel entities.HotelData, source string) {
+	t.Helper()
+	sources := hotel.GetSources()
+	assert.Len(t, sources, len(entities.FieldGroups))
+	for _, group := range entities.FieldGroups {
+		assert.Equal(t, source, sources[group].Source, group)
+	}
+}
+
+func TestUpsertHotelKeepsGroupsOfHigherPrioritySources(t *testing.T) {
+	repository, db := newSQLiteGormRepository(t)
+	ctx := context.Background()
+
+	previous, err := repository.UpsertHotel(ctx, newSourceHotel(7, "Fetcher name", entities.SourceCupid, "1001"), fetcherWrite)
+	require.NoError(t, err)
+	assert.Nil(t, previous, "a new hotel has no previous row")
+
+	previous, err = repository.UpsertHotel(ctx, newSourceHotel(7, "Import name", "import", "I-7"), importWrite)
+	require.NoError(t, err)
+	require.NotNil(t, previous)
+	assert.Equal(t, "Fetcher name", previous.Name, "the previous row is returned")
+
+	stored := loadHotel(t, db, 7)
+	assert.Equal(t, "Fetcher name", stored.Name, "an import does not overwrite the fetcher's fields")
+	assert.Equal(t, map[string]string{entities.SourceCupid: "1001", "import": "I-7"}, stored.GetSourceMappings())
+	assertGroupsWrittenBy(t, stored, entities.DataSourceCupidFetcher)
+
+	_, err = repository.UpsertHotel(ctx, newSourceHotel(7, "Forced name", "import", "I-7"), entities.SourceWrite{Source: entities.DataSourceImport, Force: true})
+	require.NoError(t, err)
+	stored = loadHotel(t, db, 7)
+	assert.Equal(t, "Forced name", stored.Name)
+	assertGroupsWrittenBy(t, stored, entities.DataSourceImport)
+}
+
+func TestUpsertHotelHoldsTheRowAgainstInterleavedWrites(t *testing.T) {
+	repository, db := newSQLiteGormRepository(t)
+	ctx := context.Background()
+	_, err := repository.UpsertHotel(ctx, newSourceHotel(7, "Import name", "import", "I-7"), importWrite)
+	require.NoError(t, err)
+
+	// A fetcher write starts once the import has read the stored row, and is given the time to
+	// commit before the import writes.
+	var started atomic.Bool
+	fetcherDone := make(chan error, 1)
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:interleave_fetcher", func(tx *gorm.DB) {
+		if tx.Statement.Table != "hotels" || !started.CompareAndSwap(false, true) {
+			return
+		}
+		go func() {
+			_, err := repository.UpsertHotel(ctx, newSourceHotel(7, "Fetcher name", entities.SourceCupid, "1001"), fetcherWrite)
+			fetcherDone <- err
+		}()
+		time.Sleep(200 * time.Millisecond)
+	}))
+
+	_, err = repository.UpsertHotel(ctx, newSourceHotel(7, "Import name v2", "import", "I-7"), importWrite)
+	require.NoError(t, err)
+	require.True(t, started.Load())
+	select {
+	case err := <-fetcherDone:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the fetcher write did not finish")
+	}
+
+	stored := loadHotel(t, db, 7)
+	assert.Equal(t, "Fetcher name", stored.Name, "the import does not overwrite what the fetcher wrote after its read")
+	assert.Equal(t, map[string]string{entities.SourceCupid: "1001", "import": "I-7"}, stored.GetSourceMappings())
+	assertGroupsWrittenBy(t, stored, entities.DataSourceCupidFetcher)
+}
+
+func TestUpsertHotelMergesIntoHotelStoredByRacingFallback(t *testing.T) {
+	repository, db := newSQLiteGormRepository(t)
+	fallbackWrite := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	// The search-service fallback stores the hotel after the upsert found none and before its insert.
+	var raced bool
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:race_fallback", func(tx *gorm.DB) {
+		if raced || tx.Statement.Table != "hotels" {
+			return
+		}
+		raced = true
+		fallback := newSourceHotel(7, "Fallback name", "expedia", "E-7")
+		_, err := fallback.MergeSources(nil, entities.SourceWrite{Source: entities.DataSourceFallback}, fallbackWrite)
+		require.NoError(t, err)
+		require.NoError(t, tx.Session(&gorm.Session{NewDB: true}).Table("hotels").Create(fallback).Error)
+	}))
+
+	previous, err := repository.UpsertHotel(context.Background(), newSourceHotel(7, "Import name", "import", "I-7"), importWrite)
+	require.NoError(t, err)
+	require.True(t, raced)
+	require.NotNil(t, previous, "the row the fallback stored is returned as the previous one")
+	assert.Equal(t, "Fallback name", previous.Name)
+
+	stored := loadHotel(t, db, 7)
+	assert.Equal(t, "Fallback name", stored.Name, "the fallback's fields outrank the import")
+	assert.Equal(t, map[string]string{"expedia": "E-7", "import": "I-7"}, stored.GetSourceMappings())
+	for _, group := range entities.FieldGroups {
+		source := stored.GetSources()[group]
+		assert.Equal(t, entities.DataSourceFallback, source.Source, group)
+		assert.True(t, fallbackWrite.Equal(source.UpdatedAt), group)
+	}
+}
+
+func TestUpsertHotelStoresTheAttributionOfEveryGroup(t *testing.T) {
+	repository, db := newSQLiteGormRepository(t)
+	before := time.Now()
+
+	_, err := repository.UpsertHotel(context.Background(), newSourceHotel(7, "Fetcher name", entities.SourceCupid, "1001"), fetcherWrite)
+	require.NoError(t, err)
+
+	var attribution map[string]map[string]any
+	require.NoError(t, json.Unmarshal(loadHotel(t, db, 7).Sources, &attribution))
+	assert.ElementsMatch(t, entities.FieldGroups, slices.Collect(maps.Keys(attribution)), "every field group is attributed, and nothing else")
+	for group, source := range attribution {
+		assert.ElementsMatch(t, []string{"source", "updated_at"}, slices.Collect(maps.Keys(source)), group)
+		assert.Equal(t, entities.DataSourceCupidFetcher, source["source"], group)
+		updatedAt, err := time.Parse(time.RFC3339Nano, source["updated_at"].(string))
+		require.NoError(t, err, group)
+		assert.False(t, updatedAt.Before(before.Truncate(time.Second)), group)
+	}
+}
+
+func TestCreateReviewUpdatesAReviewInsertedConcurrently(t *testing.T) {
+	repository, db := newSQLiteGormRepository(t)
+	ctx := context.Background()
+
+	// Another worker inserted the review after the caller found none.
+	stored := &entities.ReviewData{HotelID: 7, ReviewID: 100, AverageScore: 6, Headline: "Fine", Date: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, db.Create(stored).Error)
+
+	review := &entities.ReviewData{HotelID: 7, ReviewID: 100, AverageScore: 9, Headline: "Lovely stay", Date: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, repository.CreateReview(ctx, review), "the duplicate key is turned into an update")
+
+	var reviews []entities.ReviewData
+	require.NoError(t, db.Where(constants.ReviewId+" = ?", 100).Find(&reviews).Error)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, stored.ID, reviews[0].ID)
+	assert.Equal(t, "Lovely stay", reviews[0].Headline)
+	assert.Equal(t, int32(9), reviews[0].AverageScore)
+}
@@ -0,0 +1,166 @@
+package adapter
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/geo"
+	"github.com/victoragudo/hotel-management-system/pkg/httpclient"
+)
+
+const (
+	DefaultOverpassURL = "https://overpass-api.de/api/interpreter"
+
+	// overpassMaxPerCategory keeps the nearest attractions of each category, so a city centre
+	// full of restaurants does not crowd out its museum.
+	overpassMaxPerCategory = 10
+)
+
+// overpassCategories maps each attraction category to the OpenStreetMap tag that marks it.
+var overpassCategories = []struct {
+	category string
+	key      string
+	value    string
+}{
+	{"museum", "tourism", "museum"},
+	{"restaurant", "amenity", "restaurant"},
+	{"beach", "natural", "beach"},
+	{"park", "leisure", "park"},
+}
+
+type OverpassConfig struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// OverpassAdapter finds attractions with the OpenStreetMap Overpass API, which needs no key.
+type OverpassAdapter struct {
+	client *http.Client
+	url    string
+}
+
+func NewOverpassAdapter(config OverpassConfig) *OverpassAdapter {
+	if config.URL == "" {
+		config.URL = DefaultOverpassURL
+	}
+	return &OverpassAdapter{
+		client: httpclient.NewClient(config.Timeout, httpclient.DefaultTransportConfig()),
+		url:    config.URL,
+	}
+}
+
+type overpassResponse struct {
+	Elements []overpassElement `json:"elements"`
+}
+
+type overpassElement struct {
+	Lat    float64           `json:"lat"`
+	Lon    float64           `json:"lon"`
+	Center *overpassCenter   `json:"center"`
+	Tags   map[string]string `json:"tags"`
+}
+
+type overpassCenter struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func (o *OverpassAdapter) GetNearbyAttractions(ctx context.Context, lat, lon float64, radius int) ([]entities.Attraction, error) {
+	form := url.Values{"data": {overpassQuery(lat, lon, radius)}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("accept", "application/json")
+
+	httpResponse, err := o.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("overpass request failed: %w", err)
+	}
+	httpclient.LimitBody(httpResponse, httpclient.DefaultMaxResponseBytes)
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(httpResponse.Body)
+
+	if httpResponse.StatusCode >= 400 {
+		return nil, &httpStatusError{StatusCode: httpResponse.StatusCode, Body: httpclient.ErrorBody(httpResponse.Body)}
+	}
+
+	var response overpassResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode overpass response: %w", err)
+	}
+
+	return nearestAttractions(lat, lon, response.Elements), nil
+}
+
+// overpassQuery selects the nodes, ways and relations of every attraction category within
+// radius meters, with the center of ways and relations as their position.
+func overpassQuery(lat, lon float64, radius int) string {
+	var query strings.Builder
+	query.WriteString("[out:json][timeout:10];(")
+	for _, c := range overpassCategories {
+		fmt.Fprintf(&query, `nwr["%s"="%s"](around:%d,%f,%f);`, c.key, c.value, radius, lat, lon)
+	}
+	query.WriteString(");out center tags;")
+	return query.String()
+}
+
+// nearestAttractions converts the named elements into attractions, nearest first, keeping one
+// attraction per name and category and at most overpassMaxPerCategory per category.
+func nearestAttractions(lat, lon float64, elements []overpassElement) []entities.Attraction {
+	attractions := make([]entities.Attraction, 0, len(elements))
+	seen := make(map[string]bool)
+	for _, element := range elements {
+		name := strings.TrimSpace(element.Tags["name"])
+		category := elementCategory(element.Tags)
+		if name == "" || category == "" || seen[category+"\x00"+name] {
+			continue
+		}
+		seen[category+"\x00"+name] = true
+
+		elementLat, elementLon := element.Lat, element.Lon
+		if element.Center != nil {
+			elementLat, elementLon = element.Center.Lat, element.Center.Lon
+		}
+		attractions = append(attractions, entities.Attraction{
+			Name:           name,
+			Category:       category,
+			DistanceMeters: int(math.Round(geo.Haversine(lat, lon, elementLat, elementLon) * 1000)),
+		})
+	}
+
+	slices.SortStableFunc(attractions, func(a, b entities.Attraction) int {
+		return cmp.Compare(a.DistanceMeters, b.DistanceMeters)
+	})
+
+	perCategory := make(map[string]int)
+	nearest := attractions[:0]
+	for _, attraction := range attractions {
+		if perCategory[attraction.Category] < overpassMaxPerCategory {
+			perCategory[attraction.Category]++
+			nearest = append(nearest, attraction)
+		}
+	}
+	return nearest
+}
+
+func elementCategory(tags map[string]string) string {
+	for _, c := range overpassCategories {
+		if tags[c.key] == c.value {
+			return c.category
+		}
+	}
+	return ""
+}
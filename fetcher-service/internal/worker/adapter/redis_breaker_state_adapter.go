@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/redislock"
+)
+
+// RedisBreakerStateAdapter keeps the worker that opened the shared circuit in a key that
+// expires on its own, so a worker that dies with the circuit open does not hold it forever.
+type RedisBreakerStateAdapter struct {
+	client *redis.Client
+	key    string
+}
+
+func NewRedisBreakerStateAdapter(addr, password string, db int, key string) ports.BreakerStatePort {
+	c := redis.NewClient(&redis.Options{Addr: addr, Password: password, DB: db})
+	return &RedisBreakerStateAdapter{client: c, key: key}
+}
+
+func (r *RedisBreakerStateAdapter) Open(ctx context.Context, owner string, ttl time.Duration) error {
+	return r.client.Set(ctx, r.key, owner, ttl).Err()
+}
+
+func (r *RedisBreakerStateAdapter) Owner(ctx context.Context) (string, error) {
+	return redislock.Owner(ctx, r.client, r.key)
+}
+
+func (r *RedisBreakerStateAdapter) Clear(ctx context.Context, owner string) error {
+	return redislock.Release(ctx, r.client, r.key, owner)
+}
+
+func (r *RedisBreakerStateAdapter) Close() error {
+	return r.client.Close()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/redislock"
 )
 
 type RedisLockAdapter struct {
@@ -27,6 +28,14 @@ func (r *RedisLockAdapter) Release(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r *RedisLockAdapter) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	return redislock.Acquire(ctx, r.client, key, owner, ttl)
+}
+
+func (r *RedisLockAdapter) ReleaseLease(ctx context.Context, key, owner string) error {
+	return redislock.Release(ctx, r.client, key, owner)
+}
+
 func (r *RedisLockAdapter) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLeaseIsExclusive(t *testing.T) {
+	server := miniredis.RunT(t)
+	lock := NewRedisLockAdapter(server.Addr(), "", 0)
+	t.Cleanup(func() { _ = lock.Close() })
+	ctx := context.Background()
+
+	leased, err := lock.AcquireLease(ctx, "hotel_lease:7", "worker-a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, leased)
+
+	leased, err = lock.AcquireLease(ctx, "hotel_lease:7", "worker-b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, leased, "a held lease is not granted to another worker")
+
+	require.NoError(t, lock.ReleaseLease(ctx, "hotel_lease:7", "worker-a"))
+	leased, err = lock.AcquireLease(ctx, "hotel_lease:7", "worker-b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, leased, "a released lease can be taken")
+}
+
+func TestRedisLeaseReleaseKeepsLeaseTakenOver(t *testing.T) {
+	server := miniredis.RunT(t)
+	lock := NewRedisLockAdapter(server.Addr(), "", 0)
+	t.Cleanup(func() { _ = lock.Close() })
+	ctx := context.Background()
+
+	_, err := lock.AcquireLease(ctx, "hotel_lease:7", "worker-a", time.Second)
+	require.NoError(t, err)
+	server.FastForward(2 * time.Second)
+
+	leased, err := lock.AcquireLease(ctx, "hotel_lease:7", "worker-b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, leased, "an expired lease can be taken over")
+
+	require.NoError(t, lock.ReleaseLease(ctx, "hotel_lease:7", "worker-a"))
+	owner, err := server.Get("hotel_lease:7")
+	require.NoError(t, err)
+	assert.Equal(t, "worker-b", owner, "the previous holder does not release the new holder's lease")
+}
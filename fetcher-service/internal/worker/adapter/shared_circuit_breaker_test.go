@@ -0,0 +1,213 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/ports"
+	"github.com/victoragudo/hotel-management-system/pkg/api-models/cupidtest"
+)
+
+const testBreakerKey = "worker:cupid_circuit_open"
+
+// cupidStub serves hotel requests, failing them with 503 while down, and counts them.
+type cupidStub struct {
+	down     atomic.Bool
+	requests atomic.Int32
+}
+
+func newCupidStub(t *testing.T) (*cupidStub, string) {
+	t.Helper()
+	stub := &cupidStub{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		stub.requests.Add(1)
+		if stub.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"hotel_id":1641879}`)
+	}))
+	t.Cleanup(server.Close)
+	return stub, server.URL
+}
+
+// newSharedBreakerWorker returns the Cupid API adapter of one worker sharing its circuit
+// through state. Its circuit opens on the first failure and half-opens after 50ms.
+func newSharedBreakerWorker(t *testing.T, baseURL string, state ports.BreakerStatePort, owner string, onError func(error)) *CupidAPIAdapter {
+	t.Helper()
+	cupidAPI := NewCupidAPIAdapter(&APIConfig{
+		BaseURL:    baseURL,
+		APIKey:     cupidtest.APIKey,
+		Timeout:    time.Second,
+		RateLimit:  1000,
+		BurstLimit: 100,
+		CircuitBreaker: &CircuitBreakerConfig{
+			MaxRequests: 1,
+			Timeout:     50 * time.Millisecond,
+			ReadyToTrip: func(counts gobreaker.Counts) bool { return counts.ConsecutiveFailures >= 1 },
+		},
+		SharedBreaker:        state,
+		SharedBreakerTTL:     time.Minute,
+		BreakerOwner:         owner,
+		OnSharedBreakerError: onError,
+	})
+	t.Cleanup(cupidAPI.WaitForBreakerState)
+	return cupidAPI
+}
+
+func newRedisBreakerState(t *testing.T, server *miniredis.Miniredis) ports.BreakerStatePort {
+	t.Helper()
+	state := NewRedisBreakerStateAdapter(server.Addr(), "", 0, testBreakerKey)
+	t.Cleanup(func() { _ = state.Close() })
+	return state
+}
+
+func TestSharedCircuitBreakerStopsTheOtherWorkers(t *testing.T) {
+	server := miniredis.RunT(t)
+	stub, baseURL := newCupidStub(t)
+	workerA := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-a", nil)
+	workerB := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-b", nil)
+	ctx := context.Background()
+
+	stub.down.Store(true)
+	_, err := workerA.FetchHotelData(ctx, 1641879)
+	require.Error(t, err)
+	workerA.WaitForBreakerState()
+
+	owner, err := server.Get(testBreakerKey)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-a", owner)
+	assert.Equal(t, time.Minute, server.TTL(testBreakerKey))
+
+	requests := stub.requests.Load()
+	_, err = workerB.FetchHotelData(ctx, 1641879)
+	assert.ErrorIs(t, err, ports.ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "opened by worker-a")
+	assert.Equal(t, requests, stub.requests.Load(), "a worker does not call the API while another holds the circuit open")
+	assert.Equal(t, gobreaker.StateClosed, workerB.circuitBreaker.State(), "the shared circuit does not trip the local one")
+}
+
+func TestSharedCircuitBreakerClosesWithTheOpeningWorker(t *testing.T) {
+	server := miniredis.RunT(t)
+	stub, baseURL := newCupidStub(t)
+	workerA := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-a", nil)
+	workerB := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-b", nil)
+	ctx := context.Background()
+
+	stub.down.Store(true)
+	_, err := workerA.FetchHotelData(ctx, 1641879)
+	require.Error(t, err)
+	workerA.WaitForBreakerState()
+	require.True(t, server.Exists(testBreakerKey))
+
+	stub.down.Store(false)
+	require.Eventually(t, func() bool {
+		_, err := workerA.FetchHotelData(ctx, 1641879)
+		return err == nil
+	}, 2*time.Second, 20*time.Millisecond, "the half-open probe closes worker-a's circuit")
+	workerA.WaitForBreakerState()
+
+	assert.False(t, server.Exists(testBreakerKey), "closing the circuit clears the shared state")
+	_, err = workerB.FetchHotelData(ctx, 1641879)
+	assert.NoError(t, err)
+}
+
+func TestSharedCircuitBreakerExpires(t *testing.T) {
+	server := miniredis.RunT(t)
+	stub, baseURL := newCupidStub(t)
+	workerB := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-b", nil)
+	ctx := context.Background()
+
+	require.NoError(t, server.Set(testBreakerKey, "worker-a"))
+	server.SetTTL(testBreakerKey, time.Minute)
+	_, err := workerB.FetchHotelData(ctx, 1641879)
+	require.ErrorIs(t, err, ports.ErrCircuitOpen)
+
+	server.FastForward(time.Minute)
+	_, err = workerB.FetchHotelData(ctx, 1641879)
+	assert.NoError(t, err, "a circuit left open by a worker that died expires")
+	assert.Equal(t, int32(1), stub.requests.Load())
+}
+
+func TestSharedCircuitBreakerFallsBackToTheLocalBreaker(t *testing.T) {
+	server := miniredis.RunT(t)
+	stub, baseURL := newCupidStub(t)
+	var mu sync.Mutex
+	var sharedErrors []error
+	worker := newSharedBreakerWorker(t, baseURL, newRedisBreakerState(t, server), "worker-a", func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sharedErrors = append(sharedErrors, err)
+	})
+	server.Close()
+	ctx := context.Background()
+
+	_, err := worker.FetchHotelData(ctx, 1641879)
+	require.NoError(t, err, "requests go through while Redis is down")
+
+	stub.down.Store(true)
+	_, err = worker.FetchHotelData(ctx, 1641879)
+	require.Error(t, err)
+	assert.Equal(t, gobreaker.StateOpen, worker.circuitBreaker.State(), "the local circuit still opens")
+
+	worker.WaitForBreakerState()
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotEmpty(t, sharedErrors)
+}
+
+// blockingBreakerState is a shared circuit state whose writes wait until released.
+type blockingBreakerState struct {
+	release chan struct{}
+	mu      sync.Mutex
+	opened  []string
+}
+
+func (b *blockingBreakerState) Open(_ context.Context, owner string, _ time.Duration) error {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.opened = append(b.opened, owner)
+	return nil
+}
+
+func (b *blockingBreakerState) Owner(context.Context) (string, error) { return "", nil }
+func (b *blockingBreakerState) Clear(context.Context, string) error   { return nil }
+func (b *blockingBreakerState) Close() error                          { return nil }
+
+func TestSharedCircuitBreakerPublishesOutsideTheBreaker(t *testing.T) {
+	stub, baseURL := newCupidStub(t)
+	state := &blockingBreakerState{release: make(chan struct{})}
+	worker := newSharedBreakerWorker(t, baseURL, state, "worker-a", nil)
+	stub.down.Store(true)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := worker.FetchHotelData(context.Background(), 1641879)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the request waited for the shared circuit state to be published")
+	}
+	assert.Equal(t, gobreaker.StateOpen, worker.circuitBreaker.State(), "the circuit breaker is not held by the publish")
+
+	close(state.release)
+	worker.WaitForBreakerState()
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	assert.Equal(t, []string{"worker-a"}, state.opened)
+}
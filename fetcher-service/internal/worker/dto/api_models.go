@@ -3,9 +3,12 @@ package dto
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/phone"
+	"github.com/victoragudo/hotel-management-system/pkg/timezones"
 )
 
 type HotelAPIResponse struct {
@@ -73,6 +76,7 @@ type Photo struct {
 type Facility struct {
 	FacilityID int    `json:"facility_id"`
 	Name       string `json:"name"`
+	Slug       string `json:"slug,omitempty"`
 }
 
 type Policy struct {
@@ -126,6 +130,11 @@ type ReviewAPIResponse struct {
 	Pros         string `json:"pros"`
 	Cons         string `json:"cons"`
 	Source       string `json:"source"`
+	// Category scores are zero when the source does not rate the category.
+	ScoreLocation   int32 `json:"score_location"`
+	ScoreService    int32 `json:"score_service"`
+	ScoreValue      int32 `json:"score_value"`
+	ScoreFacilities int32 `json:"score_facilities"`
 }
 
 type TranslationAPIResponse struct {
@@ -177,12 +186,21 @@ type TranslationInfo struct {
 
 type ReviewFetchOptions struct {
 	ReviewCount int64
+	// Offset skips that many of the hotel's most recent reviews.
+	Offset int64
 }
 
 type TranslationFetchOptions struct {
 	Lang string
 }
 
+// NormalizeFacilities sets the canonical slug of every facility, keeping its raw name.
+func (hotelAPIResponse *HotelAPIResponse) NormalizeFacilities(taxonomy *facilities.Taxonomy) {
+	for i := range hotelAPIResponse.Facilities {
+		hotelAPIResponse.Facilities[i].Slug, _ = taxonomy.Normalize(hotelAPIResponse.Facilities[i].Name)
+	}
+}
+
 func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, error) {
 	hotelData := &entities.HotelData{
 		HotelID:             hotelAPIResponse.HotelID,
@@ -210,6 +228,11 @@ func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, er
 		ImportantInfo:       hotelAPIResponse.ImportantInfo,
 	}
 
+	sourceMappings := map[string]string{entities.SourceCupid: strconv.Itoa(hotelAPIResponse.CupidID)}
+	if err := hotelData.SetSourceMappings(sourceMappings); err != nil {
+		return nil, fmt.Errorf("failed to set source mappings: %w", err)
+	}
+
 	addressMap := map[string]string{
 		"address":     hotelAPIResponse.Address.Address,
 		"city":        hotelAPIResponse.Address.City,
@@ -226,10 +249,15 @@ func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, er
 		"fax":   hotelAPIResponse.Fax,
 		"email": hotelAPIResponse.Email,
 	}
+	setNormalizedNumbers(contactMap, hotelAPIResponse.Address.Country)
 	if err := hotelData.SetContactInfo(contactMap); err != nil {
 		return nil, fmt.Errorf("failed to set contact info: %w", err)
 	}
 
+	if hotelAPIResponse.Latitude != 0 || hotelAPIResponse.Longitude != 0 {
+		hotelData.Timezone, _ = timezones.Lookup(hotelAPIResponse.Latitude, hotelAPIResponse.Longitude)
+	}
+
 	policiesMap := make(map[string]any)
 	for i, policy := range hotelAPIResponse.Policies {
 		policyKey := fmt.Sprintf("policy_%d", i)
@@ -266,6 +294,19 @@ func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, er
 		hotelData.Facilities = facilitiesData
 	}
 
+	amenities := make([]string, 0, len(hotelAPIResponse.Facilities))
+	seen := make(map[string]bool, len(hotelAPIResponse.Facilities))
+	for _, facility := range hotelAPIResponse.Facilities {
+		if facility.Slug == "" || seen[facility.Slug] {
+			continue
+		}
+		seen[facility.Slug] = true
+		amenities = append(amenities, facility.Slug)
+	}
+	if err := hotelData.SetAmenities(amenities); err != nil {
+		return nil, fmt.Errorf("failed to set amenities: %w", err)
+	}
+
 	if hotelAPIResponse.Checkin.CheckinStart != "" || hotelAPIResponse.Checkin.CheckinEnd != "" || hotelAPIResponse.Checkin.Checkout != "" {
 		checkinData, err := json.Marshal(hotelAPIResponse.Checkin)
 		if err != nil {
@@ -321,6 +362,7 @@ func (translationAPIResponse *TranslationAPIResponse) ToHotelTranslations(lang s
 		"fax":   translationAPIResponse.Fax,
 		"email": translationAPIResponse.Email,
 	}
+	setNormalizedNumbers(contactMap, translationAPIResponse.Address.Country)
 	if err := hotelData.SetContactInfo(contactMap); err != nil {
 		return nil, fmt.Errorf("failed to set contact info: %w", err)
 	}
@@ -390,25 +432,25 @@ func (translationAPIResponse *TranslationAPIResponse) ToHotelTranslations(lang s
 
 func (reviewApiResponse *ReviewAPIResponse) ToReviewData(hotelID int64) (*entities.ReviewData, error) {
 	review := &entities.ReviewData{
-		HotelID:      hotelID,
-		ReviewID:     reviewApiResponse.ReviewID,
-		AverageScore: reviewApiResponse.AverageScore,
-		Country:      reviewApiResponse.Country,
-		Type:         reviewApiResponse.Type,
-		Name:         reviewApiResponse.Name,
-		Headline:     reviewApiResponse.Headline,
-		Language:     reviewApiResponse.Language,
-		Pros:         reviewApiResponse.Pros,
-		Cons:         reviewApiResponse.Cons,
-		Source:       reviewApiResponse.Source,
-	}
-
-	if reviewApiResponse.Date != "" {
-		if parsedTime, err := time.Parse("2006-01-02 15:04:05", reviewApiResponse.Date); err == nil {
-			review.Date = parsedTime
-		}
+		HotelID:         hotelID,
+		ReviewID:        reviewApiResponse.ReviewID,
+		AverageScore:    reviewApiResponse.AverageScore,
+		Country:         reviewApiResponse.Country,
+		Type:            reviewApiResponse.Type,
+		Name:            reviewApiResponse.Name,
+		Headline:        reviewApiResponse.Headline,
+		Language:        reviewApiResponse.Language,
+		Pros:            reviewApiResponse.Pros,
+		Cons:            reviewApiResponse.Cons,
+		Source:          reviewApiResponse.Source,
+		ScoreLocation:   reviewApiResponse.ScoreLocation,
+		ScoreService:    reviewApiResponse.ScoreService,
+		ScoreValue:      reviewApiResponse.ScoreValue,
+		ScoreFacilities: reviewApiResponse.ScoreFacilities,
 	}
 
+	review.SetDate(reviewApiResponse.Date)
+
 	return review, nil
 }
 
@@ -429,3 +471,13 @@ func (reviewDataList ReviewDataList) ToReviewDataList(hotelID int64) ([]*entitie
 type TranslationsAPIResponse struct {
 	HotelAPIResponse
 }
+
+// setNormalizedNumbers adds the E.164 form of the phone and fax numbers that can be read, as
+// phone_e164 and fax_e164. Numbers that cannot be read keep only their raw form.
+func setNormalizedNumbers(contact map[string]string, country string) {
+	for _, field := range []string{"phone", "fax"} {
+		if normalized, err := phone.Normalize(contact[field], country); err == nil {
+			contact[field+"_e164"] = normalized
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+)
+
+func TestToHotelDataStoresCanonicalAmenities(t *testing.T) {
+	response := &HotelAPIResponse{
+		HotelID: 7,
+		Facilities: []Facility{
+			{FacilityID: 47, Name: "Free WiFi"},
+			{FacilityID: 3, Name: "WI-FI"},
+			{FacilityID: 12, Name: "Car park"},
+			{FacilityID: 99, Name: "Rooftop Garden"},
+		},
+	}
+
+	response.NormalizeFacilities(facilities.Default())
+	hotelData, err := response.ToHotelData()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `["wifi", "parking", "rooftop_garden"]`, string(hotelData.Amenities))
+	assert.JSONEq(t, `[
+		{"facility_id": 47, "name": "Free WiFi", "slug": "wifi"},
+		{"facility_id": 3, "name": "WI-FI", "slug": "wifi"},
+		{"facility_id": 12, "name": "Car park", "slug": "parking"},
+		{"facility_id": 99, "name": "Rooftop Garden", "slug": "rooftop_garden"}
+	]`, string(hotelData.Facilities), "the raw names are kept")
+}
+
+func TestToHotelDataStoresNormalizedContactNumbers(t *testing.T) {
+	response := &HotelAPIResponse{
+		HotelID: 7,
+		Phone:   "(212) 555-0100 ext. 4",
+		Fax:     "ask at reception",
+		Email:   "info@example.com",
+		Address: Address{City: "New York", Country: "us"},
+	}
+
+	hotelData, err := response.ToHotelData()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"phone": "(212) 555-0100 ext. 4",
+		"phone_e164": "+12125550100",
+		"fax": "ask at reception",
+		"email": "info@example.com"
+	}`, string(hotelData.ContactInfo), "an invalid number keeps only its raw form")
+}
@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	MessageStatusSuccess = "success"
+	MessageStatusError   = "error"
+	MessageStatusSkipped = "skipped"
+)
+
+// WorkerMetrics holds the worker's Prometheus collectors. They are registered on their own
+// registry instead of the global one so several instances can coexist in one process.
+type WorkerMetrics struct {
+	Registry *prometheus.Registry
+
+	messagesProcessed *prometheus.CounterVec
+	messageDuration   *prometheus.HistogramVec
+	messageQueueAge   *prometheus.HistogramVec
+	cupidAPICalls     *prometheus.CounterVec
+	dbUpsertDuration  *prometheus.HistogramVec
+	cacheHits         *prometheus.CounterVec
+	cacheMisses       *prometheus.CounterVec
+	dlqMessages       prometheus.Counter
+	circuitRequeues   prometheus.Counter
+	queueDepth        prometheus.Gauge
+	paused            prometheus.Gauge
+}
+
+func NewWorkerMetrics() *WorkerMetrics {
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+
+	metrics := &WorkerMetrics{
+		Registry: registry,
+		messagesProcessed: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "messages_processed_total",
+			Help: "Queue messages processed by type and outcome",
+		}, []string{"type", "status"}),
+		messageDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "message_processing_duration_seconds",
+			Help:    "Time spent processing a queue message",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{"type"}),
+		messageQueueAge: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "message_queue_age_seconds",
+			Help:    "Time a queue message waited between being published and processed",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"type"}),
+		cupidAPICalls: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "cupid_api_calls_total",
+			Help: "Cupid API calls by HTTP status code, error when no response was received",
+		}, []string{"status_code"}),
+		dbUpsertDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_upsert_duration_seconds",
+			Help:    "Time spent persisting fetched entities",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"entity_type"}),
+		cacheHits: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Messages answered from the Redis cache",
+		}, []string{"entity_type"}),
+		cacheMisses: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Messages that had to fetch from the Cupid API",
+		}, []string{"entity_type"}),
+		dlqMessages: factory.NewCounter(prometheus.CounterOpts{
+			Name: "dlq_messages_total",
+			Help: "Messages rejected to the dead letter queue",
+		}),
+		circuitRequeues: factory.NewCounter(prometheus.CounterOpts{
+			Name: "circuit_open_requeues_total",
+			Help: "Messages requeued because the Cupid API circuit was open",
+		}),
+		queueDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "queue_depth",
+			Help: "Messages waiting in the main queue",
+		}),
+		paused: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "worker_paused",
+			Help: "1 while consumption is paused through the admin endpoints or the fleet flag",
+		}),
+	}
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return metrics
+}
+
+func (m *WorkerMetrics) ObserveMessage(messageType, status string, duration time.Duration) {
+	m.messagesProcessed.WithLabelValues(messageType, status).Inc()
+	m.messageDuration.WithLabelValues(messageType).Observe(duration.Seconds())
+}
+
+func (m *WorkerMetrics) ObserveQueueAge(messageType string, age time.Duration) {
+	m.messageQueueAge.WithLabelValues(messageType).Observe(age.Seconds())
+}
+
+// ObserveCupidAPICall counts a Cupid API response. A zero statusCode means the request failed
+// before a response was received.
+func (m *WorkerMetrics) ObserveCupidAPICall(statusCode int) {
+	label := MessageStatusError
+	if statusCode > 0 {
+		label = strconv.Itoa(statusCode)
+	}
+	m.cupidAPICalls.WithLabelValues(label).Inc()
+}
+
+func (m *WorkerMetrics) ObserveUpsert(entityType string, duration time.Duration) {
+	m.dbUpsertDuration.WithLabelValues(entityType).Observe(duration.Seconds())
+}
+
+func (m *WorkerMetrics) CacheHit(entityType string) {
+	m.cacheHits.WithLabelValues(entityType).Inc()
+}
+
+func (m *WorkerMetrics) CacheMiss(entityType string) {
+	m.cacheMisses.WithLabelValues(entityType).Inc()
+}
+
+func (m *WorkerMetrics) DLQMessage() {
+	m.dlqMessages.Inc()
+}
+
+func (m *WorkerMetrics) CircuitOpenRequeue() {
+	m.circuitRequeues.Inc()
+}
+
+func (m *WorkerMetrics) SetQueueDepth(depth int) {
+	m.queueDepth.Set(float64(depth))
+}
+
+func (m *WorkerMetrics) SetPaused(paused bool) {
+	if paused {
+		m.paused.Set(1)
+	} else {
+		m.paused.Set(0)
+	}
+}
@@ -2,10 +2,43 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/victoragudo/hotel-management-system/fetcher-service/internal/worker/dto"
 )
 
+// ErrHotelRemoved is returned by FetchHotelData when the API answers 404 or 410 for the hotel,
+// meaning it is no longer in the catalog. Retrying does not help.
+var ErrHotelRemoved = errors.New("hotel removed from the upstream catalog")
+
+// ErrCircuitOpen is returned while the Cupid API circuit is open, on this worker or on another
+// one sharing its breaker state. The request was not sent, so it is worth trying again later.
+var ErrCircuitOpen = errors.New("cupid api circuit is open")
+
+// ErrRetryBudgetExceeded is returned when retrying a request would run past the time budget
+// of its message. The message is dead-lettered rather than holding the worker any longer.
+var ErrRetryBudgetExceeded = errors.New("retry budget exceeded")
+
+type retryDeadlineKey struct{}
+
+// WithRetryBudget returns a context whose Cupid API calls share a retry budget: once budget has
+// elapsed they stop retrying and fail with ErrRetryBudgetExceeded. A message starts its budget
+// before its first call, so all the requests it makes draw from one budget. Zero leaves
+// retries unbounded.
+func WithRetryBudget(ctx context.Context, budget time.Duration) context.Context {
+	if budget <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryDeadlineKey{}, time.Now().Add(budget))
+}
+
+// RetryDeadline returns when the retry budget of ctx runs out, if it has one.
+func RetryDeadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(retryDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
 type APIClientPort interface {
 	FetchHotelData(ctx context.Context, hotelId int64) (*dto.HotelAPIResponse, error)
 	FetchHotelReviews(ctx context.Context, hotelID int64, options *dto.ReviewFetchOptions) (*dto.ReviewDataList, error)
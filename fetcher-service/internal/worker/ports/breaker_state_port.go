@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// BreakerStatePort shares the Cupid API circuit breaker state across the worker fleet, so one
+// worker tripping its breaker stops the others from sending requests bound to fail.
+type BreakerStatePort interface {
+	// Open publishes that owner opened the circuit, until ttl elapses or owner clears it.
+	Open(ctx context.Context, owner string, ttl time.Duration) error
+	// Owner returns the worker holding the circuit open, or "" when it is closed.
+	Owner(ctx context.Context) (string, error)
+	// Clear closes the circuit, unless a worker other than owner opened it since.
+	Clear(ctx context.Context, owner string) error
+	Close() error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+)
+
+type GeoEnrichmentService interface {
+	// GetNearbyAttractions returns the attractions within radius meters of the coordinates,
+	// nearest first.
+	GetNearbyAttractions(ctx context.Context, lat, lon float64, radius int) ([]entities.Attraction, error)
+}
@@ -8,5 +8,9 @@ import (
 type LockPort interface {
 	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
 	Release(ctx context.Context, key string) error
+	// AcquireLease sets key to owner if it is not held. ReleaseLease only deletes the key while
+	// owner still holds it, so an expired lease taken over by another worker is left alone.
+	AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, key, owner string) error
 	Close() error
 }
@@ -2,18 +2,39 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/victoragudo/hotel-management-system/pkg/entities"
 )
 
 type RepositoryPort interface {
-	UpsertHotel(ctx context.Context, hotel *entities.HotelData) error
+	// UpsertHotel stores the hotel and returns the row it replaced, or nil when it was created.
+	// Field groups a higher-priority source wrote keep their stored values, see
+	// entities.HotelData.MergeSources.
+	UpsertHotel(ctx context.Context, hotel *entities.HotelData, write entities.SourceWrite) (*entities.HotelData, error)
+	CreateHotelChange(ctx context.Context, change *entities.HotelChange) error
+	// MarkHotelRemoved sets the hotel's status and next update without touching its data, and
+	// returns the row as it was before, or nil when the hotel is not stored.
+	MarkHotelRemoved(ctx context.Context, hotelID int64, status string, nextUpdateAt time.Time) (*entities.HotelData, error)
+	// UpdateNearbyAttractions replaces the attractions stored for the hotel.
+	UpdateNearbyAttractions(ctx context.Context, hotelID int64, attractions []entities.Attraction) error
 	UpsertHotelTranslations(ctx context.Context, translations *entities.HotelTranslation) error
 	CreateReview(ctx context.Context, review *entities.ReviewData) error
 	UpdateReview(ctx context.Context, review *entities.ReviewData) error
 	GetReviewByReviewID(ctx context.Context, reviewID int64) (*entities.ReviewData, error)
+	// GetReviewByFingerprint returns the oldest review of the hotel with the content fingerprint,
+	// or gorm.ErrRecordNotFound when there is none.
+	GetReviewByFingerprint(ctx context.Context, hotelID int64, fingerprint string) (*entities.ReviewData, error)
+	// IsReviewMerged reports whether the review id belongs to a review soft-deleted as a duplicate.
+	IsReviewMerged(ctx context.Context, reviewID int64) (bool, error)
 	GetHotelIdByPk(ctx context.Context, id string) int64
-	ReviewCountByHotelId(ctx context.Context, hotelId int64) int64
+	// HotelReviewCount returns the review count the Cupid API reported for the hotel. It returns
+	// gorm.ErrRecordNotFound when the hotel is not stored.
+	HotelReviewCount(ctx context.Context, hotelId int64) (int64, error)
+	// GetReviewFetchState returns the review fetch bookkeeping of the hotel, or nil when its
+	// reviews were never fetched.
+	GetReviewFetchState(ctx context.Context, hotelId int64) (*entities.ReviewFetchState, error)
+	SaveReviewFetchState(ctx context.Context, state *entities.ReviewFetchState) error
 	GetHotelIdByTranslationId(ctx context.Context, id string) int64
 	GetHotelIdFromReviewByPk(ctx context.Context, id string) int64
 	GetLangById(ctx context.Context, id string) string
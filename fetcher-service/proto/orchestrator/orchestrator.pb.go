@@ -388,6 +388,142 @@ func (x *HealthResponse) GetTimestamp() int64 {
 	return 0
 }
 
+type QueueStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueStatsRequest) Reset() {
+	*x = QueueStatsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatsRequest) ProtoMessage() {}
+
+func (x *QueueStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatsRequest.ProtoReflect.Descriptor instead.
+func (*QueueStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{4}
+}
+
+type QueueStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MainQueue     string                 `protobuf:"bytes,1,opt,name=main_queue,json=mainQueue,proto3" json:"main_queue,omitempty"`
+	MainDepth     int64                  `protobuf:"varint,2,opt,name=main_depth,json=mainDepth,proto3" json:"main_depth,omitempty"`
+	DlqQueue      string                 `protobuf:"bytes,3,opt,name=dlq_queue,json=dlqQueue,proto3" json:"dlq_queue,omitempty"`
+	DlqDepth      int64                  `protobuf:"varint,4,opt,name=dlq_depth,json=dlqDepth,proto3" json:"dlq_depth,omitempty"`
+	LastEnqueueAt int64                  `protobuf:"varint,5,opt,name=last_enqueue_at,json=lastEnqueueAt,proto3" json:"last_enqueue_at,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	DelayQueue    string                 `protobuf:"bytes,7,opt,name=delay_queue,json=delayQueue,proto3" json:"delay_queue,omitempty"`
+	DelayDepth    int64                  `protobuf:"varint,8,opt,name=delay_depth,json=delayDepth,proto3" json:"delay_depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueStatsResponse) Reset() {
+	*x = QueueStatsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatsResponse) ProtoMessage() {}
+
+func (x *QueueStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatsResponse.ProtoReflect.Descriptor instead.
+func (*QueueStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueueStatsResponse) GetMainQueue() string {
+	if x != nil {
+		return x.MainQueue
+	}
+	return ""
+}
+
+func (x *QueueStatsResponse) GetMainDepth() int64 {
+	if x != nil {
+		return x.MainDepth
+	}
+	return 0
+}
+
+func (x *QueueStatsResponse) GetDlqQueue() string {
+	if x != nil {
+		return x.DlqQueue
+	}
+	return ""
+}
+
+func (x *QueueStatsResponse) GetDlqDepth() int64 {
+	if x != nil {
+		return x.DlqDepth
+	}
+	return 0
+}
+
+func (x *QueueStatsResponse) GetLastEnqueueAt() int64 {
+	if x != nil {
+		return x.LastEnqueueAt
+	}
+	return 0
+}
+
+func (x *QueueStatsResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *QueueStatsResponse) GetDelayQueue() string {
+	if x != nil {
+		return x.DelayQueue
+	}
+	return ""
+}
+
+func (x *QueueStatsResponse) GetDelayDepth() int64 {
+	if x != nil {
+		return x.DelayDepth
+	}
+	return 0
+}
+
 type JobInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HotelId       int32                  `protobuf:"varint,1,opt,name=hotel_id,json=hotelId,proto3" json:"hotel_id,omitempty"`
@@ -399,7 +535,7 @@ type JobInfo struct {
 
 func (x *JobInfo) Reset() {
 	*x = JobInfo{}
-	mi := &file_proto_orchestrator_proto_msgTypes[4]
+	mi := &file_proto_orchestrator_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -411,7 +547,7 @@ func (x *JobInfo) String() string {
 func (*JobInfo) ProtoMessage() {}
 
 func (x *JobInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orchestrator_proto_msgTypes[4]
+	mi := &file_proto_orchestrator_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +560,7 @@ func (x *JobInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobInfo.ProtoReflect.Descriptor instead.
 func (*JobInfo) Descriptor() ([]byte, []int) {
-	return file_proto_orchestrator_proto_rawDescGZIP(), []int{4}
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *JobInfo) GetHotelId() int32 {
@@ -476,7 +612,21 @@ const file_proto_orchestrator_proto_rawDesc = "" +
 	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x1a=\n" +
 	"\x0fComponentsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x13\n" +
+	"\x11QueueStatsRequest\"\x94\x02\n" +
+	"\x12QueueStatsResponse\x12\x1d\n" +
+	"\n" +
+	"main_queue\x18\x01 \x01(\tR\tmainQueue\x12\x1d\n" +
+	"\n" +
+	"main_depth\x18\x02 \x01(\x03R\tmainDepth\x12\x1b\n" +
+	"\tdlq_queue\x18\x03 \x01(\tR\bdlqQueue\x12\x1b\n" +
+	"\tdlq_depth\x18\x04 \x01(\x03R\bdlqDepth\x12&\n" +
+	"\x0flast_enqueue_at\x18\x05 \x01(\x03R\rlastEnqueueAt\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12\x1f\n" +
+	"\vdelay_queue\x18\a \x01(\tR\n" +
+	"delayQueue\x12\x1f\n" +
+	"\vdelay_depth\x18\b \x01(\x03R\n" +
+	"delayDepth\"\x93\x01\n" +
 	"\aJobInfo\x12\x19\n" +
 	"\bhotel_id\x18\x01 \x01(\x05R\ahotelId\x12<\n" +
 	"\fmessage_type\x18\x02 \x01(\x0e2\x19.orchestrator.MessageTypeR\vmessageType\x12/\n" +
@@ -495,10 +645,11 @@ const file_proto_orchestrator_proto_rawDesc = "" +
 	"\x14JOB_STATUS_COMPLETED\x10\x03\x12\x15\n" +
 	"\x11JOB_STATUS_FAILED\x10\x04\x12\x17\n" +
 	"\x13JOB_STATUS_RETRYING\x10\x05\x12\x1a\n" +
-	"\x16JOB_STATUS_DEAD_LETTER\x10\x062\xb3\x01\n" +
+	"\x16JOB_STATUS_DEAD_LETTER\x10\x062\x87\x02\n" +
 	"\x13OrchestratorService\x12N\n" +
 	"\x13ProcessFetchRequest\x12\x1a.orchestrator.FetchRequest\x1a\x1b.orchestrator.FetchResponse\x12L\n" +
-	"\x0fGetHealthStatus\x12\x1b.orchestrator.HealthRequest\x1a\x1c.orchestrator.HealthResponseBSZQgithub.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestratorb\x06proto3"
+	"\x0fGetHealthStatus\x12\x1b.orchestrator.HealthRequest\x1a\x1c.orchestrator.HealthResponse\x12R\n" +
+	"\rGetQueueStats\x12\x1f.orchestrator.QueueStatsRequest\x1a .orchestrator.QueueStatsResponseBSZQgithub.com/victoragudo/hotel-management-system/fetcher-service/proto/orchestratorb\x06proto3"
 
 var (
 	file_proto_orchestrator_proto_rawDescOnce sync.Once
@@ -513,29 +664,33 @@ func file_proto_orchestrator_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_orchestrator_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_proto_orchestrator_proto_goTypes = []any{
-	(MessageType)(0),       // 0: orchestrator.MessageType
-	(JobStatus)(0),         // 1: orchestrator.JobStatus
-	(*FetchRequest)(nil),   // 2: orchestrator.FetchRequest
-	(*FetchResponse)(nil),  // 3: orchestrator.FetchResponse
-	(*HealthRequest)(nil),  // 4: orchestrator.HealthRequest
-	(*HealthResponse)(nil), // 5: orchestrator.HealthResponse
-	(*JobInfo)(nil),        // 6: orchestrator.JobInfo
-	nil,                    // 7: orchestrator.HealthResponse.ComponentsEntry
+	(MessageType)(0),           // 0: orchestrator.MessageType
+	(JobStatus)(0),             // 1: orchestrator.JobStatus
+	(*FetchRequest)(nil),       // 2: orchestrator.FetchRequest
+	(*FetchResponse)(nil),      // 3: orchestrator.FetchResponse
+	(*HealthRequest)(nil),      // 4: orchestrator.HealthRequest
+	(*HealthResponse)(nil),     // 5: orchestrator.HealthResponse
+	(*QueueStatsRequest)(nil),  // 6: orchestrator.QueueStatsRequest
+	(*QueueStatsResponse)(nil), // 7: orchestrator.QueueStatsResponse
+	(*JobInfo)(nil),            // 8: orchestrator.JobInfo
+	nil,                        // 9: orchestrator.HealthResponse.ComponentsEntry
 }
 var file_proto_orchestrator_proto_depIdxs = []int32{
 	0, // 0: orchestrator.FetchRequest.message_type:type_name -> orchestrator.MessageType
-	6, // 1: orchestrator.FetchResponse.jobs:type_name -> orchestrator.JobInfo
-	7, // 2: orchestrator.HealthResponse.components:type_name -> orchestrator.HealthResponse.ComponentsEntry
+	8, // 1: orchestrator.FetchResponse.jobs:type_name -> orchestrator.JobInfo
+	9, // 2: orchestrator.HealthResponse.components:type_name -> orchestrator.HealthResponse.ComponentsEntry
 	0, // 3: orchestrator.JobInfo.message_type:type_name -> orchestrator.MessageType
 	1, // 4: orchestrator.JobInfo.status:type_name -> orchestrator.JobStatus
 	2, // 5: orchestrator.OrchestratorService.ProcessFetchRequest:input_type -> orchestrator.FetchRequest
 	4, // 6: orchestrator.OrchestratorService.GetHealthStatus:input_type -> orchestrator.HealthRequest
-	3, // 7: orchestrator.OrchestratorService.ProcessFetchRequest:output_type -> orchestrator.FetchResponse
-	5, // 8: orchestrator.OrchestratorService.GetHealthStatus:output_type -> orchestrator.HealthResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
+	6, // 7: orchestrator.OrchestratorService.GetQueueStats:input_type -> orchestrator.QueueStatsRequest
+	3, // 8: orchestrator.OrchestratorService.ProcessFetchRequest:output_type -> orchestrator.FetchResponse
+	5, // 9: orchestrator.OrchestratorService.GetHealthStatus:output_type -> orchestrator.HealthResponse
+	7, // 10: orchestrator.OrchestratorService.GetQueueStats:output_type -> orchestrator.QueueStatsResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
 	5, // [5:5] is the sub-list for extension type_name
 	5, // [5:5] is the sub-list for extension extendee
 	0, // [0:5] is the sub-list for field type_name
@@ -552,7 +707,7 @@ func file_proto_orchestrator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_orchestrator_proto_rawDesc), len(file_proto_orchestrator_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	OrchestratorService_ProcessFetchRequest_FullMethodName = "/orchestrator.OrchestratorService/ProcessFetchRequest"
 	OrchestratorService_GetHealthStatus_FullMethodName     = "/orchestrator.OrchestratorService/GetHealthStatus"
+	OrchestratorService_GetQueueStats_FullMethodName       = "/orchestrator.OrchestratorService/GetQueueStats"
 )
 
 // OrchestratorServiceClient is the client API for OrchestratorService service.
@@ -29,6 +30,7 @@ const (
 type OrchestratorServiceClient interface {
 	ProcessFetchRequest(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
 	GetHealthStatus(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	GetQueueStats(ctx context.Context, in *QueueStatsRequest, opts ...grpc.CallOption) (*QueueStatsResponse, error)
 }
 
 type orchestratorServiceClient struct {
@@ -59,12 +61,23 @@ func (c *orchestratorServiceClient) GetHealthStatus(ctx context.Context, in *Hea
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) GetQueueStats(ctx context.Context, in *QueueStatsRequest, opts ...grpc.CallOption) (*QueueStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueStatsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetQueueStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServiceServer is the server API for OrchestratorService service.
 // All implementations must embed UnimplementedOrchestratorServiceServer
 // for forward compatibility.
 type OrchestratorServiceServer interface {
 	ProcessFetchRequest(context.Context, *FetchRequest) (*FetchResponse, error)
 	GetHealthStatus(context.Context, *HealthRequest) (*HealthResponse, error)
+	GetQueueStats(context.Context, *QueueStatsRequest) (*QueueStatsResponse, error)
 	mustEmbedUnimplementedOrchestratorServiceServer()
 }
 
@@ -81,6 +94,9 @@ func (UnimplementedOrchestratorServiceServer) ProcessFetchRequest(context.Contex
 func (UnimplementedOrchestratorServiceServer) GetHealthStatus(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHealthStatus not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) GetQueueStats(context.Context, *QueueStatsRequest) (*QueueStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStats not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) mustEmbedUnimplementedOrchestratorServiceServer() {}
 func (UnimplementedOrchestratorServiceServer) testEmbeddedByValue()                             {}
 
@@ -138,6 +154,24 @@ func _OrchestratorService_GetHealthStatus_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_GetQueueStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetQueueStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetQueueStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetQueueStats(ctx, req.(*QueueStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrchestratorService_ServiceDesc is the grpc.ServiceDesc for OrchestratorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +187,10 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHealthStatus",
 			Handler:    _OrchestratorService_GetHealthStatus_Handler,
 		},
+		{
+			MethodName: "GetQueueStats",
+			Handler:    _OrchestratorService_GetQueueStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/orchestrator.proto",
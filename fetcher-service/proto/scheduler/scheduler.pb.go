@@ -335,6 +335,250 @@ func (x *ScheduleStatusResponse) GetScheduleInfo() map[string]string {
 	return nil
 }
 
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PauseRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{5}
+}
+
+type PauseStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	PausedAt      int64                  `protobuf:"varint,3,opt,name=paused_at,json=pausedAt,proto3" json:"paused_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseStateResponse) Reset() {
+	*x = PauseStateResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseStateResponse) ProtoMessage() {}
+
+func (x *PauseStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseStateResponse.ProtoReflect.Descriptor instead.
+func (*PauseStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PauseStateResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *PauseStateResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PauseStateResponse) GetPausedAt() int64 {
+	if x != nil {
+		return x.PausedAt
+	}
+	return 0
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageType   MessageType            `protobuf:"varint,1,opt,name=message_type,json=messageType,proto3,enum=scheduler.MessageType" json:"message_type,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HistoryRequest) GetMessageType() MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return MessageType_UNSPECIFIED
+}
+
+func (x *HistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageType   MessageType            `protobuf:"varint,1,opt,name=message_type,json=messageType,proto3,enum=scheduler.MessageType" json:"message_type,omitempty"`
+	TriggeredAt   []int64                `protobuf:"varint,2,rep,packed,name=triggered_at,json=triggeredAt,proto3" json:"triggered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryResponse) GetMessageType() MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return MessageType_UNSPECIFIED
+}
+
+func (x *HistoryResponse) GetTriggeredAt() []int64 {
+	if x != nil {
+		return x.TriggeredAt
+	}
+	return nil
+}
+
 var File_proto_scheduler_proto protoreflect.FileDescriptor
 
 const file_proto_scheduler_proto_rawDesc = "" +
@@ -365,17 +609,33 @@ const file_proto_scheduler_proto_rawDesc = "" +
 	"\rschedule_info\x18\x05 \x03(\v23.scheduler.ScheduleStatusResponse.ScheduleInfoEntryR\fscheduleInfo\x1a?\n" +
 	"\x11ScheduleInfoEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\x96\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
+	"\fPauseRequest\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"\x0f\n" +
+	"\rResumeRequest\"a\n" +
+	"\x12PauseStateResponse\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tpaused_at\x18\x03 \x01(\x03R\bpausedAt\"a\n" +
+	"\x0eHistoryRequest\x129\n" +
+	"\fmessage_type\x18\x01 \x01(\x0e2\x16.scheduler.MessageTypeR\vmessageType\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"o\n" +
+	"\x0fHistoryResponse\x129\n" +
+	"\fmessage_type\x18\x01 \x01(\x0e2\x16.scheduler.MessageTypeR\vmessageType\x12!\n" +
+	"\ftriggered_at\x18\x02 \x03(\x03R\vtriggeredAt*\x96\x01\n" +
 	"\vMessageType\x12\x0f\n" +
 	"\vUNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fUPDATE_HOTEL\x10\x01\x12\x11\n" +
 	"\rUPDATE_REVIEW\x10\x02\x12\x16\n" +
 	"\x12UPDATE_TRANSLATION\x10\x03\x12\x1e\n" +
 	"\x1aFETCH_MISSING_TRANSLATIONS\x10\x04\x12\x19\n" +
-	"\x15FETCH_MISSING_REVIEWS\x10\x052\xb3\x01\n" +
+	"\x15FETCH_MISSING_REVIEWS\x10\x052\x97\x03\n" +
 	"\x10SchedulerService\x12E\n" +
 	"\fTriggerFetch\x12\x19.scheduler.TriggerRequest\x1a\x1a.scheduler.TriggerResponse\x12X\n" +
-	"\x11GetScheduleStatus\x12 .scheduler.ScheduleStatusRequest\x1a!.scheduler.ScheduleStatusResponseBPZNgithub.com/victoragudo/hotel-management-system/fetcher-service/proto/schedulerb\x06proto3"
+	"\x11GetScheduleStatus\x12 .scheduler.ScheduleStatusRequest\x1a!.scheduler.ScheduleStatusResponse\x12H\n" +
+	"\x0ePauseScheduler\x12\x17.scheduler.PauseRequest\x1a\x1d.scheduler.PauseStateResponse\x12J\n" +
+	"\x0fResumeScheduler\x12\x18.scheduler.ResumeRequest\x1a\x1d.scheduler.PauseStateResponse\x12L\n" +
+	"\x13GetSchedulerHistory\x12\x19.scheduler.HistoryRequest\x1a\x1a.scheduler.HistoryResponseBPZNgithub.com/victoragudo/hotel-management-system/fetcher-service/proto/schedulerb\x06proto3"
 
 var (
 	file_proto_scheduler_proto_rawDescOnce sync.Once
@@ -390,27 +650,40 @@ func file_proto_scheduler_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_proto_scheduler_proto_goTypes = []any{
 	(MessageType)(0),               // 0: scheduler.MessageType
 	(*TriggerRequest)(nil),         // 1: scheduler.TriggerRequest
 	(*TriggerResponse)(nil),        // 2: scheduler.TriggerResponse
 	(*ScheduleStatusRequest)(nil),  // 3: scheduler.ScheduleStatusRequest
 	(*ScheduleStatusResponse)(nil), // 4: scheduler.ScheduleStatusResponse
-	nil,                            // 5: scheduler.ScheduleStatusResponse.ScheduleInfoEntry
+	(*PauseRequest)(nil),           // 5: scheduler.PauseRequest
+	(*ResumeRequest)(nil),          // 6: scheduler.ResumeRequest
+	(*PauseStateResponse)(nil),     // 7: scheduler.PauseStateResponse
+	(*HistoryRequest)(nil),         // 8: scheduler.HistoryRequest
+	(*HistoryResponse)(nil),        // 9: scheduler.HistoryResponse
+	nil,                            // 10: scheduler.ScheduleStatusResponse.ScheduleInfoEntry
 }
 var file_proto_scheduler_proto_depIdxs = []int32{
-	0, // 0: scheduler.TriggerRequest.message_type:type_name -> scheduler.MessageType
-	5, // 1: scheduler.ScheduleStatusResponse.schedule_info:type_name -> scheduler.ScheduleStatusResponse.ScheduleInfoEntry
-	1, // 2: scheduler.SchedulerService.TriggerFetch:input_type -> scheduler.TriggerRequest
-	3, // 3: scheduler.SchedulerService.GetScheduleStatus:input_type -> scheduler.ScheduleStatusRequest
-	2, // 4: scheduler.SchedulerService.TriggerFetch:output_type -> scheduler.TriggerResponse
-	4, // 5: scheduler.SchedulerService.GetScheduleStatus:output_type -> scheduler.ScheduleStatusResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: scheduler.TriggerRequest.message_type:type_name -> scheduler.MessageType
+	10, // 1: scheduler.ScheduleStatusResponse.schedule_info:type_name -> scheduler.ScheduleStatusResponse.ScheduleInfoEntry
+	0,  // 2: scheduler.HistoryRequest.message_type:type_name -> scheduler.MessageType
+	0,  // 3: scheduler.HistoryResponse.message_type:type_name -> scheduler.MessageType
+	1,  // 4: scheduler.SchedulerService.TriggerFetch:input_type -> scheduler.TriggerRequest
+	3,  // 5: scheduler.SchedulerService.GetScheduleStatus:input_type -> scheduler.ScheduleStatusRequest
+	5,  // 6: scheduler.SchedulerService.PauseScheduler:input_type -> scheduler.PauseRequest
+	6,  // 7: scheduler.SchedulerService.ResumeScheduler:input_type -> scheduler.ResumeRequest
+	8,  // 8: scheduler.SchedulerService.GetSchedulerHistory:input_type -> scheduler.HistoryRequest
+	2,  // 9: scheduler.SchedulerService.TriggerFetch:output_type -> scheduler.TriggerResponse
+	4,  // 10: scheduler.SchedulerService.GetScheduleStatus:output_type -> scheduler.ScheduleStatusResponse
+	7,  // 11: scheduler.SchedulerService.PauseScheduler:output_type -> scheduler.PauseStateResponse
+	7,  // 12: scheduler.SchedulerService.ResumeScheduler:output_type -> scheduler.PauseStateResponse
+	9,  // 13: scheduler.SchedulerService.GetSchedulerHistory:output_type -> scheduler.HistoryResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_scheduler_proto_init() }
@@ -424,7 +697,7 @@ func file_proto_scheduler_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_scheduler_proto_rawDesc), len(file_proto_scheduler_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
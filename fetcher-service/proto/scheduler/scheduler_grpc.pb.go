@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SchedulerService_TriggerFetch_FullMethodName      = "/scheduler.SchedulerService/TriggerFetch"
-	SchedulerService_GetScheduleStatus_FullMethodName = "/scheduler.SchedulerService/GetScheduleStatus"
+	SchedulerService_TriggerFetch_FullMethodName        = "/scheduler.SchedulerService/TriggerFetch"
+	SchedulerService_GetScheduleStatus_FullMethodName   = "/scheduler.SchedulerService/GetScheduleStatus"
+	SchedulerService_PauseScheduler_FullMethodName      = "/scheduler.SchedulerService/PauseScheduler"
+	SchedulerService_ResumeScheduler_FullMethodName     = "/scheduler.SchedulerService/ResumeScheduler"
+	SchedulerService_GetSchedulerHistory_FullMethodName = "/scheduler.SchedulerService/GetSchedulerHistory"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -29,6 +32,9 @@ const (
 type SchedulerServiceClient interface {
 	TriggerFetch(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
 	GetScheduleStatus(ctx context.Context, in *ScheduleStatusRequest, opts ...grpc.CallOption) (*ScheduleStatusResponse, error)
+	PauseScheduler(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseStateResponse, error)
+	ResumeScheduler(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*PauseStateResponse, error)
+	GetSchedulerHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
 }
 
 type schedulerServiceClient struct {
@@ -59,12 +65,45 @@ func (c *schedulerServiceClient) GetScheduleStatus(ctx context.Context, in *Sche
 	return out, nil
 }
 
+func (c *schedulerServiceClient) PauseScheduler(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseStateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_PauseScheduler_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ResumeScheduler(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*PauseStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseStateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ResumeScheduler_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetSchedulerHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetSchedulerHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerServiceServer is the server API for SchedulerService service.
 // All implementations must embed UnimplementedSchedulerServiceServer
 // for forward compatibility.
 type SchedulerServiceServer interface {
 	TriggerFetch(context.Context, *TriggerRequest) (*TriggerResponse, error)
 	GetScheduleStatus(context.Context, *ScheduleStatusRequest) (*ScheduleStatusResponse, error)
+	PauseScheduler(context.Context, *PauseRequest) (*PauseStateResponse, error)
+	ResumeScheduler(context.Context, *ResumeRequest) (*PauseStateResponse, error)
+	GetSchedulerHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
 	mustEmbedUnimplementedSchedulerServiceServer()
 }
 
@@ -81,6 +120,15 @@ func (UnimplementedSchedulerServiceServer) TriggerFetch(context.Context, *Trigge
 func (UnimplementedSchedulerServiceServer) GetScheduleStatus(context.Context, *ScheduleStatusRequest) (*ScheduleStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetScheduleStatus not implemented")
 }
+func (UnimplementedSchedulerServiceServer) PauseScheduler(context.Context, *PauseRequest) (*PauseStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduler not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ResumeScheduler(context.Context, *ResumeRequest) (*PauseStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduler not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetSchedulerHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulerHistory not implemented")
+}
 func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
 func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
 
@@ -138,6 +186,60 @@ func _SchedulerService_GetScheduleStatus_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerService_PauseScheduler_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).PauseScheduler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_PauseScheduler_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).PauseScheduler(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ResumeScheduler_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ResumeScheduler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ResumeScheduler_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ResumeScheduler(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetSchedulerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetSchedulerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetSchedulerHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetSchedulerHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SchedulerService_ServiceDesc is the grpc.ServiceDesc for SchedulerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +255,18 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetScheduleStatus",
 			Handler:    _SchedulerService_GetScheduleStatus_Handler,
 		},
+		{
+			MethodName: "PauseScheduler",
+			Handler:    _SchedulerService_PauseScheduler_Handler,
+		},
+		{
+			MethodName: "ResumeScheduler",
+			Handler:    _SchedulerService_ResumeScheduler_Handler,
+		},
+		{
+			MethodName: "GetSchedulerHistory",
+			Handler:    _SchedulerService_GetSchedulerHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/scheduler.proto",
@@ -0,0 +1,42 @@
+package airports
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Airport is the location of an airport identified by its IATA code.
+type Airport struct {
+	IATA string  `json:"iata"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+}
+
+//go:embed airports.json
+var airportsJSON []byte
+
+var (
+	loadOnce sync.Once
+	byIATA   map[string]Airport
+)
+
+// Lookup returns the airport with the given IATA code, ignoring case.
+func Lookup(iata string) (Airport, bool) {
+	loadOnce.Do(load)
+	airport, ok := byIATA[strings.ToUpper(strings.TrimSpace(iata))]
+	return airport, ok
+}
+
+func load() {
+	var list []Airport
+	if err := json.Unmarshal(airportsJSON, &list); err != nil {
+		panic("airports: invalid embedded airports.json: " + err.Error())
+	}
+
+	byIATA = make(map[string]Airport, len(list))
+	for _, airport := range list {
+		byIATA[airport.IATA] = airport
+	}
+}
@@ -0,0 +1,42 @@
+package airports
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedAirports(t *testing.T) {
+	var list []Airport
+	require.NoError(t, json.Unmarshal(airportsJSON, &list))
+	assert.Len(t, list, 500)
+
+	iataCode := regexp.MustCompile(`^[A-Z]{3}$`)
+	seen := make(map[string]bool, len(list))
+	for _, airport := range list {
+		assert.Regexp(t, iataCode, airport.IATA)
+		assert.False(t, seen[airport.IATA], "%s is listed twice", airport.IATA)
+		seen[airport.IATA] = true
+		assert.True(t, airport.Lat >= -90 && airport.Lat <= 90, "%s latitude %f", airport.IATA, airport.Lat)
+		assert.True(t, airport.Lon >= -180 && airport.Lon <= 180, "%s longitude %f", airport.IATA, airport.Lon)
+		assert.False(t, airport.Lat == 0 && airport.Lon == 0, "%s has no coordinates", airport.IATA)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	airport, ok := Lookup("JFK")
+	require.True(t, ok)
+	assert.Equal(t, Airport{IATA: "JFK", Lat: 40.6413, Lon: -73.7781}, airport)
+
+	airport, ok = Lookup(" cdg ")
+	require.True(t, ok)
+	assert.Equal(t, "CDG", airport.IATA)
+
+	_, ok = Lookup("XXX")
+	assert.False(t, ok)
+	_, ok = Lookup("")
+	assert.False(t, ok)
+}
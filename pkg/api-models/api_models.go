@@ -3,9 +3,10 @@ package apimodels
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/phone"
 )
 
 type HotelAPIResponse struct {
@@ -71,8 +72,8 @@ type Photo struct {
 }
 
 type Facility struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	FacilityID int    `json:"facility_id"`
+	Name       string `json:"name"`
 }
 
 type Policy struct {
@@ -127,6 +128,11 @@ type ReviewAPIResponse struct {
 	Pros         string `json:"pros"`
 	Cons         string `json:"cons"`
 	Source       string `json:"source"`
+	// Category scores are zero when the source does not rate the category.
+	ScoreLocation   int32 `json:"score_location"`
+	ScoreService    int32 `json:"score_service"`
+	ScoreValue      int32 `json:"score_value"`
+	ScoreFacilities int32 `json:"score_facilities"`
 }
 
 type TranslationAPIResponse struct {
@@ -198,6 +204,11 @@ func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, er
 		ImportantInfo:       hotelAPIResponse.ImportantInfo,
 	}
 
+	sourceMappings := map[string]string{entities.SourceCupid: strconv.Itoa(hotelAPIResponse.CupidID)}
+	if err := hotelData.SetSourceMappings(sourceMappings); err != nil {
+		return nil, fmt.Errorf("error setting source mappings: %w", err)
+	}
+
 	addressData := map[string]string{
 		"address":     hotelAPIResponse.Address.Address,
 		"city":        hotelAPIResponse.Address.City,
@@ -236,6 +247,7 @@ func (hotelAPIResponse *HotelAPIResponse) ToHotelData() (*entities.HotelData, er
 		"fax":   hotelAPIResponse.Fax,
 		"email": hotelAPIResponse.Email,
 	}
+	setNormalizedNumbers(contact, hotelAPIResponse.Address.Country)
 	if err := hotelData.SetContactInfo(contact); err != nil {
 		return nil, fmt.Errorf("error setting contact info: %w", err)
 	}
@@ -326,6 +338,7 @@ func (translationAPIResponse *TranslationAPIResponse) ToHotelTranslations(lang s
 		"fax":   translationAPIResponse.Fax,
 		"email": translationAPIResponse.Email,
 	}
+	setNormalizedNumbers(contact, translationAPIResponse.Address.Country)
 	if err := translation.SetContactInfo(contact); err != nil {
 		return nil, fmt.Errorf("error setting contact info: %w", err)
 	}
@@ -366,25 +379,25 @@ func (translationAPIResponse *TranslationAPIResponse) ToHotelTranslations(lang s
 
 func (reviewApiResponse *ReviewAPIResponse) ToReviewData(hotelID int64) (*entities.ReviewData, error) {
 	reviewData := &entities.ReviewData{
-		HotelID:      hotelID,
-		ReviewID:     reviewApiResponse.ReviewID,
-		AverageScore: int32(reviewApiResponse.AverageScore),
-		Country:      reviewApiResponse.Country,
-		Type:         reviewApiResponse.Type,
-		Name:         reviewApiResponse.Name,
-		Headline:     reviewApiResponse.Headline,
-		Language:     reviewApiResponse.Language,
-		Pros:         reviewApiResponse.Pros,
-		Cons:         reviewApiResponse.Cons,
-		Source:       reviewApiResponse.Source,
-	}
-
-	if reviewApiResponse.Date != "" {
-		if parsedDate, err := time.Parse(time.RFC3339, reviewApiResponse.Date); err == nil {
-			reviewData.Date = parsedDate
-		}
+		HotelID:         hotelID,
+		ReviewID:        reviewApiResponse.ReviewID,
+		AverageScore:    int32(reviewApiResponse.AverageScore),
+		Country:         reviewApiResponse.Country,
+		Type:            reviewApiResponse.Type,
+		Name:            reviewApiResponse.Name,
+		Headline:        reviewApiResponse.Headline,
+		Language:        reviewApiResponse.Language,
+		Pros:            reviewApiResponse.Pros,
+		Cons:            reviewApiResponse.Cons,
+		Source:          reviewApiResponse.Source,
+		ScoreLocation:   reviewApiResponse.ScoreLocation,
+		ScoreService:    reviewApiResponse.ScoreService,
+		ScoreValue:      reviewApiResponse.ScoreValue,
+		ScoreFacilities: reviewApiResponse.ScoreFacilities,
 	}
 
+	reviewData.SetDate(reviewApiResponse.Date)
+
 	return reviewData, nil
 }
 
@@ -399,3 +412,13 @@ func (reviewDataList ReviewDataList) ToReviewDataList(hotelID int64) ([]*entitie
 	}
 	return reviews, nil
 }
+
+// setNormalizedNumbers adds the E.164 form of the phone and fax numbers that can be read, as
+// phone_e164 and fax_e164. Numbers that cannot be read keep only their raw form.
+func setNormalizedNumbers(contact map[string]string, country string) {
+	for _, field := range []string{"phone", "fax"} {
+		if normalized, err := phone.Normalize(contact[field], country); err == nil {
+			contact[field+"_e164"] = normalized
+		}
+	}
+}
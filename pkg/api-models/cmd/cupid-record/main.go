@@ -0,0 +1,182 @@
+// Command cupid-record fetches property, review and translation responses from the Cupid API
+// and writes them as sanitized JSON fixtures named <kind>_<hotel_id>[_<lang>].json.
+//
+// Usage:
+//
+//	CUPID_API_KEY=... go run ./api-models/cmd/cupid-record -hotels 1641879,317597 -languages fr,es
+//
+// Contact details and any occurrence of the API key are replaced before writing. Decoding the
+// fixtures with CUPID_STRICT_DECODE=true fails on fields the API models do not declare. The
+// contract tests of both Cupid adapters replay the fixtures through cupidtest.NewServer.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodels "github.com/victoragudo/hotel-management-system/pkg/api-models"
+)
+
+const scrubbedValue = "REDACTED"
+
+// scrubbedFields are replaced wherever they appear in a response.
+var scrubbedFields = map[string]bool{
+	"email": true,
+	"phone": true,
+	"fax":   true,
+}
+
+type recorder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	outDir  string
+}
+
+func main() {
+	baseURL := flag.String("base-url", envOr("CUPID_API_BASE_URL", "https://content-api.cupid.travel/v3.0"), "Cupid API base URL")
+	hotels := flag.String("hotels", "", "comma-separated hotel ids to record")
+	languages := flag.String("languages", "fr,es", "comma-separated translation languages to record")
+	reviews := flag.Int("reviews", 10, "number of reviews to record per hotel")
+	outDir := flag.String("out", "api-models/testdata", "directory the fixtures are written to")
+	flag.Parse()
+
+	apiKey := os.Getenv("CUPID_API_KEY")
+	if apiKey == "" {
+		log.Fatal("CUPID_API_KEY is required")
+	}
+	if *hotels == "" {
+		log.Fatal("-hotels is required")
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("failed to create output directory: %v", err)
+	}
+
+	r := &recorder{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		baseURL: strings.TrimSuffix(*baseURL, "/"),
+		apiKey:  apiKey,
+		outDir:  *outDir,
+	}
+
+	for _, value := range strings.Split(*hotels, ",") {
+		hotelID, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			log.Fatalf("invalid hotel id %q: %v", value, err)
+		}
+
+		r.record(fmt.Sprintf("property_%d", hotelID), fmt.Sprintf("/property/%d", hotelID), &apimodels.HotelAPIResponse{})
+		r.record(fmt.Sprintf("reviews_%d", hotelID), fmt.Sprintf("/property/reviews/%d/%d", hotelID, *reviews), &[]apimodels.ReviewAPIResponse{})
+		for _, lang := range strings.Split(*languages, ",") {
+			lang = strings.TrimSpace(lang)
+			if lang == "" {
+				continue
+			}
+			r.record(fmt.Sprintf("translation_%d_%s", hotelID, lang), fmt.Sprintf("/property/%d/lang/%s", hotelID, lang), &apimodels.TranslationAPIResponse{})
+		}
+	}
+}
+
+// record fetches path, scrubs the body and writes it to name.json. The body is also decoded
+// strictly into model so schema drift is reported while recording.
+func (r *recorder) record(name, path string, model any) {
+	body, err := r.fetch(path)
+	if err != nil {
+		log.Printf("skipping %s: %v", name, err)
+		return
+	}
+
+	var document any
+	if err := json.Unmarshal(body, &document); err != nil {
+		log.Printf("skipping %s: invalid JSON: %v", name, err)
+		return
+	}
+
+	fixture, err := json.MarshalIndent(scrub(document), "", "  ")
+	if err != nil {
+		log.Printf("skipping %s: %v", name, err)
+		return
+	}
+	fixture = bytes.ReplaceAll(fixture, []byte(r.apiKey), []byte(scrubbedValue))
+
+	decoder := json.NewDecoder(bytes.NewReader(fixture))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(model); err != nil {
+		log.Printf("warning: %s does not match the API models: %v", name, err)
+	}
+
+	file := filepath.Join(r.outDir, name+".json")
+	if err := os.WriteFile(file, append(fixture, '\n'), 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", file, err)
+	}
+	log.Printf("recorded %s", file)
+}
+
+func (r *recorder) fetch(path string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, r.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("accept", "application/json")
+	request.Header.Set("x-api-key", r.apiKey)
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", response.StatusCode)
+	}
+
+	return body, nil
+}
+
+func scrub(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if scrubbedFields[strings.ToLower(key)] {
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = scrubbedValue
+				}
+				continue
+			}
+			v[key] = scrub(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = scrub(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
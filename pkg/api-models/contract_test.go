@@ -0,0 +1,251 @@
+package apimodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/api-models/cupidtest"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+)
+
+// decodeFixture strictly decodes the fixture name of dir into v, and returns its top-level
+// fields as raw JSON for comparisons against the converted entities.
+func decodeFixture(t *testing.T, dir, name string, v any) map[string]json.RawMessage {
+	t.Helper()
+	t.Setenv(StrictDecodeEnv, "true")
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+	require.NoError(t, Unmarshal(data, v), "fixture %s no longer matches the API models", name)
+
+	var raw map[string]json.RawMessage
+	_ = json.Unmarshal(data, &raw)
+	return raw
+}
+
+func TestFixturesDecodeStrictly(t *testing.T) {
+	t.Setenv(StrictDecodeEnv, "true")
+
+	files, err := filepath.Glob(filepath.Join(cupidtest.Dir(), "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			var model any
+			switch {
+			case strings.HasPrefix(name, "property_"):
+				model = &HotelAPIResponse{}
+			case strings.HasPrefix(name, "reviews_"):
+				model = &[]ReviewAPIResponse{}
+			case strings.HasPrefix(name, "translation_"):
+				model = &TranslationAPIResponse{}
+			default:
+				t.Fatalf("unknown fixture kind %s", name)
+			}
+
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.NoError(t, Unmarshal(data, model))
+		})
+	}
+}
+
+// assertRichHotel checks the entity converted from property_1641879.json field by field. Each
+// assertion names the upstream field it covers, so a drifted fixture points at it.
+func assertRichHotel(t assert.TestingT, h *entities.HotelData, raw map[string]json.RawMessage) {
+	assert.Equal(t, int64(1641879), h.HotelID, "hotel_id")
+	assert.Equal(t, int64(1641879), h.CupidID, "cupid_id")
+	assert.Equal(t, int64(204), h.HotelTypeID, "hotel_type_id")
+	assert.Equal(t, "Hotels", h.HotelType, "hotel_type")
+	assert.Equal(t, "Hotel Le Marais", h.Name, "hotel_name")
+	assert.Equal(t, "<p>A boutique hotel in the heart of the Marais.</p>", h.Description, "description")
+	assert.Equal(t, "A boutique hotel in the heart of the Marais.", h.MarkdownDescription, "markdown_description")
+	assert.Equal(t, "Adults only. Guests must be 18 or older to check in.", h.ImportantInfo, "important_info")
+	assert.Equal(t, 8.6, h.Rating, "rating")
+	assert.Equal(t, int32(4), h.StarRating, "stars")
+	assert.Equal(t, 48.86486, h.Latitude, "latitude")
+	assert.Equal(t, 2.37089, h.Longitude, "longitude")
+	assert.Equal(t, "https://static.cupid.travel/hotels/thumbnail/1641879/main.jpg", h.MainImageTh, "main_image_th")
+	assert.Equal(t, "Independent", h.Chain, "chain")
+	assert.Equal(t, int32(0), h.ChainID, "chain_id")
+	assert.Equal(t, "CDG", h.AirportCode, "airport_code")
+	assert.Equal(t, int32(1240), h.ReviewCount, "review_count")
+	assert.Equal(t, "Paid parking nearby", h.Parking, "parking")
+	assert.True(t, h.ChildAllowed, "child_allowed")
+	assert.False(t, h.PetsAllowed, "pets_allowed")
+	assert.Equal(t, "REDACTED", h.Phone, "phone")
+	assert.Equal(t, "REDACTED", h.Fax, "fax")
+	assert.Equal(t, "REDACTED", h.Email, "email")
+
+	assert.JSONEq(t, `{"cupid":"1641879"}`, string(h.SourceMappings), "cupid_id")
+	assert.JSONEq(t, `{"address":"31 Rue de la Folie Méricourt","city":"Paris","state":"","country":"fr","postal_code":"75011"}`,
+		string(h.Address), "address")
+	assert.JSONEq(t, `{"phone":"REDACTED","fax":"REDACTED","email":"REDACTED"}`, string(h.ContactInfo), "contact")
+	assert.JSONEq(t, `["Free WiFi","Fitness centre","24-hour front desk","Rooftop terrace"]`, string(h.Facilities), "facilities")
+	assert.JSONEq(t, `{
+		"pets": {"name":"Pets","description":"Pets are not allowed.","child_allowed":"","pets_allowed":"N","parking":""},
+		"children": {"name":"Children and extra beds","description":"Children of all ages are welcome.","child_allowed":"Y","pets_allowed":"","parking":""}
+	}`, string(h.Policies), "policies")
+	assert.JSONEq(t, `{
+		"checkin_start":"15:00","checkin_end":"23:00","checkout":"11:00",
+		"instructions":["Extra-person charges may apply and vary depending on property policy"],
+		"special_instructions":"Front desk staff will greet guests on arrival."
+	}`, string(h.Checkin), "checkin")
+	assert.JSONEq(t, `5`, string(h.GroupRoomMin), "group_room_min")
+	assert.JSONEq(t, string(raw["photos"]), string(h.Photos), "photos")
+	assert.JSONEq(t, string(raw["rooms"]), string(h.Rooms), "rooms")
+}
+
+func TestHotelContract(t *testing.T) {
+	var response HotelAPIResponse
+	raw := decodeFixture(t, cupidtest.Dir(), "property_1641879.json", &response)
+
+	hotelData, err := response.ToHotelData()
+	require.NoError(t, err)
+	assertRichHotel(t, hotelData, raw)
+}
+
+func TestHotelContractEdgeCases(t *testing.T) {
+	var response HotelAPIResponse
+	decodeFixture(t, cupidtest.Dir(), "property_317597.json", &response)
+
+	hotelData, err := response.ToHotelData()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(317597), hotelData.HotelID)
+	assert.Equal(t, "Casa do Largo", hotelData.Name)
+	assert.Equal(t, int32(0), hotelData.StarRating)
+	assert.Equal(t, 0.0, hotelData.Rating)
+	assert.JSONEq(t, `null`, string(hotelData.GroupRoomMin), "a null group_room_min stays null")
+	assert.JSONEq(t, `[]`, string(hotelData.Rooms))
+	assert.JSONEq(t, `[]`, string(hotelData.Photos))
+	assert.Empty(t, hotelData.Facilities)
+	assert.Empty(t, hotelData.Policies)
+	assert.JSONEq(t, `{"phone":"","fax":"","email":""}`, string(hotelData.ContactInfo))
+	assert.JSONEq(t, `{"checkin_start":"","checkin_end":"","checkout":"","instructions":[],"special_instructions":""}`,
+		string(hotelData.Checkin))
+}
+
+func TestReviewContract(t *testing.T) {
+	var responses ReviewDataList
+	data, err := os.ReadFile(filepath.Join(cupidtest.Dir(), "reviews_1641879.json"))
+	require.NoError(t, err)
+	t.Setenv(StrictDecodeEnv, "true")
+	require.NoError(t, Unmarshal(data, &responses))
+
+	reviews, err := responses.ToReviewDataList(1641879)
+	require.NoError(t, err)
+	require.Len(t, reviews, 3)
+
+	first := reviews[0]
+	assert.Equal(t, int64(1641879), first.HotelID, "hotel_id")
+	assert.Equal(t, int64(88120031), first.ReviewID, "review_id")
+	assert.Equal(t, int32(9), first.AverageScore, "average_score")
+	assert.Equal(t, "gb", first.Country, "country")
+	assert.Equal(t, "couple", first.Type, "type")
+	assert.Equal(t, "Emma", first.Name, "name")
+	assert.Equal(t, "Lovely stay", first.Headline, "headline")
+	assert.Equal(t, "en", first.Language, "language")
+	assert.Equal(t, "Great location and friendly staff.", first.Pros, "pros")
+	assert.Equal(t, "Small room.", first.Cons, "cons")
+	assert.Equal(t, "Nuitee", first.Source, "source")
+	assert.Equal(t, time.Date(2024, 3, 15, 10, 22, 31, 0, time.UTC), first.Date, "date")
+	assert.Equal(t, "2024-03-15 10:22:31", first.RawDate, "date")
+	assert.Equal(t, []int32{10, 9, 8, 8},
+		[]int32{first.ScoreLocation, first.ScoreService, first.ScoreValue, first.ScoreFacilities}, "category scores")
+
+	dateOnly := reviews[1]
+	assert.Equal(t, time.Date(2023, 11, 2, 0, 0, 0, 0, time.UTC), dateOnly.Date, "date without a time")
+	assert.Equal(t, []int32{0, 0, 0, 0},
+		[]int32{dateOnly.ScoreLocation, dateOnly.ScoreService, dateOnly.ScoreValue, dateOnly.ScoreFacilities},
+		"unrated categories stay zero")
+
+	unparsed := reviews[2]
+	assert.True(t, unparsed.HasUnparsedDate(), "an unknown date format keeps its raw value only")
+	assert.Equal(t, "last summer", unparsed.RawDate)
+}
+
+func TestReviewContractEmpty(t *testing.T) {
+	var responses ReviewDataList
+	data, err := os.ReadFile(filepath.Join(cupidtest.Dir(), "reviews_317597.json"))
+	require.NoError(t, err)
+	require.NoError(t, Unmarshal(data, &responses))
+
+	reviews, err := responses.ToReviewDataList(317597)
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+}
+
+func TestTranslationContract(t *testing.T) {
+	var response TranslationAPIResponse
+	raw := decodeFixture(t, cupidtest.Dir(), "translation_1641879_fr.json", &response)
+
+	translation, err := response.ToHotelTranslations("fr")
+	require.NoError(t, err)
+
+	assert.Equal(t, "fr", translation.Lang, "lang")
+	assert.Equal(t, int64(1641879), translation.HotelID, "hotel_id")
+	assert.Equal(t, "Hôtel Le Marais", translation.Name, "hotel_name")
+	assert.Equal(t, "<p>Un hôtel de charme au cœur du Marais.</p>", translation.Description, "description")
+	assert.Equal(t, "Un hôtel de charme au cœur du Marais.", translation.MarkdownDescription, "markdown_description")
+	assert.Equal(t, "Réservé aux adultes.", translation.ImportantInfo, "important_info")
+	assert.Equal(t, "Parking payant à proximité", translation.Parking, "parking")
+	assert.Equal(t, "Independent", translation.Chain, "chain")
+	assert.JSONEq(t, `["WiFi gratuit","Fitness centre","24-hour front desk","Rooftop terrace"]`, string(translation.Facilities), "facilities")
+	assert.Contains(t, string(translation.Policies), "Les animaux ne sont pas admis.", "policies")
+	assert.JSONEq(t, `5`, string(translation.GroupRoomMin), "group_room_min")
+	assert.JSONEq(t, string(raw["photos"]), string(translation.Photos), "photos")
+	assert.JSONEq(t, string(raw["rooms"]), string(translation.Rooms), "rooms")
+}
+
+// failureRecorder collects the failures of assertions run against it.
+type failureRecorder struct {
+	failures []string
+}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestFieldRenameFailsContract(t *testing.T) {
+	dir := cupidtest.RenameField(t, cupidtest.Dir(), "property_1641879.json", "hotel_name", "name")
+
+	t.Run("strict decode rejects the renamed field", func(t *testing.T) {
+		t.Setenv(StrictDecodeEnv, "true")
+		data, err := os.ReadFile(filepath.Join(dir, "property_1641879.json"))
+		require.NoError(t, err)
+
+		err = Unmarshal(data, &HotelAPIResponse{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "name"`)
+	})
+
+	t.Run("field assertions point at the renamed field", func(t *testing.T) {
+		t.Setenv(StrictDecodeEnv, "false")
+		data, err := os.ReadFile(filepath.Join(dir, "property_1641879.json"))
+		require.NoError(t, err)
+		var response HotelAPIResponse
+		require.NoError(t, Unmarshal(data, &response))
+		var raw map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(data, &raw))
+
+		hotelData, err := response.ToHotelData()
+		require.NoError(t, err)
+
+		recorder := &failureRecorder{}
+		assertRichHotel(recorder, hotelData, raw)
+		require.Len(t, recorder.failures, 1)
+		assert.Contains(t, recorder.failures[0], `expected: "Hotel Le Marais"`)
+		assert.Contains(t, recorder.failures[0], `actual  : ""`)
+		assert.Contains(t, recorder.failures[0], "hotel_name")
+	})
+}
@@ -0,0 +1,111 @@
+// Package cupidtest replays the recorded Cupid API fixtures of api-models/testdata over HTTP,
+// so the Cupid adapters of every service can be checked against the same responses.
+package cupidtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// APIKey is the key the server expects in the x-api-key header.
+const APIKey = "contract-test-key"
+
+// Dir returns the directory holding the recorded fixtures.
+func Dir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "testdata")
+}
+
+// NewServer serves the fixtures of dir the way the Cupid API serves the responses they were
+// recorded from:
+//
+//	/property/{id}                 property_{id}.json
+//	/property/reviews/{id}/{count} the first count reviews of reviews_{id}.json
+//	/property/{id}/lang/{lang}     translation_{id}_{lang}.json
+//
+// Requests without APIKey get 401 and requests without a fixture 404. The server is closed
+// when the test ends.
+func NewServer(t testing.TB, dir string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != APIKey {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := fixtureFor(dir, r.URL.Path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// RenameField copies the fixtures of dir to a temporary directory, renaming the JSON key from
+// to to in the named fixture, and returns the copy. It simulates an upstream field rename.
+func RenameField(t testing.TB, dir, fixture, from, to string) string {
+	t.Helper()
+
+	copyDir := t.TempDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %v", err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read fixture %s: %v", entry.Name(), err)
+		}
+		if entry.Name() == fixture {
+			renamed := bytes.ReplaceAll(data, []byte(strconv.Quote(from)+":"), []byte(strconv.Quote(to)+":"))
+			if bytes.Equal(renamed, data) {
+				t.Fatalf("fixture %s has no field %q", fixture, from)
+			}
+			data = renamed
+		}
+		if err := os.WriteFile(filepath.Join(copyDir, entry.Name()), data, 0o644); err != nil {
+			t.Fatalf("failed to write fixture %s: %v", entry.Name(), err)
+		}
+	}
+	return copyDir
+}
+
+func fixtureFor(dir, path string) ([]byte, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "property":
+		return os.ReadFile(filepath.Join(dir, fmt.Sprintf("property_%s.json", parts[1])))
+	case len(parts) == 4 && parts[0] == "property" && parts[1] == "reviews":
+		count, err := strconv.Atoi(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid review count %q", parts[3])
+		}
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("reviews_%s.json", parts[2])))
+		if err != nil {
+			return nil, err
+		}
+		var reviews []json.RawMessage
+		if err := json.Unmarshal(data, &reviews); err != nil {
+			return nil, err
+		}
+		return json.Marshal(reviews[:min(count, len(reviews))])
+	case len(parts) == 4 && parts[0] == "property" && parts[2] == "lang":
+		return os.ReadFile(filepath.Join(dir, fmt.Sprintf("translation_%s_%s.json", parts[1], parts[3])))
+	default:
+		return nil, fmt.Errorf("no fixture for %s", path)
+	}
+}
@@ -0,0 +1,32 @@
+package apimodels
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+)
+
+// StrictDecodeEnv enables strict decoding of Cupid API responses when set to a true value.
+// It is meant for contract checks against recorded fixtures: any field the models do not know
+// about fails the decode, so upstream schema changes are noticed before they corrupt data.
+const StrictDecodeEnv = "CUPID_STRICT_DECODE"
+
+func StrictDecodeEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(StrictDecodeEnv))
+	return enabled
+}
+
+// Decode reads a single JSON value from r into v, rejecting unknown fields in strict mode.
+func Decode(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	if StrictDecodeEnabled() {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+func Unmarshal(data []byte, v any) error {
+	return Decode(bytes.NewReader(data), v)
+}
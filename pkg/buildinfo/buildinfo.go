@@ -0,0 +1,66 @@
+package buildinfo
+
+import (
+	"runtime"
+	"time"
+)
+
+// Version, Commit and BuildTime are set at build time, e.g.
+//
+//	go build -ldflags "-X github.com/victoragudo/hotel-management-system/pkg/buildinfo.Version=v1.2.0 \
+//	  -X github.com/victoragudo/hotel-management-system/pkg/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/victoragudo/hotel-management-system/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+var startedAt = time.Now()
+
+type Info struct {
+	Service   string    `json:"service"`
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildTime string    `json:"build_time"`
+	GoVersion string    `json:"go_version"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+func Get(service string) Info {
+	return Info{
+		Service:   service,
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		StartedAt: startedAt.UTC(),
+	}
+}
+
+// Uptime returns the time elapsed since the process loaded this package.
+func Uptime() time.Duration {
+	return time.Since(startedAt)
+}
+
+// LogAttrs returns the build metadata as slog key-value pairs.
+func (i Info) LogAttrs() []any {
+	return []any{
+		"service", i.Service,
+		"version", i.Version,
+		"commit", i.Commit,
+		"build_time", i.BuildTime,
+		"go_version", i.GoVersion,
+	}
+}
+
+// Map returns the build metadata as strings, for status payloads that only carry string maps.
+func (i Info) Map() map[string]string {
+	return map[string]string{
+		"version":    i.Version,
+		"commit":     i.Commit,
+		"build_time": i.BuildTime,
+		"go_version": i.GoVersion,
+		"uptime":     Uptime().Round(time.Second).String(),
+	}
+}
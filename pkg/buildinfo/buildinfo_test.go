@@ -0,0 +1,59 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLdflagsFlowThroughBuildInfo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+
+	const pkgPath = "github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	ldflags := "-X " + pkgPath + ".Version=v1.2.3" +
+		" -X " + pkgPath + ".Commit=abc1234" +
+		" -X " + pkgPath + ".BuildTime=2026-01-02T03:04:05Z"
+	output, err := exec.Command("go", "run", "-ldflags", ldflags, "./testdata/printinfo").Output()
+	require.NoError(t, err)
+
+	var info Info
+	require.NoError(t, json.Unmarshal(output, &info))
+	assert.Equal(t, "printinfo", info.Service)
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abc1234", info.Commit)
+	assert.Equal(t, "2026-01-02T03:04:05Z", info.BuildTime)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestGetDefaultsWithoutLdflags(t *testing.T) {
+	info := Get("search-service")
+
+	assert.Equal(t, "dev", info.Version)
+	assert.Equal(t, "unknown", info.Commit)
+	assert.Equal(t, "unknown", info.BuildTime)
+}
+
+func TestInfoMapAndLogAttrs(t *testing.T) {
+	info := Info{Service: "worker", Version: "v1.2.3", Commit: "abc1234", BuildTime: "2026-01-02T03:04:05Z", GoVersion: "go1.25"}
+
+	values := info.Map()
+	assert.Equal(t, "v1.2.3", values["version"])
+	assert.Equal(t, "abc1234", values["commit"])
+	assert.Equal(t, "2026-01-02T03:04:05Z", values["build_time"])
+	assert.Equal(t, "go1.25", values["go_version"])
+	assert.NotEmpty(t, values["uptime"])
+
+	assert.Equal(t, []any{
+		"service", "worker",
+		"version", "v1.2.3",
+		"commit", "abc1234",
+		"build_time", "2026-01-02T03:04:05Z",
+		"go_version", "go1.25",
+	}, info.LogAttrs())
+}
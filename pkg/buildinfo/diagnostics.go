@@ -0,0 +1,133 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys are matched against lower-cased config field names with separators removed.
+var sensitiveKeys = []string{"password", "secret", "token", "apikey", "dsn"}
+
+type RuntimeStats struct {
+	Goroutines     int     `json:"goroutines"`
+	GOMAXPROCS     int     `json:"gomaxprocs"`
+	HeapAllocBytes uint64  `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64  `json:"heap_inuse_bytes"`
+	HeapObjects    uint64  `json:"heap_objects"`
+	SysBytes       uint64  `json:"sys_bytes"`
+	NumGC          uint32  `json:"num_gc"`
+	LastGCPauseMs  float64 `json:"last_gc_pause_ms"`
+	TotalGCPauseMs float64 `json:"total_gc_pause_ms"`
+}
+
+type DebugInfo struct {
+	Build          Info           `json:"build"`
+	Uptime         string         `json:"uptime"`
+	UptimeMs       int64          `json:"uptime_ms"`
+	Runtime        RuntimeStats   `json:"runtime"`
+	ConfigChecksum string         `json:"config_checksum,omitempty"`
+	Config         map[string]any `json:"config,omitempty"`
+}
+
+func ReadRuntimeStats() RuntimeStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	stats := RuntimeStats{
+		Goroutines:     runtime.NumGoroutine(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		HeapAllocBytes: memStats.HeapAlloc,
+		HeapInuseBytes: memStats.HeapInuse,
+		HeapObjects:    memStats.HeapObjects,
+		SysBytes:       memStats.Sys,
+		NumGC:          memStats.NumGC,
+		TotalGCPauseMs: durationMs(time.Duration(memStats.PauseTotalNs)),
+	}
+	if memStats.NumGC > 0 {
+		stats.LastGCPauseMs = durationMs(time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256]))
+	}
+
+	return stats
+}
+
+// Collect gathers build metadata, runtime stats and a redacted copy of config. The checksum is
+// computed over the full config so that changed secrets are still visible as a checksum change.
+func Collect(service string, config any) DebugInfo {
+	info := DebugInfo{
+		Build:    Get(service),
+		Uptime:   Uptime().Round(time.Second).String(),
+		UptimeMs: Uptime().Milliseconds(),
+		Runtime:  ReadRuntimeStats(),
+	}
+
+	if config != nil {
+		info.ConfigChecksum = ConfigChecksum(config)
+		info.Config = RedactConfig(config)
+	}
+
+	return info
+}
+
+func ConfigChecksum(config any) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// RedactConfig converts config to a generic map and replaces the values of sensitive fields.
+func RedactConfig(config any) map[string]any {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+
+	redactValue(values)
+	return values
+}
+
+func redactValue(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if isSensitiveKey(key) {
+				if nested != nil && nested != "" {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			redactValue(nested)
+		}
+	case []any:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
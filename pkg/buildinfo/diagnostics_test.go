@@ -0,0 +1,79 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	Database struct {
+		Host     string
+		Password string
+	}
+	Redis struct {
+		Password string
+	}
+	SessionSecret string `json:"session_secret"`
+	Clients       []struct {
+		Name   string `json:"name"`
+		APIKey string `json:"api_key"`
+	} `json:"clients"`
+	AccessToken string `json:"access-token"`
+	Port        int
+}
+
+func newTestConfig() testConfig {
+	var config testConfig
+	config.Database.Host = "postgres"
+	config.Database.Password = "db-password"
+	config.SessionSecret = "session-secret"
+	config.Clients = append(config.Clients, struct {
+		Name   string `json:"name"`
+		APIKey string `json:"api_key"`
+	}{Name: "partner", APIKey: "key-1"})
+	config.AccessToken = "token"
+	config.Port = 8080
+	return config
+}
+
+func TestRedactConfigHidesSecrets(t *testing.T) {
+	values := RedactConfig(newTestConfig())
+
+	database := values["Database"].(map[string]any)
+	assert.Equal(t, "postgres", database["Host"])
+	assert.Equal(t, redactedValue, database["Password"])
+	assert.Equal(t, redactedValue, values["session_secret"])
+	assert.Equal(t, redactedValue, values["access-token"])
+	assert.Equal(t, float64(8080), values["Port"])
+
+	client := values["clients"].([]any)[0].(map[string]any)
+	assert.Equal(t, "partner", client["name"])
+	assert.Equal(t, redactedValue, client["api_key"])
+
+	// Unset secrets stay empty, so a missing secret can be told apart from a set one.
+	assert.Equal(t, "", values["Redis"].(map[string]any)["Password"])
+}
+
+func TestConfigChecksumChangesWithSecrets(t *testing.T) {
+	config := newTestConfig()
+	checksum := ConfigChecksum(config)
+	assert.Len(t, checksum, 64)
+	assert.Equal(t, checksum, ConfigChecksum(newTestConfig()))
+
+	config.Database.Password = "rotated"
+	assert.NotEqual(t, checksum, ConfigChecksum(config))
+	assert.Equal(t, RedactConfig(newTestConfig()), RedactConfig(config))
+}
+
+func TestCollect(t *testing.T) {
+	info := Collect("search-service", newTestConfig())
+
+	assert.Equal(t, "search-service", info.Build.Service)
+	assert.Positive(t, info.Runtime.Goroutines)
+	assert.Positive(t, info.Runtime.HeapAllocBytes)
+	assert.NotEmpty(t, info.ConfigChecksum)
+	assert.Equal(t, redactedValue, info.Config["session_secret"])
+
+	assert.Empty(t, Collect("search-service", nil).Config)
+}
@@ -0,0 +1,13 @@
+// Command printinfo prints the build info of a binary, for the ldflags test.
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+)
+
+func main() {
+	_ = json.NewEncoder(os.Stdout).Encode(buildinfo.Get("printinfo"))
+}
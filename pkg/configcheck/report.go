@@ -0,0 +1,149 @@
+// Package configcheck validates service configuration into a report that lists every problem at
+// once, so a broken deployment is fixed in one pass instead of one error per restart, and that
+// records the defaults applied to unset keys.
+package configcheck
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ValidateConfigFlag makes a service print its configuration report and exit instead of
+// starting. VALIDATE_CONFIG=true does the same.
+const ValidateConfigFlag = "--validate-config"
+
+// Issue is a configuration key that is invalid, or that was unset and received a default.
+type Issue struct {
+	Key     string
+	Message string
+}
+
+// Report collects the errors and applied defaults of a configuration.
+type Report struct {
+	Errors   []Issue
+	Defaults []Issue
+}
+
+// Errorf records an invalid key.
+func (r *Report) Errorf(key, format string, args ...any) {
+	r.Errors = append(r.Errors, Issue{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// Required records an error when value is empty.
+func (r *Report) Required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		r.Errorf(key, "is required")
+	}
+}
+
+// OneOf records an error when value is not one of allowed.
+func (r *Report) OneOf(key, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		r.Errorf(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+// Range records an error when value is outside [low, high].
+func Range[T cmp.Ordered](r *Report, key string, value, low, high T) {
+	if value < low || value > high {
+		r.Errorf(key, "must be between %v and %v, got %v", low, high, value)
+	}
+}
+
+// Default sets value to def when it is zero or negative, or empty for strings, and records it.
+func Default[T cmp.Ordered](r *Report, key string, value *T, def T) {
+	var zero T
+	if *value > zero {
+		return
+	}
+	if *value < zero {
+		r.Defaults = append(r.Defaults, Issue{Key: key, Message: fmt.Sprintf("%v (was %v)", def, *value)})
+	} else {
+		r.Defaults = append(r.Defaults, Issue{Key: key, Message: fmt.Sprint(def)})
+	}
+	*value = def
+}
+
+func (r *Report) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// Err returns all errors as one error, or nil when the configuration is valid.
+func (r *Report) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return &ValidationError{Issues: slices.Clone(r.Errors)}
+}
+
+// Print writes the report as a table of errors followed by the applied defaults.
+func (r *Report) Print(w io.Writer) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "STATUS\tKEY\tDETAIL")
+	for _, issue := range r.Errors {
+		_, _ = fmt.Fprintf(table, "error\t%s\t%s\n", issue.Key, issue.Message)
+	}
+	for _, issue := range r.Defaults {
+		_, _ = fmt.Fprintf(table, "default\t%s\t%s\n", issue.Key, issue.Message)
+	}
+	_ = table.Flush()
+
+	_, _ = fmt.Fprintf(w, "\n%d error(s), %d default(s) applied\n", len(r.Errors), len(r.Defaults))
+}
+
+// LogDefaults logs the applied defaults in a single startup record.
+func (r *Report) LogDefaults(logger *slog.Logger) {
+	if len(r.Defaults) == 0 {
+		return
+	}
+
+	args := make([]any, 0, len(r.Defaults)*2)
+	for _, issue := range r.Defaults {
+		args = append(args, issue.Key, issue.Message)
+	}
+	logger.Warn("Configuration defaults applied to unset keys", slog.Group("defaults", args...))
+}
+
+// Enforce acts on the report at startup. When ValidateOnly is requested it prints the report
+// and exits with 0 for a valid configuration and 1 otherwise. Otherwise it exits when the
+// configuration has errors and logs the applied defaults.
+func (r *Report) Enforce(logger *slog.Logger) {
+	if ValidateOnly() {
+		r.Print(os.Stdout)
+		if r.HasErrors() {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if err := r.Err(); err != nil {
+		logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	r.LogDefaults(logger)
+}
+
+// ValidateOnly reports whether the process was started with ValidateConfigFlag or
+// VALIDATE_CONFIG=true.
+func ValidateOnly() bool {
+	return slices.Contains(os.Args[1:], ValidateConfigFlag) || strings.EqualFold(os.Getenv("VALIDATE_CONFIG"), "true")
+}
+
+// ValidationError lists every invalid key of a configuration.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		messages = append(messages, issue.Key+" "+issue.Message)
+	}
+	return fmt.Sprintf("%d configuration error(s): %s", len(e.Issues), strings.Join(messages, "; "))
+}
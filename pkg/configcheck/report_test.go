@@ -0,0 +1,172 @@
+package configcheck
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enforceHelperEnv makes the test binary run TestEnforceHelperProcess as a child process, so the
+// exit codes of Enforce can be observed.
+const enforceHelperEnv = "CONFIGCHECK_ENFORCE_HELPER"
+
+// brokenReport returns a report with several simultaneous problems and defaults.
+func brokenReport() *Report {
+	report := &Report{}
+	port := 0
+	timeout := -time.Second
+	workers := 128
+
+	report.Required("service.host", " ")
+	Default(report, "service.port", &port, 8080)
+	Default(report, "service.timeout", &timeout, 30*time.Second)
+	Range(report, "service.workers", workers, 1, 64)
+	report.OneOf("service.broker", "kafka", "rabbitmq", "nats")
+	return report
+}
+
+func TestReportCollectsEveryProblem(t *testing.T) {
+	report := brokenReport()
+
+	assert.Equal(t, []Issue{
+		{Key: "service.host", Message: "is required"},
+		{Key: "service.workers", Message: "must be between 1 and 64, got 128"},
+		{Key: "service.broker", Message: `must be one of rabbitmq, nats, got "kafka"`},
+	}, report.Errors)
+	assert.Equal(t, []Issue{
+		{Key: "service.port", Message: "8080"},
+		{Key: "service.timeout", Message: "30s (was -1s)"},
+	}, report.Defaults)
+	assert.True(t, report.HasErrors())
+}
+
+func TestDefaultKeepsSetValues(t *testing.T) {
+	report := &Report{}
+	port, name := 9090, "search"
+
+	Default(report, "service.port", &port, 8080)
+	Default(report, "service.name", &name, "default")
+
+	assert.Equal(t, 9090, port)
+	assert.Equal(t, "search", name)
+	assert.Empty(t, report.Defaults)
+	assert.False(t, report.HasErrors())
+	assert.NoError(t, report.Err())
+}
+
+func TestErrListsEveryInvalidKey(t *testing.T) {
+	report := brokenReport()
+
+	err := report.Err()
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, report.Errors, validationErr.Issues)
+	assert.EqualError(t, err, `3 configuration error(s): service.host is required; `+
+		`service.workers must be between 1 and 64, got 128; `+
+		`service.broker must be one of rabbitmq, nats, got "kafka"`)
+
+	report.Errorf("service.extra", "added later")
+	assert.Len(t, validationErr.Issues, 3, "the error does not change with the report")
+}
+
+func TestPrintWritesTheFullReport(t *testing.T) {
+	var out bytes.Buffer
+
+	brokenReport().Print(&out)
+
+	assert.Equal(t, "STATUS   KEY              DETAIL\n"+
+		"error    service.host     is required\n"+
+		"error    service.workers  must be between 1 and 64, got 128\n"+
+		"error    service.broker   must be one of rabbitmq, nats, got \"kafka\"\n"+
+		"default  service.port     8080\n"+
+		"default  service.timeout  30s (was -1s)\n"+
+		"\n3 error(s), 2 default(s) applied\n", out.String())
+}
+
+func TestValidateOnly(t *testing.T) {
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+
+	os.Args = []string{"service"}
+	t.Setenv("VALIDATE_CONFIG", "")
+	assert.False(t, ValidateOnly())
+
+	t.Setenv("VALIDATE_CONFIG", "TRUE")
+	assert.True(t, ValidateOnly())
+
+	t.Setenv("VALIDATE_CONFIG", "")
+	os.Args = []string{"service", ValidateConfigFlag}
+	assert.True(t, ValidateOnly())
+
+	os.Args = []string{ValidateConfigFlag}
+	assert.False(t, ValidateOnly(), "the program name is not a flag")
+}
+
+// TestEnforceHelperProcess is not a test: it runs Enforce in a child process started by
+// runEnforce.
+func TestEnforceHelperProcess(t *testing.T) {
+	scenario := os.Getenv(enforceHelperEnv)
+	if scenario == "" {
+		t.Skip("helper process")
+	}
+
+	report := &Report{}
+	if scenario == "broken" {
+		report = brokenReport()
+	}
+	report.Enforce(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	_, _ = os.Stdout.WriteString("started\n")
+	os.Exit(3)
+}
+
+// runEnforce runs Enforce on the scenario's report in a child process and returns its exit code
+// and standard output.
+func runEnforce(t *testing.T, scenario string, validateOnly bool) (int, string) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestEnforceHelperProcess$")
+	cmd.Env = append(os.Environ(), enforceHelperEnv+"="+scenario, "VALIDATE_CONFIG=false")
+	if validateOnly {
+		cmd.Env = append(cmd.Env, "VALIDATE_CONFIG=true")
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), stdout.String()
+	}
+	require.NoError(t, err)
+	return 0, stdout.String()
+}
+
+func TestEnforceValidateOnlyExitsWithTheReport(t *testing.T) {
+	code, out := runEnforce(t, "broken", true)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "error    service.workers  must be between 1 and 64, got 128\n")
+	assert.Contains(t, out, "3 error(s), 2 default(s) applied\n")
+	assert.NotContains(t, out, "started")
+
+	code, out = runEnforce(t, "valid", true)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "0 error(s), 0 default(s) applied\n")
+	assert.NotContains(t, out, "started")
+}
+
+func TestEnforceExitsOnErrorsAndStartsOtherwise(t *testing.T) {
+	code, out := runEnforce(t, "broken", false)
+	assert.Equal(t, 1, code)
+	assert.NotContains(t, out, "started")
+
+	code, out = runEnforce(t, "valid", false)
+	assert.Equal(t, 3, code, "a valid configuration returns from Enforce")
+	assert.Contains(t, out, "started")
+}
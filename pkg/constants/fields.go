@@ -1,8 +1,9 @@
 package constants
 
 const (
-	HotelId  = "hotel_id"
-	Lang     = "lang"
-	ReviewId = "review_id"
-	Id       = "id"
+	HotelId     = "hotel_id"
+	Lang        = "lang"
+	ReviewId    = "review_id"
+	Id          = "id"
+	Fingerprint = "fingerprint"
 )
@@ -7,4 +7,5 @@ const (
 	MessageTypeUpdateTranslation = "update_translation"
 	MessageTypeFetchTranslation  = "fetch_translation"
 	MessageTypeFetchReview       = "fetch_review"
+	MessageTypeDeadLetter        = "dead_letter"
 )
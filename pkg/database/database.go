@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"os"
 
+	"github.com/victoragudo/hotel-management-system/pkg/database/migration"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -11,10 +13,18 @@ func GormOpen(dsn string) (*gorm.DB, error) {
 	return gorm.Open(postgres.Open(dsn), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
 }
 
+// RunMigrations applies the versioned SQL migrations. In development (ENV=development) the given
+// entities are also auto-migrated so struct changes can be tried before a migration is written.
 func RunMigrations(db *gorm.DB, entities ...interface{}) error {
-	if err := db.AutoMigrate(entities...); err != nil {
+	if err := migration.NewDefaultMigrationRunner().Run(context.Background(), db); err != nil {
 		return err
 	}
+
+	if os.Getenv("ENV") == "development" {
+		if err := db.AutoMigrate(entities...); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
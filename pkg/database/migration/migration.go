@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var embeddedMigrations embed.FS
+
+// advisoryLockKey serializes migration runs when several services start against the same database.
+const advisoryLockKey = 7146352001
+
+var createTablePattern = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?"?(\w+)"?`)
+
+type Migration struct {
+	Version string
+	SQL     string
+}
+
+type SchemaMigration struct {
+	Version   string    `gorm:"primaryKey;type:varchar(255)"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationRunner struct {
+	files fs.FS
+	dir   string
+}
+
+// NewMigrationRunner returns a runner for the .sql files in dir. Files are applied in
+// lexicographic order and their name without extension is used as the version.
+func NewMigrationRunner(files fs.FS, dir string) *MigrationRunner {
+	return &MigrationRunner{
+		files: files,
+		dir:   dir,
+	}
+}
+
+// NewDefaultMigrationRunner returns a runner for the migrations embedded in this package.
+func NewDefaultMigrationRunner() *MigrationRunner {
+	return NewMigrationRunner(embeddedMigrations, "sql")
+}
+
+func (r *MigrationRunner) Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(r.files, r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		content, err := fs.ReadFile(r.files, path.Join(r.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: strings.TrimSuffix(entry.Name(), ".sql"),
+			SQL:     string(content),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Tables returns the tables the migrations create, in the order they are created. The
+// schema_migrations table the runner keeps is not one of them.
+func (r *MigrationRunner) Tables() ([]string, error) {
+	migrations, err := r.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	seen := make(map[string]bool)
+	for _, migration := range migrations {
+		for _, match := range createTablePattern.FindAllStringSubmatch(migration.SQL, -1) {
+			table := strings.ToLower(match[1])
+			if !seen[table] {
+				seen[table] = true
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+// Run applies every migration not yet recorded in schema_migrations. All pending migrations run
+// in a single transaction, so statements that cannot run inside one (CREATE INDEX CONCURRENTLY)
+// are not supported.
+func (r *MigrationRunner) Run(ctx context.Context, db *gorm.DB) error {
+	migrations, err := r.Migrations()
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		if err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL
+)`).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+
+		var applied []string
+		if err := tx.Model(&SchemaMigration{}).Pluck("version", &applied).Error; err != nil {
+			return fmt.Errorf("failed to load applied migrations: %w", err)
+		}
+
+		appliedVersions := make(map[string]bool, len(applied))
+		for _, version := range applied {
+			appliedVersions[version] = true
+		}
+
+		for _, migration := range migrations {
+			if appliedVersions[migration.Version] {
+				continue
+			}
+
+			if err := tx.Exec(migration.SQL).Error; err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration.Version, err)
+			}
+
+			record := SchemaMigration{Version: migration.Version, AppliedAt: time.Now().UTC()}
+			if err := tx.Create(&record).Error; err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", migration.Version, err)
+			}
+		}
+
+		return nil
+	})
+}
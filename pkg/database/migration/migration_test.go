@@ -0,0 +1,178 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// testPostgresDSNEnv names the database the migration tests run against. The migrations use
+// PostgreSQL features, so the tests are skipped without one.
+const testPostgresDSNEnv = "HMS_TEST_POSTGRES_DSN"
+
+// openTestDatabase connects to the test database with a single connection whose search_path is
+// a schema of its own, dropped when the test ends.
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testPostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresDSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE SCHEMA "+schema).Error)
+	require.NoError(t, db.Exec("SET search_path TO "+schema).Error)
+	t.Cleanup(func() {
+		_ = db.Exec("DROP SCHEMA " + schema + " CASCADE").Error
+		_ = sqlDB.Close()
+	})
+	return db
+}
+
+// migrationsBefore returns a runner for the embedded migrations older than version.
+func migrationsBefore(t *testing.T, version string) *MigrationRunner {
+	t.Helper()
+	migrations, err := NewDefaultMigrationRunner().Migrations()
+	require.NoError(t, err)
+
+	files := fstest.MapFS{}
+	for _, migration := range migrations {
+		if migration.Version < version {
+			files["sql/"+migration.Version+".sql"] = &fstest.MapFile{Data: []byte(migration.SQL)}
+		}
+	}
+	return NewMigrationRunner(files, "sql")
+}
+
+func TestMigrationsAreOrderedByVersion(t *testing.T) {
+	migrations, err := NewDefaultMigrationRunner().Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.NotEmpty(t, strings.TrimSpace(migration.SQL), migration.Version)
+		if i > 0 {
+			assert.Less(t, migrations[i-1].Version, migration.Version)
+		}
+	}
+}
+
+func TestTablesListsTheCreatedTablesOnce(t *testing.T) {
+	runner := NewMigrationRunner(fstest.MapFS{
+		"sql/001_first.sql":  &fstest.MapFile{Data: []byte("CREATE TABLE IF NOT EXISTS hotels (id TEXT);\ncreate table reviews (id TEXT);")},
+		"sql/002_second.sql": &fstest.MapFile{Data: []byte(`ALTER TABLE hotels ADD COLUMN name TEXT;\nCREATE TABLE "Sync_Runs" (id TEXT);\nCREATE TABLE IF NOT EXISTS reviews (id TEXT);`)},
+	}, "sql")
+
+	tables, err := runner.Tables()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hotels", "reviews", "sync_runs"}, tables)
+}
+
+func TestDefaultTablesIncludeEveryMigrationTable(t *testing.T) {
+	tables, err := NewDefaultMigrationRunner().Tables()
+	require.NoError(t, err)
+	assert.Subset(t, tables, []string{"hotels", "reviews", "translations", "sync_runs", "review_fetch_states", "reviews_archive", "hotel_changes", "api_usage_daily"})
+	assert.NotContains(t, tables, "schema_migrations")
+}
+
+func TestUniqueHotelIDMigrationMergesDuplicates(t *testing.T) {
+	db := openTestDatabase(t)
+	ctx := context.Background()
+	require.NoError(t, migrationsBefore(t, "008_unique_hotel_id").Run(ctx, db))
+
+	seed := []string{
+		`INSERT INTO hotels (id, hotel_id, cupid_id, name, archived_review_count, source_mappings, created_at, updated_at, next_update_at) VALUES
+			('old', 1, 1, 'Old copy', 4, '{"cupid": "1001"}', '2026-01-01', '2026-02-01', now()),
+			('new', 1, 1, 'New copy', 0, '{"expedia": "E-1"}', '2026-01-05', '2026-03-01', now()),
+			('deleted', 1, 1, 'Deleted copy', 0, NULL, '2026-01-03', '2026-04-01', now()),
+			('single', 2, 2, 'Only copy', 0, NULL, '2026-01-01', '2026-01-01', now())`,
+		`UPDATE hotels SET deleted_at = now() WHERE id = 'deleted'`,
+		`INSERT INTO translations (id, hotel_id, name, lang, created_at, updated_at, next_update_at) VALUES
+			('es-old', 1, 'Copia vieja', 'es', now(), '2026-02-01', now()),
+			('es-new', 1, 'Copia nueva', 'es', now(), '2026-03-01', now()),
+			('fr', 1, 'Copie', 'fr', now(), '2026-02-01', now())`,
+	}
+	for _, statement := range seed {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+
+	require.NoError(t, NewDefaultMigrationRunner().Run(ctx, db))
+
+	type hotelRow struct {
+		ID                  string
+		Name                string
+		ArchivedReviewCount int
+		SourceMappings      string
+		CreatedAt           time.Time
+	}
+	var hotels []hotelRow
+	require.NoError(t, db.Raw(`SELECT id, name, archived_review_count, source_mappings::text AS source_mappings, created_at
+		FROM hotels WHERE hotel_id = 1`).Scan(&hotels).Error)
+	require.Len(t, hotels, 1)
+	kept := hotels[0]
+	assert.Equal(t, "new", kept.ID, "the most recently updated row that is not deleted is kept")
+	assert.Equal(t, 4, kept.ArchivedReviewCount)
+	assert.JSONEq(t, `{"cupid": "1001", "expedia": "E-1"}`, kept.SourceMappings)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), kept.CreatedAt.UTC())
+
+	var single int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM hotels WHERE hotel_id = 2").Scan(&single).Error)
+	assert.Equal(t, int64(1), single)
+
+	var translations []string
+	require.NoError(t, db.Raw("SELECT id FROM translations WHERE hotel_id = 1 ORDER BY lang").Scan(&translations).Error)
+	assert.Equal(t, []string{"es-new", "fr"}, translations)
+
+	err := db.Exec(`INSERT INTO hotels (id, hotel_id, cupid_id, name, created_at, updated_at, next_update_at)
+		VALUES ('again', 1, 1, 'Duplicate', now(), now(), now())`).Error
+	assert.ErrorContains(t, err, "idx_hotels_hotel_id")
+}
+
+// TestMigrationsCreateEveryEntityColumn looks for each entity column in the migrations' text, so
+// a field added to an entity without a migration is caught without a database.
+func TestMigrationsCreateEveryEntityColumn(t *testing.T) {
+	migrations, err := NewDefaultMigrationRunner().Migrations()
+	require.NoError(t, err)
+	var statements strings.Builder
+	for _, migration := range migrations {
+		statements.WriteString(strings.ToLower(migration.SQL))
+		statements.WriteString("\n")
+	}
+	sql := statements.String()
+
+	models := []any{
+		&entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}, &entities.SyncRun{},
+		&entities.ReviewFetchState{}, &entities.HotelChange{}, &entities.APIUsageDaily{},
+	}
+	for _, model := range models {
+		modelSchema, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+		for _, field := range modelSchema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			column := regexp.MustCompile(`(^|[\s(,"])` + regexp.QuoteMeta(field.DBName) + `["\s]`)
+			assert.True(t, column.MatchString(sql), "%s.%s has no migration", modelSchema.Table, field.DBName)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+CREATE TABLE IF NOT EXISTS hotels (
+    id                   VARCHAR(36) PRIMARY KEY,
+    hotel_id             BIGINT       NOT NULL,
+    cupid_id             BIGINT       NOT NULL,
+    hotel_type_id        INTEGER,
+    name                 VARCHAR(255) NOT NULL,
+    description          TEXT,
+    address              JSONB,
+    rating               DECIMAL(3, 2),
+    star_rating          SMALLINT,
+    latitude             DECIMAL(10, 8),
+    longitude            DECIMAL(11, 8),
+    amenities            JSONB,
+    policies             JSONB,
+    contact_info         JSONB,
+    status               VARCHAR(20) DEFAULT 'active',
+    source               VARCHAR(50) DEFAULT 'cupid_api',
+    main_image_th        VARCHAR(500),
+    hotel_type           VARCHAR(100),
+    chain                VARCHAR(255),
+    chain_id             INTEGER,
+    phone                VARCHAR(50),
+    fax                  VARCHAR(50),
+    email                VARCHAR(255),
+    airport_code         VARCHAR(10),
+    review_count         INTEGER,
+    checkin              JSONB,
+    parking              VARCHAR(50),
+    group_room_min       JSONB,
+    child_allowed        BOOLEAN,
+    pets_allowed         BOOLEAN,
+    photos               JSONB,
+    markdown_description TEXT,
+    important_info       TEXT,
+    facilities           JSONB,
+    rooms                JSONB,
+    created_at           TIMESTAMPTZ  NOT NULL,
+    updated_at           TIMESTAMPTZ  NOT NULL,
+    deleted_at           TIMESTAMPTZ,
+    next_update_at       TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_hotels_status ON hotels (status);
+CREATE INDEX IF NOT EXISTS idx_hotels_deleted_at ON hotels (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id             VARCHAR(36) PRIMARY KEY,
+    hotel_id       BIGINT      NOT NULL,
+    review_id      BIGINT,
+    average_score  INTEGER     NOT NULL,
+    country        VARCHAR(100),
+    type           VARCHAR(50),
+    name           VARCHAR(255),
+    date           TIMESTAMPTZ NOT NULL,
+    headline       VARCHAR(500),
+    language       VARCHAR(10) DEFAULT 'en',
+    pros           TEXT,
+    cons           TEXT,
+    source         VARCHAR(50),
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    deleted_at     TIMESTAMPTZ,
+    next_update_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_hotel_id ON reviews (hotel_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_review_id ON reviews (review_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_deleted_at ON reviews (deleted_at);
+
+CREATE TABLE IF NOT EXISTS translations (
+    id                   VARCHAR(36) PRIMARY KEY,
+    hotel_id             BIGINT       NOT NULL,
+    name                 VARCHAR(255) NOT NULL,
+    description          TEXT,
+    address              JSONB,
+    policies             JSONB,
+    contact_info         JSONB,
+    status               VARCHAR(20) DEFAULT 'active',
+    source               VARCHAR(50) DEFAULT 'cupid_api',
+    chain                VARCHAR(255),
+    checkin              JSONB,
+    parking              VARCHAR(50),
+    group_room_min       JSONB,
+    photos               JSONB,
+    markdown_description TEXT,
+    important_info       TEXT,
+    facilities           JSONB,
+    rooms                JSONB,
+    lang                 VARCHAR(10),
+    created_at           TIMESTAMPTZ  NOT NULL,
+    updated_at           TIMESTAMPTZ  NOT NULL,
+    deleted_at           TIMESTAMPTZ,
+    next_update_at       TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_translations_status ON translations (status);
+CREATE INDEX IF NOT EXISTS idx_translations_deleted_at ON translations (deleted_at);
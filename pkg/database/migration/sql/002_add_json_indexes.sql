@@ -0,0 +1,6 @@
+CREATE INDEX IF NOT EXISTS idx_hotels_hotel_id ON hotels (hotel_id);
+CREATE INDEX IF NOT EXISTS idx_translations_hotel_id_lang ON translations (hotel_id, lang);
+
+CREATE INDEX IF NOT EXISTS idx_hotels_amenities ON hotels USING GIN (amenities jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_hotels_facilities ON hotels USING GIN (facilities jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_hotels_address_city ON hotels ((address ->> 'city'));
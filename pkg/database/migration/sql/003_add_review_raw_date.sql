@@ -0,0 +1 @@
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS raw_date VARCHAR(64);
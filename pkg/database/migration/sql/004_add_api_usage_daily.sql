@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_usage_daily (
+    client_id  VARCHAR(64)  NOT NULL,
+    endpoint   VARCHAR(255) NOT NULL,
+    day        DATE         NOT NULL,
+    requests   BIGINT       NOT NULL DEFAULT 0,
+    errors     BIGINT       NOT NULL DEFAULT 0,
+    results    BIGINT       NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (client_id, endpoint, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_usage_daily_day ON api_usage_daily (day);
@@ -0,0 +1,7 @@
+ALTER TABLE hotels ADD COLUMN IF NOT EXISTS source_mappings JSONB;
+
+UPDATE hotels
+SET source_mappings = jsonb_build_object('cupid', cupid_id::text)
+WHERE source_mappings IS NULL AND cupid_id <> 0;
+
+CREATE INDEX IF NOT EXISTS idx_hotels_source_mappings ON hotels USING GIN (source_mappings jsonb_path_ops);
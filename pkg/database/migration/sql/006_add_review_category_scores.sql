@@ -0,0 +1,4 @@
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS score_location INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS score_service INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS score_value INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS score_facilities INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS reviews_archive (
+    id               VARCHAR(36) PRIMARY KEY,
+    hotel_id         BIGINT      NOT NULL,
+    review_id        BIGINT,
+    average_score    INTEGER     NOT NULL,
+    country          VARCHAR(100),
+    type             VARCHAR(50),
+    name             VARCHAR(255),
+    date             TIMESTAMPTZ NOT NULL,
+    raw_date         VARCHAR(64),
+    headline         VARCHAR(500),
+    language         VARCHAR(10) DEFAULT 'en',
+    pros             TEXT,
+    cons             TEXT,
+    source           VARCHAR(50),
+    score_location   INTEGER     NOT NULL DEFAULT 0,
+    score_service    INTEGER     NOT NULL DEFAULT 0,
+    score_value      INTEGER     NOT NULL DEFAULT 0,
+    score_facilities INTEGER     NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    deleted_at       TIMESTAMPTZ,
+    next_update_at   TIMESTAMPTZ NOT NULL,
+    archived_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_archive_hotel_id_date ON reviews_archive (hotel_id, date DESC);
+CREATE INDEX IF NOT EXISTS idx_reviews_hotel_id_date ON reviews (hotel_id, date DESC);
+
+ALTER TABLE hotels ADD COLUMN IF NOT EXISTS archived_review_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,47 @@
+-- Rows with the same hotel_id were possible when the search-service fallback and a fetcher
+-- worker inserted the same hotel at once. The most recently updated row of each hotel is kept,
+-- preferring rows that are not soft-deleted, and takes over the source mappings, creation date
+-- and archived review count of its duplicates. Reviews and translations reference hotels by
+-- hotel_id, so they stay attached to the kept row without changes.
+WITH ranked AS (
+    SELECT id, hotel_id,
+           ROW_NUMBER() OVER (PARTITION BY hotel_id ORDER BY deleted_at IS NULL DESC, updated_at DESC, id) AS position
+    FROM hotels
+), duplicates AS (
+    SELECT h.*
+    FROM hotels h
+    JOIN ranked ON ranked.id = h.id
+    WHERE ranked.position > 1
+), merged AS (
+    SELECT d.hotel_id,
+           MIN(d.created_at) AS created_at,
+           MAX(d.archived_review_count) AS archived_review_count,
+           COALESCE(jsonb_object_agg(m.key, m.value) FILTER (WHERE m.key IS NOT NULL), '{}'::jsonb) AS source_mappings
+    FROM duplicates d
+    LEFT JOIN LATERAL jsonb_each(COALESCE(d.source_mappings, '{}'::jsonb)) m ON TRUE
+    GROUP BY d.hotel_id
+), kept AS (
+    UPDATE hotels h
+    SET created_at            = LEAST(h.created_at, merged.created_at),
+        archived_review_count = GREATEST(h.archived_review_count, merged.archived_review_count),
+        source_mappings       = NULLIF(merged.source_mappings || COALESCE(h.source_mappings, '{}'::jsonb), '{}'::jsonb)
+    FROM merged, ranked
+    WHERE ranked.id = h.id AND ranked.position = 1 AND ranked.hotel_id = merged.hotel_id
+)
+DELETE FROM hotels h
+USING ranked
+WHERE h.id = ranked.id AND ranked.position > 1;
+
+DELETE FROM translations t
+USING (
+    SELECT id,
+           ROW_NUMBER() OVER (PARTITION BY hotel_id, lang ORDER BY deleted_at IS NULL DESC, updated_at DESC, id) AS position
+    FROM translations
+) ranked
+WHERE t.id = ranked.id AND ranked.position > 1;
+
+DROP INDEX IF EXISTS idx_hotels_hotel_id;
+CREATE UNIQUE INDEX idx_hotels_hotel_id ON hotels (hotel_id);
+
+DROP INDEX IF EXISTS idx_translations_hotel_id_lang;
+CREATE UNIQUE INDEX idx_translations_hotel_id_lang ON translations (hotel_id, lang);
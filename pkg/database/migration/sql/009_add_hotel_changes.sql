@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS hotel_changes (
+    id                BIGSERIAL PRIMARY KEY,
+    hotel_id          BIGINT      NOT NULL,
+    changes           JSONB       NOT NULL,
+    source_message_id VARCHAR(64),
+    changed_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_hotel_changes_hotel_id_changed_at ON hotel_changes (hotel_id, changed_at DESC);
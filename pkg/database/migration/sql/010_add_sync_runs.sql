@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS sync_runs (
+    id             BIGSERIAL PRIMARY KEY,
+    mode           VARCHAR(16)  NOT NULL,
+    trigger_source VARCHAR(16)  NOT NULL,
+    chain          VARCHAR(255),
+    status         VARCHAR(16)  NOT NULL,
+    started_at     TIMESTAMPTZ  NOT NULL,
+    finished_at    TIMESTAMPTZ  NOT NULL,
+    duration_ms    BIGINT       NOT NULL DEFAULT 0,
+    total_hotels   INTEGER      NOT NULL DEFAULT 0,
+    indexed_hotels INTEGER      NOT NULL DEFAULT 0,
+    failed_hotels  INTEGER      NOT NULL DEFAULT 0,
+    removed_hotels INTEGER      NOT NULL DEFAULT 0,
+    error_count    INTEGER      NOT NULL DEFAULT 0,
+    errors         JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_runs_started_at ON sync_runs (started_at DESC);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS review_fetch_states (
+    hotel_id   BIGINT      PRIMARY KEY,
+    target     INTEGER     NOT NULL DEFAULT 0,
+    requested  INTEGER     NOT NULL DEFAULT 0,
+    returned   INTEGER     NOT NULL DEFAULT 0,
+    fetched    INTEGER     NOT NULL DEFAULT 0,
+    shortfall  INTEGER     NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL
+);
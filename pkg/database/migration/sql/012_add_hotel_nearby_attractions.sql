@@ -0,0 +1 @@
+ALTER TABLE hotels ADD COLUMN IF NOT EXISTS nearby_attractions JSONB;
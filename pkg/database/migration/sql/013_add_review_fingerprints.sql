@@ -0,0 +1,3 @@
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_hotel_fingerprint ON reviews (hotel_id, fingerprint);
@@ -0,0 +1,4 @@
+-- Review fingerprints now include the reviewer's country and traveller type and the scores.
+-- Fingerprints computed before are cleared so the review dedupe task computes them again;
+-- until it does, ingestion cannot match those reviews by content.
+UPDATE reviews SET fingerprint = NULL WHERE fingerprint IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE hotel_changes ADD COLUMN IF NOT EXISTS actor VARCHAR(255);
@@ -9,10 +9,24 @@ import (
 	"gorm.io/gorm"
 )
 
+// SourceCupid is the SourceMappings key of the Cupid API hotel id.
+const SourceCupid = "cupid"
+
+// HotelStatusRemovedUpstream marks a hotel the Cupid API no longer serves. The next successful
+// fetch makes it active again.
+const HotelStatusRemovedUpstream = "removed_upstream"
+
+// Attraction is a landmark near a hotel, such as a museum or a beach.
+type Attraction struct {
+	Name           string `json:"name"`
+	Category       string `json:"category"`
+	DistanceMeters int    `json:"distance_meters"`
+}
+
 type HotelData struct {
 	ID string `gorm:"primaryKey;type:varchar(36)"`
 
-	HotelID     int64 `gorm:"not null"`
+	HotelID     int64 `gorm:"not null;uniqueIndex:idx_hotels_hotel_id"`
 	CupidID     int64 `gorm:"not null"`
 	HotelTypeID int64 `gorm:"type:integer"`
 
@@ -23,6 +37,7 @@ type HotelData struct {
 	StarRating          int32          `gorm:"type:smallint"`
 	Latitude            float64        `gorm:"type:decimal(10,8)"`
 	Longitude           float64        `gorm:"type:decimal(11,8)"`
+	Timezone            string         `gorm:"type:varchar(50)"`
 	Amenities           datatypes.JSON `gorm:"type:jsonb"`
 	Policies            datatypes.JSON `gorm:"type:jsonb"`
 	ContactInfo         datatypes.JSON `gorm:"type:jsonb"`
@@ -37,6 +52,7 @@ type HotelData struct {
 	Email               string         `gorm:"type:varchar(255)"`
 	AirportCode         string         `gorm:"type:varchar(10)"`
 	ReviewCount         int32          `gorm:"type:integer"`
+	ArchivedReviewCount int32          `gorm:"not null;default:0"`
 	Checkin             datatypes.JSON `gorm:"type:jsonb"`
 	Parking             string         `gorm:"type:varchar(50)"`
 	GroupRoomMin        datatypes.JSON `gorm:"type:jsonb"`
@@ -47,6 +63,14 @@ type HotelData struct {
 	ImportantInfo       string         `gorm:"type:text"`
 	Facilities          datatypes.JSON `gorm:"type:jsonb"`
 	Rooms               datatypes.JSON `gorm:"type:jsonb"`
+	// SourceMappings maps a data source name to the hotel id used by that source.
+	SourceMappings datatypes.JSON `gorm:"type:jsonb"`
+	// Sources maps each field group, such as photos, to the source that last wrote it and when.
+	// See MergeSources.
+	Sources datatypes.JSON `gorm:"type:jsonb"`
+	// NearbyAttractions lists the landmarks around the hotel found by geo enrichment, as
+	// objects with name, category and distance_meters.
+	NearbyAttractions datatypes.JSON `gorm:"type:jsonb"`
 
 	CreatedAt    time.Time      `gorm:"not null"`
 	UpdatedAt    time.Time      `gorm:"not null"`
@@ -151,3 +175,48 @@ func (h *HotelData) SetFacilities(facilities []string) error {
 	h.Facilities = data
 	return nil
 }
+
+func (h *HotelData) SetSourceMappings(mappings map[string]string) error {
+	if len(mappings) == 0 {
+		h.SourceMappings = datatypes.JSON("")
+		return nil
+	}
+	data, err := json.Marshal(mappings)
+	if err != nil {
+		return err
+	}
+	h.SourceMappings = data
+	return nil
+}
+
+// GetSourceMappings returns the stored source mappings, or an empty map when there are none.
+func (h *HotelData) GetSourceMappings() map[string]string {
+	mappings := make(map[string]string)
+	if len(h.SourceMappings) > 0 {
+		_ = json.Unmarshal(h.SourceMappings, &mappings)
+	}
+	return mappings
+}
+
+// SetNearbyAttractions stores the attractions around the hotel. An empty list is stored as
+// such, to tell a hotel without attractions from one that was never enriched.
+func (h *HotelData) SetNearbyAttractions(attractions []Attraction) error {
+	if attractions == nil {
+		attractions = []Attraction{}
+	}
+	data, err := json.Marshal(attractions)
+	if err != nil {
+		return err
+	}
+	h.NearbyAttractions = data
+	return nil
+}
+
+// GetNearbyAttractions returns the stored attractions, or nil when there are none.
+func (h *HotelData) GetNearbyAttractions() []Attraction {
+	var attractions []Attraction
+	if len(h.NearbyAttractions) > 0 {
+		_ = json.Unmarshal(h.NearbyAttractions, &attractions)
+	}
+	return attractions
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// HotelChange records the tracked fields of a hotel that one worker upsert or one manual
+// correction changed.
+type HotelChange struct {
+	ID              int64          `gorm:"primaryKey;autoIncrement"`
+	HotelID         int64          `gorm:"not null;index:idx_hotel_changes_hotel_id_changed_at,priority:1"`
+	Changes         datatypes.JSON `gorm:"type:jsonb;not null"`
+	SourceMessageID string         `gorm:"type:varchar(64)"`
+	ChangedAt       time.Time      `gorm:"not null;index:idx_hotel_changes_hotel_id_changed_at,priority:2,sort:desc"`
+	// Actor identifies the caller that made a manual correction, empty for worker upserts.
+	Actor string `gorm:"type:varchar(255)"`
+}
+
+func (c *HotelChange) TableName() string {
+	return "hotel_changes"
+}
+
+// FieldChange is the value of a hotel field before and after an upsert.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// hotelFieldValues reads the hotel fields that can be tracked for changes. Rating is rounded
+// to the precision of its column, so an API value with more decimals than the stored one is
+// not reported as a change.
+var hotelFieldValues = map[string]func(h *HotelData) any{
+	"name":          func(h *HotelData) any { return h.Name },
+	"rating":        func(h *HotelData) any { return math.Round(h.Rating*100) / 100 },
+	"star_rating":   func(h *HotelData) any { return h.StarRating },
+	"status":        func(h *HotelData) any { return h.Status },
+	"child_allowed": func(h *HotelData) any { return h.ChildAllowed },
+	"pets_allowed":  func(h *HotelData) any { return h.PetsAllowed },
+	"parking":       func(h *HotelData) any { return h.Parking },
+}
+
+// TrackableHotelFields lists the field names accepted by DiffHotel.
+var TrackableHotelFields = []string{"name", "rating", "star_rating", "status", "child_allowed", "pets_allowed", "parking"}
+
+// DiffHotel returns the given fields whose value differs between previous and current, keyed
+// by field name. It returns nil when nothing changed or when there is no previous row. Unknown
+// field names are ignored.
+func DiffHotel(previous, current *HotelData, fields []string) map[string]FieldChange {
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	var changes map[string]FieldChange
+	for _, field := range fields {
+		value, ok := hotelFieldValues[field]
+		if !ok {
+			continue
+		}
+
+		oldValue, newValue := value(previous), value(current)
+		if oldValue == newValue {
+			continue
+		}
+		if changes == nil {
+			changes = make(map[string]FieldChange)
+		}
+		changes[field] = FieldChange{Old: oldValue, New: newValue}
+	}
+	return changes
+}
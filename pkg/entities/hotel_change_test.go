@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffHotelReportsChangedTrackedFields(t *testing.T) {
+	previous := &HotelData{Name: "Harbour Hotel", Rating: 4.5, StarRating: 4, Status: "active", ChildAllowed: true, Parking: "Free"}
+	current := &HotelData{Name: "Harbour Hotel", Rating: 3.1, StarRating: 4, Status: "active", PetsAllowed: true, Parking: "Free"}
+
+	changes := DiffHotel(previous, current, TrackableHotelFields)
+
+	assert.Equal(t, map[string]FieldChange{
+		"rating":        {Old: 4.5, New: 3.1},
+		"child_allowed": {Old: true, New: false},
+		"pets_allowed":  {Old: false, New: true},
+	}, changes)
+}
+
+func TestDiffHotelOnlyTracksTheGivenFields(t *testing.T) {
+	previous := &HotelData{Name: "Harbour Hotel", Rating: 4.5}
+	current := &HotelData{Name: "Harbour Inn", Rating: 3.1}
+
+	changes := DiffHotel(previous, current, []string{"name", "description"})
+
+	assert.Equal(t, map[string]FieldChange{"name": {Old: "Harbour Hotel", New: "Harbour Inn"}}, changes,
+		"untracked and unknown fields are ignored")
+}
+
+func TestDiffHotelWithoutChanges(t *testing.T) {
+	previous := &HotelData{Name: "Harbour Hotel", Rating: 4.5, Description: "Old description"}
+	current := &HotelData{Name: "Harbour Hotel", Rating: 4.5000001, Description: "New description"}
+
+	assert.Nil(t, DiffHotel(previous, current, TrackableHotelFields),
+		"a rating below the stored precision and an untracked field are not changes")
+	assert.Nil(t, DiffHotel(nil, current, TrackableHotelFields), "a new hotel has no previous values")
+	assert.Nil(t, DiffHotel(previous, current, nil))
+}
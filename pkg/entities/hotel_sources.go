@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Field groups of a hotel, each attributed to the source that last wrote it.
+const (
+	FieldGroupCore     = "core"
+	FieldGroupPhotos   = "photos"
+	FieldGroupPolicies = "policies"
+	FieldGroupRooms    = "rooms"
+)
+
+// FieldGroups lists the field groups in a stable order.
+var FieldGroups = []string{FieldGroupCore, FieldGroupPhotos, FieldGroupPolicies, FieldGroupRooms}
+
+// Sources writing hotels: bulk imports such as the seeder, the search-service fallback to the
+// Cupid API for hotels missing from the database, and the fetcher workers.
+const (
+	DataSourceImport       = "import"
+	DataSourceFallback     = "fallback"
+	DataSourceCupidFetcher = "cupid_fetcher"
+)
+
+// DefaultSourcePriorities ranks the sources when no priorities are configured.
+var DefaultSourcePriorities = SourcePriorities{DataSourceImport, DataSourceFallback, DataSourceCupidFetcher}
+
+// FieldSource is the source that last wrote a field group, and when.
+type FieldSource struct {
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SourcePriorities lists sources from the lowest priority to the highest. Unlisted sources
+// rank below every listed one.
+type SourcePriorities []string
+
+// Validate reports empty and repeated sources.
+func (p SourcePriorities) Validate() error {
+	for i, source := range p {
+		if source == "" {
+			return fmt.Errorf("source %d is empty", i+1)
+		}
+		if slices.Index(p, source) != i {
+			return fmt.Errorf("source %q is listed twice", source)
+		}
+	}
+	return nil
+}
+
+// SourceWrite describes a write of a hotel: the source writing it, how sources rank and whether
+// field groups owned by higher-priority sources are overwritten anyway.
+type SourceWrite struct {
+	Source string
+	// Priorities ranks the sources, DefaultSourcePriorities when empty.
+	Priorities SourcePriorities
+	Force      bool
+}
+
+// fieldGroupCopiers copy the fields of a group from the stored hotel to the one being written.
+var fieldGroupCopiers = map[string]func(dst, src *HotelData){
+	FieldGroupCore: func(dst, src *HotelData) {
+		dst.CupidID = src.CupidID
+		dst.HotelTypeID = src.HotelTypeID
+		dst.HotelType = src.HotelType
+		dst.Name = src.Name
+		dst.Description = src.Description
+		dst.MarkdownDescription = src.MarkdownDescription
+		dst.Address = src.Address
+		dst.Rating = src.Rating
+		dst.StarRating = src.StarRating
+		dst.Latitude = src.Latitude
+		dst.Longitude = src.Longitude
+		dst.Timezone = src.Timezone
+		dst.Amenities = src.Amenities
+		dst.Facilities = src.Facilities
+		dst.ContactInfo = src.ContactInfo
+		dst.Phone = src.Phone
+		dst.Fax = src.Fax
+		dst.Email = src.Email
+		dst.Chain = src.Chain
+		dst.ChainID = src.ChainID
+		dst.AirportCode = src.AirportCode
+		dst.ReviewCount = src.ReviewCount
+	},
+	FieldGroupPhotos: func(dst, src *HotelData) {
+		dst.Photos = src.Photos
+		dst.MainImageTh = src.MainImageTh
+	},
+	FieldGroupPolicies: func(dst, src *HotelData) {
+		dst.Policies = src.Policies
+		dst.Checkin = src.Checkin
+		dst.Parking = src.Parking
+		dst.GroupRoomMin = src.GroupRoomMin
+		dst.ChildAllowed = src.ChildAllowed
+		dst.PetsAllowed = src.PetsAllowed
+		dst.ImportantInfo = src.ImportantInfo
+	},
+	FieldGroupRooms: func(dst, src *HotelData) {
+		dst.Rooms = src.Rooms
+	},
+}
+
+// MergeSources prepares h, about to be stored by write, over existing, the stored hotel or nil
+// for a new one. A field group existing got from a higher-priority source keeps its stored
+// values and attribution unless the write is forced. The other groups are attributed to the
+// write. It returns the groups kept.
+func (h *HotelData) MergeSources(existing *HotelData, write SourceWrite, now time.Time) ([]string, error) {
+	priorities := write.Priorities
+	if len(priorities) == 0 {
+		priorities = DefaultSourcePriorities
+	}
+
+	var stored map[string]FieldSource
+	if existing != nil {
+		stored = existing.GetSources()
+	}
+
+	sources := make(map[string]FieldSource, len(FieldGroups))
+	var kept []string
+	for _, group := range FieldGroups {
+		owner, owned := stored[group]
+		if owned && !write.Force && slices.Index(priorities, owner.Source) > slices.Index(priorities, write.Source) {
+			fieldGroupCopiers[group](h, existing)
+			sources[group] = owner
+			kept = append(kept, group)
+			continue
+		}
+		sources[group] = FieldSource{Source: write.Source, UpdatedAt: now.UTC()}
+	}
+
+	return kept, h.SetSources(sources)
+}
+
+func (h *HotelData) SetSources(sources map[string]FieldSource) error {
+	if len(sources) == 0 {
+		h.Sources = datatypes.JSON("")
+		return nil
+	}
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return err
+	}
+	h.Sources = data
+	return nil
+}
+
+// GetSources returns the stored field group attribution, or an empty map when there is none.
+func (h *HotelData) GetSources() map[string]FieldSource {
+	sources := make(map[string]FieldSource)
+	if len(h.Sources) > 0 {
+		_ = json.Unmarshal(h.Sources, &sources)
+	}
+	return sources
+}
+
+// KeepNewerSources copies from stored, the hotel as stored now, the field groups a source wrote
+// after the attribution h carries, values and attribution both, so storing h does not undo a
+// write made since h was read. It returns the groups kept.
+func (h *HotelData) KeepNewerSources(stored *HotelData) ([]string, error) {
+	sources := h.GetSources()
+	var kept []string
+	for group, storedSource := range stored.GetSources() {
+		source, attributed := sources[group]
+		if attributed && !storedSource.UpdatedAt.After(source.UpdatedAt) {
+			continue
+		}
+		if copyFields, ok := fieldGroupCopiers[group]; ok {
+			copyFields(h, stored)
+		}
+		sources[group] = storedSource
+		kept = append(kept, group)
+	}
+	slices.Sort(kept)
+	return kept, h.SetSources(sources)
+}
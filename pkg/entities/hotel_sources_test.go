@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func hotelWithSources(t *testing.T, name string, photos string, sources map[string]FieldSource) *HotelData {
+	t.Helper()
+	h := &HotelData{HotelID: 42, Name: name, Photos: datatypes.JSON(photos)}
+	require.NoError(t, h.SetSources(sources))
+	return h
+}
+
+func TestMergeSourcesKeepsGroupsOfHigherPrioritySources(t *testing.T) {
+	earlier := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	now := earlier.Add(time.Hour)
+	stored := hotelWithSources(t, "Worker name", `["worker.jpg"]`, map[string]FieldSource{
+		FieldGroupCore:   {Source: DataSourceCupidFetcher, UpdatedAt: earlier},
+		FieldGroupPhotos: {Source: DataSourceImport, UpdatedAt: earlier},
+	})
+	h := hotelWithSources(t, "Fallback name", `["fallback.jpg"]`, nil)
+
+	kept, err := h.MergeSources(stored, SourceWrite{Source: DataSourceFallback}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{FieldGroupCore}, kept)
+	assert.Equal(t, "Worker name", h.Name)
+	assert.JSONEq(t, `["fallback.jpg"]`, string(h.Photos))
+	sources := h.GetSources()
+	assert.Equal(t, FieldSource{Source: DataSourceCupidFetcher, UpdatedAt: earlier}, sources[FieldGroupCore])
+	assert.Equal(t, FieldSource{Source: DataSourceFallback, UpdatedAt: now}, sources[FieldGroupPhotos])
+	assert.Len(t, sources, len(FieldGroups))
+}
+
+func TestMergeSourcesForcedWriteOverwritesEveryGroup(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	stored := hotelWithSources(t, "Worker name", `[]`, map[string]FieldSource{
+		FieldGroupCore: {Source: DataSourceCupidFetcher, UpdatedAt: now.Add(-time.Hour)},
+	})
+	h := hotelWithSources(t, "Fallback name", `[]`, nil)
+
+	kept, err := h.MergeSources(stored, SourceWrite{Source: DataSourceFallback, Force: true}, now)
+	require.NoError(t, err)
+
+	assert.Empty(t, kept)
+	assert.Equal(t, "Fallback name", h.Name)
+	assert.Equal(t, DataSourceFallback, h.GetSources()[FieldGroupCore].Source)
+}
+
+func TestKeepNewerSourcesKeepsGroupsWrittenSinceRead(t *testing.T) {
+	read := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	written := read.Add(30 * time.Minute)
+	h := hotelWithSources(t, "Edited name", `["read.jpg"]`, map[string]FieldSource{
+		FieldGroupCore:   {Source: DataSourceFallback, UpdatedAt: read},
+		FieldGroupPhotos: {Source: DataSourceFallback, UpdatedAt: read},
+	})
+	stored := hotelWithSources(t, "Stored name", `["worker.jpg"]`, map[string]FieldSource{
+		FieldGroupCore:   {Source: DataSourceFallback, UpdatedAt: read},
+		FieldGroupPhotos: {Source: DataSourceCupidFetcher, UpdatedAt: written},
+		FieldGroupRooms:  {Source: DataSourceCupidFetcher, UpdatedAt: written},
+	})
+	stored.Rooms = datatypes.JSON(`[{"id":1}]`)
+
+	kept, err := h.KeepNewerSources(stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{FieldGroupPhotos, FieldGroupRooms}, kept)
+	assert.Equal(t, "Edited name", h.Name, "a group not written since the read keeps the update's values")
+	assert.JSONEq(t, `["worker.jpg"]`, string(h.Photos))
+	assert.JSONEq(t, `[{"id":1}]`, string(h.Rooms))
+
+	sources := h.GetSources()
+	assert.Equal(t, FieldSource{Source: DataSourceFallback, UpdatedAt: read}, sources[FieldGroupCore])
+	assert.Equal(t, FieldSource{Source: DataSourceCupidFetcher, UpdatedAt: written}, sources[FieldGroupPhotos])
+	assert.Equal(t, FieldSource{Source: DataSourceCupidFetcher, UpdatedAt: written}, sources[FieldGroupRooms])
+}
+
+func TestKeepNewerSourcesWithoutStoredSources(t *testing.T) {
+	read := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	h := hotelWithSources(t, "Edited name", `[]`, map[string]FieldSource{
+		FieldGroupCore: {Source: DataSourceFallback, UpdatedAt: read},
+	})
+
+	kept, err := h.KeepNewerSources(&HotelData{Name: "Stored name"})
+	require.NoError(t, err)
+
+	assert.Empty(t, kept)
+	assert.Equal(t, "Edited name", h.Name)
+	assert.Equal(t, map[string]FieldSource{FieldGroupCore: {Source: DataSourceFallback, UpdatedAt: read}}, h.GetSources())
+}
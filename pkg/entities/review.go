@@ -1,6 +1,10 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,19 +12,27 @@ import (
 )
 
 type ReviewData struct {
-	ID           string         `gorm:"primaryKey;type:varchar(36)"`
-	HotelID      int64          `gorm:"not null;index:idx_reviews_hotel_id"`
-	ReviewID     int64          `gorm:"uniqueIndex"`
-	AverageScore int32          `gorm:"not null"`
-	Country      string         `gorm:"type:varchar(100)"`
-	Type         string         `gorm:"type:varchar(50)"`
-	Name         string         `gorm:"type:varchar(255)"`
-	Date         time.Time      `gorm:"not null"`
-	Headline     string         `gorm:"type:varchar(500)"`
-	Language     string         `gorm:"type:varchar(10);default:en"`
-	Pros         string         `gorm:"type:text"`
-	Cons         string         `gorm:"type:text"`
-	Source       string         `gorm:"type:varchar(50)"`
+	ID              string    `gorm:"primaryKey;type:varchar(36)"`
+	HotelID         int64     `gorm:"not null;index:idx_reviews_hotel_id;index:idx_reviews_hotel_fingerprint,priority:1"`
+	ReviewID        int64     `gorm:"uniqueIndex"`
+	AverageScore    int32     `gorm:"not null"`
+	Country         string    `gorm:"type:varchar(100)"`
+	Type            string    `gorm:"type:varchar(50)"`
+	Name            string    `gorm:"type:varchar(255)"`
+	Date            time.Time `gorm:"not null"`
+	RawDate         string    `gorm:"type:varchar(64)"`
+	Headline        string    `gorm:"type:varchar(500)"`
+	Language        string    `gorm:"type:varchar(10);default:en"`
+	Pros            string    `gorm:"type:text"`
+	Cons            string    `gorm:"type:text"`
+	Source          string    `gorm:"type:varchar(50)"`
+	ScoreLocation   int32     `gorm:"not null;default:0"`
+	ScoreService    int32     `gorm:"not null;default:0"`
+	ScoreValue      int32     `gorm:"not null;default:0"`
+	ScoreFacilities int32     `gorm:"not null;default:0"`
+	// Fingerprint identifies the review content, so the same review imported under another
+	// review id is recognized. See ContentFingerprint.
+	Fingerprint  string         `gorm:"type:varchar(64);index:idx_reviews_hotel_fingerprint,priority:2"`
 	CreatedAt    time.Time      `gorm:"not null"`
 	UpdatedAt    time.Time      `gorm:"not null"`
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
@@ -39,14 +51,58 @@ func (r *ReviewData) BeforeCreate(_ *gorm.DB) (err error) {
 	if r.Language == "" {
 		r.Language = "en"
 	}
+	r.Fingerprint = r.ContentFingerprint()
 	return
 }
 
 func (r *ReviewData) BeforeUpdate(_ *gorm.DB) (err error) {
 	r.UpdatedAt = time.Now()
+	r.Fingerprint = r.ContentFingerprint()
 	return
 }
 
+// ContentFingerprint hashes the reviewer, date, scores, headline, pros and cons, ignoring case
+// and whitespace differences. The reviewer is the name, country and traveller type, so reviews
+// without text left on the same day by different guests stay apart. The date is the parsed
+// day, or the raw date when it did not parse.
+func (r *ReviewData) ContentFingerprint() string {
+	date := normalizeFingerprintField(r.RawDate)
+	if !r.Date.IsZero() {
+		date = r.Date.UTC().Format(time.DateOnly)
+	}
+
+	fields := []string{
+		normalizeFingerprintField(r.Name),
+		normalizeFingerprintField(r.Country),
+		normalizeFingerprintField(r.Type),
+		date,
+		fmt.Sprintf("%d/%d/%d/%d/%d", r.AverageScore, r.ScoreLocation, r.ScoreService, r.ScoreValue, r.ScoreFacilities),
+		normalizeFingerprintField(r.Headline),
+		normalizeFingerprintField(r.Pros),
+		normalizeFingerprintField(r.Cons),
+	}
+	hash := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(hash[:])
+}
+
+func normalizeFingerprintField(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+// SetDate keeps the original value in RawDate and stores the parsed date in UTC.
+// It returns false when the value could not be parsed, leaving Date as the zero time.
+func (r *ReviewData) SetDate(raw string) bool {
+	r.RawDate = raw
+	parsed, ok := ParseReviewDate(raw)
+	r.Date = parsed
+	return ok
+}
+
+// HasUnparsedDate reports whether the source provided a date that could not be parsed.
+func (r *ReviewData) HasUnparsedDate() bool {
+	return r.RawDate != "" && r.Date.IsZero()
+}
+
 func (r *ReviewData) TableName() string {
 	return "reviews"
 }
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var reviewDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseReviewDate parses the review date formats returned by the content API and normalizes the
+// result to UTC. The boolean is false when the value is empty or in none of the known formats.
+func ParseReviewDate(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range reviewDateLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil && seconds > 0 {
+		return time.Unix(seconds, 0).UTC(), true
+	}
+
+	return time.Time{}, false
+}
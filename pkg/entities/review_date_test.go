@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReviewDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected time.Time
+		ok       bool
+	}{
+		{name: "RFC3339 in UTC", raw: "2024-03-15T10:30:00Z", expected: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC), ok: true},
+		{name: "RFC3339 with offset", raw: "2024-03-15T10:30:00+02:00", expected: time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC), ok: true},
+		{name: "space separated", raw: "2024-03-15 10:30:00", expected: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC), ok: true},
+		{name: "T separated without zone", raw: "2024-03-15T10:30:00", expected: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC), ok: true},
+		{name: "date only", raw: "2024-03-15", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), ok: true},
+		{name: "epoch seconds", raw: "1710498600", expected: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC), ok: true},
+		{name: "surrounding spaces", raw: " 2024-03-15 ", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), ok: true},
+		{name: "empty", raw: ""},
+		{name: "unknown layout", raw: "15/03/2024"},
+		{name: "negative epoch", raw: "-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, ok := ParseReviewDate(tt.raw)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.expected.Equal(parsed), "expected %s, got %s", tt.expected, parsed)
+			if ok {
+				assert.Equal(t, time.UTC, parsed.Location())
+			}
+		})
+	}
+}
+
+func TestReviewDataSetDate(t *testing.T) {
+	var review ReviewData
+	assert.True(t, review.SetDate("2024-03-15T10:30:00+02:00"))
+	assert.Equal(t, "2024-03-15T10:30:00+02:00", review.RawDate)
+	assert.False(t, review.HasUnparsedDate())
+
+	assert.False(t, review.SetDate("last summer"))
+	assert.Equal(t, "last summer", review.RawDate)
+	assert.True(t, review.Date.IsZero())
+	assert.True(t, review.HasUnparsedDate())
+
+	assert.False(t, review.SetDate(""))
+	assert.False(t, review.HasUnparsedDate())
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// ReviewFetchState is the review fetch bookkeeping of one hotel. Target is how many reviews the
+// hotel should hold, Fetched how many the fetches so far returned and Shortfall how many are
+// still missing. The next review cycle of a hotel with a shortfall asks for the missing reviews
+// starting at Fetched; Fetched is reset to 0 when such a resumed fetch comes back short.
+type ReviewFetchState struct {
+	HotelID   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Target    int       `gorm:"not null;default:0"`
+	Requested int       `gorm:"not null;default:0"`
+	Returned  int       `gorm:"not null;default:0"`
+	Fetched   int       `gorm:"not null;default:0"`
+	Shortfall int       `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (s *ReviewFetchState) TableName() string {
+	return "review_fetch_states"
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fingerprintTestReview() ReviewData {
+	return ReviewData{
+		HotelID:         1641879,
+		ReviewID:        100,
+		AverageScore:    8,
+		Country:         "es",
+		Type:            "couple",
+		Name:            "Ana García",
+		Date:            time.Date(2024, 5, 12, 9, 30, 0, 0, time.UTC),
+		Headline:        "Lovely stay",
+		Pros:            "Great location, friendly staff",
+		Cons:            "Small rooms",
+		Source:          "booking",
+		ScoreLocation:   9,
+		ScoreService:    8,
+		ScoreValue:      7,
+		ScoreFacilities: 8,
+	}
+}
+
+func TestContentFingerprintIgnoresCaseWhitespaceAndSource(t *testing.T) {
+	review := fingerprintTestReview()
+	reimported := review
+	reimported.ReviewID = 200
+	reimported.Source = "expedia"
+	reimported.Name = "  ANA   garcía "
+	reimported.Country = "ES"
+	reimported.Type = "Couple"
+	reimported.Headline = "lovely\tstay"
+	reimported.Pros = "Great location,\n friendly   staff"
+	reimported.Date = time.Date(2024, 5, 12, 18, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, review.ContentFingerprint(), reimported.ContentFingerprint())
+	assert.Len(t, review.ContentFingerprint(), 64)
+}
+
+func TestContentFingerprintTellsReviewsApart(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*ReviewData)
+	}{
+		{"name", func(r *ReviewData) { r.Name = "Luc Martin" }},
+		{"country", func(r *ReviewData) { r.Country = "fr" }},
+		{"traveller type", func(r *ReviewData) { r.Type = "family" }},
+		{"day", func(r *ReviewData) { r.Date = r.Date.AddDate(0, 0, 1) }},
+		{"average score", func(r *ReviewData) { r.AverageScore = 6 }},
+		{"location score", func(r *ReviewData) { r.ScoreLocation = 5 }},
+		{"service score", func(r *ReviewData) { r.ScoreService = 5 }},
+		{"value score", func(r *ReviewData) { r.ScoreValue = 5 }},
+		{"facilities score", func(r *ReviewData) { r.ScoreFacilities = 5 }},
+		{"headline", func(r *ReviewData) { r.Headline = "Noisy" }},
+		{"pros", func(r *ReviewData) { r.Pros = "Breakfast" }},
+		{"cons", func(r *ReviewData) { r.Cons = "" }},
+	}
+	review := fingerprintTestReview()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := review
+			tt.change(&other)
+			assert.NotEqual(t, review.ContentFingerprint(), other.ContentFingerprint())
+		})
+	}
+}
+
+func TestContentFingerprintKeepsReviewsWithoutTextApart(t *testing.T) {
+	date := time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)
+	first := ReviewData{Name: "Ana", Country: "es", Type: "couple", Date: date, AverageScore: 8}
+	second := ReviewData{Name: "Luc", Country: "fr", Type: "solo", Date: date, AverageScore: 8}
+	anonymous := ReviewData{Date: date, AverageScore: 8}
+	anonymousLowScore := ReviewData{Date: date, AverageScore: 4}
+
+	assert.NotEqual(t, first.ContentFingerprint(), second.ContentFingerprint())
+	assert.NotEqual(t, anonymous.ContentFingerprint(), anonymousLowScore.ContentFingerprint())
+}
+
+func TestContentFingerprintUsesTheRawDateWhenItDidNotParse(t *testing.T) {
+	review := ReviewData{Name: "Ana", RawDate: "sometime in May"}
+	same := ReviewData{Name: "Ana", RawDate: "  Sometime in MAY"}
+	other := ReviewData{Name: "Ana", RawDate: "sometime in June"}
+
+	assert.Equal(t, review.ContentFingerprint(), same.ContentFingerprint())
+	assert.NotEqual(t, review.ContentFingerprint(), other.ContentFingerprint())
+}
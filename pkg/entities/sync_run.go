@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// SyncRun records one finished search index sync of the search service.
+type SyncRun struct {
+	ID            int64          `gorm:"primaryKey;autoIncrement"`
+	Mode          string         `gorm:"type:varchar(16);not null"`
+	TriggerSource string         `gorm:"type:varchar(16);not null"`
+	Chain         string         `gorm:"type:varchar(255)"`
+	Status        string         `gorm:"type:varchar(16);not null"`
+	StartedAt     time.Time      `gorm:"not null;index:idx_sync_runs_started_at,sort:desc"`
+	FinishedAt    time.Time      `gorm:"not null"`
+	DurationMs    int64          `gorm:"not null;default:0"`
+	TotalHotels   int            `gorm:"not null;default:0"`
+	IndexedHotels int            `gorm:"not null;default:0"`
+	FailedHotels  int            `gorm:"not null;default:0"`
+	RemovedHotels int            `gorm:"not null;default:0"`
+	ErrorCount    int            `gorm:"not null;default:0"`
+	Errors        datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (r *SyncRun) TableName() string {
+	return "sync_runs"
+}
@@ -12,7 +12,7 @@ import (
 type HotelTranslation struct {
 	ID string `gorm:"primaryKey;type:varchar(36)"`
 
-	HotelID int64 `gorm:"not null"`
+	HotelID int64 `gorm:"not null;uniqueIndex:idx_translations_hotel_id_lang"`
 
 	Name        string         `gorm:"not null;type:varchar(255)"`
 	Description string         `gorm:"type:text"`
@@ -32,7 +32,7 @@ type HotelTranslation struct {
 	Facilities          datatypes.JSON `gorm:"type:jsonb"`
 	Rooms               datatypes.JSON `gorm:"type:jsonb"`
 
-	Lang string `gorm:"type:varchar(10)"`
+	Lang string `gorm:"type:varchar(10);uniqueIndex:idx_translations_hotel_id_lang"`
 
 	CreatedAt    time.Time      `gorm:"not null"`
 	UpdatedAt    time.Time      `gorm:"not null"`
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// APIUsageDaily holds the usage counters of one client and endpoint for one UTC day, rolled up
+// from Redis by the search service.
+type APIUsageDaily struct {
+	ClientID  string    `gorm:"primaryKey;type:varchar(64)"`
+	Endpoint  string    `gorm:"primaryKey;type:varchar(255)"`
+	Day       time.Time `gorm:"primaryKey;type:date"`
+	Requests  int64     `gorm:"not null;default:0"`
+	Errors    int64     `gorm:"not null;default:0"`
+	Results   int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (u *APIUsageDaily) TableName() string {
+	return "api_usage_daily"
+}
@@ -0,0 +1,176 @@
+// Package facilities maps the free-text facility names of the Cupid API ("Free WiFi",
+// "free wifi", "WiFi") to canonical slugs ("wifi"), so filters and facets see one value per
+// facility.
+package facilities
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Facility is a canonical facility.
+type Facility struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type definition struct {
+	Facility
+	aliases []string
+}
+
+// defaults are the canonical facilities and the raw names known to mean them. The slug and
+// the name of each facility are aliases of it too.
+var defaults = []definition{
+	{Facility{"wifi", "WiFi"}, []string{"free wifi", "wi fi", "free wi fi", "internet", "internet access", "free internet", "wireless internet", "free wireless internet", "wifi available in all areas", "free wifi in all areas", "wifi in public areas"}},
+	{Facility{"parking", "Parking"}, []string{"free parking", "car park", "parking on site", "private parking", "on site parking", "secured parking", "parking garage", "valet parking"}},
+	{Facility{"pool", "Swimming pool"}, []string{"swimming pool", "outdoor pool", "indoor pool", "outdoor swimming pool", "indoor swimming pool", "heated pool", "seasonal outdoor pool"}},
+	{Facility{"fitness_center", "Fitness center"}, []string{"fitness centre", "gym", "fitness room", "fitness facilities", "health club"}},
+	{Facility{"spa", "Spa"}, []string{"spa and wellness centre", "spa and wellness center", "wellness centre", "wellness center", "spa services", "sauna", "massage"}},
+	{Facility{"restaurant", "Restaurant"}, []string{"on site restaurant", "restaurants"}},
+	{Facility{"bar", "Bar"}, []string{"lounge bar", "bar lounge", "snack bar", "poolside bar"}},
+	{Facility{"room_service", "Room service"}, []string{"24 hour room service"}},
+	{Facility{"airport_shuttle", "Airport shuttle"}, []string{"free airport shuttle", "airport shuttle service", "airport transfer", "shuttle service"}},
+	{Facility{"breakfast", "Breakfast"}, []string{"breakfast available", "free breakfast", "breakfast included", "buffet breakfast"}},
+	{Facility{"air_conditioning", "Air conditioning"}, []string{"air conditioned", "airconditioning"}},
+	{Facility{"front_desk_24h", "24-hour front desk"}, []string{"24 hour front desk", "24h front desk", "24 hour reception", "front desk 24 hours"}},
+	{Facility{"pet_friendly", "Pet friendly"}, []string{"pets allowed", "pet friendly", "pets welcome"}},
+	{Facility{"non_smoking", "Non-smoking rooms"}, []string{"non smoking rooms", "non smoking throughout", "smoke free property"}},
+	{Facility{"laundry", "Laundry"}, []string{"laundry service", "laundry facilities", "dry cleaning"}},
+	{Facility{"business_center", "Business center"}, []string{"business centre"}},
+	{Facility{"meeting_rooms", "Meeting rooms"}, []string{"meeting banquet facilities", "conference room", "conference rooms", "meeting facilities"}},
+	{Facility{"elevator", "Elevator"}, []string{"lift"}},
+	{Facility{"accessible", "Wheelchair accessible"}, []string{"facilities for disabled guests", "wheelchair accessible", "accessible rooms"}},
+	{Facility{"family_rooms", "Family rooms"}, nil},
+	{Facility{"kitchen", "Kitchen"}, []string{"kitchenette", "shared kitchen"}},
+	{Facility{"luggage_storage", "Luggage storage"}, []string{"baggage storage"}},
+	{Facility{"ev_charging", "EV charging"}, []string{"electric vehicle charging station", "ev charging station"}},
+}
+
+// Taxonomy resolves raw facility names to canonical facilities.
+type Taxonomy struct {
+	aliases    map[string]string
+	facilities map[string]Facility
+	order      []string
+}
+
+// Default returns the built-in taxonomy.
+func Default() *Taxonomy {
+	taxonomy, _ := New(nil)
+	return taxonomy
+}
+
+// New returns the built-in taxonomy extended with aliases, which maps raw names to slugs. A slug
+// that is not built in becomes a new canonical facility named after it.
+func New(aliases map[string]string) (*Taxonomy, error) {
+	taxonomy := &Taxonomy{
+		aliases:    make(map[string]string),
+		facilities: make(map[string]Facility),
+	}
+	for _, def := range defaults {
+		taxonomy.add(def.Facility)
+		for _, alias := range def.aliases {
+			taxonomy.aliases[Key(alias)] = def.Slug
+		}
+	}
+
+	for raw, slug := range aliases {
+		if Key(raw) == "" {
+			return nil, fmt.Errorf("facility alias for %q is empty", slug)
+		}
+		if slug == "" || Slugify(slug) != slug {
+			return nil, fmt.Errorf("facility alias %q: %q is not a slug", raw, slug)
+		}
+		if _, ok := taxonomy.facilities[slug]; !ok {
+			taxonomy.add(Facility{Slug: slug, Name: nameFromSlug(slug)})
+		}
+		taxonomy.aliases[Key(raw)] = slug
+	}
+
+	return taxonomy, nil
+}
+
+func (t *Taxonomy) add(facility Facility) {
+	t.facilities[facility.Slug] = facility
+	t.order = append(t.order, facility.Slug)
+	t.aliases[Key(facility.Slug)] = facility.Slug
+	t.aliases[Key(facility.Name)] = facility.Slug
+}
+
+// Normalize returns the canonical slug of raw and true. An unknown name falls through as its
+// own slug and false, so it still filters consistently until an alias is added for it.
+func (t *Taxonomy) Normalize(raw string) (string, bool) {
+	if slug, ok := t.aliases[Key(raw)]; ok {
+		return slug, true
+	}
+	return Slugify(raw), false
+}
+
+// Lookup returns the canonical facility with the given slug.
+func (t *Taxonomy) Lookup(slug string) (Facility, bool) {
+	facility, ok := t.facilities[slug]
+	return facility, ok
+}
+
+// All returns the canonical facilities in definition order.
+func (t *Taxonomy) All() []Facility {
+	all := make([]Facility, 0, len(t.order))
+	for _, slug := range t.order {
+		all = append(all, t.facilities[slug])
+	}
+	return all
+}
+
+// Slugs returns the distinct slugs of names in first-seen order, skipping empty names.
+func (t *Taxonomy) Slugs(names []string) []string {
+	slugs := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		slug, _ := t.Normalize(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
+
+// Key folds a raw name for matching: lower case, punctuation dropped, hyphens and underscores
+// read as spaces and runs of spaces collapsed.
+func Key(raw string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(raw) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case r == '&':
+			if b.Len() > 0 {
+				b.WriteString(" and")
+			}
+			space = true
+		case unicode.IsSpace(r) || r == '-' || r == '_' || r == '/':
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// Slugify returns the slug form of raw: its Key with spaces replaced by underscores.
+func Slugify(raw string) string {
+	return strings.ReplaceAll(Key(raw), " ", "_")
+}
+
+func nameFromSlug(slug string) string {
+	name := strings.ReplaceAll(slug, "_", " ")
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,72 @@
+package facilities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	taxonomy := Default()
+	tests := []struct {
+		raw    string
+		slug   string
+		mapped bool
+	}{
+		{"Free WiFi", "wifi", true},
+		{"free wifi", "wifi", true},
+		{"WiFi", "wifi", true},
+		{"Wi-Fi", "wifi", true},
+		{"  FREE   Wi-Fi!  ", "wifi", true},
+		{"wifi", "wifi", true},
+		{"Fitness Centre", "fitness_center", true},
+		{"24-hour front desk", "front_desk_24h", true},
+		{"Spa & wellness centre", "spa", true},
+		{"Lift", "elevator", true},
+		{"Rooftop Garden", "rooftop_garden", false},
+		{"Rooftop-garden", "rooftop_garden", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			slug, mapped := taxonomy.Normalize(tt.raw)
+			assert.Equal(t, tt.slug, slug)
+			assert.Equal(t, tt.mapped, mapped)
+		})
+	}
+}
+
+func TestNewExtendsTheDefaultsWithAliases(t *testing.T) {
+	taxonomy, err := New(map[string]string{
+		"Sky Lounge":     "bar",
+		"Rooftop Garden": "garden",
+	})
+	require.NoError(t, err)
+
+	slug, mapped := taxonomy.Normalize("sky-lounge")
+	assert.Equal(t, "bar", slug)
+	assert.True(t, mapped)
+
+	garden, ok := taxonomy.Lookup("garden")
+	require.True(t, ok, "an alias to an unknown slug adds a facility")
+	assert.Equal(t, Facility{Slug: "garden", Name: "Garden"}, garden)
+	slug, mapped = taxonomy.Normalize("Garden")
+	assert.Equal(t, "garden", slug)
+	assert.True(t, mapped)
+
+	assert.Len(t, taxonomy.All(), len(Default().All())+1)
+}
+
+func TestNewRejectsInvalidAliases(t *testing.T) {
+	_, err := New(map[string]string{"  ": "wifi"})
+	assert.Error(t, err)
+
+	_, err = New(map[string]string{"Sky Lounge": "Sky Lounge"})
+	assert.Error(t, err, "aliases map to slugs")
+}
+
+func TestSlugsAreDistinctInFirstSeenOrder(t *testing.T) {
+	assert.Equal(t, []string{"pool", "wifi", "rooftop_garden"},
+		Default().Slugs([]string{"Outdoor pool", "Free WiFi", "", "wi fi", "Rooftop Garden", "Indoor Pool"}))
+}
@@ -0,0 +1,21 @@
+package geo
+
+import "math"
+
+const EarthRadiusKm = 6371.0
+
+// Haversine returns the great-circle distance in kilometers between two points given in
+// decimal degrees.
+func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return EarthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,34 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		expectedKm             float64
+		toleranceKm            float64
+	}{
+		{name: "same point", lat1: 40.6413, lon1: -73.7781, lat2: 40.6413, lon2: -73.7781, expectedKm: 0, toleranceKm: 1e-9},
+		{name: "The Plaza to JFK", lat1: 40.7646, lon1: -73.9744, lat2: 40.6413, lon2: -73.7781, expectedKm: 21.5, toleranceKm: 0.2},
+		{name: "Hotel Ritz Paris to CDG", lat1: 48.8681, lon1: 2.3292, lat2: 49.0097, lon2: 2.5479, expectedKm: 22.6, toleranceKm: 0.3},
+		{name: "Sydney to Melbourne airports", lat1: -33.9399, lon1: 151.1753, lat2: -37.6690, lon2: 144.8410, expectedKm: 705, toleranceKm: 5},
+		{name: "LAX to JFK", lat1: 33.9416, lon1: -118.4085, lat2: 40.6413, lon2: -73.7781, expectedKm: 3974, toleranceKm: 20},
+		{name: "JFK to LHR", lat1: 40.6413, lon1: -73.7781, lat2: 51.4700, lon2: -0.4543, expectedKm: 5540, toleranceKm: 25},
+		{name: "across the antimeridian", lat1: 0, lon1: 179.5, lat2: 0, lon2: -179.5, expectedKm: 111.2, toleranceKm: 0.1},
+		{name: "antipodes", lat1: 0, lon1: 0, lat2: 0, lon2: 180, expectedKm: math.Pi * EarthRadiusKm, toleranceKm: 1e-6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance := Haversine(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			assert.InDelta(t, tt.expectedKm, distance, tt.toleranceKm)
+			assert.InDelta(t, distance, Haversine(tt.lat2, tt.lon2, tt.lat1, tt.lon1), 1e-9, "distance is symmetric")
+		})
+	}
+}
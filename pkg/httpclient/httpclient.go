@@ -0,0 +1,97 @@
+// Package httpclient builds HTTP clients for upstream APIs that bound how long and how much a
+// misbehaving server can make the caller wait and read.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultMaxResponseBytes int64 = 8 << 20
+
+	// errorBodyPrefixBytes is how much of an error response is kept for logs and messages.
+	errorBodyPrefixBytes = 512
+)
+
+// ErrResponseTooLarge is returned when a response body exceeds the configured limit.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+type TransportConfig struct {
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleConnTimeout       time.Duration
+	MaxIdleConns          int
+}
+
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		DialTimeout:           5 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+	}
+}
+
+// NewClient returns a client whose transport bounds every phase of a request. timeout still
+// caps the whole exchange, including reading the body.
+func NewClient(timeout time.Duration, config TransportConfig) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewTransport(config),
+	}
+}
+
+func NewTransport(config TransportConfig) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   config.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		MaxIdleConns:          config.MaxIdleConns,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// LimitBody caps the body of resp at maxBytes. Reading past the cap fails with an error
+// matching ErrResponseTooLarge. A maxBytes of zero or less uses DefaultMaxResponseBytes.
+func LimitBody(resp *http.Response, maxBytes int64) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxResponseBytes
+	}
+	resp.Body = &limitedBody{
+		ReadCloser: http.MaxBytesReader(nil, resp.Body, maxBytes),
+		limit:      maxBytes,
+	}
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return n, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, b.limit)
+	}
+	return n, err
+}
+
+// ErrorBody reads the start of an error response for logging. The rest of the body is not
+// read, so a huge or slow error response does not hold the caller.
+func ErrorBody(r io.Reader) string {
+	prefix, _ := io.ReadAll(io.LimitReader(r, errorBodyPrefixBytes))
+	return string(prefix)
+}
@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveBody(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLimitBody(t *testing.T) {
+	server := serveBody(t, strings.Repeat("x", 1024))
+	client := NewClient(5*time.Second, DefaultTransportConfig())
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		tooLarge bool
+	}{
+		{name: "under the limit", maxBytes: 2048},
+		{name: "at the limit", maxBytes: 1024},
+		{name: "over the limit", maxBytes: 1023, tooLarge: true},
+		{name: "default limit", maxBytes: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			LimitBody(resp, tt.maxBytes)
+			body, err := io.ReadAll(resp.Body)
+			if tt.tooLarge {
+				assert.ErrorIs(t, err, ErrResponseTooLarge)
+				assert.ErrorContains(t, err, "exceeds 1023 bytes")
+				assert.LessOrEqual(t, len(body), 1023)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, body, 1024)
+		})
+	}
+}
+
+func TestErrorBodyKeepsAPrefix(t *testing.T) {
+	assert.Equal(t, "not found", ErrorBody(strings.NewReader("not found")))
+	assert.Len(t, ErrorBody(strings.NewReader(strings.Repeat("x", 10_000))), errorBodyPrefixBytes)
+}
+
+func TestNewClientAbortsSlowHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := DefaultTransportConfig()
+	config.ResponseHeaderTimeout = 100 * time.Millisecond
+	client := NewClient(5*time.Second, config)
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestNewClientAbortsSlowBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow-loris upstream: headers arrive at once, the body a byte at a time.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		for {
+			if _, err := io.WriteString(w, " "); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(200*time.Millisecond, DefaultTransportConfig())
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	_, err = io.ReadAll(resp.Body)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
@@ -0,0 +1,68 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrMissingField is returned by the MessageData getters for keys that are absent, null or
+// empty.
+var ErrMissingField = errors.New("missing field")
+
+// MessageData is the untyped data of a version 0 message. Its getters coerce the JSON numbers
+// and strings producers used interchangeably, and fail with a descriptive error rather than
+// panicking on an unexpected type.
+type MessageData map[string]any
+
+// GetString returns the value of key as a string. Numbers are formatted in decimal.
+func (d MessageData) GetString(key string) (string, error) {
+	switch value := d[key].(type) {
+	case nil:
+		return "", fmt.Errorf("%w: %s", ErrMissingField, key)
+	case string:
+		if value == "" {
+			return "", fmt.Errorf("%w: %s", ErrMissingField, key)
+		}
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		return "", fmt.Errorf("%s must be a string, got %T", key, value)
+	}
+}
+
+// GetInt64 returns the value of key as an integer. Decimal strings are parsed, and numbers
+// must be whole.
+func (d MessageData) GetInt64(key string) (int64, error) {
+	switch value := d[key].(type) {
+	case nil:
+		return 0, fmt.Errorf("%w: %s", ErrMissingField, key)
+	case string:
+		if value == "" {
+			return 0, fmt.Errorf("%w: %s", ErrMissingField, key)
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer, got %q", key, value)
+		}
+		return parsed, nil
+	case float64:
+		if value != math.Trunc(value) || math.Abs(value) > math.MaxInt64 {
+			return 0, fmt.Errorf("%s must be an integer, got %v", key, value)
+		}
+		return int64(value), nil
+	case json.Number:
+		parsed, err := value.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer, got %s", key, value)
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("%s must be an integer, got %T", key, value)
+	}
+}
@@ -0,0 +1,61 @@
+package messages
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageDataGetInt64(t *testing.T) {
+	data := MessageData{
+		"string":     "1641879",
+		"number":     float64(1641879),
+		"json":       json.Number("1641879"),
+		"empty":      "",
+		"fraction":   1.5,
+		"word":       "abc",
+		"list":       []any{1},
+		"overflow":   1e20,
+		"bad_number": json.Number("1.5"),
+	}
+
+	for _, key := range []string{"string", "number", "json"} {
+		value, err := data.GetInt64(key)
+		assert.NoError(t, err, key)
+		assert.Equal(t, int64(1641879), value, key)
+	}
+	for _, key := range []string{"missing", "empty"} {
+		_, err := data.GetInt64(key)
+		assert.ErrorIs(t, err, ErrMissingField, key)
+	}
+	for _, key := range []string{"fraction", "word", "list", "overflow", "bad_number"} {
+		_, err := data.GetInt64(key)
+		assert.Error(t, err, key)
+		assert.NotErrorIs(t, err, ErrMissingField, key)
+	}
+}
+
+func TestMessageDataGetString(t *testing.T) {
+	data := MessageData{"lang": "fr", "number": float64(42), "json": json.Number("7"), "empty": "", "list": []any{"fr"}}
+
+	value, err := data.GetString("lang")
+	assert.NoError(t, err)
+	assert.Equal(t, "fr", value)
+
+	value, err = data.GetString("number")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	value, err = data.GetString("json")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", value)
+
+	_, err = data.GetString("empty")
+	assert.ErrorIs(t, err, ErrMissingField)
+	_, err = data.GetString("missing")
+	assert.ErrorIs(t, err, ErrMissingField)
+	_, err = data.GetString("list")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrMissingField)
+}
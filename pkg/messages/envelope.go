@@ -0,0 +1,129 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+)
+
+// CurrentVersion is the envelope version published by the orchestrator. Version 0 is the
+// unversioned {id, type, data} shape published before envelopes existed; Decode still accepts
+// it so messages already queued during a rollout are not lost.
+const CurrentVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported message version")
+	ErrInvalidMessage     = errors.New("invalid message")
+)
+
+// Envelope is the message exchanged between the orchestrator and the workers.
+type Envelope struct {
+	Version int             `json:"version"`
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Payload is implemented by the typed body of every message type.
+type Payload interface {
+	Validate() error
+}
+
+type wireMessage struct {
+	Envelope
+	Data MessageData `json:"data"`
+}
+
+func newEnvelope(id, messageType string, payload Payload) Envelope {
+	body, _ := json.Marshal(payload)
+	return Envelope{
+		Version: CurrentVersion,
+		ID:      id,
+		Type:    messageType,
+		Payload: body,
+	}
+}
+
+// Decode parses a message body into an envelope, upgrading version 0 messages to the current
+// version. It fails with ErrUnsupportedVersion for versions this build does not know.
+func Decode(body []byte) (Envelope, error) {
+	var message wireMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+
+	envelope := message.Envelope
+	switch envelope.Version {
+	case 0:
+		payload, err := upgradeV0(envelope.ID, envelope.Type, message.Data)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		envelope.Version = CurrentVersion
+		envelope.Payload = payload
+	case CurrentVersion:
+	default:
+		return Envelope{}, fmt.Errorf("%w %d (supported: 0, %d)", ErrUnsupportedVersion, envelope.Version, CurrentVersion)
+	}
+
+	if envelope.ID == "" {
+		return Envelope{}, fmt.Errorf("%w: id is empty", ErrInvalidMessage)
+	}
+	if envelope.Type == "" {
+		return Envelope{}, fmt.Errorf("%w: type is empty", ErrInvalidMessage)
+	}
+
+	return envelope, nil
+}
+
+// DecodePayload unmarshals and validates the payload of envelope.
+func DecodePayload[T Payload](envelope Envelope) (T, error) {
+	var payload T
+	if len(envelope.Payload) == 0 {
+		return payload, fmt.Errorf("%w: %s payload is empty", ErrInvalidMessage, envelope.Type)
+	}
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return payload, fmt.Errorf("%w: %s payload: %v", ErrInvalidMessage, envelope.Type, err)
+	}
+	if err := payload.Validate(); err != nil {
+		return payload, fmt.Errorf("%w: %s payload: %v", ErrInvalidMessage, envelope.Type, err)
+	}
+	return payload, nil
+}
+
+// upgradeV0 maps the untyped data of a version 0 message to the payload of its type. Row ids
+// travelled as the message id and hotel ids as decimal strings, or numbers from some producers.
+func upgradeV0(id, messageType string, data MessageData) (json.RawMessage, error) {
+	if data == nil {
+		return nil, fmt.Errorf("data is empty")
+	}
+
+	hotelID, err := data.GetInt64(constants.HotelId)
+	if err != nil && !errors.Is(err, ErrMissingField) {
+		return nil, err
+	}
+	lang, err := data.GetString(constants.Lang)
+	if err != nil && !errors.Is(err, ErrMissingField) {
+		return nil, err
+	}
+
+	var payload any
+	switch messageType {
+	case constants.MessageTypeUpdateHotel:
+		payload = HotelUpdatePayload{HotelRowID: id, HotelID: hotelID}
+	case constants.MessageTypeUpdateReview:
+		payload = ReviewUpdatePayload{ReviewRowID: id, HotelID: hotelID}
+	case constants.MessageTypeFetchReview:
+		payload = ReviewFetchPayload{HotelID: hotelID}
+	case constants.MessageTypeUpdateTranslation:
+		payload = TranslationUpdatePayload{TranslationRowID: id, HotelID: hotelID}
+	case constants.MessageTypeFetchTranslation:
+		payload = TranslationFetchPayload{HotelID: hotelID, Lang: lang}
+	default:
+		payload = data
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,237 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+)
+
+// roundTrip encodes envelope the way the orchestrator publishes it and decodes it the way the
+// worker does.
+func roundTrip[T Payload](t *testing.T, envelope Envelope) (Envelope, T) {
+	t.Helper()
+	body, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	decoded, err := Decode(body)
+	require.NoError(t, err)
+	payload, err := DecodePayload[T](decoded)
+	require.NoError(t, err)
+	return decoded, payload
+}
+
+func TestEncodeDecodeEveryType(t *testing.T) {
+	t.Run("update_hotel", func(t *testing.T) {
+		envelope, payload := roundTrip[HotelUpdatePayload](t, NewHotelUpdate("row-1", 1641879))
+		assert.Equal(t, Envelope{Version: CurrentVersion, ID: "row-1", Type: constants.MessageTypeUpdateHotel, Payload: envelope.Payload}, envelope)
+		assert.Equal(t, HotelUpdatePayload{HotelRowID: "row-1", HotelID: 1641879}, payload)
+	})
+
+	t.Run("update_review", func(t *testing.T) {
+		envelope, payload := roundTrip[ReviewUpdatePayload](t, NewReviewUpdate("review-row-1", 1641879))
+		assert.Equal(t, constants.MessageTypeUpdateReview, envelope.Type)
+		assert.Equal(t, "review-row-1", envelope.ID)
+		assert.Equal(t, ReviewUpdatePayload{ReviewRowID: "review-row-1", HotelID: 1641879}, payload)
+	})
+
+	t.Run("fetch_review", func(t *testing.T) {
+		envelope, payload := roundTrip[ReviewFetchPayload](t, NewReviewFetch("row-1", 1641879))
+		assert.Equal(t, constants.MessageTypeFetchReview, envelope.Type)
+		assert.Equal(t, "row-1", envelope.ID)
+		assert.Equal(t, ReviewFetchPayload{HotelID: 1641879}, payload)
+	})
+
+	t.Run("update_translation", func(t *testing.T) {
+		envelope, payload := roundTrip[TranslationUpdatePayload](t, NewTranslationUpdate("translation-row-1", 1641879))
+		assert.Equal(t, constants.MessageTypeUpdateTranslation, envelope.Type)
+		assert.Equal(t, TranslationUpdatePayload{TranslationRowID: "translation-row-1", HotelID: 1641879}, payload)
+	})
+
+	t.Run("fetch_translation", func(t *testing.T) {
+		envelope, payload := roundTrip[TranslationFetchPayload](t, NewTranslationFetch(1641879, "fr"))
+		assert.Equal(t, constants.MessageTypeFetchTranslation, envelope.Type)
+		assert.Equal(t, "1641879_fr", envelope.ID)
+		assert.Equal(t, TranslationFetchPayload{HotelID: 1641879, Lang: "fr"}, payload)
+	})
+
+	t.Run("dead_letter", func(t *testing.T) {
+		failedAt := time.Date(2026, 3, 14, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+		original, err := json.Marshal(NewHotelUpdate("row-1", 1641879))
+		require.NoError(t, err)
+
+		envelope, payload := roundTrip[DeadLetterPayload](t, NewDeadLetter("row-1", original, errors.New("boom"), "processing_failed", 3, failedAt))
+		assert.Equal(t, constants.MessageTypeDeadLetter, envelope.Type)
+		assert.JSONEq(t, string(original), string(payload.Body))
+		assert.Empty(t, payload.RawBody)
+		assert.Equal(t, "boom", payload.Error)
+		assert.Equal(t, "processing_failed", payload.Reason)
+		assert.Equal(t, 3, payload.Attempts)
+		assert.Equal(t, failedAt.UTC(), payload.FailedAt)
+
+		_, payload = roundTrip[DeadLetterPayload](t, NewDeadLetter("amqp-42", []byte("not json"), errors.New("boom"), "invalid_message", 1, failedAt))
+		assert.Nil(t, payload.Body)
+		assert.Equal(t, "not json", payload.RawBody)
+	})
+}
+
+func TestDecodeVersion0(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		decode   func(Envelope) (Payload, error)
+		expected Payload
+	}{
+		{
+			name:     "update_hotel with a string hotel id",
+			body:     `{"id":"row-1","type":"update_hotel","data":{"hotel_id":"1641879"}}`,
+			decode:   decodeAs[HotelUpdatePayload],
+			expected: HotelUpdatePayload{HotelRowID: "row-1", HotelID: 1641879},
+		},
+		{
+			name:     "update_hotel with a numeric hotel id",
+			body:     `{"id":"row-1","type":"update_hotel","data":{"hotel_id":1641879}}`,
+			decode:   decodeAs[HotelUpdatePayload],
+			expected: HotelUpdatePayload{HotelRowID: "row-1", HotelID: 1641879},
+		},
+		{
+			name:     "update_review",
+			body:     `{"id":"review-row-1","type":"update_review","data":{"hotel_id":"1641879"}}`,
+			decode:   decodeAs[ReviewUpdatePayload],
+			expected: ReviewUpdatePayload{ReviewRowID: "review-row-1", HotelID: 1641879},
+		},
+		{
+			name:     "fetch_review",
+			body:     `{"id":"row-1","type":"fetch_review","data":{"hotel_id":"1641879"}}`,
+			decode:   decodeAs[ReviewFetchPayload],
+			expected: ReviewFetchPayload{HotelID: 1641879},
+		},
+		{
+			name:     "update_translation",
+			body:     `{"id":"translation-row-1","type":"update_translation","data":{"hotel_id":"1641879"}}`,
+			decode:   decodeAs[TranslationUpdatePayload],
+			expected: TranslationUpdatePayload{TranslationRowID: "translation-row-1", HotelID: 1641879},
+		},
+		{
+			name:     "fetch_translation",
+			body:     `{"id":"1641879_fr","type":"fetch_translation","data":{"hotel_id":"1641879","lang":"fr"}}`,
+			decode:   decodeAs[TranslationFetchPayload],
+			expected: TranslationFetchPayload{HotelID: 1641879, Lang: "fr"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := Decode([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, CurrentVersion, envelope.Version, "version 0 messages are upgraded")
+
+			payload, err := tt.decode(envelope)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, payload)
+		})
+	}
+}
+
+func decodeAs[T Payload](envelope Envelope) (Payload, error) {
+	return DecodePayload[T](envelope)
+}
+
+func TestDecodeRejectsUnsupportedVersions(t *testing.T) {
+	for _, body := range []string{
+		`{"version":2,"id":"row-1","type":"update_hotel","payload":{"hotel_row_id":"row-1","hotel_id":1}}`,
+		`{"version":-1,"id":"row-1","type":"update_hotel","payload":{}}`,
+	} {
+		_, err := Decode([]byte(body))
+		assert.ErrorIs(t, err, ErrUnsupportedVersion, body)
+		assert.NotErrorIs(t, err, ErrInvalidMessage)
+	}
+}
+
+func TestDecodeRejectsInvalidMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "not JSON", body: `update_hotel 1641879`},
+		{name: "missing id", body: `{"version":1,"type":"update_hotel","payload":{"hotel_row_id":"row-1","hotel_id":1}}`},
+		{name: "missing type", body: `{"version":1,"id":"row-1","payload":{"hotel_row_id":"row-1","hotel_id":1}}`},
+		{name: "version 0 without data", body: `{"id":"row-1","type":"update_hotel"}`},
+		{name: "version 0 with a malformed hotel id", body: `{"id":"row-1","type":"update_hotel","data":{"hotel_id":"16418x79"}}`},
+		{name: "version 0 with a fractional hotel id", body: `{"id":"row-1","type":"update_hotel","data":{"hotel_id":1.5}}`},
+		{name: "version 0 with a non-string lang", body: `{"id":"1_fr","type":"fetch_translation","data":{"hotel_id":1,"lang":["fr"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.body))
+			assert.ErrorIs(t, err, ErrInvalidMessage)
+		})
+	}
+}
+
+func TestDecodePayloadValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		decode  func(Envelope) (Payload, error)
+		errText string
+	}{
+		{
+			name:    "empty payload",
+			message: `{"version":1,"id":"row-1","type":"update_hotel"}`,
+			decode:  decodeAs[HotelUpdatePayload],
+			errText: "update_hotel payload is empty",
+		},
+		{
+			name:    "hotel id of the wrong type",
+			message: `{"version":1,"id":"row-1","type":"update_hotel","payload":{"hotel_row_id":"row-1","hotel_id":"1641879"}}`,
+			decode:  decodeAs[HotelUpdatePayload],
+			errText: "cannot unmarshal string",
+		},
+		{
+			name:    "missing row id",
+			message: `{"version":1,"id":"row-1","type":"update_hotel","payload":{"hotel_id":1641879}}`,
+			decode:  decodeAs[HotelUpdatePayload],
+			errText: "hotel_row_id is empty",
+		},
+		{
+			name:    "non-positive hotel id",
+			message: `{"version":1,"id":"row-1","type":"fetch_review","payload":{"hotel_id":0}}`,
+			decode:  decodeAs[ReviewFetchPayload],
+			errText: "hotel_id must be positive, got 0",
+		},
+		{
+			name:    "missing lang",
+			message: `{"version":1,"id":"1_fr","type":"fetch_translation","payload":{"hotel_id":1}}`,
+			decode:  decodeAs[TranslationFetchPayload],
+			errText: "lang is empty",
+		},
+		{
+			name:    "version 0 without a hotel id",
+			message: `{"id":"row-1","type":"update_review","data":{"other":"value"}}`,
+			decode:  decodeAs[ReviewUpdatePayload],
+			errText: "hotel_id must be positive",
+		},
+		{
+			name:    "dead letter without an error",
+			message: `{"version":1,"id":"row-1","type":"dead_letter","payload":{"reason":"panic"}}`,
+			decode:  decodeAs[DeadLetterPayload],
+			errText: "error is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := Decode([]byte(tt.message))
+			require.NoError(t, err)
+
+			_, err = tt.decode(envelope)
+			assert.ErrorIs(t, err, ErrInvalidMessage)
+			assert.ErrorContains(t, err, tt.errText)
+		})
+	}
+}
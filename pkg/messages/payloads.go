@@ -0,0 +1,133 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/constants"
+)
+
+// HotelUpdatePayload refreshes an existing hotel row.
+type HotelUpdatePayload struct {
+	HotelRowID string `json:"hotel_row_id"`
+	HotelID    int64  `json:"hotel_id"`
+}
+
+func NewHotelUpdate(hotelRowID string, hotelID int64) Envelope {
+	return newEnvelope(hotelRowID, constants.MessageTypeUpdateHotel, HotelUpdatePayload{HotelRowID: hotelRowID, HotelID: hotelID})
+}
+
+func (p HotelUpdatePayload) Validate() error {
+	if p.HotelRowID == "" {
+		return fmt.Errorf("hotel_row_id is empty")
+	}
+	return validateHotelID(p.HotelID)
+}
+
+// ReviewUpdatePayload refreshes the reviews of a hotel that already has some.
+type ReviewUpdatePayload struct {
+	ReviewRowID string `json:"review_row_id"`
+	HotelID     int64  `json:"hotel_id"`
+}
+
+func NewReviewUpdate(reviewRowID string, hotelID int64) Envelope {
+	return newEnvelope(reviewRowID, constants.MessageTypeUpdateReview, ReviewUpdatePayload{ReviewRowID: reviewRowID, HotelID: hotelID})
+}
+
+func (p ReviewUpdatePayload) Validate() error {
+	if p.ReviewRowID == "" {
+		return fmt.Errorf("review_row_id is empty")
+	}
+	return validateHotelID(p.HotelID)
+}
+
+// ReviewFetchPayload fetches the reviews of a hotel that has none yet.
+type ReviewFetchPayload struct {
+	HotelID int64 `json:"hotel_id"`
+}
+
+func NewReviewFetch(hotelRowID string, hotelID int64) Envelope {
+	return newEnvelope(hotelRowID, constants.MessageTypeFetchReview, ReviewFetchPayload{HotelID: hotelID})
+}
+
+func (p ReviewFetchPayload) Validate() error {
+	return validateHotelID(p.HotelID)
+}
+
+// TranslationUpdatePayload refreshes an existing translation row.
+type TranslationUpdatePayload struct {
+	TranslationRowID string `json:"translation_row_id"`
+	HotelID          int64  `json:"hotel_id"`
+}
+
+func NewTranslationUpdate(translationRowID string, hotelID int64) Envelope {
+	return newEnvelope(translationRowID, constants.MessageTypeUpdateTranslation, TranslationUpdatePayload{TranslationRowID: translationRowID, HotelID: hotelID})
+}
+
+func (p TranslationUpdatePayload) Validate() error {
+	if p.TranslationRowID == "" {
+		return fmt.Errorf("translation_row_id is empty")
+	}
+	return validateHotelID(p.HotelID)
+}
+
+// TranslationFetchPayload fetches a translation a hotel is missing.
+type TranslationFetchPayload struct {
+	HotelID int64  `json:"hotel_id"`
+	Lang    string `json:"lang"`
+}
+
+func NewTranslationFetch(hotelID int64, lang string) Envelope {
+	id := fmt.Sprintf("%d_%s", hotelID, lang)
+	return newEnvelope(id, constants.MessageTypeFetchTranslation, TranslationFetchPayload{HotelID: hotelID, Lang: lang})
+}
+
+func (p TranslationFetchPayload) Validate() error {
+	if p.Lang == "" {
+		return fmt.Errorf("lang is empty")
+	}
+	return validateHotelID(p.HotelID)
+}
+
+// DeadLetterPayload is a message a worker failed to process, published to the dead letter
+// exchange. Body holds the original message when it is JSON and RawBody otherwise.
+type DeadLetterPayload struct {
+	Body     json.RawMessage `json:"body,omitempty"`
+	RawBody  string          `json:"raw_body,omitempty"`
+	Error    string          `json:"error"`
+	Reason   string          `json:"reason"`
+	Attempts int             `json:"attempts"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+// NewDeadLetter wraps the failed message body with the failure that rejected it. id is the id
+// of the failed message, when it could be decoded.
+func NewDeadLetter(id string, body []byte, err error, reason string, attempts int, failedAt time.Time) Envelope {
+	payload := DeadLetterPayload{
+		Error:    err.Error(),
+		Reason:   reason,
+		Attempts: attempts,
+		FailedAt: failedAt.UTC(),
+	}
+	if json.Valid(body) {
+		payload.Body = body
+	} else {
+		payload.RawBody = string(body)
+	}
+	return newEnvelope(id, constants.MessageTypeDeadLetter, payload)
+}
+
+func (p DeadLetterPayload) Validate() error {
+	if p.Error == "" {
+		return fmt.Errorf("error is empty")
+	}
+	return nil
+}
+
+func validateHotelID(hotelID int64) error {
+	if hotelID <= 0 {
+		return fmt.Errorf("hotel_id must be positive, got %d", hotelID)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+const (
+	minNationalDigits = 4
+	maxE164Digits     = 15
+	nanpNationalLen   = 10
+)
+
+type region struct {
+	callingCode string
+	// trunkPrefix is dialled before national numbers inside the country and dropped in E.164.
+	trunkPrefix string
+}
+
+// regions maps ISO 3166-1 alpha-2 country codes to their dialling rules.
+var regions = map[string]region{
+	"us": {"1", ""}, "ca": {"1", ""}, "pr": {"1", ""}, "do": {"1", ""}, "jm": {"1", ""}, "bs": {"1", ""},
+	"mx": {"52", ""}, "br": {"55", "0"}, "ar": {"54", "0"}, "cl": {"56", ""}, "co": {"57", "0"},
+	"pe": {"51", "0"}, "ve": {"58", "0"}, "ec": {"593", "0"}, "uy": {"598", "0"}, "cr": {"506", ""},
+	"pa": {"507", ""}, "cu": {"53", "0"},
+	"gb": {"44", "0"}, "ie": {"353", "0"}, "fr": {"33", "0"}, "de": {"49", "0"}, "es": {"34", ""},
+	"pt": {"351", ""}, "it": {"39", ""}, "nl": {"31", "0"}, "be": {"32", "0"}, "lu": {"352", ""},
+	"ch": {"41", "0"}, "at": {"43", "0"}, "dk": {"45", ""}, "se": {"46", "0"}, "no": {"47", ""},
+	"fi": {"358", "0"}, "is": {"354", ""}, "pl": {"48", ""}, "cz": {"420", ""}, "sk": {"421", "0"},
+	"hu": {"36", "06"}, "ro": {"40", "0"}, "bg": {"359", "0"}, "gr": {"30", ""}, "hr": {"385", "0"},
+	"si": {"386", "0"}, "rs": {"381", "0"}, "ee": {"372", ""}, "lv": {"371", ""}, "lt": {"370", "8"},
+	"ua": {"380", "0"}, "ru": {"7", "8"}, "tr": {"90", "0"}, "cy": {"357", ""}, "mt": {"356", ""},
+	"mc": {"377", ""}, "ad": {"376", ""},
+	"ma": {"212", "0"}, "eg": {"20", "0"}, "za": {"27", "0"}, "ke": {"254", "0"}, "ng": {"234", "0"},
+	"tn": {"216", ""}, "ae": {"971", "0"}, "sa": {"966", "0"}, "qa": {"974", ""}, "il": {"972", "0"},
+	"jo": {"962", "0"},
+	"jp": {"81", "0"}, "cn": {"86", "0"}, "hk": {"852", ""}, "tw": {"886", "0"}, "kr": {"82", "0"},
+	"in": {"91", "0"}, "th": {"66", "0"}, "vn": {"84", "0"}, "sg": {"65", ""}, "my": {"60", "0"},
+	"id": {"62", "0"}, "ph": {"63", "0"}, "au": {"61", "0"}, "nz": {"64", "0"}, "mv": {"960", ""},
+	"lk": {"94", "0"},
+}
+
+// callingCodes holds every known country calling code, to split international numbers.
+var callingCodes = func() map[string]bool {
+	codes := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		codes[r.callingCode] = true
+	}
+	return codes
+}()
+
+// extensionMarkers end the dialable part of a number; anything after one is an extension.
+var extensionMarkers = []string{";ext=", "extension", "ext.", "ext", "#", "x"}
+
+// Normalize returns number in E.164 form, e.g. +12125550100. Numbers written without an
+// international prefix are read as national numbers of country, an ISO 3166-1 alpha-2 code.
+// Extensions are dropped. Numbers that cannot be read return ErrInvalidNumber.
+func Normalize(number, country string) (string, error) {
+	number = stripExtension(strings.ToLower(strings.TrimSpace(number)))
+	// "+44 (0)20 ..." repeats the trunk prefix after the country code.
+	number = strings.ReplaceAll(number, "(0)", "")
+
+	international := strings.HasPrefix(number, "+")
+	var digits strings.Builder
+	for _, r := range strings.TrimPrefix(number, "+") {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -./() ", r):
+		default:
+			return "", ErrInvalidNumber
+		}
+	}
+
+	national := digits.String()
+	r, hasRegion := regions[strings.ToLower(strings.TrimSpace(country))]
+	switch {
+	case international:
+	case strings.HasPrefix(national, "00"):
+		national, international = national[2:], true
+	case strings.HasPrefix(national, "011") && hasRegion && r.callingCode == "1":
+		national, international = national[3:], true
+	}
+
+	if international {
+		return fromInternational(national)
+	}
+	if !hasRegion {
+		return "", ErrInvalidNumber
+	}
+	if r.callingCode == "1" && len(national) == nanpNationalLen+1 && national[0] == '1' {
+		national = national[1:]
+	} else if r.trunkPrefix != "" {
+		national = strings.TrimPrefix(national, r.trunkPrefix)
+	}
+	return format(r.callingCode, national)
+}
+
+// Valid reports whether Normalize accepts number.
+func Valid(number, country string) bool {
+	_, err := Normalize(number, country)
+	return err == nil
+}
+
+func fromInternational(digits string) (string, error) {
+	for length := 1; length <= 3 && length < len(digits); length++ {
+		if code := digits[:length]; callingCodes[code] {
+			return format(code, digits[length:])
+		}
+	}
+	return "", ErrInvalidNumber
+}
+
+func format(callingCode, national string) (string, error) {
+	if callingCode == "1" && len(national) != nanpNationalLen {
+		return "", ErrInvalidNumber
+	}
+	if len(national) < minNationalDigits || len(callingCode)+len(national) > maxE164Digits || national[0] == '0' && callingCode != "39" {
+		return "", ErrInvalidNumber
+	}
+	return "+" + callingCode + national, nil
+}
+
+func stripExtension(number string) string {
+	for _, marker := range extensionMarkers {
+		if i := strings.Index(number, marker); i > 0 {
+			number = number[:i]
+		}
+	}
+	return strings.TrimRight(number, " ,;-")
+}
@@ -0,0 +1,67 @@
+package phone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		country  string
+		expected string
+	}{
+		{"international with separators", "+1 212-555-0100", "", "+12125550100"},
+		{"national with parentheses", "(212) 555-0100", "us", "+12125550100"},
+		{"national with the NANP country code", "12125550100", "US", "+12125550100"},
+		{"US international access code", "011 44 20 7946 0958", "us", "+442079460958"},
+		{"international access code", "0044 20 7946 0958", "fr", "+442079460958"},
+		{"trunk prefix in parentheses", "+44 (0)20 7946 0958", "gb", "+442079460958"},
+		{"national with trunk prefix", "020 7946 0958", "gb", "+442079460958"},
+		{"dotted national", "01.42.68.53.00", "fr", "+33142685300"},
+		{"country hint ignored for international numbers", "+33 1 42 68 53 00", "us", "+33142685300"},
+		{"Italian numbers keep their leading zero", "+39 06 6982 0000", "", "+390669820000"},
+		{"no trunk prefix", "91 123 45 67", "es", "+34911234567"},
+		{"extension with ext.", "+1 212-555-0100 ext. 25", "", "+12125550100"},
+		{"extension with x", "+1 212 555 0100 x123", "", "+12125550100"},
+		{"extension with ;ext=", "+44 20 7946 0958;ext=12", "", "+442079460958"},
+		{"extension with #", "212-555-0100#9", "us", "+12125550100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := Normalize(tt.number, tt.country)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+			assert.True(t, Valid(tt.number, tt.country))
+		})
+	}
+}
+
+func TestNormalizeRejectsGarbage(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		country string
+	}{
+		{"empty", "", "us"},
+		{"letters", "call the front desk", "us"},
+		{"national without a country", "020 7946 0958", ""},
+		{"unknown country", "020 7946 0958", "zz"},
+		{"unknown calling code", "+999 1234567", ""},
+		{"too short NANP number", "555-0100", "us"},
+		{"too long NANP number", "+1 212 555 01000", ""},
+		{"longer than E.164 allows", "+44 1234 5678 9012 3456", ""},
+		{"too few national digits", "+44 123", ""},
+		{"leading zero after the calling code", "+33 0142685300 0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Normalize(tt.number, tt.country)
+			assert.ErrorIs(t, err, ErrInvalidNumber)
+			assert.False(t, Valid(tt.number, tt.country))
+		})
+	}
+}
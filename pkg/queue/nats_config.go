@@ -0,0 +1,54 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	BrokerRabbitMQ = "rabbitmq"
+	BrokerNATS     = "nats"
+)
+
+type NATSConfig struct {
+	URL          string `mapstructure:"url"`
+	StreamName   string `mapstructure:"stream_name"`
+	ConsumerName string `mapstructure:"consumer_name"`
+}
+
+func connectJetStream(config *NATSConfig, subject string) (*nats.Conn, jetstream.JetStream, error) {
+	conn, err := nats.Connect(config.URL,
+		nats.Name(config.ConsumerName),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(2*time.Second),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      config.StreamName,
+		Subjects:  []string{subject},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to create stream %s: %w", config.StreamName, err)
+	}
+
+	return conn, js, nil
+}
@@ -0,0 +1,193 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type NATSConsumer struct {
+	config        *NATSConfig
+	subject       string
+	prefetchCount int
+	logger        *slog.Logger
+	conn          *nats.Conn
+	consumer      jetstream.Consumer
+	messages      jetstream.MessagesContext
+	mu            sync.Mutex
+	closed        int64
+	ctx           context.Context
+	cancel        context.CancelFunc
+	deliveryTag   uint64
+}
+
+func NewNATSConsumer(config *NATSConfig, subject string, prefetchCount, maxDeliver int, logger *slog.Logger) (*NATSConsumer, error) {
+	conn, js, err := connectJetStream(config, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	createCtx, createCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer createCancel()
+
+	consumer, err := js.CreateOrUpdateConsumer(createCtx, config.StreamName, jetstream.ConsumerConfig{
+		Durable:       config.ConsumerName,
+		FilterSubject: subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		MaxDeliver:    maxDeliver,
+	})
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, fmt.Errorf("failed to create consumer %s: %w", config.ConsumerName, err)
+	}
+
+	if prefetchCount <= 0 {
+		prefetchCount = 1
+	}
+
+	return &NATSConsumer{
+		config:        config,
+		subject:       subject,
+		prefetchCount: prefetchCount,
+		logger:        logger,
+		conn:          conn,
+		consumer:      consumer,
+		ctx:           ctx,
+		cancel:        cancel,
+	}, nil
+}
+
+// Consume adapts JetStream messages to amqp.Delivery so the worker can ack and nack
+// them exactly as it does with RabbitMQ.
+func (c *NATSConsumer) Consume() (<-chan amqp.Delivery, error) {
+	if atomic.LoadInt64(&c.closed) == 1 {
+		return nil, fmt.Errorf("consumer is closed")
+	}
+
+	messages, err := c.consumer.Messages(jetstream.PullMaxMessages(c.prefetchCount))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.mu.Lock()
+	c.messages = messages
+	c.mu.Unlock()
+
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+		for {
+			msg, err := messages.Next()
+			if err != nil {
+				if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+					return
+				}
+				c.logger.Warn("Failed to receive NATS message", "error", err)
+				continue
+			}
+
+			select {
+			case deliveries <- c.toDelivery(msg):
+			case <-c.ctx.Done():
+				_ = msg.Nak()
+				return
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+func (c *NATSConsumer) toDelivery(msg jetstream.Msg) amqp.Delivery {
+	delivery := amqp.Delivery{
+		Acknowledger: &natsAcknowledger{msg: msg},
+		ContentType:  msg.Headers().Get("Content-Type"),
+		DeliveryTag:  atomic.AddUint64(&c.deliveryTag, 1),
+		RoutingKey:   msg.Subject(),
+		Body:         msg.Data(),
+	}
+
+	if metadata, err := msg.Metadata(); err == nil {
+		delivery.Timestamp = metadata.Timestamp
+		delivery.Redelivered = metadata.NumDelivered > 1
+	}
+
+	return delivery
+}
+
+func (c *NATSConsumer) StopConsuming() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages == nil {
+		return fmt.Errorf("consumer is not consuming")
+	}
+
+	c.messages.Stop()
+	c.logger.Info("Stopped consuming new deliveries", "consumer_name", c.config.ConsumerName)
+	return nil
+}
+
+func (c *NATSConsumer) Close() error {
+	if !atomic.CompareAndSwapInt64(&c.closed, 0, 1) {
+		return nil
+	}
+
+	c.cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages != nil {
+		c.messages.Stop()
+	}
+
+	c.conn.Close()
+
+	c.logger.Info("NATS consumer closed successfully")
+	return nil
+}
+
+func (c *NATSConsumer) HealthCheck() error {
+	if atomic.LoadInt64(&c.closed) == 1 {
+		return fmt.Errorf("consumer is closed")
+	}
+
+	if !c.conn.IsConnected() {
+		return fmt.Errorf("connection is not available")
+	}
+
+	return nil
+}
+
+// natsAcknowledger maps AMQP acknowledgements onto JetStream: a nack without requeue
+// terminates the message so it is not redelivered, mirroring the RabbitMQ DLQ path.
+type natsAcknowledger struct {
+	msg jetstream.Msg
+}
+
+func (a *natsAcknowledger) Ack(_ uint64, _ bool) error {
+	return a.msg.Ack()
+}
+
+func (a *natsAcknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	if requeue {
+		return a.msg.Nak()
+	}
+	return a.msg.Term()
+}
+
+func (a *natsAcknowledger) Reject(_ uint64, requeue bool) error {
+	return a.Nack(0, false, requeue)
+}
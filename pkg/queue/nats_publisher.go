@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type NATSPublisher struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	stream  string
+	subject string
+}
+
+func NewNATSPublisher(config *NATSConfig, subject string) (*NATSPublisher, error) {
+	conn, js, err := connectJetStream(config, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NATSPublisher{
+		conn:    conn,
+		js:      js,
+		stream:  config.StreamName,
+		subject: subject,
+	}, nil
+}
+
+func (p *NATSPublisher) PublishBatch(ctx context.Context, messages []Message) error {
+	for _, message := range messages {
+		b, _ := json.Marshal(message)
+		msg := nats.NewMsg(p.subject)
+		msg.Header.Set("Content-Type", "application/json")
+		msg.Data = b
+		if _, err := p.js.PublishMsg(ctx, msg); err != nil {
+			return fmt.Errorf("failed to publish message %s: %w", message.ID, err)
+		}
+	}
+	return nil
+}
+
+func (p *NATSPublisher) PublishWithRetry(ctx context.Context, jobs []Message, maxAttempts int) error {
+	return publishWithRetry(ctx, p, jobs, maxAttempts)
+}
+
+// QueueDepth returns the messages pending in the stream. Only the publisher's own subject is a
+// queue under JetStream; rejected messages are terminated rather than moved elsewhere.
+func (p *NATSPublisher) QueueDepth(ctx context.Context, queueName string) (int64, error) {
+	if queueName != p.subject {
+		return 0, fmt.Errorf("queue %s is not served by stream %s", queueName, p.stream)
+	}
+
+	stream, err := p.js.Stream(ctx, p.stream)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stream %s: %w", p.stream, err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stream %s info: %w", p.stream, err)
+	}
+	return int64(info.State.Msgs), nil
+}
+
+func (p *NATSPublisher) Close() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+}
@@ -0,0 +1,28 @@
+package queue
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/victoragudo/hotel-management-system/pkg/messages"
+)
+
+// Message is the versioned envelope the worker decodes with messages.Decode.
+type Message = messages.Envelope
+
+// ConsumerPort delivers queued messages. The NATS consumer adapts its messages to amqp
+// deliveries, so both brokers are consumed the same way.
+type ConsumerPort interface {
+	Consume() (<-chan amqp.Delivery, error)
+	StopConsuming() error
+	Close() error
+	HealthCheck() error
+}
+
+type PublisherPort interface {
+	PublishBatch(ctx context.Context, messages []Message) error
+	PublishWithRetry(ctx context.Context, jobs []Message, maxAttempts int) error
+	// QueueDepth returns the number of messages waiting in the named queue.
+	QueueDepth(ctx context.Context, queueName string) (int64, error)
+	Close()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,12 +13,6 @@ import (
 	"github.com/sony/gobreaker"
 )
 
-type ConsumerPort interface {
-	Consume() (<-chan amqp.Delivery, error)
-	Close() error
-	HealthCheck() error
-}
-
 type RabbitMQConfig struct {
 	Host                 string
 	Port                 int
@@ -45,6 +40,7 @@ type RabbitMQConsumer struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	reconnectCount int64
+	consumerTag    string
 }
 
 func NewRabbitMQConfigFromWorkerConfig(host, username, password, queueName string, port, prefetchCount, maxRetryAttempts int) *RabbitMQConfig {
@@ -83,12 +79,15 @@ func NewRabbitMQConsumer(config *RabbitMQConfig, logger *slog.Logger) *RabbitMQC
 		},
 	}
 
+	hostname, _ := os.Hostname()
+
 	consumer := &RabbitMQConsumer{
 		config:         config,
 		logger:         logger,
 		circuitBreaker: gobreaker.NewCircuitBreaker(cbSettings),
 		ctx:            ctx,
 		cancel:         cancel,
+		consumerTag:    fmt.Sprintf("worker-%s-%d", hostname, os.Getpid()),
 	}
 
 	if err := consumer.connect(); err != nil {
@@ -201,7 +200,7 @@ func (c *RabbitMQConsumer) Consume() (<-chan amqp.Delivery, error) {
 
 		deliveries, err := c.channel.Consume(
 			c.config.QueueName,
-			"",
+			c.consumerTag,
 			false,
 			false,
 			false,
@@ -231,6 +230,24 @@ func (c *RabbitMQConsumer) Consume() (<-chan amqp.Delivery, error) {
 	return result.(chan amqp.Delivery), nil
 }
 
+// StopConsuming cancels the consumer so the broker stops sending new deliveries,
+// while leaving the channel open so in-flight messages can still be acked.
+func (c *RabbitMQConsumer) StopConsuming() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.channel == nil {
+		return fmt.Errorf("channel is not available")
+	}
+
+	if err := c.channel.Cancel(c.consumerTag, false); err != nil {
+		return fmt.Errorf("failed to cancel consumer: %w", err)
+	}
+
+	c.logger.Info("Stopped consuming new deliveries", "consumer_tag", c.consumerTag)
+	return nil
+}
+
 func (c *RabbitMQConsumer) Close() error {
 	if !atomic.CompareAndSwapInt64(&c.closed, 0, 1) {
 		return nil
@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type RabbitMQPublisher struct {
+	conn         *amqp.Connection
+	ch           *amqp.Channel
+	exchange     string
+	primaryQueue string
+}
+
+func NewMQPublisher(amqpConnection *amqp.Connection, amqpChannel *amqp.Channel, queueName string) (*RabbitMQPublisher, error) {
+	if err := amqpChannel.Confirm(false); err != nil {
+		_ = amqpChannel.Close()
+		_ = amqpConnection.Close()
+		return nil, fmt.Errorf("failed to enable publish confirms: %w", err)
+	}
+
+	return &RabbitMQPublisher{
+		conn:         amqpConnection,
+		ch:           amqpChannel,
+		primaryQueue: queueName,
+	}, nil
+}
+
+// NewMQDeadLetterPublisher declares a durable direct exchange and a durable queue bound to it
+// under the queue's name, and publishes to that exchange. Declaring them itself makes dead
+// lettering independent of any dead letter exchange configured on the broker.
+func NewMQDeadLetterPublisher(amqpConnection *amqp.Connection, amqpChannel *amqp.Channel, exchange, queueName string) (*RabbitMQPublisher, error) {
+	if err := amqpChannel.ExchangeDeclare(exchange, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+		_ = amqpChannel.Close()
+		_ = amqpConnection.Close()
+		return nil, fmt.Errorf("failed to declare exchange %s: %w", exchange, err)
+	}
+	if _, err := amqpChannel.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		_ = amqpChannel.Close()
+		_ = amqpConnection.Close()
+		return nil, fmt.Errorf("failed to declare queue %s: %w", queueName, err)
+	}
+	if err := amqpChannel.QueueBind(queueName, queueName, exchange, false, nil); err != nil {
+		_ = amqpChannel.Close()
+		_ = amqpConnection.Close()
+		return nil, fmt.Errorf("failed to bind queue %s to exchange %s: %w", queueName, exchange, err)
+	}
+
+	publisher, err := NewMQPublisher(amqpConnection, amqpChannel, queueName)
+	if err != nil {
+		return nil, err
+	}
+	publisher.exchange = exchange
+	return publisher, nil
+}
+
+// PublishBatch publishes messages and waits until the broker confirms every one of them, so a
+// nil error means the broker has taken responsibility for the whole batch.
+func (p *RabbitMQPublisher) PublishBatch(ctx context.Context, messages []Message) error {
+	confirmations := make([]*amqp.DeferredConfirmation, 0, len(messages))
+	for _, message := range messages {
+		b, _ := json.Marshal(message)
+		pub := amqp.Publishing{ContentType: "application/json", Body: b, DeliveryMode: amqp.Persistent, Timestamp: time.Now()}
+		confirmation, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, p.primaryQueue, false, false, pub)
+		if err != nil {
+			return err
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+
+	for _, confirmation := range confirmations {
+		acked, err := confirmation.WaitContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for publish confirm: %w", err)
+		}
+		if !acked {
+			return fmt.Errorf("broker nacked the publish with delivery tag %d", confirmation.DeliveryTag)
+		}
+	}
+	return nil
+}
+
+// QueueDepth inspects the queue on a channel of its own: inspecting a missing queue closes the
+// channel, which must not take the publishing channel down with it.
+func (p *RabbitMQPublisher) QueueDepth(_ context.Context, queueName string) (int64, error) {
+	ch, err := p.conn.Channel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open inspection channel: %w", err)
+	}
+	defer func() { _ = ch.Close() }()
+
+	q, err := ch.QueueDeclarePassive(queueName, true, false, false, false, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect queue %s: %w", queueName, err)
+	}
+	return int64(q.Messages), nil
+}
+
+func (p *RabbitMQPublisher) Close() {
+	if p.ch != nil {
+		_ = p.ch.Close()
+	}
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+}
+
+func backoffDelay(attempt int) time.Duration {
+	switch attempt {
+	case 1:
+		return 1 * time.Second
+	case 2:
+		return 5 * time.Second
+	case 3:
+		return 30 * time.Second
+	case 4:
+		return 2 * time.Minute
+	default:
+		return 5 * time.Minute
+	}
+}
+
+func (p *RabbitMQPublisher) PublishWithRetry(ctx context.Context, jobs []Message, maxAttempts int) error {
+	return publishWithRetry(ctx, p, jobs, maxAttempts)
+}
+
+func publishWithRetry(ctx context.Context, publisher PublisherPort, jobs []Message, maxAttempts int) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = publisher.PublishBatch(ctx, jobs)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context canceled: %w", err)
+		case <-time.After(backoffDelay(attempt)):
+		}
+	}
+	return fmt.Errorf("publish failed after %d attempts: %w", maxAttempts, err)
+}
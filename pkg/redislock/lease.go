@@ -0,0 +1,51 @@
+// Package redislock holds leases on Redis keys. A lease is a key holding the name of its owner
+// until its TTL lapses. Only the owner renews or releases it, so a holder whose lease expired and
+// was taken over cannot extend or delete the new holder's lease.
+package redislock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var renewLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Acquire sets key to owner for ttl if no one holds it.
+func Acquire(ctx context.Context, client redis.Cmdable, key, owner string, ttl time.Duration) (bool, error) {
+	return client.SetNX(ctx, key, owner, ttl).Result()
+}
+
+// Renew extends the lease to ttl from now while owner still holds it.
+func Renew(ctx context.Context, client redis.Cmdable, key, owner string, ttl time.Duration) (bool, error) {
+	renewed, err := renewLeaseScript.Run(ctx, client, []string{key}, owner, ttl.Milliseconds()).Int()
+	return renewed == 1, err
+}
+
+// Release deletes the lease while owner still holds it.
+func Release(ctx context.Context, client redis.Cmdable, key, owner string) error {
+	return releaseLeaseScript.Run(ctx, client, []string{key}, owner).Err()
+}
+
+// Owner returns the holder of the lease, empty when no one holds it.
+func Owner(ctx context.Context, client redis.Cmdable, key string) (string, error) {
+	owner, err := client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return owner, err
+}
@@ -0,0 +1,70 @@
+package redislock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return server, client
+}
+
+func TestLeaseIsHeldByOneOwner(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	acquired, err := Acquire(ctx, client, "lease", "a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = Acquire(ctx, client, "lease", "b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	owner, err := Owner(ctx, client, "lease")
+	require.NoError(t, err)
+	assert.Equal(t, "a", owner)
+
+	server.FastForward(30 * time.Second)
+	renewed, err := Renew(ctx, client, "lease", "a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, renewed)
+	assert.Equal(t, time.Minute, server.TTL("lease"))
+
+	require.NoError(t, Release(ctx, client, "lease", "a"))
+	owner, err = Owner(ctx, client, "lease")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+}
+
+func TestLeaseTakenOverIsNotRenewedOrReleasedByThePreviousOwner(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := Acquire(ctx, client, "lease", "a", time.Second)
+	require.NoError(t, err)
+	server.FastForward(2 * time.Second)
+
+	acquired, err := Acquire(ctx, client, "lease", "b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired, "an expired lease can be taken over")
+
+	renewed, err := Renew(ctx, client, "lease", "a", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, renewed)
+
+	require.NoError(t, Release(ctx, client, "lease", "a"))
+	owner, err := Owner(ctx, client, "lease")
+	require.NoError(t, err)
+	assert.Equal(t, "b", owner)
+}
@@ -0,0 +1,73 @@
+// Package timezones finds the IANA timezone of a position from the timezone boundary
+// polygons, so a position near a border takes the zone it lies in.
+package timezones
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+	// The service images carry no zoneinfo, so Load falls back to the embedded database.
+	_ "time/tzdata"
+
+	"github.com/ringsaturn/tzf"
+)
+
+// maxCachedLookups bounds the lookup cache; it starts over once full.
+const maxCachedLookups = 10000
+
+var (
+	finderOnce sync.Once
+	finder     tzf.F
+	finderErr  error
+
+	cacheMu sync.Mutex
+	cache   = make(map[string]string)
+)
+
+// Lookup returns the timezone whose boundaries contain the position. Positions at sea take the
+// nautical zone of their longitude, such as Etc/GMT+9. It is false for positions out of range
+// and when the boundaries cannot be loaded. Results are cached, as they only depend on the
+// position.
+func Lookup(lat, lon float64) (string, bool) {
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return "", false
+	}
+	key := fmt.Sprintf("%.4f,%.4f", lat, lon)
+
+	cacheMu.Lock()
+	name, ok := cache[key]
+	cacheMu.Unlock()
+	if ok {
+		return name, name != ""
+	}
+
+	// The boundaries take a few hundred milliseconds to load, so they are loaded on first use.
+	finderOnce.Do(func() {
+		finder, finderErr = tzf.NewDefaultFinder()
+	})
+	if finderErr != nil {
+		return "", false
+	}
+	name = finder.GetTimezoneName(lon, lat)
+
+	cacheMu.Lock()
+	if len(cache) >= maxCachedLookups {
+		cache = make(map[string]string)
+	}
+	cache[key] = name
+	cacheMu.Unlock()
+	return name, name != ""
+}
+
+// Load returns the location of a timezone name, or UTC when it is empty or unknown.
+func Load(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
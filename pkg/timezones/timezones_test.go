@@ -0,0 +1,65 @@
+package timezones
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupTakesTheZoneThePositionLiesIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		expected string
+	}{
+		{name: "Tokyo", lat: 35.6895, lon: 139.6917, expected: "Asia/Tokyo"},
+		{name: "Paris", lat: 48.8566, lon: 2.3522, expected: "Europe/Paris"},
+		// Towns a few kilometres apart on either side of a border.
+		{name: "Strasbourg", lat: 48.5734, lon: 7.7521, expected: "Europe/Paris"},
+		{name: "Kehl", lat: 48.5725, lon: 7.8150, expected: "Europe/Berlin"},
+		{name: "Valga", lat: 57.7773, lon: 26.0473, expected: "Europe/Tallinn"},
+		{name: "Valka", lat: 57.7750, lon: 26.0175, expected: "Europe/Riga"},
+		{name: "Irun", lat: 43.3390, lon: -1.7896, expected: "Europe/Madrid"},
+		{name: "Hendaye", lat: 43.3587, lon: -1.7747, expected: "Europe/Paris"},
+		{name: "Tijuana", lat: 32.5149, lon: -117.0382, expected: "America/Tijuana"},
+		{name: "San Diego", lat: 32.7157, lon: -117.1611, expected: "America/Los_Angeles"},
+		{name: "El Paso", lat: 31.7619, lon: -106.4850, expected: "America/Denver"},
+		{name: "Ciudad Juárez", lat: 31.6904, lon: -106.4245, expected: "America/Ciudad_Juarez"},
+		// Zones within one country.
+		{name: "Gary", lat: 41.5934, lon: -87.3464, expected: "America/Chicago"},
+		{name: "Indianapolis", lat: 39.7684, lon: -86.1581, expected: "America/Indiana/Indianapolis"},
+		{name: "Las Palmas", lat: 28.1235, lon: -15.4363, expected: "Atlantic/Canary"},
+		{name: "Kaliningrad", lat: 54.7104, lon: 20.4522, expected: "Europe/Kaliningrad"},
+		// A seafront hotel whose coordinates fall just off the coastline.
+		{name: "Nice seafront", lat: 43.6950, lon: 7.2650, expected: "Europe/Paris"},
+		{name: "open sea", lat: 0, lon: -140, expected: "Etc/GMT+9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := Lookup(tt.lat, tt.lon)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, name)
+
+			cached, ok := Lookup(tt.lat, tt.lon)
+			assert.True(t, ok)
+			assert.Equal(t, name, cached)
+		})
+	}
+}
+
+func TestLookupRejectsPositionsOutOfRange(t *testing.T) {
+	for _, position := range [][2]float64{{91, 0}, {-91, 0}, {0, 181}, {0, -181}, {math.NaN(), 0}, {0, math.NaN()}} {
+		name, ok := Lookup(position[0], position[1])
+		assert.False(t, ok, position)
+		assert.Empty(t, name, position)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	assert.Equal(t, "Asia/Tokyo", Load("Asia/Tokyo").String())
+	assert.Equal(t, time.UTC, Load(""))
+	assert.Equal(t, time.UTC, Load("Mars/Olympus_Mons"))
+}
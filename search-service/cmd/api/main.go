@@ -2,11 +2,16 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/http/pprof"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -18,17 +23,30 @@ import (
 
 	"github.com/common-nighthawk/go-figure"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
 	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
 	"github.com/victoragudo/hotel-management-system/pkg/logger"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/favorites"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/leader"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/pipeline"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/adapter"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/cdn"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/config"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/handler"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/openapi"
 	"gorm.io/gorm"
 
-	_ "github.com/victoragudo/hotel-management-system/search-service/docs"
+	"github.com/victoragudo/hotel-management-system/search-service/docs"
 )
 
 // @title Hotel Management & Search Service API
@@ -40,9 +58,121 @@ import (
 // @contact.email support@swagger.io
 // @license.name Apache 2.0
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
-// @host localhost:8080
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey Bearer
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the access token.
+
+var (
+	httpRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "search_http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	})
+	httpRequestsRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "search_http_requests_rejected_total",
+		Help: "Number of HTTP requests rejected because the concurrency limit was reached.",
+	})
+
+	dbOpenConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_open_connections",
+		Help: "Number of established PostgreSQL connections, in use or idle.",
+	})
+	dbIdleConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_idle_connections",
+		Help: "Number of idle PostgreSQL connections.",
+	})
+	dbInUseConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_in_use_connections",
+		Help: "Number of PostgreSQL connections currently in use.",
+	})
+	dbWaitCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_wait_count",
+		Help: "Total number of times a query waited for a free PostgreSQL connection.",
+	})
+	dbWaitDurationMs = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_wait_duration_ms",
+		Help: "Total time in milliseconds spent waiting for a free PostgreSQL connection.",
+	})
+
+	searchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "search_duration_seconds",
+		Help:    "Duration of hotel searches: stage total is measured by the service, stage search_engine is reported by the search engine.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"stage"})
+)
+
+var localCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "search_local_cache_lookups_total",
+	Help: "Lookups in the in-process cache in front of Redis, by result (hit or miss).",
+}, []string{"result"})
+
+func observeLocalCacheLookup(hit bool) {
+	if hit {
+		localCacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		localCacheLookups.WithLabelValues("miss").Inc()
+	}
+}
+
+// observeSearchDurations records a search in searchDuration. Cached results, which did not
+// reach the search engine, only count towards the total.
+func observeSearchDurations(total, searchEngine time.Duration) {
+	searchDuration.WithLabelValues("total").Observe(total.Seconds())
+	if searchEngine > 0 {
+		searchDuration.WithLabelValues("search_engine").Observe(searchEngine.Seconds())
+	}
+}
+
+// registerLoadSheddingMetrics exports the state of the load shedder, read at scrape time.
+func registerLoadSheddingMetrics(monitor *adapter.LoadShedder) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "search_load_shedding_active",
+		Help: "Whether search requests are being shed because the search engine is degraded (1) or not (0).",
+	}, func() float64 {
+		if monitor.LoadStatus().Shedding {
+			return 1
+		}
+		return 0
+	})
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name: "search_load_shed_requests_total",
+		Help: "Number of search requests rejected while shedding load.",
+	}, func() float64 {
+		return float64(monitor.LoadStatus().ShedRequests)
+	})
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "search_engine_error_rate",
+		Help: "Share of failed search engine calls in the load shedding window.",
+	}, func() float64 {
+		return monitor.LoadStatus().ErrorRate
+	})
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "search_engine_p95_latency_seconds",
+		Help: "95th percentile latency of search engine calls in the load shedding window.",
+	}, func() float64 {
+		return monitor.LoadStatus().P95Latency.Seconds()
+	})
+}
+
+const (
+	dbPoolSampleInterval = 30 * time.Second
+	// dbPoolSaturation is the share of MaxOpenConnections in use above which the pool is reported
+	// as nearly exhausted.
+	dbPoolSaturation = 0.9
+)
+
+// usageRollupDelay leaves time after midnight for the last usage events of the day to be flushed.
+const usageRollupDelay = 5 * time.Minute
+
+const (
+	requestIDHeader = "X-Request-ID"
+
+	// slowRequestLogSize is how many slow requests /api/v1/admin/slow-requests returns.
+	slowRequestLogSize  = 100
+	maxSlowResponseBody = 1 << 10
+)
 
 type Application struct {
 	config *config.Config
@@ -55,13 +185,30 @@ type Application struct {
 	cache         *adapter.RedisCacheAdapter
 	searchEngine  *adapter.TypesenseAdapter
 	hotelProvider *adapter.CupidAPIAdapter
+	orchestrator  *adapter.OrchestratorClient
 
 	getHotelByIDUseCase        *usecase.GetHotelByIDUseCase
 	searchHotelsUseCase        *usecase.SearchHotelsUseCase
 	getHotelSuggestionsUseCase *usecase.GetHotelSuggestionsUseCase
+	getChainSuggestionsUseCase *usecase.GetChainSuggestionsUseCase
 	syncHotelsUseCase          *usecase.SyncHotelsUseCase
-
-	hotelHandler *handler.HotelHandler
+	combinedSearchUseCase      *usecase.CombinedSearchUseCase
+	indexBackfillUseCase       *usecase.IndexBackfillUseCase
+	reconcileUseCase           *usecase.ReconcileUseCase
+	hotelTranslationsUseCase   *usecase.GetHotelTranslationsUseCase
+	usageReportUseCase         *usecase.UsageReportUseCase
+	searchConfigUseCase        *usecase.SearchConfigUseCase
+	reviewArchivalUseCase      *usecase.ReviewArchivalUseCase
+
+	usageCounter *adapter.RedisUsageCounter
+	stopUsage    context.CancelFunc
+	usageDone    chan struct{}
+
+	// leaderElector is nil when sync.always_run is set.
+	leaderElector *adapter.RedisLeaderElector
+	stopLeader    context.CancelFunc
+
+	handlers routeHandlers
 }
 
 func main() {
@@ -73,9 +220,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Invalid configuration: %v", err)
-	}
+	cfg.Validate().Enforce(applicationLogger)
 
 	app, err := NewApplication(cfg, applicationLogger)
 	if err != nil {
@@ -94,25 +239,72 @@ func NewApplication(cfg *config.Config, applicationLogger *slog.Logger) (*Applic
 		return nil, err
 	}
 
-	err = database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{})
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConnections)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConnections)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLife)
+
+	err = database.RunMigrations(db, &entities.HotelData{}, &entities.ReviewData{}, &entities.HotelTranslation{}, &entities.APIUsageDaily{}, &entities.HotelChange{}, &entities.SyncRun{})
 	if err != nil {
 		return nil, err
 	}
 
 	redisClient := initRedis(cfg.Redis, applicationLogger)
 
-	hotelRepo := adapter.NewPostgresHotelRepository(db, applicationLogger)
+	hotelRepo := adapter.NewPostgresHotelRepository(db, cfg.SourcePriorities, applicationLogger)
 	cache := adapter.NewRedisCacheAdapterWithClient(redisClient, applicationLogger)
 
-	searchEngine, err := adapter.NewTypesenseAdapter(cfg.Typesense.Host, cfg.Typesense.ApiKey, cfg.Typesense.CollectionName, applicationLogger)
+	// hotCache serves the hotel detail and search result keys, and the hotel invalidations
+	// that must clear them, through the local cache when it is enabled.
+	var hotCache hotel.CacheRepository = cache
+	if cfg.LocalCache.Enabled {
+		hotCache = adapter.NewLocalCache(cache, adapter.LocalCacheConfig{
+			MaxEntries:  cfg.LocalCache.MaxEntries,
+			TTL:         cfg.LocalCache.TTL,
+			KeyPrefixes: cfg.LocalCache.KeyPrefixes,
+			OnLookup:    observeLocalCacheLookup,
+		})
+		applicationLogger.Info("Local cache enabled",
+			"max_entries", cfg.LocalCache.MaxEntries,
+			"ttl", cfg.LocalCache.TTL,
+			"key_prefixes", cfg.LocalCache.KeyPrefixes)
+	}
+
+	loadShedding := cfg.Typesense.LoadShedding
+	loadShedder := adapter.NewLoadShedder(adapter.LoadShedderConfig{
+		Enabled:          loadShedding.Enabled,
+		WindowSize:       loadShedding.WindowSize,
+		Window:           loadShedding.Window,
+		MinSamples:       loadShedding.MinSamples,
+		MaxErrorRate:     loadShedding.MaxErrorRate,
+		LatencyThreshold: loadShedding.LatencyThreshold,
+		Cooldown:         loadShedding.Cooldown,
+	}, applicationLogger)
+	registerLoadSheddingMetrics(loadShedder)
+
+	photoURLs := photoURLRewriter(cfg.Server)
+	searchEngine, err := adapter.NewTypesenseAdapter(cfg.Typesense.Host, cfg.Typesense.Nodes, cfg.Typesense.ApiKey, cfg.Typesense.CollectionName, cfg.SearchLanguages(), adapter.ImportConfig{
+		BatchSize:       cfg.Typesense.Import.BatchSize,
+		MaxPayloadBytes: cfg.Typesense.Import.MaxPayloadBytes,
+	}, photoURLs, loadShedder, applicationLogger)
 	if err != nil {
 		return nil, err
 	}
 
+	facilityTaxonomy, err := facilities.New(cfg.FacilityAliases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build facility taxonomy: %w", err)
+	}
+
 	hotelProvider := adapter.NewCupidAPIAdapter(
 		cfg.CupidAPI.BaseURL,
 		cfg.CupidAPI.APIKey,
 		cfg.CupidAPI.Timeout,
+		cfg.CupidAPI.MaxResponseBytes,
+		facilityTaxonomy,
 		applicationLogger,
 	)
 
@@ -120,38 +312,219 @@ func NewApplication(cfg *config.Config, applicationLogger *slog.Logger) (*Applic
 		hotelRepo,
 		hotelProvider,
 		searchEngine,
-		cache,
+		hotCache,
+		cfg.ResponseLimits.Max.Reviews,
+		applicationLogger,
+	)
+
+	trendingQueries := usecase.NewTrendingQueries(
+		adapter.NewRedisQueryLog(redisClient, cfg.Trending.BucketTTL),
+		cfg.Trending.MinSamples,
 		applicationLogger,
 	)
 
 	searchHotelsUseCase := usecase.NewSearchHotelsUseCase(
 		searchEngine,
-		cache,
+		hotCache,
+		searchEngine,
+		trendingQueries,
+		cfg.Results.SnippetLength,
+		cfg.Results.CacheMaxAge,
+		cfg.Results.CacheStaleWhileRevalidate,
+		observeSearchDurations,
 		applicationLogger,
 	)
 
 	getHotelSuggestionsUseCase := usecase.NewGetHotelSuggestionsUseCase(
+		hotelRepo,
+		searchEngine,
+		cache,
+		trendingQueries,
+		applicationLogger,
+	)
+
+	getChainSuggestionsUseCase := usecase.NewGetChainSuggestionsUseCase(
 		searchEngine,
 		cache,
 		applicationLogger,
 	)
 
+	var pipelineStats pipeline.StatsProvider
+	var orchestrator *adapter.OrchestratorClient
+	if cfg.Orchestrator.Address != "" {
+		orchestrator, err = adapter.NewOrchestratorClient(cfg.Orchestrator.Address, cfg.Orchestrator.Timeout, applicationLogger)
+		if err != nil {
+			return nil, err
+		}
+		pipelineStats = orchestrator
+	}
+
+	var syncLeader leader.Elector = leader.Always{Replica: leader.ReplicaID()}
+	var leaderElector *adapter.RedisLeaderElector
+	if !cfg.Sync.AlwaysRun {
+		leaderElector = adapter.NewRedisLeaderElector(redisClient, leader.ReplicaID(), cfg.Sync.LeaderLockTTL, applicationLogger)
+		syncLeader = leaderElector
+	}
+
 	syncHotelsUseCase := usecase.NewSyncHotelsUseCase(
+		hotelRepo,
+		searchEngine,
+		cache,
+		cache,
+		pipelineStats,
+		syncLeader,
+		cfg.Sync.DeleteRemovedHotels,
+		adapter.NewPostgresSyncHistoryRepository(db, applicationLogger),
+		time.Duration(cfg.Sync.HistoryRetentionDays)*24*time.Hour,
+		applicationLogger,
+	)
+
+	combinedSearchUseCase := usecase.NewCombinedSearchUseCase(
+		searchEngine,
+		searchEngine,
+		cfg.Results.SnippetLength,
+		applicationLogger,
+	)
+
+	indexBackfillUseCase := usecase.NewIndexBackfillUseCase(
 		hotelRepo,
 		searchEngine,
 		cache,
 		applicationLogger,
 	)
 
-	hotelHandler := handler.NewHotelHandler(
-		getHotelByIDUseCase,
-		searchHotelsUseCase,
-		getHotelSuggestionsUseCase,
-		syncHotelsUseCase,
+	reconcileUseCase := usecase.NewReconcileUseCase(
+		hotelRepo,
+		searchEngine,
+		cache,
 		applicationLogger,
 	)
 
-	server := initServer(cfg.Server, hotelHandler, applicationLogger)
+	hotelTranslationsUseCase := usecase.NewGetHotelTranslationsUseCase(
+		hotelRepo,
+		hotelProvider,
+		cache,
+		applicationLogger,
+	)
+
+	hotelReviewsUseCase := usecase.NewHotelReviewsUseCase(hotelRepo, applicationLogger)
+	hotelChangesUseCase := usecase.NewHotelChangesUseCase(hotelRepo, applicationLogger)
+
+	facilitiesUseCase := usecase.NewFacilitiesUseCase(hotelRepo, cache, facilityTaxonomy, applicationLogger)
+	facilityBackfillUseCase := usecase.NewFacilityBackfillUseCase(hotelRepo, searchEngine, cache, facilityTaxonomy, applicationLogger)
+
+	reviewArchivalUseCase := usecase.NewReviewArchivalUseCase(
+		hotelRepo,
+		cache,
+		usecase.ReviewArchiveOptions{
+			KeepNewest: cfg.ReviewArchive.KeepNewest,
+			MinAgeDays: cfg.ReviewArchive.MinAgeDays,
+			BatchSize:  cfg.ReviewArchive.BatchSize,
+		},
+		applicationLogger,
+	)
+
+	reviewDedupUseCase := usecase.NewReviewDedupUseCase(hotelRepo, cache, applicationLogger)
+
+	updateHotelUseCase := usecase.NewUpdateHotelUseCase(
+		hotelRepo,
+		searchEngine,
+		hotCache,
+		applicationLogger,
+	)
+
+	hotelDuplicatesUseCase := usecase.NewHotelDuplicatesUseCase(
+		hotelRepo,
+		searchEngine,
+		hotCache,
+		applicationLogger,
+	)
+
+	usageCounter := adapter.NewRedisUsageCounter(redisClient, applicationLogger)
+	usageReportUseCase := usecase.NewUsageReportUseCase(
+		usageCounter,
+		adapter.NewPostgresUsageRepository(db, applicationLogger),
+		applicationLogger,
+	)
+
+	searchConfigUseCase := usecase.NewSearchConfigUseCase(
+		searchEngine,
+		searchEngine,
+		cache,
+		cfg.Tuning.SearchTuning(),
+		applicationLogger,
+	)
+
+	var favoritesUseCase *usecase.FavoritesUseCase
+	var sessions *handler.SessionSigner
+	if cfg.Server.EnableFavorites {
+		favoritesUseCase = usecase.NewFavoritesUseCase(
+			adapter.NewRedisFavoritesStore(redisClient, applicationLogger),
+			hotelRepo,
+			applicationLogger,
+		)
+		sessions = handler.NewSessionSigner(cfg.Server.SessionSecret, favorites.TTL)
+	}
+
+	slowRequests := handler.NewSlowRequestLog(slowRequestLogSize)
+	cachePolicies := newCachePolicies(cfg.Server.CacheControl)
+	httpHandlers := routeHandlers{
+		hotel: handler.NewHotelHandler(
+			getHotelByIDUseCase,
+			usecase.NewCompareHotelsUseCase(hotelRepo, applicationLogger),
+			hotelTranslationsUseCase,
+			hotelReviewsUseCase,
+			favoritesUseCase,
+			sessions,
+			handler.ResponseLimits{
+				Default:  cfg.ResponseLimits.Default.CollectionLimits(),
+				Max:      cfg.ResponseLimits.Max.CollectionLimits(),
+				MaxBytes: cfg.ResponseLimits.MaxResponseBytes,
+			},
+			cachePolicies,
+			cfg.Server.ExposeDataSources,
+			photoURLs,
+			applicationLogger,
+		),
+		search: handler.NewSearchHandler(
+			searchHotelsUseCase,
+			getHotelSuggestionsUseCase,
+			getChainSuggestionsUseCase,
+			combinedSearchUseCase,
+			facilitiesUseCase,
+			cachePolicies,
+			handler.NewHotels{
+				MaxDays:     cfg.Results.NewHotelsMaxDays,
+				CacheMaxAge: cfg.Results.NewHotelsCacheMaxAge,
+			},
+			applicationLogger,
+		),
+		admin: handler.NewAdminHandler(
+			getHotelByIDUseCase,
+			updateHotelUseCase,
+			hotelChangesUseCase,
+			hotelDuplicatesUseCase,
+			syncHotelsUseCase,
+			indexBackfillUseCase,
+			reconcileUseCase,
+			reviewArchivalUseCase,
+			reviewDedupUseCase,
+			usageReportUseCase,
+			searchConfigUseCase,
+			facilitiesUseCase,
+			facilityBackfillUseCase,
+			applicationLogger,
+		),
+		health: handler.NewHealthHandler(searchHotelsUseCase, syncHotelsUseCase, applicationLogger),
+		debug:  handler.NewDebugHandler(cfg, slowRequests, applicationLogger),
+	}
+
+	apiSpec, err := openapi.Load(docs.SwaggerInfo.ReadDoc())
+	if err != nil {
+		return nil, err
+	}
+
+	server := initServer(cfg.Server, httpHandlers, apiSpec, slowRequests, usageCounter, applicationLogger)
 
 	return &Application{
 		config:                     cfg,
@@ -163,11 +536,23 @@ func NewApplication(cfg *config.Config, applicationLogger *slog.Logger) (*Applic
 		cache:                      cache,
 		searchEngine:               searchEngine,
 		hotelProvider:              hotelProvider,
+		orchestrator:               orchestrator,
 		getHotelByIDUseCase:        getHotelByIDUseCase,
 		searchHotelsUseCase:        searchHotelsUseCase,
 		getHotelSuggestionsUseCase: getHotelSuggestionsUseCase,
+		getChainSuggestionsUseCase: getChainSuggestionsUseCase,
 		syncHotelsUseCase:          syncHotelsUseCase,
-		hotelHandler:               hotelHandler,
+		combinedSearchUseCase:      combinedSearchUseCase,
+		indexBackfillUseCase:       indexBackfillUseCase,
+		reconcileUseCase:           reconcileUseCase,
+		hotelTranslationsUseCase:   hotelTranslationsUseCase,
+		usageReportUseCase:         usageReportUseCase,
+		searchConfigUseCase:        searchConfigUseCase,
+		reviewArchivalUseCase:      reviewArchivalUseCase,
+		usageCounter:               usageCounter,
+		usageDone:                  make(chan struct{}),
+		leaderElector:              leaderElector,
+		handlers:                   httpHandlers,
 	}, nil
 }
 
@@ -175,22 +560,51 @@ func (app *Application) Start() error {
 	ctx := context.Background()
 
 	app.logger.Info("Starting search service",
-		"version", "1.0.0",
-		"address", app.config.Server.Address())
+		append(buildinfo.Get("search-service").LogAttrs(), "address", app.config.Server.Address())...)
 
 	if err := app.performHealthChecks(ctx); err != nil {
 		app.logger.Error("Health checks failed", "error", err)
 		return err
 	}
 
+	app.searchConfigUseCase.Load(ctx)
+	go app.searchConfigUseCase.Watch(ctx, app.config.Tuning.RefreshInterval)
+
+	if app.leaderElector != nil {
+		app.leaderElector.Campaign(ctx)
+		leaderCtx, stopLeader := context.WithCancel(ctx)
+		app.stopLeader = stopLeader
+		go app.leaderElector.Run(leaderCtx)
+	}
+
+	// Only the replica that leads at startup runs the initial sync; a replica taking over later
+	// continues with the periodic syncs.
 	if app.config.Sync.InitialSyncOnStart {
-		go app.performInitialSync(ctx)
+		if app.syncHotelsUseCase.IsLeader() {
+			go app.performInitialSync(ctx)
+		} else {
+			app.logger.Info("Skipping initial sync, another replica is the sync leader")
+		}
 	}
 
 	if app.config.Sync.IncrementalInterval > 0 {
 		go app.startPeriodicSync(ctx)
 	}
 
+	usageCtx, stopUsage := context.WithCancel(ctx)
+	app.stopUsage = stopUsage
+	go func() {
+		defer close(app.usageDone)
+		app.usageCounter.Run(usageCtx)
+	}()
+	go app.startUsageRollup(usageCtx)
+
+	if app.config.ReviewArchive.Interval > 0 {
+		go app.startReviewArchival(ctx)
+	}
+
+	go app.monitorDBPool(ctx)
+
 	go func() {
 		figure.NewFigure("API", "", true).Print()
 		fmt.Println("")
@@ -234,9 +648,14 @@ func (app *Application) performInitialSync(ctx context.Context) {
 		BatchSize:        app.config.Sync.BatchSize,
 		ClearIndexFirst:  true,
 		UpdateCacheAfter: true,
+		Trigger:          synchistory.TriggerInitial,
 	}
 
 	result, err := app.syncHotelsUseCase.Execute(ctx, options)
+	if errors.Is(err, usecase.ErrSyncSuperseded) {
+		app.logger.Info("Initial sync superseded", "reason", err)
+		return
+	}
 	if err != nil {
 		app.logger.Error("Initial sync failed", "error", err)
 		return
@@ -259,14 +678,23 @@ func (app *Application) startPeriodicSync(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if !app.syncHotelsUseCase.IsLeader() {
+				app.logger.Debug("Skipping incremental sync, standing by for the sync leader")
+				continue
+			}
 			app.logger.Debug("Running incremental sync")
 
 			options := usecase.SyncOptions{
 				BatchSize:        app.config.Sync.BatchSize,
 				UpdateCacheAfter: true,
+				Trigger:          synchistory.TriggerPeriodic,
 			}
 
 			result, err := app.syncHotelsUseCase.Execute(ctx, options)
+			if errors.Is(err, usecase.ErrSyncSuperseded) {
+				app.logger.Info("Incremental sync superseded", "reason", err)
+				continue
+			}
 			if err != nil {
 				app.logger.Error("Incremental sync failed", "error", err)
 				continue
@@ -282,6 +710,100 @@ func (app *Application) startPeriodicSync(ctx context.Context) {
 	}
 }
 
+// startReviewArchival starts a review archival every ReviewArchive.Interval. A run still in
+// progress, here or triggered through the admin API, is left to finish.
+func (app *Application) startReviewArchival(ctx context.Context) {
+	ticker := time.NewTicker(app.config.ReviewArchive.Interval)
+	defer ticker.Stop()
+
+	app.logger.Info("Starting scheduled review archival", "interval", app.config.ReviewArchive.Interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job, err := app.reviewArchivalUseCase.Start(ctx, usecase.ReviewArchiveOptions{})
+			if errors.Is(err, usecase.ErrReviewArchiveRunning) {
+				app.logger.Debug("Skipping scheduled review archival, a run is in progress")
+				continue
+			}
+			if err != nil {
+				app.logger.Error("Scheduled review archival failed to start", "error", err)
+				continue
+			}
+			app.logger.Info("Scheduled review archival started", "job_id", job.ID)
+		}
+	}
+}
+
+// startUsageRollup rolls the previous days' usage counters into PostgreSQL at startup and then
+// shortly after every UTC midnight. Both of the last two days are rolled up so a run missed
+// while the service was down is caught up; re-running a day is harmless.
+func (app *Application) startUsageRollup(ctx context.Context) {
+	for {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		for _, day := range []time.Time{today.AddDate(0, 0, -2), today.AddDate(0, 0, -1)} {
+			if _, err := app.usageReportUseCase.Rollup(ctx, day); err != nil {
+				app.logger.Error("Usage rollup failed", "day", day.Format(usage.DayLayout), "error", err)
+			}
+		}
+
+		next := today.AddDate(0, 0, 1).Add(usageRollupDelay)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// monitorDBPool exports the connection pool statistics every dbPoolSampleInterval and warns
+// when queries had to wait for a connection or the pool is close to MaxOpenConnections.
+func (app *Application) monitorDBPool(ctx context.Context) {
+	sqlDB, err := app.db.DB()
+	if err != nil {
+		app.logger.Error("Failed to get database handle for pool monitoring", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(dbPoolSampleInterval)
+	defer ticker.Stop()
+
+	lastWaitCount := int64(-1)
+	for {
+		stats := sqlDB.Stats()
+		dbOpenConnections.Set(float64(stats.OpenConnections))
+		dbIdleConnections.Set(float64(stats.Idle))
+		dbInUseConnections.Set(float64(stats.InUse))
+		dbWaitCount.Set(float64(stats.WaitCount))
+		dbWaitDurationMs.Set(float64(stats.WaitDuration.Milliseconds()))
+
+		if lastWaitCount >= 0 && stats.WaitCount > lastWaitCount {
+			app.logger.Warn("Database queries waited for a pool connection",
+				"waits", stats.WaitCount-lastWaitCount,
+				"open_connections", stats.OpenConnections,
+				"max_open_connections", stats.MaxOpenConnections)
+		}
+		lastWaitCount = stats.WaitCount
+
+		if stats.MaxOpenConnections > 0 && float64(stats.OpenConnections)/float64(stats.MaxOpenConnections) > dbPoolSaturation {
+			app.logger.Error("Database connection pool nearly exhausted",
+				"open_connections", stats.OpenConnections,
+				"in_use", stats.InUse,
+				"max_open_connections", stats.MaxOpenConnections)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (app *Application) waitForShutdown() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -296,6 +818,16 @@ func (app *Application) waitForShutdown() {
 		app.logger.Error("Server forced to shutdown", "error", err)
 	}
 
+	if app.stopUsage != nil {
+		app.stopUsage()
+		<-app.usageDone
+	}
+
+	if app.stopLeader != nil {
+		app.stopLeader()
+		app.leaderElector.Resign()
+	}
+
 	if sqlDB, err := app.db.DB(); err == nil {
 		if err := sqlDB.Close(); err != nil {
 			app.logger.Error("Error closing database", "error", err)
@@ -306,6 +838,12 @@ func (app *Application) waitForShutdown() {
 		app.logger.Error("Error closing Redis", "error", err)
 	}
 
+	if app.orchestrator != nil {
+		if err := app.orchestrator.Close(); err != nil {
+			app.logger.Error("Error closing orchestrator client", "error", err)
+		}
+	}
+
 	app.logger.Info("Server stopped gracefully")
 }
 
@@ -327,32 +865,40 @@ func initRedis(cfg config.RedisConfig, logger *slog.Logger) *redis.Client {
 	return client
 }
 
-func initServer(cfg config.ServerConfig, hotelHandler *handler.HotelHandler, logger *slog.Logger) *http.Server {
-	router := mux.NewRouter()
-
-	api := router.PathPrefix("/api/v1").Subrouter()
-
-	api.HandleFunc("/hotels/{id}", hotelHandler.GetHotelByID).Methods("GET")
-
-	api.HandleFunc("/search/hotels", hotelHandler.SearchHotels).Methods("GET")
-	api.HandleFunc("/search/suggestions", hotelHandler.GetHotelSuggestions).Methods("GET")
-	api.HandleFunc("/search/trending", hotelHandler.GetTrendingSuggestions).Methods("GET")
-	api.HandleFunc("/search/facets", hotelHandler.GetFacets).Methods("GET")
-
-	admin := api.PathPrefix("/admin").Subrouter()
-	admin.HandleFunc("/sync", hotelHandler.TriggerSync).Methods("POST")
-	admin.HandleFunc("/sync/stats", hotelHandler.GetSyncStats).Methods("GET")
-
-	router.HandleFunc("/health", hotelHandler.HealthCheck).Methods("GET")
+func newCachePolicies(cfg config.CacheControlConfig) handler.CachePolicies {
+	policy := func(c config.CachePolicyConfig) handler.CachePolicy {
+		return handler.CachePolicy{MaxAge: c.MaxAge, SharedMaxAge: c.SharedMaxAge}
+	}
+	return handler.CachePolicies{
+		Search:    policy(cfg.Search),
+		Hotel:     policy(cfg.Hotel),
+		Reference: policy(cfg.Reference),
+	}
+}
 
-	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
+// routeHandlers groups the HTTP handlers newRouter routes requests to.
+type routeHandlers struct {
+	hotel  *handler.HotelHandler
+	search *handler.SearchHandler
+	admin  *handler.AdminHandler
+	health *handler.HealthHandler
+	debug  *handler.DebugHandler
+}
 
-	router.Use(rateLimitMiddleware(100, time.Minute))
-	router.Use(loggingMiddleware(logger))
-	if cfg.EnableCORS {
-		router.Use(corsMiddleware)
+// photoURLRewriter serves hotel photos through the configured CDN. Without one it is nil and
+// photos keep their origin URLs.
+func photoURLRewriter(cfg config.ServerConfig) cdn.PhotoURLRewriter {
+	if cfg.CDNBaseURL == "" {
+		return nil
 	}
+	return cdn.NewCDNPhotoURLRewriter(cdn.CDNConfig{
+		BaseURL:    cfg.CDNBaseURL,
+		SigningKey: cfg.CDNSigningKey,
+	})
+}
 
+func initServer(cfg config.ServerConfig, handlers routeHandlers, apiSpec *openapi.Spec, slowRequests *handler.SlowRequestLog, usageCounter usage.Counter, logger *slog.Logger) *http.Server {
+	router := newRouter(cfg, handlers, apiSpec, slowRequests, usageCounter, logger)
 	printRoutes(router, logger)
 
 	return &http.Server{
@@ -364,6 +910,104 @@ func initServer(cfg config.ServerConfig, hotelHandler *handler.HotelHandler, log
 	}
 }
 
+// newRouter registers every route of the service and its middleware.
+func newRouter(cfg config.ServerConfig, handlers routeHandlers, apiSpec *openapi.Spec, slowRequests *handler.SlowRequestLog, usageCounter usage.Counter, logger *slog.Logger) *mux.Router {
+	router := mux.NewRouter()
+
+	api := router.PathPrefix("/api/v1").Subrouter()
+
+	api.HandleFunc("/hotels/compare", handlers.hotel.CompareHotels).Methods("GET")
+	api.HandleFunc("/hotels/new", handlers.search.GetNewHotels).Methods("GET")
+	api.HandleFunc("/hotels/{id}", handlers.hotel.GetHotelByID).Methods("GET")
+	api.HandleFunc("/hotels/{id}/reviews", handlers.hotel.GetHotelReviews).Methods("GET")
+	api.HandleFunc("/hotels/{id}/reviews/stats", handlers.hotel.GetHotelReviewStats).Methods("GET")
+	api.HandleFunc("/hotels/{id}/translations", handlers.hotel.GetHotelTranslations).Methods("GET")
+	api.HandleFunc("/hotels/{id}/translations/{lang}", handlers.hotel.GetHotelTranslation).Methods("GET")
+
+	api.HandleFunc("/search/hotels", handlers.search.SearchHotels).Methods("GET")
+	api.HandleFunc("/search/suggestions", handlers.search.GetHotelSuggestions).Methods("GET")
+	api.HandleFunc("/search/chain-suggestions", handlers.search.GetChainSuggestions).Methods("GET")
+	api.HandleFunc("/search/combined", handlers.search.CombinedSearch).Methods("GET")
+	api.HandleFunc("/search/trending", handlers.search.GetTrendingSuggestions).Methods("GET")
+	api.HandleFunc("/search/popular", handlers.search.GetPopularSearches).Methods("GET")
+	api.HandleFunc("/search/facets", handlers.search.GetFacets).Methods("GET")
+	api.HandleFunc("/facilities", handlers.search.GetFacilities).Methods("GET")
+
+	if cfg.EnableFavorites {
+		favoriteRoutes := api.PathPrefix("/favorites").Subrouter()
+		favoriteRoutes.Use(rateLimitMiddleware(10, time.Minute))
+		favoriteRoutes.HandleFunc("", handlers.hotel.ListFavorites).Methods("GET")
+		favoriteRoutes.HandleFunc("/{hotel_id}", handlers.hotel.AddFavorite).Methods("POST")
+		favoriteRoutes.HandleFunc("/{hotel_id}", handlers.hotel.RemoveFavorite).Methods("DELETE")
+	}
+
+	admin := api.PathPrefix("/admin").Subrouter()
+	admin.Use(noStoreMiddleware)
+	admin.HandleFunc("/hotels", handlers.admin.FindHotelsBySource).Methods("GET")
+	admin.HandleFunc("/hotels/duplicates", handlers.admin.FindDuplicateHotels).Methods("GET")
+	admin.HandleFunc("/hotels/merge", handlers.admin.MergeHotels).Methods("POST")
+	admin.HandleFunc("/hotels/{id}", handlers.admin.PatchHotel).Methods("PATCH")
+	admin.HandleFunc("/hotels/{id}/changes", handlers.admin.GetHotelChanges).Methods("GET")
+	admin.HandleFunc("/hotels/{id}/sources", handlers.admin.GetHotelSources).Methods("GET")
+	admin.HandleFunc("/facilities/unmapped", handlers.admin.GetUnmappedFacilities).Methods("GET")
+	admin.HandleFunc("/facilities/backfill", handlers.admin.TriggerFacilityBackfill).Methods("POST")
+	admin.HandleFunc("/facilities/backfill/{id}", handlers.admin.GetFacilityBackfillJob).Methods("GET")
+	admin.HandleFunc("/sync", handlers.admin.TriggerSync).Methods("POST")
+	admin.HandleFunc("/sync/stats", handlers.admin.GetSyncStats).Methods("GET")
+	admin.HandleFunc("/sync/history", handlers.admin.GetSyncHistory).Methods("GET")
+	admin.HandleFunc("/index/backfill", handlers.admin.TriggerIndexBackfill).Methods("POST")
+	admin.HandleFunc("/index/backfill/{id}", handlers.admin.GetIndexBackfillJob).Methods("GET")
+	admin.HandleFunc("/index/reconcile", handlers.admin.TriggerReconcileJob).Methods("POST")
+	admin.HandleFunc("/index/reconcile/{id}", handlers.admin.GetReconcileJob).Methods("GET")
+	admin.HandleFunc("/reconcile/diff", handlers.admin.GetReconcileDiff).Methods("GET")
+	admin.HandleFunc("/reconcile", handlers.admin.TriggerReconcile).Methods("POST")
+	admin.HandleFunc("/chains/{chain_name}/sync", handlers.admin.TriggerChainSync).Methods("POST")
+	admin.HandleFunc("/chains/{chain_name}/sync/{job_id}", handlers.admin.GetChainSyncProgress).Methods("GET")
+	admin.HandleFunc("/reviews/archive", handlers.admin.TriggerReviewArchival).Methods("POST")
+	admin.HandleFunc("/reviews/archive/{id}", handlers.admin.GetReviewArchiveJob).Methods("GET")
+	admin.HandleFunc("/reviews/dedupe", handlers.admin.TriggerReviewDedup).Methods("POST")
+	admin.HandleFunc("/reviews/dedupe/{id}", handlers.admin.GetReviewDedupJob).Methods("GET")
+	admin.HandleFunc("/usage", handlers.admin.GetUsage).Methods("GET")
+	admin.HandleFunc("/slow-requests", handlers.debug.GetSlowRequests).Methods("GET")
+	admin.HandleFunc("/search/config", handlers.admin.ExportSearchConfig).Methods("GET")
+	admin.HandleFunc("/search/config", handlers.admin.ApplySearchConfig).Methods("PUT")
+	admin.HandleFunc("/search/config/rollback", handlers.admin.RollbackSearchConfig).Methods("POST")
+
+	router.HandleFunc("/health", handlers.health.HealthCheck).Methods("GET")
+
+	debug := router.PathPrefix("/debug").Subrouter()
+	debug.Use(internalOnlyMiddleware, noStoreMiddleware)
+	debug.HandleFunc("/info", handlers.debug.GetDebugInfo).Methods("GET")
+	debug.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	if cfg.EnablePprof {
+		debug.HandleFunc("/pprof/cmdline", pprof.Cmdline)
+		debug.HandleFunc("/pprof/profile", pprof.Profile)
+		debug.HandleFunc("/pprof/symbol", pprof.Symbol)
+		debug.HandleFunc("/pprof/trace", pprof.Trace)
+		debug.PathPrefix("/pprof/").HandlerFunc(pprof.Index)
+	}
+
+	router.HandleFunc("/openapi.json", serveOpenAPI(apiSpec, logger)).Methods("GET")
+	router.HandleFunc("/swagger/token", swaggerTokenRedirect(cfg.AuthTokenURL)).Methods("GET")
+	router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
+		httpSwagger.URL("/openapi.json"),
+		httpSwagger.UIConfig(map[string]string{"persistAuthorization": "true"}),
+	))
+
+	router.Use(rateLimitMiddleware(100, time.Minute))
+	router.Use(concurrencyLimitMiddleware(cfg.MaxConcurrentRequests))
+	router.Use(loggingMiddleware(logger, cfg.SlowRequestThreshold, slowRequests))
+	if cfg.EnableCORS {
+		router.Use(corsMiddleware)
+	}
+	router.Use(usageMiddleware(usageCounter, usage.NewKeys(cfg.UsageAPIKeys)))
+	if cfg.ValidateRequests {
+		router.Use(handler.RequestValidationMiddleware(apiSpec, logger))
+	}
+
+	return router
+}
+
 func printRoutes(router *mux.Router, logger *slog.Logger) {
 	fmt.Println("API Routes Overview")
 	fmt.Println("═══════════════════════════════════════════════════════════════")
@@ -385,24 +1029,100 @@ func printRoutes(router *mux.Router, logger *slog.Logger) {
 		routeDesc := fmt.Sprintf("  %-8s %s", methodStr, pathTemplate)
 
 		switch {
+		case strings.Contains(pathTemplate, "/debug/metrics"):
+			routeDesc += " - Prometheus metrics"
 		case strings.Contains(pathTemplate, "/health"):
 			routeDesc += " - Health check endpoint"
 		case strings.Contains(pathTemplate, "/swagger"):
 			routeDesc += " - API documentation (Swagger UI)"
+		case strings.Contains(pathTemplate, "/openapi.json"):
+			routeDesc += " - OpenAPI 3 document"
+		case strings.Contains(pathTemplate, "/admin/search/config/rollback"):
+			routeDesc += " - Roll back search config"
+		case strings.Contains(pathTemplate, "/admin/search/config"):
+			routeDesc += " - Export or apply search config bundle"
+		case strings.Contains(pathTemplate, "/admin/slow-requests"):
+			routeDesc += " - List recent slow requests"
+		case strings.Contains(pathTemplate, "/hotels/new"):
+			routeDesc += " - List recently added hotels"
+		case strings.Contains(pathTemplate, "/hotels/compare"):
+			routeDesc += " - Compare hotels side by side"
+		case strings.Contains(pathTemplate, "/favorites/{hotel_id}"):
+			routeDesc += " - Add or remove a favorite hotel"
+		case strings.Contains(pathTemplate, "/favorites"):
+			routeDesc += " - List favorite hotels of the session"
+		case strings.Contains(pathTemplate, "/admin/facilities/unmapped"):
+			routeDesc += " - List facility names missing from the taxonomy"
+		case strings.Contains(pathTemplate, "/facilities"):
+			routeDesc += " - List canonical facilities with hotel counts"
+		case strings.Contains(pathTemplate, "/admin/hotels/duplicates"):
+			routeDesc += " - Find duplicate hotels"
+		case strings.Contains(pathTemplate, "/admin/hotels/merge"):
+			routeDesc += " - Merge a duplicate hotel into its primary"
+		case strings.Contains(pathTemplate, "/admin/hotels/{id}/sources"):
+			routeDesc += " - Sources of the hotel's field groups"
+		case strings.Contains(pathTemplate, "/admin/hotels/{id}/changes"):
+			routeDesc += " - Hotel field change history"
+		case strings.Contains(pathTemplate, "/admin/hotels/{id}"):
+			routeDesc += " - Correct hotel fields"
+		case strings.Contains(pathTemplate, "/admin/hotels"):
+			routeDesc += " - Find hotels by source id"
+		case strings.Contains(pathTemplate, "/admin/reviews/archive/{id}"):
+			routeDesc += " - Get review archive job status"
+		case strings.Contains(pathTemplate, "/admin/reviews/archive"):
+			routeDesc += " - Archive old reviews"
+		case strings.Contains(pathTemplate, "/admin/reviews/dedupe/{id}"):
+			routeDesc += " - Get review dedupe job status"
+		case strings.Contains(pathTemplate, "/admin/reviews/dedupe"):
+			routeDesc += " - Merge duplicate reviews"
+		case strings.Contains(pathTemplate, "/hotels/{id}/reviews/stats"):
+			routeDesc += " - Get hotel review score statistics"
+		case strings.Contains(pathTemplate, "/hotels/{id}/reviews"):
+			routeDesc += " - List hotel reviews"
+		case strings.Contains(pathTemplate, "/hotels/{id}/translations/{lang}"):
+			routeDesc += " - Get hotel localized to a language"
+		case strings.Contains(pathTemplate, "/hotels/{id}/translations"):
+			routeDesc += " - List hotel translations"
 		case strings.Contains(pathTemplate, "/hotels/{id}"):
 			routeDesc += " - Get specific hotel by ID"
 		case strings.Contains(pathTemplate, "/search/hotels"):
 			routeDesc += " - Search hotels with filters"
+		case strings.Contains(pathTemplate, "/search/chain-suggestions"):
+			routeDesc += " - Get hotel chain suggestions"
 		case strings.Contains(pathTemplate, "/search/suggestions"):
 			routeDesc += " - Get hotel search suggestions"
+		case strings.Contains(pathTemplate, "/search/combined"):
+			routeDesc += " - Search hotels and get suggestions in one call"
 		case strings.Contains(pathTemplate, "/search/trending"):
 			routeDesc += " - Get trending hotel suggestions"
+		case strings.Contains(pathTemplate, "/search/popular"):
+			routeDesc += " - Get popular searches"
 		case strings.Contains(pathTemplate, "/search/facets"):
 			routeDesc += " - Get search facets for filtering"
-		case strings.Contains(pathTemplate, "/admin/sync"):
-			routeDesc += " - Trigger hotel data synchronization"
+		case strings.Contains(pathTemplate, "/admin/index/reconcile/{id}"):
+			routeDesc += " - Get index reconciliation job status"
+		case strings.Contains(pathTemplate, "/admin/index/reconcile"):
+			routeDesc += " - Start index reconciliation job"
+		case strings.Contains(pathTemplate, "/admin/index/backfill/{id}"):
+			routeDesc += " - Get index backfill job status"
+		case strings.Contains(pathTemplate, "/admin/index/backfill"):
+			routeDesc += " - Backfill search index fields"
+		case strings.Contains(pathTemplate, "/admin/chains/{chain_name}/sync/{job_id}"):
+			routeDesc += " - Get chain sync progress"
+		case strings.Contains(pathTemplate, "/admin/chains/{chain_name}/sync"):
+			routeDesc += " - Re-sync all hotels of a chain"
+		case strings.Contains(pathTemplate, "/admin/reconcile/diff"):
+			routeDesc += " - Preview search index reconciliation"
+		case strings.Contains(pathTemplate, "/admin/reconcile"):
+			routeDesc += " - Reconcile search index with the database"
+		case strings.Contains(pathTemplate, "/admin/usage"):
+			routeDesc += " - Get API usage per client"
+		case strings.Contains(pathTemplate, "/admin/sync/history"):
+			routeDesc += " - Get synchronization history and trends"
 		case strings.Contains(pathTemplate, "/admin/sync/stats"):
 			routeDesc += " - Get synchronization statistics"
+		case strings.Contains(pathTemplate, "/admin/sync"):
+			routeDesc += " - Trigger hotel data synchronization"
 		default:
 			routeDesc += " - API endpoint"
 		}
@@ -425,32 +1145,123 @@ func printRoutes(router *mux.Router, logger *slog.Logger) {
 	fmt.Println("Visit /swagger/ for interactive API documentation")
 }
 
-func loggingMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
+// loggingMiddleware logs every request. Requests slower than slowThreshold are also logged at
+// WARN with the start of their response body and recorded in slowRequests.
+func loggingMiddleware(logger *slog.Logger, slowThreshold time.Duration, slowRequests *handler.SlowRequestLog) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
-			wrapped := &responseWriter{ResponseWriter: w, statusCode: 200}
+			requestID := r.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: 200, start: start, slowThreshold: slowThreshold}
 
 			next.ServeHTTP(wrapped, r)
 
+			duration := time.Since(start)
 			logger.Info("HTTP request",
+				"request_id", requestID,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"remote_addr", r.RemoteAddr,
 				"user_agent", r.UserAgent(),
 				"status_code", wrapped.statusCode,
-				"duration", time.Since(start),
+				"duration", duration,
+			)
+
+			if duration <= slowThreshold {
+				return
+			}
+
+			timeToHeader := duration
+			if !wrapped.headerWrittenAt.IsZero() {
+				timeToHeader = wrapped.headerWrittenAt.Sub(start)
+			}
+			slowRequest := handler.SlowRequest{
+				RequestID:             requestID,
+				Method:                r.Method,
+				Path:                  r.URL.Path,
+				Query:                 r.URL.RawQuery,
+				UserAgent:             r.UserAgent(),
+				StatusCode:            wrapped.statusCode,
+				RecordedAt:            time.Now().UTC(),
+				DurationMs:            duration.Milliseconds(),
+				TimeToHeaderMs:        timeToHeader.Milliseconds(),
+				WriteBodyMs:           (duration - timeToHeader).Milliseconds(),
+				ResponseBody:          string(wrapped.body),
+				ResponseBodyTruncated: wrapped.bodyTruncated,
+			}
+			slowRequests.Record(slowRequest)
+
+			logger.Warn("Slow HTTP request",
+				"request_id", requestID,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"query", r.URL.RawQuery,
+				"user_agent", r.UserAgent(),
+				"status_code", wrapped.statusCode,
+				"duration", duration,
+				"time_to_header", timeToHeader,
+				"write_body", duration-timeToHeader,
+				"response_body", slowRequest.ResponseBody,
+				"response_body_truncated", slowRequest.ResponseBodyTruncated,
 			)
 		})
 	}
 }
 
+func newRequestID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// serveOpenAPI serves the OpenAPI 3 document converted from the generated Swagger document.
+func serveOpenAPI(spec *openapi.Spec, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := spec.JSON()
+		if err != nil {
+			logger.Error("Failed to encode OpenAPI document", "error", err)
+			http.Error(w, "failed to encode OpenAPI document", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}
+}
+
+// Example credentials the token form of the Swagger UI is pre-filled with.
+const (
+	swaggerExampleUsername = "admin"
+	swaggerExamplePassword = "admin"
+)
+
+// swaggerTokenRedirect sends Swagger UI users to the token endpoint, pre-filled with example
+// credentials, to get the Bearer token admin routes require.
+func swaggerTokenRedirect(tokenURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target, err := url.Parse(tokenURL)
+		if err != nil {
+			http.Error(w, "invalid token endpoint", http.StatusInternalServerError)
+			return
+		}
+		query := target.Query()
+		query.Set("username", swaggerExampleUsername)
+		query.Set("password", swaggerExamplePassword)
+		target.RawQuery = query.Encode()
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -522,12 +1333,149 @@ func rateLimitMiddleware(maxRequests int, window time.Duration) mux.MiddlewareFu
 	}
 }
 
+// concurrencyLimitMiddleware caps the number of requests served at once. The rate limiter bounds
+// requests per window, not simultaneous ones, so a burst from a single client could otherwise
+// tie up every goroutine. Requests over the limit are rejected immediately instead of queued.
+func concurrencyLimitMiddleware(maxConcurrent int) mux.MiddlewareFunc {
+	semaphore := make(chan struct{}, maxConcurrent)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case semaphore <- struct{}{}:
+			default:
+				httpRequestsRejected.Inc()
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error":"Too many concurrent requests"}`))
+				return
+			}
+			defer func() { <-semaphore }()
+
+			httpRequestsInFlight.Inc()
+			defer httpRequestsInFlight.Dec()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// noStoreMiddleware keeps the admin and debug responses out of every cache, including those
+// written by handlers that do not set a cache policy of their own.
+func noStoreMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", handler.NoStore.String())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// internalOnlyMiddleware rejects requests that do not originate from a loopback or private
+// address. X-Forwarded-For is deliberately ignored so the check cannot be bypassed by a header.
+func internalOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil || !(ip.IsLoopback() || ip.IsPrivate()) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"Forbidden"}`))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// usageMiddleware counts API requests per client, endpoint and day. The API key is read from
+// X-API-Key or a bearer token; requests without one, or with a key not in keys, are counted as
+// anonymous. Counting only enqueues the event, the counter writes to Redis in the background.
+func usageMiddleware(counter usage.Counter, keys usage.Keys) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			wrapped := &usageResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(wrapped, r)
+
+			endpoint := r.URL.Path
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					endpoint = template
+				}
+			}
+
+			counter.Record(usage.Event{
+				ClientID: keys.ClientID(requestAPIKey(r)),
+				Endpoint: r.Method + " " + endpoint,
+				Day:      usage.Day(time.Now()),
+				Error:    wrapped.statusCode >= http.StatusBadRequest,
+				Results:  wrapped.results,
+			})
+		})
+	}
+}
+
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+type usageResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	results    int64
+}
+
+func (w *usageResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *usageResponseWriter) RecordResults(n int64) {
+	w.results += n
+}
+
+// responseWriter records the status code and when the headers were written. Body writes made
+// once the request has run longer than slowThreshold are buffered, up to maxSlowResponseBody
+// bytes, so fast requests never copy their body.
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+
+	start           time.Time
+	slowThreshold   time.Duration
+	headerWrittenAt time.Time
+	body            []byte
+	bodyTruncated   bool
 }
 
 func (w *responseWriter) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
+	w.headerWrittenAt = time.Now()
 	w.ResponseWriter.WriteHeader(statusCode)
 }
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.headerWrittenAt.IsZero() {
+		w.headerWrittenAt = time.Now()
+	}
+	if time.Since(w.start) > w.slowThreshold && !w.bodyTruncated {
+		room := maxSlowResponseBody - len(w.body)
+		w.body = append(w.body, b[:min(len(b), room)]...)
+		w.bodyTruncated = len(b) > room
+	}
+	return w.ResponseWriter.Write(b)
+}
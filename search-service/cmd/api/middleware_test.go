@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimitMiddlewareRejectsWhenSlotsAreTaken(t *testing.T) {
+	const slots = 2
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	limited := concurrencyLimitMiddleware(slots)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var wg sync.WaitGroup
+	for range slots {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder := httptest.NewRecorder()
+			limited.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/slow", nil))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		}()
+		<-entered
+	}
+	assert.Equal(t, float64(slots), testutil.ToFloat64(httpRequestsInFlight))
+
+	rejectedBefore := testutil.ToFloat64(httpRequestsRejected)
+	recorder := httptest.NewRecorder()
+	limited.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Too many concurrent requests"}`, recorder.Body.String())
+	assert.Equal(t, rejectedBefore+1, testutil.ToFloat64(httpRequestsRejected))
+
+	close(release)
+	wg.Wait()
+	assert.Zero(t, testutil.ToFloat64(httpRequestsInFlight))
+
+	recorder = httptest.NewRecorder()
+	limited.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
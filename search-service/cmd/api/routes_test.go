@@ -0,0 +1,152 @@
+package main
+
+import (
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/config"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/handler"
+)
+
+// expectedRoutes is the full route table with favorites enabled and pprof disabled.
+var expectedRoutes = map[string]string{
+	"GET /api/v1/hotels/compare":                          "HotelHandler.CompareHotels",
+	"GET /api/v1/hotels/new":                              "SearchHandler.GetNewHotels",
+	"GET /api/v1/hotels/{id}":                             "HotelHandler.GetHotelByID",
+	"GET /api/v1/hotels/{id}/reviews":                     "HotelHandler.GetHotelReviews",
+	"GET /api/v1/hotels/{id}/reviews/stats":               "HotelHandler.GetHotelReviewStats",
+	"GET /api/v1/hotels/{id}/translations":                "HotelHandler.GetHotelTranslations",
+	"GET /api/v1/hotels/{id}/translations/{lang}":         "HotelHandler.GetHotelTranslation",
+	"GET /api/v1/search/hotels":                           "SearchHandler.SearchHotels",
+	"GET /api/v1/search/suggestions":                      "SearchHandler.GetHotelSuggestions",
+	"GET /api/v1/search/chain-suggestions":                "SearchHandler.GetChainSuggestions",
+	"GET /api/v1/search/combined":                         "SearchHandler.CombinedSearch",
+	"GET /api/v1/search/trending":                         "SearchHandler.GetTrendingSuggestions",
+	"GET /api/v1/search/popular":                          "SearchHandler.GetPopularSearches",
+	"GET /api/v1/search/facets":                           "SearchHandler.GetFacets",
+	"GET /api/v1/facilities":                              "SearchHandler.GetFacilities",
+	"GET /api/v1/favorites":                               "HotelHandler.ListFavorites",
+	"POST /api/v1/favorites/{hotel_id}":                   "HotelHandler.AddFavorite",
+	"DELETE /api/v1/favorites/{hotel_id}":                 "HotelHandler.RemoveFavorite",
+	"GET /api/v1/admin/hotels":                            "AdminHandler.FindHotelsBySource",
+	"GET /api/v1/admin/hotels/duplicates":                 "AdminHandler.FindDuplicateHotels",
+	"POST /api/v1/admin/hotels/merge":                     "AdminHandler.MergeHotels",
+	"PATCH /api/v1/admin/hotels/{id}":                     "AdminHandler.PatchHotel",
+	"GET /api/v1/admin/hotels/{id}/changes":               "AdminHandler.GetHotelChanges",
+	"GET /api/v1/admin/hotels/{id}/sources":               "AdminHandler.GetHotelSources",
+	"GET /api/v1/admin/facilities/unmapped":               "AdminHandler.GetUnmappedFacilities",
+	"POST /api/v1/admin/facilities/backfill":              "AdminHandler.TriggerFacilityBackfill",
+	"GET /api/v1/admin/facilities/backfill/{id}":          "AdminHandler.GetFacilityBackfillJob",
+	"POST /api/v1/admin/sync":                             "AdminHandler.TriggerSync",
+	"GET /api/v1/admin/sync/stats":                        "AdminHandler.GetSyncStats",
+	"GET /api/v1/admin/sync/history":                      "AdminHandler.GetSyncHistory",
+	"POST /api/v1/admin/index/backfill":                   "AdminHandler.TriggerIndexBackfill",
+	"GET /api/v1/admin/index/backfill/{id}":               "AdminHandler.GetIndexBackfillJob",
+	"POST /api/v1/admin/index/reconcile":                  "AdminHandler.TriggerReconcileJob",
+	"GET /api/v1/admin/index/reconcile/{id}":              "AdminHandler.GetReconcileJob",
+	"GET /api/v1/admin/reconcile/diff":                    "AdminHandler.GetReconcileDiff",
+	"POST /api/v1/admin/reconcile":                        "AdminHandler.TriggerReconcile",
+	"POST /api/v1/admin/chains/{chain_name}/sync":         "AdminHandler.TriggerChainSync",
+	"GET /api/v1/admin/chains/{chain_name}/sync/{job_id}": "AdminHandler.GetChainSyncProgress",
+	"POST /api/v1/admin/reviews/archive":                  "AdminHandler.TriggerReviewArchival",
+	"GET /api/v1/admin/reviews/archive/{id}":              "AdminHandler.GetReviewArchiveJob",
+	"POST /api/v1/admin/reviews/dedupe":                   "AdminHandler.TriggerReviewDedup",
+	"GET /api/v1/admin/reviews/dedupe/{id}":               "AdminHandler.GetReviewDedupJob",
+	"GET /api/v1/admin/usage":                             "AdminHandler.GetUsage",
+	"GET /api/v1/admin/slow-requests":                     "DebugHandler.GetSlowRequests",
+	"GET /api/v1/admin/search/config":                     "AdminHandler.ExportSearchConfig",
+	"PUT /api/v1/admin/search/config":                     "AdminHandler.ApplySearchConfig",
+	"POST /api/v1/admin/search/config/rollback":           "AdminHandler.RollbackSearchConfig",
+	"GET /health":                                         "HealthHandler.HealthCheck",
+	"GET /debug/info":                                     "DebugHandler.GetDebugInfo",
+	"GET /debug/metrics":                                  "promhttp.InstrumentHandlerCounter",
+	"GET /openapi.json":                                   "serveOpenAPI",
+	"GET /swagger/token":                                  "swaggerTokenRedirect",
+	"ALL /swagger/":                                       "http-swagger.Handler",
+}
+
+// handlerIdentity names the function serving route, e.g. HotelHandler.GetHotelByID for a
+// handler method or serveOpenAPI for a handler built by a function of this package.
+func handlerIdentity(route *mux.Route) string {
+	h := route.GetHandler()
+	if h == nil {
+		return ""
+	}
+	if reflect.TypeOf(h).Kind() != reflect.Func {
+		return reflect.TypeOf(h).String()
+	}
+	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.NewReplacer("(*", "", ")", "", "-fm", "").Replace(name)
+	if prefix, _, found := strings.Cut(name, ".func"); found {
+		name = prefix
+	}
+	for _, prefix := range []string{"handler.", "api."} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+func routeTable(t *testing.T, router *mux.Router) map[string]string {
+	t.Helper()
+	table := make(map[string]string)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+		path, err := route.GetPathTemplate()
+		require.NoError(t, err)
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{"ALL"}
+		}
+		for _, method := range methods {
+			key := method + " " + path
+			assert.NotContains(t, table, key, "route registered twice")
+			table[key] = handlerIdentity(route)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return table
+}
+
+func newTestRouter(cfg config.ServerConfig) *mux.Router {
+	handlers := routeHandlers{
+		hotel:  &handler.HotelHandler{},
+		search: &handler.SearchHandler{},
+		admin:  &handler.AdminHandler{},
+		health: &handler.HealthHandler{},
+		debug:  &handler.DebugHandler{},
+	}
+	return newRouter(cfg, handlers, nil, nil, nil, slog.New(slog.DiscardHandler))
+}
+
+func TestRouterRegistersEveryRoute(t *testing.T) {
+	table := routeTable(t, newTestRouter(config.ServerConfig{EnableFavorites: true}))
+
+	assert.Equal(t, expectedRoutes, table)
+}
+
+func TestRouterRegistersOptionalRoutesOnlyWhenEnabled(t *testing.T) {
+	table := routeTable(t, newTestRouter(config.ServerConfig{}))
+	for key := range expectedRoutes {
+		if strings.Contains(key, "/favorites") {
+			assert.NotContains(t, table, key)
+		} else {
+			assert.Contains(t, table, key)
+		}
+	}
+
+	table = routeTable(t, newTestRouter(config.ServerConfig{EnableFavorites: true, EnablePprof: true}))
+	for _, key := range []string{"ALL /debug/pprof/cmdline", "ALL /debug/pprof/profile", "ALL /debug/pprof/symbol", "ALL /debug/pprof/trace", "ALL /debug/pprof/"} {
+		assert.Contains(t, table, key)
+	}
+	assert.Len(t, table, len(expectedRoutes)+5)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerTokenRedirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokenURL string
+		want     string
+	}{
+		{"relative endpoint", "/auth/token", "/auth/token?password=admin&username=admin"},
+		{"auth service", "https://auth.example.com/auth/token", "https://auth.example.com/auth/token?password=admin&username=admin"},
+		{"endpoint with query", "/auth/token?grant_type=password", "/auth/token?grant_type=password&password=admin&username=admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			swaggerTokenRedirect(tt.tokenURL)(recorder, httptest.NewRequest(http.MethodGet, "/swagger/token", nil))
+
+			assert.Equal(t, http.StatusFound, recorder.Code)
+			assert.Equal(t, tt.want, recorder.Header().Get("Location"))
+		})
+	}
+}
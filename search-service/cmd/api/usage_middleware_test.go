@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+// recordingCounter keeps the recorded events in memory.
+type recordingCounter struct {
+	mu     sync.Mutex
+	events []usage.Event
+}
+
+func (c *recordingCounter) Record(event usage.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+func (c *recordingCounter) Collect(context.Context, time.Time) ([]usage.DailyUsage, error) {
+	return nil, nil
+}
+
+func newUsageTestRouter(counter usage.Counter, keys usage.Keys) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/hotels/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] == "0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.(usage.ResultRecorder).RecordResults(1)
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+	router.Use(usageMiddleware(counter, keys))
+	return router
+}
+
+func TestUsageMiddlewareCountsVerifiedKeys(t *testing.T) {
+	counter := &recordingCounter{}
+	router := newUsageTestRouter(counter, usage.NewKeys([]string{"partner-key"}))
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/hotels/7", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/hotels/0", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/hotels/8", nil),
+		httptest.NewRequest(http.MethodGet, "/health", nil),
+	}
+	requests[0].Header.Set("X-API-Key", "partner-key")
+	requests[1].Header.Set("Authorization", "Bearer partner-key")
+	for _, request := range requests {
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	day := usage.Day(time.Now())
+	require.Len(t, counter.events, 3, "only API requests are counted")
+	assert.Equal(t, usage.Event{ClientID: usage.ClientID("partner-key"), Endpoint: "GET /api/v1/hotels/{id}", Day: day, Results: 1}, counter.events[0])
+	assert.Equal(t, usage.Event{ClientID: usage.ClientID("partner-key"), Endpoint: "GET /api/v1/hotels/{id}", Day: day, Error: true}, counter.events[1])
+	assert.Equal(t, usage.Event{ClientID: usage.AnonymousClient, Endpoint: "GET /api/v1/hotels/{id}", Day: day, Results: 1}, counter.events[2])
+}
+
+func TestUsageMiddlewareCountsUnverifiedKeysAsAnonymous(t *testing.T) {
+	counter := &recordingCounter{}
+	router := newUsageTestRouter(counter, usage.NewKeys([]string{"partner-key"}))
+
+	for i := range 100 {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/hotels/7", nil)
+		request.Header.Set("X-API-Key", fmt.Sprintf("garbage-%d", i))
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	clientIDs := make(map[string]int)
+	for _, event := range counter.events {
+		clientIDs[event.ClientID]++
+	}
+	assert.Equal(t, map[string]int{usage.AnonymousClient: 100}, clientIDs, "made-up keys do not create counters")
+}
@@ -0,0 +1,50 @@
+// Command openapi writes the OpenAPI 3 document of the search API, converted from the Swagger
+// document swag generates, to docs/openapi.json. With -check it instead fails when the committed
+// document differs from the generated one, so that CI catches API changes left out of the
+// snapshot.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/victoragudo/hotel-management-system/search-service/docs"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/openapi"
+)
+
+func main() {
+	output := flag.String("o", "docs/openapi.json", "path of the OpenAPI document")
+	check := flag.Bool("check", false, "fail when the document at -o is out of date instead of writing it")
+	flag.Parse()
+
+	if err := run(*output, *check); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, check bool) error {
+	spec, err := openapi.Load(docs.SwaggerInfo.ReadDoc())
+	if err != nil {
+		return err
+	}
+	generated, err := spec.JSON()
+	if err != nil {
+		return err
+	}
+
+	if !check {
+		return os.WriteFile(output, generated, 0o644)
+	}
+
+	committed, err := os.ReadFile(output)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", output, err)
+	}
+	if !bytes.Equal(committed, generated) {
+		return fmt.Errorf("%s is out of date, run make swagger-gen and commit the result", output)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommittedDocumentIsUpToDate fails when docs/openapi.json no longer matches the document
+// generated from the committed swag output, so that API changes reach review.
+func TestCommittedDocumentIsUpToDate(t *testing.T) {
+	assert.NoError(t, run("../../docs/openapi.json", true))
+}
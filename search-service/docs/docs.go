@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/admin/sync": {
+        "/api/v1/admin/chains/{chain_name}/sync": {
             "post": {
-                "description": "Manually trigger synchronization of hotel data from external sources",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start a background job that re-indexes every active hotel belonging to the given chain and return its job ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -36,8 +41,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Trigger manual sync",
+                "summary": "Trigger chain sync",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chain name",
+                        "name": "chain_name",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "Synchronization options",
                         "name": "options",
@@ -49,7 +61,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Synchronization result with statistics",
+                        "description": "Chain sync job created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncProgress"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -63,9 +93,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/sync/stats": {
+        "/api/v1/admin/chains/{chain_name}/sync/{job_id}": {
             "get": {
-                "description": "Get current statistics about hotel data synchronization including index size, document count, and last update time",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the status and progress of a chain sync job",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,10 +110,44 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get sync statistics",
+                "summary": "Get chain sync progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chain name",
+                        "name": "chain_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sync job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Synchronization statistics",
+                        "description": "Chain sync progress",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncProgress"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -92,9 +161,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/hotels/{id}": {
-            "get": {
-                "description": "Get detailed information about a specific hotel by its ID with optional reviews limit",
+        "/api/v1/admin/facilities/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start a background job that sets the canonical slug of every stored facility and the hotels' amenities from the facility taxonomy, and updates the amenities of the search index. Run it after deploying the taxonomy or changing facility_aliases so /api/v1/facilities and the amenities filter see every hotel without waiting for it to be refetched. The job resumes from its last position unless restart is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -102,40 +176,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hotels"
+                    "admin"
                 ],
-                "summary": "Get hotel by ID",
+                "summary": "Start facility backfill",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Hotel ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Limit the number of reviews to return",
-                        "name": "reviewsLimit",
-                        "in": "query"
+                        "description": "Backfill options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.FacilityBackfillOptions"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Hotel details",
+                        "description": "Facility backfill job created",
                         "schema": {
-                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.FacilityBackfillJob"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid parameters",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found - Hotel not found",
+                    "409": {
+                        "description": "Facility backfill is already running",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -149,22 +229,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search/facets": {
+        "/api/v1/admin/facilities/backfill/{id}": {
             "get": {
-                "description": "Get available facets for filtering hotel search results (cities, countries, star ratings, amenities, etc.)",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Get the status and progress of a facility backfill job",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "admin"
+                ],
+                "summary": "Get facility backfill job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Facility backfill job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get search facets",
                 "responses": {
                     "200": {
-                        "description": "Available search facets with counts",
+                        "description": "Facility backfill job status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.FacilityBackfillJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -178,9 +287,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search/hotels": {
+        "/api/v1/admin/facilities/unmapped": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the facility names received from Cupid that no facility alias maps to a canonical facility, with the slug each falls through to and its hotel count, most common first. Add them to facility_aliases to merge them into a canonical facility",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List unmapped facilities",
+                "responses": {
+                    "200": {
+                        "description": "Unmapped facility names",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UnmappedFacility"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/hotels": {
             "get": {
-                "description": "Search for hotels using various filters like query, location, rating, price range, amenities, etc.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Find the hotels mapped to the given hotel id of an external data source, e.g. source=cupid\u0026source_id=12345",
                 "consumes": [
                     "application/json"
                 ],
@@ -188,148 +348,183 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "admin"
                 ],
-                "summary": "Search hotels",
+                "summary": "Find hotels by source id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query (hotel name, description, etc.)",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "City filter",
-                        "name": "city",
-                        "in": "query"
+                        "description": "Data source name (e.g. cupid, booking)",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Country filter",
-                        "name": "country",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Minimum rating (0-5)",
-                        "name": "rating_min",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Maximum rating (0-5)",
-                        "name": "rating_max",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Star rating filter (1-5)",
-                        "name": "star_rating",
-                        "in": "query"
+                        "description": "Hotel id in the data source",
+                        "name": "source_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching hotels",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
                     },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Amenities filter (comma-separated)",
-                        "name": "amenities",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request - Missing source or source_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/hotels/duplicates": {
+            "get": {
+                "security": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Tags filter (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
-                    },
+                        "Bearer": []
+                    }
+                ],
+                "description": "Find active hotels imported more than once under different ids: hotels within max_distance_km of each other whose names have at least name_similarity trigram similarity. Pairs sharing a hotel form one group. Confidence is the weakest pair's name similarity, discounted by up to half with distance. Merge a group with POST /api/v1/admin/hotels/merge",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Find duplicate hotels",
+                "parameters": [
                     {
+                        "maximum": 1,
+                        "minimum": 0,
                         "type": "number",
-                        "description": "Minimum price",
-                        "name": "price_min",
+                        "description": "Lowest name similarity, from 0 to 1 (default: 0.6)",
+                        "name": "name_similarity",
                         "in": "query"
                     },
                     {
+                        "maximum": 50,
+                        "minimum": 0,
                         "type": "number",
-                        "description": "Maximum price",
-                        "name": "price_max",
+                        "description": "Largest distance between the hotels in km (default: 0.5)",
+                        "name": "max_distance_km",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Currency code (e.g., USD, EUR)",
-                        "name": "currency",
+                        "type": "boolean",
+                        "description": "Only pair hotels of the same chain",
+                        "name": "same_chain_only",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duplicate groups, most confident first",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.DuplicateGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "enum": [
-                            "rating",
-                            "price",
-                            "distance",
-                            "relevance",
-                            "name",
-                            "created_at"
-                        ],
-                        "type": "string",
-                        "description": "Sort field: rating, price, distance, relevance, name, created_at",
-                        "name": "sort_by",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request - Invalid criteria",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/hotels/merge": {
+            "post": {
+                "security": [
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "Sort order: asc, desc",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
+                        "Bearer": []
+                    }
+                ],
+                "description": "Move the reviews and translations of duplicate_id to primary_id and soft-delete duplicate_id. Translations in a language the primary already has are deleted with the duplicate, and the review_count of the primary is recounted from its stored reviews. The duplicate is removed from the search index and the primary reindexed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Merge duplicate hotels",
+                "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Hotel ID to keep",
+                        "name": "primary_id",
+                        "in": "query",
+                        "required": true
                     },
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Items per page (default: 20, max: 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Latitude for location-based search",
-                        "name": "latitude",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Longitude for location-based search",
-                        "name": "longitude",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Search radius in kilometers",
-                        "name": "radius",
-                        "in": "query"
+                        "description": "Hotel ID to merge into the primary and delete",
+                        "name": "duplicate_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Search results with pagination metadata",
+                        "description": "Merge result",
                         "schema": {
-                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.MergeResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid parameters",
+                        "description": "Bad Request - Missing or identical hotel IDs",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -343,43 +538,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search/suggestions": {
-            "get": {
-                "description": "Get autocomplete suggestions for hotel search based on partial query input",
+        "/api/v1/admin/hotels/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch to a hotel. Only name, description, rating, star_rating, parking, child_allowed and pets_allowed can be changed; hotel_id and cupid_id are rejected. The database, cache and search index are updated, and the old and new value of each changed field is recorded in the hotel's change history with the caller's address",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "admin"
                 ],
-                "summary": "Get hotel search suggestions",
+                "summary": "Correct hotel fields",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Search query for suggestions",
-                        "name": "q",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Maximum number of suggestions to return (default: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Patch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of search suggestions",
+                        "description": "Patched hotel and changed fields",
                         "schema": {
-                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UpdateHotelResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Query parameter is required",
+                        "description": "Bad Request - Invalid or disallowed fields",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
                         }
@@ -393,30 +620,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search/trending": {
+        "/api/v1/admin/hotels/{id}/changes": {
             "get": {
-                "description": "Get currently trending hotel search suggestions based on popular searches",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "List the tracked fields (rating, stars, name, status, child/pets flags, parking) that worker updates changed for a hotel, and the fields corrected by hand, most recent first, with the old and new value of each field and the message or caller that caused the change",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "admin"
                 ],
-                "summary": "Get trending search suggestions",
+                "summary": "List hotel changes",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Maximum number of trending suggestions to return (default: 10)",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Changes per page (max: 100, default: 20)",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of trending search suggestions",
+                        "description": "Hotel changes",
                         "schema": {
                             "allOf": [
                                 {
@@ -428,14 +670,23 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Suggestion"
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Change"
                                             }
+                                        },
+                                        "meta": {
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -445,22 +696,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/v1/admin/hotels/{id}/sources": {
             "get": {
-                "description": "Get the current health status of the search service",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Attribute the core, photos, policies and rooms field groups of a hotel to the source that last wrote them (import, fallback or cupid_fetcher) and when. A source does not overwrite a group written by a higher-priority source, as ranked by source_priorities. Hotels stored before sources were recorded return no groups",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Get hotel data sources",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "Service health status with timestamp and version",
+                        "description": "Hotel data sources",
                         "schema": {
                             "allOf": [
                                 {
@@ -470,55 +732,4553 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.HotelSources"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncOptions": {
-            "type": "object",
-            "properties": {
-                "batchSize": {
-                    "type": "integer"
-                },
-                "clearIndexFirst": {
-                    "type": "boolean"
-                },
-                "fullSync": {
-                    "type": "boolean"
-                },
-                "sinceTimestamp": {
-                    "type": "string"
-                },
-                "updateCacheAfter": {
-                    "type": "boolean"
-                }
-            }
         },
-        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Suggestion": {
-            "type": "object",
-            "properties": {
-                "hotel_id": {
-                    "type": "integer"
-                },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "score": {
-                    "type": "number"
-                },
-                "text": {
-                    "type": "string"
-                },
-                "type": {
+        "/api/v1/admin/index/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start an asynchronous job that repopulates the given fields on existing search documents from the database using partial updates. The job resumes from the last processed hotel_id unless restart is set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Backfill search index fields",
+                "parameters": [
+                    {
+                        "description": "Fields to backfill",
+                        "name": "options",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.BackfillOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backfill job created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/index/backfill/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the status and progress of an index backfill job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get backfill job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backfill job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backfill job status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/index/reconcile": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start an asynchronous job that streams hotel_id and updated_at from the database and the search index, merges them in hotel_id order and counts missing, stale and orphaned documents. With repair=true missing and stale hotels are reindexed and orphaned documents deleted; otherwise the job only reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start index reconciliation job",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Apply the fixes instead of only reporting them",
+                        "name": "repair",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation job created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/index/reconcile/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the status, progress and findings of an index reconciliation job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get index reconciliation job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reconciliation job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation job status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reconcile": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Run the index reconciliation of POST /api/v1/admin/index/reconcile?repair=true synchronously: missing and stale hotels are reindexed and orphaned documents deleted. The report counts the reindexed, deleted and failed hotels",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reconcile search index",
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReconcileJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reconcile/diff": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Run the index reconciliation of POST /api/v1/admin/index/reconcile synchronously without changing anything, and return its report: the missing, stale and orphaned counts with a sample of hotel_ids for each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Preview index reconciliation",
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReconcileJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reviews/archive": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start an asynchronous job that moves reviews past the newest keep_newest of each hotel and older than min_age_days into the reviews archive. Omitted options use the configured retention. The job resumes from the last processed hotel_id unless restart is set, and running it again archives nothing new",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Archive old reviews",
+                "parameters": [
+                    {
+                        "description": "Retention overrides",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewArchiveOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review archive job created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewArchiveJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Review archival is already running",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reviews/archive/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the status and progress of a review archive job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get review archive job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review archive job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review archive job status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewArchiveJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reviews/dedupe": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start an asynchronous maintenance job that fingerprints reviews stored without one and merges the reviews of a hotel with the same fingerprint: the oldest is kept, the others are soft-deleted and the review_count of the hotel is recounted from its stored reviews. The job resumes from the last processed hotel_id unless restart is set, and running it again merges nothing new",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Merge duplicate reviews",
+                "parameters": [
+                    {
+                        "description": "Dedupe options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewDedupOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review dedupe job created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewDedupJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Review dedupe is already running",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/reviews/dedupe/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the status and progress of a review dedupe job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get review dedupe job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review dedupe job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review dedupe job status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewDedupJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/search/config": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Export the active synonyms, ranking profiles and facet settings as one bundle. The version is a hash of the content, so environments with the same configuration report the same version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export search config",
+                "responses": {
+                    "200": {
+                        "description": "Active search config bundle",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ConfigBundle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Validate and apply a search config bundle as a whole: nothing is changed when any part is invalid, and applied parts are reverted when a later step fails. The replaced bundle is kept for rollback. The version in the body is ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Apply search config",
+                "parameters": [
+                    {
+                        "description": "Search config bundle",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ConfigBundle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Applied search config bundle",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ConfigBundle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid bundle",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/search/config/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Re-apply the search config bundle replaced by the last apply or rollback",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Roll back search config",
+                "responses": {
+                    "200": {
+                        "description": "Restored search config bundle",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ConfigBundle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - No previous bundle",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/slow-requests": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the most recent requests (up to 100) that took longer than server.slow_request_threshold, most recent first, with their duration breakdown and the start of their response body. Kept in memory by each instance, so only this instance's requests since it started are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List slow requests",
+                "responses": {
+                    "200": {
+                        "description": "Slow requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_infrastructure_handler.SlowRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/sync": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Manually trigger synchronization of hotel data from external sources. When a newer sync starts before this one finishes, this sync stops before its next batch and the result is returned with Superseded set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Trigger manual sync",
+                "parameters": [
+                    {
+                        "description": "Synchronization options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Synchronization result with statistics",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/sync/history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the recorded syncs (initial, periodic and manual) started in the last days, most recent first, with the totals, failed counts and error summaries of each. The summary aggregates every sync of the window: run count, average duration and failure rate, where a run fails when it errors or fails to index a hotel",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get sync history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Window in days (default and max: sync.history_retention_days)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Runs per page (max: 100, default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync history",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncHistory"
+                                        },
+                                        "meta": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/sync/stats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get current statistics about hotel data synchronization: index document count, newest indexed document, PostgreSQL table counts and the lag between them, and a summary of the last recorded sync. Counts are cached for 30 seconds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get sync statistics",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Use planner row estimates instead of exact counts",
+                        "name": "estimate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Synchronization statistics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/usage": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the daily request, error and result counts of an API client per endpoint, rolled up nightly from live counters. Use key=anonymous for requests made without an API key or with a key not in usage_api_keys. Days are UTC and both bounds are inclusive",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get API usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key of the client, or anonymous",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD (default: 30 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD (default: today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usage report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid key or date range",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/facilities": {
+            "get": {
+                "description": "List the canonical facilities offered by at least one active hotel with the number of hotels offering each, most common first. The slugs are the values accepted by the amenities and amenity_weights search parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "List facilities",
+                "responses": {
+                    "200": {
+                        "description": "Canonical facilities",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.FacilityCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/favorites": {
+            "get": {
+                "description": "List the favorites of the session identified by the hms_session cookie as hotel IDs, or as full hotels with expand=true. Requests without a session get an empty list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "List favorite hotels",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Return the hotels instead of their IDs",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Favorite hotel IDs, or hotels with expand=true",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "integer",
+                                                "format": "int64"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/favorites/{hotel_id}": {
+            "post": {
+                "description": "Bookmark a hotel for the anonymous guest identified by the hms_session cookie. A session is started when the request has none. Favorites are kept for 30 days after the session last used them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Add a favorite hotel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "hotel_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Favorite hotel IDs of the session",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "integer",
+                                                "format": "int64"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Favorites limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a hotel from the favorites of the session identified by the hms_session cookie. Removing a hotel that is not a favorite is not an error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Remove a favorite hotel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "hotel_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Favorite hotel IDs of the session",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "integer",
+                                                "format": "int64"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/compare": {
+            "get": {
+                "description": "Compare 2 to 5 hotels side by side. attributes holds one row per compared dimension (star rating, rating, review count, child and pets allowed, parking, check-in start, max occupancy, then every amenity of any of the hotels) with one value per hotel, in the order of hotels. has_all is set when every hotel shares the value, so shared amenities can be highlighted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "Compare hotels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated hotel IDs, 2 to 5",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hotel comparison",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ComparisonResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid ids or not 2 to 5 hotels",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/new": {
+            "get": {
+                "description": "List the hotels first indexed in the last days days, or since created_after, newest first, for \"new on the platform\" carousels. It is a search with created_after set to days ago, rounded down to the hour, and sort_by=created_at, whose results are cached for results.new_hotels_cache_max_age, longer than other searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "List new hotels",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Window in days (default: 30, max: results.new_hotels_max_days)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window instead of days, as a date, RFC 3339 or relative to now such as -30d; at most results.new_hotels_max_days ago",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Maximum number of hotels to return (max: 100, default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Newest hotels first",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel"
+                                            }
+                                        },
+                                        "meta": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - days or limit is not a positive integer, created_after does not parse, both days and created_after are given, or the window exceeds the retention window",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Search temporarily unavailable, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific hotel by its ID. Photos, rooms, reviews and translations are capped to configured defaults, which the *Limit parameters raise up to configured maxima; meta reports each collection's total and whether it was truncated. meta.data_freshness is stale when the hotel is overdue for a refresh from the Cupid API, with meta.last_updated, and fresh otherwise, with meta.next_update_at when an update is scheduled. data_sources, which source last wrote each field group, is included when server.expose_data_sources is set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "Get hotel by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit the number of photos to return, up to the configured maximum",
+                        "name": "photosLimit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit the number of rooms to return, up to the configured maximum",
+                        "name": "roomsLimit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit the number of reviews to return, up to the configured maximum",
+                        "name": "reviewsLimit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit the number of translations to return, up to the configured maximum",
+                        "name": "translationsLimit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hotel details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "meta": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.HotelMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/{id}/reviews": {
+            "get": {
+                "description": "List the stored reviews of a hotel, most recent first. Reviews moved to the archive are only returned with include_archived=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "List hotel reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived reviews",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reviews per page (max: 100, default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hotel reviews",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Review"
+                                            }
+                                        },
+                                        "meta": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/{id}/reviews/stats": {
+            "get": {
+                "description": "Get the review count, the average review score and the per-category averages (location, service, value, facilities) across the hotel's stored reviews. A category is null when no review rated it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "Get hotel review statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review statistics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ReviewStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/{id}/translations": {
+            "get": {
+                "description": "List the available translations of a hotel with the translated name and a description snippet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "List hotel translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Available translations",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.TranslationSummary"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hotels/{id}/translations/{lang}": {
+            "get": {
+                "description": "Get the hotel with the translation for the given language overlaid on the base document. fallback_fields lists the fields that kept their default-language value. Translations missing from the database are fetched from the Cupid API and stored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "Get localized hotel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code (e.g. fr, es)",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Localized hotel",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.LocalizedHotel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid hotel ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found - Hotel or translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/chain-suggestions": {
+            "get": {
+                "description": "Get autocomplete suggestions for hotel chains, so a partial query can be turned into a chain filter instead of a single hotel. Chains whose name or one of its words starts with the query are returned, largest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get hotel chain suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial chain name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of chains to return (default: 10, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching chains with their hotel counts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ChainSuggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Query parameter is required",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/combined": {
+            "get": {
+                "description": "Run a hotel search and fetch autocomplete suggestions for the same query in one request. Accepts the same filters as /api/v1/search/hotels",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Combined search and suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by airport code",
+                        "name": "airport_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by facility slugs from /api/v1/facilities; raw facility names are normalized",
+                        "name": "amenities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rank by weighted amenities, e.g. pool:0.9,wifi:0.3 (weights 0-1, at most 10)",
+                        "name": "amenity_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected arrival time (e.g. 23:30 or 11:30 PM); only hotels still checking guests in are returned",
+                        "name": "arrival_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel chain",
+                        "name": "chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by child allowed status",
+                        "name": "child_allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels indexed at or after this time (e.g. 2024-01-01, RFC 3339, or relative to now such as -30d, -2w or -12h), at most results.new_hotels_max_days ago",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels indexed at or before this time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel description",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reorder each page of relevance-ranked results so that no more than max_same_chain consecutive hotels belong to the same chain. Ignored with other sorts",
+                        "name": "diversify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel fax number, in any format. Numbers without a country code are read as numbers of country",
+                        "name": "fax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional fields to include; description returns the full description, markdown description and important info instead of only description_snippet",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels inside this polygon, as comma-separated alternating latitudes and longitudes of at least 3 vertices; cannot be combined with radius",
+                        "name": "geo_polygon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the search, used as its locale when locale is not given",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels whose content is available in every listed language, comma-separated (e.g. es,fr)",
+                        "name": "languages",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude for location-based search",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page (max: 100, default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale the search is counted under for the trending lists, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude for location-based search",
+                        "name": "longitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum distance in kilometers to the hotel's nearest airport",
+                        "name": "max_airport_distance_km",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Keep hotels whose minimum stay is at most this many nights; hotels without a minimum stay always match",
+                        "name": "max_min_nights",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Consecutive hotels of one chain allowed when diversify is set (default: 2)",
+                        "name": "max_same_chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep hotels with at least this many room types",
+                        "name": "min_room_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep hotels whose room types host at least this many guests in total (sum of max occupancy)",
+                        "name": "min_total_capacity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum average value-for-money review score",
+                        "name": "min_value_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels near an attraction of this category (museum, restaurant, beach, park) or with this exact name",
+                        "name": "near_attraction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "Typos tolerated per query word, from 0 for exact matching up to 2. Values above 2 are capped; omit for the engine default",
+                        "name": "num_typos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by parking information",
+                        "name": "parking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by pets allowed status",
+                        "name": "pets_allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel phone number, in any format. Numbers without a country code are read as numbers of country",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels whose policy summary mentions this keyword, such as parking or free parking",
+                        "name": "policy_keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query used for both results and suggestions",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in kilometers",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "classic"
+                        ],
+                        "type": "string",
+                        "description": "Ranking profile: relevance (default) boosts exact name and phrase matches, classic sorts by rating unless sort_by is given",
+                        "name": "ranking_profile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum rating (0-5)",
+                        "name": "rating_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum rating (0-5)",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by review count",
+                        "name": "review_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by field (rating, distance, relevance, name, created_at). Defaults to relevance, then rating descending; ties are always broken by hotel_id ascending",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum star rating (1-5)",
+                        "name": "star_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude hotels without check-in hours when filtering by arrival_time",
+                        "name": "strict_checkin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of suggestions to return (default: 5)",
+                        "name": "suggest_limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels updated at or after this time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels updated at or before this time",
+                        "name": "updated_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results and suggestions",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Query parameter is required, invalid search parameters, a page deeper than the engine serves (code page_out_of_range; pages past meta.total_pages are empty)",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Search temporarily unavailable, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/facets": {
+            "get": {
+                "description": "Get available facets for filtering hotel search results (cities, countries, star ratings, amenities, etc.)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get search facets",
+                "responses": {
+                    "200": {
+                        "description": "Available search facets with counts",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/hotels": {
+            "get": {
+                "description": "Search for hotels using various filters based on TypesenseDocument fields. Ordering is deterministic: every sort ends with hotel_id ascending as a tiebreaker, and without sort_by results are ordered by relevance, then rating descending, so paging never repeats or skips hotels. meta.served_from is cache or engine, with meta.cache_age_seconds for cached results, and meta.engine and meta.collection name what computed them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search hotels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by airport code",
+                        "name": "airport_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by facility slugs from /api/v1/facilities; raw facility names are normalized",
+                        "name": "amenities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rank by weighted amenities, e.g. pool:0.9,wifi:0.3 (weights 0-1, at most 10)",
+                        "name": "amenity_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected arrival time (e.g. 23:30 or 11:30 PM); only hotels still checking guests in are returned",
+                        "name": "arrival_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel chain",
+                        "name": "chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by child allowed status",
+                        "name": "child_allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels indexed at or after this time (e.g. 2024-01-01, RFC 3339, or relative to now such as -30d, -2w or -12h), at most results.new_hotels_max_days ago",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels indexed at or before this time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel description",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reorder each page of relevance-ranked results so that no more than max_same_chain consecutive hotels belong to the same chain. Ignored with other sorts",
+                        "name": "diversify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel fax number, in any format. Numbers without a country code are read as numbers of country",
+                        "name": "fax",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional fields to include; description returns the full description, markdown description and important info instead of only description_snippet",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels inside this polygon, as comma-separated alternating latitudes and longitudes of at least 3 vertices; cannot be combined with radius",
+                        "name": "geo_polygon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the search, used as its locale when locale is not given",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels whose content is available in every listed language, comma-separated (e.g. es,fr)",
+                        "name": "languages",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude for location-based search",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page (max: 100, default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale the search is counted under for the trending lists, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude for location-based search",
+                        "name": "longitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum distance in kilometers to the hotel's nearest airport",
+                        "name": "max_airport_distance_km",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Keep hotels whose minimum stay is at most this many nights; hotels without a minimum stay always match",
+                        "name": "max_min_nights",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Consecutive hotels of one chain allowed when diversify is set (default: 2)",
+                        "name": "max_same_chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep hotels with at least this many room types",
+                        "name": "min_room_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep hotels whose room types host at least this many guests in total (sum of max occupancy)",
+                        "name": "min_total_capacity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum average value-for-money review score",
+                        "name": "min_value_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels near an attraction of this category (museum, restaurant, beach, park) or with this exact name",
+                        "name": "near_attraction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "Typos tolerated per query word, from 0 for exact matching up to 2. Values above 2 are capped; omit for the engine default",
+                        "name": "num_typos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by parking information",
+                        "name": "parking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by pets allowed status",
+                        "name": "pets_allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by hotel phone number, in any format. Numbers without a country code are read as numbers of country",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep hotels whose policy summary mentions this keyword, such as parking or free parking",
+                        "name": "policy_keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query for hotel name, description, or location. Double-quoted words are matched as a phrase",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in kilometers",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "classic"
+                        ],
+                        "type": "string",
+                        "description": "Ranking profile: relevance (default) boosts exact name and phrase matches, classic sorts by rating unless sort_by is given",
+                        "name": "ranking_profile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum rating (0-5)",
+                        "name": "rating_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum rating (0-5)",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by review count",
+                        "name": "review_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by field (rating, distance, relevance, name, created_at). Defaults to relevance, then rating descending; ties are always broken by hotel_id ascending",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum star rating (1-5)",
+                        "name": "star_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude hotels without check-in hours when filtering by arrival_time",
+                        "name": "strict_checkin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels updated at or after this time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hotels updated at or before this time",
+                        "name": "updated_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results with hotels and pagination",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel"
+                                            }
+                                        },
+                                        "meta": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the search cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid search parameters, a page deeper than the engine serves (code page_out_of_range; pages past meta.total_pages are empty)",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Search temporarily unavailable, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/popular": {
+            "get": {
+                "description": "Get the most searched queries of a locale. Locales with too few recorded searches get the global ones, and a static list is returned until enough searches are recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get popular searches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of searches to return (default: 8)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale whose searches are ranked, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language whose searches are ranked when locale is not given",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of popular searches",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/suggestions": {
+            "get": {
+                "description": "Get autocomplete suggestions for hotel search based on partial query input. When nothing matches, misspelled words of five or more characters are corrected against the indexed hotel names and cities and the suggestions of the corrected query are returned with corrected_from set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get hotel search suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query for suggestions",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of suggestions to return (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of search suggestions",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Query parameter is required",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/trending": {
+            "get": {
+                "description": "Get the most searched queries of a locale. Locales with too few recorded searches get the global ones, and a static list is returned until enough searches are recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get trending search suggestions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of trending suggestions to return (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale whose searches are ranked, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language whose searches are ranked when locale is not given",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of trending search suggestions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Suggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/debug/info": {
+            "get": {
+                "description": "Get the build version, commit and build time, Go runtime stats, uptime and the redacted service configuration with its checksum. Only reachable from internal networks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "debug"
+                ],
+                "summary": "Build and runtime diagnostics",
+                "responses": {
+                    "200": {
+                        "description": "Build and runtime diagnostics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/buildinfo.DebugInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get the current health status of the search service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Service health status with timestamp and version",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_infrastructure_handler.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "buildinfo.DebugInfo": {
+            "type": "object",
+            "properties": {
+                "build": {
+                    "$ref": "#/definitions/buildinfo.Info"
+                },
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "config_checksum": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "$ref": "#/definitions/buildinfo.RuntimeStats"
+                },
+                "uptime": {
+                    "type": "string"
+                },
+                "uptime_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "buildinfo.RuntimeStats": {
+            "type": "object",
+            "properties": {
+                "gomaxprocs": {
+                    "type": "integer"
+                },
+                "goroutines": {
+                    "type": "integer"
+                },
+                "heap_alloc_bytes": {
+                    "type": "integer"
+                },
+                "heap_inuse_bytes": {
+                    "type": "integer"
+                },
+                "heap_objects": {
+                    "type": "integer"
+                },
+                "last_gc_pause_ms": {
+                    "type": "number"
+                },
+                "num_gc": {
+                    "type": "integer"
+                },
+                "sys_bytes": {
+                    "type": "integer"
+                },
+                "total_gc_pause_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.BackfillOptions": {
+            "type": "object",
+            "properties": {
+                "batch_size": {
+                    "type": "integer"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "restart": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ComparisonAttribute": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "has_all": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ComparisonResult": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ComparisonAttribute"
+                    }
+                },
+                "hotels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.FacilityBackfillJob": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "failed_hotels": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_hotel_id": {
+                    "type": "integer"
+                },
+                "processed_hotels": {
+                    "type": "integer"
+                },
+                "resumed_from": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.JobStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_hotels": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.FacilityBackfillOptions": {
+            "type": "object",
+            "properties": {
+                "batch_size": {
+                    "type": "integer"
+                },
+                "restart": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.HotelMeta": {
+            "type": "object",
+            "properties": {
+                "data_freshness": {
+                    "type": "string"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "next_update_at": {
+                    "type": "string"
+                },
+                "photos_total": {
+                    "type": "integer"
+                },
+                "photos_truncated": {
+                    "type": "boolean"
+                },
+                "reviews_total": {
+                    "type": "integer"
+                },
+                "reviews_truncated": {
+                    "type": "boolean"
+                },
+                "rooms_total": {
+                    "type": "integer"
+                },
+                "rooms_truncated": {
+                    "type": "boolean"
+                },
+                "translations_total": {
+                    "type": "integer"
+                },
+                "translations_truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.HotelSources": {
+            "type": "object",
+            "properties": {
+                "data_sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.DataSource"
+                    }
+                },
+                "hotel_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.JobStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "JobStatusRunning",
+                "JobStatusCompleted",
+                "JobStatusFailed"
+            ]
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.LocalizedHotel": {
+            "type": "object",
+            "properties": {
+                "fallback_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hotel": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "translated_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReconcileJob": {
+            "type": "object",
+            "properties": {
+                "database_scanned": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_repairs": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index_scanned": {
+                    "type": "integer"
+                },
+                "last_hotel_id": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "missing_sample": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "orphaned": {
+                    "type": "integer"
+                },
+                "orphaned_sample": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reindexed": {
+                    "type": "integer"
+                },
+                "repair": {
+                    "type": "boolean"
+                },
+                "stale": {
+                    "type": "integer"
+                },
+                "stale_sample": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.JobStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewArchiveJob": {
+            "type": "object",
+            "properties": {
+                "archived_reviews": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keep_newest": {
+                    "type": "integer"
+                },
+                "last_hotel_id": {
+                    "type": "integer"
+                },
+                "min_age_days": {
+                    "type": "integer"
+                },
+                "processed_hotels": {
+                    "type": "integer"
+                },
+                "resumed_from": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.JobStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewArchiveOptions": {
+            "type": "object",
+            "properties": {
+                "batch_size": {
+                    "type": "integer"
+                },
+                "keep_newest": {
+                    "type": "integer"
+                },
+                "min_age_days": {
+                    "type": "integer"
+                },
+                "restart": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewDedupJob": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "fingerprinted_reviews": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_hotel_id": {
+                    "type": "integer"
+                },
+                "merged_reviews": {
+                    "type": "integer"
+                },
+                "processed_hotels": {
+                    "type": "integer"
+                },
+                "resumed_from": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.JobStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.ReviewDedupOptions": {
+            "type": "object",
+            "properties": {
+                "batch_size": {
+                    "type": "integer"
+                },
+                "restart": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncHistory": {
+            "type": "object",
+            "properties": {
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_synchistory.Run"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_synchistory.Summary"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncOptions": {
+            "type": "object",
+            "properties": {
+                "BatchSize": {
+                    "type": "integer"
+                },
+                "ChainFilter": {
+                    "type": "string"
+                },
+                "ClearIndexFirst": {
+                    "type": "boolean"
+                },
+                "FullSync": {
+                    "type": "boolean"
+                },
+                "SinceTimestamp": {
+                    "type": "string"
+                },
+                "UpdateCacheAfter": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncProgress": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_hotels": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "indexed_hotels": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "processed_hotels": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncStatus"
+                },
+                "total_hotels": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncStats": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.TableCounts"
+                },
+                "fetcher_pipeline": {
+                    "description": "FetcherPipeline is omitted when the orchestrator is not configured or unreachable.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_pipeline.Stats"
+                        }
+                    ]
+                },
+                "index": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.IndexStats"
+                },
+                "index_lag": {
+                    "description": "IndexLag is the number of active hotels in PostgreSQL minus the documents in the index.",
+                    "type": "integer"
+                },
+                "last_run": {
+                    "description": "LastRun is the most recent sync recorded in the sync history.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_synchistory.Run"
+                        }
+                    ]
+                },
+                "last_sync_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.SyncStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "failed",
+                "superseded"
+            ],
+            "x-enum-varnames": [
+                "SyncStatusRunning",
+                "SyncStatusCompleted",
+                "SyncStatusFailed",
+                "SyncStatusSuperseded"
+            ]
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.TableCounts": {
+            "type": "object",
+            "properties": {
+                "counted_at": {
+                    "type": "string"
+                },
+                "estimated": {
+                    "type": "boolean"
+                },
+                "hotels": {
+                    "type": "integer"
+                },
+                "hotels_updated_since_newest": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UnmappedFacility": {
+            "type": "object",
+            "properties": {
+                "hotel_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UpdateHotelResult": {
+            "type": "object",
+            "properties": {
+                "changed_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hotel": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageReport": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageReportEntry"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageTotals"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageReportEntry": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "error_rate": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_application_usecase.UsageTotals": {
+            "type": "object",
+            "properties": {
+                "error_rate": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Address": {
+            "type": "object",
+            "properties": {
+                "City": {
+                    "type": "string"
+                },
+                "Country": {
+                    "type": "string"
+                },
+                "PostalCode": {
+                    "type": "string"
+                },
+                "State": {
+                    "type": "string"
+                },
+                "Street": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Amenity": {
+            "type": "object",
+            "properties": {
+                "AmenitiesID": {
+                    "type": "integer"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Sort": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Attraction": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.BedType": {
+            "type": "object",
+            "properties": {
+                "BedSize": {
+                    "type": "string"
+                },
+                "BedType": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "integer"
+                },
+                "Quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Change": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.FieldChange"
+                    }
+                },
+                "hotel_id": {
+                    "type": "integer"
+                },
+                "source_message_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.CheckinInfo": {
+            "type": "object",
+            "properties": {
+                "CheckinEnd": {
+                    "type": "string"
+                },
+                "CheckinStart": {
+                    "type": "string"
+                },
+                "Checkout": {
+                    "type": "string"
+                },
+                "Instructions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "SpecialInstructions": {
+                    "type": "string"
+                },
+                "UnparsedTimes": {
+                    "description": "UnparsedTimes keeps the stored times in none of the known layouts by their stored key,\nsuch as checkin_start, so saving the hotel does not drop them.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.CheckinWindow": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "end_minutes": {
+                    "type": "integer"
+                },
+                "is_24h": {
+                    "type": "boolean"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "start_minutes": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ContactInfo": {
+            "type": "object",
+            "properties": {
+                "Email": {
+                    "type": "string"
+                },
+                "Fax": {
+                    "type": "string"
+                },
+                "Phone": {
+                    "type": "string"
+                },
+                "fax_e164": {
+                    "type": "string"
+                },
+                "phone_e164": {
+                    "description": "PhoneE164 and FaxE164 are the numbers in E.164 form, empty when they cannot be read.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.DataSource": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.DuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "hotel_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Facility": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Slug": {
+                    "description": "Slug is the canonical facility the name maps to.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.FacilityCount": {
+            "type": "object",
+            "properties": {
+                "hotel_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.FieldChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.GroupRoomMin": {
+            "type": "object",
+            "properties": {
+                "min_nights": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel": {
+            "type": "object",
+            "properties": {
+                "Address": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Address"
+                },
+                "AirportCode": {
+                    "type": "string"
+                },
+                "Amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ArchivedReviewCount": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "Chain": {
+                    "type": "string"
+                },
+                "ChainID": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "CheckinInfo": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.CheckinInfo"
+                },
+                "ChildAllowed": {
+                    "type": "boolean"
+                },
+                "ContactInfo": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ContactInfo"
+                },
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "CupidID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "Description": {
+                    "type": "string"
+                },
+                "Facilities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Facility"
+                    }
+                },
+                "HotelID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "HotelType": {
+                    "type": "string"
+                },
+                "HotelTypeID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ImportantInfo": {
+                    "type": "string"
+                },
+                "Latitude": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "Location": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Location"
+                },
+                "Longitude": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "MainImageTh": {
+                    "type": "string"
+                },
+                "MarkdownDescription": {
+                    "type": "string"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "NextUpdateAt": {
+                    "type": "string"
+                },
+                "Parking": {
+                    "type": "string"
+                },
+                "PetsAllowed": {
+                    "type": "boolean"
+                },
+                "Photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Photo"
+                    }
+                },
+                "Policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Policy"
+                    }
+                },
+                "Rating": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "ReviewCount": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "Reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Review"
+                    }
+                },
+                "Rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Room"
+                    }
+                },
+                "Source": {
+                    "type": "string"
+                },
+                "StarRating": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Translation"
+                    }
+                },
+                "UpdatedAt": {
+                    "type": "string"
+                },
+                "checkin_window": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.CheckinWindow"
+                },
+                "data_sources": {
+                    "description": "DataSources attributes each field group, such as core or photos, to the source that last\nwrote it.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.DataSource"
+                    }
+                },
+                "description_snippet": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "group_room_min": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.GroupRoomMin"
+                },
+                "nearby_attractions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Attraction"
+                    }
+                },
+                "source_mappings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA timezone of the hotel, such as Asia/Tokyo, derived from its\ncoordinates. Its check-in and check-out times are clock times in that zone.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Location": {
+            "type": "object",
+            "properties": {
+                "Latitude": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "Longitude": {
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.MergeResult": {
+            "type": "object",
+            "properties": {
+                "dropped_translations": {
+                    "type": "integer"
+                },
+                "duplicate_id": {
+                    "type": "integer"
+                },
+                "moved_reviews": {
+                    "type": "integer"
+                },
+                "moved_translations": {
+                    "type": "integer"
+                },
+                "primary_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Patch": {
+            "type": "object",
+            "properties": {
+                "child_allowed": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parking": {
+                    "type": "string"
+                },
+                "pets_allowed": {
+                    "type": "boolean"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "star_rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Photo": {
+            "type": "object",
+            "properties": {
+                "ClassID": {
+                    "type": "integer"
+                },
+                "ClassOrder": {
+                    "type": "integer"
+                },
+                "HDURL": {
+                    "type": "string"
+                },
+                "ImageClass1": {
+                    "type": "string"
+                },
+                "ImageClass2": {
+                    "type": "string"
+                },
+                "ImageDescription": {
+                    "type": "string"
+                },
+                "MainPhoto": {
+                    "type": "boolean"
+                },
+                "Score": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "URL": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Policy": {
+            "type": "object",
+            "properties": {
+                "ChildAllowed": {
+                    "type": "string"
+                },
+                "Description": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "integer"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Parking": {
+                    "type": "string"
+                },
+                "PetsAllowed": {
+                    "type": "string"
+                },
+                "PolicyType": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Review": {
+            "type": "object",
+            "properties": {
+                "AverageScore": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "Cons": {
+                    "type": "string"
+                },
+                "Country": {
+                    "type": "string"
+                },
+                "Date": {
+                    "type": "string"
+                },
+                "Headline": {
+                    "type": "string"
+                },
+                "HotelID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Language": {
+                    "type": "string"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Pros": {
+                    "type": "string"
+                },
+                "ReviewID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "ScoreFacilities": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "ScoreLocation": {
+                    "description": "Category scores are zero when the source did not rate the category.",
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "ScoreService": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "ScoreValue": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "Source": {
+                    "type": "string"
+                },
+                "Type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ReviewStats": {
+            "type": "object",
+            "properties": {
+                "average_score": {
+                    "type": "number"
+                },
+                "hotel_id": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "review_score_breakdown": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ScoreBreakdown"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Room": {
+            "type": "object",
+            "properties": {
+                "BedRelation": {
+                    "type": "string"
+                },
+                "BedTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.BedType"
+                    }
+                },
+                "Description": {
+                    "type": "string"
+                },
+                "HotelID": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "integer"
+                },
+                "MaxAdults": {
+                    "type": "integer"
+                },
+                "MaxChildren": {
+                    "type": "integer"
+                },
+                "MaxOccupancy": {
+                    "type": "integer"
+                },
+                "Photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.RoomPhoto"
+                    }
+                },
+                "RoomAmenities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Amenity"
+                    }
+                },
+                "RoomName": {
+                    "type": "string"
+                },
+                "RoomSizeSquare": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "RoomSizeUnit": {
+                    "type": "string"
+                },
+                "Views": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.RoomPhoto": {
+            "type": "object",
+            "properties": {
+                "ClassID": {
+                    "type": "integer"
+                },
+                "ClassOrder": {
+                    "type": "integer"
+                },
+                "HDURL": {
+                    "type": "string"
+                },
+                "ImageClass1": {
+                    "type": "string"
+                },
+                "ImageClass2": {
+                    "type": "string"
+                },
+                "ImageDescription": {
+                    "type": "string"
+                },
+                "MainPhoto": {
+                    "type": "boolean"
+                },
+                "Score": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "URL": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ScoreBreakdown": {
+            "type": "object",
+            "properties": {
+                "facilities": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "number"
+                },
+                "service": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Translation": {
+            "type": "object",
+            "properties": {
+                "Address": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Address"
+                },
+                "Amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "Chain": {
+                    "type": "string"
+                },
+                "CheckinInfo": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.CheckinInfo"
+                },
+                "ContactInfo": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.ContactInfo"
+                },
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "Description": {
+                    "type": "string"
+                },
+                "Facilities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Facility"
+                    }
+                },
+                "HotelID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "HotelTypeID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ImportantInfo": {
+                    "type": "string"
+                },
+                "Lang": {
+                    "type": "string"
+                },
+                "Latitude": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "Longitude": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "MarkdownDescription": {
+                    "type": "string"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "NextUpdateAt": {
+                    "type": "string"
+                },
+                "Parking": {
+                    "type": "string"
+                },
+                "Photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Photo"
+                    }
+                },
+                "Policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Policy"
+                    }
+                },
+                "Reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Review"
+                    }
+                },
+                "Rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Room"
+                    }
+                },
+                "Source": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Translation"
+                    }
+                },
+                "UpdatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.TranslationSummary": {
+            "type": "object",
+            "properties": {
+                "description_snippet": {
+                    "type": "string"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_pipeline.Stats": {
+            "type": "object",
+            "properties": {
+                "delay_depth": {
+                    "description": "DelayDepth counts jobs waiting out a retry delay; nil when the orchestrator has no delay\nqueue to report.",
+                    "type": "integer"
+                },
+                "dlq_depth": {
+                    "description": "DLQDepth is nil when the orchestrator has no dead letter queue to report.",
+                    "type": "integer"
+                },
+                "last_enqueue_at": {
+                    "type": "string"
+                },
+                "main_depth": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ChainSuggestion": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "type": "string"
+                },
+                "hotel_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.ConfigBundle": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.FacetSettings"
+                },
+                "ranking_profiles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.RankingProfileSettings"
+                    }
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Synonym"
+                    }
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.FacetSettings": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_values": {
+                    "description": "MaxValues is the number of values returned per facet, the engine default when zero.",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.IndexStats": {
+            "type": "object",
+            "properties": {
+                "disk_size_bytes": {
+                    "description": "DiskSizeBytes is the disk used by a search node; replicas hold the same data, so the\nlargest node is reported. MemorySizeBytes is the memory in use summed over all nodes.",
+                    "type": "integer"
+                },
+                "disk_size_human": {
+                    "type": "string"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "memory_size_bytes": {
+                    "type": "integer"
+                },
+                "memory_size_human": {
+                    "type": "string"
+                },
+                "newest_document_at": {
+                    "description": "NewestDocumentAt is the highest updated_at among indexed documents, nil for an empty index.",
+                    "type": "string"
+                },
+                "total_documents": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.RankingProfileSettings": {
+            "type": "object",
+            "properties": {
+                "prioritize_exact_match": {
+                    "type": "boolean"
+                },
+                "query_by_weights": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Suggestion": {
+            "type": "object",
+            "properties": {
+                "corrected_from": {
+                    "description": "CorrectedFrom is the original query when the suggestion was found for a spelling\ncorrection of it.",
+                    "type": "string"
+                },
+                "hotel_id": {
+                    "type": "integer"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "score": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_search.Synonym": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "root": {
+                    "type": "string"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_synchistory.Run": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error_count": {
+                    "description": "ErrorCount counts every error of the run; Errors keeps the first MaxErrors of them,\ntruncated to MaxErrorLength characters.",
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed_hotels": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "indexed_hotels": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "removed_hotels": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_hotels": {
+                    "type": "integer"
+                },
+                "trigger": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_victoragudo_hotel-management-system_search-service_internal_domain_synchistory.Summary": {
+            "type": "object",
+            "properties": {
+                "avg_duration_ms": {
+                    "type": "number"
+                },
+                "failed_hotels": {
+                    "type": "integer"
+                },
+                "failed_runs": {
+                    "type": "integer"
+                },
+                "failure_rate": {
+                    "description": "FailureRate is the share of runs that failed or failed to index at least one hotel.",
+                    "type": "number"
+                },
+                "indexed_hotels": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "since": {
                     "type": "string"
                 }
             }
@@ -526,6 +5286,9 @@ const docTemplate = `{
         "internal_infrastructure_handler.APIResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {},
                 "error": {
                     "type": "string"
@@ -535,6 +5298,57 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "internal_infrastructure_handler.SlowRequest": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "description": "DurationMs is split into the time the handler took to write the response headers and the\ntime spent writing the body after them.",
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "response_body": {
+                    "description": "ResponseBody holds the start of the body, up to the first write made once the request was\nalready slow.",
+                    "type": "string"
+                },
+                "response_body_truncated": {
+                    "type": "boolean"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "time_to_header_ms": {
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "write_body_ms": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "Bearer": {
+            "description": "Type \"Bearer\" followed by a space and the access token.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -542,7 +5356,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{"http", "https"},
 	Title:            "Hotel Management & Search Service API",
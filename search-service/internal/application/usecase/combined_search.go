@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+type CombinedSearchResult struct {
+	SearchResult *search.Result       `json:"search_result"`
+	Suggestions  []*search.Suggestion `json:"suggestions"`
+}
+
+type CombinedSearchUseCase struct {
+	searchEngine  search.Engine
+	loadMonitor   search.LoadMonitor
+	snippetLength int
+	logger        *slog.Logger
+}
+
+func NewCombinedSearchUseCase(
+	searchEngine search.Engine,
+	loadMonitor search.LoadMonitor,
+	snippetLength int,
+	logger *slog.Logger,
+) *CombinedSearchUseCase {
+	return &CombinedSearchUseCase{
+		searchEngine:  searchEngine,
+		loadMonitor:   loadMonitor,
+		snippetLength: snippetLength,
+		logger:        logger,
+	}
+}
+
+func (uc *CombinedSearchUseCase) Execute(ctx context.Context, params search.Params, suggestionLimit int) (*CombinedSearchResult, error) {
+	startTime := time.Now()
+
+	if params.Query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", search.ErrInvalidParams, err)
+	}
+	capabilities := uc.searchEngine.Capabilities()
+	if err := params.ApplyCapabilities(capabilities); err != nil {
+		return nil, err
+	}
+
+	if suggestionLimit <= 0 {
+		suggestionLimit = 5
+	}
+	if suggestionLimit > 50 {
+		suggestionLimit = 50
+	}
+
+	if uc.loadMonitor != nil {
+		if shed, retryAfter := uc.loadMonitor.ShouldShed(); shed {
+			uc.loadMonitor.RecordShed()
+			uc.logger.Warn("Shedding combined search request", "retry_after", retryAfter)
+			return nil, &search.OverloadedError{RetryAfter: retryAfter}
+		}
+	}
+
+	result, suggestions, err := uc.searchEngine.MultiSearch(ctx, params, params.Query, suggestionLimit)
+	if err != nil {
+		return nil, fmt.Errorf("search engine error: %w", err)
+	}
+
+	result.ProcessingTime = time.Since(startTime)
+	result.Query = params.Query
+	result.Page = params.Page
+	result.Limit = params.Limit
+	result.CalculateTotalPages()
+	result.ApplyCapabilities(capabilities)
+	result.ApplySnippets(uc.snippetLength, params.IncludesField(search.FieldDescription))
+
+	uc.logger.Debug("Combined search completed",
+		"query", params.Query,
+		"total_hits", result.TotalHits,
+		"suggestions", len(suggestions),
+		"duration", result.ProcessingTime)
+
+	return &CombinedSearchResult{
+		SearchResult: result,
+		Suggestions:  suggestions,
+	}, nil
+}
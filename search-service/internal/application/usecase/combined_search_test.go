@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeLoadMonitor struct {
+	shedding     bool
+	retryAfter   time.Duration
+	shedRequests int64
+}
+
+func (m *fakeLoadMonitor) ShouldShed() (bool, time.Duration) {
+	return m.shedding, m.retryAfter
+}
+
+func (m *fakeLoadMonitor) RecordShed() {
+	m.shedRequests++
+}
+
+func (m *fakeLoadMonitor) LoadStatus() search.LoadStatus {
+	return search.LoadStatus{Enabled: true, Shedding: m.shedding, ShedRequests: m.shedRequests}
+}
+
+func newCombinedSearchTest(t *testing.T) (*CombinedSearchUseCase, *mocks.MockEngine, *fakeLoadMonitor) {
+	t.Helper()
+	engine := mocks.NewMockEngine(gomock.NewController(t))
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}).AnyTimes()
+	monitor := &fakeLoadMonitor{}
+	return NewCombinedSearchUseCase(engine, monitor, 0, slog.New(slog.DiscardHandler)), engine, monitor
+}
+
+func TestCombinedSearchShedsWhenOverloaded(t *testing.T) {
+	uc, _, monitor := newCombinedSearchTest(t)
+	monitor.shedding = true
+	monitor.retryAfter = 15 * time.Second
+
+	result, err := uc.Execute(context.Background(), search.Params{Query: "paris"}, 5)
+
+	assert.Nil(t, result)
+	var overloadedErr *search.OverloadedError
+	require.True(t, errors.As(err, &overloadedErr))
+	assert.Equal(t, 15*time.Second, overloadedErr.RetryAfter)
+	assert.ErrorIs(t, err, search.ErrOverloaded)
+	assert.Equal(t, int64(1), monitor.shedRequests)
+}
+
+func TestCombinedSearchRunsMultiSearchWhenHealthy(t *testing.T) {
+	uc, engine, monitor := newCombinedSearchTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().MultiSearch(ctx, gomock.Any(), "paris", 5).
+		Return(&search.Result{TotalHits: 1}, []*search.Suggestion{{}}, nil)
+
+	result, err := uc.Execute(ctx, search.Params{Query: "paris"}, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.SearchResult.TotalHits)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Zero(t, monitor.shedRequests)
+}
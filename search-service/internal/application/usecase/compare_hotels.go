@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	MinComparedHotels = 2
+	MaxComparedHotels = 5
+)
+
+var ErrInvalidComparison = errors.New("invalid hotel comparison")
+
+const (
+	ComparisonGroupDetails   = "details"
+	ComparisonGroupAmenities = "amenities"
+
+	comparisonYes = "yes"
+	comparisonNo  = "no"
+)
+
+// ComparisonAttribute is one row of a side-by-side comparison, with one value per compared
+// hotel in the order of ComparisonResult.Hotels. HasAll is set when every hotel shares the
+// value; for an amenity, when every hotel offers it.
+type ComparisonAttribute struct {
+	Name   string   `json:"name"`
+	Group  string   `json:"group"`
+	Values []string `json:"values"`
+	HasAll bool     `json:"has_all"`
+}
+
+type ComparisonResult struct {
+	Hotels     []*hotel.Hotel        `json:"hotels"`
+	Attributes []ComparisonAttribute `json:"attributes"`
+}
+
+// CompareHotelsUseCase lines up hotels attribute by attribute for side-by-side display.
+type CompareHotelsUseCase struct {
+	hotelRepo hotel.Repository
+	logger    *slog.Logger
+}
+
+func NewCompareHotelsUseCase(hotelRepo hotel.Repository, logger *slog.Logger) *CompareHotelsUseCase {
+	return &CompareHotelsUseCase{
+		hotelRepo: hotelRepo,
+		logger:    logger,
+	}
+}
+
+// Execute compares the given hotels in the order requested; repeated ids are compared once.
+// It returns ErrInvalidComparison for fewer than MinComparedHotels or more than
+// MaxComparedHotels distinct hotels, and ErrHotelNotFound when one of them is not stored.
+func (uc *CompareHotelsUseCase) Execute(ctx context.Context, hotelIDs []int64) (*ComparisonResult, error) {
+	var ids []int64
+	for _, hotelID := range hotelIDs {
+		if !slices.Contains(ids, hotelID) {
+			ids = append(ids, hotelID)
+		}
+	}
+	if len(ids) < MinComparedHotels || len(ids) > MaxComparedHotels {
+		return nil, fmt.Errorf("%w: between %d and %d distinct hotels can be compared, got %d",
+			ErrInvalidComparison, MinComparedHotels, MaxComparedHotels, len(ids))
+	}
+
+	found, err := uc.hotelRepo.FindByHotelIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotels: %w", err)
+	}
+
+	byID := make(map[int64]*hotel.Hotel, len(found))
+	for _, h := range found {
+		byID[h.HotelID] = h
+	}
+	hotels := make([]*hotel.Hotel, 0, len(ids))
+	for _, hotelID := range ids {
+		h, ok := byID[hotelID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrHotelNotFound, hotelID)
+		}
+		hotels = append(hotels, h)
+	}
+
+	uc.logger.Debug("Comparing hotels", "hotel_ids", ids)
+
+	return &ComparisonResult{
+		Hotels:     hotels,
+		Attributes: append(detailAttributes(hotels), amenityAttributes(hotels)...),
+	}, nil
+}
+
+func detailAttributes(hotels []*hotel.Hotel) []ComparisonAttribute {
+	details := []struct {
+		name  string
+		value func(h *hotel.Hotel) string
+	}{
+		{"Star Rating", func(h *hotel.Hotel) string { return strconv.Itoa(int(h.StarRating)) }},
+		{"Rating", func(h *hotel.Hotel) string { return strconv.FormatFloat(h.Rating, 'f', -1, 64) }},
+		{"Review Count", func(h *hotel.Hotel) string { return strconv.Itoa(int(h.ReviewCount)) }},
+		{"Child Allowed", func(h *hotel.Hotel) string { return yesNo(h.ChildAllowed) }},
+		{"Pets Allowed", func(h *hotel.Hotel) string { return yesNo(h.PetsAllowed) }},
+		{"Parking", func(h *hotel.Hotel) string { return h.Parking }},
+		{"Check-in Start", checkinStart},
+		{"Max Occupancy", func(h *hotel.Hotel) string { return strconv.Itoa(maxOccupancy(h)) }},
+	}
+
+	attributes := make([]ComparisonAttribute, 0, len(details))
+	for _, detail := range details {
+		values := make([]string, len(hotels))
+		for i, h := range hotels {
+			values[i] = detail.value(h)
+		}
+		attributes = append(attributes, ComparisonAttribute{
+			Name:   detail.name,
+			Group:  ComparisonGroupDetails,
+			Values: values,
+			HasAll: allEqual(values),
+		})
+	}
+	return attributes
+}
+
+// amenityAttributes compares the union of the hotels' amenities, shared ones first and each
+// group in alphabetical order. Amenities are matched case-insensitively.
+func amenityAttributes(hotels []*hotel.Hotel) []ComparisonAttribute {
+	offered := make([]map[string]bool, len(hotels))
+	names := make(map[string]string)
+	for i, h := range hotels {
+		offered[i] = make(map[string]bool, len(h.Amenities))
+		for _, amenity := range h.Amenities {
+			amenity = strings.TrimSpace(amenity)
+			key := strings.ToLower(amenity)
+			if key == "" {
+				continue
+			}
+			offered[i][key] = true
+			if _, ok := names[key]; !ok {
+				names[key] = amenity
+			}
+		}
+	}
+
+	attributes := make([]ComparisonAttribute, 0, len(names))
+	for key, name := range names {
+		attribute := ComparisonAttribute{
+			Name:   name,
+			Group:  ComparisonGroupAmenities,
+			Values: make([]string, len(hotels)),
+			HasAll: true,
+		}
+		for i := range hotels {
+			attribute.Values[i] = yesNo(offered[i][key])
+			attribute.HasAll = attribute.HasAll && offered[i][key]
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	slices.SortFunc(attributes, func(a, b ComparisonAttribute) int {
+		if a.HasAll != b.HasAll {
+			if a.HasAll {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return attributes
+}
+
+func checkinStart(h *hotel.Hotel) string {
+	if h.CheckinWindow.Is24h {
+		return "24h"
+	}
+	return h.CheckinWindow.Start
+}
+
+func maxOccupancy(h *hotel.Hotel) int {
+	occupancy := 0
+	for _, room := range h.Rooms {
+		occupancy = max(occupancy, room.MaxOccupancy)
+	}
+	return occupancy
+}
+
+func yesNo(value bool) string {
+	if value {
+		return comparisonYes
+	}
+	return comparisonNo
+}
+
+func allEqual(values []string) bool {
+	for _, value := range values[1:] {
+		if value != values[0] {
+			return false
+		}
+	}
+	return true
+}
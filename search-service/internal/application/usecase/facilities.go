@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	facilitiesCacheKey = "facilities:counts"
+	facilitiesTTL      = 10 * time.Minute
+)
+
+// UnmappedFacility is a raw facility name the taxonomy does not map, with the slug it falls
+// through to.
+type UnmappedFacility struct {
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	HotelCount int    `json:"hotel_count"`
+}
+
+// FacilitiesUseCase lists the canonical facilities of the taxonomy and the raw names it
+// misses, and normalizes the facility names clients filter by.
+type FacilitiesUseCase struct {
+	hotelRepo hotel.Repository
+	cache     hotel.CacheRepository
+	taxonomy  *facilities.Taxonomy
+	logger    *slog.Logger
+}
+
+func NewFacilitiesUseCase(hotelRepo hotel.Repository, cache hotel.CacheRepository, taxonomy *facilities.Taxonomy, logger *slog.Logger) *FacilitiesUseCase {
+	return &FacilitiesUseCase{
+		hotelRepo: hotelRepo,
+		cache:     cache,
+		taxonomy:  taxonomy,
+		logger:    logger,
+	}
+}
+
+// List returns the canonical facilities listed by at least one active hotel, most common
+// first. Slugs that fell through unmapped are left to Unmapped.
+func (uc *FacilitiesUseCase) List(ctx context.Context) ([]hotel.FacilityCount, error) {
+	if cachedData, err := uc.cache.Get(ctx, facilitiesCacheKey); err == nil {
+		var cached []hotel.FacilityCount
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			return cached, nil
+		}
+		uc.logger.Warn("Failed to unmarshal cached facilities", "error", err)
+	}
+
+	counts, err := uc.hotelRepo.CountAmenities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list facilities: %w", err)
+	}
+
+	list := make([]hotel.FacilityCount, 0, len(counts))
+	for _, count := range counts {
+		facility, ok := uc.taxonomy.Lookup(count.Slug)
+		if !ok {
+			continue
+		}
+		list = append(list, hotel.FacilityCount{Slug: facility.Slug, Name: facility.Name, HotelCount: count.HotelCount})
+	}
+
+	if data, err := json.Marshal(list); err == nil {
+		if err := uc.cache.Set(ctx, facilitiesCacheKey, data, facilitiesTTL); err != nil {
+			uc.logger.Warn("Failed to cache facilities", "error", err)
+		}
+	}
+
+	return list, nil
+}
+
+// Unmapped returns the raw facility names no alias maps, most common first, so the taxonomy
+// can be extended through facility_aliases.
+func (uc *FacilitiesUseCase) Unmapped(ctx context.Context) ([]UnmappedFacility, error) {
+	counts, err := uc.hotelRepo.CountRawFacilities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmapped facilities: %w", err)
+	}
+
+	unmapped := make([]UnmappedFacility, 0)
+	for _, count := range counts {
+		slug, mapped := uc.taxonomy.Normalize(count.Name)
+		if mapped {
+			continue
+		}
+		unmapped = append(unmapped, UnmappedFacility{Name: count.Name, Slug: slug, HotelCount: count.HotelCount})
+	}
+
+	return unmapped, nil
+}
+
+// Slugs normalizes facility names to their distinct canonical slugs, so "Free WiFi" and
+// "wifi" filter alike.
+func (uc *FacilitiesUseCase) Slugs(names []string) []string {
+	return uc.taxonomy.Slugs(names)
+}
+
+// Slug normalizes one facility name to its canonical slug.
+func (uc *FacilitiesUseCase) Slug(name string) string {
+	slug, _ := uc.taxonomy.Normalize(name)
+	return slug
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFacilitiesListCountsCanonicalFacilities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	cache := newFakeCache()
+	uc := NewFacilitiesUseCase(repository, cache, facilities.Default(), slog.New(slog.DiscardHandler))
+	ctx := context.Background()
+
+	repository.EXPECT().CountAmenities(ctx).Return([]hotel.FacilityCount{
+		{Slug: "wifi", HotelCount: 12},
+		{Slug: "rooftop_garden", HotelCount: 4},
+		{Slug: "pool", HotelCount: 3},
+	}, nil).Times(1)
+
+	list, err := uc.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []hotel.FacilityCount{
+		{Slug: "wifi", Name: "WiFi", HotelCount: 12},
+		{Slug: "pool", Name: "Swimming pool", HotelCount: 3},
+	}, list, "slugs that fell through unmapped are not canonical facilities")
+
+	cached, err := uc.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, list, cached, "the counts are served from the cache")
+}
+
+func TestFacilitiesUnmappedReportsNamesNoAliasMaps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	taxonomy, err := facilities.New(map[string]string{"Sky Lounge": "bar"})
+	require.NoError(t, err)
+	uc := NewFacilitiesUseCase(repository, newFakeCache(), taxonomy, slog.New(slog.DiscardHandler))
+
+	repository.EXPECT().CountRawFacilities(gomock.Any()).Return([]hotel.FacilityCount{
+		{Name: "Free WiFi", HotelCount: 9},
+		{Name: "Rooftop Garden", HotelCount: 4},
+		{Name: "Sky Lounge", HotelCount: 2},
+	}, nil)
+
+	unmapped, err := uc.Unmapped(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []UnmappedFacility{{Name: "Rooftop Garden", Slug: "rooftop_garden", HotelCount: 4}}, unmapped)
+}
+
+func TestFacilitiesSlugsFilterVariedNamesAlike(t *testing.T) {
+	uc := NewFacilitiesUseCase(nil, nil, facilities.Default(), slog.New(slog.DiscardHandler))
+
+	assert.Equal(t, []string{"wifi", "pool"}, uc.Slugs([]string{"Free WiFi", "wi-fi", "WIFI", "Outdoor swimming pool", ""}))
+	assert.Equal(t, "fitness_center", uc.Slug("Gym"))
+}
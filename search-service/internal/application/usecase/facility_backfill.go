@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	facilityBackfillJobKeyPrefix     = "facility_backfill:job:"
+	facilityBackfillCursorKey        = "facility_backfill:cursor"
+	facilityBackfillJobTTL           = 7 * 24 * time.Hour
+	defaultFacilityBackfillBatchSize = 200
+)
+
+var (
+	ErrFacilityBackfillJobNotFound = errors.New("facility backfill job not found")
+	ErrFacilityBackfillRunning     = errors.New("facility backfill is already running")
+	ErrInvalidFacilityBackfill     = errors.New("invalid facility backfill options")
+)
+
+type FacilityBackfillOptions struct {
+	BatchSize int  `json:"batch_size,omitempty"`
+	Restart   bool `json:"restart,omitempty"`
+}
+
+type FacilityBackfillJob struct {
+	ID              string     `json:"id"`
+	Status          JobStatus  `json:"status"`
+	ProcessedHotels int        `json:"processed_hotels"`
+	UpdatedHotels   int        `json:"updated_hotels"`
+	FailedHotels    int        `json:"failed_hotels"`
+	ResumedFrom     int64      `json:"resumed_from,omitempty"`
+	LastHotelID     int64      `json:"last_hotel_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// FacilityBackfillUseCase is a maintenance task that brings the hotels stored before the
+// facility taxonomy, or before facility_aliases last changed, into it. It sets the canonical
+// slug of every stored facility, stores the distinct slugs as the hotel's amenities and
+// updates the amenities of the search index, so /facilities and the amenities filter see
+// those hotels without waiting for them to be refetched. Like review dedupe it works through
+// hotels in hotel_id order and resumes from its saved cursor.
+type FacilityBackfillUseCase struct {
+	hotelRepo    hotel.Repository
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	taxonomy     *facilities.Taxonomy
+	logger       *slog.Logger
+
+	running atomic.Bool
+}
+
+func NewFacilityBackfillUseCase(hotelRepo hotel.Repository, searchEngine search.Engine, cache hotel.CacheRepository, taxonomy *facilities.Taxonomy, logger *slog.Logger) *FacilityBackfillUseCase {
+	return &FacilityBackfillUseCase{
+		hotelRepo:    hotelRepo,
+		searchEngine: searchEngine,
+		cache:        cache,
+		taxonomy:     taxonomy,
+		logger:       logger,
+	}
+}
+
+// Start records a new job and runs the backfill in the background. Only one backfill runs per
+// instance at a time.
+func (uc *FacilityBackfillUseCase) Start(ctx context.Context, options FacilityBackfillOptions) (*FacilityBackfillJob, error) {
+	if options.BatchSize == 0 {
+		options.BatchSize = defaultFacilityBackfillBatchSize
+	}
+	if options.BatchSize < 0 {
+		return nil, fmt.Errorf("%w: batch_size must be positive", ErrInvalidFacilityBackfill)
+	}
+
+	if !uc.running.CompareAndSwap(false, true) {
+		return nil, ErrFacilityBackfillRunning
+	}
+
+	if options.Restart {
+		if err := uc.cache.Delete(ctx, facilityBackfillCursorKey); err != nil {
+			uc.logger.Warn("Failed to reset facility backfill cursor", "error", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	job := &FacilityBackfillJob{
+		ID:        uuid.NewString(),
+		Status:    JobStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	job.ResumedFrom = uc.loadCursor(ctx)
+	job.LastHotelID = job.ResumedFrom
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		uc.running.Store(false)
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		defer uc.running.Store(false)
+		if err := uc.Run(context.Background(), job, options.BatchSize); err != nil {
+			uc.logger.Error("Facility backfill failed", "job_id", job.ID, "error", err)
+		}
+	}()
+
+	return &snapshot, nil
+}
+
+// Run normalizes the facilities of batchSize hotels at a time after the job's cursor. A hotel
+// whose index update fails is counted as failed; its database row is already normalized, so
+// the next sync indexes its amenities.
+func (uc *FacilityBackfillUseCase) Run(ctx context.Context, job *FacilityBackfillJob, batchSize int) error {
+	uc.logger.Info("Starting facility backfill", "job_id", job.ID, "resume_from", job.LastHotelID)
+
+	for {
+		batch, err := uc.hotelRepo.NormalizeFacilities(ctx, job.LastHotelID, batchSize, uc.slug)
+		if err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels == 0 {
+			break
+		}
+
+		for hotelID, amenities := range batch.Updated {
+			if err := uc.searchEngine.PartialUpdate(ctx, hotelID, map[string]any{"amenities": amenities}); err != nil {
+				uc.logger.Warn("Failed to update indexed amenities", "job_id", job.ID, "hotel_id", hotelID, "error", err)
+				job.FailedHotels++
+				continue
+			}
+			job.UpdatedHotels++
+		}
+
+		job.ProcessedHotels += batch.Hotels
+		job.LastHotelID = batch.LastHotelID
+		job.UpdatedAt = time.Now().UTC()
+
+		uc.saveCursor(ctx, job.LastHotelID)
+		if err := uc.saveJob(ctx, job); err != nil {
+			uc.logger.Warn("Failed to save facility backfill progress", "job_id", job.ID, "error", err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels < batchSize {
+			break
+		}
+	}
+
+	if err := uc.cache.Delete(ctx, facilityBackfillCursorKey); err != nil {
+		uc.logger.Warn("Failed to clear facility backfill cursor", "job_id", job.ID, "error", err)
+	}
+	if err := uc.cache.Delete(ctx, facilitiesCacheKey); err != nil {
+		uc.logger.Warn("Failed to invalidate cached facilities", "job_id", job.ID, "error", err)
+	}
+	uc.finishJob(ctx, job, JobStatusCompleted, nil)
+
+	uc.logger.Info("Facility backfill completed",
+		"job_id", job.ID,
+		"processed_hotels", job.ProcessedHotels,
+		"updated_hotels", job.UpdatedHotels,
+		"failed_hotels", job.FailedHotels)
+
+	return nil
+}
+
+func (uc *FacilityBackfillUseCase) GetJob(ctx context.Context, jobID string) (*FacilityBackfillJob, error) {
+	data, err := uc.cache.Get(ctx, facilityBackfillJobKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrFacilityBackfillJobNotFound
+	}
+
+	var job FacilityBackfillJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode facility backfill job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (uc *FacilityBackfillUseCase) slug(name string) string {
+	slug, _ := uc.taxonomy.Normalize(name)
+	return slug
+}
+
+func (uc *FacilityBackfillUseCase) finishJob(ctx context.Context, job *FacilityBackfillJob, status JobStatus, cause error) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	if err := uc.saveJob(context.WithoutCancel(ctx), job); err != nil {
+		uc.logger.Warn("Failed to save facility backfill job status", "job_id", job.ID, "error", err)
+	}
+}
+
+func (uc *FacilityBackfillUseCase) saveJob(ctx context.Context, job *FacilityBackfillJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode facility backfill job: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, facilityBackfillJobKeyPrefix+job.ID, data, facilityBackfillJobTTL); err != nil {
+		return fmt.Errorf("failed to save facility backfill job: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *FacilityBackfillUseCase) loadCursor(ctx context.Context) int64 {
+	data, err := uc.cache.Get(ctx, facilityBackfillCursorKey)
+	if err != nil {
+		return 0
+	}
+
+	cursor, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		uc.logger.Warn("Ignoring invalid facility backfill cursor", "error", err)
+		return 0
+	}
+
+	return cursor
+}
+
+func (uc *FacilityBackfillUseCase) saveCursor(ctx context.Context, hotelID int64) {
+	value := []byte(strconv.FormatInt(hotelID, 10))
+	if err := uc.cache.Set(ctx, facilityBackfillCursorKey, value, facilityBackfillJobTTL); err != nil {
+		uc.logger.Warn("Failed to save facility backfill cursor", "hotel_id", hotelID, "error", err)
+	}
+}
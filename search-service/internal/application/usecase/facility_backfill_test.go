@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newFacilityBackfillTest(t *testing.T) (*FacilityBackfillUseCase, *mocks.MockRepository, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewFacilityBackfillUseCase(repository, engine, cache, facilities.Default(), slog.New(slog.DiscardHandler))
+	return uc, repository, engine, cache
+}
+
+func TestFacilityBackfillNormalizesAndReindexesAmenities(t *testing.T) {
+	uc, repository, engine, cache := newFacilityBackfillTest(t)
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, facilitiesCacheKey, []byte(`[]`), 0))
+
+	repository.EXPECT().NormalizeFacilities(ctx, int64(0), 2, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ int, normalize func(string) string) (hotel.FacilityNormalizeBatch, error) {
+			assert.Equal(t, "wifi", normalize("Free WiFi"))
+			assert.Equal(t, "wifi", normalize("WI-FI"))
+			assert.Equal(t, "rooftop_garden", normalize("Rooftop Garden"), "unmapped names fall through as slugs")
+			return hotel.FacilityNormalizeBatch{LastHotelID: 2, Hotels: 2, Updated: map[int64][]string{1: {"wifi"}, 2: {"pool", "spa"}}}, nil
+		})
+	repository.EXPECT().NormalizeFacilities(ctx, int64(2), 2, gomock.Any()).
+		Return(hotel.FacilityNormalizeBatch{LastHotelID: 3, Hotels: 1, Updated: map[int64][]string{3: {"gym"}}}, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"amenities": []string{"wifi"}}).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(2), map[string]any{"amenities": []string{"pool", "spa"}}).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(3), map[string]any{"amenities": []string{"gym"}}).Return(errors.New("not indexed"))
+
+	job := &FacilityBackfillJob{ID: "job"}
+	require.NoError(t, uc.Run(ctx, job, 2))
+
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 3, job.ProcessedHotels)
+	assert.Equal(t, 2, job.UpdatedHotels)
+	assert.Equal(t, 1, job.FailedHotels)
+	assert.NotContains(t, cache.values, facilityBackfillCursorKey)
+	assert.NotContains(t, cache.values, facilitiesCacheKey, "the cached facility counts are recomputed")
+}
+
+func TestFacilityBackfillResumesFromCursor(t *testing.T) {
+	uc, repository, _, cache := newFacilityBackfillTest(t)
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, facilityBackfillCursorKey, []byte("41"), 0))
+
+	repository.EXPECT().NormalizeFacilities(gomock.Any(), int64(41), defaultFacilityBackfillBatchSize, gomock.Any()).
+		Return(hotel.FacilityNormalizeBatch{}, nil)
+
+	job, err := uc.Start(ctx, FacilityBackfillOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(41), job.ResumedFrom)
+
+	require.Eventually(t, func() bool {
+		stored, err := uc.GetJob(ctx, job.ID)
+		return err == nil && stored.Status == JobStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestFacilityBackfillKeepsCursorOnFailure(t *testing.T) {
+	uc, repository, engine, cache := newFacilityBackfillTest(t)
+	ctx := context.Background()
+
+	repository.EXPECT().NormalizeFacilities(ctx, int64(0), 1, gomock.Any()).
+		Return(hotel.FacilityNormalizeBatch{LastHotelID: 5, Hotels: 1, Updated: map[int64][]string{5: {"wifi"}}}, nil)
+	repository.EXPECT().NormalizeFacilities(ctx, int64(5), 1, gomock.Any()).
+		Return(hotel.FacilityNormalizeBatch{}, errors.New("connection reset"))
+	engine.EXPECT().PartialUpdate(ctx, int64(5), gomock.Any()).Return(nil)
+
+	job := &FacilityBackfillJob{ID: "job"}
+	require.Error(t, uc.Run(ctx, job, 1))
+
+	assert.Equal(t, JobStatusFailed, job.Status)
+	assert.Equal(t, []byte("5"), cache.values[facilityBackfillCursorKey], "a restart resumes after the last normalized hotel")
+}
+
+func TestFacilityBackfillRejectsNegativeBatchSize(t *testing.T) {
+	uc, _, _, _ := newFacilityBackfillTest(t)
+
+	_, err := uc.Start(context.Background(), FacilityBackfillOptions{BatchSize: -1})
+	assert.ErrorIs(t, err, ErrInvalidFacilityBackfill)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// fakeCache is an in-memory hotel.CacheRepository.
+type fakeCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: make(map[string][]byte)}
+}
+
+func (c *fakeCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return nil, errors.New("cache miss")
+	}
+	return value, nil
+}
+
+func (c *fakeCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *fakeCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	return nil
+}
+
+func (c *fakeCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+// Increment implements hotel.Counter on the same values, as the Redis cache does.
+func (c *fakeCache) Increment(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, _ := strconv.ParseInt(string(c.values[key]), 10, 64)
+	current++
+	c.values[key] = []byte(strconv.FormatInt(current, 10))
+	return current, nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+)
+
+// fakeSyncHistory is an in-memory synchistory.Repository.
+type fakeSyncHistory struct {
+	mu   sync.Mutex
+	runs []synchistory.Run
+}
+
+func (h *fakeSyncHistory) Save(_ context.Context, run *synchistory.Run) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	run.ID = int64(len(h.runs) + 1)
+	h.runs = append(h.runs, *run)
+	return nil
+}
+
+func (h *fakeSyncHistory) List(_ context.Context, options synchistory.ListOptions) ([]synchistory.Run, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var runs []synchistory.Run
+	for i := len(h.runs) - 1; i >= 0; i-- {
+		if !h.runs[i].StartedAt.Before(options.Since) {
+			runs = append(runs, h.runs[i])
+		}
+	}
+	return runs, nil
+}
+
+func (h *fakeSyncHistory) Summarize(_ context.Context, since time.Time) (synchistory.Summary, error) {
+	return synchistory.Summary{Since: since}, nil
+}
+
+func (h *fakeSyncHistory) Last(_ context.Context) (*synchistory.Run, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.runs) == 0 {
+		return nil, nil
+	}
+	last := h.runs[len(h.runs)-1]
+	return &last, nil
+}
+
+func (h *fakeSyncHistory) DeleteBefore(_ context.Context, cutoff time.Time) (int64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	kept := h.runs[:0]
+	for _, run := range h.runs {
+		if !run.StartedAt.Before(cutoff) {
+			kept = append(kept, run)
+		}
+	}
+	deleted := int64(len(h.runs) - len(kept))
+	h.runs = kept
+	return deleted, nil
+}
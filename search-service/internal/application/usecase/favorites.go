@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/favorites"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+// FavoritesUseCase manages the hotels bookmarked by anonymous sessions.
+type FavoritesUseCase struct {
+	store     favorites.Store
+	hotelRepo hotel.Repository
+	logger    *slog.Logger
+}
+
+func NewFavoritesUseCase(store favorites.Store, hotelRepo hotel.Repository, logger *slog.Logger) *FavoritesUseCase {
+	return &FavoritesUseCase{
+		store:     store,
+		hotelRepo: hotelRepo,
+		logger:    logger,
+	}
+}
+
+// Add bookmarks a hotel for the session and returns the session's favorites. It returns
+// ErrHotelNotFound for hotels that are not stored.
+func (uc *FavoritesUseCase) Add(ctx context.Context, sessionID string, hotelID int64) ([]int64, error) {
+	hotels, err := uc.hotelRepo.FindByHotelIDs(ctx, []int64{hotelID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find hotel: %w", err)
+	}
+	if len(hotels) == 0 {
+		return nil, ErrHotelNotFound
+	}
+
+	if err := uc.store.Add(ctx, sessionID, hotelID); err != nil {
+		return nil, err
+	}
+	return uc.store.List(ctx, sessionID)
+}
+
+// Remove drops a hotel from the session's favorites and returns the ones left.
+func (uc *FavoritesUseCase) Remove(ctx context.Context, sessionID string, hotelID int64) ([]int64, error) {
+	if err := uc.store.Remove(ctx, sessionID, hotelID); err != nil {
+		return nil, err
+	}
+	return uc.store.List(ctx, sessionID)
+}
+
+// List returns the hotel ids bookmarked by the session.
+func (uc *FavoritesUseCase) List(ctx context.Context, sessionID string) ([]int64, error) {
+	return uc.store.List(ctx, sessionID)
+}
+
+// ListHotels returns the hotels bookmarked by the session. Favorites whose hotel has since been
+// deleted are left out.
+func (uc *FavoritesUseCase) ListHotels(ctx context.Context, sessionID string) ([]*hotel.Hotel, error) {
+	hotelIDs, err := uc.store.List(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotels, err := uc.hotelRepo.FindByHotelIDs(ctx, hotelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load favorite hotels: %w", err)
+	}
+	if missing := len(hotelIDs) - len(hotels); missing > 0 {
+		uc.logger.Debug("Favorites reference hotels that no longer exist", "session_favorites", len(hotelIDs), "missing", missing)
+	}
+	return hotels, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	chainSuggestionsTTL = 30 * time.Minute
+	maxChainSuggestions = 50
+)
+
+// GetChainSuggestionsUseCase suggests hotel chains for an autocomplete query, so a user typing
+// "mar" can filter by Marriott rather than pick a single hotel.
+type GetChainSuggestionsUseCase struct {
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	logger       *slog.Logger
+}
+
+func NewGetChainSuggestionsUseCase(
+	searchEngine search.Engine,
+	cache hotel.CacheRepository,
+	logger *slog.Logger,
+) *GetChainSuggestionsUseCase {
+	return &GetChainSuggestionsUseCase{
+		searchEngine: searchEngine,
+		cache:        cache,
+		logger:       logger,
+	}
+}
+
+// Execute returns the chains whose name, or one of its words, starts with query, largest
+// chains first.
+func (uc *GetChainSuggestionsUseCase) Execute(ctx context.Context, query string, limit int) ([]search.ChainSuggestion, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > maxChainSuggestions {
+		limit = maxChainSuggestions
+	}
+
+	cacheKey := fmt.Sprintf("chain_suggestions:%s:%d", strings.ToLower(query), limit)
+
+	if cachedData, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var cachedSuggestions []search.ChainSuggestion
+		if err := json.Unmarshal(cachedData, &cachedSuggestions); err == nil {
+			return cachedSuggestions, nil
+		}
+		uc.logger.Warn("Failed to unmarshal cached chain suggestions", "error", err)
+	}
+
+	values, err := uc.searchEngine.GetFacetValues(ctx, "chain", query, maxChainSuggestions)
+	if err != nil {
+		uc.logger.Error("Failed to get chain facet values", "query", query, "error", err)
+		return nil, fmt.Errorf("failed to get chain suggestions: %w", err)
+	}
+
+	suggestions := make([]search.ChainSuggestion, 0, len(values))
+	for _, value := range values {
+		// The engine also matches typos; only keep chains the query actually completes.
+		if value.Value == "" || search.SuggestionScore(value.Value, query) < 0.8 {
+			continue
+		}
+		suggestions = append(suggestions, search.ChainSuggestion{Chain: value.Value, HotelCount: value.Count})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b search.ChainSuggestion) int {
+		return cmp.Compare(b.HotelCount, a.HotelCount)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	if data, err := json.Marshal(suggestions); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, data, chainSuggestionsTTL); err != nil {
+			uc.logger.Warn("Failed to cache chain suggestions", "error", err)
+		}
+	}
+
+	return suggestions, nil
+}
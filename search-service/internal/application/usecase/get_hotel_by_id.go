@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/victoragudo/hotel-management-system/pkg/constants"
@@ -12,11 +14,60 @@ import (
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
 )
 
+var ErrInvalidSourceLookup = errors.New("source and source_id are required")
+
+// hotelCacheReviews is how many reviews are fetched from the provider when a hotel is not in
+// the database and the reviews limit is unbounded. The cache keeps one canonical entry per
+// hotel and every limit is served by truncating its collections, so the entry holds as many
+// reviews as the largest limit a request may ask for.
+const hotelCacheReviews = 500
+
+const (
+	DataFresh = "fresh"
+	DataStale = "stale"
+)
+
+// Freshness tells API consumers whether the hotel data is overdue for a refresh. A stale hotel
+// reports when it was last updated, a fresh one when its next update is due.
+type Freshness struct {
+	DataFreshness string     `json:"data_freshness"`
+	LastUpdated   *time.Time `json:"last_updated,omitempty"`
+	NextUpdateAt  *time.Time `json:"next_update_at,omitempty"`
+}
+
+// HotelMeta is the meta of a hotel detail response: the freshness of its data and how its
+// embedded collections were truncated.
+type HotelMeta struct {
+	Freshness
+	hotel.Truncation
+}
+
+func hotelMeta(h *hotel.Hotel, limits hotel.CollectionLimits, now time.Time) HotelMeta {
+	return HotelMeta{
+		Freshness:  hotelFreshness(h, now),
+		Truncation: h.Truncate(limits),
+	}
+}
+
+func hotelFreshness(h *hotel.Hotel, now time.Time) Freshness {
+	if h.IsStale(now) {
+		lastUpdated := h.UpdatedAt
+		return Freshness{DataFreshness: DataStale, LastUpdated: &lastUpdated}
+	}
+	freshness := Freshness{DataFreshness: DataFresh}
+	if !h.NextUpdateAt.IsZero() {
+		nextUpdateAt := h.NextUpdateAt
+		freshness.NextUpdateAt = &nextUpdateAt
+	}
+	return freshness
+}
+
 type GetHotelByIDUseCase struct {
 	hotelRepo     hotel.Repository
 	hotelProvider hotel.Provider
 	searchEngine  search.Engine
 	cache         hotel.CacheRepository
+	maxReviews    int
 	logger        *slog.Logger
 }
 
@@ -25,18 +76,25 @@ func NewGetHotelByIDUseCase(
 	hotelProvider hotel.Provider,
 	searchEngine search.Engine,
 	cache hotel.CacheRepository,
+	maxReviews int,
 	logger *slog.Logger,
 ) *GetHotelByIDUseCase {
+	if maxReviews <= 0 {
+		maxReviews = hotelCacheReviews
+	}
 	return &GetHotelByIDUseCase{
 		hotelRepo:     hotelRepo,
 		hotelProvider: hotelProvider,
 		searchEngine:  searchEngine,
 		cache:         cache,
+		maxReviews:    maxReviews,
 		logger:        logger,
 	}
 }
 
-func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hotelID int64, reviewsCount int) (*hotel.Hotel, error) {
+// Execute returns the hotel with its embedded collections truncated to limits, and the
+// freshness of its data. A cached hotel is judged by the update dates it was cached with.
+func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hotelID int64, limits hotel.CollectionLimits) (*hotel.Hotel, HotelMeta, error) {
 	startTime := time.Now()
 
 	getHotelByIdUseCase.logger.Info("Getting hotel by ID", constants.HotelId, hotelID)
@@ -45,7 +103,7 @@ func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hot
 	if cachedData, err := getHotelByIdUseCase.cache.Get(ctx, cacheKey); err == nil {
 		var cachedHotel hotel.Hotel
 		if err := json.Unmarshal(cachedData, &cachedHotel); err == nil {
-			return &cachedHotel, nil
+			return &cachedHotel, hotelMeta(&cachedHotel, limits, time.Now()), nil
 		}
 		getHotelByIdUseCase.logger.Warn("Failed to unmarshal cached hotel", constants.HotelId, hotelID, "error", err)
 	}
@@ -56,7 +114,7 @@ func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hot
 			_ = getHotelByIdUseCase.cache.Set(ctx, cacheKey, hotelData, 5*time.Minute)
 		}
 		go getHotelByIdUseCase.indexHotel(*foundHotel)
-		return foundHotel, nil
+		return foundHotel, hotelMeta(foundHotel, limits, time.Now()), nil
 	}
 	if err != nil {
 		getHotelByIdUseCase.logger.Warn("Error querying hotel from database", constants.HotelId, hotelID, "error", err)
@@ -67,14 +125,15 @@ func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hot
 	externalHotel, err := getHotelByIdUseCase.hotelProvider.GetHotelByID(ctx, hotelID)
 	if err != nil {
 		getHotelByIdUseCase.logger.Error("Failed to fetch hotel from Cupid API", constants.HotelId, hotelID, "error", err)
-		return nil, fmt.Errorf("hotel not found in database and failed to fetch from external API: %w", err)
+		return nil, HotelMeta{}, fmt.Errorf("hotel not found in database and failed to fetch from external API: %w", err)
 	}
 
-	if reviews, err := getHotelByIdUseCase.hotelProvider.GetHotelReviews(ctx, hotelID, reviewsCount); err == nil {
+	if reviews, err := getHotelByIdUseCase.hotelProvider.GetHotelReviews(ctx, hotelID, getHotelByIdUseCase.maxReviews); err == nil {
 		reviewSlice := make([]hotel.Review, len(reviews))
 		for i, review := range reviews {
 			reviewSlice[i] = *review
 		}
+		hotel.SortReviewsByDate(reviewSlice)
 		externalHotel.Reviews = reviewSlice
 	} else {
 		getHotelByIdUseCase.logger.Warn("Failed to fetch hotel reviews", constants.HotelId, hotelID, "error", err)
@@ -106,7 +165,23 @@ func (getHotelByIdUseCase *GetHotelByIDUseCase) Execute(ctx context.Context, hot
 		}
 	}
 	getHotelByIdUseCase.logger.Info("Hotel fetched from external API", "hotel_id", hotelID, "duration", time.Since(startTime))
-	return externalHotel, nil
+	return externalHotel, hotelMeta(externalHotel, limits, time.Now()), nil
+}
+
+// FindBySource returns the hotels that an external data source identifies as sourceID.
+func (getHotelByIdUseCase *GetHotelByIDUseCase) FindBySource(ctx context.Context, source, sourceID string) ([]*hotel.Hotel, error) {
+	source = strings.ToLower(strings.TrimSpace(source))
+	sourceID = strings.TrimSpace(sourceID)
+	if source == "" || sourceID == "" {
+		return nil, ErrInvalidSourceLookup
+	}
+
+	hotels, err := getHotelByIdUseCase.hotelRepo.FindBySourceID(ctx, source, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find hotels by source id: %w", err)
+	}
+
+	return hotels, nil
 }
 
 func (getHotelByIdUseCase *GetHotelByIDUseCase) indexHotel(h hotel.Hotel) {
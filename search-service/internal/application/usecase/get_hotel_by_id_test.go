@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+type getHotelByIDTest struct {
+	uc       *GetHotelByIDUseCase
+	repo     *mocks.MockRepository
+	provider *mocks.MockProvider
+	cache    *fakeCache
+	indexed  chan int64
+}
+
+func newGetHotelByIDTest(t *testing.T, maxReviews int) *getHotelByIDTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	test := &getHotelByIDTest{
+		repo:     mocks.NewMockRepository(ctrl),
+		provider: mocks.NewMockProvider(ctrl),
+		cache:    newFakeCache(),
+		indexed:  make(chan int64, 1),
+	}
+	engine := mocks.NewMockEngine(ctrl)
+	engine.EXPECT().UpdateHotel(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, h *hotel.Hotel) error {
+		test.indexed <- h.HotelID
+		return nil
+	}).AnyTimes()
+	test.uc = NewGetHotelByIDUseCase(test.repo, test.provider, engine, test.cache, maxReviews, slog.New(slog.DiscardHandler))
+	return test
+}
+
+// waitIndexed waits for the background indexing of a hotel, so no mock is called after the test.
+func (test *getHotelByIDTest) waitIndexed(t *testing.T) {
+	t.Helper()
+	select {
+	case <-test.indexed:
+	case <-time.After(time.Second):
+		t.Fatal("the hotel was not indexed")
+	}
+}
+
+func testReviews(count int) []*hotel.Review {
+	reviews := make([]*hotel.Review, count)
+	for i := range reviews {
+		reviews[i] = &hotel.Review{ReviewID: int64(i + 1), Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)}
+	}
+	return reviews
+}
+
+func TestGetHotelByIDCachesEveryProviderReviewUpToTheResponseMaximum(t *testing.T) {
+	test := newGetHotelByIDTest(t, 200)
+	ctx := context.Background()
+
+	test.repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(nil, errors.New("record not found"))
+	test.provider.EXPECT().GetHotelByID(ctx, int64(7)).Return(&hotel.Hotel{HotelID: 7, Name: "Harbour Hotel"}, nil)
+	test.provider.EXPECT().GetHotelReviews(ctx, int64(7), 200).Return(testReviews(120), nil)
+	test.provider.EXPECT().GetHotelTranslations(ctx, int64(7), gomock.Any()).Return(nil, nil)
+	test.repo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	found, meta, err := test.uc.Execute(ctx, 7, hotel.CollectionLimits{Reviews: 10})
+	require.NoError(t, err)
+	test.waitIndexed(t)
+	assert.Len(t, found.Reviews, 10)
+	assert.Equal(t, 120, meta.ReviewsTotal, "the total counts every fetched review, not the first request's limit")
+	assert.True(t, meta.ReviewsTruncated)
+
+	// A later request with a larger limit is served from the same cache entry.
+	var cached hotel.Hotel
+	require.NoError(t, json.Unmarshal(test.cache.values["hotel:7"], &cached))
+	assert.Len(t, cached.Reviews, 120)
+
+	found, meta, err = test.uc.Execute(ctx, 7, hotel.CollectionLimits{Reviews: 100})
+	require.NoError(t, err)
+	assert.Len(t, found.Reviews, 100)
+	assert.Equal(t, 120, meta.ReviewsTotal)
+}
+
+func TestGetHotelByIDCachesEveryStoredReview(t *testing.T) {
+	test := newGetHotelByIDTest(t, 50)
+	ctx := context.Background()
+
+	stored := &hotel.Hotel{HotelID: 7, Name: "Harbour Hotel"}
+	for _, review := range testReviews(80) {
+		stored.Reviews = append(stored.Reviews, *review)
+	}
+	test.repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(stored, nil)
+
+	found, meta, err := test.uc.Execute(ctx, 7, hotel.CollectionLimits{Reviews: 50})
+	require.NoError(t, err)
+	test.waitIndexed(t)
+	assert.Len(t, found.Reviews, 50)
+	assert.Equal(t, 80, meta.ReviewsTotal)
+
+	var cached hotel.Hotel
+	require.NoError(t, json.Unmarshal(test.cache.values["hotel:7"], &cached))
+	assert.Len(t, cached.Reviews, 80, "stored reviews are cached beyond the response maximum")
+}
+
+func TestGetHotelByIDFetchesTheDefaultReviewCountWithoutAMaximum(t *testing.T) {
+	test := newGetHotelByIDTest(t, 0)
+	ctx := context.Background()
+
+	test.repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(nil, nil)
+	test.provider.EXPECT().GetHotelByID(ctx, int64(7)).Return(&hotel.Hotel{HotelID: 7}, nil)
+	test.provider.EXPECT().GetHotelReviews(ctx, int64(7), hotelCacheReviews).Return(nil, nil)
+	test.provider.EXPECT().GetHotelTranslations(ctx, int64(7), gomock.Any()).Return(nil, nil)
+	test.repo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	_, _, err := test.uc.Execute(ctx, 7, hotel.CollectionLimits{})
+	require.NoError(t, err)
+	test.waitIndexed(t)
+}
@@ -5,26 +5,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
 )
 
+const (
+	locationSuggestionsTTL = 15 * time.Minute
+
+	// suggestionVocabularyKey holds the search.Vocabulary built by full syncs.
+	suggestionVocabularyKey = "suggestions_vocabulary"
+	suggestionVocabularyTTL = 7 * 24 * time.Hour
+	// spellCheckerReloadInterval is how long a loaded vocabulary is used before the cache is
+	// checked for a newer one.
+	spellCheckerReloadInterval = 10 * time.Minute
+)
+
+type loadedSpellChecker struct {
+	checker  *search.SpellChecker
+	builtAt  time.Time
+	loadedAt time.Time
+}
+
 type GetHotelSuggestionsUseCase struct {
+	hotelRepo    hotel.Repository
 	searchEngine search.Engine
 	cache        hotel.CacheRepository
+	trending     *TrendingQueries
 	logger       *slog.Logger
+
+	spellChecker atomic.Pointer[loadedSpellChecker]
 }
 
 func NewGetHotelSuggestionsUseCase(
+	hotelRepo hotel.Repository,
 	searchEngine search.Engine,
 	cache hotel.CacheRepository,
+	trending *TrendingQueries,
 	logger *slog.Logger,
 ) *GetHotelSuggestionsUseCase {
 	return &GetHotelSuggestionsUseCase{
+		hotelRepo:    hotelRepo,
 		searchEngine: searchEngine,
 		cache:        cache,
+		trending:     trending,
 		logger:       logger,
 	}
 }
@@ -65,6 +91,10 @@ func (uc *GetHotelSuggestionsUseCase) Execute(ctx context.Context, query string,
 		return nil, fmt.Errorf("failed to get suggestions: %w", err)
 	}
 
+	if len(suggestions) == 0 {
+		suggestions = uc.correctedSuggestions(ctx, query, limit)
+	}
+
 	if suggestionsData, err := json.Marshal(suggestions); err == nil {
 		if err := uc.cache.Set(ctx, cacheKey, suggestionsData, 30*time.Minute); err != nil {
 			uc.logger.Warn("Failed to cache suggestions", "error", err)
@@ -79,12 +109,70 @@ func (uc *GetHotelSuggestionsUseCase) Execute(ctx context.Context, query string,
 	return suggestions, nil
 }
 
-func (uc *GetHotelSuggestionsUseCase) GetTrendingSuggestions(ctx context.Context, limit int) ([]*search.Suggestion, error) {
+// correctedSuggestions retries a query without suggestions after correcting its misspelled
+// words against the indexed vocabulary, for typos beyond what the engine tolerates on prefixes.
+func (uc *GetHotelSuggestionsUseCase) correctedSuggestions(ctx context.Context, query string, limit int) []*search.Suggestion {
+	checker := uc.loadSpellChecker(ctx)
+	if checker == nil {
+		return nil
+	}
+
+	corrected, ok := checker.Correct(query)
+	if !ok {
+		return nil
+	}
+
+	suggestions, err := uc.searchEngine.GetSuggestions(ctx, corrected, limit)
+	if err != nil {
+		uc.logger.Warn("Failed to get suggestions for corrected query", "query", query, "corrected", corrected, "error", err)
+		return nil
+	}
+
+	for _, suggestion := range suggestions {
+		suggestion.CorrectedFrom = query
+	}
+
+	uc.logger.Debug("Suggestions found for corrected query", "query", query, "corrected", corrected, "count", len(suggestions))
+	return suggestions
+}
+
+// loadSpellChecker returns the spell checker of the cached vocabulary, or nil when no full sync
+// has built one yet. The checker is rebuilt only when the vocabulary changed.
+func (uc *GetHotelSuggestionsUseCase) loadSpellChecker(ctx context.Context) *search.SpellChecker {
+	current := uc.spellChecker.Load()
+	if current != nil && time.Since(current.loadedAt) < spellCheckerReloadInterval {
+		return current.checker
+	}
+
+	next := &loadedSpellChecker{loadedAt: time.Now()}
+	if current != nil {
+		next.checker, next.builtAt = current.checker, current.builtAt
+	}
+
+	if data, err := uc.cache.Get(ctx, suggestionVocabularyKey); err == nil {
+		var vocabulary search.Vocabulary
+		if err := json.Unmarshal(data, &vocabulary); err != nil {
+			uc.logger.Warn("Failed to decode suggestion vocabulary", "error", err)
+		} else if !vocabulary.BuiltAt.Equal(next.builtAt) {
+			next.checker = search.NewSpellChecker(vocabulary)
+			next.builtAt = vocabulary.BuiltAt
+			uc.logger.Info("Loaded suggestion vocabulary", "tokens", len(vocabulary.Tokens), "built_at", vocabulary.BuiltAt)
+		}
+	}
+
+	uc.spellChecker.Store(next)
+	return next.checker
+}
+
+// GetTrendingSuggestions returns the most searched queries of locale, scored against the most
+// searched one. It falls back to the global queries and then to a static list while too few
+// searches are recorded.
+func (uc *GetHotelSuggestionsUseCase) GetTrendingSuggestions(ctx context.Context, locale string, limit int) ([]*search.Suggestion, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	cacheKey := fmt.Sprintf("trending_suggestions:%d", limit)
+	cacheKey := fmt.Sprintf("trending_suggestions:%s:%d", locale, limit)
 
 	if cachedData, err := uc.cache.Get(ctx, cacheKey); err == nil {
 		var cachedSuggestions []*search.Suggestion
@@ -93,17 +181,18 @@ func (uc *GetHotelSuggestionsUseCase) GetTrendingSuggestions(ctx context.Context
 		}
 	}
 
-	trendingSuggestions := []*search.Suggestion{
-		{Text: "luxury hotels", Type: "category", Score: 0.95},
-		{Text: "beach resorts", Type: "category", Score: 0.90},
-		{Text: "city center hotels", Type: "location", Score: 0.85},
-		{Text: "spa hotels", Type: "amenity", Score: 0.80},
-		{Text: "business hotels", Type: "category", Score: 0.75},
-		{Text: "family hotels", Type: "category", Score: 0.70},
-		{Text: "boutique hotels", Type: "category", Score: 0.65},
-		{Text: "airport hotels", Type: "location", Score: 0.60},
-		{Text: "mountain resorts", Type: "location", Score: 0.55},
-		{Text: "pet-friendly hotels", Type: "amenity", Score: 0.50},
+	var trendingSuggestions []*search.Suggestion
+	if queries, bucket := uc.trending.Top(ctx, locale, limit); queries != nil {
+		for _, query := range queries {
+			trendingSuggestions = append(trendingSuggestions, &search.Suggestion{
+				Text:     query.Query,
+				Type:     "query",
+				Score:    float64(query.Count) / float64(queries[0].Count),
+				Metadata: map[string]interface{}{"locale": bucket},
+			})
+		}
+	} else {
+		trendingSuggestions = defaultTrendingSuggestions
 	}
 
 	if limit < len(trendingSuggestions) {
@@ -111,38 +200,72 @@ func (uc *GetHotelSuggestionsUseCase) GetTrendingSuggestions(ctx context.Context
 	}
 
 	if data, err := json.Marshal(trendingSuggestions); err == nil {
-		_ = uc.cache.Set(ctx, cacheKey, data, 2*time.Hour)
+		_ = uc.cache.Set(ctx, cacheKey, data, trendingCacheTTL)
 	}
 
 	return trendingSuggestions, nil
 }
 
+// defaultTrendingSuggestions are served until enough searches are recorded.
+var defaultTrendingSuggestions = []*search.Suggestion{
+	{Text: "luxury hotels", Type: "category", Score: 0.95},
+	{Text: "beach resorts", Type: "category", Score: 0.90},
+	{Text: "city center hotels", Type: "location", Score: 0.85},
+	{Text: "spa hotels", Type: "amenity", Score: 0.80},
+	{Text: "business hotels", Type: "category", Score: 0.75},
+	{Text: "family hotels", Type: "category", Score: 0.70},
+	{Text: "boutique hotels", Type: "category", Score: 0.65},
+	{Text: "airport hotels", Type: "location", Score: 0.60},
+	{Text: "mountain resorts", Type: "location", Score: 0.55},
+	{Text: "pet-friendly hotels", Type: "amenity", Score: 0.50},
+}
+
 func (uc *GetHotelSuggestionsUseCase) GetLocationSuggestions(ctx context.Context, query string, limit int) ([]*search.Suggestion, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	locationSuggestions := []*search.Suggestion{
-		{Text: "New York", Type: "city", Score: 0.95, Metadata: map[string]interface{}{"country": "USA"}},
-		{Text: "London", Type: "city", Score: 0.90, Metadata: map[string]interface{}{"country": "UK"}},
-		{Text: "Paris", Type: "city", Score: 0.85, Metadata: map[string]interface{}{"country": "France"}},
-		{Text: "Tokyo", Type: "city", Score: 0.80, Metadata: map[string]interface{}{"country": "Japan"}},
-		{Text: "Dubai", Type: "city", Score: 0.75, Metadata: map[string]interface{}{"country": "UAE"}},
+	cacheKey := fmt.Sprintf("location_suggestions:%s:%d", query, limit)
+
+	if cachedData, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var cachedSuggestions []*search.Suggestion
+		if err := json.Unmarshal(cachedData, &cachedSuggestions); err == nil {
+			return cachedSuggestions, nil
+		}
+	}
+
+	cities, err := uc.hotelRepo.GetDistinctCities(ctx, query, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get location suggestions", "query", query, "error", err)
+		return nil, fmt.Errorf("failed to get location suggestions: %w", err)
 	}
 
-	if query != "" {
-		var filtered []*search.Suggestion
-		for _, suggestion := range locationSuggestions {
-			if len(suggestion.Text) >= len(query) &&
-				suggestion.Text[:len(query)] == query {
-				filtered = append(filtered, suggestion)
-			}
+	maxCount := 0
+	for _, city := range cities {
+		maxCount = max(maxCount, city.HotelCount)
+	}
+
+	locationSuggestions := make([]*search.Suggestion, 0, len(cities))
+	for _, city := range cities {
+		score := 0.0
+		if maxCount > 0 {
+			score = float64(city.HotelCount) / float64(maxCount)
 		}
-		locationSuggestions = filtered
+		locationSuggestions = append(locationSuggestions, &search.Suggestion{
+			Text:  city.City,
+			Type:  "city",
+			Score: score,
+			Metadata: map[string]interface{}{
+				"country":     city.Country,
+				"hotel_count": city.HotelCount,
+			},
+		})
 	}
 
-	if limit < len(locationSuggestions) {
-		locationSuggestions = locationSuggestions[:limit]
+	if data, err := json.Marshal(locationSuggestions); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, data, locationSuggestionsTTL); err != nil {
+			uc.logger.Warn("Failed to cache location suggestions", "error", err)
+		}
 	}
 
 	return locationSuggestions, nil
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newSuggestionsTest(t *testing.T) (*GetHotelSuggestionsUseCase, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	engine := mocks.NewMockEngine(gomock.NewController(t))
+	cache := newFakeCache()
+	uc := NewGetHotelSuggestionsUseCase(nil, engine, cache, nil, slog.New(slog.DiscardHandler))
+	return uc, engine, cache
+}
+
+func storeVocabulary(t *testing.T, cache *fakeCache, tokens ...string) {
+	t.Helper()
+	data, err := json.Marshal(search.Vocabulary{Tokens: tokens, BuiltAt: time.Now().UTC()})
+	require.NoError(t, err)
+	cache.values[suggestionVocabularyKey] = data
+}
+
+func TestSuggestionsCorrectMisspelledQuery(t *testing.T) {
+	uc, engine, cache := newSuggestionsTest(t)
+	ctx := context.Background()
+	storeVocabulary(t, cache, "hilton", "paris", "marriott")
+
+	engine.EXPECT().GetSuggestions(ctx, "hliton", 5).Return(nil, nil)
+	engine.EXPECT().GetSuggestions(ctx, "hilton", 5).Return([]*search.Suggestion{
+		{Text: "Hilton Paris Opera", Type: "hotel", Score: 1},
+	}, nil)
+
+	suggestions, err := uc.Execute(ctx, "hliton", 5)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "Hilton Paris Opera", suggestions[0].Text)
+	assert.Equal(t, "hliton", suggestions[0].CorrectedFrom)
+
+	// The corrected suggestions are cached under the original query.
+	cached, err := uc.Execute(ctx, "hliton", 5)
+	require.NoError(t, err)
+	assert.Equal(t, suggestions, cached)
+}
+
+func TestSuggestionsKeepQueriesWithoutCorrection(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "short word", query: "hlt"},
+		{name: "ambiguous word", query: "hotal"},
+		{name: "too many edits", query: "hxxxxon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, engine, cache := newSuggestionsTest(t)
+			ctx := context.Background()
+			storeVocabulary(t, cache, "hilton", "hotel", "total")
+
+			engine.EXPECT().GetSuggestions(ctx, tt.query, 10).Return(nil, nil).Times(1)
+
+			suggestions, err := uc.Execute(ctx, tt.query, 0)
+			require.NoError(t, err)
+			assert.Empty(t, suggestions)
+		})
+	}
+}
+
+func TestSuggestionsFoundAreNotCorrected(t *testing.T) {
+	uc, engine, cache := newSuggestionsTest(t)
+	ctx := context.Background()
+	storeVocabulary(t, cache, "hilton")
+
+	engine.EXPECT().GetSuggestions(ctx, "hilto", 10).Return([]*search.Suggestion{{Text: "Hilton", Type: "hotel"}}, nil)
+
+	suggestions, err := uc.Execute(ctx, "hilto", 10)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Empty(t, suggestions[0].CorrectedFrom)
+}
+
+func TestSuggestionsWithoutVocabulary(t *testing.T) {
+	uc, engine, _ := newSuggestionsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().GetSuggestions(ctx, "hliton", 10).Return(nil, nil).Times(1)
+
+	suggestions, err := uc.Execute(ctx, "hliton", 10)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestSpellCheckerReloadsNewerVocabulary(t *testing.T) {
+	uc, _, cache := newSuggestionsTest(t)
+	ctx := context.Background()
+	storeVocabulary(t, cache, "hilton")
+
+	first := uc.loadSpellChecker(ctx)
+	require.NotNil(t, first)
+	assert.Same(t, first, uc.loadSpellChecker(ctx), "the checker is reused until the reload interval")
+
+	// Once the interval passed, an unchanged vocabulary keeps the checker and a newer one
+	// replaces it.
+	expire := func() {
+		loaded := *uc.spellChecker.Load()
+		loaded.loadedAt = time.Now().Add(-spellCheckerReloadInterval)
+		uc.spellChecker.Store(&loaded)
+	}
+	expire()
+	assert.Same(t, first, uc.loadSpellChecker(ctx))
+
+	data, err := json.Marshal(search.Vocabulary{Tokens: []string{"marriott"}, BuiltAt: time.Now().Add(time.Minute).UTC()})
+	require.NoError(t, err)
+	cache.values[suggestionVocabularyKey] = data
+	expire()
+	second := uc.loadSpellChecker(ctx)
+	assert.NotSame(t, first, second)
+	corrected, ok := second.Correct("mariott")
+	assert.True(t, ok)
+	assert.Equal(t, "marriott", corrected)
+}
+
+func TestFullSyncBuildsSuggestionVocabulary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewSyncHotelsUseCase(repository, engine, cache, cache, nil, nil, false, &fakeSyncHistory{}, 0, slog.New(slog.DiscardHandler))
+	ctx := context.Background()
+
+	hotels := []*hotel.Hotel{
+		{HotelID: 1, Name: "Hilton Paris Opera", Address: hotel.Address{City: "Paris"}},
+		{HotelID: 2, Name: "Hilton London", Address: hotel.Address{City: "London"}},
+	}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil)
+
+	_, err := uc.Execute(ctx, SyncOptions{FullSync: true, BatchSize: 10})
+	require.NoError(t, err)
+
+	require.Contains(t, cache.values, suggestionVocabularyKey)
+	var vocabulary search.Vocabulary
+	require.NoError(t, json.Unmarshal(cache.values[suggestionVocabularyKey], &vocabulary))
+	assert.Equal(t, []string{"hilton", "london", "paris", "opera"}, vocabulary.Tokens)
+}
+
+func TestIncrementalSyncKeepsSuggestionVocabulary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewSyncHotelsUseCase(repository, engine, cache, cache, nil, nil, false, &fakeSyncHistory{}, 0, slog.New(slog.DiscardHandler))
+	ctx := context.Background()
+	storeVocabulary(t, cache, "hilton")
+	stored := cache.values[suggestionVocabularyKey]
+
+	repository.EXPECT().FindUpdatedAfter(gomock.Any(), gomock.Any()).Return([]*hotel.Hotel{{HotelID: 3, Name: "Marriott Rome"}}, nil)
+	engine.EXPECT().Index(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := uc.Execute(ctx, SyncOptions{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, stored, cache.values[suggestionVocabularyKey], "an incremental sync sees only some hotels")
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const hotelTranslationTTL = 5 * time.Minute
+
+var (
+	ErrHotelNotFound       = errors.New("hotel not found")
+	ErrTranslationNotFound = errors.New("translation not found")
+)
+
+// LocalizedHotel is a hotel with one translation overlaid. TranslatedFields come from the
+// requested language; FallbackFields keep their default-language values.
+type LocalizedHotel struct {
+	Lang             string       `json:"lang"`
+	Hotel            *hotel.Hotel `json:"hotel"`
+	TranslatedFields []string     `json:"translated_fields"`
+	FallbackFields   []string     `json:"fallback_fields"`
+}
+
+type GetHotelTranslationsUseCase struct {
+	hotelRepo     hotel.Repository
+	hotelProvider hotel.Provider
+	cache         hotel.CacheRepository
+	logger        *slog.Logger
+}
+
+func NewGetHotelTranslationsUseCase(
+	hotelRepo hotel.Repository,
+	hotelProvider hotel.Provider,
+	cache hotel.CacheRepository,
+	logger *slog.Logger,
+) *GetHotelTranslationsUseCase {
+	return &GetHotelTranslationsUseCase{
+		hotelRepo:     hotelRepo,
+		hotelProvider: hotelProvider,
+		cache:         cache,
+		logger:        logger,
+	}
+}
+
+func (uc *GetHotelTranslationsUseCase) ListLanguages(ctx context.Context, hotelID int64) ([]hotel.TranslationSummary, error) {
+	summaries, err := uc.hotelRepo.ListTranslations(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// GetLocalized returns the hotel overlaid with its translation in lang. Translations missing
+// from the database are fetched from the provider and persisted.
+func (uc *GetHotelTranslationsUseCase) GetLocalized(ctx context.Context, hotelID int64, lang string) (*LocalizedHotel, error) {
+	lang = strings.ToLower(lang)
+
+	cacheKey := fmt.Sprintf("hotel_translation:%d:%s", hotelID, lang)
+	if cachedData, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var cached LocalizedHotel
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			return &cached, nil
+		}
+		uc.logger.Warn("Failed to unmarshal cached translation", "hotel_id", hotelID, "lang", lang, "error", err)
+	}
+
+	baseHotel, err := uc.hotelRepo.FindByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotel: %w", err)
+	}
+	if baseHotel == nil {
+		return nil, ErrHotelNotFound
+	}
+
+	translation, err := uc.findTranslation(ctx, hotelID, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	localizedHotel, translated, fallback := baseHotel.Localize(*translation)
+	localized := &LocalizedHotel{
+		Lang:             lang,
+		Hotel:            &localizedHotel,
+		TranslatedFields: translated,
+		FallbackFields:   fallback,
+	}
+
+	if data, err := json.Marshal(localized); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, data, hotelTranslationTTL); err != nil {
+			uc.logger.Warn("Failed to cache translation", "hotel_id", hotelID, "lang", lang, "error", err)
+		}
+	}
+
+	return localized, nil
+}
+
+func (uc *GetHotelTranslationsUseCase) findTranslation(ctx context.Context, hotelID int64, lang string) (*hotel.Translation, error) {
+	translation, err := uc.hotelRepo.FindTranslation(ctx, hotelID, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translation: %w", err)
+	}
+	if translation != nil {
+		return translation, nil
+	}
+
+	uc.logger.Info("Translation not in database, falling back to provider", "hotel_id", hotelID, "lang", lang)
+
+	translations, err := uc.hotelProvider.GetHotelTranslations(ctx, hotelID, []string{lang})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch translation from provider: %w", err)
+	}
+	if len(translations) == 0 {
+		return nil, ErrTranslationNotFound
+	}
+
+	translation = translations[0]
+	translation.HotelID = hotelID
+	translation.Lang = lang
+
+	if err := uc.hotelRepo.SaveTranslation(ctx, translation); err != nil {
+		uc.logger.Error("Failed to save translation from provider", "hotel_id", hotelID, "lang", lang, "error", err)
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newGetHotelTranslationsTest(t *testing.T) (*GetHotelTranslationsUseCase, *mocks.MockRepository, *mocks.MockProvider, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	provider := mocks.NewMockProvider(ctrl)
+	cache := newFakeCache()
+	return NewGetHotelTranslationsUseCase(repo, provider, cache, slog.New(slog.DiscardHandler)), repo, provider, cache
+}
+
+func translationTestHotel() *hotel.Hotel {
+	return &hotel.Hotel{
+		HotelID:     7,
+		Name:        "Harbour Hotel",
+		Description: "A hotel by the harbour.",
+		Parking:     "Free parking",
+		Address:     hotel.Address{Street: "1 Quay Street", City: "Lisbon", Country: "pt"},
+		Policies:    []hotel.Policy{{PolicyType: "pets", Description: "No pets."}},
+	}
+}
+
+func TestListLanguagesReturnsAvailableTranslations(t *testing.T) {
+	uc, repo, _, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	summaries := []hotel.TranslationSummary{
+		{Lang: "es", Name: "Hotel del Puerto", DescriptionSnippet: "Un hotel junto al puerto."},
+		{Lang: "fr", Name: "Hôtel du Port", DescriptionSnippet: "Un hôtel sur le port."},
+	}
+	repo.EXPECT().ListTranslations(ctx, int64(7)).Return(summaries, nil)
+
+	languages, err := uc.ListLanguages(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, summaries, languages)
+}
+
+func TestListLanguagesWrapsRepositoryErrors(t *testing.T) {
+	uc, repo, _, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().ListTranslations(ctx, int64(7)).Return(nil, errors.New("connection refused"))
+
+	_, err := uc.ListLanguages(ctx, 7)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestGetLocalizedOverlaysTranslationWithFallbacks(t *testing.T) {
+	uc, repo, _, cache := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(translationTestHotel(), nil)
+	repo.EXPECT().FindTranslation(ctx, int64(7), "fr").Return(&hotel.Translation{
+		HotelID:     7,
+		Lang:        "fr",
+		Name:        "Hôtel du Port",
+		Description: "Un hôtel sur le port.",
+	}, nil)
+
+	localized, err := uc.GetLocalized(ctx, 7, "FR")
+	require.NoError(t, err)
+
+	assert.Equal(t, "fr", localized.Lang)
+	assert.Equal(t, "Hôtel du Port", localized.Hotel.Name)
+	assert.Equal(t, "Un hôtel sur le port.", localized.Hotel.Description)
+	assert.Equal(t, "Free parking", localized.Hotel.Parking, "parking falls back to the default language")
+	assert.Equal(t, "Lisbon", localized.Hotel.Address.City)
+	assert.Equal(t, []string{"name", "description"}, localized.TranslatedFields)
+	assert.Contains(t, localized.FallbackFields, "parking")
+	assert.Contains(t, localized.FallbackFields, "address")
+	assert.Contains(t, localized.FallbackFields, "policies")
+	assert.NotContains(t, localized.FallbackFields, "name")
+	assert.Contains(t, cache.values, "hotel_translation:7:fr")
+}
+
+func TestGetLocalizedServesCachedDocument(t *testing.T) {
+	uc, repo, _, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(translationTestHotel(), nil).Times(1)
+	repo.EXPECT().FindTranslation(ctx, int64(7), "fr").Return(&hotel.Translation{Name: "Hôtel du Port"}, nil).Times(1)
+
+	first, err := uc.GetLocalized(ctx, 7, "fr")
+	require.NoError(t, err)
+	second, err := uc.GetLocalized(ctx, 7, "fr")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestGetLocalizedFallsBackToProviderAndPersists(t *testing.T) {
+	uc, repo, provider, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(translationTestHotel(), nil)
+	repo.EXPECT().FindTranslation(ctx, int64(7), "es").Return(nil, nil)
+	provider.EXPECT().GetHotelTranslations(ctx, int64(7), []string{"es"}).
+		Return([]*hotel.Translation{{Name: "Hotel del Puerto", Parking: "Aparcamiento gratuito"}}, nil)
+	repo.EXPECT().SaveTranslation(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, translation *hotel.Translation) error {
+		assert.Equal(t, int64(7), translation.HotelID)
+		assert.Equal(t, "es", translation.Lang)
+		assert.Equal(t, "Hotel del Puerto", translation.Name)
+		return nil
+	})
+
+	localized, err := uc.GetLocalized(ctx, 7, "es")
+	require.NoError(t, err)
+	assert.Equal(t, "Hotel del Puerto", localized.Hotel.Name)
+	assert.Equal(t, "Aparcamiento gratuito", localized.Hotel.Parking)
+	assert.Equal(t, "A hotel by the harbour.", localized.Hotel.Description)
+	assert.Contains(t, localized.FallbackFields, "description")
+}
+
+func TestGetLocalizedServesProviderTranslationWhenSaveFails(t *testing.T) {
+	uc, repo, provider, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(translationTestHotel(), nil)
+	repo.EXPECT().FindTranslation(ctx, int64(7), "es").Return(nil, nil)
+	provider.EXPECT().GetHotelTranslations(ctx, int64(7), []string{"es"}).
+		Return([]*hotel.Translation{{Name: "Hotel del Puerto"}}, nil)
+	repo.EXPECT().SaveTranslation(ctx, gomock.Any()).Return(errors.New("database is read-only"))
+
+	localized, err := uc.GetLocalized(ctx, 7, "es")
+	require.NoError(t, err)
+	assert.Equal(t, "Hotel del Puerto", localized.Hotel.Name)
+}
+
+func TestGetLocalizedTranslationMissingEverywhere(t *testing.T) {
+	uc, repo, provider, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(translationTestHotel(), nil)
+	repo.EXPECT().FindTranslation(ctx, int64(7), "de").Return(nil, nil)
+	provider.EXPECT().GetHotelTranslations(ctx, int64(7), []string{"de"}).Return(nil, nil)
+
+	_, err := uc.GetLocalized(ctx, 7, "de")
+	assert.ErrorIs(t, err, ErrTranslationNotFound)
+}
+
+func TestGetLocalizedHotelNotFound(t *testing.T) {
+	uc, repo, _, _ := newGetHotelTranslationsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByHotelID(ctx, int64(7)).Return(nil, nil)
+
+	_, err := uc.GetLocalized(ctx, 7, "fr")
+	assert.ErrorIs(t, err, ErrHotelNotFound)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	defaultChangesPageSize = 20
+	maxChangesPageSize     = 100
+)
+
+// HotelSources attributes the field groups of a hotel to the sources that last wrote them.
+type HotelSources struct {
+	HotelID     int64                       `json:"hotel_id"`
+	UpdatedAt   time.Time                   `json:"updated_at"`
+	DataSources map[string]hotel.DataSource `json:"data_sources"`
+}
+
+// HotelChangesUseCase reads the field changes the workers recorded for a hotel.
+type HotelChangesUseCase struct {
+	hotelRepo hotel.Repository
+	logger    *slog.Logger
+}
+
+func NewHotelChangesUseCase(hotelRepo hotel.Repository, logger *slog.Logger) *HotelChangesUseCase {
+	return &HotelChangesUseCase{
+		hotelRepo: hotelRepo,
+		logger:    logger,
+	}
+}
+
+// List returns one page of the hotel's changes, most recent first.
+func (uc *HotelChangesUseCase) List(ctx context.Context, hotelID int64, page, limit int) ([]hotel.Change, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultChangesPageSize
+	}
+	limit = min(limit, maxChangesPageSize)
+
+	changes, err := uc.hotelRepo.ListChanges(ctx, hotelID, hotel.ListChangesOptions{
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hotel changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// Sources returns which source last wrote each field group of the hotel. Hotels stored before
+// sources were recorded have no attribution.
+func (uc *HotelChangesUseCase) Sources(ctx context.Context, hotelID int64) (*HotelSources, error) {
+	h, err := uc.hotelRepo.FindByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotel: %w", err)
+	}
+	if h == nil {
+		return nil, ErrHotelNotFound
+	}
+
+	sources := &HotelSources{HotelID: hotelID, UpdatedAt: h.UpdatedAt, DataSources: h.DataSources}
+	if sources.DataSources == nil {
+		sources.DataSources = map[string]hotel.DataSource{}
+	}
+	return sources, nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	defaultDuplicateNameSimilarity = 0.6
+	defaultDuplicateMaxDistanceKm  = 0.5
+	// maxDuplicateDistanceKm bounds the search radius, as the pair search grows with its square.
+	maxDuplicateDistanceKm = 50
+)
+
+var (
+	ErrInvalidDuplicateCriteria = errors.New("invalid duplicate criteria")
+	ErrInvalidMerge             = errors.New("invalid hotel merge")
+)
+
+// HotelDuplicatesUseCase finds hotels imported more than once under different ids, for example
+// after Cupid changed an id or listed a property under two chains, and merges them.
+type HotelDuplicatesUseCase struct {
+	hotelRepo    hotel.Repository
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	logger       *slog.Logger
+}
+
+func NewHotelDuplicatesUseCase(
+	hotelRepo hotel.Repository,
+	searchEngine search.Engine,
+	cache hotel.CacheRepository,
+	logger *slog.Logger,
+) *HotelDuplicatesUseCase {
+	return &HotelDuplicatesUseCase{
+		hotelRepo:    hotelRepo,
+		searchEngine: searchEngine,
+		cache:        cache,
+		logger:       logger,
+	}
+}
+
+// Find groups the likely duplicates. Zero criteria take the defaults: names at least 0.6
+// similar within 500 m.
+func (uc *HotelDuplicatesUseCase) Find(ctx context.Context, criteria hotel.DuplicateCriteria) ([]hotel.DuplicateGroup, error) {
+	if criteria.NameSimilarityThreshold == 0 {
+		criteria.NameSimilarityThreshold = defaultDuplicateNameSimilarity
+	}
+	if criteria.MaxDistanceKm == 0 {
+		criteria.MaxDistanceKm = defaultDuplicateMaxDistanceKm
+	}
+	if criteria.NameSimilarityThreshold < 0 || criteria.NameSimilarityThreshold > 1 {
+		return nil, fmt.Errorf("%w: name_similarity must be between 0 and 1", ErrInvalidDuplicateCriteria)
+	}
+	if criteria.MaxDistanceKm < 0 || criteria.MaxDistanceKm > maxDuplicateDistanceKm {
+		return nil, fmt.Errorf("%w: max_distance_km must be between 0 and %d", ErrInvalidDuplicateCriteria, maxDuplicateDistanceKm)
+	}
+
+	return uc.hotelRepo.FindDuplicates(ctx, criteria)
+}
+
+// Merge folds duplicateID into primaryID, then drops the duplicate from the cache and the
+// search index and reindexes the primary with its new reviews and translations.
+func (uc *HotelDuplicatesUseCase) Merge(ctx context.Context, primaryID, duplicateID int64, actor string) (*hotel.MergeResult, error) {
+	if primaryID <= 0 || duplicateID <= 0 {
+		return nil, fmt.Errorf("%w: primary_id and duplicate_id are required", ErrInvalidMerge)
+	}
+	if primaryID == duplicateID {
+		return nil, fmt.Errorf("%w: a hotel cannot be merged into itself", ErrInvalidMerge)
+	}
+
+	duplicate, err := uc.hotelRepo.FindByHotelID(ctx, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotel: %w", err)
+	}
+	if duplicate == nil {
+		return nil, fmt.Errorf("%w: %d", ErrHotelNotFound, duplicateID)
+	}
+
+	result, err := uc.hotelRepo.MergeHotels(ctx, primaryID, duplicateID)
+	if err != nil {
+		if errors.Is(err, hotel.ErrMergeHotelNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrHotelNotFound, primaryID)
+		}
+		return nil, err
+	}
+
+	uc.logger.Info("Hotels merged",
+		"audit", true,
+		"primary_id", primaryID,
+		"duplicate_id", duplicateID,
+		"moved_reviews", result.MovedReviews,
+		"moved_translations", result.MovedTranslations,
+		"dropped_translations", result.DroppedTranslations,
+		"actor", actor)
+
+	primary, err := uc.hotelRepo.FindByHotelID(ctx, primaryID)
+	if err != nil || primary == nil {
+		uc.logger.Error("Failed to reload merged hotel", "hotel_id", primaryID, "error", err)
+	}
+	uc.invalidateCache(ctx, primaryID, primary)
+	uc.invalidateCache(ctx, duplicateID, duplicate)
+
+	if err := uc.searchEngine.DeleteHotel(ctx, strconv.FormatInt(duplicateID, 10)); err != nil {
+		uc.logger.Error("Failed to delete merged duplicate from search engine", "hotel_id", duplicateID, "error", err)
+	}
+	if primary != nil {
+		if err := uc.searchEngine.UpdateHotel(ctx, primary); err != nil {
+			uc.logger.Error("Failed to update merged hotel in search engine", "hotel_id", primaryID, "error", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// invalidateCache drops the cached hotel and, when it is known, its cached translations.
+func (uc *HotelDuplicatesUseCase) invalidateCache(ctx context.Context, hotelID int64, h *hotel.Hotel) {
+	keys := []string{fmt.Sprintf("hotel:%d", hotelID)}
+	if h != nil {
+		for _, translation := range h.Translations {
+			keys = append(keys, fmt.Sprintf("hotel_translation:%d:%s", hotelID, translation.Lang))
+		}
+	}
+
+	for _, key := range keys {
+		if err := uc.cache.Delete(ctx, key); err != nil {
+			uc.logger.Warn("Failed to invalidate hotel cache", "key", key, "error", err)
+		}
+	}
+}
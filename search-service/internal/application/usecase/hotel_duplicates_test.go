@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeOfAMissingDuplicateIsNotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(2)).Return(nil, nil)
+	uc := NewHotelDuplicatesUseCase(repo, nil, newFakeCache(), slog.New(slog.DiscardHandler))
+
+	_, err := uc.Merge(context.Background(), 1, 2, "admin")
+
+	assert.ErrorIs(t, err, ErrHotelNotFound, "nothing is merged into the primary")
+}
+
+func TestMergeIntoAMissingPrimaryIsNotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(2)).Return(&hotel.Hotel{HotelID: 2}, nil)
+	repo.EXPECT().MergeHotels(gomock.Any(), int64(1), int64(2)).
+		Return(hotel.MergeResult{}, errors.Join(errors.New("failed to merge hotel 2 into 1"), hotel.ErrMergeHotelNotFound))
+	uc := NewHotelDuplicatesUseCase(repo, nil, newFakeCache(), slog.New(slog.DiscardHandler))
+
+	_, err := uc.Merge(context.Background(), 1, 2, "admin")
+
+	assert.ErrorIs(t, err, ErrHotelNotFound)
+}
+
+func TestMergeRejectsInvalidIDs(t *testing.T) {
+	uc := NewHotelDuplicatesUseCase(mocks.NewMockRepository(gomock.NewController(t)), nil, newFakeCache(), slog.New(slog.DiscardHandler))
+
+	_, err := uc.Merge(context.Background(), 1, 1, "admin")
+	assert.ErrorIs(t, err, ErrInvalidMerge)
+	_, err = uc.Merge(context.Background(), 0, 2, "admin")
+	assert.ErrorIs(t, err, ErrInvalidMerge)
+}
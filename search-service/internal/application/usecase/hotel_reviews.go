@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	defaultReviewsPageSize = 20
+	maxReviewsPageSize     = 100
+)
+
+// HotelReviewsUseCase reads the stored reviews of a hotel. It reads the database rather than
+// the hotel cache, which only keeps the most recent reviews.
+type HotelReviewsUseCase struct {
+	hotelRepo hotel.Repository
+	logger    *slog.Logger
+}
+
+func NewHotelReviewsUseCase(hotelRepo hotel.Repository, logger *slog.Logger) *HotelReviewsUseCase {
+	return &HotelReviewsUseCase{
+		hotelRepo: hotelRepo,
+		logger:    logger,
+	}
+}
+
+// List returns one page of the hotel's reviews, most recent first. Archived reviews are only
+// included when includeArchived is set.
+func (uc *HotelReviewsUseCase) List(ctx context.Context, hotelID int64, includeArchived bool, page, limit int) ([]hotel.Review, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultReviewsPageSize
+	}
+	limit = min(limit, maxReviewsPageSize)
+
+	reviews, err := uc.hotelRepo.ListReviews(ctx, hotelID, hotel.ListReviewsOptions{
+		IncludeArchived: includeArchived,
+		Limit:           limit,
+		Offset:          (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// Stats summarizes the reviews kept in the reviews table; archived reviews are not counted.
+func (uc *HotelReviewsUseCase) Stats(ctx context.Context, hotelID int64) (*hotel.ReviewStats, error) {
+	h, err := uc.hotelRepo.FindByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotel: %w", err)
+	}
+	if h == nil {
+		return nil, ErrHotelNotFound
+	}
+
+	stats := h.ReviewStats()
+	return &stats, nil
+}
@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	backfillJobKeyPrefix    = "index_backfill:job:"
+	backfillCursorKeyPrefix = "index_backfill:cursor:"
+	backfillJobTTL          = 7 * 24 * time.Hour
+	defaultBackfillBatch    = 200
+)
+
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+var (
+	ErrBackfillJobNotFound   = errors.New("backfill job not found")
+	ErrInvalidBackfillFields = errors.New("invalid backfill fields")
+)
+
+// backfillFieldExtractors maps search document fields to their values on the domain hotel.
+// The boolean result is false when the hotel has no value for the field.
+var backfillFieldExtractors = map[string]func(h *hotel.Hotel) (any, bool){
+	"name":          func(h *hotel.Hotel) (any, bool) { return h.Name, true },
+	"description":   func(h *hotel.Hotel) (any, bool) { return h.Description, true },
+	"phone":         func(h *hotel.Hotel) (any, bool) { return h.ContactInfo.Phone, true },
+	"chain":         func(h *hotel.Hotel) (any, bool) { return h.Chain, true },
+	"rating":        func(h *hotel.Hotel) (any, bool) { return h.Rating, true },
+	"star_rating":   func(h *hotel.Hotel) (any, bool) { return h.StarRating, true },
+	"latitude":      func(h *hotel.Hotel) (any, bool) { return h.Latitude, true },
+	"longitude":     func(h *hotel.Hotel) (any, bool) { return h.Longitude, true },
+	"fax":           func(h *hotel.Hotel) (any, bool) { return h.ContactInfo.Fax, true },
+	"email":         func(h *hotel.Hotel) (any, bool) { return h.ContactInfo.Email, true },
+	"phone_e164":    func(h *hotel.Hotel) (any, bool) { return h.ContactInfo.PhoneE164, h.ContactInfo.PhoneE164 != "" },
+	"fax_e164":      func(h *hotel.Hotel) (any, bool) { return h.ContactInfo.FaxE164, h.ContactInfo.FaxE164 != "" },
+	"airport_code":  func(h *hotel.Hotel) (any, bool) { return h.AirportCode, true },
+	"review_count":  func(h *hotel.Hotel) (any, bool) { return h.ReviewCount, true },
+	"child_allowed": func(h *hotel.Hotel) (any, bool) { return h.ChildAllowed, true },
+	"pets_allowed":  func(h *hotel.Hotel) (any, bool) { return h.PetsAllowed, true },
+	"parking":       func(h *hotel.Hotel) (any, bool) { return h.Parking, true },
+	"amenities": func(h *hotel.Hotel) (any, bool) {
+		if h.Amenities == nil {
+			return []string{}, true
+		}
+		return h.Amenities, true
+	},
+	"nearby_attractions": func(h *hotel.Hotel) (any, bool) {
+		return h.AttractionNames(), len(h.NearbyAttractions) > 0
+	},
+	"nearby_attraction_categories": func(h *hotel.Hotel) (any, bool) {
+		return h.AttractionCategories(), len(h.NearbyAttractions) > 0
+	},
+	"policy_summary": func(h *hotel.Hotel) (any, bool) {
+		summary := h.PolicySummary()
+		return summary, summary != ""
+	},
+	"min_nights": func(h *hotel.Hotel) (any, bool) {
+		return h.MinNights(), true
+	},
+	"timezone": func(h *hotel.Hotel) (any, bool) {
+		timezone := h.TimezoneName()
+		return timezone, timezone != ""
+	},
+	"main_image_th": func(h *hotel.Hotel) (any, bool) {
+		return h.MainImageTh, h.MainImageTh != ""
+	},
+	"coordinates_valid": func(h *hotel.Hotel) (any, bool) {
+		return h.HasCoordinates(), true
+	},
+	"location": func(h *hotel.Hotel) (any, bool) {
+		// A null location removes the one indexed before the coordinates were found invalid.
+		if !h.HasCoordinates() {
+			return nil, true
+		}
+		return []float64{h.Latitude, h.Longitude}, true
+	},
+	"airport_distance_km": func(h *hotel.Hotel) (any, bool) {
+		distance, ok := h.AirportDistanceKm()
+		return float32(distance), ok
+	},
+	"languages_available": func(h *hotel.Hotel) (any, bool) {
+		return h.LanguagesAvailable(), true
+	},
+	"room_types_count": func(h *hotel.Hotel) (any, bool) { return int32(h.RoomTypesCount()), true },
+	"total_capacity":   func(h *hotel.Hotel) (any, bool) { return h.TotalCapacity(), true },
+	"avg_score_location": func(h *hotel.Hotel) (any, bool) {
+		return backfillScore(h.ReviewScoreBreakdown().Location)
+	},
+	"avg_score_service": func(h *hotel.Hotel) (any, bool) {
+		return backfillScore(h.ReviewScoreBreakdown().Service)
+	},
+	"avg_score_value": func(h *hotel.Hotel) (any, bool) {
+		return backfillScore(h.ReviewScoreBreakdown().Value)
+	},
+	"avg_score_facilities": func(h *hotel.Hotel) (any, bool) {
+		return backfillScore(h.ReviewScoreBreakdown().Facilities)
+	},
+	"checkin_start_minutes": func(h *hotel.Hotel) (any, bool) {
+		window := h.CheckinInfo.Window()
+		if window.StartMinutes == nil {
+			return nil, false
+		}
+		return *window.StartMinutes, true
+	},
+	"checkin_end_minutes": func(h *hotel.Hotel) (any, bool) {
+		window := h.CheckinInfo.Window()
+		if window.EndMinutes == nil {
+			return nil, false
+		}
+		return *window.EndMinutes, true
+	},
+	"checkin_24h":     func(h *hotel.Hotel) (any, bool) { return h.CheckinInfo.Window().Is24h, true },
+	"checkin_unknown": func(h *hotel.Hotel) (any, bool) { return h.CheckinInfo.Window().Unknown, true },
+	"created_at":      func(h *hotel.Hotel) (any, bool) { return h.CreatedAt.UTC().Unix(), true },
+	"updated_at":      func(h *hotel.Hotel) (any, bool) { return h.UpdatedAt.UTC().Unix(), true },
+}
+
+func backfillScore(average *float64) (any, bool) {
+	if average == nil {
+		return nil, false
+	}
+	return float32(*average), true
+}
+
+type BackfillOptions struct {
+	Fields    []string `json:"fields"`
+	BatchSize int      `json:"batch_size,omitempty"`
+	Restart   bool     `json:"restart,omitempty"`
+}
+
+type BackfillJob struct {
+	ID              string     `json:"id"`
+	Fields          []string   `json:"fields"`
+	Status          JobStatus  `json:"status"`
+	ProcessedHotels int        `json:"processed_hotels"`
+	UpdatedHotels   int        `json:"updated_hotels"`
+	FailedHotels    int        `json:"failed_hotels"`
+	ResumedFrom     int64      `json:"resumed_from,omitempty"`
+	LastHotelID     int64      `json:"last_hotel_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	Error           string     `json:"error,omitempty"`
+	// CursorHotelID is where a resumed job starts: the last hotel before the first one that
+	// failed, so failed hotels are retried rather than skipped.
+	CursorHotelID int64 `json:"cursor_hotel_id"`
+}
+
+type IndexBackfillUseCase struct {
+	hotelRepo    hotel.Repository
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	logger       *slog.Logger
+}
+
+func NewIndexBackfillUseCase(
+	hotelRepo hotel.Repository,
+	searchEngine search.Engine,
+	cache hotel.CacheRepository,
+	logger *slog.Logger,
+) *IndexBackfillUseCase {
+	return &IndexBackfillUseCase{
+		hotelRepo:    hotelRepo,
+		searchEngine: searchEngine,
+		cache:        cache,
+		logger:       logger,
+	}
+}
+
+// Start validates the options, records a new job and runs the backfill in the background.
+func (uc *IndexBackfillUseCase) Start(ctx context.Context, options BackfillOptions) (*BackfillJob, error) {
+	fields, err := normalizeBackfillFields(options.Fields)
+	if err != nil {
+		return nil, err
+	}
+	options.Fields = fields
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBackfillBatch
+	}
+
+	if options.Restart {
+		if err := uc.cache.Delete(ctx, backfillCursorKey(fields)); err != nil {
+			uc.logger.Warn("Failed to reset backfill cursor", "fields", fields, "error", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	job := &BackfillJob{
+		ID:        uuid.NewString(),
+		Fields:    fields,
+		Status:    JobStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	job.ResumedFrom = uc.loadCursor(ctx, fields)
+	job.LastHotelID = job.ResumedFrom
+	job.CursorHotelID = job.ResumedFrom
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		if err := uc.Run(context.Background(), job, options.BatchSize); err != nil {
+			uc.logger.Error("Index backfill failed", "job_id", job.ID, "error", err)
+		}
+	}()
+
+	return &snapshot, nil
+}
+
+// Run pages through hotels after the job's cursor and sends partial updates for the requested
+// fields, persisting the cursor after every batch so an interrupted job can be resumed. The
+// cursor stops before the first hotel that failed, and is kept when the job completes with
+// failures, so the next run retries them.
+func (uc *IndexBackfillUseCase) Run(ctx context.Context, job *BackfillJob, batchSize int) error {
+	uc.logger.Info("Starting index backfill",
+		"job_id", job.ID,
+		"fields", job.Fields,
+		"resume_from", job.LastHotelID)
+
+	for {
+		hotels, err := uc.hotelRepo.FindAfterHotelID(ctx, job.LastHotelID, batchSize)
+		if err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return fmt.Errorf("failed to fetch hotels after %d: %w", job.LastHotelID, err)
+		}
+
+		if len(hotels) == 0 {
+			break
+		}
+
+		for _, h := range hotels {
+			fields := backfillFields(h, job.Fields)
+			if len(fields) > 0 {
+				if err := uc.searchEngine.PartialUpdate(ctx, h.HotelID, fields); err != nil {
+					uc.logger.Warn("Failed to backfill hotel", "job_id", job.ID, "hotel_id", h.HotelID, "error", err)
+					job.FailedHotels++
+				} else {
+					job.UpdatedHotels++
+				}
+			}
+
+			job.ProcessedHotels++
+			job.LastHotelID = h.HotelID
+			if job.FailedHotels == 0 {
+				job.CursorHotelID = h.HotelID
+			}
+		}
+
+		job.UpdatedAt = time.Now().UTC()
+		uc.saveCursor(ctx, job.Fields, job.CursorHotelID)
+		if err := uc.saveJob(ctx, job); err != nil {
+			uc.logger.Warn("Failed to save backfill progress", "job_id", job.ID, "error", err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if len(hotels) < batchSize {
+			break
+		}
+	}
+
+	if job.FailedHotels == 0 {
+		if err := uc.cache.Delete(ctx, backfillCursorKey(job.Fields)); err != nil {
+			uc.logger.Warn("Failed to clear backfill cursor", "job_id", job.ID, "error", err)
+		}
+	}
+	uc.finishJob(ctx, job, JobStatusCompleted, nil)
+
+	uc.logger.Info("Index backfill completed",
+		"job_id", job.ID,
+		"processed_hotels", job.ProcessedHotels,
+		"updated_hotels", job.UpdatedHotels,
+		"failed_hotels", job.FailedHotels)
+
+	return nil
+}
+
+func (uc *IndexBackfillUseCase) GetJob(ctx context.Context, jobID string) (*BackfillJob, error) {
+	data, err := uc.cache.Get(ctx, backfillJobKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrBackfillJobNotFound
+	}
+
+	var job BackfillJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode backfill job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (uc *IndexBackfillUseCase) finishJob(ctx context.Context, job *BackfillJob, status JobStatus, cause error) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	// The request context may already be cancelled; the final status must still be recorded.
+	if err := uc.saveJob(context.WithoutCancel(ctx), job); err != nil {
+		uc.logger.Warn("Failed to save backfill job status", "job_id", job.ID, "error", err)
+	}
+}
+
+func (uc *IndexBackfillUseCase) saveJob(ctx context.Context, job *BackfillJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode backfill job: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, backfillJobKeyPrefix+job.ID, data, backfillJobTTL); err != nil {
+		return fmt.Errorf("failed to save backfill job: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *IndexBackfillUseCase) loadCursor(ctx context.Context, fields []string) int64 {
+	data, err := uc.cache.Get(ctx, backfillCursorKey(fields))
+	if err != nil {
+		return 0
+	}
+
+	cursor, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		uc.logger.Warn("Ignoring invalid backfill cursor", "fields", fields, "error", err)
+		return 0
+	}
+
+	return cursor
+}
+
+func (uc *IndexBackfillUseCase) saveCursor(ctx context.Context, fields []string, hotelID int64) {
+	value := []byte(strconv.FormatInt(hotelID, 10))
+	if err := uc.cache.Set(ctx, backfillCursorKey(fields), value, backfillJobTTL); err != nil {
+		uc.logger.Warn("Failed to save backfill cursor", "fields", fields, "hotel_id", hotelID, "error", err)
+	}
+}
+
+func backfillCursorKey(fields []string) string {
+	return backfillCursorKeyPrefix + strings.Join(fields, ",")
+}
+
+func backfillFields(h *hotel.Hotel, names []string) map[string]any {
+	fields := make(map[string]any, len(names))
+	for _, name := range names {
+		if value, ok := backfillFieldExtractors[name](h); ok {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+func normalizeBackfillFields(fields []string) ([]string, error) {
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if _, ok := backfillFieldExtractors[field]; !ok {
+			return nil, fmt.Errorf("%w: unsupported field %s", ErrInvalidBackfillFields, field)
+		}
+		if !slices.Contains(normalized, field) {
+			normalized = append(normalized, field)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("%w: at least one field is required", ErrInvalidBackfillFields)
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
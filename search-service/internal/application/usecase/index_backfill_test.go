@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newBackfillTest(t *testing.T) (*IndexBackfillUseCase, *mocks.MockRepository, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	return NewIndexBackfillUseCase(repository, engine, cache, slog.New(slog.DiscardHandler)), repository, engine, cache
+}
+
+func backfillHotels(ids ...int64) []*hotel.Hotel {
+	hotels := make([]*hotel.Hotel, len(ids))
+	for i, id := range ids {
+		hotels[i] = &hotel.Hotel{HotelID: id, Name: "Hotel", Rating: 4.5, Latitude: 48.85, Longitude: 2.35}
+	}
+	return hotels
+}
+
+func TestBackfillSendsOnlyRequestedFields(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(backfillHotels(1, 2), nil)
+	for _, id := range []int64{1, 2} {
+		engine.EXPECT().PartialUpdate(ctx, id, map[string]any{
+			"rating":   4.5,
+			"location": []float64{48.85, 2.35},
+		}).Return(nil)
+	}
+
+	job := &BackfillJob{ID: "job", Fields: []string{"location", "rating"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 2, job.ProcessedHotels)
+	assert.Equal(t, 2, job.UpdatedHotels)
+	assert.Equal(t, int64(2), job.LastHotelID)
+}
+
+func TestBackfillRoomInventory(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	hotels := backfillHotels(1, 2)
+	hotels[0].Rooms = []hotel.Room{{MaxOccupancy: 2}, {MaxOccupancy: 4}, {MaxOccupancy: 6}}
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(hotels, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"room_types_count": int32(3), "total_capacity": int32(12)}).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(2), map[string]any{"room_types_count": int32(0), "total_capacity": int32(0)}).Return(nil)
+
+	job := &BackfillJob{ID: "job", Fields: []string{"room_types_count", "total_capacity"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+	assert.Equal(t, 2, job.UpdatedHotels)
+}
+
+func TestBackfillLanguagesAvailable(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	hotels := backfillHotels(1, 2)
+	hotels[0].Translations = []hotel.Translation{{Lang: "es"}, {Lang: " FR "}, {Lang: "en"}}
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(hotels, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"languages_available": []string{"en", "es", "fr"}}).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(2), map[string]any{"languages_available": []string{"en"}}).Return(nil)
+
+	job := &BackfillJob{ID: "job", Fields: []string{"languages_available"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+	assert.Equal(t, 2, job.UpdatedHotels)
+}
+
+func TestBackfillResumesFromCursor(t *testing.T) {
+	uc, repository, _, cache := newBackfillTest(t)
+	ctx := context.Background()
+	fields := []string{"rating"}
+	require.NoError(t, cache.Set(ctx, backfillCursorKey(fields), []byte("41"), 0))
+
+	repository.EXPECT().FindAfterHotelID(gomock.Any(), int64(41), defaultBackfillBatch).Return(nil, nil)
+
+	job, err := uc.Start(ctx, BackfillOptions{Fields: fields})
+	require.NoError(t, err)
+	assert.Equal(t, int64(41), job.ResumedFrom)
+	assert.Equal(t, int64(41), job.LastHotelID)
+
+	require.Eventually(t, func() bool {
+		stored, err := uc.GetJob(ctx, job.ID)
+		return err == nil && stored.Status == JobStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBackfillKeepsCursorBeforeFailedHotel(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	fields := []string{"rating"}
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 3).Return(backfillHotels(1, 2, 3), nil)
+	repository.EXPECT().FindAfterHotelID(ctx, int64(3), 3).Return(backfillHotels(4), nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), gomock.Any()).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(2), gomock.Any()).Return(errors.New("typesense unavailable"))
+	engine.EXPECT().PartialUpdate(ctx, int64(3), gomock.Any()).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(4), gomock.Any()).Return(nil)
+
+	job := &BackfillJob{ID: "job", Fields: fields}
+	require.NoError(t, uc.Run(ctx, job, 3))
+
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 4, job.ProcessedHotels)
+	assert.Equal(t, 3, job.UpdatedHotels)
+	assert.Equal(t, 1, job.FailedHotels)
+	assert.Equal(t, int64(4), job.LastHotelID)
+	assert.Equal(t, int64(1), job.CursorHotelID)
+	assert.Equal(t, int64(1), uc.loadCursor(ctx, fields))
+
+	stored, err := uc.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stored.ProcessedHotels)
+}
+
+func TestBackfillClearsCursorWhenComplete(t *testing.T) {
+	uc, repository, engine, cache := newBackfillTest(t)
+	ctx := context.Background()
+	fields := []string{"rating"}
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(backfillHotels(7), nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(7), gomock.Any()).Return(nil)
+
+	require.NoError(t, uc.Run(ctx, &BackfillJob{ID: "job", Fields: fields}, 10))
+
+	exists, err := cache.Exists(ctx, backfillCursorKey(fields))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestNormalizeBackfillFields(t *testing.T) {
+	fields, err := normalizeBackfillFields([]string{" Rating", "location", "rating", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"location", "rating"}, fields)
+
+	_, err = normalizeBackfillFields([]string{"unknown"})
+	assert.ErrorIs(t, err, ErrInvalidBackfillFields)
+
+	_, err = normalizeBackfillFields(nil)
+	assert.ErrorIs(t, err, ErrInvalidBackfillFields)
+}
+
+func TestBackfillNormalizedContactNumbers(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	hotels := backfillHotels(1, 2)
+	hotels[0].ContactInfo = hotel.ContactInfo{Phone: "+1 212-555-0100", PhoneE164: "+12125550100", FaxE164: "+12125550101"}
+	hotels[1].ContactInfo = hotel.ContactInfo{Phone: "reception"}
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(hotels, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"phone_e164": "+12125550100", "fax_e164": "+12125550101"}).Return(nil)
+
+	job := &BackfillJob{ID: "job", Fields: []string{"phone_e164", "fax_e164"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+	assert.Equal(t, 1, job.UpdatedHotels)
+}
+
+func TestBackfillClearsTheLocationOfImplausibleCoordinates(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	hotels := backfillHotels(1, 2, 3, 4)
+	hotels[1].Latitude, hotels[1].Longitude = 0, 0
+	hotels[2].Latitude, hotels[2].Longitude = 0.0004, -0.0007
+	hotels[3].Latitude, hotels[3].Longitude = 91.2, 2.35
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(hotels, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"coordinates_valid": true, "location": []float64{48.85, 2.35}}).Return(nil)
+	for _, id := range []int64{2, 3, 4} {
+		engine.EXPECT().PartialUpdate(ctx, id, map[string]any{"coordinates_valid": false, "location": nil}).Return(nil)
+	}
+
+	job := &BackfillJob{ID: "job", Fields: []string{"coordinates_valid", "location"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+	assert.Equal(t, 4, job.UpdatedHotels)
+}
+
+func TestBackfillTimezone(t *testing.T) {
+	uc, repository, engine, _ := newBackfillTest(t)
+	ctx := context.Background()
+	hotels := backfillHotels(1, 2, 3)
+	hotels[0].Address.Country = "fr"
+	hotels[1].Timezone = "Asia/Tokyo"
+	hotels[2].Latitude, hotels[2].Longitude = 0, 0
+
+	repository.EXPECT().FindAfterHotelID(ctx, int64(0), 10).Return(hotels, nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(1), map[string]any{"timezone": "Europe/Paris"}).Return(nil)
+	engine.EXPECT().PartialUpdate(ctx, int64(2), map[string]any{"timezone": "Asia/Tokyo"}).Return(nil)
+
+	job := &BackfillJob{ID: "job", Fields: []string{"timezone"}}
+	require.NoError(t, uc.Run(ctx, job, 10))
+	assert.Equal(t, 2, job.UpdatedHotels, "hotels without coordinates are left without a timezone")
+}
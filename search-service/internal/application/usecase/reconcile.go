@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	reconcileIndexBatch    = 200
+	reconcileJobKeyPrefix  = "index_reconcile:job:"
+	reconcileJobTTL        = 7 * 24 * time.Hour
+	reconcileVersionBatch  = 1000
+	reconcileProgressEvery = 5000
+	// reconcileSampleSize bounds the hotel_ids kept per category in a job report.
+	reconcileSampleSize = 100
+)
+
+var ErrReconcileJobNotFound = errors.New("reconcile job not found")
+
+type ReconcileJobOptions struct {
+	Repair bool `json:"repair"`
+}
+
+// ReconcileJob reports a streaming comparison of the database with the search index. Missing
+// hotels are active but not indexed, stale ones are indexed with an older updated_at than the
+// database and orphaned ones are indexed but not active. Repair jobs reindex the first two and
+// delete the last.
+type ReconcileJob struct {
+	ID              string     `json:"id"`
+	Repair          bool       `json:"repair"`
+	Status          JobStatus  `json:"status"`
+	DatabaseScanned int        `json:"database_scanned"`
+	IndexScanned    int        `json:"index_scanned"`
+	Missing         int        `json:"missing"`
+	Stale           int        `json:"stale"`
+	Orphaned        int        `json:"orphaned"`
+	MissingSample   []int64    `json:"missing_sample"`
+	StaleSample     []int64    `json:"stale_sample"`
+	OrphanedSample  []int64    `json:"orphaned_sample"`
+	Reindexed       int        `json:"reindexed"`
+	Deleted         int        `json:"deleted"`
+	FailedRepairs   int        `json:"failed_repairs"`
+	LastHotelID     int64      `json:"last_hotel_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+type ReconcileUseCase struct {
+	hotelRepo    hotel.Repository
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	logger       *slog.Logger
+}
+
+func NewReconcileUseCase(hotelRepo hotel.Repository, searchEngine search.Engine, cache hotel.CacheRepository, logger *slog.Logger) *ReconcileUseCase {
+	return &ReconcileUseCase{
+		hotelRepo:    hotelRepo,
+		searchEngine: searchEngine,
+		cache:        cache,
+		logger:       logger,
+	}
+}
+
+// Run records a new reconciliation job and runs it before returning its report.
+func (uc *ReconcileUseCase) Run(ctx context.Context, options ReconcileJobOptions) (*ReconcileJob, error) {
+	job, err := uc.newJob(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.RunJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// StartJob records a new reconciliation job and runs it in the background.
+func (uc *ReconcileUseCase) StartJob(ctx context.Context, options ReconcileJobOptions) (*ReconcileJob, error) {
+	job, err := uc.newJob(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		if err := uc.RunJob(context.Background(), job); err != nil {
+			uc.logger.Error("Index reconciliation job failed", "job_id", job.ID, "error", err)
+		}
+	}()
+
+	return &snapshot, nil
+}
+
+// RunJob merges the active hotels and the indexed documents, both ordered by hotel_id, so
+// memory use does not grow with the number of hotels.
+func (uc *ReconcileUseCase) RunJob(ctx context.Context, job *ReconcileJob) error {
+	uc.logger.Info("Starting index reconciliation job", "job_id", job.ID, "repair", job.Repair)
+
+	nextDatabase, stopDatabase := iter.Pull2(uc.activeVersions(ctx))
+	defer stopDatabase()
+	nextIndexed, stopIndexed := iter.Pull2(uc.searchEngine.ExportIDs(ctx))
+	defer stopIndexed()
+
+	reindex := make([]int64, 0, reconcileIndexBatch)
+	flush := func() {
+		if len(reindex) > 0 {
+			uc.reindex(ctx, job, reindex)
+			reindex = reindex[:0]
+		}
+	}
+	queueReindex := func(hotelID int64) {
+		if !job.Repair {
+			return
+		}
+		reindex = append(reindex, hotelID)
+		if len(reindex) >= reconcileIndexBatch {
+			flush()
+		}
+	}
+
+	dbVersion, dbErr, dbOK := nextDatabase()
+	indexed, indexErr, indexOK := nextIndexed()
+	compared := 0
+	for dbOK || indexOK {
+		if err := errors.Join(dbErr, indexErr); err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		switch {
+		case indexOK && (!dbOK || indexed.HotelID < dbVersion.HotelID):
+			job.Orphaned++
+			job.OrphanedSample = appendSample(job.OrphanedSample, indexed.HotelID)
+			if job.Repair {
+				uc.deleteOrphan(ctx, job, indexed.HotelID)
+			}
+			job.IndexScanned++
+			job.LastHotelID = indexed.HotelID
+			indexed, indexErr, indexOK = nextIndexed()
+		case dbOK && (!indexOK || dbVersion.HotelID < indexed.HotelID):
+			job.Missing++
+			job.MissingSample = appendSample(job.MissingSample, dbVersion.HotelID)
+			queueReindex(dbVersion.HotelID)
+			job.DatabaseScanned++
+			job.LastHotelID = dbVersion.HotelID
+			dbVersion, dbErr, dbOK = nextDatabase()
+		default:
+			// The index stores updated_at in whole seconds.
+			if indexed.UpdatedAt.Before(dbVersion.UpdatedAt.Truncate(time.Second)) {
+				job.Stale++
+				job.StaleSample = appendSample(job.StaleSample, dbVersion.HotelID)
+				queueReindex(dbVersion.HotelID)
+			}
+			job.DatabaseScanned++
+			job.IndexScanned++
+			job.LastHotelID = dbVersion.HotelID
+			dbVersion, dbErr, dbOK = nextDatabase()
+			indexed, indexErr, indexOK = nextIndexed()
+		}
+
+		compared++
+		if compared%reconcileProgressEvery == 0 {
+			job.UpdatedAt = time.Now().UTC()
+			if err := uc.saveJob(ctx, job); err != nil {
+				uc.logger.Warn("Failed to save reconciliation progress", "job_id", job.ID, "error", err)
+			}
+			if err := ctx.Err(); err != nil {
+				uc.finishJob(ctx, job, JobStatusFailed, err)
+				return err
+			}
+		}
+	}
+
+	flush()
+	uc.finishJob(ctx, job, JobStatusCompleted, nil)
+
+	uc.logger.Info("Index reconciliation job completed",
+		"job_id", job.ID,
+		"missing", job.Missing,
+		"stale", job.Stale,
+		"orphaned", job.Orphaned,
+		"reindexed", job.Reindexed,
+		"deleted", job.Deleted,
+		"failed_repairs", job.FailedRepairs)
+
+	return nil
+}
+
+func (uc *ReconcileUseCase) newJob(ctx context.Context, options ReconcileJobOptions) (*ReconcileJob, error) {
+	now := time.Now().UTC()
+	job := &ReconcileJob{
+		ID:             uuid.NewString(),
+		Repair:         options.Repair,
+		Status:         JobStatusRunning,
+		MissingSample:  make([]int64, 0),
+		StaleSample:    make([]int64, 0),
+		OrphanedSample: make([]int64, 0),
+		StartedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (uc *ReconcileUseCase) GetJob(ctx context.Context, jobID string) (*ReconcileJob, error) {
+	data, err := uc.cache.Get(ctx, reconcileJobKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrReconcileJobNotFound
+	}
+
+	var job ReconcileJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode reconcile job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// activeVersions pages through the active hotels of the database in hotel_id order.
+func (uc *ReconcileUseCase) activeVersions(ctx context.Context) iter.Seq2[hotel.Version, error] {
+	return func(yield func(hotel.Version, error) bool) {
+		var afterHotelID int64
+		for {
+			versions, err := uc.hotelRepo.FindActiveVersionsAfter(ctx, afterHotelID, reconcileVersionBatch)
+			if err != nil {
+				yield(hotel.Version{}, fmt.Errorf("failed to list active hotels after %d: %w", afterHotelID, err))
+				return
+			}
+
+			for _, version := range versions {
+				if !yield(version, nil) {
+					return
+				}
+				afterHotelID = version.HotelID
+			}
+
+			if len(versions) < reconcileVersionBatch {
+				return
+			}
+		}
+	}
+}
+
+func (uc *ReconcileUseCase) reindex(ctx context.Context, job *ReconcileJob, hotelIDs []int64) {
+	hotels, err := uc.hotelRepo.FindByHotelIDs(ctx, hotelIDs)
+	if err != nil {
+		uc.logger.Warn("Failed to load hotels to reindex", "job_id", job.ID, "count", len(hotelIDs), "error", err)
+		job.FailedRepairs += len(hotelIDs)
+		return
+	}
+
+	if len(hotels) > 0 {
+		if err := uc.searchEngine.Index(ctx, hotels); err != nil {
+			uc.logger.Warn("Failed to reindex hotels", "job_id", job.ID, "count", len(hotels), "error", err)
+			job.FailedRepairs += len(hotelIDs)
+			return
+		}
+	}
+
+	job.Reindexed += len(hotels)
+	job.FailedRepairs += len(hotelIDs) - len(hotels)
+}
+
+func (uc *ReconcileUseCase) deleteOrphan(ctx context.Context, job *ReconcileJob, hotelID int64) {
+	if err := uc.searchEngine.DeleteHotel(ctx, strconv.FormatInt(hotelID, 10)); err != nil {
+		uc.logger.Warn("Failed to delete orphaned hotel from index", "job_id", job.ID, "hotel_id", hotelID, "error", err)
+		job.FailedRepairs++
+		return
+	}
+	job.Deleted++
+}
+
+func (uc *ReconcileUseCase) finishJob(ctx context.Context, job *ReconcileJob, status JobStatus, cause error) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	if err := uc.saveJob(context.WithoutCancel(ctx), job); err != nil {
+		uc.logger.Warn("Failed to save reconcile job status", "job_id", job.ID, "error", err)
+	}
+}
+
+func (uc *ReconcileUseCase) saveJob(ctx context.Context, job *ReconcileJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode reconcile job: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, reconcileJobKeyPrefix+job.ID, data, reconcileJobTTL); err != nil {
+		return fmt.Errorf("failed to save reconcile job: %w", err)
+	}
+
+	return nil
+}
+
+func appendSample(sample []int64, hotelID int64) []int64 {
+	if len(sample) >= reconcileSampleSize {
+		return sample
+	}
+	return append(sample, hotelID)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newReconcileTest(t *testing.T) (*ReconcileUseCase, *mocks.MockRepository, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	return NewReconcileUseCase(repository, engine, cache, slog.New(slog.DiscardHandler)), repository, engine, cache
+}
+
+func indexedVersions(versions ...hotel.Version) iter.Seq2[hotel.Version, error] {
+	return func(yield func(hotel.Version, error) bool) {
+		for _, version := range versions {
+			if !yield(version, nil) {
+				return
+			}
+		}
+	}
+}
+
+// expectDivergence seeds a database and an index that diverge in every category: hotel 2 is not
+// indexed, hotel 3 is indexed with an older updated_at and hotels 1 and 5 are only indexed.
+func expectDivergence(repository *mocks.MockRepository, engine *mocks.MockEngine) {
+	updatedAt := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	repository.EXPECT().FindActiveVersionsAfter(gomock.Any(), int64(0), reconcileVersionBatch).Return([]hotel.Version{
+		{HotelID: 2, UpdatedAt: updatedAt},
+		{HotelID: 3, UpdatedAt: updatedAt.Add(90 * time.Second)},
+		{HotelID: 4, UpdatedAt: updatedAt.Add(500 * time.Millisecond)},
+	}, nil)
+	engine.EXPECT().ExportIDs(gomock.Any()).Return(indexedVersions(
+		hotel.Version{HotelID: 1, UpdatedAt: updatedAt},
+		hotel.Version{HotelID: 3, UpdatedAt: updatedAt},
+		hotel.Version{HotelID: 4, UpdatedAt: updatedAt},
+		hotel.Version{HotelID: 5, UpdatedAt: updatedAt},
+	))
+}
+
+func TestReconcileReportsDivergenceWithoutRepairing(t *testing.T) {
+	uc, repository, engine, cache := newReconcileTest(t)
+	expectDivergence(repository, engine)
+
+	job, err := uc.Run(context.Background(), ReconcileJobOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 1, job.Missing)
+	assert.Equal(t, []int64{2}, job.MissingSample)
+	assert.Equal(t, 1, job.Stale)
+	assert.Equal(t, []int64{3}, job.StaleSample, "sub-second differences are not stale")
+	assert.Equal(t, 2, job.Orphaned)
+	assert.Equal(t, []int64{1, 5}, job.OrphanedSample)
+	assert.Equal(t, 3, job.DatabaseScanned)
+	assert.Equal(t, 4, job.IndexScanned)
+	assert.Zero(t, job.Reindexed)
+	assert.Zero(t, job.Deleted)
+
+	stored, err := uc.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.Missing, stored.Missing, "the report is stored like an asynchronous job")
+	assert.Contains(t, cache.values, reconcileJobKeyPrefix+job.ID)
+}
+
+func TestReconcileRepairsDivergence(t *testing.T) {
+	uc, repository, engine, _ := newReconcileTest(t)
+	expectDivergence(repository, engine)
+
+	reindexed := []*hotel.Hotel{{HotelID: 2}, {HotelID: 3}}
+	engine.EXPECT().DeleteHotel(gomock.Any(), "1").Return(nil)
+	engine.EXPECT().DeleteHotel(gomock.Any(), "5").Return(errors.New("timeout"))
+	repository.EXPECT().FindByHotelIDs(gomock.Any(), []int64{2, 3}).Return(reindexed, nil)
+	engine.EXPECT().Index(gomock.Any(), reindexed).Return(nil)
+
+	job, err := uc.Run(context.Background(), ReconcileJobOptions{Repair: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 2, job.Reindexed)
+	assert.Equal(t, 1, job.Deleted)
+	assert.Equal(t, 1, job.FailedRepairs)
+}
+
+func TestReconcileFailsWhenTheIndexCannotBeRead(t *testing.T) {
+	uc, repository, engine, _ := newReconcileTest(t)
+	repository.EXPECT().FindActiveVersionsAfter(gomock.Any(), int64(0), reconcileVersionBatch).Return(nil, nil)
+	engine.EXPECT().ExportIDs(gomock.Any()).Return(func(yield func(hotel.Version, error) bool) {
+		yield(hotel.Version{}, errors.New("connection refused"))
+	})
+
+	_, err := uc.Run(context.Background(), ReconcileJobOptions{Repair: true})
+
+	assert.ErrorContains(t, err, "connection refused")
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	reviewArchiveJobKeyPrefix = "review_archive:job:"
+	reviewArchiveCursorKey    = "review_archive:cursor"
+	reviewArchiveJobTTL       = 7 * 24 * time.Hour
+)
+
+var (
+	ErrReviewArchiveJobNotFound = errors.New("review archive job not found")
+	ErrReviewArchiveRunning     = errors.New("review archival is already running")
+	ErrInvalidReviewArchive     = errors.New("invalid review archive options")
+)
+
+// ReviewArchiveOptions overrides the configured retention for one run. Zero values keep the
+// configured defaults.
+type ReviewArchiveOptions struct {
+	KeepNewest int  `json:"keep_newest,omitempty"`
+	MinAgeDays int  `json:"min_age_days,omitempty"`
+	BatchSize  int  `json:"batch_size,omitempty"`
+	Restart    bool `json:"restart,omitempty"`
+}
+
+type ReviewArchiveJob struct {
+	ID              string     `json:"id"`
+	Status          JobStatus  `json:"status"`
+	KeepNewest      int        `json:"keep_newest"`
+	MinAgeDays      int        `json:"min_age_days"`
+	ProcessedHotels int        `json:"processed_hotels"`
+	ArchivedReviews int64      `json:"archived_reviews"`
+	ResumedFrom     int64      `json:"resumed_from,omitempty"`
+	LastHotelID     int64      `json:"last_hotel_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// ReviewArchivalUseCase moves old reviews out of the reviews table, keeping the newest
+// reviews of every hotel. Hotels are processed in hotel_id order and the cursor is saved
+// after every batch, so an interrupted run resumes where it stopped.
+type ReviewArchivalUseCase struct {
+	hotelRepo hotel.Repository
+	cache     hotel.CacheRepository
+	defaults  ReviewArchiveOptions
+	logger    *slog.Logger
+
+	running atomic.Bool
+}
+
+func NewReviewArchivalUseCase(
+	hotelRepo hotel.Repository,
+	cache hotel.CacheRepository,
+	defaults ReviewArchiveOptions,
+	logger *slog.Logger,
+) *ReviewArchivalUseCase {
+	return &ReviewArchivalUseCase{
+		hotelRepo: hotelRepo,
+		cache:     cache,
+		defaults:  defaults,
+		logger:    logger,
+	}
+}
+
+// Start records a new job and runs the archival in the background. Only one archival runs
+// per instance at a time.
+func (uc *ReviewArchivalUseCase) Start(ctx context.Context, options ReviewArchiveOptions) (*ReviewArchiveJob, error) {
+	options = uc.withDefaults(options)
+	if options.KeepNewest <= 0 || options.MinAgeDays < 0 || options.BatchSize <= 0 {
+		return nil, fmt.Errorf("%w: keep_newest and batch_size must be positive and min_age_days not negative", ErrInvalidReviewArchive)
+	}
+
+	if !uc.running.CompareAndSwap(false, true) {
+		return nil, ErrReviewArchiveRunning
+	}
+
+	if options.Restart {
+		if err := uc.cache.Delete(ctx, reviewArchiveCursorKey); err != nil {
+			uc.logger.Warn("Failed to reset review archive cursor", "error", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	job := &ReviewArchiveJob{
+		ID:         uuid.NewString(),
+		Status:     JobStatusRunning,
+		KeepNewest: options.KeepNewest,
+		MinAgeDays: options.MinAgeDays,
+		StartedAt:  now,
+		UpdatedAt:  now,
+	}
+	job.ResumedFrom = uc.loadCursor(ctx)
+	job.LastHotelID = job.ResumedFrom
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		uc.running.Store(false)
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		defer uc.running.Store(false)
+		if err := uc.Run(context.Background(), job, options.BatchSize); err != nil {
+			uc.logger.Error("Review archival failed", "job_id", job.ID, "error", err)
+		}
+	}()
+
+	return &snapshot, nil
+}
+
+// Run archives the reviews of batchSize hotels at a time after the job's cursor.
+func (uc *ReviewArchivalUseCase) Run(ctx context.Context, job *ReviewArchiveJob, batchSize int) error {
+	retention := hotel.ReviewRetention{
+		KeepNewest: job.KeepNewest,
+		MinAge:     time.Duration(job.MinAgeDays) * 24 * time.Hour,
+	}
+
+	uc.logger.Info("Starting review archival",
+		"job_id", job.ID,
+		"keep_newest", job.KeepNewest,
+		"min_age_days", job.MinAgeDays,
+		"resume_from", job.LastHotelID)
+
+	for {
+		batch, err := uc.hotelRepo.ArchiveReviews(ctx, job.LastHotelID, batchSize, retention)
+		if err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels == 0 {
+			break
+		}
+
+		job.ProcessedHotels += batch.Hotels
+		job.ArchivedReviews += batch.ArchivedReviews
+		job.LastHotelID = batch.LastHotelID
+		job.UpdatedAt = time.Now().UTC()
+
+		uc.saveCursor(ctx, job.LastHotelID)
+		if err := uc.saveJob(ctx, job); err != nil {
+			uc.logger.Warn("Failed to save review archive progress", "job_id", job.ID, "error", err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels < batchSize {
+			break
+		}
+	}
+
+	if err := uc.cache.Delete(ctx, reviewArchiveCursorKey); err != nil {
+		uc.logger.Warn("Failed to clear review archive cursor", "job_id", job.ID, "error", err)
+	}
+	uc.finishJob(ctx, job, JobStatusCompleted, nil)
+
+	uc.logger.Info("Review archival completed",
+		"job_id", job.ID,
+		"processed_hotels", job.ProcessedHotels,
+		"archived_reviews", job.ArchivedReviews)
+
+	return nil
+}
+
+func (uc *ReviewArchivalUseCase) GetJob(ctx context.Context, jobID string) (*ReviewArchiveJob, error) {
+	data, err := uc.cache.Get(ctx, reviewArchiveJobKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrReviewArchiveJobNotFound
+	}
+
+	var job ReviewArchiveJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode review archive job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (uc *ReviewArchivalUseCase) withDefaults(options ReviewArchiveOptions) ReviewArchiveOptions {
+	if options.KeepNewest == 0 {
+		options.KeepNewest = uc.defaults.KeepNewest
+	}
+	if options.MinAgeDays == 0 {
+		options.MinAgeDays = uc.defaults.MinAgeDays
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = uc.defaults.BatchSize
+	}
+	return options
+}
+
+func (uc *ReviewArchivalUseCase) finishJob(ctx context.Context, job *ReviewArchiveJob, status JobStatus, cause error) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	if err := uc.saveJob(context.WithoutCancel(ctx), job); err != nil {
+		uc.logger.Warn("Failed to save review archive job status", "job_id", job.ID, "error", err)
+	}
+}
+
+func (uc *ReviewArchivalUseCase) saveJob(ctx context.Context, job *ReviewArchiveJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode review archive job: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, reviewArchiveJobKeyPrefix+job.ID, data, reviewArchiveJobTTL); err != nil {
+		return fmt.Errorf("failed to save review archive job: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *ReviewArchivalUseCase) loadCursor(ctx context.Context) int64 {
+	data, err := uc.cache.Get(ctx, reviewArchiveCursorKey)
+	if err != nil {
+		return 0
+	}
+
+	cursor, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		uc.logger.Warn("Ignoring invalid review archive cursor", "error", err)
+		return 0
+	}
+
+	return cursor
+}
+
+func (uc *ReviewArchivalUseCase) saveCursor(ctx context.Context, hotelID int64) {
+	value := []byte(strconv.FormatInt(hotelID, 10))
+	if err := uc.cache.Set(ctx, reviewArchiveCursorKey, value, reviewArchiveJobTTL); err != nil {
+		uc.logger.Warn("Failed to save review archive cursor", "hotel_id", hotelID, "error", err)
+	}
+}
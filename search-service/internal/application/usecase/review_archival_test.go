@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryReviews keeps the reviews and reviews_archive tables in memory and applies retention
+// the way the archive query does: reviews are ranked newest first per hotel, and those ranked
+// past KeepNewest and older than MinAge move to the archive.
+type memoryReviews struct {
+	hotelIDs []int64
+	active   map[int64][]hotel.Review
+	archive  map[int64][]hotel.Review
+	archived map[int64]int
+	now      time.Time
+
+	// failAfter fails ArchiveReviews once for the batch after this hotel id, when set.
+	failAfter *int64
+	cursors   []int64
+}
+
+func newMemoryReviews(now time.Time, reviewsPerHotel map[int64]int) *memoryReviews {
+	store := &memoryReviews{
+		active:   make(map[int64][]hotel.Review),
+		archive:  make(map[int64][]hotel.Review),
+		archived: make(map[int64]int),
+		now:      now,
+	}
+	reviewID := int64(0)
+	for hotelID, count := range reviewsPerHotel {
+		store.hotelIDs = append(store.hotelIDs, hotelID)
+		for i := range count {
+			reviewID++
+			store.active[hotelID] = append(store.active[hotelID], hotel.Review{
+				HotelID:  hotelID,
+				ReviewID: reviewID,
+				Date:     now.AddDate(0, 0, -10*i),
+			})
+		}
+	}
+	slices.Sort(store.hotelIDs)
+	return store
+}
+
+func sortNewestFirst(reviews []hotel.Review) {
+	slices.SortFunc(reviews, func(a, b hotel.Review) int {
+		if c := b.Date.Compare(a.Date); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ReviewID, b.ReviewID)
+	})
+}
+
+func (s *memoryReviews) archiveReviews(_ context.Context, afterHotelID int64, hotelLimit int, retention hotel.ReviewRetention) (hotel.ReviewArchiveBatch, error) {
+	s.cursors = append(s.cursors, afterHotelID)
+	if s.failAfter != nil && *s.failAfter == afterHotelID {
+		s.failAfter = nil
+		return hotel.ReviewArchiveBatch{}, errors.New("connection reset by peer")
+	}
+
+	var batch hotel.ReviewArchiveBatch
+	cutoff := s.now.Add(-retention.MinAge)
+	for _, hotelID := range s.hotelIDs {
+		if hotelID <= afterHotelID || batch.Hotels == hotelLimit {
+			continue
+		}
+		batch.Hotels++
+		batch.LastHotelID = hotelID
+
+		reviews := s.active[hotelID]
+		sortNewestFirst(reviews)
+		var kept []hotel.Review
+		for position, review := range reviews {
+			if position >= retention.KeepNewest && review.Date.Before(cutoff) {
+				s.archive[hotelID] = append(s.archive[hotelID], review)
+				s.archived[hotelID]++
+				batch.ArchivedReviews++
+				continue
+			}
+			kept = append(kept, review)
+		}
+		s.active[hotelID] = kept
+	}
+	return batch, nil
+}
+
+func (s *memoryReviews) listReviews(_ context.Context, hotelID int64, options hotel.ListReviewsOptions) ([]hotel.Review, error) {
+	reviews := slices.Clone(s.active[hotelID])
+	if options.IncludeArchived {
+		reviews = append(reviews, s.archive[hotelID]...)
+	}
+	sortNewestFirst(reviews)
+	if options.Offset >= len(reviews) {
+		return []hotel.Review{}, nil
+	}
+	return reviews[options.Offset:min(options.Offset+options.Limit, len(reviews))], nil
+}
+
+func newReviewArchivalTest(t *testing.T, store *memoryReviews) (*ReviewArchivalUseCase, *fakeCache) {
+	t.Helper()
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().ArchiveReviews(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(store.archiveReviews).AnyTimes()
+	cache := newFakeCache()
+	defaults := ReviewArchiveOptions{KeepNewest: 10, MinAgeDays: 30, BatchSize: 2}
+	return NewReviewArchivalUseCase(repo, cache, defaults, slog.New(slog.DiscardHandler)), cache
+}
+
+// assertRetention checks that every hotel keeps its newest reviews and every review younger
+// than the minimum age, and that nothing was lost or duplicated.
+func assertRetention(t *testing.T, store *memoryReviews, reviewsPerHotel map[int64]int, keepNewest int, minAge time.Duration) {
+	t.Helper()
+	cutoff := store.now.Add(-minAge)
+	for hotelID, total := range reviewsPerHotel {
+		active, archived := store.active[hotelID], store.archive[hotelID]
+		assert.Len(t, append(slices.Clone(active), archived...), total, "hotel %d keeps every review", hotelID)
+		assert.Equal(t, len(archived), store.archived[hotelID], "hotel %d archived_review_count", hotelID)
+
+		recent := 0
+		for i := range total {
+			if !store.now.AddDate(0, 0, -10*i).Before(cutoff) {
+				recent++
+			}
+		}
+		assert.Len(t, active, max(min(keepNewest, total), recent), "hotel %d", hotelID)
+
+		for _, review := range archived {
+			assert.True(t, review.Date.Before(cutoff), "hotel %d archived review %d is older than the minimum age", hotelID, review.ReviewID)
+			for _, kept := range active {
+				assert.True(t, kept.Date.After(review.Date), "hotel %d keeps its newest reviews", hotelID)
+			}
+		}
+	}
+}
+
+func TestReviewArchivalAppliesRetentionIdempotently(t *testing.T) {
+	ctx := context.Background()
+	reviewsPerHotel := map[int64]int{1: 30, 2: 5, 3: 12, 7: 40}
+	store := newMemoryReviews(time.Now(), reviewsPerHotel)
+	uc, cache := newReviewArchivalTest(t, store)
+
+	job := &ReviewArchiveJob{ID: "first", KeepNewest: 10, MinAgeDays: 30}
+	require.NoError(t, uc.Run(ctx, job, 2))
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 4, job.ProcessedHotels)
+	assert.Equal(t, int64(20+0+2+30), job.ArchivedReviews)
+	assert.Equal(t, []int64{0, 2, 7}, store.cursors, "hotels are paged by hotel_id")
+	assertRetention(t, store, reviewsPerHotel, 10, 30*24*time.Hour)
+	assert.NotContains(t, cache.values, reviewArchiveCursorKey, "a completed run clears the cursor")
+
+	archive := make(map[int64][]hotel.Review)
+	for hotelID, reviews := range store.archive {
+		archive[hotelID] = slices.Clone(reviews)
+	}
+
+	again := &ReviewArchiveJob{ID: "second", KeepNewest: 10, MinAgeDays: 30}
+	require.NoError(t, uc.Run(ctx, again, 2))
+	assert.Equal(t, 4, again.ProcessedHotels)
+	assert.Zero(t, again.ArchivedReviews, "a second run archives nothing new")
+	assert.Equal(t, archive, store.archive)
+	assertRetention(t, store, reviewsPerHotel, 10, 30*24*time.Hour)
+}
+
+func TestReviewArchivalKeepsReviewsYoungerThanTheMinimumAge(t *testing.T) {
+	reviewsPerHotel := map[int64]int{1: 30}
+	store := newMemoryReviews(time.Now(), reviewsPerHotel)
+	uc, _ := newReviewArchivalTest(t, store)
+
+	job := &ReviewArchiveJob{ID: "job", KeepNewest: 5, MinAgeDays: 150}
+	require.NoError(t, uc.Run(context.Background(), job, 10))
+	assert.Len(t, store.active[1], 16, "reviews up to 150 days old stay past the newest 5")
+	assertRetention(t, store, reviewsPerHotel, 5, 150*24*time.Hour)
+}
+
+func TestReviewArchivalResumesFromTheCursor(t *testing.T) {
+	ctx := context.Background()
+	reviewsPerHotel := map[int64]int{1: 30, 2: 25, 3: 12, 4: 15}
+	store := newMemoryReviews(time.Now(), reviewsPerHotel)
+	failAfter := int64(2)
+	store.failAfter = &failAfter
+	uc, cache := newReviewArchivalTest(t, store)
+
+	failed := &ReviewArchiveJob{ID: "failed", KeepNewest: 10, MinAgeDays: 30}
+	assert.ErrorContains(t, uc.Run(ctx, failed, 2), "connection reset")
+	assert.Equal(t, JobStatusFailed, failed.Status)
+	assert.Equal(t, "2", string(cache.values[reviewArchiveCursorKey]))
+
+	job, err := uc.Start(ctx, ReviewArchiveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), job.ResumedFrom)
+	require.Eventually(t, func() bool {
+		stored, err := uc.GetJob(ctx, job.ID)
+		return err == nil && stored.Status == JobStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	stored, err := uc.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.ProcessedHotels, "the resumed run starts after the saved cursor")
+	assert.Equal(t, []int64{0, 2, 2, 4}, store.cursors)
+	assertRetention(t, store, reviewsPerHotel, 10, 30*24*time.Hour)
+}
+
+func TestReviewArchivalStartValidatesOptions(t *testing.T) {
+	uc, _ := newReviewArchivalTest(t, newMemoryReviews(time.Now(), nil))
+
+	for _, options := range []ReviewArchiveOptions{
+		{KeepNewest: -1},
+		{MinAgeDays: -1},
+		{BatchSize: -5},
+	} {
+		_, err := uc.Start(context.Background(), options)
+		assert.ErrorIs(t, err, ErrInvalidReviewArchive, "%+v", options)
+	}
+}
+
+func TestHotelReviewsListIncludesArchivedReviewsOnRequest(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryReviews(time.Now(), map[int64]int{1: 30})
+	uc, _ := newReviewArchivalTest(t, store)
+	require.NoError(t, uc.Run(ctx, &ReviewArchiveJob{ID: "job", KeepNewest: 10, MinAgeDays: 30}, 10))
+
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().ListReviews(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(store.listReviews).AnyTimes()
+	reviews := NewHotelReviewsUseCase(repo, slog.New(slog.DiscardHandler))
+
+	current, err := reviews.List(ctx, 1, false, 1, 100)
+	require.NoError(t, err)
+	assert.Len(t, current, 10)
+
+	history, err := reviews.List(ctx, 1, true, 1, 100)
+	require.NoError(t, err)
+	assert.Len(t, history, 30)
+	assert.Equal(t, current, history[:10], "archived reviews follow the current ones")
+
+	page, err := reviews.List(ctx, 1, true, 3, 12)
+	require.NoError(t, err)
+	assert.Equal(t, history[24:], page)
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+const (
+	reviewDedupJobKeyPrefix     = "review_dedup:job:"
+	reviewDedupCursorKey        = "review_dedup:cursor"
+	reviewDedupJobTTL           = 7 * 24 * time.Hour
+	defaultReviewDedupBatchSize = 100
+)
+
+var (
+	ErrReviewDedupJobNotFound = errors.New("review dedupe job not found")
+	ErrReviewDedupRunning     = errors.New("review dedupe is already running")
+	ErrInvalidReviewDedup     = errors.New("invalid review dedupe options")
+)
+
+type ReviewDedupOptions struct {
+	BatchSize int  `json:"batch_size,omitempty"`
+	Restart   bool `json:"restart,omitempty"`
+}
+
+type ReviewDedupJob struct {
+	ID                   string     `json:"id"`
+	Status               JobStatus  `json:"status"`
+	ProcessedHotels      int        `json:"processed_hotels"`
+	FingerprintedReviews int64      `json:"fingerprinted_reviews"`
+	MergedReviews        int64      `json:"merged_reviews"`
+	ResumedFrom          int64      `json:"resumed_from,omitempty"`
+	LastHotelID          int64      `json:"last_hotel_id"`
+	StartedAt            time.Time  `json:"started_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	FinishedAt           *time.Time `json:"finished_at,omitempty"`
+	Error                string     `json:"error,omitempty"`
+}
+
+// ReviewDedupUseCase is a maintenance task that merges reviews stored more than once under
+// different review ids. It fingerprints the reviews stored before fingerprints existed, keeps
+// the oldest review of every fingerprint and soft-deletes the rest, recounting the review count
+// of the hotel from the reviews left. Like review archival it works through hotels in hotel_id
+// order and resumes from its saved cursor.
+type ReviewDedupUseCase struct {
+	hotelRepo hotel.Repository
+	cache     hotel.CacheRepository
+	logger    *slog.Logger
+
+	running atomic.Bool
+}
+
+func NewReviewDedupUseCase(hotelRepo hotel.Repository, cache hotel.CacheRepository, logger *slog.Logger) *ReviewDedupUseCase {
+	return &ReviewDedupUseCase{
+		hotelRepo: hotelRepo,
+		cache:     cache,
+		logger:    logger,
+	}
+}
+
+// Start records a new job and runs the dedupe in the background. Only one dedupe runs per
+// instance at a time.
+func (uc *ReviewDedupUseCase) Start(ctx context.Context, options ReviewDedupOptions) (*ReviewDedupJob, error) {
+	if options.BatchSize == 0 {
+		options.BatchSize = defaultReviewDedupBatchSize
+	}
+	if options.BatchSize < 0 {
+		return nil, fmt.Errorf("%w: batch_size must be positive", ErrInvalidReviewDedup)
+	}
+
+	if !uc.running.CompareAndSwap(false, true) {
+		return nil, ErrReviewDedupRunning
+	}
+
+	if options.Restart {
+		if err := uc.cache.Delete(ctx, reviewDedupCursorKey); err != nil {
+			uc.logger.Warn("Failed to reset review dedupe cursor", "error", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	job := &ReviewDedupJob{
+		ID:        uuid.NewString(),
+		Status:    JobStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	job.ResumedFrom = uc.loadCursor(ctx)
+	job.LastHotelID = job.ResumedFrom
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		uc.running.Store(false)
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		defer uc.running.Store(false)
+		if err := uc.Run(context.Background(), job, options.BatchSize); err != nil {
+			uc.logger.Error("Review dedupe failed", "job_id", job.ID, "error", err)
+		}
+	}()
+
+	return &snapshot, nil
+}
+
+// Run deduplicates the reviews of batchSize hotels at a time after the job's cursor.
+func (uc *ReviewDedupUseCase) Run(ctx context.Context, job *ReviewDedupJob, batchSize int) error {
+	uc.logger.Info("Starting review dedupe", "job_id", job.ID, "resume_from", job.LastHotelID)
+
+	for {
+		batch, err := uc.hotelRepo.DeduplicateReviews(ctx, job.LastHotelID, batchSize)
+		if err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels == 0 {
+			break
+		}
+
+		job.ProcessedHotels += batch.Hotels
+		job.FingerprintedReviews += batch.Fingerprinted
+		job.MergedReviews += batch.MergedReviews
+		job.LastHotelID = batch.LastHotelID
+		job.UpdatedAt = time.Now().UTC()
+
+		uc.saveCursor(ctx, job.LastHotelID)
+		if err := uc.saveJob(ctx, job); err != nil {
+			uc.logger.Warn("Failed to save review dedupe progress", "job_id", job.ID, "error", err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			uc.finishJob(ctx, job, JobStatusFailed, err)
+			return err
+		}
+
+		if batch.Hotels < batchSize {
+			break
+		}
+	}
+
+	if err := uc.cache.Delete(ctx, reviewDedupCursorKey); err != nil {
+		uc.logger.Warn("Failed to clear review dedupe cursor", "job_id", job.ID, "error", err)
+	}
+	uc.finishJob(ctx, job, JobStatusCompleted, nil)
+
+	uc.logger.Info("Review dedupe completed",
+		"job_id", job.ID,
+		"processed_hotels", job.ProcessedHotels,
+		"fingerprinted_reviews", job.FingerprintedReviews,
+		"merged_reviews", job.MergedReviews)
+
+	return nil
+}
+
+func (uc *ReviewDedupUseCase) GetJob(ctx context.Context, jobID string) (*ReviewDedupJob, error) {
+	data, err := uc.cache.Get(ctx, reviewDedupJobKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrReviewDedupJobNotFound
+	}
+
+	var job ReviewDedupJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode review dedupe job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (uc *ReviewDedupUseCase) finishJob(ctx context.Context, job *ReviewDedupJob, status JobStatus, cause error) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	if err := uc.saveJob(context.WithoutCancel(ctx), job); err != nil {
+		uc.logger.Warn("Failed to save review dedupe job status", "job_id", job.ID, "error", err)
+	}
+}
+
+func (uc *ReviewDedupUseCase) saveJob(ctx context.Context, job *ReviewDedupJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode review dedupe job: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, reviewDedupJobKeyPrefix+job.ID, data, reviewDedupJobTTL); err != nil {
+		return fmt.Errorf("failed to save review dedupe job: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *ReviewDedupUseCase) loadCursor(ctx context.Context) int64 {
+	data, err := uc.cache.Get(ctx, reviewDedupCursorKey)
+	if err != nil {
+		return 0
+	}
+
+	cursor, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		uc.logger.Warn("Ignoring invalid review dedupe cursor", "error", err)
+		return 0
+	}
+
+	return cursor
+}
+
+func (uc *ReviewDedupUseCase) saveCursor(ctx context.Context, hotelID int64) {
+	value := []byte(strconv.FormatInt(hotelID, 10))
+	if err := uc.cache.Set(ctx, reviewDedupCursorKey, value, reviewDedupJobTTL); err != nil {
+		uc.logger.Warn("Failed to save review dedupe cursor", "hotel_id", hotelID, "error", err)
+	}
+}
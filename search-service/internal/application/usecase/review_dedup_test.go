@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// dedupBatches serves a ReviewDedupBatch per hotel of hotelIDs, each with the given merged
+// reviews, and records the cursors it was asked to continue from.
+type dedupBatches struct {
+	hotelIDs []int64
+	merged   map[int64]int64
+	cursors  []int64
+
+	// failAfter fails the batch after this hotel id once, when set.
+	failAfter *int64
+}
+
+func (d *dedupBatches) deduplicate(_ context.Context, afterHotelID int64, hotelLimit int) (hotel.ReviewDedupBatch, error) {
+	d.cursors = append(d.cursors, afterHotelID)
+	if d.failAfter != nil && *d.failAfter == afterHotelID {
+		d.failAfter = nil
+		return hotel.ReviewDedupBatch{}, errors.New("connection reset by peer")
+	}
+
+	var batch hotel.ReviewDedupBatch
+	for _, hotelID := range d.hotelIDs {
+		if hotelID <= afterHotelID || batch.Hotels == hotelLimit {
+			continue
+		}
+		batch.Hotels++
+		batch.LastHotelID = hotelID
+		batch.Fingerprinted++
+		batch.MergedReviews += d.merged[hotelID]
+		d.merged[hotelID] = 0
+	}
+	return batch, nil
+}
+
+func newReviewDedupTest(t *testing.T, batches *dedupBatches) (*ReviewDedupUseCase, *fakeCache) {
+	t.Helper()
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().DeduplicateReviews(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(batches.deduplicate).AnyTimes()
+	cache := newFakeCache()
+	return NewReviewDedupUseCase(repo, cache, slog.New(slog.DiscardHandler)), cache
+}
+
+func TestReviewDedupTotalsTheBatches(t *testing.T) {
+	ctx := context.Background()
+	batches := &dedupBatches{hotelIDs: []int64{1, 2, 5, 9, 12}, merged: map[int64]int64{1: 2, 5: 1, 12: 4}}
+	uc, cache := newReviewDedupTest(t, batches)
+
+	job := &ReviewDedupJob{ID: "first"}
+	require.NoError(t, uc.Run(ctx, job, 2))
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 5, job.ProcessedHotels)
+	assert.Equal(t, int64(5), job.FingerprintedReviews)
+	assert.Equal(t, int64(7), job.MergedReviews)
+	assert.Equal(t, int64(12), job.LastHotelID)
+	assert.Equal(t, []int64{0, 2, 9}, batches.cursors, "hotels are paged by hotel_id")
+	assert.NotContains(t, cache.values, reviewDedupCursorKey, "a completed run clears the cursor")
+
+	stored, err := uc.GetJob(ctx, "first")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), stored.MergedReviews)
+	assert.NotNil(t, stored.FinishedAt)
+
+	again := &ReviewDedupJob{ID: "second"}
+	require.NoError(t, uc.Run(ctx, again, 2))
+	assert.Zero(t, again.MergedReviews, "a second run merges nothing new")
+}
+
+func TestReviewDedupResumesFromTheCursor(t *testing.T) {
+	ctx := context.Background()
+	failAfter := int64(2)
+	batches := &dedupBatches{hotelIDs: []int64{1, 2, 3, 4}, merged: map[int64]int64{1: 1, 3: 1}, failAfter: &failAfter}
+	uc, cache := newReviewDedupTest(t, batches)
+
+	failed := &ReviewDedupJob{ID: "failed"}
+	assert.ErrorContains(t, uc.Run(ctx, failed, 2), "connection reset")
+	assert.Equal(t, JobStatusFailed, failed.Status)
+	assert.Equal(t, "2", string(cache.values[reviewDedupCursorKey]))
+
+	job, err := uc.Start(ctx, ReviewDedupOptions{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), job.ResumedFrom)
+	require.Eventually(t, func() bool {
+		stored, err := uc.GetJob(ctx, job.ID)
+		return err == nil && stored.Status == JobStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	stored, err := uc.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.ProcessedHotels, "the resumed run starts after the saved cursor")
+	assert.Equal(t, int64(1), stored.MergedReviews)
+	assert.Equal(t, []int64{0, 2, 2, 4}, batches.cursors)
+}
+
+func TestReviewDedupRestartIgnoresTheCursor(t *testing.T) {
+	ctx := context.Background()
+	batches := &dedupBatches{hotelIDs: []int64{1, 2}, merged: map[int64]int64{}}
+	uc, cache := newReviewDedupTest(t, batches)
+	cache.values[reviewDedupCursorKey] = []byte("2")
+
+	job, err := uc.Start(ctx, ReviewDedupOptions{Restart: true})
+	require.NoError(t, err)
+	assert.Zero(t, job.ResumedFrom)
+	require.Eventually(t, func() bool {
+		stored, err := uc.GetJob(ctx, job.ID)
+		return err == nil && stored.Status == JobStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReviewDedupStartValidatesOptions(t *testing.T) {
+	uc, _ := newReviewDedupTest(t, &dedupBatches{merged: map[int64]int64{}})
+
+	_, err := uc.Start(context.Background(), ReviewDedupOptions{BatchSize: -5})
+	assert.ErrorIs(t, err, ErrInvalidReviewDedup)
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	searchTuningKey         = "search_config:tuning"
+	searchPreviousBundleKey = "search_config:previous"
+)
+
+var ErrNoPreviousSearchConfig = errors.New("no previous search config to roll back to")
+
+// SearchConfigUseCase exports and applies the search configuration as one bundle. Synonyms
+// live in the search index; ranking profiles and facets are runtime tuning stored in Redis,
+// with the file configuration as the fallback.
+type SearchConfigUseCase struct {
+	synonyms       search.SynonymStore
+	engine         search.TuningApplier
+	cache          hotel.CacheRepository
+	fallbackTuning search.Tuning
+	logger         *slog.Logger
+
+	// mu serializes applies so two bundles are never interleaved.
+	mu sync.Mutex
+}
+
+func NewSearchConfigUseCase(synonyms search.SynonymStore, engine search.TuningApplier, cache hotel.CacheRepository, fallbackTuning search.Tuning, logger *slog.Logger) *SearchConfigUseCase {
+	return &SearchConfigUseCase{
+		synonyms:       synonyms,
+		engine:         engine,
+		cache:          cache,
+		fallbackTuning: fallbackTuning,
+		logger:         logger,
+	}
+}
+
+// Load applies the stored tuning to the engine. It is called at startup and periodically so
+// every instance follows bundles applied through another one.
+func (uc *SearchConfigUseCase) Load(ctx context.Context) search.Tuning {
+	tuning := uc.currentTuning(ctx)
+	uc.engine.ApplyTuning(tuning)
+	return tuning
+}
+
+// Watch reloads the tuning every interval until ctx is done.
+func (uc *SearchConfigUseCase) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			uc.Load(ctx)
+		}
+	}
+}
+
+func (uc *SearchConfigUseCase) Export(ctx context.Context) (*search.ConfigBundle, error) {
+	synonyms, err := uc.synonyms.ListSynonyms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export synonyms: %w", err)
+	}
+
+	bundle := &search.ConfigBundle{
+		Synonyms: synonyms,
+		Tuning:   uc.currentTuning(ctx),
+	}
+	bundle.Normalize()
+	bundle.Version = bundle.Hash()
+
+	return bundle, nil
+}
+
+// Apply validates the whole bundle before changing anything. When a step fails, the steps
+// already done are reverted, so a bundle is applied completely or not at all. The replaced
+// bundle is kept for Rollback.
+func (uc *SearchConfigUseCase) Apply(ctx context.Context, bundle search.ConfigBundle) (*search.ConfigBundle, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.apply(ctx, bundle)
+}
+
+// Rollback re-applies the bundle replaced by the last Apply. Rolling back twice returns to
+// the bundle that was rolled back.
+func (uc *SearchConfigUseCase) Rollback(ctx context.Context) (*search.ConfigBundle, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	data, err := uc.cache.Get(ctx, searchPreviousBundleKey)
+	if err != nil {
+		return nil, ErrNoPreviousSearchConfig
+	}
+
+	var previous search.ConfigBundle
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to decode previous search config: %w", err)
+	}
+
+	return uc.apply(ctx, previous)
+}
+
+func (uc *SearchConfigUseCase) apply(ctx context.Context, bundle search.ConfigBundle) (*search.ConfigBundle, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	bundle.Normalize()
+	bundle.Version = bundle.Hash()
+
+	current, err := uc.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version == bundle.Version {
+		return current, nil
+	}
+
+	tuningData, err := json.Marshal(bundle.Tuning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search tuning: %w", err)
+	}
+	previousData, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode previous search config: %w", err)
+	}
+
+	if err := uc.synonyms.ReplaceSynonyms(ctx, bundle.Synonyms); err != nil {
+		uc.restoreSynonyms(ctx, current.Synonyms)
+		return nil, fmt.Errorf("failed to apply synonyms: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, searchTuningKey, tuningData, 0); err != nil {
+		uc.restoreSynonyms(ctx, current.Synonyms)
+		return nil, fmt.Errorf("failed to store search tuning: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, searchPreviousBundleKey, previousData, 0); err != nil {
+		uc.logger.Warn("Failed to store previous search config, rollback will not be available", "error", err)
+	}
+
+	uc.engine.ApplyTuning(bundle.Tuning)
+
+	uc.logger.Info("Search config applied",
+		"audit", true,
+		"version", bundle.Version,
+		"previous_version", current.Version,
+		"synonyms", len(bundle.Synonyms))
+
+	return &bundle, nil
+}
+
+func (uc *SearchConfigUseCase) restoreSynonyms(ctx context.Context, synonyms []search.Synonym) {
+	if err := uc.synonyms.ReplaceSynonyms(ctx, synonyms); err != nil {
+		uc.logger.Error("Failed to restore synonyms after a failed search config apply", "error", err)
+	}
+}
+
+func (uc *SearchConfigUseCase) currentTuning(ctx context.Context) search.Tuning {
+	data, err := uc.cache.Get(ctx, searchTuningKey)
+	if err != nil {
+		return uc.fallbackTuning
+	}
+
+	var tuning search.Tuning
+	if err := json.Unmarshal(data, &tuning); err != nil {
+		uc.logger.Warn("Failed to decode stored search tuning, using file config", "error", err)
+		return uc.fallbackTuning
+	}
+
+	return tuning
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+// fakeSynonymStore keeps the synonyms of an index in memory.
+type fakeSynonymStore struct {
+	synonyms   []search.Synonym
+	replaces   int
+	replaceErr error
+}
+
+func (s *fakeSynonymStore) ListSynonyms(context.Context) ([]search.Synonym, error) {
+	return slices.Clone(s.synonyms), nil
+}
+
+func (s *fakeSynonymStore) ReplaceSynonyms(_ context.Context, synonyms []search.Synonym) error {
+	s.replaces++
+	if s.replaceErr != nil {
+		return s.replaceErr
+	}
+	s.synonyms = slices.Clone(synonyms)
+	return nil
+}
+
+// recordingApplier records the tunings applied to the engine.
+type recordingApplier struct {
+	applied []search.Tuning
+}
+
+func (a *recordingApplier) ApplyTuning(tuning search.Tuning) {
+	a.applied = append(a.applied, tuning)
+}
+
+func (a *recordingApplier) last() search.Tuning {
+	return a.applied[len(a.applied)-1]
+}
+
+// tuningWriteFailingCache fails to store the search tuning.
+type tuningWriteFailingCache struct {
+	*fakeCache
+}
+
+func (c tuningWriteFailingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if key == searchTuningKey {
+		return errors.New("redis: connection refused")
+	}
+	return c.fakeCache.Set(ctx, key, value, ttl)
+}
+
+type searchConfigTest struct {
+	uc       *SearchConfigUseCase
+	synonyms *fakeSynonymStore
+	engine   *recordingApplier
+	cache    *fakeCache
+}
+
+func newSearchConfigTest() *searchConfigTest {
+	test := &searchConfigTest{
+		synonyms: &fakeSynonymStore{},
+		engine:   &recordingApplier{},
+		cache:    newFakeCache(),
+	}
+	test.uc = NewSearchConfigUseCase(test.synonyms, test.engine, test.cache, search.DefaultTuning(), slog.New(slog.DiscardHandler))
+	return test
+}
+
+func stagingBundle() search.ConfigBundle {
+	return search.ConfigBundle{
+		Synonyms: []search.Synonym{
+			{ID: "nyc", Root: "nyc", Synonyms: []string{"new york", "manhattan"}},
+			{ID: "hotel-terms", Synonyms: []string{"hotel", "inn", "lodge"}},
+		},
+		Tuning: search.Tuning{
+			RankingProfiles: map[string]search.RankingProfileSettings{
+				search.RankingProfileRelevance: {QueryByWeights: "6,2", PrioritizeExactMatch: true},
+				search.RankingProfileClassic:   {},
+			},
+			Facets: search.FacetSettings{Fields: []string{"city", "star_rating"}, MaxValues: 20},
+		},
+	}
+}
+
+func TestSearchConfigExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	staging := newSearchConfigTest()
+
+	applied, err := staging.uc.Apply(ctx, stagingBundle())
+	require.NoError(t, err)
+	assert.NotEmpty(t, applied.Version)
+
+	exported, err := staging.uc.Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, applied, exported)
+	assert.Equal(t, []string{"hotel-terms", "nyc"}, []string{exported.Synonyms[0].ID, exported.Synonyms[1].ID}, "synonyms are exported in id order")
+
+	// The exported document promotes the configuration to another environment unchanged.
+	document, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var imported search.ConfigBundle
+	require.NoError(t, json.Unmarshal(document, &imported))
+
+	production := newSearchConfigTest()
+	_, err = production.uc.Apply(ctx, imported)
+	require.NoError(t, err)
+	promoted, err := production.uc.Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, exported, promoted)
+	assert.Equal(t, exported.Tuning, production.engine.last())
+
+	// Applying the active bundle again changes nothing.
+	replaces := production.synonyms.replaces
+	again, err := production.uc.Apply(ctx, imported)
+	require.NoError(t, err)
+	assert.Equal(t, exported.Version, again.Version)
+	assert.Equal(t, replaces, production.synonyms.replaces)
+}
+
+func TestSearchConfigRejectsMalformedBundles(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*search.ConfigBundle)
+	}{
+		{name: "invalid synonym id", modify: func(b *search.ConfigBundle) { b.Synonyms[0].ID = "new york" }},
+		{name: "duplicate synonym id", modify: func(b *search.ConfigBundle) { b.Synonyms[1].ID = b.Synonyms[0].ID }},
+		{name: "multi-way synonym with one term", modify: func(b *search.ConfigBundle) { b.Synonyms[1].Synonyms = []string{"hotel"} }},
+		{name: "empty synonym term", modify: func(b *search.ConfigBundle) { b.Synonyms[0].Synonyms = []string{"new york", " "} }},
+		{name: "unknown ranking profile", modify: func(b *search.ConfigBundle) {
+			b.RankingProfiles["popularity"] = search.RankingProfileSettings{}
+		}},
+		{name: "malformed weights", modify: func(b *search.ConfigBundle) {
+			b.RankingProfiles[search.RankingProfileRelevance] = search.RankingProfileSettings{QueryByWeights: "6,2,1"}
+		}},
+		{name: "no facet fields", modify: func(b *search.ConfigBundle) { b.Facets.Fields = nil }},
+		{name: "unfacetable field", modify: func(b *search.ConfigBundle) { b.Facets.Fields = []string{"city", "description"} }},
+		{name: "too many facet values", modify: func(b *search.ConfigBundle) { b.Facets.MaxValues = search.MaxFacetValues + 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newSearchConfigTest()
+			bundle := stagingBundle()
+			tt.modify(&bundle)
+
+			_, err := test.uc.Apply(context.Background(), bundle)
+			assert.ErrorIs(t, err, search.ErrInvalidConfigBundle)
+			assert.Zero(t, test.synonyms.replaces, "nothing is applied")
+			assert.Empty(t, test.cache.values)
+			assert.Empty(t, test.engine.applied)
+		})
+	}
+}
+
+func TestSearchConfigApplyIsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	synonyms := &fakeSynonymStore{synonyms: []search.Synonym{{ID: "beach", Synonyms: []string{"beach", "seaside"}}}}
+	engine := &recordingApplier{}
+	cache := tuningWriteFailingCache{newFakeCache()}
+	uc := NewSearchConfigUseCase(synonyms, engine, cache, search.DefaultTuning(), slog.New(slog.DiscardHandler))
+
+	_, err := uc.Apply(ctx, stagingBundle())
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, []search.Synonym{{ID: "beach", Synonyms: []string{"beach", "seaside"}}}, synonyms.synonyms,
+		"the synonyms are restored when the tuning cannot be stored")
+	assert.Empty(t, engine.applied)
+	assert.NotContains(t, cache.values, searchPreviousBundleKey)
+}
+
+func TestSearchConfigRollbackRestoresPreviousBundle(t *testing.T) {
+	ctx := context.Background()
+	test := newSearchConfigTest()
+
+	_, err := test.uc.Rollback(ctx)
+	assert.ErrorIs(t, err, ErrNoPreviousSearchConfig)
+
+	initial, err := test.uc.Export(ctx)
+	require.NoError(t, err)
+	applied, err := test.uc.Apply(ctx, stagingBundle())
+	require.NoError(t, err)
+
+	rolledBack, err := test.uc.Rollback(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, initial.Version, rolledBack.Version)
+	assert.Equal(t, search.DefaultTuning(), test.engine.last(), "queries follow the previous tuning again")
+	assert.Empty(t, test.synonyms.synonyms)
+	exported, err := test.uc.Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, initial, exported)
+
+	// Rolling back twice returns to the bundle that was rolled back.
+	rolledBack, err = test.uc.Rollback(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, applied.Version, rolledBack.Version)
+	assert.Equal(t, applied.Tuning, test.engine.last())
+}
+
+func TestSearchConfigLoadFollowsStoredTuning(t *testing.T) {
+	ctx := context.Background()
+	test := newSearchConfigTest()
+	assert.Equal(t, search.DefaultTuning(), test.uc.Load(ctx), "the file config applies until a bundle is stored")
+
+	// A bundle applied through another instance sharing the cache.
+	other := NewSearchConfigUseCase(test.synonyms, &recordingApplier{}, test.cache, search.DefaultTuning(), slog.New(slog.DiscardHandler))
+	applied, err := other.Apply(ctx, stagingBundle())
+	require.NoError(t, err)
+
+	assert.Equal(t, applied.Tuning, test.uc.Load(ctx))
+	assert.Equal(t, applied.Tuning, test.engine.last())
+
+	test.cache.values[searchTuningKey] = []byte("{")
+	assert.Equal(t, search.DefaultTuning(), test.uc.Load(ctx), "an undecodable tuning falls back to the file config")
+}
@@ -7,61 +7,154 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
 )
 
+const searchRefreshTimeout = 10 * time.Second
+
+// cachedSearchResult is a search result with the time it was computed, which tells fresh,
+// stale and expired entries apart without reading the Redis TTL.
+type cachedSearchResult struct {
+	GeneratedAt time.Time     `json:"cache_generated_at"`
+	Result      search.Result `json:"result"`
+}
+
 type SearchHotelsUseCase struct {
-	searchEngine search.Engine
-	cache        hotel.CacheRepository
-	logger       *slog.Logger
+	searchEngine  search.Engine
+	cache         hotel.CacheRepository
+	loadMonitor   search.LoadMonitor
+	trending      *TrendingQueries
+	snippetLength int
+	logger        *slog.Logger
+	now           func() time.Time
+
+	// observeDurations, when set, receives the total and search engine time of every search.
+	observeDurations func(total, searchEngine time.Duration)
+
+	// Cached results are served as they are for maxAge, then served stale while a background
+	// search refreshes them for staleWhileRevalidate more.
+	maxAge               time.Duration
+	staleWhileRevalidate time.Duration
+	refreshing           sync.Map
 }
 
 func NewSearchHotelsUseCase(
 	searchEngine search.Engine,
 	cache hotel.CacheRepository,
+	loadMonitor search.LoadMonitor,
+	trending *TrendingQueries,
+	snippetLength int,
+	maxAge time.Duration,
+	staleWhileRevalidate time.Duration,
+	observeDurations func(total, searchEngine time.Duration),
 	logger *slog.Logger,
 ) *SearchHotelsUseCase {
 	return &SearchHotelsUseCase{
-		searchEngine: searchEngine,
-		cache:        cache,
-		logger:       logger,
+		searchEngine:         searchEngine,
+		cache:                cache,
+		loadMonitor:          loadMonitor,
+		trending:             trending,
+		snippetLength:        snippetLength,
+		logger:               logger,
+		now:                  time.Now,
+		maxAge:               maxAge,
+		staleWhileRevalidate: staleWhileRevalidate,
+		observeDurations:     observeDurations,
 	}
 }
 
 func (uc *SearchHotelsUseCase) Execute(ctx context.Context, params search.Params) (*search.Result, error) {
+	return uc.ExecuteWithMaxAge(ctx, params, uc.maxAge)
+}
+
+// ExecuteWithMaxAge is Execute with cached results served as fresh for maxAge instead of the
+// configured age, for searches whose results change slowly.
+func (uc *SearchHotelsUseCase) ExecuteWithMaxAge(ctx context.Context, params search.Params, maxAge time.Duration) (*search.Result, error) {
 	startTime := time.Now()
 
 	if err := params.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid search parameters: %w", err)
+		return nil, fmt.Errorf("%w: %w", search.ErrInvalidParams, err)
+	}
+	capabilities := uc.searchEngine.Capabilities()
+	if err := params.ApplyCapabilities(capabilities); err != nil {
+		return nil, err
+	}
+
+	if params.Page <= 1 {
+		uc.trending.Record(ctx, params.Locale, params.Query)
 	}
 
 	cacheKey := uc.generateCacheKey(params)
-	if cachedResult, err := uc.cache.Get(ctx, cacheKey); err == nil {
-		uc.logger.Debug("Cache hit for search", "cache_key", cacheKey)
-		var result search.Result
-		if err := json.Unmarshal(cachedResult, &result); err == nil {
+	if cached, ok := uc.getCached(ctx, cacheKey); ok {
+		age := uc.now().Sub(cached.GeneratedAt)
+		if age <= maxAge+uc.staleWhileRevalidate {
+			if age > maxAge {
+				uc.logger.Debug("Serving stale search result", "cache_key", cacheKey, "age", age)
+				uc.refreshInBackground(cacheKey, params, maxAge)
+			} else {
+				uc.logger.Debug("Cache hit for search", "cache_key", cacheKey)
+			}
+			result := cached.Result
+			result.ApplyCapabilities(capabilities)
+			// The engine was not queried for a cached result.
+			result.SearchEngineTime = 0
+			result.Provenance = search.Provenance{ServedFrom: search.ServedFromCache, CacheAge: age, Engine: uc.searchEngine.Info()}
 			result.ProcessingTime = time.Since(startTime)
+			uc.observe(&result)
 			return &result, nil
 		}
 	}
 
+	if uc.loadMonitor != nil {
+		if shed, retryAfter := uc.loadMonitor.ShouldShed(); shed {
+			uc.loadMonitor.RecordShed()
+			uc.logger.Warn("Shedding search request", "retry_after", retryAfter)
+			return nil, &search.OverloadedError{RetryAfter: retryAfter}
+		}
+	}
+
+	result, err := uc.search(ctx, cacheKey, params, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	result.ApplyCapabilities(capabilities)
+
+	result.Provenance = search.Provenance{ServedFrom: search.ServedFromEngine, Engine: uc.searchEngine.Info()}
+	result.ProcessingTime = time.Since(startTime)
+	uc.observe(result)
+	return result, nil
+}
+
+func (uc *SearchHotelsUseCase) observe(result *search.Result) {
+	if uc.observeDurations != nil {
+		uc.observeDurations(result.ProcessingTime, result.SearchEngineTime)
+	}
+}
+
+// search queries the engine and caches the result for maxAge and the stale period after it.
+func (uc *SearchHotelsUseCase) search(ctx context.Context, cacheKey string, params search.Params, maxAge time.Duration) (*search.Result, error) {
 	result, err := uc.searchEngine.Search(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("search engine error: %w", err)
 	}
 
-	result.ProcessingTime = time.Since(startTime)
 	result.Query = params.Query
 	result.Page = params.Page
 	result.Limit = params.Limit
 	result.CalculateTotalPages()
+	result.ApplySnippets(uc.snippetLength, params.IncludesField(search.FieldDescription))
+	// Custom sorts are explicit orders, so only relevance ranking is diversified.
+	if params.Diversify && params.SortBy == "relevance" {
+		result.Hotels = hotel.DiversifyResults(result.Hotels, params.MaxSameChain)
+	}
 
-	if resultData, err := json.Marshal(result); err == nil {
-		cacheTTL := time.Minute * 5
-		if err := uc.cache.Set(ctx, cacheKey, resultData, cacheTTL); err != nil {
+	entry := cachedSearchResult{GeneratedAt: uc.now().UTC(), Result: *result}
+	if data, err := json.Marshal(entry); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, data, maxAge+uc.staleWhileRevalidate); err != nil {
 			uc.logger.Warn("Failed to cache search result", "error", err)
 		}
 	}
@@ -69,6 +162,51 @@ func (uc *SearchHotelsUseCase) Execute(ctx context.Context, params search.Params
 	return result, nil
 }
 
+func (uc *SearchHotelsUseCase) getCached(ctx context.Context, cacheKey string) (*cachedSearchResult, bool) {
+	data, err := uc.cache.Get(ctx, cacheKey)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached cachedSearchResult
+	if err := json.Unmarshal(data, &cached); err != nil || cached.GeneratedAt.IsZero() {
+		return nil, false
+	}
+	return &cached, true
+}
+
+// refreshInBackground re-runs a search whose cached result went stale. Only one refresh per
+// cache key runs at a time in this instance, and none while the engine is shedding load.
+func (uc *SearchHotelsUseCase) refreshInBackground(cacheKey string, params search.Params, maxAge time.Duration) {
+	if _, running := uc.refreshing.LoadOrStore(cacheKey, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer uc.refreshing.Delete(cacheKey)
+
+		if uc.loadMonitor != nil {
+			if shed, _ := uc.loadMonitor.ShouldShed(); shed {
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), searchRefreshTimeout)
+		defer cancel()
+
+		if _, err := uc.search(ctx, cacheKey, params, maxAge); err != nil {
+			uc.logger.Warn("Failed to refresh stale search result", "cache_key", cacheKey, "error", err)
+		}
+	}()
+}
+
+func (uc *SearchHotelsUseCase) LoadStatus() search.LoadStatus {
+	if uc.loadMonitor == nil {
+		return search.LoadStatus{}
+	}
+	return uc.loadMonitor.LoadStatus()
+}
+
 func (uc *SearchHotelsUseCase) generateCacheKey(params search.Params) string {
 	data, _ := json.Marshal(params)
 	hash := sha256.Sum256(data)
@@ -93,8 +231,10 @@ func (uc *SearchHotelsUseCase) ExecuteWithFacets(ctx context.Context, params sea
 	return result, nil
 }
 
-func (uc *SearchHotelsUseCase) GetPopularSearches(ctx context.Context, limit int) ([]string, error) {
-	cacheKey := fmt.Sprintf("popular_searches:%d", limit)
+// GetPopularSearches returns the most searched queries of locale, falling back to the global
+// ones and then to a static list while too few searches are recorded.
+func (uc *SearchHotelsUseCase) GetPopularSearches(ctx context.Context, locale string, limit int) ([]string, error) {
+	cacheKey := fmt.Sprintf("popular_searches:%s:%d", locale, limit)
 
 	if cachedData, err := uc.cache.Get(ctx, cacheKey); err == nil {
 		var searches []string
@@ -103,15 +243,13 @@ func (uc *SearchHotelsUseCase) GetPopularSearches(ctx context.Context, limit int
 		}
 	}
 
-	popularSearches := []string{
-		"luxury hotels",
-		"beach resort",
-		"city center",
-		"business hotel",
-		"spa hotel",
-		"family hotel",
-		"boutique hotel",
-		"airport hotel",
+	var popularSearches []string
+	if queries, _ := uc.trending.Top(ctx, locale, max(limit, len(defaultPopularSearches))); queries != nil {
+		for _, query := range queries {
+			popularSearches = append(popularSearches, query.Query)
+		}
+	} else {
+		popularSearches = defaultPopularSearches
 	}
 
 	if limit > 0 && limit < len(popularSearches) {
@@ -119,8 +257,20 @@ func (uc *SearchHotelsUseCase) GetPopularSearches(ctx context.Context, limit int
 	}
 
 	if data, err := json.Marshal(popularSearches); err == nil {
-		_ = uc.cache.Set(ctx, cacheKey, data, time.Hour)
+		_ = uc.cache.Set(ctx, cacheKey, data, trendingCacheTTL)
 	}
 
 	return popularSearches, nil
 }
+
+// defaultPopularSearches are served until enough searches are recorded.
+var defaultPopularSearches = []string{
+	"luxury hotels",
+	"beach resort",
+	"city center",
+	"business hotel",
+	"spa hotel",
+	"family hotel",
+	"boutique hotel",
+	"airport hotel",
+}
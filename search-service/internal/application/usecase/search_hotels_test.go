@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newSearchHotelsTest(t *testing.T) (*SearchHotelsUseCase, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	engine := mocks.NewMockEngine(gomock.NewController(t))
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}).AnyTimes()
+	engine.EXPECT().Info().Return(search.EngineInfo{}).AnyTimes()
+	cache := newFakeCache()
+	uc := NewSearchHotelsUseCase(engine, cache, nil, nil, search.DefaultSnippetLength, time.Minute, time.Minute, nil, slog.New(slog.DiscardHandler))
+	return uc, engine, cache
+}
+
+func TestSearchCacheKeyIgnoresEquivalentDefaultSorts(t *testing.T) {
+	uc, engine, cache := newSearchHotelsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().Search(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, params search.Params) (*search.Result, error) {
+		assert.Equal(t, "rating", params.SortBy)
+		assert.Equal(t, "desc", params.SortOrder)
+		return &search.Result{TotalHits: 3}, nil
+	}).Times(1)
+
+	equivalent := []search.Params{
+		{},
+		{SortBy: "rating"},
+		{SortBy: "rating", SortOrder: "desc"},
+		{SortBy: "relevance", SortOrder: "desc"},
+	}
+	for _, params := range equivalent {
+		result, err := uc.Execute(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.TotalHits)
+	}
+	assert.Len(t, cache.values, 1)
+}
+
+func TestSearchCacheKeySeparatesDifferentSorts(t *testing.T) {
+	uc, engine, cache := newSearchHotelsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().Search(ctx, gomock.Any()).Return(&search.Result{}, nil).Times(3)
+
+	for _, params := range []search.Params{{}, {SortBy: "rating", SortOrder: "asc"}, {Query: "paris"}} {
+		_, err := uc.Execute(ctx, params)
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.values, 3)
+}
+
+func TestSearchProvenanceTracksCacheHitsAndAge(t *testing.T) {
+	uc, engine, _ := newSearchHotelsTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	engine.EXPECT().Search(ctx, gomock.Any()).Return(&search.Result{TotalHits: 3}, nil).Times(1)
+
+	result, err := uc.Execute(ctx, search.Params{Query: "paris"})
+	require.NoError(t, err)
+	assert.Equal(t, search.ServedFromEngine, result.Provenance.ServedFrom)
+	assert.Zero(t, result.Provenance.CacheAge)
+
+	now = now.Add(42 * time.Second)
+	result, err = uc.Execute(ctx, search.Params{Query: "paris"})
+	require.NoError(t, err)
+	assert.Equal(t, search.ServedFromCache, result.Provenance.ServedFrom)
+	assert.Equal(t, 42*time.Second, result.Provenance.CacheAge)
+	assert.Zero(t, result.SearchEngineTime, "the engine is not queried for a cached result")
+}
+
+func TestSearchProvenanceCountsStaleEntriesAsHits(t *testing.T) {
+	uc, engine, _ := newSearchHotelsTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	refreshed := make(chan struct{})
+	gomock.InOrder(
+		engine.EXPECT().Search(ctx, gomock.Any()).Return(&search.Result{}, nil),
+		engine.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, search.Params) (*search.Result, error) {
+			close(refreshed)
+			return &search.Result{}, nil
+		}),
+	)
+
+	_, err := uc.Execute(ctx, search.Params{})
+	require.NoError(t, err)
+
+	now = now.Add(90 * time.Second)
+	result, err := uc.Execute(ctx, search.Params{})
+	require.NoError(t, err)
+	assert.Equal(t, search.ServedFromCache, result.Provenance.ServedFrom)
+	assert.Equal(t, 90*time.Second, result.Provenance.CacheAge)
+	<-refreshed
+}
+
+func TestSearchProvenanceMissesExpiredEntries(t *testing.T) {
+	uc, engine, _ := newSearchHotelsTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	engine.EXPECT().Search(ctx, gomock.Any()).Return(&search.Result{}, nil).Times(2)
+
+	_, err := uc.Execute(ctx, search.Params{})
+	require.NoError(t, err)
+
+	now = now.Add(3 * time.Minute)
+	result, err := uc.Execute(ctx, search.Params{})
+	require.NoError(t, err)
+	assert.Equal(t, search.ServedFromEngine, result.Provenance.ServedFrom)
+	assert.Zero(t, result.Provenance.CacheAge)
+}
+
+func TestSearchDiversifiesRelevanceRankedPages(t *testing.T) {
+	chains := []string{"Marriott", "Marriott", "Marriott", "Hilton"}
+	tests := []struct {
+		name     string
+		params   search.Params
+		expected []int64
+	}{
+		{name: "relevance", params: search.Params{Query: "paris", Diversify: true, MaxSameChain: 1}, expected: []int64{1, 4, 2, 3}},
+		{name: "default run", params: search.Params{Query: "paris", Diversify: true}, expected: []int64{1, 2, 4, 3}},
+		{name: "not requested", params: search.Params{Query: "paris"}, expected: []int64{1, 2, 3, 4}},
+		{name: "custom sort", params: search.Params{Query: "paris", Diversify: true, MaxSameChain: 1, SortBy: "rating"}, expected: []int64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, engine, _ := newSearchHotelsTest(t)
+			engine.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, search.Params) (*search.Result, error) {
+				hotels := make([]*hotel.Hotel, len(chains))
+				for i, chain := range chains {
+					hotels[i] = &hotel.Hotel{HotelID: int64(i + 1), Chain: chain}
+				}
+				return &search.Result{TotalHits: int64(len(hotels)), Hotels: hotels}, nil
+			})
+
+			result, err := uc.Execute(context.Background(), tt.params)
+			require.NoError(t, err)
+
+			ids := make([]int64, len(result.Hotels))
+			for i, h := range result.Hotels {
+				ids[i] = h.HotelID
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
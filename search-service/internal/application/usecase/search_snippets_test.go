@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"go.uber.org/mock/gomock"
+)
+
+// longDescriptionHotels returns hotels with descriptions of several kilobytes, as Cupid serves them.
+func longDescriptionHotels(count int) []*hotel.Hotel {
+	paragraph := "Set in a restored 19th-century townhouse, the hotel offers spacious rooms with hand-painted tiles, a rooftop terrace overlooking the river and a breakfast of local pastries. "
+	hotels := make([]*hotel.Hotel, count)
+	for i := range hotels {
+		hotels[i] = &hotel.Hotel{
+			HotelID:             int64(i + 1),
+			Name:                fmt.Sprintf("Riverside Hotel %d", i+1),
+			Rating:              8.7,
+			Description:         "<p>" + strings.Repeat(paragraph, 40) + "</p>",
+			MarkdownDescription: strings.Repeat(paragraph, 40),
+			ImportantInfo:       strings.Repeat("Guests must present a photo ID and a credit card at check-in. ", 20),
+		}
+	}
+	return hotels
+}
+
+func TestSearchSnippetsShrinkResultPages(t *testing.T) {
+	uc, engine, _ := newSearchHotelsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().Search(ctx, gomock.Any()).DoAndReturn(func(context.Context, search.Params) (*search.Result, error) {
+		return &search.Result{Hotels: longDescriptionHotels(20), TotalHits: 20}, nil
+	}).Times(2)
+
+	full, err := uc.Execute(ctx, search.Params{Limit: 20, Fields: []string{search.FieldDescription}})
+	require.NoError(t, err)
+	snippeted, err := uc.Execute(ctx, search.Params{Limit: 20})
+	require.NoError(t, err)
+
+	fullJSON, err := json.Marshal(full)
+	require.NoError(t, err)
+	snippetedJSON, err := json.Marshal(snippeted)
+	require.NoError(t, err)
+
+	reduction := 1 - float64(len(snippetedJSON))/float64(len(fullJSON))
+	assert.Greater(t, reduction, 0.6, "a page of %d bytes shrank to %d bytes", len(fullJSON), len(snippetedJSON))
+
+	require.Len(t, snippeted.Hotels, 20)
+	for _, h := range snippeted.Hotels {
+		assert.NotEmpty(t, h.DescriptionSnippet)
+		assert.LessOrEqual(t, len([]rune(h.DescriptionSnippet)), search.DefaultSnippetLength+1)
+		assert.Empty(t, h.Description)
+	}
+	assert.NotEmpty(t, full.Hotels[0].Description, "fields=description keeps the full text")
+}
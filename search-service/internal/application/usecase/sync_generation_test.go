@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"go.uber.org/mock/gomock"
+)
+
+type syncOutcome struct {
+	result *SyncResult
+	err    error
+}
+
+func TestConcurrentSyncsSupersedeTheOlderOne(t *testing.T) {
+	history := &fakeSyncHistory{}
+	uc, repository, engine := newSyncHistoryTest(t, history)
+	ctx := context.Background()
+
+	hotels := []*hotel.Hotel{{HotelID: 1, Name: "Harbour Hotel"}, {HotelID: 2, Name: "Old Town Inn"}}
+	var mu sync.Mutex
+	fetches := 0
+	secondStarted := make(chan struct{})
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).DoAndReturn(func(context.Context, int, int, ...hotel.FindFilter) ([]*hotel.Hotel, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetches++
+		if fetches == 2 {
+			close(secondStarted)
+		}
+		return hotels, nil
+	}).Times(2)
+
+	// The first sync's first batch is held until the second sync has finished.
+	var indexed [][]int64
+	firstBatch := make(chan struct{})
+	releaseFirst := make(chan struct{})
+	engine.EXPECT().Index(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, batch []*hotel.Hotel) error {
+		mu.Lock()
+		calls := len(indexed)
+		ids := make([]int64, 0, len(batch))
+		for _, h := range batch {
+			ids = append(ids, h.HotelID)
+		}
+		indexed = append(indexed, ids)
+		mu.Unlock()
+		if calls == 0 {
+			close(firstBatch)
+			<-releaseFirst
+		}
+		return nil
+	}).AnyTimes()
+
+	options := SyncOptions{FullSync: true, BatchSize: 1}
+	first := make(chan syncOutcome, 1)
+	go func() {
+		result, err := uc.Execute(ctx, options)
+		first <- syncOutcome{result, err}
+	}()
+	<-firstBatch
+
+	second := make(chan syncOutcome, 1)
+	go func() {
+		result, err := uc.Execute(ctx, options)
+		second <- syncOutcome{result, err}
+	}()
+	<-secondStarted
+
+	var newer syncOutcome
+	select {
+	case newer = <-second:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the newer sync did not finish")
+	}
+	close(releaseFirst)
+	older := <-first
+
+	require.NoError(t, newer.err, "the newer sync completes")
+	assert.Equal(t, 2, newer.result.IndexedHotels)
+	assert.False(t, newer.result.Superseded)
+
+	assert.True(t, errors.Is(older.err, ErrSyncSuperseded), "the older sync stops: %v", older.err)
+	assert.True(t, older.result.Superseded)
+	assert.Equal(t, 1, older.result.IndexedHotels, "the older sync indexes no batch after the newer one started")
+
+	assert.Equal(t, [][]int64{{1}, {1}, {2}}, indexed)
+
+	require.Len(t, history.runs, 2)
+	assert.Equal(t, synchistory.StatusCompleted, history.runs[0].Status)
+	assert.Equal(t, synchistory.StatusSuperseded, history.runs[1].Status)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/leader"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const testHistoryRetention = 30 * 24 * time.Hour
+
+func newSyncHistoryTest(t *testing.T, history *fakeSyncHistory) (*SyncHotelsUseCase, *mocks.MockRepository, *mocks.MockEngine) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewSyncHotelsUseCase(repository, engine, cache, cache, nil, leader.Always{Replica: "replica-1"}, false, history, testHistoryRetention, slog.New(slog.DiscardHandler))
+	return uc, repository, engine
+}
+
+func TestSyncRecordsEveryFinishedSync(t *testing.T) {
+	history := &fakeSyncHistory{}
+	uc, repository, engine := newSyncHistoryTest(t, history)
+	ctx := context.Background()
+
+	hotels := []*hotel.Hotel{{HotelID: 1, Name: "Harbour Hotel"}, {HotelID: 2, Name: "Old Town Inn"}}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil)
+	_, err := uc.Execute(ctx, SyncOptions{FullSync: true, BatchSize: 10, Trigger: synchistory.TriggerInitial})
+	require.NoError(t, err)
+
+	databaseErr := errors.New(strings.Repeat("connection refused ", 40))
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(nil, databaseErr)
+	_, err = uc.Execute(ctx, SyncOptions{FullSync: true, BatchSize: 10})
+	require.Error(t, err)
+
+	require.Len(t, history.runs, 2)
+	completed := history.runs[0]
+	assert.Equal(t, synchistory.ModeFull, completed.Mode)
+	assert.Equal(t, synchistory.TriggerInitial, completed.Trigger)
+	assert.Equal(t, synchistory.StatusCompleted, completed.Status)
+	assert.Equal(t, 2, completed.TotalHotels)
+	assert.Equal(t, 2, completed.IndexedHotels)
+	assert.Zero(t, completed.ErrorCount)
+	assert.False(t, completed.FinishedAt.Before(completed.StartedAt))
+
+	failed := history.runs[1]
+	assert.Equal(t, synchistory.TriggerManual, failed.Trigger, "a sync without a trigger was started by hand")
+	assert.Equal(t, synchistory.StatusFailed, failed.Status)
+	assert.Equal(t, 1, failed.ErrorCount)
+	require.Len(t, failed.Errors, 1)
+	assert.LessOrEqual(t, len([]rune(failed.Errors[0])), synchistory.MaxErrorLength)
+	assert.Contains(t, failed.Errors[0], "connection refused")
+
+	last, err := history.Last(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, failed.ID, last.ID)
+}
+
+func TestSyncPrunesRunsPastTheRetention(t *testing.T) {
+	now := time.Now().UTC()
+	history := &fakeSyncHistory{runs: []synchistory.Run{
+		{ID: 1, Status: synchistory.StatusCompleted, StartedAt: now.Add(-testHistoryRetention - time.Hour)},
+		{ID: 2, Status: synchistory.StatusCompleted, StartedAt: now.Add(-testHistoryRetention + time.Hour)},
+	}}
+	uc, repository, engine := newSyncHistoryTest(t, history)
+
+	hotels := []*hotel.Hotel{{HotelID: 1, Name: "Harbour Hotel"}}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil)
+	_, err := uc.Execute(context.Background(), SyncOptions{FullSync: true, BatchSize: 10})
+	require.NoError(t, err)
+
+	require.Len(t, history.runs, 2)
+	assert.Equal(t, int64(2), history.runs[0].ID)
+	assert.Equal(t, synchistory.ModeFull, history.runs[1].Mode)
+}
+
+func TestGetSyncHistoryCapsTheWindowAtTheRetention(t *testing.T) {
+	now := time.Now().UTC()
+	history := &fakeSyncHistory{runs: []synchistory.Run{
+		{ID: 1, StartedAt: now.AddDate(0, 0, -20)},
+		{ID: 2, StartedAt: now.AddDate(0, 0, -5)},
+	}}
+	uc, _, _ := newSyncHistoryTest(t, history)
+	ctx := context.Background()
+
+	all, err := uc.GetSyncHistory(ctx, 365, 0, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-testHistoryRetention), all.Summary.Since, time.Minute)
+	require.Len(t, all.Runs, 2)
+	assert.Equal(t, int64(2), all.Runs[0].ID)
+
+	recent, err := uc.GetSyncHistory(ctx, 7, 1, 20)
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.AddDate(0, 0, -7), recent.Summary.Since, time.Minute)
+	require.Len(t, recent.Runs, 1)
+	assert.Equal(t, int64(2), recent.Runs[0].ID)
+}
@@ -2,65 +2,281 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/leader"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/pipeline"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
 )
 
+const (
+	syncProgressKeyPrefix = "sync:progress:"
+	syncProgressTTL       = 24 * time.Hour
+
+	syncStatsCountsKeyPrefix = "sync:stats:counts:"
+	syncStatsCountsTTL       = 30 * time.Second
+
+	syncGenerationKey = "sync:generation"
+
+	syncHistoryTimeout         = 5 * time.Second
+	defaultSyncHistoryPageSize = 20
+	maxSyncHistoryPageSize     = 100
+)
+
+var (
+	ErrSyncJobNotFound = errors.New("sync job not found")
+	// ErrSyncSuperseded is returned by a sync that stopped because a newer sync started. It is
+	// not a failure: the newer sync indexes the same hotels.
+	ErrSyncSuperseded = errors.New("sync superseded by a newer sync")
+	// ErrSyncLeadershipLost is returned by a scheduled sync on a replica that is not, or no
+	// longer, the sync leader. It wraps ErrSyncSuperseded: the leader runs the next sync.
+	ErrSyncLeadershipLost = fmt.Errorf("%w: sync leadership lost", ErrSyncSuperseded)
+)
+
+type SyncStatus string
+
+const (
+	SyncStatusRunning    SyncStatus = "running"
+	SyncStatusCompleted  SyncStatus = "completed"
+	SyncStatusFailed     SyncStatus = "failed"
+	SyncStatusSuperseded SyncStatus = "superseded"
+)
+
+// SyncHotelsUseCase indexes hotels from the database. Every sync takes a new generation from
+// a shared counter and stops before its next batch once a newer sync has started, so
+// overlapping syncs never interleave their batches.
 type SyncHotelsUseCase struct {
 	hotelRepo    hotel.Repository
 	searchEngine search.Engine
 	cache        hotel.CacheRepository
-	logger       *slog.Logger
+	generations  hotel.Counter
+	// pipelineStats is nil when no orchestrator is configured.
+	pipelineStats pipeline.StatsProvider
+	// leader decides which replica runs the scheduled syncs; syncs triggered through the
+	// admin API run on any replica.
+	leader leader.Elector
+	// deleteRemovedHotels makes incremental syncs delete hotels removed upstream from the index.
+	deleteRemovedHotels bool
+	// history records every finished sync and keeps the records for historyRetention.
+	history          synchistory.Repository
+	historyRetention time.Duration
+	logger           *slog.Logger
 }
 
 func NewSyncHotelsUseCase(
 	hotelRepo hotel.Repository,
 	searchEngine search.Engine,
 	cache hotel.CacheRepository,
+	generations hotel.Counter,
+	pipelineStats pipeline.StatsProvider,
+	leader leader.Elector,
+	deleteRemovedHotels bool,
+	history synchistory.Repository,
+	historyRetention time.Duration,
 	logger *slog.Logger,
 ) *SyncHotelsUseCase {
 	return &SyncHotelsUseCase{
-		hotelRepo:    hotelRepo,
-		searchEngine: searchEngine,
-		cache:        cache,
-		logger:       logger,
+		hotelRepo:           hotelRepo,
+		searchEngine:        searchEngine,
+		cache:               cache,
+		generations:         generations,
+		pipelineStats:       pipelineStats,
+		leader:              leader,
+		deleteRemovedHotels: deleteRemovedHotels,
+		history:             history,
+		historyRetention:    historyRetention,
+		logger:              logger,
 	}
 }
 
+// IsLeader reports whether this replica runs the scheduled syncs.
+func (uc *SyncHotelsUseCase) IsLeader() bool {
+	return uc.leader.IsLeader()
+}
+
+func (uc *SyncHotelsUseCase) LeaderStatus(ctx context.Context) leader.Status {
+	return uc.leader.Status(ctx)
+}
+
 type SyncOptions struct {
 	BatchSize        int
 	FullSync         bool
 	SinceTimestamp   time.Time
 	ClearIndexFirst  bool
 	UpdateCacheAfter bool
+	ChainFilter      string
+	// Trigger is what started the sync, one of the synchistory.Trigger values. Manual when empty.
+	Trigger string `json:"-"`
+}
+
+// scheduled reports whether the sync was started by the schedule rather than through the admin
+// API. Only the sync leader runs scheduled syncs.
+func (o SyncOptions) scheduled() bool {
+	return o.Trigger == synchistory.TriggerInitial || o.Trigger == synchistory.TriggerPeriodic
+}
+
+// SyncProgress tracks an asynchronous sync job. It is stored in the cache and updated after every batch.
+type SyncProgress struct {
+	JobID           string     `json:"job_id"`
+	Chain           string     `json:"chain,omitempty"`
+	Status          SyncStatus `json:"status"`
+	TotalHotels     int        `json:"total_hotels"`
+	ProcessedHotels int        `json:"processed_hotels"`
+	IndexedHotels   int        `json:"indexed_hotels"`
+	FailedHotels    int        `json:"failed_hotels"`
+	StartedAt       time.Time  `json:"started_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// TableCounts are the PostgreSQL row counts reported by the sync stats. They are cached for
+// syncStatsCountsTTL so frequent polling does not run COUNT(*) on every request.
+type TableCounts struct {
+	Hotels                   int64     `json:"hotels"`
+	Reviews                  int64     `json:"reviews"`
+	Translations             int64     `json:"translations"`
+	HotelsUpdatedSinceNewest int64     `json:"hotels_updated_since_newest"`
+	Estimated                bool      `json:"estimated"`
+	CountedAt                time.Time `json:"counted_at"`
+}
+
+type SyncStats struct {
+	Index    *search.IndexStats `json:"index"`
+	Database *TableCounts       `json:"database"`
+	// IndexLag is the number of active hotels in PostgreSQL minus the documents in the index.
+	IndexLag     int64      `json:"index_lag"`
+	LastSyncTime *time.Time `json:"last_sync_time,omitempty"`
+	// FetcherPipeline is omitted when the orchestrator is not configured or unreachable.
+	FetcherPipeline *pipeline.Stats `json:"fetcher_pipeline,omitempty"`
+	// LastRun is the most recent sync recorded in the sync history.
+	LastRun *synchistory.Run `json:"last_run,omitempty"`
+}
+
+// SyncHistory is one page of recorded syncs with the aggregates of every sync in its window.
+type SyncHistory struct {
+	Runs    []synchistory.Run   `json:"runs"`
+	Summary synchistory.Summary `json:"summary"`
 }
 
 type SyncResult struct {
-	TotalHotels       int
-	IndexedHotels     int
-	FailedHotels      int
-	TotalTranslations int
-	Duration          time.Duration
-	StartTime         time.Time
-	EndTime           time.Time
-	LastSyncTime      time.Time
-	Errors            []string
+	TotalHotels       int `json:"total_hotels"`
+	IndexedHotels     int `json:"indexed_hotels"`
+	FailedHotels      int `json:"failed_hotels"`
+	TotalTranslations int `json:"total_translations"`
+	// RemovedHotels counts the hotels removed upstream that were deleted from the index.
+	RemovedHotels int           `json:"removed_hotels"`
+	Duration      time.Duration `json:"duration"`
+	StartTime     time.Time     `json:"start_time"`
+	EndTime       time.Time     `json:"end_time"`
+	LastSyncTime  time.Time     `json:"last_sync_time"`
+	Errors        []string      `json:"errors"`
+	// Superseded is set when a newer sync started before this one finished.
+	Superseded bool `json:"superseded"`
+}
+
+// MarshalJSON writes Duration as a duration string and as duration_ms.
+func (r SyncResult) MarshalJSON() ([]byte, error) {
+	type syncResult SyncResult
+	return json.Marshal(struct {
+		syncResult
+		Duration   string `json:"duration"`
+		DurationMs int64  `json:"duration_ms"`
+	}{syncResult(r), r.Duration.String(), r.Duration.Milliseconds()})
 }
 
 func (uc *SyncHotelsUseCase) Execute(ctx context.Context, options SyncOptions) (*SyncResult, error) {
+	return uc.execute(ctx, options, nil)
+}
+
+// StartChainSync re-indexes every active hotel of the given chain in the background and returns
+// the job's initial progress.
+func (uc *SyncHotelsUseCase) StartChainSync(ctx context.Context, chain string, options SyncOptions) (*SyncProgress, error) {
+	options.ChainFilter = chain
+	options.ClearIndexFirst = false
+
+	now := time.Now().UTC()
+	progress := &SyncProgress{
+		JobID:     uuid.NewString(),
+		Chain:     chain,
+		Status:    SyncStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.saveProgress(ctx, progress); err != nil {
+		return nil, err
+	}
+
+	snapshot := *progress
+	go func() {
+		jobCtx := context.WithoutCancel(ctx)
+		if _, err := uc.execute(jobCtx, options, progress); err != nil {
+			if errors.Is(err, ErrSyncSuperseded) {
+				uc.logger.Info("Chain sync superseded by a newer sync", "job_id", progress.JobID, "chain", chain)
+				uc.finishProgress(jobCtx, progress, SyncStatusSuperseded, nil)
+				return
+			}
+			uc.logger.Error("Chain sync failed", "job_id", progress.JobID, "chain", chain, "error", err)
+			uc.finishProgress(jobCtx, progress, SyncStatusFailed, err)
+			return
+		}
+		uc.finishProgress(jobCtx, progress, SyncStatusCompleted, nil)
+	}()
+
+	return &snapshot, nil
+}
+
+func (uc *SyncHotelsUseCase) GetSyncProgress(ctx context.Context, jobID string) (*SyncProgress, error) {
+	data, err := uc.cache.Get(ctx, syncProgressKeyPrefix+jobID)
+	if err != nil {
+		return nil, ErrSyncJobNotFound
+	}
+
+	var progress SyncProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode sync progress: %w", err)
+	}
+
+	return &progress, nil
+}
+
+func (uc *SyncHotelsUseCase) execute(ctx context.Context, options SyncOptions, progress *SyncProgress) (result *SyncResult, err error) {
+	if options.scheduled() && !uc.leader.IsLeader() {
+		return nil, ErrSyncLeadershipLost
+	}
+
 	startTime := time.Now()
+	defer func() {
+		uc.recordRun(ctx, options, startTime, result, err)
+	}()
+
+	generation, err := uc.generations.Increment(ctx, syncGenerationKey)
+	if err != nil {
+		// Without the counter overlapping syncs cannot be detected, but the sync itself is
+		// still correct.
+		uc.logger.Warn("Failed to take a sync generation, running without supersede checks", "error", err)
+		generation = 0
+	}
 
 	uc.logger.Info("Starting hotel synchronization",
 		"full_sync", options.FullSync,
+		"chain", options.ChainFilter,
 		"batch_size", options.BatchSize,
-		"clear_index_first", options.ClearIndexFirst)
+		"clear_index_first", options.ClearIndexFirst,
+		"generation", generation)
 
-	result := &SyncResult{
-		StartTime: startTime,
+	result = &SyncResult{
+		StartTime: startTime.UTC(),
 		Errors:    make([]string, 0),
 	}
 
@@ -78,14 +294,17 @@ func (uc *SyncHotelsUseCase) Execute(ctx context.Context, options SyncOptions) (
 	}
 
 	var hotels []*hotel.Hotel
-	var err error
+	var since time.Time
 
-	if options.FullSync {
+	if options.ChainFilter != "" {
+		hotels, err = uc.getAllHotels(ctx, hotel.FindFilter{Chain: options.ChainFilter})
+	} else if options.FullSync {
 		hotels, err = uc.getAllHotels(ctx)
-	} else if !options.SinceTimestamp.IsZero() {
-		hotels, err = uc.hotelRepo.FindUpdatedAfter(ctx, options.SinceTimestamp)
 	} else {
-		since := time.Now().Add(-5 * time.Minute)
+		since = options.SinceTimestamp
+		if since.IsZero() {
+			since = time.Now().Add(-5 * time.Minute)
+		}
 		hotels, err = uc.hotelRepo.FindUpdatedAfter(ctx, since)
 	}
 
@@ -97,18 +316,44 @@ func (uc *SyncHotelsUseCase) Execute(ctx context.Context, options SyncOptions) (
 	result.TotalHotels = len(hotels)
 	uc.logger.Info("UpdateHotels fetched from database", "count", result.TotalHotels)
 
+	if progress != nil {
+		progress.TotalHotels = result.TotalHotels
+		progress.UpdatedAt = time.Now().UTC()
+		if err := uc.saveProgress(ctx, progress); err != nil {
+			uc.logger.Warn("Failed to save sync progress", "job_id", progress.JobID, "error", err)
+		}
+	}
+
 	if len(hotels) > 0 {
-		result.IndexedHotels, result.FailedHotels, result.TotalTranslations = uc.indexHotelsInBatches(ctx, hotels, options.BatchSize)
+		result.IndexedHotels, result.FailedHotels, result.TotalTranslations, err = uc.indexHotelsInBatches(ctx, hotels, options, generation, progress)
 	}
 
-	result.EndTime = time.Now()
-	result.Duration = result.EndTime.Sub(result.StartTime)
+	if !since.IsZero() && uc.deleteRemovedHotels && !errors.Is(err, ErrSyncSuperseded) {
+		result.RemovedHotels = uc.deleteRemovedFromIndex(ctx, since, result)
+	}
+
+	result.EndTime = time.Now().UTC()
+	result.Duration = time.Since(startTime)
 	result.LastSyncTime = result.EndTime
 
+	if errors.Is(err, ErrSyncSuperseded) {
+		result.Superseded = true
+		uc.logger.Info("Hotel synchronization superseded",
+			"reason", err,
+			"generation", generation,
+			"indexed_hotels", result.IndexedHotels,
+			"total_hotels", result.TotalHotels)
+		return result, err
+	}
+
 	if options.UpdateCacheAfter {
 		uc.updateLastSyncTime(ctx, result.LastSyncTime)
 	}
 
+	if options.FullSync && options.ChainFilter == "" {
+		uc.saveSuggestionVocabulary(ctx, hotels)
+	}
+
 	uc.logger.Info("Hotel synchronization completed",
 		"total_hotels", result.TotalHotels,
 		"indexed_hotels", result.IndexedHotels,
@@ -120,13 +365,63 @@ func (uc *SyncHotelsUseCase) Execute(ctx context.Context, options SyncOptions) (
 	return result, nil
 }
 
-func (uc *SyncHotelsUseCase) getAllHotels(ctx context.Context) ([]*hotel.Hotel, error) {
+// deleteRemovedFromIndex deletes the hotels marked removed upstream since the given time from
+// the index and returns how many it deleted. Failures are recorded in result.
+func (uc *SyncHotelsUseCase) deleteRemovedFromIndex(ctx context.Context, since time.Time, result *SyncResult) int {
+	hotelIDs, err := uc.hotelRepo.FindRemovedHotelIDsAfter(ctx, since)
+	if err != nil {
+		uc.logger.Error("Failed to find hotels removed upstream", "error", err)
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to find removed hotels: %v", err))
+		return 0
+	}
+
+	deleted := 0
+	for _, hotelID := range hotelIDs {
+		if err := uc.searchEngine.DeleteHotel(ctx, strconv.FormatInt(hotelID, 10)); err != nil {
+			uc.logger.Warn("Failed to delete removed hotel from index", "hotel_id", hotelID, "error", err)
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to delete removed hotel %d: %v", hotelID, err))
+			continue
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		uc.logger.Info("Deleted hotels removed upstream from the index", "count", deleted)
+	}
+	return deleted
+}
+
+// saveSuggestionVocabulary stores the most frequent words of the hotel names and cities, which
+// the suggestions use to correct misspelled queries. Only full syncs see every hotel, so only
+// they refresh it.
+func (uc *SyncHotelsUseCase) saveSuggestionVocabulary(ctx context.Context, hotels []*hotel.Hotel) {
+	builder := search.NewVocabularyBuilder()
+	for _, h := range hotels {
+		builder.Add(h.Name)
+		builder.Add(h.Address.City)
+	}
+	vocabulary := builder.Build(search.MaxVocabularyTokens)
+
+	data, err := json.Marshal(vocabulary)
+	if err != nil {
+		uc.logger.Warn("Failed to encode suggestion vocabulary", "error", err)
+		return
+	}
+
+	if err := uc.cache.Set(ctx, suggestionVocabularyKey, data, suggestionVocabularyTTL); err != nil {
+		uc.logger.Warn("Failed to save suggestion vocabulary", "error", err)
+		return
+	}
+
+	uc.logger.Info("Suggestion vocabulary saved", "tokens", len(vocabulary.Tokens))
+}
+
+func (uc *SyncHotelsUseCase) getAllHotels(ctx context.Context, filter ...hotel.FindFilter) ([]*hotel.Hotel, error) {
 	var allHotels []*hotel.Hotel
 	limit := 1000
 	offset := 0
 
 	for {
-		hotels, err := uc.hotelRepo.FindAll(ctx, limit, offset)
+		hotels, err := uc.hotelRepo.FindAll(ctx, limit, offset, filter...)
 		if err != nil {
 			return nil, err
 		}
@@ -148,8 +443,18 @@ func (uc *SyncHotelsUseCase) getAllHotels(ctx context.Context) ([]*hotel.Hotel,
 	return allHotels, nil
 }
 
-func (uc *SyncHotelsUseCase) indexHotelsInBatches(ctx context.Context, hotels []*hotel.Hotel, batchSize int) (indexed, failed, totalTranslations int) {
+func (uc *SyncHotelsUseCase) indexHotelsInBatches(ctx context.Context, hotels []*hotel.Hotel, options SyncOptions, generation int64, progress *SyncProgress) (indexed, failed, totalTranslations int, err error) {
+	batchSize := options.BatchSize
 	for i := 0; i < len(hotels); i += batchSize {
+		if err := uc.checkGeneration(ctx, generation); err != nil {
+			return indexed, failed, totalTranslations, err
+		}
+		// A leader that stalls past its lease loses the leadership to another replica, which
+		// then runs its own sync; this one stops rather than index alongside it.
+		if options.scheduled() && !uc.leader.IsLeader() {
+			return indexed, failed, totalTranslations, ErrSyncLeadershipLost
+		}
+
 		end := i + batchSize
 		if end > len(hotels) {
 			end = len(hotels)
@@ -177,10 +482,67 @@ func (uc *SyncHotelsUseCase) indexHotelsInBatches(ctx context.Context, hotels []
 			totalTranslations += batchTranslations
 		}
 
+		if progress != nil {
+			progress.ProcessedHotels = end
+			progress.IndexedHotels = indexed
+			progress.FailedHotels = failed
+			progress.UpdatedAt = time.Now().UTC()
+			if err := uc.saveProgress(ctx, progress); err != nil {
+				uc.logger.Warn("Failed to save sync progress", "job_id", progress.JobID, "error", err)
+			}
+		}
+
 		time.Sleep(100 * time.Millisecond)
 	}
 
-	return indexed, failed, totalTranslations
+	return indexed, failed, totalTranslations, nil
+}
+
+// checkGeneration returns ErrSyncSuperseded when a sync newer than generation has started.
+// A generation of zero, or a counter that cannot be read, is not checked.
+func (uc *SyncHotelsUseCase) checkGeneration(ctx context.Context, generation int64) error {
+	if generation == 0 {
+		return nil
+	}
+
+	data, err := uc.cache.Get(ctx, syncGenerationKey)
+	if err != nil {
+		return nil
+	}
+
+	current, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || current == generation {
+		return nil
+	}
+
+	return ErrSyncSuperseded
+}
+
+func (uc *SyncHotelsUseCase) finishProgress(ctx context.Context, progress *SyncProgress, status SyncStatus, cause error) {
+	now := time.Now().UTC()
+	progress.Status = status
+	progress.UpdatedAt = now
+	progress.FinishedAt = &now
+	if cause != nil {
+		progress.Error = cause.Error()
+	}
+
+	if err := uc.saveProgress(ctx, progress); err != nil {
+		uc.logger.Warn("Failed to save sync progress", "job_id", progress.JobID, "error", err)
+	}
+}
+
+func (uc *SyncHotelsUseCase) saveProgress(ctx context.Context, progress *SyncProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync progress: %w", err)
+	}
+
+	if err := uc.cache.Set(ctx, syncProgressKeyPrefix+progress.JobID, data, syncProgressTTL); err != nil {
+		return fmt.Errorf("failed to save sync progress: %w", err)
+	}
+
+	return nil
 }
 
 func (uc *SyncHotelsUseCase) GetLastSyncTime(ctx context.Context) (*time.Time, error) {
@@ -214,12 +576,185 @@ func (uc *SyncHotelsUseCase) updateLastSyncTime(ctx context.Context, syncTime ti
 	}
 }
 
-func (uc *SyncHotelsUseCase) GetSyncStats(ctx context.Context) (*search.IndexStats, error) {
-	stats, err := uc.searchEngine.GetIndexStats(ctx)
+// GetSyncStats combines the index statistics with PostgreSQL counts. With estimate set, table
+// sizes come from the planner statistics instead of COUNT(*).
+func (uc *SyncHotelsUseCase) GetSyncStats(ctx context.Context, estimate bool) (*SyncStats, error) {
+	indexStats, err := uc.searchEngine.GetIndexStats(ctx)
 	if err != nil {
 		uc.logger.Error("Failed to get index stats", "error", err)
 		return nil, fmt.Errorf("failed to get index stats: %w", err)
 	}
 
+	counts, err := uc.getTableCounts(ctx, estimate, indexStats.NewestDocumentAt)
+	if err != nil {
+		uc.logger.Error("Failed to get table counts", "error", err)
+		return nil, err
+	}
+
+	stats := &SyncStats{
+		Index:    indexStats,
+		Database: counts,
+		IndexLag: counts.Hotels - indexStats.TotalDocuments,
+	}
+
+	if lastSyncTime, _ := uc.GetLastSyncTime(ctx); lastSyncTime != nil {
+		stats.LastSyncTime = lastSyncTime
+		stats.Index.LastUpdated = *lastSyncTime
+	}
+
+	if lastRun, err := uc.history.Last(ctx); err != nil {
+		uc.logger.Warn("Failed to get last sync run", "error", err)
+	} else {
+		stats.LastRun = lastRun
+	}
+
+	if uc.pipelineStats != nil {
+		pipelineStats, err := uc.pipelineStats.PipelineStats(ctx)
+		if err != nil {
+			uc.logger.Warn("Failed to get fetcher pipeline stats", "error", err)
+		} else {
+			stats.FetcherPipeline = pipelineStats
+		}
+	}
+
 	return stats, nil
 }
+
+// GetSyncHistory returns one page of the syncs started in the last days, most recent first,
+// with their aggregates. days is capped at the history retention.
+func (uc *SyncHotelsUseCase) GetSyncHistory(ctx context.Context, days, page, limit int) (*SyncHistory, error) {
+	retentionDays := int(uc.historyRetention / (24 * time.Hour))
+	if days <= 0 || days > retentionDays {
+		days = retentionDays
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultSyncHistoryPageSize
+	}
+	limit = min(limit, maxSyncHistoryPageSize)
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	runs, err := uc.history.List(ctx, synchistory.ListOptions{
+		Since:  since,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := uc.history.Summarize(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncHistory{Runs: runs, Summary: summary}, nil
+}
+
+// recordRun stores the history record of a finished sync and prunes the records older than the
+// retention. Failures are logged, as recording the history must never fail the sync.
+func (uc *SyncHotelsUseCase) recordRun(ctx context.Context, options SyncOptions, startTime time.Time, result *SyncResult, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), syncHistoryTimeout)
+	defer cancel()
+
+	finishedAt := time.Now().UTC()
+	run := &synchistory.Run{
+		Mode:       synchistory.ModeIncremental,
+		Trigger:    options.Trigger,
+		Chain:      options.ChainFilter,
+		Status:     synchistory.StatusCompleted,
+		StartedAt:  startTime.UTC(),
+		FinishedAt: finishedAt,
+		DurationMs: finishedAt.Sub(startTime).Milliseconds(),
+	}
+	switch {
+	case options.ChainFilter != "":
+		run.Mode = synchistory.ModeChain
+	case options.FullSync:
+		run.Mode = synchistory.ModeFull
+	}
+	if run.Trigger == "" {
+		run.Trigger = synchistory.TriggerManual
+	}
+
+	var syncErrors []string
+	if result != nil {
+		run.TotalHotels = result.TotalHotels
+		run.IndexedHotels = result.IndexedHotels
+		run.FailedHotels = result.FailedHotels
+		run.RemovedHotels = result.RemovedHotels
+		syncErrors = result.Errors
+	}
+	switch {
+	case errors.Is(err, ErrSyncSuperseded):
+		run.Status = synchistory.StatusSuperseded
+	case err != nil:
+		run.Status = synchistory.StatusFailed
+		syncErrors = append(slices.Clip(syncErrors), err.Error())
+	}
+	run.ErrorCount = len(syncErrors)
+	for _, syncErr := range syncErrors[:min(len(syncErrors), synchistory.MaxErrors)] {
+		run.Errors = append(run.Errors, truncateRunes(syncErr, synchistory.MaxErrorLength))
+	}
+
+	if err := uc.history.Save(ctx, run); err != nil {
+		uc.logger.Warn("Failed to record sync run", "mode", run.Mode, "trigger", run.Trigger, "error", err)
+		return
+	}
+
+	if uc.historyRetention > 0 {
+		deleted, err := uc.history.DeleteBefore(ctx, finishedAt.Add(-uc.historyRetention))
+		if err != nil {
+			uc.logger.Warn("Failed to prune sync history", "error", err)
+		} else if deleted > 0 {
+			uc.logger.Debug("Pruned sync history", "deleted", deleted)
+		}
+	}
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes])
+}
+
+func (uc *SyncHotelsUseCase) getTableCounts(ctx context.Context, estimate bool, newest *time.Time) (*TableCounts, error) {
+	cacheKey := fmt.Sprintf("%s%t", syncStatsCountsKeyPrefix, estimate)
+
+	if data, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var counts TableCounts
+		if err := json.Unmarshal(data, &counts); err == nil {
+			return &counts, nil
+		}
+	}
+
+	counts := &TableCounts{Estimated: estimate, CountedAt: time.Now().UTC()}
+
+	var err error
+	if counts.Hotels, err = uc.hotelRepo.CountHotels(ctx, estimate); err != nil {
+		return nil, fmt.Errorf("failed to count hotels: %w", err)
+	}
+	if counts.Reviews, err = uc.hotelRepo.CountReviews(ctx, estimate); err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	if counts.Translations, err = uc.hotelRepo.CountTranslations(ctx, estimate); err != nil {
+		return nil, fmt.Errorf("failed to count translations: %w", err)
+	}
+	if newest != nil {
+		if counts.HotelsUpdatedSinceNewest, err = uc.hotelRepo.CountHotelsUpdatedAfter(ctx, *newest); err != nil {
+			return nil, fmt.Errorf("failed to count updated hotels: %w", err)
+		}
+	}
+
+	if data, err := json.Marshal(counts); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, data, syncStatsCountsTTL); err != nil {
+			uc.logger.Warn("Failed to cache table counts", "error", err)
+		}
+	}
+
+	return counts, nil
+}
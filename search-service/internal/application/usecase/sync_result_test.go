@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncResultSerializesUTCTimesAndDurations(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC-5", -5*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewSyncHotelsUseCase(repository, engine, cache, cache, nil, nil, false, &fakeSyncHistory{}, 0, slog.New(slog.DiscardHandler))
+
+	hotels := []*hotel.Hotel{{HotelID: 1, Name: "Harbour Hotel"}}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil)
+
+	result, err := uc.Execute(context.Background(), SyncOptions{FullSync: true, BatchSize: 10})
+	require.NoError(t, err)
+	result.Duration = 1500 * time.Millisecond
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(data, &response))
+
+	for _, key := range []string{"start_time", "end_time", "last_sync_time"} {
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`, response[key], key)
+	}
+	assert.Equal(t, "1.5s", response["duration"])
+	assert.EqualValues(t, 1500, response["duration_ms"])
+	assert.NotContains(t, response, "Duration")
+	assert.Equal(t, float64(1), response["indexed_hotels"])
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/pipeline"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newSyncStatsTest(t *testing.T) (*SyncHotelsUseCase, *mocks.MockRepository, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	uc := NewSyncHotelsUseCase(repository, engine, cache, nil, nil, nil, false, &fakeSyncHistory{}, 0, slog.New(slog.DiscardHandler))
+	return uc, repository, engine, cache
+}
+
+// fakePipelineStats stands in for the orchestrator client.
+type fakePipelineStats struct {
+	stats *pipeline.Stats
+	err   error
+}
+
+func (f fakePipelineStats) PipelineStats(context.Context) (*pipeline.Stats, error) {
+	return f.stats, f.err
+}
+
+func expectTableCounts(repository *mocks.MockRepository, estimate bool, hotels, reviews, translations int64) {
+	repository.EXPECT().CountHotels(gomock.Any(), estimate).Return(hotels, nil)
+	repository.EXPECT().CountReviews(gomock.Any(), estimate).Return(reviews, nil)
+	repository.EXPECT().CountTranslations(gomock.Any(), estimate).Return(translations, nil)
+}
+
+func TestGetSyncStatsComputesIndexLag(t *testing.T) {
+	uc, repository, engine, _ := newSyncStatsTest(t)
+	ctx := context.Background()
+	newest := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{TotalDocuments: 90_000, NewestDocumentAt: &newest}, nil)
+	expectTableCounts(repository, false, 120_000, 2_500_000, 400_000)
+	repository.EXPECT().CountHotelsUpdatedAfter(gomock.Any(), newest).Return(int64(1_200), nil)
+
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(30_000), stats.IndexLag)
+	assert.Equal(t, int64(120_000), stats.Database.Hotels)
+	assert.Equal(t, int64(2_500_000), stats.Database.Reviews)
+	assert.Equal(t, int64(400_000), stats.Database.Translations)
+	assert.Equal(t, int64(1_200), stats.Database.HotelsUpdatedSinceNewest)
+	assert.False(t, stats.Database.Estimated)
+	assert.Equal(t, &newest, stats.Index.NewestDocumentAt)
+	assert.Nil(t, stats.LastSyncTime)
+}
+
+func TestGetSyncStatsReportsIndexAhead(t *testing.T) {
+	uc, repository, engine, _ := newSyncStatsTest(t)
+	ctx := context.Background()
+
+	// An empty index has no newest document, so updated hotels are not counted.
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{TotalDocuments: 105}, nil)
+	expectTableCounts(repository, false, 100, 0, 0)
+
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5), stats.IndexLag)
+	assert.Zero(t, stats.Database.HotelsUpdatedSinceNewest)
+}
+
+func TestGetSyncStatsCachesTableCounts(t *testing.T) {
+	uc, repository, engine, cache := newSyncStatsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{TotalDocuments: 10}, nil).Times(3)
+	expectTableCounts(repository, false, 12, 30, 4)
+
+	first, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	second, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Database.Hotels, second.Database.Hotels)
+	assert.Equal(t, int64(2), second.IndexLag)
+	assert.Contains(t, cache.values, syncStatsCountsKeyPrefix+"false")
+
+	// Estimated counts are cached apart from exact ones.
+	expectTableCounts(repository, true, 11, 29, 4)
+	estimated, err := uc.GetSyncStats(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, estimated.Database.Estimated)
+	assert.Equal(t, int64(1), estimated.IndexLag)
+}
+
+func TestGetSyncStatsCountsAgainAfterExpiry(t *testing.T) {
+	uc, repository, engine, cache := newSyncStatsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{TotalDocuments: 10}, nil).Times(2)
+	expectTableCounts(repository, false, 10, 0, 0)
+	_, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Delete(ctx, syncStatsCountsKeyPrefix+"false"))
+	expectTableCounts(repository, false, 15, 0, 0)
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.IndexLag)
+}
+
+func TestGetSyncStatsIncludesLastSyncTime(t *testing.T) {
+	uc, repository, engine, _ := newSyncStatsTest(t)
+	ctx := context.Background()
+	lastSync := time.Date(2026, 10, 2, 8, 30, 0, 0, time.UTC)
+	uc.updateLastSyncTime(ctx, lastSync)
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{}, nil)
+	expectTableCounts(repository, false, 0, 0, 0)
+
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	require.NotNil(t, stats.LastSyncTime)
+	assert.True(t, lastSync.Equal(*stats.LastSyncTime))
+	assert.True(t, lastSync.Equal(stats.Index.LastUpdated))
+}
+
+func TestGetSyncStatsFailsWhenCountingFails(t *testing.T) {
+	uc, repository, engine, cache := newSyncStatsTest(t)
+	ctx := context.Background()
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{}, nil)
+	repository.EXPECT().CountHotels(gomock.Any(), false).Return(int64(0), errors.New("connection refused"))
+
+	_, err := uc.GetSyncStats(ctx, false)
+	assert.ErrorContains(t, err, "failed to count hotels")
+	assert.NotContains(t, cache.values, syncStatsCountsKeyPrefix+"false")
+}
+
+func TestGetSyncStatsEmbedsFetcherPipeline(t *testing.T) {
+	uc, repository, engine, _ := newSyncStatsTest(t)
+	ctx := context.Background()
+	delayDepth, dlqDepth := int64(35), int64(7)
+	lastEnqueueAt := time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)
+	uc.pipelineStats = fakePipelineStats{stats: &pipeline.Stats{
+		MainDepth:     4_200,
+		DelayDepth:    &delayDepth,
+		DLQDepth:      &dlqDepth,
+		LastEnqueueAt: &lastEnqueueAt,
+	}}
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{}, nil)
+	expectTableCounts(repository, false, 0, 0, 0)
+
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	require.NotNil(t, stats.FetcherPipeline)
+	assert.Equal(t, int64(4_200), stats.FetcherPipeline.MainDepth)
+	assert.Equal(t, &delayDepth, stats.FetcherPipeline.DelayDepth)
+	assert.Equal(t, &dlqDepth, stats.FetcherPipeline.DLQDepth)
+	assert.Equal(t, &lastEnqueueAt, stats.FetcherPipeline.LastEnqueueAt)
+}
+
+func TestGetSyncStatsOmitsFetcherPipelineWhenOrchestratorIsUnreachable(t *testing.T) {
+	uc, repository, engine, _ := newSyncStatsTest(t)
+	ctx := context.Background()
+	uc.pipelineStats = fakePipelineStats{err: errors.New("connection refused")}
+
+	engine.EXPECT().GetIndexStats(ctx).Return(&search.IndexStats{TotalDocuments: 3}, nil)
+	expectTableCounts(repository, false, 5, 0, 0)
+
+	stats, err := uc.GetSyncStats(ctx, false)
+	require.NoError(t, err)
+	assert.Nil(t, stats.FetcherPipeline)
+	assert.Equal(t, int64(2), stats.IndexLag)
+
+	body, err := json.Marshal(stats)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "fetcher_pipeline")
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	queryRecordTimeout = time.Second
+	// trendingCacheTTL is how long a trending list is served before the counters are read again.
+	trendingCacheTTL = 5 * time.Minute
+)
+
+// TrendingQueries ranks the searches recorded per locale. A locale with fewer than minSamples
+// searches falls back to the global bucket, and callers fall back to their static lists when
+// the global bucket is short of data too. A nil TrendingQueries records nothing and always
+// falls back.
+type TrendingQueries struct {
+	log        search.QueryLog
+	minSamples int64
+	logger     *slog.Logger
+}
+
+func NewTrendingQueries(log search.QueryLog, minSamples int64, logger *slog.Logger) *TrendingQueries {
+	return &TrendingQueries{log: log, minSamples: minSamples, logger: logger}
+}
+
+// Record counts a search in the background, so a slow Redis does not delay the search.
+func (t *TrendingQueries) Record(ctx context.Context, locale, query string) {
+	if t == nil {
+		return
+	}
+	if query = search.NormalizeTrendingQuery(query); query == "" {
+		return
+	}
+	if locale == "" {
+		locale = search.GlobalLocale
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryRecordTimeout)
+		defer cancel()
+		if err := t.log.Record(ctx, locale, query); err != nil {
+			t.logger.Warn("Failed to record search query", "locale", locale, "error", err)
+		}
+	}()
+}
+
+// Top returns the most searched queries of locale and the bucket they were read from, or nil
+// when neither the locale nor the global bucket has minSamples searches.
+func (t *TrendingQueries) Top(ctx context.Context, locale string, limit int) ([]search.QueryCount, string) {
+	if t == nil {
+		return nil, ""
+	}
+
+	buckets := []string{search.GlobalLocale}
+	if locale != "" && locale != search.GlobalLocale {
+		buckets = []string{locale, search.GlobalLocale}
+	}
+
+	for _, bucket := range buckets {
+		queries, samples, err := t.log.Top(ctx, bucket, limit)
+		if err != nil {
+			t.logger.Warn("Failed to read trending queries", "locale", bucket, "error", err)
+			return nil, ""
+		}
+		if samples >= t.minSamples && len(queries) > 0 {
+			return queries, bucket
+		}
+		t.logger.Debug("Not enough searches for trending queries", "locale", bucket, "samples", samples, "min_samples", t.minSamples)
+	}
+	return nil, ""
+}
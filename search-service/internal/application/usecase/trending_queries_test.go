@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+// fakeQueryLog is an in-memory search.QueryLog.
+type fakeQueryLog struct {
+	mu      sync.Mutex
+	counts  map[string]map[string]int64
+	samples map[string]int64
+}
+
+func newFakeQueryLog() *fakeQueryLog {
+	return &fakeQueryLog{counts: map[string]map[string]int64{}, samples: map[string]int64{}}
+}
+
+func (l *fakeQueryLog) Record(_ context.Context, locale, query string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, bucket := range []string{search.GlobalLocale, locale} {
+		if l.counts[bucket] == nil {
+			l.counts[bucket] = map[string]int64{}
+		}
+		l.counts[bucket][query]++
+		l.samples[bucket]++
+		if locale == search.GlobalLocale {
+			break
+		}
+	}
+	return nil
+}
+
+func (l *fakeQueryLog) Top(_ context.Context, locale string, limit int) ([]search.QueryCount, int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var counts []search.QueryCount
+	for query, count := range l.counts[locale] {
+		counts = append(counts, search.QueryCount{Query: query, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Query < counts[j].Query
+	})
+	return counts[:min(limit, len(counts))], l.samples[locale], nil
+}
+
+// searchTimes records query as searched times in locale.
+func searchTimes(t *testing.T, log *fakeQueryLog, locale, query string, times int) {
+	t.Helper()
+	for range times {
+		require.NoError(t, log.Record(context.Background(), locale, query))
+	}
+}
+
+func newTrendingTest(t *testing.T, minSamples int64) (*GetHotelSuggestionsUseCase, *SearchHotelsUseCase, *fakeQueryLog) {
+	t.Helper()
+	log := newFakeQueryLog()
+	trending := NewTrendingQueries(log, minSamples, slog.New(slog.DiscardHandler))
+	logger := slog.New(slog.DiscardHandler)
+	suggestions := NewGetHotelSuggestionsUseCase(nil, nil, newFakeCache(), trending, logger)
+	searches := NewSearchHotelsUseCase(nil, newFakeCache(), nil, trending, search.DefaultSnippetLength, time.Minute, time.Minute, nil, logger)
+	return suggestions, searches, log
+}
+
+func suggestionTexts(suggestions []*search.Suggestion) []string {
+	texts := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		texts = append(texts, suggestion.Text)
+	}
+	return texts
+}
+
+func TestTrendingSuggestionsFollowTheLocale(t *testing.T) {
+	suggestions, searches, log := newTrendingTest(t, 5)
+	ctx := context.Background()
+
+	searchTimes(t, log, "es", "hoteles en madrid", 4)
+	searchTimes(t, log, "es", "playa", 2)
+	searchTimes(t, log, "en", "london hotels", 3)
+	searchTimes(t, log, "en", "spa", 3)
+
+	spanish, err := suggestions.GetTrendingSuggestions(ctx, "es", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hoteles en madrid", "playa"}, suggestionTexts(spanish))
+	assert.Equal(t, 1.0, spanish[0].Score)
+	assert.Equal(t, 0.5, spanish[1].Score)
+	assert.Equal(t, "es", spanish[0].Metadata["locale"])
+
+	english, err := searches.GetPopularSearches(ctx, "en", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"london hotels", "spa"}, english)
+}
+
+func TestTrendingSuggestionsFallBackToTheGlobalSearches(t *testing.T) {
+	suggestions, searches, log := newTrendingTest(t, 5)
+	ctx := context.Background()
+
+	searchTimes(t, log, "es", "hoteles en madrid", 5)
+	searchTimes(t, log, "fr", "hôtel paris", 2)
+
+	french, err := suggestions.GetTrendingSuggestions(ctx, "fr", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hoteles en madrid", "hôtel paris"}, suggestionTexts(french), "two French searches are not enough for a list of their own")
+	assert.Equal(t, search.GlobalLocale, french[0].Metadata["locale"])
+
+	popular, err := searches.GetPopularSearches(ctx, "fr", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hoteles en madrid"}, popular)
+}
+
+func TestTrendingSuggestionsFallBackToTheStaticLists(t *testing.T) {
+	suggestions, searches, log := newTrendingTest(t, 5)
+	ctx := context.Background()
+
+	trending, err := suggestions.GetTrendingSuggestions(ctx, "es", 3)
+	require.NoError(t, err)
+	assert.Equal(t, defaultTrendingSuggestions[:3], trending)
+
+	// Searches below the minimum sample do not replace the static lists either.
+	searchTimes(t, log, "es", "playa", 4)
+	popular, err := searches.GetPopularSearches(ctx, "de", 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultPopularSearches, popular)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+type UpdateHotelResult struct {
+	Hotel         *hotel.Hotel `json:"hotel"`
+	ChangedFields []string     `json:"changed_fields"`
+}
+
+type UpdateHotelUseCase struct {
+	hotelRepo    hotel.Repository
+	searchEngine search.Engine
+	cache        hotel.CacheRepository
+	logger       *slog.Logger
+}
+
+func NewUpdateHotelUseCase(
+	hotelRepo hotel.Repository,
+	searchEngine search.Engine,
+	cache hotel.CacheRepository,
+	logger *slog.Logger,
+) *UpdateHotelUseCase {
+	return &UpdateHotelUseCase{
+		hotelRepo:    hotelRepo,
+		searchEngine: searchEngine,
+		cache:        cache,
+		logger:       logger,
+	}
+}
+
+// Patch applies a manual correction to a hotel, then refreshes the cached copies and the
+// search document. A patch that changes nothing is not written.
+func (uc *UpdateHotelUseCase) Patch(ctx context.Context, hotelID int64, patch *hotel.Patch, actor string) (*UpdateHotelResult, error) {
+	existing, err := uc.hotelRepo.FindByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotel: %w", err)
+	}
+	if existing == nil {
+		return nil, ErrHotelNotFound
+	}
+
+	before := *existing
+	changed := patch.Apply(existing)
+	result := &UpdateHotelResult{Hotel: existing, ChangedFields: changed}
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	// The correction and its audit entry are stored together; the entry is listed with the
+	// other changes of the hotel.
+	change := hotel.Change{
+		HotelID:   hotelID,
+		Changes:   hotel.PatchChanges(&before, existing, changed),
+		Actor:     actor,
+		ChangedAt: time.Now().UTC(),
+	}
+	if err := uc.hotelRepo.UpdateWithChange(ctx, existing, change); err != nil {
+		return nil, fmt.Errorf("failed to update hotel: %w", err)
+	}
+
+	uc.logger.Info("Hotel patched",
+		"audit", true,
+		"hotel_id", hotelID,
+		"changed_fields", changed,
+		"actor", actor)
+
+	uc.invalidateCache(ctx, existing)
+
+	if err := uc.searchEngine.UpdateHotel(ctx, existing); err != nil {
+		uc.logger.Error("Failed to update patched hotel in search engine", "hotel_id", hotelID, "error", err)
+	}
+
+	return result, nil
+}
+
+func (uc *UpdateHotelUseCase) invalidateCache(ctx context.Context, h *hotel.Hotel) {
+	keys := []string{fmt.Sprintf("hotel:%d", h.HotelID)}
+	for _, translation := range h.Translations {
+		keys = append(keys, fmt.Sprintf("hotel_translation:%d:%s", h.HotelID, translation.Lang))
+	}
+
+	for _, key := range keys {
+		if err := uc.cache.Delete(ctx, key); err != nil {
+			uc.logger.Warn("Failed to invalidate hotel cache", "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newUpdateHotelTest(t *testing.T) (*UpdateHotelUseCase, *mocks.MockRepository, *mocks.MockEngine, *fakeCache) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := newFakeCache()
+	return NewUpdateHotelUseCase(repo, engine, cache, slog.New(slog.DiscardHandler)), repo, engine, cache
+}
+
+func patchTestHotel() *hotel.Hotel {
+	return &hotel.Hotel{
+		HotelID:      7,
+		Name:         "Harbour Hotel",
+		Rating:       4.5,
+		Parking:      "Free",
+		Translations: []hotel.Translation{{Lang: "es"}, {Lang: "fr"}},
+	}
+}
+
+func TestPatchRecordsTheCorrectionAndInvalidatesTheCache(t *testing.T) {
+	uc, repo, engine, cache := newUpdateHotelTest(t)
+	ctx := context.Background()
+	for _, key := range []string{"hotel:7", "hotel_translation:7:es", "hotel_translation:7:fr", "hotel:8"} {
+		require.NoError(t, cache.Set(ctx, key, []byte("cached"), time.Hour))
+	}
+
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(patchTestHotel(), nil)
+	var recorded hotel.Change
+	repo.EXPECT().UpdateWithChange(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, h *hotel.Hotel, change hotel.Change) error {
+		assert.Equal(t, "Harbour Hotel & Spa", h.Name)
+		recorded = change
+		return nil
+	})
+	engine.EXPECT().UpdateHotel(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, h *hotel.Hotel) error {
+		assert.Equal(t, 3.9, h.Rating, "the search document gets the correction")
+		return nil
+	})
+
+	name, rating, parking := "Harbour Hotel & Spa", 3.9, "Free"
+	result, err := uc.Patch(ctx, 7, &hotel.Patch{Name: &name, Rating: &rating, Parking: &parking}, "203.0.113.9:51234")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"name", "rating"}, result.ChangedFields, "an unchanged value is not reported")
+	assert.Equal(t, int64(7), recorded.HotelID)
+	assert.Equal(t, "203.0.113.9:51234", recorded.Actor)
+	assert.Equal(t, map[string]hotel.FieldChange{
+		"name":   {Old: "Harbour Hotel", New: "Harbour Hotel & Spa"},
+		"rating": {Old: 4.5, New: 3.9},
+	}, recorded.Changes)
+	assert.False(t, recorded.ChangedAt.IsZero())
+
+	for _, key := range []string{"hotel:7", "hotel_translation:7:es", "hotel_translation:7:fr"} {
+		cached, _ := cache.Exists(ctx, key)
+		assert.False(t, cached, key)
+	}
+	cached, _ := cache.Exists(ctx, "hotel:8")
+	assert.True(t, cached, "other hotels stay cached")
+}
+
+func TestPatchWithoutChangesWritesNothing(t *testing.T) {
+	uc, repo, _, cache := newUpdateHotelTest(t)
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, "hotel:7", []byte("cached"), time.Hour))
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(patchTestHotel(), nil)
+
+	name := "Harbour Hotel"
+	result, err := uc.Patch(ctx, 7, &hotel.Patch{Name: &name}, "203.0.113.9:51234")
+	require.NoError(t, err)
+
+	assert.Empty(t, result.ChangedFields)
+	cached, _ := cache.Exists(ctx, "hotel:7")
+	assert.True(t, cached)
+}
+
+func TestPatchErrors(t *testing.T) {
+	name := "Harbour Hotel & Spa"
+
+	t.Run("hotel not found", func(t *testing.T) {
+		uc, repo, _, _ := newUpdateHotelTest(t)
+		repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(nil, nil)
+
+		_, err := uc.Patch(context.Background(), 7, &hotel.Patch{Name: &name}, "203.0.113.9:51234")
+		assert.ErrorIs(t, err, ErrHotelNotFound)
+	})
+
+	t.Run("write fails", func(t *testing.T) {
+		uc, repo, _, cache := newUpdateHotelTest(t)
+		ctx := context.Background()
+		require.NoError(t, cache.Set(ctx, "hotel:7", []byte("cached"), time.Hour))
+		repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(patchTestHotel(), nil)
+		repo.EXPECT().UpdateWithChange(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+		_, err := uc.Patch(ctx, 7, &hotel.Patch{Name: &name}, "203.0.113.9:51234")
+		assert.ErrorContains(t, err, "connection reset")
+		cached, _ := cache.Exists(ctx, "hotel:7")
+		assert.True(t, cached, "nothing is invalidated when the correction is not stored")
+	})
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+// maxUsageReportDays bounds the range a single usage report can cover.
+const maxUsageReportDays = 366
+
+var ErrInvalidUsageRange = errors.New("invalid usage range")
+
+type UsageReportEntry struct {
+	Day       string  `json:"day"`
+	Endpoint  string  `json:"endpoint"`
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	Results   int64   `json:"results"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+type UsageTotals struct {
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	Results   int64   `json:"results"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+type UsageReport struct {
+	ClientID string             `json:"client_id"`
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Totals   UsageTotals        `json:"totals"`
+	Series   []UsageReportEntry `json:"series"`
+}
+
+type UsageReportUseCase struct {
+	counter usage.Counter
+	repo    usage.Repository
+	logger  *slog.Logger
+}
+
+func NewUsageReportUseCase(counter usage.Counter, repo usage.Repository, logger *slog.Logger) *UsageReportUseCase {
+	return &UsageReportUseCase{
+		counter: counter,
+		repo:    repo,
+		logger:  logger,
+	}
+}
+
+// Rollup copies the Redis counters of day into PostgreSQL. It can be re-run for the same day.
+func (uc *UsageReportUseCase) Rollup(ctx context.Context, day time.Time) (int, error) {
+	day = usage.Day(day)
+
+	usages, err := uc.counter.Collect(ctx, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect usage counters: %w", err)
+	}
+
+	if err := uc.repo.UpsertDailyUsage(ctx, usages); err != nil {
+		return 0, err
+	}
+
+	uc.logger.Info("Usage rolled up", "day", day.Format(usage.DayLayout), "rows", len(usages))
+	return len(usages), nil
+}
+
+// Report returns the rolled up usage of the client identified by apiKey between from and to,
+// both inclusive. An empty key or "anonymous" reports unauthenticated traffic.
+func (uc *UsageReportUseCase) Report(ctx context.Context, apiKey string, from, to time.Time) (*UsageReport, error) {
+	from, to = usage.Day(from), usage.Day(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from is after to", ErrInvalidUsageRange)
+	}
+	if to.Sub(from) > maxUsageReportDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: at most %d days", ErrInvalidUsageRange, maxUsageReportDays)
+	}
+
+	clientID := usage.AnonymousClient
+	if apiKey != usage.AnonymousClient {
+		clientID = usage.ClientID(apiKey)
+	}
+
+	usages, err := uc.repo.FindDailyUsage(ctx, clientID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{
+		ClientID: clientID,
+		From:     from.Format(usage.DayLayout),
+		To:       to.Format(usage.DayLayout),
+		Series:   make([]UsageReportEntry, len(usages)),
+	}
+
+	var totals usage.DailyUsage
+	for i, u := range usages {
+		report.Series[i] = UsageReportEntry{
+			Day:       u.Day.Format(usage.DayLayout),
+			Endpoint:  u.Endpoint,
+			Requests:  u.Requests,
+			Errors:    u.Errors,
+			Results:   u.Results,
+			ErrorRate: u.ErrorRate(),
+		}
+		totals.Requests += u.Requests
+		totals.Errors += u.Errors
+		totals.Results += u.Results
+	}
+
+	report.Totals = UsageTotals{
+		Requests:  totals.Requests,
+		Errors:    totals.Errors,
+		Results:   totals.Results,
+		ErrorRate: totals.ErrorRate(),
+	}
+
+	return report, nil
+}
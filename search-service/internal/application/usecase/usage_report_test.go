@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+// fakeUsageCounter returns fixed counters per day.
+type fakeUsageCounter struct {
+	days map[time.Time][]usage.DailyUsage
+}
+
+func (c *fakeUsageCounter) Record(usage.Event) {}
+
+func (c *fakeUsageCounter) Collect(_ context.Context, day time.Time) ([]usage.DailyUsage, error) {
+	return c.days[day], nil
+}
+
+// fakeUsageRepository keeps the highest value of each counter, like the PostgreSQL upsert.
+type fakeUsageRepository struct {
+	rows map[[3]string]usage.DailyUsage
+}
+
+func newFakeUsageRepository() *fakeUsageRepository {
+	return &fakeUsageRepository{rows: make(map[[3]string]usage.DailyUsage)}
+}
+
+func (r *fakeUsageRepository) UpsertDailyUsage(_ context.Context, usages []usage.DailyUsage) error {
+	for _, u := range usages {
+		key := [3]string{u.ClientID, u.Endpoint, u.Day.Format(usage.DayLayout)}
+		stored := r.rows[key]
+		u.Requests = max(u.Requests, stored.Requests)
+		u.Errors = max(u.Errors, stored.Errors)
+		u.Results = max(u.Results, stored.Results)
+		r.rows[key] = u
+	}
+	return nil
+}
+
+func (r *fakeUsageRepository) FindDailyUsage(_ context.Context, clientID string, from, to time.Time) ([]usage.DailyUsage, error) {
+	var usages []usage.DailyUsage
+	for _, u := range r.rows {
+		if u.ClientID == clientID && !u.Day.Before(from) && !u.Day.After(to) {
+			usages = append(usages, u)
+		}
+	}
+	return usages, nil
+}
+
+func TestUsageRollupIsIdempotent(t *testing.T) {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	partner := usage.ClientID("partner-key")
+	counter := &fakeUsageCounter{days: map[time.Time][]usage.DailyUsage{
+		day: {
+			{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Requests: 10, Errors: 1, Results: 200},
+			{ClientID: usage.AnonymousClient, Endpoint: "GET /api/v1/hotels/search", Day: day, Requests: 4},
+		},
+	}}
+	repo := newFakeUsageRepository()
+	useCase := NewUsageReportUseCase(counter, repo, slog.New(slog.DiscardHandler))
+
+	rows, err := useCase.Rollup(context.Background(), day.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, rows)
+	first := maps.Clone(repo.rows)
+
+	rows, err = useCase.Rollup(context.Background(), day)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rows)
+	assert.Equal(t, first, repo.rows, "re-running a rollup changes nothing")
+
+	// Counters lost in a Redis restart do not lower what was rolled up.
+	counter.days[day] = []usage.DailyUsage{{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Requests: 2}}
+	_, err = useCase.Rollup(context.Background(), day)
+	require.NoError(t, err)
+	assert.Equal(t, first, repo.rows)
+}
+
+func TestUsageReportFiltersAndTotalsDays(t *testing.T) {
+	partner := usage.ClientID("partner-key")
+	repo := newFakeUsageRepository()
+	require.NoError(t, repo.UpsertDailyUsage(context.Background(), []usage.DailyUsage{
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Requests: 100},
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Requests: 10, Errors: 1, Results: 50},
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC), Requests: 30, Errors: 3, Results: 150},
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC), Requests: 100},
+		{ClientID: usage.AnonymousClient, Endpoint: "GET /api/v1/hotels/search", Day: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), Requests: 7},
+	}))
+	useCase := NewUsageReportUseCase(&fakeUsageCounter{}, repo, slog.New(slog.DiscardHandler))
+
+	report, err := useCase.Report(context.Background(), "partner-key",
+		time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC), time.Date(2026, 3, 12, 6, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, partner, report.ClientID)
+	assert.Equal(t, "2026-03-10", report.From)
+	assert.Equal(t, "2026-03-12", report.To)
+	days := make([]string, len(report.Series))
+	for i, entry := range report.Series {
+		days[i] = entry.Day
+	}
+	assert.ElementsMatch(t, []string{"2026-03-10", "2026-03-12"}, days, "both bounds are inclusive days")
+	assert.Equal(t, UsageTotals{Requests: 40, Errors: 4, Results: 200, ErrorRate: 0.1}, report.Totals)
+
+	anonymous, err := useCase.Report(context.Background(), usage.AnonymousClient,
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), anonymous.Totals.Requests)
+}
+
+func TestUsageReportRejectsInvalidRanges(t *testing.T) {
+	useCase := NewUsageReportUseCase(&fakeUsageCounter{}, newFakeUsageRepository(), slog.New(slog.DiscardHandler))
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	_, err := useCase.Report(context.Background(), "partner-key", day, day.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, ErrInvalidUsageRange)
+
+	_, err = useCase.Report(context.Background(), "partner-key", day.AddDate(-2, 0, 0), day)
+	assert.ErrorIs(t, err, ErrInvalidUsageRange)
+}
@@ -0,0 +1,26 @@
+package favorites
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TTL is how long a session's favorites are kept after the session last touched them.
+const TTL = 30 * 24 * time.Hour
+
+// MaxPerSession bounds the favorites of one session.
+const MaxPerSession = 100
+
+var ErrLimitReached = errors.New("favorites limit reached")
+
+// Store keeps the hotels bookmarked by anonymous sessions. Every call extends the session's
+// favorites by TTL.
+type Store interface {
+	// Add bookmarks hotelID, returning ErrLimitReached when the session already has
+	// MaxPerSession other favorites.
+	Add(ctx context.Context, sessionID string, hotelID int64) error
+	Remove(ctx context.Context, sessionID string, hotelID int64) error
+	// List returns the bookmarked hotel ids in ascending order.
+	List(ctx context.Context, sessionID string) ([]int64, error)
+}
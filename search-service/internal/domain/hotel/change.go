@@ -0,0 +1,25 @@
+package hotel
+
+import "time"
+
+// FieldChange is the value of a hotel field before and after an update.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Change lists the tracked fields of a hotel that one worker update or one manual correction
+// changed. Manual corrections carry the caller that made them as Actor.
+type Change struct {
+	HotelID         int64                  `json:"hotel_id"`
+	Changes         map[string]FieldChange `json:"changes"`
+	SourceMessageID string                 `json:"source_message_id,omitempty"`
+	Actor           string                 `json:"actor,omitempty"`
+	ChangedAt       time.Time              `json:"changed_at"`
+}
+
+// ListChangesOptions pages through the changes of a hotel, most recent first.
+type ListChangesOptions struct {
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,183 @@
+package hotel
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const checkinTimeLayout = "15:04"
+
+var checkinTimeLayouts = []string{
+	checkinTimeLayout,
+	"15:04:05",
+	"3:04 PM",
+	"3:04PM",
+	time.RFC3339,
+}
+
+// CheckinInfoJSON is the stored shape of the checkin JSONB column, where times are
+// kept as "15:04" strings rather than full timestamps.
+type CheckinInfoJSON struct {
+	CheckinStart        string   `json:"checkin_start"`
+	CheckinEnd          string   `json:"checkin_end"`
+	Checkout            string   `json:"checkout"`
+	Instructions        []string `json:"instructions"`
+	SpecialInstructions string   `json:"special_instructions"`
+}
+
+func NewCheckinInfoJSON(checkinInfo CheckinInfo) CheckinInfoJSON {
+	return CheckinInfoJSON{
+		CheckinStart:        checkinInfo.formatTime(checkinStartKey, checkinInfo.CheckinStart),
+		CheckinEnd:          checkinInfo.formatTime(checkinEndKey, checkinInfo.CheckinEnd),
+		Checkout:            checkinInfo.formatTime(checkoutKey, checkinInfo.Checkout),
+		Instructions:        checkinInfo.Instructions,
+		SpecialInstructions: checkinInfo.SpecialInstructions,
+	}
+}
+
+func (c CheckinInfoJSON) ToCheckinInfo() CheckinInfo {
+	checkinInfo := CheckinInfo{
+		Instructions:        c.Instructions,
+		SpecialInstructions: c.SpecialInstructions,
+	}
+	checkinInfo.CheckinStart = checkinInfo.parseTime(checkinStartKey, c.CheckinStart)
+	checkinInfo.CheckinEnd = checkinInfo.parseTime(checkinEndKey, c.CheckinEnd)
+	checkinInfo.Checkout = checkinInfo.parseTime(checkoutKey, c.Checkout)
+	return checkinInfo
+}
+
+// Keys of the stored times, as in CheckinInfoJSON.
+const (
+	checkinStartKey = "checkin_start"
+	checkinEndKey   = "checkin_end"
+	checkoutKey     = "checkout"
+)
+
+// parseTime parses the stored time s, keeping it in UnparsedTimes when it is in none of the
+// known layouts.
+func (c *CheckinInfo) parseTime(key, s string) time.Time {
+	t := parseCheckinTime(s)
+	if t.IsZero() && strings.TrimSpace(s) != "" {
+		if c.UnparsedTimes == nil {
+			c.UnparsedTimes = make(map[string]string)
+		}
+		c.UnparsedTimes[key] = s
+	}
+	return t
+}
+
+// formatTime formats t for storage, falling back to the unparsed value it was read from.
+func (c CheckinInfo) formatTime(key string, t time.Time) string {
+	if raw, ok := c.UnparsedTimes[key]; ok && t.IsZero() {
+		return raw
+	}
+	return formatCheckinTime(t)
+}
+
+func ParseCheckinInfo(data []byte) (CheckinInfo, error) {
+	var checkinInfoJSON CheckinInfoJSON
+	if err := json.Unmarshal(data, &checkinInfoJSON); err != nil {
+		return CheckinInfo{}, err
+	}
+	return checkinInfoJSON.ToCheckinInfo(), nil
+}
+
+func MarshalCheckinInfo(checkinInfo CheckinInfo) ([]byte, error) {
+	return json.Marshal(NewCheckinInfoJSON(checkinInfo))
+}
+
+func formatCheckinTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(checkinTimeLayout)
+}
+
+func parseCheckinTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range checkinTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+const (
+	MinutesPerDay = 24 * 60
+
+	// LateNightCutoffMinutes separates the early hours that belong to the previous night from
+	// the start of a new day: a window ending at 02:00 and an arrival at 01:30 are both
+	// measured past midnight of the check-in day.
+	LateNightCutoffMinutes = 6 * 60
+)
+
+var checkin24hMarkers = []string{"24 hour", "24-hour", "24h", "24/7", "around the clock", "round the clock"}
+
+// CheckinWindow is the check-in period as minutes since midnight of the check-in day.
+// EndMinutes exceeds MinutesPerDay when the window runs past midnight.
+type CheckinWindow struct {
+	Start        string `json:"start,omitempty"`
+	End          string `json:"end,omitempty"`
+	StartMinutes *int   `json:"start_minutes,omitempty"`
+	EndMinutes   *int   `json:"end_minutes,omitempty"`
+	Is24h        bool   `json:"is_24h"`
+	Unknown      bool   `json:"unknown"`
+}
+
+// Window derives the structured check-in window. A window is 24h when start and end are the
+// same time or the instructions say so, and unknown when neither the end time nor a 24h
+// marker is available.
+func (c CheckinInfo) Window() CheckinWindow {
+	window := CheckinWindow{
+		Start: formatCheckinTime(c.CheckinStart),
+		End:   formatCheckinTime(c.CheckinEnd),
+	}
+
+	if !c.CheckinStart.IsZero() {
+		start := minutesOfDay(c.CheckinStart)
+		window.StartMinutes = &start
+	}
+
+	if !c.CheckinEnd.IsZero() {
+		end := minutesOfDay(c.CheckinEnd)
+		switch {
+		case window.StartMinutes != nil && end == *window.StartMinutes:
+			window.Is24h = true
+		case window.StartMinutes != nil && end < *window.StartMinutes:
+			end += MinutesPerDay
+		case window.StartMinutes == nil && end < LateNightCutoffMinutes:
+			end += MinutesPerDay
+		}
+		window.EndMinutes = &end
+	}
+
+	if !window.Is24h {
+		window.Is24h = c.mentions24h()
+	}
+	window.Unknown = !window.Is24h && window.EndMinutes == nil
+
+	return window
+}
+
+func (c CheckinInfo) mentions24h() bool {
+	texts := append([]string{c.SpecialInstructions}, c.Instructions...)
+	for _, text := range texts {
+		text = strings.ToLower(text)
+		for _, marker := range checkin24hMarkers {
+			if strings.Contains(text, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func minutesOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
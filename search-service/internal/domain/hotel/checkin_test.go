@@ -0,0 +1,119 @@
+package hotel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckinInfoRoundTrip(t *testing.T) {
+	stored := []byte(`{"checkin_start":"14:00","checkin_end":"23:30","checkout":"11:00",` +
+		`"instructions":["Key at reception","Show ID"],"special_instructions":"Call ahead"}`)
+
+	checkinInfo, err := ParseCheckinInfo(stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, "14:00", checkinInfo.CheckinStart.Format(checkinTimeLayout))
+	assert.Equal(t, "23:30", checkinInfo.CheckinEnd.Format(checkinTimeLayout))
+	assert.Equal(t, "11:00", checkinInfo.Checkout.Format(checkinTimeLayout))
+	assert.Equal(t, []string{"Key at reception", "Show ID"}, checkinInfo.Instructions)
+	assert.Equal(t, "Call ahead", checkinInfo.SpecialInstructions)
+	assert.Empty(t, checkinInfo.UnparsedTimes)
+
+	marshaled, err := MarshalCheckinInfo(checkinInfo)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(stored), string(marshaled))
+}
+
+func TestCheckinInfoAcceptsTwelveHourTimes(t *testing.T) {
+	checkinInfo, err := ParseCheckinInfo([]byte(`{"checkin_start":"3:00 PM","checkout":"11:30AM"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "15:00", checkinInfo.CheckinStart.Format(checkinTimeLayout))
+	assert.Equal(t, "11:30", checkinInfo.Checkout.Format(checkinTimeLayout))
+}
+
+func TestCheckinInfoKeepsUnparsedTimes(t *testing.T) {
+	checkinInfo, err := ParseCheckinInfo([]byte(`{"checkin_start":"from noon","checkin_end":"22:00","checkout":""}`))
+	require.NoError(t, err)
+
+	assert.True(t, checkinInfo.CheckinStart.IsZero())
+	assert.Equal(t, map[string]string{"checkin_start": "from noon"}, checkinInfo.UnparsedTimes)
+	assert.Empty(t, checkinInfo.Window().Start)
+
+	marshaled, err := MarshalCheckinInfo(checkinInfo)
+	require.NoError(t, err)
+
+	var stored CheckinInfoJSON
+	require.NoError(t, json.Unmarshal(marshaled, &stored))
+	assert.Equal(t, "from noon", stored.CheckinStart)
+	assert.Equal(t, "22:00", stored.CheckinEnd)
+	assert.Empty(t, stored.Checkout)
+}
+
+func intPointer(v int) *int {
+	return &v
+}
+
+func TestCheckinWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		want   CheckinWindow
+	}{
+		{
+			name:   "same-day window",
+			stored: `{"checkin_start":"14:00","checkin_end":"23:00"}`,
+			want:   CheckinWindow{Start: "14:00", End: "23:00", StartMinutes: intPointer(840), EndMinutes: intPointer(1380)},
+		},
+		{
+			name:   "window crossing midnight",
+			stored: `{"checkin_start":"15:00","checkin_end":"02:00"}`,
+			want:   CheckinWindow{Start: "15:00", End: "02:00", StartMinutes: intPointer(900), EndMinutes: intPointer(MinutesPerDay + 120)},
+		},
+		{
+			name:   "early-hours end without a start",
+			stored: `{"checkin_end":"01:30"}`,
+			want:   CheckinWindow{End: "01:30", EndMinutes: intPointer(MinutesPerDay + 90)},
+		},
+		{
+			name:   "morning end without a start",
+			stored: `{"checkin_end":"11:00"}`,
+			want:   CheckinWindow{End: "11:00", EndMinutes: intPointer(660)},
+		},
+		{
+			name:   "start equal to end is 24h",
+			stored: `{"checkin_start":"00:00","checkin_end":"00:00"}`,
+			want:   CheckinWindow{Start: "00:00", End: "00:00", StartMinutes: intPointer(0), EndMinutes: intPointer(0), Is24h: true},
+		},
+		{
+			name:   "24h from the instructions",
+			stored: `{"checkin_start":"14:00","instructions":["Front desk open 24-hour"]}`,
+			want:   CheckinWindow{Start: "14:00", StartMinutes: intPointer(840), Is24h: true},
+		},
+		{
+			name:   "24h from the special instructions",
+			stored: `{"special_instructions":"Reception is staffed around the clock"}`,
+			want:   CheckinWindow{Is24h: true},
+		},
+		{
+			name:   "start only is unknown",
+			stored: `{"checkin_start":"14:00"}`,
+			want:   CheckinWindow{Start: "14:00", StartMinutes: intPointer(840), Unknown: true},
+		},
+		{
+			name:   "no check-in information is unknown",
+			stored: `{}`,
+			want:   CheckinWindow{Unknown: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkinInfo, err := ParseCheckinInfo([]byte(tt.stored))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checkinInfo.Window())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package hotel
+
+// CollectionLimits caps the embedded collections of a hotel response. A limit of zero or less
+// keeps every item.
+type CollectionLimits struct {
+	Photos       int
+	Rooms        int
+	Reviews      int
+	Translations int
+}
+
+// Truncation reports how many items each embedded collection held and whether it was cut to
+// its limit.
+type Truncation struct {
+	PhotosTotal           int  `json:"photos_total"`
+	PhotosTruncated       bool `json:"photos_truncated"`
+	RoomsTotal            int  `json:"rooms_total"`
+	RoomsTruncated        bool `json:"rooms_truncated"`
+	ReviewsTotal          int  `json:"reviews_total"`
+	ReviewsTruncated      bool `json:"reviews_truncated"`
+	TranslationsTotal     int  `json:"translations_total"`
+	TranslationsTruncated bool `json:"translations_truncated"`
+}
+
+// Truncate keeps the first items of each embedded collection up to limits. The collections
+// are resliced, never modified, so a hotel shared with the cache or the indexer is unaffected.
+func (h *Hotel) Truncate(limits CollectionLimits) Truncation {
+	truncation := Truncation{
+		PhotosTotal:       len(h.Photos),
+		RoomsTotal:        len(h.Rooms),
+		ReviewsTotal:      len(h.Reviews),
+		TranslationsTotal: len(h.Translations),
+	}
+	h.Photos, truncation.PhotosTruncated = truncate(h.Photos, limits.Photos)
+	h.Rooms, truncation.RoomsTruncated = truncate(h.Rooms, limits.Rooms)
+	h.Reviews, truncation.ReviewsTruncated = truncate(h.Reviews, limits.Reviews)
+	h.Translations, truncation.TranslationsTruncated = truncate(h.Translations, limits.Translations)
+	return truncation
+}
+
+func truncate[T any](items []T, limit int) ([]T, bool) {
+	if limit > 0 && len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
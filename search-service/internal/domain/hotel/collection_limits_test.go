@@ -0,0 +1,63 @@
+package hotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// oversizedHotel has more photos, rooms, reviews and translations than any response limit.
+func oversizedHotel() *Hotel {
+	h := &Hotel{HotelID: 1641879}
+	h.Photos = make([]Photo, 412)
+	h.Rooms = make([]Room, 60)
+	h.Reviews = make([]Review, 250)
+	h.Translations = make([]Translation, 9)
+	for i := range h.Reviews {
+		h.Reviews[i].ReviewID = int64(i + 1)
+	}
+	return h
+}
+
+func TestTruncateCutsEachCollectionToItsLimit(t *testing.T) {
+	h := oversizedHotel()
+
+	truncation := h.Truncate(CollectionLimits{Photos: 50, Rooms: 20, Reviews: 10, Translations: 9})
+
+	assert.Equal(t, Truncation{
+		PhotosTotal:       412,
+		PhotosTruncated:   true,
+		RoomsTotal:        60,
+		RoomsTruncated:    true,
+		ReviewsTotal:      250,
+		ReviewsTruncated:  true,
+		TranslationsTotal: 9,
+	}, truncation)
+	assert.Len(t, h.Photos, 50)
+	assert.Len(t, h.Rooms, 20)
+	assert.Len(t, h.Reviews, 10)
+	assert.Len(t, h.Translations, 9)
+	assert.Equal(t, int64(1), h.Reviews[0].ReviewID, "the first items are kept")
+}
+
+func TestTruncateWithoutLimitsKeepsEveryItem(t *testing.T) {
+	h := oversizedHotel()
+
+	truncation := h.Truncate(CollectionLimits{})
+
+	assert.False(t, truncation.PhotosTruncated || truncation.RoomsTruncated || truncation.ReviewsTruncated || truncation.TranslationsTruncated)
+	assert.Equal(t, 412, truncation.PhotosTotal)
+	assert.Len(t, h.Photos, 412)
+}
+
+func TestTruncateLeavesSharedCollectionsIntact(t *testing.T) {
+	shared := oversizedHotel()
+	response := *shared
+
+	response.Truncate(CollectionLimits{Photos: 5, Reviews: 5})
+
+	assert.Len(t, response.Photos, 5)
+	assert.Len(t, shared.Photos, 412, "the cached hotel keeps every photo")
+	assert.Len(t, shared.Reviews, 250)
+	assert.Equal(t, int64(6), shared.Reviews[5].ReviewID)
+}
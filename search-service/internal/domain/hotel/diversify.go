@@ -0,0 +1,46 @@
+package hotel
+
+import (
+	"slices"
+	"strings"
+)
+
+// DiversifyResults reorders hotels so that no more than maxSameChain consecutive hotels share a
+// chain, keeping the ranking otherwise: each position takes the best ranked remaining hotel that
+// does not extend a run past maxSameChain. Once only hotels of the running chain remain, they
+// follow in order. Hotels without a chain never form a run.
+func DiversifyResults(hotels []*Hotel, maxSameChain int) []*Hotel {
+	if maxSameChain <= 0 || len(hotels) <= maxSameChain {
+		return hotels
+	}
+
+	remaining := slices.Clone(hotels)
+	diversified := make([]*Hotel, 0, len(hotels))
+	runChain, runLength := "", 0
+	for len(remaining) > 0 {
+		next := 0
+		if runLength >= maxSameChain {
+			if i := slices.IndexFunc(remaining, func(h *Hotel) bool { return chainKey(h) != runChain }); i >= 0 {
+				next = i
+			}
+		}
+
+		h := remaining[next]
+		remaining = slices.Delete(remaining, next, next+1)
+		diversified = append(diversified, h)
+
+		switch chain := chainKey(h); {
+		case chain == "":
+			runChain, runLength = "", 0
+		case chain == runChain:
+			runLength++
+		default:
+			runChain, runLength = chain, 1
+		}
+	}
+	return diversified
+}
+
+func chainKey(h *Hotel) string {
+	return strings.ToLower(strings.TrimSpace(h.Chain))
+}
@@ -0,0 +1,103 @@
+package hotel
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rankedHotels returns one hotel per chain, in ranking order, with increasing hotel ids.
+func rankedHotels(chains ...string) []*Hotel {
+	hotels := make([]*Hotel, len(chains))
+	for i, chain := range chains {
+		hotels[i] = &Hotel{HotelID: int64(i + 1), Chain: chain}
+	}
+	return hotels
+}
+
+func hotelOrder(hotels []*Hotel) []string {
+	order := make([]string, len(hotels))
+	for i, h := range hotels {
+		order[i] = fmt.Sprintf("%d:%s", h.HotelID, h.Chain)
+	}
+	return order
+}
+
+func TestDiversifyResultsInterleavesChains(t *testing.T) {
+	tests := []struct {
+		name         string
+		chains       []string
+		maxSameChain int
+		expected     []string
+	}{
+		{
+			name:         "a run of one chain",
+			chains:       []string{"Marriott", "Marriott", "Marriott", "Marriott", "Hilton", "Hyatt"},
+			maxSameChain: 2,
+			expected:     []string{"1:Marriott", "2:Marriott", "5:Hilton", "3:Marriott", "4:Marriott", "6:Hyatt"},
+		},
+		{
+			name:         "alternating chains",
+			chains:       []string{"Marriott", "Marriott", "Marriott", "Hilton", "Hilton", "Hilton"},
+			maxSameChain: 1,
+			expected:     []string{"1:Marriott", "4:Hilton", "2:Marriott", "5:Hilton", "3:Marriott", "6:Hilton"},
+		},
+		{
+			name:         "already diverse",
+			chains:       []string{"Marriott", "Hilton", "Marriott", "Hyatt"},
+			maxSameChain: 1,
+			expected:     []string{"1:Marriott", "2:Hilton", "3:Marriott", "4:Hyatt"},
+		},
+		{
+			name:         "one chain left",
+			chains:       []string{"Marriott", "Hilton", "Hilton", "Hilton", "Hilton"},
+			maxSameChain: 2,
+			expected:     []string{"1:Marriott", "2:Hilton", "3:Hilton", "4:Hilton", "5:Hilton"},
+		},
+		{
+			name:         "independent hotels break runs",
+			chains:       []string{"Marriott", "Marriott", "", "Marriott", "Marriott", "Hilton"},
+			maxSameChain: 2,
+			expected:     []string{"1:Marriott", "2:Marriott", "3:", "4:Marriott", "5:Marriott", "6:Hilton"},
+		},
+		{
+			name:         "independent hotels never form a run",
+			chains:       []string{"", "", "", "Hilton"},
+			maxSameChain: 1,
+			expected:     []string{"1:", "2:", "3:", "4:Hilton"},
+		},
+		{
+			name:         "chain names differing in case",
+			chains:       []string{"Marriott", "MARRIOTT ", "marriott", "Hilton"},
+			maxSameChain: 2,
+			expected:     []string{"1:Marriott", "2:MARRIOTT ", "4:Hilton", "3:marriott"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hotels := rankedHotels(tt.chains...)
+			diversified := DiversifyResults(hotels, tt.maxSameChain)
+
+			assert.Equal(t, tt.expected, hotelOrder(diversified))
+			assert.ElementsMatch(t, hotels, diversified, "no hotel is dropped or repeated")
+		})
+	}
+}
+
+func TestDiversifyResultsKeepsTheRankingWithoutAValidRun(t *testing.T) {
+	hotels := rankedHotels("Marriott", "Marriott", "Marriott", "Hilton")
+
+	assert.Equal(t, hotelOrder(hotels), hotelOrder(DiversifyResults(hotels, 0)))
+	assert.Equal(t, hotelOrder(hotels), hotelOrder(DiversifyResults(hotels, 4)), "a page no longer than the run is unchanged")
+	assert.Empty(t, DiversifyResults(nil, 2))
+}
+
+func TestDiversifyResultsDoesNotReorderItsInput(t *testing.T) {
+	hotels := rankedHotels("Marriott", "Marriott", "Marriott", "Hilton")
+
+	DiversifyResults(hotels, 1)
+
+	assert.Equal(t, []string{"1:Marriott", "2:Marriott", "3:Marriott", "4:Hilton"}, hotelOrder(hotels))
+}
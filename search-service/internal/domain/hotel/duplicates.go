@@ -0,0 +1,100 @@
+package hotel
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// ErrMergeHotelNotFound is returned by MergeHotels when either hotel does not exist or was
+// already deleted.
+var ErrMergeHotelNotFound = errors.New("hotel to merge not found")
+
+// DuplicateCriteria decides when two hotels are the same property listed twice, for example
+// after Cupid changed its id or under two chains.
+type DuplicateCriteria struct {
+	// NameSimilarityThreshold is the lowest trigram similarity of the names, from 0 to 1.
+	NameSimilarityThreshold float64
+	MaxDistanceKm           float64
+	SameChainOnly           bool
+}
+
+// DuplicatePair is two hotels matching the criteria, the lower hotel id first.
+type DuplicatePair struct {
+	HotelID        int64
+	OtherHotelID   int64
+	NameSimilarity float64
+	DistanceKm     float64
+}
+
+// DuplicateGroup is a set of hotels that are likely the same property, in hotel id order.
+// Confidence runs from 0 to 1 and is that of the weakest pair linking the group.
+type DuplicateGroup struct {
+	HotelIDs   []int64 `json:"hotel_ids"`
+	Confidence float64 `json:"confidence"`
+}
+
+// MergeResult reports the merge of a duplicate hotel into its primary.
+type MergeResult struct {
+	PrimaryID           int64 `json:"primary_id"`
+	DuplicateID         int64 `json:"duplicate_id"`
+	MovedReviews        int64 `json:"moved_reviews"`
+	MovedTranslations   int64 `json:"moved_translations"`
+	DroppedTranslations int64 `json:"dropped_translations"`
+}
+
+// Confidence scores the pair from its name similarity, discounted by up to half as the hotels
+// lie further apart within maxDistanceKm.
+func (p DuplicatePair) Confidence(maxDistanceKm float64) float64 {
+	proximity := 1.0
+	if maxDistanceKm > 0 {
+		proximity = 1 - 0.5*min(p.DistanceKm/maxDistanceKm, 1)
+	}
+	return p.NameSimilarity * proximity
+}
+
+// GroupDuplicates joins pairs sharing a hotel into groups, so three listings of one property
+// form one group. Groups come most confident first.
+func GroupDuplicates(pairs []DuplicatePair, maxDistanceKm float64) []DuplicateGroup {
+	parent := make(map[int64]int64)
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	for _, pair := range pairs {
+		root, otherRoot := find(pair.HotelID), find(pair.OtherHotelID)
+		if root != otherRoot {
+			parent[max(root, otherRoot)] = min(root, otherRoot)
+		}
+	}
+
+	groups := make(map[int64]*DuplicateGroup)
+	for _, pair := range pairs {
+		root := find(pair.HotelID)
+		group, ok := groups[root]
+		if !ok {
+			group = &DuplicateGroup{Confidence: 1}
+			groups[root] = group
+		}
+		group.HotelIDs = append(group.HotelIDs, pair.HotelID, pair.OtherHotelID)
+		group.Confidence = min(group.Confidence, pair.Confidence(maxDistanceKm))
+	}
+
+	result := make([]DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		slices.Sort(group.HotelIDs)
+		group.HotelIDs = slices.Compact(group.HotelIDs)
+		result = append(result, *group)
+	}
+	slices.SortFunc(result, func(a, b DuplicateGroup) int {
+		return cmp.Or(cmp.Compare(b.Confidence, a.Confidence), cmp.Compare(a.HotelIDs[0], b.HotelIDs[0]))
+	})
+	return result
+}
@@ -0,0 +1,17 @@
+package hotel
+
+// FacilityCount is a facility with the number of active hotels listing it. Slug is the
+// canonical slug, Name the display name or, for a raw facility, the name Cupid sent.
+type FacilityCount struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	HotelCount int    `json:"hotel_count"`
+}
+
+// FacilityNormalizeBatch reports one batch of facility normalization. Updated holds the hotels
+// whose facility slugs or amenities changed, with their new amenities.
+type FacilityNormalizeBatch struct {
+	LastHotelID int64
+	Hotels      int
+	Updated     map[int64][]string
+}
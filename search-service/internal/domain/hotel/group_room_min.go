@@ -0,0 +1,49 @@
+package hotel
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// GroupRoomMin is the stored shape of the group_room_min JSONB column, the restrictions a hotel
+// puts on group bookings.
+type GroupRoomMin struct {
+	MinNights int32 `json:"min_nights"`
+}
+
+// ParseGroupRoomMin reads the group_room_min column. Cupid, and so most rows, hold the minimum
+// nights of a group booking as a bare integer; rows written back by this service hold a
+// GroupRoomMin object. Null gives nil.
+func ParseGroupRoomMin(data []byte) (*GroupRoomMin, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		if value != math.Trunc(value) || value < 0 || value > math.MaxInt32 {
+			return nil, fmt.Errorf("group_room_min %v is not a number of nights", value)
+		}
+		return &GroupRoomMin{MinNights: int32(value)}, nil
+	case map[string]any:
+		var groupRoomMin GroupRoomMin
+		if err := json.Unmarshal(data, &groupRoomMin); err != nil {
+			return nil, err
+		}
+		return &groupRoomMin, nil
+	default:
+		return nil, fmt.Errorf("group_room_min holds a %T, want a number of nights", value)
+	}
+}
+
+// MinNights is the minimum stay the hotel requires, 0 when it has no restriction.
+func (h *Hotel) MinNights() int32 {
+	if h.GroupRoomMin == nil || h.GroupRoomMin.MinNights < 0 {
+		return 0
+	}
+	return h.GroupRoomMin.MinNights
+}
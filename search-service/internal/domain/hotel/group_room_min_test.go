@@ -0,0 +1,43 @@
+package hotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupRoomMin(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected *GroupRoomMin
+	}{
+		{data: `5`, expected: &GroupRoomMin{MinNights: 5}},
+		{data: `0`, expected: &GroupRoomMin{}},
+		{data: `{"min_nights": 7}`, expected: &GroupRoomMin{MinNights: 7}},
+		{data: `null`, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			groupRoomMin, err := ParseGroupRoomMin([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, groupRoomMin)
+		})
+	}
+}
+
+func TestParseGroupRoomMinRejectsOtherShapes(t *testing.T) {
+	for _, data := range []string{`2.5`, `-1`, `"5"`, `[5]`, `{"min_nights": "5"}`, `not json`} {
+		_, err := ParseGroupRoomMin([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestMinNights(t *testing.T) {
+	groupRoomMin, err := ParseGroupRoomMin([]byte(`6`))
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(6), (&Hotel{GroupRoomMin: groupRoomMin}).MinNights())
+	assert.Zero(t, (&Hotel{}).MinNights(), "no restriction")
+}
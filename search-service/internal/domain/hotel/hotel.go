@@ -1,12 +1,22 @@
 package hotel
 
 import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/airports"
+	"github.com/victoragudo/hotel-management-system/pkg/geo"
+	"github.com/victoragudo/hotel-management-system/pkg/phone"
 )
 
 type Facility struct {
 	ID   int
 	Name string
+	// Slug is the canonical facility the name maps to.
+	Slug string
 }
 
 type Hotel struct {
@@ -15,6 +25,7 @@ type Hotel struct {
 	CupidID             int64
 	Name                string
 	Description         string
+	DescriptionSnippet  string `json:"description_snippet,omitempty"`
 	Address             Address
 	Rating              float64
 	StarRating          int32
@@ -29,13 +40,13 @@ type Hotel struct {
 	HotelType           string
 	Chain               string
 	ChainID             int32
-	Phone               string
-	Fax                 string
-	Email               string
 	AirportCode         string
 	ReviewCount         int32
+	ArchivedReviewCount int32
 	CheckinInfo         CheckinInfo
+	CheckinWindow       CheckinWindow `json:"checkin_window"`
 	Parking             string
+	GroupRoomMin        *GroupRoomMin `json:"group_room_min,omitempty"`
 	ChildAllowed        bool
 	PetsAllowed         bool
 	Photos              []Photo
@@ -43,6 +54,8 @@ type Hotel struct {
 	ImportantInfo       string
 	Facilities          []Facility
 	Rooms               []Room
+	SourceMappings      map[string]string `json:"source_mappings,omitempty"`
+	NearbyAttractions   []Attraction      `json:"nearby_attractions,omitempty"`
 	Reviews             []Review
 	Translations        []Translation
 	CreatedAt           time.Time
@@ -51,6 +64,75 @@ type Hotel struct {
 	HotelTypeID         int64
 	Latitude            float64
 	Longitude           float64
+	// Timezone is the IANA timezone of the hotel, such as Asia/Tokyo, derived from its
+	// coordinates. Its check-in and check-out times are clock times in that zone.
+	Timezone   string   `json:"timezone,omitempty"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+	// DataSources attributes each field group, such as core or photos, to the source that last
+	// wrote it.
+	DataSources map[string]DataSource `json:"data_sources,omitempty"`
+}
+
+// IsStale reports whether the hotel was due for a refresh from the Cupid API before now. A
+// hotel without a scheduled update was just fetched from the API and is fresh.
+func (h *Hotel) IsStale(now time.Time) bool {
+	return !h.NextUpdateAt.IsZero() && h.NextUpdateAt.Before(now)
+}
+
+// nullIslandDegrees is how close to 0,0 coordinates must be to count as a missing geocode.
+const nullIslandDegrees = 0.001
+
+// HasCoordinates reports whether the hotel has a plausible position. Coordinates at or next to
+// 0,0 are a missing geocode, and coordinates out of range are corrupt.
+func (h *Hotel) HasCoordinates() bool {
+	if math.Abs(h.Latitude) < nullIslandDegrees && math.Abs(h.Longitude) < nullIslandDegrees {
+		return false
+	}
+	return h.Latitude >= -90 && h.Latitude <= 90 && h.Longitude >= -180 && h.Longitude <= 180
+}
+
+// AirportDistanceKm returns the distance to the hotel's nearest airport. It is false when the
+// hotel has no coordinates or its airport code is not in the airports dataset.
+func (h *Hotel) AirportDistanceKm() (float64, bool) {
+	if !h.HasCoordinates() || h.AirportCode == "" {
+		return 0, false
+	}
+	airport, ok := airports.Lookup(h.AirportCode)
+	if !ok {
+		return 0, false
+	}
+	return geo.Haversine(h.Latitude, h.Longitude, airport.Lat, airport.Lon), true
+}
+
+// RoomTypesCount is the number of room types in the hotel's inventory.
+func (h *Hotel) RoomTypesCount() int {
+	return len(h.Rooms)
+}
+
+// TotalCapacity is the number of guests the hotel hosts with one room of each type occupied,
+// the sum of the maximum occupancy of its room types.
+func (h *Hotel) TotalCapacity() int32 {
+	var capacity int32
+	for _, room := range h.Rooms {
+		capacity += int32(max(room.MaxOccupancy, 0))
+	}
+	return capacity
+}
+
+// BaseLanguage is the language of the hotel's own content; translations add the others.
+const BaseLanguage = "en"
+
+// LanguagesAvailable lists the languages the hotel's content is available in: the base
+// language followed by its translations, without duplicates.
+func (h *Hotel) LanguagesAvailable() []string {
+	languages := []string{BaseLanguage}
+	for _, translation := range h.Translations {
+		lang := strings.ToLower(strings.TrimSpace(translation.Lang))
+		if lang != "" && !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
 }
 
 type Address struct {
@@ -66,10 +148,125 @@ type Location struct {
 	Longitude float64
 }
 
+// Attraction is a landmark near the hotel, such as a museum or a beach, found by the worker's
+// geo enrichment.
+type Attraction struct {
+	Name           string `json:"name"`
+	Category       string `json:"category"`
+	DistanceMeters int    `json:"distance_meters"`
+}
+
+// DataSource is the source that last wrote a group of hotel fields, such as cupid_fetcher or
+// fallback, and when.
+type DataSource struct {
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttractionNames lists the names of the hotel's nearby attractions, without duplicates.
+func (h *Hotel) AttractionNames() []string {
+	var names []string
+	for _, attraction := range h.NearbyAttractions {
+		if !slices.Contains(names, attraction.Name) {
+			names = append(names, attraction.Name)
+		}
+	}
+	return names
+}
+
+// AttractionCategories lists the categories of the hotel's nearby attractions, without duplicates.
+func (h *Hotel) AttractionCategories() []string {
+	var categories []string
+	for _, attraction := range h.NearbyAttractions {
+		if !slices.Contains(categories, attraction.Category) {
+			categories = append(categories, attraction.Category)
+		}
+	}
+	return categories
+}
+
+// PolicySummary is a one-line summary of the hotel's smoking, pet and parking policies for
+// hotel cards, such as "No smoking · No pets · Free parking". Policies that cannot be read are
+// left out, and the hotel's parking field stands in for a missing parking policy.
+func (h *Hotel) PolicySummary() string {
+	var smoking, pets, parking string
+	for _, policy := range h.Policies {
+		text := strings.ToLower(policy.Name + " " + policy.Description)
+		switch strings.ToLower(strings.TrimSpace(policy.PolicyType)) {
+		case "smoking":
+			if smoking == "" {
+				smoking = allowancePhrase(text, "Smoking allowed", "No smoking")
+			}
+		case "pets", "pet":
+			if pets == "" {
+				pets = allowancePhrase(strings.ToLower(policy.PetsAllowed)+" "+text, "Pets allowed", "No pets")
+			}
+		case "parking":
+			if parking == "" {
+				parking = parkingPhrase(strings.ToLower(policy.Parking) + " " + text)
+			}
+		}
+	}
+	if parking == "" {
+		parking = parkingPhrase(strings.ToLower(h.Parking))
+	}
+
+	var parts []string
+	for _, part := range []string{smoking, pets, parking} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func allowancePhrase(text, allowed, denied string) string {
+	switch {
+	case containsAny(text, "not allowed", "not permitted", "prohibited", "forbidden", "non-smoking", "no pets", "no smoking", "false"):
+		return denied
+	case containsAny(text, "allowed", "permitted", "welcome", "true", "yes"):
+		return allowed
+	}
+	return ""
+}
+
+func parkingPhrase(text string) string {
+	switch {
+	case containsAny(text, "no parking", "not available", "unavailable", "none"):
+		return "No parking"
+	case containsAny(text, "free", "no charge", "complimentary"):
+		return "Free parking"
+	case containsAny(text, "paid", "charge", "fee", "per day", "per night"):
+		return "Paid parking"
+	case containsAny(text, "available", "possible", "on site", "onsite", "yes"):
+		return "Parking available"
+	}
+	return ""
+}
+
+func containsAny(text string, words ...string) bool {
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
 type ContactInfo struct {
 	Phone string
 	Fax   string
 	Email string
+	// PhoneE164 and FaxE164 are the numbers in E.164 form, empty when they cannot be read.
+	PhoneE164 string `json:"phone_e164,omitempty"`
+	FaxE164   string `json:"fax_e164,omitempty"`
+}
+
+// NormalizeNumbers sets the E.164 form of the phone and fax numbers, reading national numbers
+// as numbers of country.
+func (c *ContactInfo) NormalizeNumbers(country string) {
+	c.PhoneE164, _ = phone.Normalize(c.Phone, country)
+	c.FaxE164, _ = phone.Normalize(c.Fax, country)
 }
 
 type CheckinInfo struct {
@@ -78,6 +275,9 @@ type CheckinInfo struct {
 	Checkout            time.Time
 	Instructions        []string
 	SpecialInstructions string
+	// UnparsedTimes keeps the stored times in none of the known layouts by their stored key,
+	// such as checkin_start, so saving the hotel does not drop them.
+	UnparsedTimes map[string]string `json:",omitempty"`
 }
 
 type Photo struct {
@@ -148,6 +348,11 @@ type Review struct {
 	Pros         string
 	Cons         string
 	Source       string
+	// Category scores are zero when the source did not rate the category.
+	ScoreLocation   int32
+	ScoreService    int32
+	ScoreValue      int32
+	ScoreFacilities int32
 }
 
 type Policy struct {
@@ -190,3 +395,13 @@ type Translation struct {
 	Latitude            float64
 	Longitude           float64
 }
+
+// SortReviewsByDate orders reviews most recent first. Reviews without a known date go last.
+func SortReviewsByDate(reviews []Review) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if reviews[i].Date.IsZero() != reviews[j].Date.IsZero() {
+			return !reviews[i].Date.IsZero()
+		}
+		return reviews[i].Date.After(reviews[j].Date)
+	})
+}
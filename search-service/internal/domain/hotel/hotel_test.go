@@ -0,0 +1,91 @@
+package hotel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortReviewsByDate(t *testing.T) {
+	reviews := []Review{
+		{ReviewID: 1},
+		{ReviewID: 2, Date: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{ReviewID: 3, Date: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)},
+		{ReviewID: 4},
+		{ReviewID: 5, Date: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)},
+	}
+
+	SortReviewsByDate(reviews)
+
+	ids := make([]int64, len(reviews))
+	for i, review := range reviews {
+		ids[i] = review.ReviewID
+	}
+	assert.Equal(t, []int64{5, 3, 2, 1, 4}, ids)
+}
+
+func TestRoomInventory(t *testing.T) {
+	h := Hotel{Rooms: []Room{{MaxOccupancy: 2}, {MaxOccupancy: 4}, {MaxOccupancy: 6}}}
+	assert.Equal(t, 3, h.RoomTypesCount())
+	assert.Equal(t, int32(12), h.TotalCapacity())
+
+	h.Rooms = append(h.Rooms, Room{MaxOccupancy: -1}, Room{})
+	assert.Equal(t, 5, h.RoomTypesCount())
+	assert.Equal(t, int32(12), h.TotalCapacity(), "room types without a valid occupancy add no capacity")
+
+	empty := Hotel{}
+	assert.Zero(t, empty.RoomTypesCount())
+	assert.Zero(t, empty.TotalCapacity())
+}
+
+func TestAirportDistanceKm(t *testing.T) {
+	plaza := Hotel{Latitude: 40.7646, Longitude: -73.9744, AirportCode: "jfk"}
+	distance, ok := plaza.AirportDistanceKm()
+	assert.True(t, ok)
+	assert.InDelta(t, 21.5, distance, 0.2)
+
+	for name, h := range map[string]Hotel{
+		"no airport code":     {Latitude: 40.7646, Longitude: -73.9744},
+		"unknown airport":     {Latitude: 40.7646, Longitude: -73.9744, AirportCode: "XXX"},
+		"no coordinates":      {AirportCode: "JFK"},
+		"invalid coordinates": {Latitude: 140.7646, Longitude: -73.9744, AirportCode: "JFK"},
+	} {
+		_, ok := h.AirportDistanceKm()
+		assert.False(t, ok, name)
+	}
+}
+
+func TestContactInfoNormalizeNumbers(t *testing.T) {
+	contact := ContactInfo{Phone: "020 7946 0958", Fax: "n/a"}
+
+	contact.NormalizeNumbers("gb")
+
+	assert.Equal(t, "+442079460958", contact.PhoneE164)
+	assert.Empty(t, contact.FaxE164)
+}
+
+func TestHasCoordinates(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  bool
+	}{
+		{"paris", 48.8566, 2.3522, true},
+		{"on the equator", 0, 32.58, true},
+		{"on the prime meridian", 51.4779, 0, true},
+		{"just outside null island", 0.0011, 0, true},
+		{"poles and antimeridian", -90, 180, true},
+		{"null island", 0, 0, false},
+		{"next to null island", 0.0009, -0.0009, false},
+		{"latitude out of range", 90.01, 10, false},
+		{"longitude out of range", 10, -180.01, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Hotel{Latitude: tt.latitude, Longitude: tt.longitude}
+			assert.Equal(t, tt.expected, h.HasCoordinates())
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package hotel
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch   = errors.New("invalid hotel patch")
+	ErrImmutableField = errors.New("field cannot be modified")
+)
+
+// patchableFieldValues read the fields a Patch can change, by their JSON name.
+var patchableFieldValues = map[string]func(h *Hotel) any{
+	"name":          func(h *Hotel) any { return h.Name },
+	"description":   func(h *Hotel) any { return h.Description },
+	"rating":        func(h *Hotel) any { return h.Rating },
+	"star_rating":   func(h *Hotel) any { return h.StarRating },
+	"parking":       func(h *Hotel) any { return h.Parking },
+	"child_allowed": func(h *Hotel) any { return h.ChildAllowed },
+	"pets_allowed":  func(h *Hotel) any { return h.PetsAllowed },
+}
+
+// immutableFields identify the hotel and are rejected even though Patch would ignore them.
+var immutableFields = []string{"hotel_id", "cupid_id"}
+
+// Patch is a JSON merge patch (RFC 7396) restricted to the fields that can be corrected by
+// hand. Nil fields are left unchanged.
+type Patch struct {
+	Name         *string  `json:"name,omitempty"`
+	Description  *string  `json:"description,omitempty"`
+	Rating       *float64 `json:"rating,omitempty"`
+	StarRating   *int32   `json:"star_rating,omitempty"`
+	Parking      *string  `json:"parking,omitempty"`
+	ChildAllowed *bool    `json:"child_allowed,omitempty"`
+	PetsAllowed  *bool    `json:"pets_allowed,omitempty"`
+}
+
+// ParsePatch decodes a merge patch document. Unknown and immutable fields are rejected; null
+// clears description and parking and is rejected for the other fields.
+func ParsePatch(data []byte) (*Patch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidPatch)
+	}
+
+	for _, field := range immutableFields {
+		if _, ok := fields[field]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrImmutableField, field)
+		}
+	}
+
+	patch := &Patch{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patch); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for field, value := range fields {
+		if !bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			continue
+		}
+		switch field {
+		case "description":
+			patch.Description = new(string)
+		case "parking":
+			patch.Parking = new(string)
+		default:
+			return nil, fmt.Errorf("%w: %s cannot be null", ErrInvalidPatch, field)
+		}
+	}
+
+	if patch.Name != nil {
+		*patch.Name = strings.TrimSpace(*patch.Name)
+	}
+
+	if err := patch.validate(); err != nil {
+		return nil, err
+	}
+
+	return patch, nil
+}
+
+func (p *Patch) validate() error {
+	if p.Name != nil && *p.Name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidPatch)
+	}
+	if p.Rating != nil && (*p.Rating < 0 || *p.Rating > 5) {
+		return fmt.Errorf("%w: rating must be between 0 and 5", ErrInvalidPatch)
+	}
+	if p.StarRating != nil && (*p.StarRating < 0 || *p.StarRating > 5) {
+		return fmt.Errorf("%w: star_rating must be between 0 and 5", ErrInvalidPatch)
+	}
+	return nil
+}
+
+// Apply writes the patched fields to h and returns the names of the fields whose value changed.
+func (p *Patch) Apply(h *Hotel) []string {
+	changed := make([]string, 0)
+
+	if p.Name != nil && *p.Name != h.Name {
+		h.Name = *p.Name
+		changed = append(changed, "name")
+	}
+	if p.Description != nil && *p.Description != h.Description {
+		h.Description = *p.Description
+		changed = append(changed, "description")
+	}
+	if p.Rating != nil && *p.Rating != h.Rating {
+		h.Rating = *p.Rating
+		changed = append(changed, "rating")
+	}
+	if p.StarRating != nil && *p.StarRating != h.StarRating {
+		h.StarRating = *p.StarRating
+		changed = append(changed, "star_rating")
+	}
+	if p.Parking != nil && *p.Parking != h.Parking {
+		h.Parking = *p.Parking
+		changed = append(changed, "parking")
+	}
+	if p.ChildAllowed != nil && *p.ChildAllowed != h.ChildAllowed {
+		h.ChildAllowed = *p.ChildAllowed
+		changed = append(changed, "child_allowed")
+	}
+	if p.PetsAllowed != nil && *p.PetsAllowed != h.PetsAllowed {
+		h.PetsAllowed = *p.PetsAllowed
+		changed = append(changed, "pets_allowed")
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// PatchChanges returns the old and new value of each of the fields Apply changed, reading them
+// from the hotel before and after the patch.
+func PatchChanges(before, after *Hotel, fields []string) map[string]FieldChange {
+	changes := make(map[string]FieldChange, len(fields))
+	for _, field := range fields {
+		value, ok := patchableFieldValues[field]
+		if !ok {
+			continue
+		}
+		changes[field] = FieldChange{Old: value(before), New: value(after)}
+	}
+	return changes
+}
@@ -9,17 +9,79 @@ type Repository interface {
 	FindByHotelID(ctx context.Context, hotelID int64) (*Hotel, error)
 	Save(ctx context.Context, hotel *Hotel) error
 	Update(ctx context.Context, hotel *Hotel) error
-	FindAll(ctx context.Context, limit, offset int) ([]*Hotel, error)
+	// UpdateWithChange stores hotel and records change in one transaction, so a manual
+	// correction is never stored without its audit entry.
+	UpdateWithChange(ctx context.Context, hotel *Hotel, change Change) error
+	FindAll(ctx context.Context, limit, offset int, filter ...FindFilter) ([]*Hotel, error)
 	FindUpdatedAfter(ctx context.Context, timestamp time.Time) ([]*Hotel, error)
+	FindAfterHotelID(ctx context.Context, afterHotelID int64, limit int) ([]*Hotel, error)
+	FindByHotelIDs(ctx context.Context, hotelIDs []int64) ([]*Hotel, error)
+	FindBySourceID(ctx context.Context, source, sourceID string) ([]*Hotel, error)
+	// FindRemovedHotelIDsAfter returns the hotels marked removed upstream after timestamp.
+	FindRemovedHotelIDsAfter(ctx context.Context, timestamp time.Time) ([]int64, error)
+	FindActiveVersionsAfter(ctx context.Context, afterHotelID int64, limit int) ([]Version, error)
+	CountHotels(ctx context.Context, estimate bool) (int64, error)
+	CountReviews(ctx context.Context, estimate bool) (int64, error)
+	CountTranslations(ctx context.Context, estimate bool) (int64, error)
+	CountHotelsUpdatedAfter(ctx context.Context, timestamp time.Time) (int64, error)
+	GetDistinctCities(ctx context.Context, prefix string, limit int) ([]CityResult, error)
+	FindTranslation(ctx context.Context, hotelID int64, lang string) (*Translation, error)
+	ListTranslations(ctx context.Context, hotelID int64) ([]TranslationSummary, error)
+	SaveTranslation(ctx context.Context, translation *Translation) error
+	ListReviews(ctx context.Context, hotelID int64, options ListReviewsOptions) ([]Review, error)
+	ListChanges(ctx context.Context, hotelID int64, options ListChangesOptions) ([]Change, error)
+	// CountAmenities counts the active hotels listing each canonical facility slug.
+	CountAmenities(ctx context.Context) ([]FacilityCount, error)
+	// CountRawFacilities counts the active hotels listing each facility name as Cupid sent it.
+	CountRawFacilities(ctx context.Context) ([]FacilityCount, error)
+	// NormalizeFacilities sets the slug of every facility of the next hotelLimit active hotels
+	// after afterHotelID to normalize(name), and their amenities to the distinct slugs, in one
+	// transaction.
+	NormalizeFacilities(ctx context.Context, afterHotelID int64, hotelLimit int, normalize func(name string) string) (FacilityNormalizeBatch, error)
+	// ArchiveReviews applies retention to the next hotelLimit hotels after afterHotelID,
+	// moving their expired reviews to the archive in one transaction.
+	ArchiveReviews(ctx context.Context, afterHotelID int64, hotelLimit int, retention ReviewRetention) (ReviewArchiveBatch, error)
+	// DeduplicateReviews merges the reviews with the same content fingerprint of the next
+	// hotelLimit hotels after afterHotelID, keeping the oldest, in one transaction.
+	DeduplicateReviews(ctx context.Context, afterHotelID int64, hotelLimit int) (ReviewDedupBatch, error)
+	// FindDuplicates groups the active hotels that look like the same property listed more
+	// than once.
+	FindDuplicates(ctx context.Context, criteria DuplicateCriteria) ([]DuplicateGroup, error)
+	// MergeHotels moves the reviews and translations of duplicateID to primaryID and
+	// soft-deletes duplicateID, in one transaction.
+	MergeHotels(ctx context.Context, primaryID, duplicateID int64) (MergeResult, error)
 	Delete(ctx context.Context, id string) error
 }
 
+// FindFilter narrows FindAll to a subset of hotels. Empty fields are ignored.
+type FindFilter struct {
+	Chain string
+}
+
+// Version identifies the revision of a hotel by the time it was last updated.
+type Version struct {
+	HotelID   int64
+	UpdatedAt time.Time
+}
+
+// CityResult is a city with the number of active hotels located in it.
+type CityResult struct {
+	City       string
+	Country    string
+	HotelCount int
+}
+
 type Provider interface {
 	GetHotelByID(ctx context.Context, hotelID int64) (*Hotel, error)
 	GetHotelReviews(ctx context.Context, hotelID int64, reviewsCount int) ([]*Review, error)
 	GetHotelTranslations(ctx context.Context, hotelID int64, languages []string) ([]*Translation, error)
 }
 
+// Counter is an atomic counter shared by every instance of the service.
+type Counter interface {
+	Increment(ctx context.Context, key string) (int64, error)
+}
+
 type CacheRepository interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
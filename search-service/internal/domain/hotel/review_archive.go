@@ -0,0 +1,34 @@
+package hotel
+
+import "time"
+
+// ReviewRetention decides which reviews stay in the reviews table. The newest KeepNewest
+// reviews of a hotel are always kept; older ones are archived once they are older than MinAge.
+type ReviewRetention struct {
+	KeepNewest int
+	MinAge     time.Duration
+}
+
+// ReviewArchiveBatch reports one batch of archival. LastHotelID is the keyset cursor for the
+// next batch and is zero when no hotels were left.
+type ReviewArchiveBatch struct {
+	LastHotelID     int64
+	Hotels          int
+	ArchivedReviews int64
+}
+
+// ReviewDedupBatch reports one batch of review deduplication. Fingerprinted counts the reviews
+// stored before fingerprints existed that got one; MergedReviews the duplicates soft-deleted.
+type ReviewDedupBatch struct {
+	LastHotelID   int64
+	Hotels        int
+	Fingerprinted int64
+	MergedReviews int64
+}
+
+// ListReviewsOptions pages through the reviews of a hotel, most recent first.
+type ListReviewsOptions struct {
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
@@ -0,0 +1,52 @@
+package hotel
+
+// ScoreBreakdown holds the average score of each review category. A category is nil when no
+// review rated it.
+type ScoreBreakdown struct {
+	Location   *float64 `json:"location"`
+	Service    *float64 `json:"service"`
+	Value      *float64 `json:"value"`
+	Facilities *float64 `json:"facilities"`
+}
+
+type ReviewStats struct {
+	HotelID        int64          `json:"hotel_id"`
+	ReviewCount    int            `json:"review_count"`
+	AverageScore   *float64       `json:"average_score"`
+	ScoreBreakdown ScoreBreakdown `json:"review_score_breakdown"`
+}
+
+// ReviewScoreBreakdown averages each category score across the hotel's reviews. Zero scores
+// mean the review did not rate the category and are left out of the average.
+func (h *Hotel) ReviewScoreBreakdown() ScoreBreakdown {
+	return ScoreBreakdown{
+		Location:   averageScore(h.Reviews, func(r Review) int32 { return r.ScoreLocation }),
+		Service:    averageScore(h.Reviews, func(r Review) int32 { return r.ScoreService }),
+		Value:      averageScore(h.Reviews, func(r Review) int32 { return r.ScoreValue }),
+		Facilities: averageScore(h.Reviews, func(r Review) int32 { return r.ScoreFacilities }),
+	}
+}
+
+func (h *Hotel) ReviewStats() ReviewStats {
+	return ReviewStats{
+		HotelID:        h.HotelID,
+		ReviewCount:    len(h.Reviews),
+		AverageScore:   averageScore(h.Reviews, func(r Review) int32 { return r.AverageScore }),
+		ScoreBreakdown: h.ReviewScoreBreakdown(),
+	}
+}
+
+func averageScore(reviews []Review, score func(Review) int32) *float64 {
+	var sum, count int64
+	for _, review := range reviews {
+		if s := score(review); s > 0 {
+			sum += int64(s)
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	average := float64(sum) / float64(count)
+	return &average
+}
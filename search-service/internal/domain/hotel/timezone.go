@@ -0,0 +1,36 @@
+package hotel
+
+import (
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/timezones"
+)
+
+// TimezoneName is the IANA timezone of the hotel, the stored one or else the one its
+// coordinates fall in. It is empty when the hotel has neither.
+func (h *Hotel) TimezoneName() string {
+	if h.Timezone != "" {
+		return h.Timezone
+	}
+	if !h.HasCoordinates() {
+		return ""
+	}
+	name, _ := timezones.Lookup(h.Latitude, h.Longitude)
+	return name
+}
+
+// LocalCheckinTime is today's check-in start at the hotel, in the hotel's timezone, so that
+// 14:00 at a Tokyo hotel reads as 14:00 JST. Hotels without a timezone are taken to be on UTC.
+// It is zero when the hotel has no check-in start.
+func (h *Hotel) LocalCheckinTime() time.Time {
+	return localClockTime(h.CheckinInfo.CheckinStart, timezones.Load(h.TimezoneName()), time.Now())
+}
+
+// localClockTime places the clock time of t on the day now falls on in location.
+func localClockTime(t time.Time, location *time.Location, now time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+	year, month, day := now.In(location).Date()
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, location)
+}
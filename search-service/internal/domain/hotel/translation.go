@@ -0,0 +1,51 @@
+package hotel
+
+// TranslationSummary describes one available translation of a hotel without its full content.
+type TranslationSummary struct {
+	Lang               string `json:"lang"`
+	Name               string `json:"name"`
+	DescriptionSnippet string `json:"description_snippet"`
+}
+
+// Localize returns a copy of the hotel with the translated fields of t overlaid. A field falls
+// back to the default language when the translation leaves it empty. The returned slices name
+// the overlaid and the fallback fields.
+func (h Hotel) Localize(t Translation) (localized Hotel, translated []string, fallback []string) {
+	localized = h
+	localized.Translations = nil
+	translated = make([]string, 0)
+	fallback = make([]string, 0)
+
+	overlay := func(field string, present bool, apply func()) {
+		if present {
+			apply()
+			translated = append(translated, field)
+			return
+		}
+		fallback = append(fallback, field)
+	}
+
+	overlay("name", t.Name != "", func() { localized.Name = t.Name })
+	overlay("description", t.Description != "", func() { localized.Description = t.Description })
+	overlay("markdown_description", t.MarkdownDescription != "", func() { localized.MarkdownDescription = t.MarkdownDescription })
+	overlay("important_info", t.ImportantInfo != "", func() { localized.ImportantInfo = t.ImportantInfo })
+	overlay("chain", t.Chain != "", func() { localized.Chain = t.Chain })
+	overlay("parking", t.Parking != "", func() { localized.Parking = t.Parking })
+	overlay("address", t.Address != (Address{}), func() { localized.Address = t.Address })
+	overlay("policies", len(t.Policies) > 0, func() { localized.Policies = t.Policies })
+	overlay("checkin_info", t.CheckinInfo.hasText(), func() {
+		localized.CheckinInfo.Instructions = t.CheckinInfo.Instructions
+		localized.CheckinInfo.SpecialInstructions = t.CheckinInfo.SpecialInstructions
+	})
+	overlay("photos", len(t.Photos) > 0, func() { localized.Photos = t.Photos })
+	overlay("facilities", len(t.Facilities) > 0, func() { localized.Facilities = t.Facilities })
+	overlay("rooms", len(t.Rooms) > 0, func() { localized.Rooms = t.Rooms })
+
+	return localized, translated, fallback
+}
+
+// hasText reports whether the check-in info carries any language-dependent text. Times are the
+// same in every language and always come from the base hotel.
+func (c CheckinInfo) hasText() bool {
+	return len(c.Instructions) > 0 || c.SpecialInstructions != ""
+}
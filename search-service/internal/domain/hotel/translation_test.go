@@ -0,0 +1,48 @@
+package hotel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizeKeepsCheckinTimesFromBaseHotel(t *testing.T) {
+	base := Hotel{
+		Name: "Harbour Hotel",
+		CheckinInfo: CheckinInfo{
+			CheckinStart: clock(15, 0),
+			Instructions: []string{"Collect keys at reception"},
+		},
+		Translations: []Translation{{Lang: "fr"}},
+	}
+
+	localized, translated, fallback := base.Localize(Translation{
+		CheckinInfo: CheckinInfo{
+			CheckinStart: clock(9, 0),
+			Instructions: []string{"Récupérez les clés à la réception"},
+		},
+	})
+
+	assert.Equal(t, clock(15, 0), localized.CheckinInfo.CheckinStart, "times are not language-dependent")
+	assert.Equal(t, []string{"Récupérez les clés à la réception"}, localized.CheckinInfo.Instructions)
+	assert.Equal(t, []string{"checkin_info"}, translated)
+	assert.Contains(t, fallback, "name")
+	assert.Equal(t, "Harbour Hotel", localized.Name)
+	assert.Nil(t, localized.Translations, "the localized document carries no other languages")
+	assert.Len(t, base.Translations, 1, "the base hotel is not modified")
+}
+
+func TestLocalizeEmptyTranslationFallsBackEverywhere(t *testing.T) {
+	_, translated, fallback := Hotel{Name: "Harbour Hotel"}.Localize(Translation{})
+
+	assert.Empty(t, translated)
+	assert.Equal(t, []string{
+		"name", "description", "markdown_description", "important_info", "chain", "parking",
+		"address", "policies", "checkin_info", "photos", "facilities", "rooms",
+	}, fallback)
+}
+
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,45 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Status is the sync leadership as seen by one replica.
+type Status struct {
+	Replica string `json:"replica"`
+	// Leader is the replica holding the leadership, empty when it has lapsed.
+	Leader   string `json:"leader,omitempty"`
+	IsLeader bool   `json:"is_leader"`
+	// Elected is false when every replica runs the syncs without an election.
+	Elected bool `json:"elected"`
+}
+
+// Elector decides whether this replica runs the scheduled syncs.
+type Elector interface {
+	IsLeader() bool
+	Status(ctx context.Context) Status
+}
+
+// Always is the Elector of single-instance deployments: the replica always leads.
+type Always struct {
+	Replica string
+}
+
+func (a Always) IsLeader() bool {
+	return true
+}
+
+func (a Always) Status(_ context.Context) Status {
+	return Status{Replica: a.Replica, Leader: a.Replica, IsLeader: true}
+}
+
+// ReplicaID identifies this process among the replicas of the service.
+func ReplicaID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "search-service"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,22 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// Stats is the backlog of the fetcher pipeline that fills the hotels table. A deep main queue
+// explains data that is stale in the database, not just in the index.
+type Stats struct {
+	MainDepth int64 `json:"main_depth"`
+	// DelayDepth counts jobs waiting out a retry delay; nil when the orchestrator has no delay
+	// queue to report.
+	DelayDepth *int64 `json:"delay_depth,omitempty"`
+	// DLQDepth is nil when the orchestrator has no dead letter queue to report.
+	DLQDepth      *int64     `json:"dlq_depth,omitempty"`
+	LastEnqueueAt *time.Time `json:"last_enqueue_at,omitempty"`
+}
+
+type StatsProvider interface {
+	PipelineStats(ctx context.Context) (*Stats, error)
+}
@@ -0,0 +1,16 @@
+package search
+
+import (
+	"math"
+
+	"github.com/victoragudo/hotel-management-system/pkg/geo"
+)
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula.
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	return geo.Haversine(lat1, lng1, lat2, lng2)
+}
+
+func RoundDistanceKm(distance float64) float64 {
+	return math.Round(distance*10) / 10
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Language is a translation language whose hotel names are indexed for search. Locale is the
+// Typesense locale used to tokenize the language's fields; empty keeps the default tokenizer,
+// which splits on spaces and strips diacritics and is only suited to Latin scripts.
+type Language struct {
+	Code   string
+	Locale string
+}
+
+// NameField is the index field holding the hotel name translated into the language.
+func (l Language) NameField() string {
+	return "name_" + l.Code
+}
+
+func (l Language) Validate() error {
+	if l.Code == "" {
+		return fmt.Errorf("language code is required")
+	}
+	for _, r := range l.Code {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return fmt.Errorf("language code %q must be lowercase letters", l.Code)
+		}
+	}
+	return nil
+}
+
+// unsegmentedScripts are written without spaces between words.
+var unsegmentedScripts = []*unicode.RangeTable{
+	unicode.Han,
+	unicode.Hiragana,
+	unicode.Katakana,
+	unicode.Thai,
+	unicode.Lao,
+	unicode.Khmer,
+	unicode.Myanmar,
+}
+
+// IsSpaceDelimited reports whether text separates its words with spaces, which is false as
+// soon as it contains a script written without them, such as Japanese or Chinese.
+func IsSpaceDelimited(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unsegmentedScripts...) {
+			return false
+		}
+	}
+	return true
+}
+
+// SuggestionScore rates how well text completes query: 1 when text starts with the query and
+// 0.8 when one of its words does. Text without spaces between words has no word boundaries to
+// match, so any substring match scores 0.8 instead. Text that does not contain the query, as
+// with typo matches, scores 0.5.
+func SuggestionScore(text, query string) float64 {
+	text = strings.ToLower(strings.TrimSpace(text))
+	query = strings.ToLower(strings.TrimSpace(query))
+	if text == "" || query == "" {
+		return 0
+	}
+
+	switch {
+	case strings.HasPrefix(text, query):
+		return 1
+	case !IsSpaceDelimited(text) || !IsSpaceDelimited(query):
+		if strings.Contains(text, query) {
+			return 0.8
+		}
+	case strings.Contains(" "+strings.Join(strings.Fields(text), " "), " "+query):
+		return 0.8
+	}
+
+	return 0.5
+}
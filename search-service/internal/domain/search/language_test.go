@@ -0,0 +1,38 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSpaceDelimited(t *testing.T) {
+	assert.True(t, IsSpaceDelimited("Hotel Le Marais"))
+	assert.True(t, IsSpaceDelimited("فندق القصر دبي"), "Arabic separates words with spaces")
+	assert.False(t, IsSpaceDelimited("東京ステーションホテル"))
+	assert.False(t, IsSpaceDelimited("Hotel 東京"))
+}
+
+func TestSuggestionScore(t *testing.T) {
+	tests := []struct {
+		text, query string
+		expected    float64
+	}{
+		{text: "Hotel Le Marais", query: "hotel", expected: 1},
+		{text: "Hotel Le Marais", query: "mar", expected: 0.8},
+		{text: "Hotel Le Marais", query: "arais", expected: 0.5},
+		{text: "東京ステーションホテル", query: "東京", expected: 1},
+		{text: "東京ステーションホテル", query: "ホテル", expected: 0.8},
+		{text: "東京ステーションホテル", query: "大阪", expected: 0.5},
+		{text: "فندق القصر دبي", query: "القصر", expected: 0.8},
+		{text: "فندق القصر دبي", query: "صر", expected: 0.5},
+		{text: "", query: "hotel", expected: 0},
+		{text: "Hotel", query: " ", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SuggestionScore(tt.text, tt.query))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrOverloaded = errors.New("search engine overloaded")
+
+// OverloadedError is returned when a request is rejected because the search engine is unhealthy.
+type OverloadedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *OverloadedError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrOverloaded, e.RetryAfter)
+}
+
+func (e *OverloadedError) Unwrap() error {
+	return ErrOverloaded
+}
+
+type LoadStatus struct {
+	Enabled       bool          `json:"enabled"`
+	Shedding      bool          `json:"shedding"`
+	Samples       int           `json:"samples"`
+	ErrorRate     float64       `json:"error_rate"`
+	P95Latency    time.Duration `json:"p95_latency"`
+	SheddingSince *time.Time    `json:"shedding_since,omitempty"`
+	ShedRequests  int64         `json:"shed_requests"`
+}
+
+// MarshalJSON writes P95Latency as a duration string and as p95_latency_ms.
+func (s LoadStatus) MarshalJSON() ([]byte, error) {
+	type loadStatus LoadStatus
+	return json.Marshal(struct {
+		loadStatus
+		P95Latency   string `json:"p95_latency"`
+		P95LatencyMs int64  `json:"p95_latency_ms"`
+	}{loadStatus(s), s.P95Latency.String(), s.P95Latency.Milliseconds()})
+}
+
+// LoadMonitor decides whether new engine calls should be rejected to protect the service.
+type LoadMonitor interface {
+	ShouldShed() (bool, time.Duration)
+	RecordShed()
+	LoadStatus() LoadStatus
+}
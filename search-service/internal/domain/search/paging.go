@@ -0,0 +1,82 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+var (
+	ErrPageOutOfRange  = errors.New("page out of range")
+	ErrPerPageTooLarge = errors.New("per_page too large")
+)
+
+// Capabilities are the paging limits of a search engine.
+type Capabilities struct {
+	// MaxPerPage is the largest page the engine returns.
+	MaxPerPage int `json:"max_per_page"`
+	// MaxResultWindow is how deep results can be paged: page*limit may not exceed it.
+	MaxResultWindow int `json:"max_result_window"`
+}
+
+// MaxPage is the deepest page the engine serves with pages of limit hits.
+func (c Capabilities) MaxPage(limit int) int {
+	if limit <= 0 || c.MaxResultWindow <= 0 {
+		return 0
+	}
+	return max(c.MaxResultWindow/limit, 1)
+}
+
+// PageLimitError is returned for a page request beyond the limits of the engine. It wraps
+// ErrPageOutOfRange or ErrPerPageTooLarge.
+type PageLimitError struct {
+	Err        error
+	Page       int
+	MaxPage    int
+	MaxPerPage int
+}
+
+func (e *PageLimitError) Error() string {
+	if errors.Is(e.Err, ErrPerPageTooLarge) {
+		return fmt.Sprintf("%s: at most %d results can be returned per page", e.Err, e.MaxPerPage)
+	}
+	return fmt.Sprintf("%s: page %d requested, the last page is %d", e.Err, e.Page, e.MaxPage)
+}
+
+func (e *PageLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Code identifies the error in API responses.
+func (e *PageLimitError) Code() string {
+	if errors.Is(e.Err, ErrPerPageTooLarge) {
+		return "per_page_too_large"
+	}
+	return "page_out_of_range"
+}
+
+// ApplyCapabilities caps the page size at what the engine returns and rejects pages deeper than
+// it serves, which it would otherwise fail with an engine-specific error.
+func (p *Params) ApplyCapabilities(capabilities Capabilities) error {
+	if capabilities.MaxPerPage > 0 && p.Limit > capabilities.MaxPerPage {
+		p.Limit = capabilities.MaxPerPage
+	}
+	if maxPage := capabilities.MaxPage(p.Limit); maxPage > 0 && p.Page > maxPage {
+		return &PageLimitError{Err: ErrPageOutOfRange, Page: p.Page, MaxPage: maxPage, MaxPerPage: capabilities.MaxPerPage}
+	}
+	return nil
+}
+
+// ApplyCapabilities sets MaxPage, the last page that has results and that the engine serves. A
+// page past the results is empty rather than an error: the hits may have shrunk since the
+// client computed it. The first page is always served, even when nothing matched.
+func (r *Result) ApplyCapabilities(capabilities Capabilities) {
+	r.MaxPage = max(r.TotalPages, 1)
+	if maxPage := capabilities.MaxPage(r.Limit); maxPage > 0 {
+		r.MaxPage = min(r.MaxPage, maxPage)
+	}
+	if r.Page > r.MaxPage {
+		r.Hotels = []*hotel.Hotel{}
+	}
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+var testCapabilities = Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}
+
+func TestParamsApplyCapabilitiesClampsThePageSize(t *testing.T) {
+	params := Params{Page: 2, Limit: 400}
+
+	require.NoError(t, params.ApplyCapabilities(testCapabilities))
+	assert.Equal(t, 250, params.Limit)
+}
+
+func TestParamsApplyCapabilitiesRejectsPagesPastTheResultWindow(t *testing.T) {
+	params := Params{Page: 300, Limit: 100}
+
+	err := params.ApplyCapabilities(testCapabilities)
+
+	var pageLimitErr *PageLimitError
+	require.ErrorAs(t, err, &pageLimitErr)
+	assert.True(t, errors.Is(err, ErrPageOutOfRange))
+	assert.Equal(t, "page_out_of_range", pageLimitErr.Code())
+	assert.Equal(t, 100, pageLimitErr.MaxPage)
+	assert.Equal(t, 250, pageLimitErr.MaxPerPage)
+
+	params = Params{Page: 100, Limit: 100}
+	assert.NoError(t, params.ApplyCapabilities(testCapabilities))
+}
+
+func TestResultApplyCapabilitiesServesAnEmptyPagePastTheResults(t *testing.T) {
+	result := Result{Page: 5, Limit: 20, TotalHits: 30, Hotels: []*hotel.Hotel{{HotelID: 1}}}
+	result.CalculateTotalPages()
+
+	result.ApplyCapabilities(testCapabilities)
+
+	assert.Equal(t, 2, result.MaxPage)
+	assert.NotNil(t, result.Hotels)
+	assert.Empty(t, result.Hotels)
+}
+
+func TestResultApplyCapabilitiesLimitsMaxPageToTheResultWindow(t *testing.T) {
+	result := Result{Page: 3, Limit: 100, TotalHits: 50000, Hotels: []*hotel.Hotel{{HotelID: 1}}}
+	result.CalculateTotalPages()
+
+	result.ApplyCapabilities(testCapabilities)
+
+	assert.Equal(t, 500, result.TotalPages)
+	assert.Equal(t, 100, result.MaxPage)
+	assert.Len(t, result.Hotels, 1)
+}
+
+func TestResultApplyCapabilitiesServesTheFirstPageWithoutHits(t *testing.T) {
+	result := Result{Page: 1, Limit: 20}
+	result.CalculateTotalPages()
+
+	result.ApplyCapabilities(testCapabilities)
+
+	assert.Equal(t, 1, result.MaxPage)
+}
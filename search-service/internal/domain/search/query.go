@@ -0,0 +1,71 @@
+package search
+
+import "strings"
+
+// Ranking profiles select how text queries are matched and ordered. RankingProfileRelevance
+// matches quoted phrases and boosts name matches; RankingProfileClassic keeps the original
+// behavior of sorting by rating unless sort_by is given.
+const (
+	RankingProfileRelevance = "relevance"
+	RankingProfileClassic   = "classic"
+)
+
+const DefaultRankingProfile = RankingProfileRelevance
+
+func IsRankingProfile(profile string) bool {
+	return profile == RankingProfileRelevance || profile == RankingProfileClassic
+}
+
+// ParsedQuery is a text query split into quoted phrases and the remaining free terms.
+type ParsedQuery struct {
+	Phrases []string
+	Terms   []string
+}
+
+// ParseQuery extracts the double-quoted phrases from q. An unterminated quote is treated as
+// plain terms, and phrases of a single word are kept as terms.
+func ParseQuery(q string) ParsedQuery {
+	var parsed ParsedQuery
+
+	rest := q
+	for {
+		before, after, found := strings.Cut(rest, `"`)
+		if !found {
+			parsed.Terms = append(parsed.Terms, strings.Fields(before)...)
+			break
+		}
+		parsed.Terms = append(parsed.Terms, strings.Fields(before)...)
+
+		phrase, remaining, closed := strings.Cut(after, `"`)
+		if !closed {
+			parsed.Terms = append(parsed.Terms, strings.Fields(after)...)
+			break
+		}
+
+		words := strings.Fields(phrase)
+		switch len(words) {
+		case 0:
+		case 1:
+			parsed.Terms = append(parsed.Terms, words[0])
+		default:
+			parsed.Phrases = append(parsed.Phrases, strings.Join(words, " "))
+		}
+		rest = remaining
+	}
+
+	return parsed
+}
+
+func (q ParsedQuery) HasPhrases() bool {
+	return len(q.Phrases) > 0
+}
+
+// String renders the query with normalized whitespace and every phrase quoted.
+func (q ParsedQuery) String() string {
+	parts := make([]string, 0, len(q.Phrases)+len(q.Terms))
+	for _, phrase := range q.Phrases {
+		parts = append(parts, `"`+phrase+`"`)
+	}
+	parts = append(parts, q.Terms...)
+	return strings.Join(parts, " ")
+}
@@ -2,6 +2,15 @@ package search
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
@@ -26,10 +35,6 @@ type Params struct {
 	ChildAllowed *bool    `json:"child_allowed,omitempty"`
 	PetsAllowed  *bool    `json:"pets_allowed,omitempty"`
 	Amenities    []string `json:"amenities,omitempty"`
-	Tags         []string `json:"tags,omitempty"`
-	PriceMin     float64  `json:"price_min,omitempty"`
-	PriceMax     float64  `json:"price_max,omitempty"`
-	Currency     string   `json:"currency,omitempty"`
 	SortBy       string   `json:"sort_by,omitempty"`
 	SortOrder    string   `json:"sort_order,omitempty"`
 	Page         int      `json:"page,omitempty"`
@@ -37,17 +42,173 @@ type Params struct {
 	Latitude     float64  `json:"latitude,omitempty"`
 	Longitude    float64  `json:"longitude,omitempty"`
 	Radius       float64  `json:"radius,omitempty"`
+
+	// GeoPolygon keeps hotels inside the polygon with these latitude/longitude vertices, such as
+	// a district. Validate closes it. It cannot be combined with a radius filter.
+	GeoPolygon [][2]float64 `json:"geo_polygon,omitempty"`
+
+	// MaxAirportDistanceKm keeps hotels within that distance of their nearest airport.
+	MaxAirportDistanceKm float64 `json:"max_airport_distance_km,omitempty"`
+
+	// MinValueScore keeps hotels whose reviews rate value for money at least that high on average.
+	MinValueScore float64 `json:"min_value_score,omitempty"`
+
+	// NumTypos is the number of typos tolerated per query token, at most MaxNumTypos. Nil keeps
+	// the search engine default.
+	NumTypos *int `json:"num_typos,omitempty"`
+
+	// AmenityWeights ranks hotels by the weighted sum of the amenities they have. Weights are
+	// between 0 and 1, keyed by amenity name.
+	AmenityWeights map[string]float64 `json:"amenity_weights,omitempty"`
+
+	// ArrivalTime restricts results to hotels whose check-in window is still open at that
+	// time. Hotels without check-in information are kept unless StrictCheckin is set.
+	ArrivalTime    string `json:"arrival_time,omitempty"`
+	StrictCheckin  bool   `json:"strict_checkin,omitempty"`
+	ArrivalMinutes int    `json:"-"`
+
+	// RankingProfile is one of the RankingProfile constants, DefaultRankingProfile when empty.
+	RankingProfile string `json:"ranking_profile,omitempty"`
+
+	// Fields lists optional result fields to include, e.g. FieldDescription.
+	Fields []string `json:"fields,omitempty"`
+
+	// CreatedAfter, CreatedBefore, UpdatedAfter and UpdatedBefore keep hotels indexed or
+	// last updated within the range, bounds included.
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+
+	// MinRoomTypes and MinTotalCapacity keep hotels with at least that many room types, or
+	// whose room types host at least that many guests in total.
+	MinRoomTypes     *int32 `json:"min_room_types,omitempty"`
+	MinTotalCapacity *int32 `json:"min_total_capacity,omitempty"`
+
+	// MaxMinNights keeps hotels whose minimum stay is at most that many nights, so a stay of N
+	// nights is bookable. Hotels without a minimum stay always pass.
+	MaxMinNights *int32 `json:"max_min_nights,omitempty"`
+
+	// RequiredLanguages keeps hotels whose content is available in every listed language.
+	RequiredLanguages []string `json:"required_languages,omitempty"`
+
+	// NearAttraction keeps hotels near an attraction of that category, such as beach or museum,
+	// or near an attraction with exactly that name.
+	NearAttraction string `json:"near_attraction,omitempty"`
+
+	// PolicyKeyword keeps hotels whose policy summary mentions the keyword, such as parking or
+	// "free parking".
+	PolicyKeyword string `json:"policy_keyword,omitempty"`
+
+	// Diversify reorders each page of relevance-ranked results so that no more than
+	// MaxSameChain consecutive hotels belong to the same chain.
+	Diversify    bool `json:"diversify,omitempty"`
+	MaxSameChain int  `json:"max_same_chain,omitempty"`
+
+	// Locale is the searcher's market, which buckets the query for trending lists. It does not
+	// change the results.
+	Locale string `json:"-"`
+}
+
+func (p Params) IncludesField(field string) bool {
+	return slices.Contains(p.Fields, field)
 }
 
+const MaxAmenityWeights = 10
+
+// MaxNumTypos is the highest typo tolerance the search engine supports.
+const MaxNumTypos = 2
+
+// ErrInvalidParams wraps the errors of Params.Validate returned by the search use cases.
+var ErrInvalidParams = errors.New("invalid search parameters")
+
+var ErrInvalidArrivalTime = errors.New("invalid arrival_time")
+
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+var ErrInvalidGeoPolygon = errors.New("invalid geo_polygon")
+
+// DefaultMaxSameChain is the run of same-chain hotels a diversified search allows by default.
+const DefaultMaxSameChain = 2
+
+// MinGeoPolygonVertices is the number of distinct vertices a polygon needs to enclose an area.
+const MinGeoPolygonVertices = 3
+
 type Result struct {
 	Hotels         []*hotel.Hotel `json:"hotels"`
 	TotalHits      int64          `json:"total_hits"`
 	Page           int            `json:"page"`
 	Limit          int            `json:"limit"`
 	TotalPages     int            `json:"total_pages"`
+	MaxPage        int            `json:"max_page"`
 	ProcessingTime time.Duration  `json:"processing_time"`
-	Facets         *Facets        `json:"facets,omitempty"`
-	Query          string         `json:"query,omitempty"`
+	// SearchEngineTime is the time the search engine reports spending on the query, zero when
+	// the result did not come from the engine. ProcessingTime minus SearchEngineTime is the
+	// network, cache and serialization overhead.
+	SearchEngineTime time.Duration `json:"search_engine_time"`
+	Facets           *Facets       `json:"facets,omitempty"`
+	Query            string        `json:"query,omitempty"`
+	ReferencePoint   *GeoPoint     `json:"reference_point,omitempty"`
+	// Provenance tells where this response was served from. It is set per request and not cached.
+	Provenance Provenance `json:"-"`
+}
+
+// Where a search result was served from.
+const (
+	ServedFromCache  = "cache"
+	ServedFromEngine = "engine"
+)
+
+// Provenance tells whether a search result came from the cache or the engine, and which engine
+// and collection computed it, to debug unexpected results.
+type Provenance struct {
+	ServedFrom string
+	// CacheAge is how long ago a cached result was computed, zero when served from the engine.
+	CacheAge time.Duration
+	Engine   EngineInfo
+}
+
+// EngineInfo names a search engine and the collection or alias it searches.
+type EngineInfo struct {
+	Name       string `json:"engine"`
+	Collection string `json:"collection"`
+}
+
+// MarshalJSON writes ProcessingTime and SearchEngineTime as duration strings and in
+// milliseconds.
+func (r Result) MarshalJSON() ([]byte, error) {
+	type result Result
+	return json.Marshal(struct {
+		result
+		ProcessingTime     string `json:"processing_time"`
+		ProcessingTimeMs   int64  `json:"processing_time_ms"`
+		SearchEngineTime   string `json:"search_engine_time"`
+		SearchEngineTimeMs int64  `json:"search_engine_time_ms"`
+	}{result(r), r.ProcessingTime.String(), r.ProcessingTime.Milliseconds(),
+		r.SearchEngineTime.String(), r.SearchEngineTime.Milliseconds()})
+}
+
+// UnmarshalJSON reads results cached by MarshalJSON.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	type result Result
+	aux := struct {
+		*result
+		ProcessingTime     string `json:"processing_time"`
+		ProcessingTimeMs   int64  `json:"processing_time_ms"`
+		SearchEngineTime   string `json:"search_engine_time"`
+		SearchEngineTimeMs int64  `json:"search_engine_time_ms"`
+	}{result: (*result)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ProcessingTime = time.Duration(aux.ProcessingTimeMs) * time.Millisecond
+	r.SearchEngineTime = time.Duration(aux.SearchEngineTimeMs) * time.Millisecond
+	return nil
+}
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 type Facets struct {
@@ -58,6 +219,7 @@ type Facets struct {
 	PriceRanges  []FacetItem `json:"price_ranges,omitempty"`
 	HotelChains  []FacetItem `json:"hotel_chains,omitempty"`
 	RatingRanges []FacetItem `json:"rating_ranges,omitempty"`
+	Languages    []FacetItem `json:"languages,omitempty"`
 }
 
 type FacetItem struct {
@@ -65,35 +227,65 @@ type FacetItem struct {
 	Count int64  `json:"count"`
 }
 
+// ChainSuggestion is a hotel chain matching an autocomplete query, with the number of indexed
+// hotels in it.
+type ChainSuggestion struct {
+	Chain      string `json:"chain"`
+	HotelCount int64  `json:"hotel_count"`
+}
+
 type Suggestion struct {
 	Text     string                 `json:"text"`
 	Type     string                 `json:"type"`
 	Score    float64                `json:"score"`
 	HotelID  *int64                 `json:"hotel_id,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// CorrectedFrom is the original query when the suggestion was found for a spelling
+	// correction of it.
+	CorrectedFrom string `json:"corrected_from,omitempty"`
 }
 
 type Engine interface {
 	Index(ctx context.Context, hotels []*hotel.Hotel) error
 	Search(ctx context.Context, params Params) (*Result, error)
+	MultiSearch(ctx context.Context, params Params, suggestionQuery string, suggestionLimit int) (*Result, []*Suggestion, error)
 	GetSuggestions(ctx context.Context, query string, limit int) ([]*Suggestion, error)
 	GetFacets(ctx context.Context) (*Facets, error)
+	// GetFacetValues returns up to limit values of a facet field that match query, with their
+	// hotel counts.
+	GetFacetValues(ctx context.Context, field, query string, limit int) ([]FacetItem, error)
 	UpdateHotel(ctx context.Context, hotel *hotel.Hotel) error
+	PartialUpdate(ctx context.Context, hotelID int64, fields map[string]any) error
 	DeleteHotel(ctx context.Context, hotelID string) error
+	// ExportIDs yields the indexed hotels ordered by hotel_id. Iteration stops at the first error.
+	ExportIDs(ctx context.Context) iter.Seq2[hotel.Version, error]
 	ClearIndex(ctx context.Context) error
 	GetIndexStats(ctx context.Context) (*IndexStats, error)
 	HealthCheck(ctx context.Context) error
+	// Capabilities reports the paging limits of the engine.
+	Capabilities() Capabilities
+	// Info names the engine and the collection it searches.
+	Info() EngineInfo
 }
 
 type IndexStats struct {
 	TotalDocuments int64     `json:"total_documents"`
-	IndexSize      int64     `json:"index_size_bytes"`
 	LastUpdated    time.Time `json:"last_updated"`
 	Version        string    `json:"version"`
+
+	// DiskSizeBytes is the disk used by a search node; replicas hold the same data, so the
+	// largest node is reported. MemorySizeBytes is the memory in use summed over all nodes.
+	DiskSizeBytes   int64  `json:"disk_size_bytes"`
+	DiskSizeHuman   string `json:"disk_size_human"`
+	MemorySizeBytes int64  `json:"memory_size_bytes"`
+	MemorySizeHuman string `json:"memory_size_human"`
+
+	// NewestDocumentAt is the highest updated_at among indexed documents, nil for an empty index.
+	NewestDocumentAt *time.Time `json:"newest_document_at,omitempty"`
 }
 
 func (p *Params) Validate() error {
-	if p.Page < 0 {
+	if p.Page <= 0 {
 		p.Page = 1
 	}
 	if p.Limit <= 0 {
@@ -117,7 +309,6 @@ func (p *Params) Validate() error {
 
 	validSortFields := map[string]bool{
 		"rating":     true,
-		"price":      true,
 		"distance":   true,
 		"relevance":  true,
 		"name":       true,
@@ -131,15 +322,206 @@ func (p *Params) Validate() error {
 		p.SortOrder = "desc"
 	}
 
+	if !IsRankingProfile(p.RankingProfile) {
+		p.RankingProfile = DefaultRankingProfile
+	}
+
+	p.normalizeDefaultSort()
+
+	if p.Diversify && p.MaxSameChain <= 0 {
+		p.MaxSameChain = DefaultMaxSameChain
+	}
+
+	if len(p.AmenityWeights) > 0 {
+		p.AmenityWeights = normalizeAmenityWeights(p.AmenityWeights)
+	}
+
+	if p.ArrivalTime != "" {
+		minutes, err := ParseArrivalTime(p.ArrivalTime)
+		if err != nil {
+			return err
+		}
+		p.ArrivalMinutes = minutes
+	}
+
+	if len(p.GeoPolygon) > 0 {
+		if err := p.validateGeoPolygon(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTimeRange("created", p.CreatedAfter, p.CreatedBefore); err != nil {
+		return err
+	}
+	if err := validateTimeRange("updated", p.UpdatedAfter, p.UpdatedBefore); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func validateTimeRange(field string, after, before *time.Time) error {
+	if after != nil && before != nil && !after.Before(*before) {
+		return fmt.Errorf("%w: %s_after must be before %s_before", ErrInvalidTimeRange, field, field)
+	}
+	return nil
+}
+
+// validateGeoPolygon checks the polygon vertices and closes the polygon by repeating its first
+// vertex when the last one differs.
+func (p *Params) validateGeoPolygon() error {
+	if p.HasLocationFilter() {
+		return fmt.Errorf("%w: cannot be combined with radius", ErrInvalidGeoPolygon)
+	}
+
+	for i, vertex := range p.GeoPolygon {
+		if !validLatitude(vertex[0]) || !validLongitude(vertex[1]) {
+			return fmt.Errorf("%w: vertex %d (%v, %v) is not a valid latitude/longitude", ErrInvalidGeoPolygon, i+1, vertex[0], vertex[1])
+		}
+	}
+
+	if p.GeoPolygon[0] != p.GeoPolygon[len(p.GeoPolygon)-1] {
+		p.GeoPolygon = append(slices.Clip(p.GeoPolygon), p.GeoPolygon[0])
+	}
+	if vertices := len(p.GeoPolygon) - 1; vertices < MinGeoPolygonVertices {
+		return fmt.Errorf("%w: needs at least %d vertices, got %d", ErrInvalidGeoPolygon, MinGeoPolygonVertices, vertices)
+	}
+
+	return nil
+}
+
+func validLatitude(latitude float64) bool {
+	return latitude >= -90 && latitude <= 90
+}
+
+func validLongitude(longitude float64) bool {
+	return longitude >= -180 && longitude <= 180
+}
+
+// normalizeAmenityWeights clamps weights to [0, 1], drops amenities weighted zero and keeps the
+// MaxAmenityWeights highest weighted ones.
+func normalizeAmenityWeights(weights map[string]float64) map[string]float64 {
+	normalized := make(map[string]float64, len(weights))
+	for amenity, weight := range weights {
+		weight = math.Min(math.Max(weight, 0), 1)
+		if amenity == "" || weight == 0 || math.IsNaN(weight) {
+			continue
+		}
+		normalized[amenity] = weight
+	}
+
+	if len(normalized) > MaxAmenityWeights {
+		for _, amenity := range SortedAmenityWeights(normalized)[MaxAmenityWeights:] {
+			delete(normalized, amenity)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// SortedAmenityWeights returns the weighted amenities by descending weight, ties broken by name.
+func SortedAmenityWeights(weights map[string]float64) []string {
+	amenities := make([]string, 0, len(weights))
+	for amenity := range weights {
+		amenities = append(amenities, amenity)
+	}
+	sort.Slice(amenities, func(i, j int) bool {
+		if weights[amenities[i]] != weights[amenities[j]] {
+			return weights[amenities[i]] > weights[amenities[j]]
+		}
+		return amenities[i] < amenities[j]
+	})
+	return amenities
+}
+
+// normalizeDefaultSort rewrites sorts that are equivalent to the default ordering to a single
+// form, so the implicit and explicit spellings of a query share a cache key. Without a text
+// query or amenities to score, relevance ordering degenerates to rating:desc. The classic profile
+// sorts by rating unless a sort is requested.
+func (p *Params) normalizeDefaultSort() {
+	if p.SortBy == "" && p.RankingProfile == RankingProfileClassic {
+		p.SortBy = "rating"
+		p.SortOrder = "desc"
+	}
+	if p.SortBy == "" {
+		p.SortBy = "relevance"
+		p.SortOrder = "desc"
+	}
+
+	hasTextQuery := p.Query != "" && p.Query != "*"
+	if p.SortBy == "relevance" && p.SortOrder == "desc" && !hasTextQuery &&
+		len(p.Amenities) == 0 && len(p.AmenityWeights) == 0 {
+		p.SortBy = "rating"
+	}
+}
+
+func (p *Params) HasArrivalFilter() bool {
+	return p.ArrivalTime != ""
+}
+
+// ParseArrivalTime converts an arrival time such as "23:30", "2330", "23" or "11:30 PM" into
+// minutes since midnight of the check-in day. Arrivals before hotel.LateNightCutoffMinutes are
+// taken as the following night, so 01:00 becomes 1500.
+func ParseArrivalTime(value string) (int, error) {
+	s := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm"} {
+		if trimmed, ok := strings.CutSuffix(s, suffix); ok {
+			s, meridiem = trimmed, suffix
+			break
+		}
+	}
+
+	hourPart, minutePart := s, "0"
+	if before, after, found := strings.Cut(s, ":"); found {
+		hourPart, minutePart = before, after
+	} else if len(s) == 4 {
+		hourPart, minutePart = s[:2], s[2:]
+	}
+
+	hour, hourErr := strconv.Atoi(hourPart)
+	minute, minuteErr := strconv.Atoi(minutePart)
+	if hourErr != nil || minuteErr != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidArrivalTime, value)
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidArrivalTime, value)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	default:
+		if hour == 24 && minute > 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidArrivalTime, value)
+		}
+	}
+
+	minutes := hour*60 + minute
+	if minutes < hotel.LateNightCutoffMinutes {
+		minutes += hotel.MinutesPerDay
+	}
+
+	return minutes, nil
+}
+
 func (p *Params) HasLocationFilter() bool {
 	return p.Latitude != 0 && p.Longitude != 0 && p.Radius > 0
 }
 
-func (p *Params) HasPriceFilter() bool {
-	return p.PriceMin > 0 || p.PriceMax > 0
+func (p *Params) HasGeoPolygon() bool {
+	return len(p.GeoPolygon) >= MinGeoPolygonVertices
+}
+
+func (p *Params) HasReferencePoint() bool {
+	return p.Latitude != 0 && p.Longitude != 0
 }
 
 func (p *Params) HasRatingFilter() bool {
@@ -159,3 +541,18 @@ func (r *Result) HasNextPage() bool {
 func (r *Result) HasPreviousPage() bool {
 	return r.Page > 1
 }
+
+// FormatBytes renders a byte count with a binary unit, e.g. 1288490188 as "1.2 GB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for value := n / unit; value >= unit; value /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+func TestParseArrivalTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"23:30", 23*60 + 30},
+		{"2330", 23*60 + 30},
+		{"23", 23 * 60},
+		{" 18:05 ", 18*60 + 5},
+		{"11:30 PM", 23*60 + 30},
+		{"11:30pm", 23*60 + 30},
+		{"12 PM", 12 * 60},
+		{"7 am", 7 * 60},
+		{"06:00", 6 * 60},
+		// Arrivals in the early hours belong to the night after the check-in day.
+		{"01:00", hotel.MinutesPerDay + 60},
+		{"0145", hotel.MinutesPerDay + 105},
+		{"12 AM", hotel.MinutesPerDay},
+		{"00:00", hotel.MinutesPerDay},
+		{"24:00", hotel.MinutesPerDay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			minutes, err := ParseArrivalTime(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, minutes)
+		})
+	}
+}
+
+func TestParseArrivalTimeRejectsInvalidValues(t *testing.T) {
+	for _, value := range []string{"", "late", "25:00", "24:30", "23:60", "13 PM", "0 AM", "23:3x", "-1"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseArrivalTime(value)
+			assert.ErrorIs(t, err, ErrInvalidArrivalTime)
+		})
+	}
+}
+
+func TestValidateParsesArrivalTime(t *testing.T) {
+	params := Params{ArrivalTime: "23:30"}
+	require.NoError(t, params.Validate())
+	assert.True(t, params.HasArrivalFilter())
+	assert.Equal(t, 23*60+30, params.ArrivalMinutes)
+
+	params = Params{ArrivalTime: "midnight"}
+	assert.ErrorIs(t, params.Validate(), ErrInvalidArrivalTime)
+
+	params = Params{}
+	require.NoError(t, params.Validate())
+	assert.False(t, params.HasArrivalFilter())
+}
+
+func TestValidateNormalizesAmenityWeights(t *testing.T) {
+	params := Params{AmenityWeights: map[string]float64{"pool": 1.5, "wifi": 0.3, "spa": -0.2, "gym": 0, "": 0.5}}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, map[string]float64{"pool": 1, "wifi": 0.3}, params.AmenityWeights)
+
+	params = Params{AmenityWeights: map[string]float64{"spa": -1}}
+	require.NoError(t, params.Validate())
+	assert.Nil(t, params.AmenityWeights)
+}
+
+func TestValidateKeepsTheHighestAmenityWeights(t *testing.T) {
+	weights := make(map[string]float64)
+	for i := range MaxAmenityWeights + 2 {
+		weights[fmt.Sprintf("amenity-%02d", i)] = float64(i+1) / 20
+	}
+
+	params := Params{AmenityWeights: weights}
+	require.NoError(t, params.Validate())
+
+	require.Len(t, params.AmenityWeights, MaxAmenityWeights)
+	assert.NotContains(t, params.AmenityWeights, "amenity-00")
+	assert.NotContains(t, params.AmenityWeights, "amenity-01")
+	assert.Equal(t, 0.6, params.AmenityWeights["amenity-11"])
+}
+
+func TestSortedAmenityWeights(t *testing.T) {
+	weights := map[string]float64{"wifi": 0.3, "spa": 0.7, "pool": 0.9, "bar": 0.7}
+	assert.Equal(t, []string{"pool", "bar", "spa", "wifi"}, SortedAmenityWeights(weights))
+}
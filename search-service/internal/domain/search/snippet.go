@@ -0,0 +1,57 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSnippetLength is the length in characters of the description snippet of search results.
+const DefaultSnippetLength = 200
+
+// FieldDescription, when requested through Params.Fields, keeps the full description texts in
+// search results.
+const FieldDescription = "description"
+
+const snippetEllipsis = "…"
+
+// Snippet shortens text to at most maxLength characters, cutting at the last word boundary so no
+// word or multi-byte rune is split, and appends an ellipsis. Text that fits is returned trimmed.
+// Text without spaces in range, such as CJK, is cut at maxLength.
+func Snippet(text string, maxLength int) string {
+	text = strings.TrimSpace(text)
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	runes := []rune(text)
+	end := maxLength
+	if !unicode.IsSpace(runes[maxLength]) {
+		for i := maxLength - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				end = i
+				break
+			}
+		}
+	}
+
+	snippet := strings.TrimRightFunc(string(runes[:end]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return snippet + snippetEllipsis
+}
+
+// ApplySnippets gives every hotel without a DescriptionSnippet one cut from its description and,
+// unless keepFullText is set, drops the long description fields that list views do not show.
+func (r *Result) ApplySnippets(length int, keepFullText bool) {
+	for _, h := range r.Hotels {
+		if h.DescriptionSnippet == "" {
+			h.DescriptionSnippet = Snippet(h.Description, length)
+		}
+		if !keepFullText {
+			h.Description = ""
+			h.MarkdownDescription = ""
+			h.ImportantInfo = ""
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		expected  string
+	}{
+		{name: "fits", text: "  A quiet hotel.  ", maxLength: 20, expected: "A quiet hotel."},
+		{name: "cut at a word boundary", text: "A quiet hotel near the old harbour", maxLength: 15, expected: "A quiet hotel…"},
+		{name: "cut exactly at a space", text: "A quiet hotel near", maxLength: 13, expected: "A quiet hotel…"},
+		{name: "trailing punctuation dropped", text: "Quiet, central, affordable rooms", maxLength: 16, expected: "Quiet, central…"},
+		{name: "multi-byte runes counted as characters", text: "Hôtel élégant à deux pas de l'Opéra", maxLength: 20, expected: "Hôtel élégant à deux…"},
+		{name: "text without spaces", text: "東京駅から徒歩五分の静かなホテルです", maxLength: 6, expected: "東京駅から徒…"},
+		{name: "emoji are not split", text: "🏨🏨🏨 🌊🌊🌊 🌴🌴🌴", maxLength: 9, expected: "🏨🏨🏨 🌊🌊🌊…"},
+		{name: "no limit", text: "A quiet hotel", maxLength: 0, expected: "A quiet hotel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet := Snippet(tt.text, tt.maxLength)
+			assert.Equal(t, tt.expected, snippet)
+			assert.True(t, utf8.ValidString(snippet))
+		})
+	}
+}
+
+func TestSnippetNeverSplitsRunes(t *testing.T) {
+	text := strings.Repeat("日本語のテキスト and ünïcödé wörds ", 20)
+	for length := 1; length <= utf8.RuneCountInString(text); length++ {
+		snippet := Snippet(text, length)
+		assert.True(t, utf8.ValidString(snippet), "length %d", length)
+		assert.LessOrEqual(t, utf8.RuneCountInString(strings.TrimSuffix(snippet, snippetEllipsis)), length)
+	}
+}
+
+func TestApplySnippets(t *testing.T) {
+	newResult := func() *Result {
+		return &Result{Hotels: []*hotel.Hotel{
+			{HotelID: 1, Description: "A quiet hotel near the old harbour", MarkdownDescription: "A quiet hotel", ImportantInfo: "No pets"},
+			{HotelID: 2, Description: "A quiet hotel near the old harbour", DescriptionSnippet: "near the <mark>harbour</mark>"},
+		}}
+	}
+
+	result := newResult()
+	result.ApplySnippets(15, false)
+	assert.Equal(t, "A quiet hotel…", result.Hotels[0].DescriptionSnippet)
+	assert.Equal(t, "near the <mark>harbour</mark>", result.Hotels[1].DescriptionSnippet, "a highlighted fragment is kept")
+	for _, h := range result.Hotels {
+		assert.Empty(t, h.Description)
+		assert.Empty(t, h.MarkdownDescription)
+		assert.Empty(t, h.ImportantInfo)
+	}
+
+	result = newResult()
+	result.ApplySnippets(15, true)
+	assert.Equal(t, "A quiet hotel…", result.Hotels[0].DescriptionSnippet)
+	assert.Equal(t, "A quiet hotel near the old harbour", result.Hotels[0].Description)
+	assert.Equal(t, "No pets", result.Hotels[0].ImportantInfo)
+}
@@ -0,0 +1,212 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// MaxVocabularyTokens caps the words kept in a vocabulary, most frequent first.
+	MaxVocabularyTokens = 5000
+
+	// minCorrectionLength is the shortest word that is corrected. Shorter words have too many
+	// neighbors within two edits for a correction to be meaningful.
+	minCorrectionLength = 5
+	maxCorrectionEdits  = 2
+	minVocabularyLength = 3
+	// maxVocabularyLength bounds the deletes indexed per word, which grow with its square.
+	maxVocabularyLength = 16
+)
+
+// Vocabulary is the set of frequent words in indexed hotel names and cities, most frequent first.
+type Vocabulary struct {
+	Tokens  []string  `json:"tokens"`
+	BuiltAt time.Time `json:"built_at"`
+}
+
+// VocabularyBuilder counts the words of the texts added to it.
+type VocabularyBuilder struct {
+	counts map[string]int
+}
+
+func NewVocabularyBuilder() *VocabularyBuilder {
+	return &VocabularyBuilder{counts: make(map[string]int)}
+}
+
+// Add counts the words of text. Words of scripts written without spaces have no word
+// boundaries to correct and are skipped.
+func (b *VocabularyBuilder) Add(text string) {
+	for _, token := range tokenize(text) {
+		length := len([]rune(token))
+		if length < minVocabularyLength || length > maxVocabularyLength || !IsSpaceDelimited(token) {
+			continue
+		}
+		b.counts[token]++
+	}
+}
+
+// Build returns the maxTokens most frequent words, ties broken alphabetically.
+func (b *VocabularyBuilder) Build(maxTokens int) Vocabulary {
+	tokens := make([]string, 0, len(b.counts))
+	for token := range b.counts {
+		tokens = append(tokens, token)
+	}
+
+	slices.SortFunc(tokens, func(x, y string) int {
+		if c := cmp.Compare(b.counts[y], b.counts[x]); c != 0 {
+			return c
+		}
+		return strings.Compare(x, y)
+	})
+	if maxTokens > 0 && len(tokens) > maxTokens {
+		tokens = tokens[:maxTokens]
+	}
+
+	return Vocabulary{Tokens: tokens, BuiltAt: time.Now().UTC()}
+}
+
+// SpellChecker corrects misspelled words against a vocabulary. Every vocabulary word is indexed
+// under the strings obtained by deleting up to two of its characters, so a lookup only
+// generates the deletes of the query word instead of comparing it with every word.
+type SpellChecker struct {
+	tokens  map[string]struct{}
+	deletes map[string][]string
+}
+
+func NewSpellChecker(vocabulary Vocabulary) *SpellChecker {
+	checker := &SpellChecker{
+		tokens:  make(map[string]struct{}, len(vocabulary.Tokens)),
+		deletes: make(map[string][]string),
+	}
+
+	for _, token := range vocabulary.Tokens {
+		checker.tokens[token] = struct{}{}
+		for variant := range deletes(token, maxCorrectionEdits) {
+			checker.deletes[variant] = append(checker.deletes[variant], token)
+		}
+	}
+
+	return checker
+}
+
+// Correct replaces the words of query that are not in the vocabulary with the only vocabulary
+// word at the smallest edit distance, at most two. Words shorter than five characters, and
+// words with several equally close candidates, are kept. The boolean reports whether any word
+// was replaced.
+func (c *SpellChecker) Correct(query string) (string, bool) {
+	words := tokenize(query)
+	corrected := false
+
+	for i, word := range words {
+		if len([]rune(word)) < minCorrectionLength {
+			continue
+		}
+		if _, known := c.tokens[word]; known {
+			continue
+		}
+		if replacement, ok := c.closest(word); ok {
+			words[i] = replacement
+			corrected = true
+		}
+	}
+
+	if !corrected {
+		return query, false
+	}
+	return strings.Join(words, " "), true
+}
+
+func (c *SpellChecker) closest(word string) (string, bool) {
+	best := ""
+	bestDistance := maxCorrectionEdits + 1
+	ambiguous := false
+
+	seen := make(map[string]struct{})
+	for variant := range deletes(word, maxCorrectionEdits) {
+		for _, candidate := range c.deletes[variant] {
+			if _, ok := seen[candidate]; ok {
+				continue
+			}
+			seen[candidate] = struct{}{}
+
+			// Words sharing a delete can still be up to four edits apart.
+			distance := editDistance(word, candidate)
+			if distance > maxCorrectionEdits {
+				continue
+			}
+
+			switch {
+			case distance < bestDistance:
+				best, bestDistance, ambiguous = candidate, distance, false
+			case distance == bestDistance:
+				ambiguous = true
+			}
+		}
+	}
+
+	if best == "" || ambiguous {
+		return "", false
+	}
+	return best, true
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// deletes returns word and every string obtained by deleting up to edits of its characters.
+func deletes(word string, edits int) map[string]struct{} {
+	variants := map[string]struct{}{word: {}}
+	frontier := []string{word}
+
+	for range edits {
+		var next []string
+		for _, variant := range frontier {
+			runes := []rune(variant)
+			for i := range runes {
+				deleted := string(runes[:i]) + string(runes[i+1:])
+				if _, ok := variants[deleted]; !ok {
+					variants[deleted] = struct{}{}
+					next = append(next, deleted)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return variants
+}
+
+// editDistance is the optimal string alignment distance between a and b: insertions,
+// deletions, substitutions and transpositions of adjacent characters each count as one edit.
+func editDistance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	rows := make([][]int, len(x)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(y)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(x)][len(y)]
+}
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVocabularyBuilderKeepsMostFrequentWords(t *testing.T) {
+	builder := NewVocabularyBuilder()
+	builder.Add("Hilton Paris Opera")
+	builder.Add("Hilton London")
+	builder.Add("Paris Marriott")
+	builder.Add("Hilton Garden Inn, Paris")
+
+	vocabulary := builder.Build(3)
+
+	// Ties are broken alphabetically.
+	assert.Equal(t, []string{"hilton", "paris", "garden"}, vocabulary.Tokens)
+	assert.False(t, vocabulary.BuiltAt.IsZero())
+	assert.Len(t, builder.Build(0).Tokens, 7, "no cap keeps every word")
+}
+
+func TestVocabularyBuilderSkipsWordsItCannotCorrect(t *testing.T) {
+	builder := NewVocabularyBuilder()
+	builder.Add("Inn by the sea")
+	builder.Add("Pneumonoultramicroscopic")
+	builder.Add("東京ステーションホテル")
+	builder.Add("Kyoto")
+
+	assert.Equal(t, []string{"inn", "kyoto", "sea", "the"}, builder.Build(MaxVocabularyTokens).Tokens)
+}
+
+func TestVocabularyBuildIsCapped(t *testing.T) {
+	builder := NewVocabularyBuilder()
+	for i := range MaxVocabularyTokens + 100 {
+		builder.Add(fmt.Sprintf("hotel%05d", i))
+	}
+
+	assert.Len(t, builder.Build(MaxVocabularyTokens).Tokens, MaxVocabularyTokens)
+}
+
+func TestSpellCheckerCorrect(t *testing.T) {
+	checker := NewSpellChecker(Vocabulary{Tokens: []string{"hilton", "hotel", "total", "marriott", "paris", "barcelona", "inn"}})
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  string
+		corrected bool
+	}{
+		{name: "transposition", query: "hliton", expected: "hilton", corrected: true},
+		{name: "two edits", query: "barcalonna", expected: "barcelona", corrected: true},
+		{name: "only misspelled words change", query: "Marriot Paris", expected: "marriott paris", corrected: true},
+		{name: "known words", query: "Hilton Paris", expected: "Hilton Paris"},
+		{name: "short words are kept", query: "inm", expected: "inm"},
+		{name: "five letter word is corrected", query: "prais", expected: "paris", corrected: true},
+		{name: "four letter word is kept", query: "hilt", expected: "hilt"},
+		{name: "ambiguous correction", query: "hotal", expected: "hotal"},
+		{name: "too many edits", query: "hxxxxon", expected: "hxxxxon"},
+		{name: "unknown word", query: "zanzibar", expected: "zanzibar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrected, ok := checker.Correct(tt.query)
+			assert.Equal(t, tt.corrected, ok)
+			assert.Equal(t, tt.expected, corrected)
+		})
+	}
+}
+
+func TestSpellCheckerWithEmptyVocabulary(t *testing.T) {
+	corrected, ok := NewSpellChecker(Vocabulary{}).Correct("hliton")
+	assert.False(t, ok)
+	assert.Equal(t, "hliton", corrected)
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{a: "hilton", b: "hilton", distance: 0},
+		{a: "hliton", b: "hilton", distance: 1},
+		{a: "hilton", b: "hiltn", distance: 1},
+		{a: "hilton", b: "hiltonn", distance: 1},
+		{a: "hilton", b: "hixton", distance: 1},
+		{a: "hotal", b: "total", distance: 1},
+		{a: "", b: "inn", distance: 3},
+		{a: "münchen", b: "munchen", distance: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.distance, editDistance(tt.a, tt.b))
+			assert.Equal(t, tt.distance, editDistance(tt.b, tt.a))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+// GlobalLocale is the bucket every search is counted in, whatever its locale.
+const GlobalLocale = "global"
+
+// maxTrendingQueryLength keeps pasted text and other oddities out of the trending lists.
+const maxTrendingQueryLength = 100
+
+// QueryCount is how often a query was searched in a locale.
+type QueryCount struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
+
+// QueryLog counts the searches made in each locale, so trending lists can follow the market.
+type QueryLog interface {
+	// Record counts a search for query in the locale's bucket and in the global one.
+	Record(ctx context.Context, locale, query string) error
+	// Top returns the most searched queries of a locale, most searched first, and the number of
+	// searches recorded in it.
+	Top(ctx context.Context, locale string, limit int) ([]QueryCount, int64, error)
+}
+
+// NormalizeLocale reduces a language tag such as es-ES, or an Accept-Language header, to the
+// lowercase primary subtag that names a trending bucket. Values that are not a two or three
+// letter code give GlobalLocale.
+func NormalizeLocale(value string) string {
+	tag, _, _ := strings.Cut(value, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	tag, _, _ = strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+
+	if len(tag) < 2 || len(tag) > 3 {
+		return GlobalLocale
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return GlobalLocale
+		}
+	}
+	return tag
+}
+
+// countryLanguages maps the country hint of the edge proxy to the main language of its market.
+var countryLanguages = map[string]string{
+	"AR": "es", "CL": "es", "CO": "es", "ES": "es", "MX": "es", "PE": "es",
+	"AU": "en", "CA": "en", "GB": "en", "IE": "en", "NZ": "en", "US": "en",
+	"BE": "fr", "FR": "fr",
+	"AT": "de", "CH": "de", "DE": "de",
+	"IT": "it",
+	"BR": "pt", "PT": "pt",
+	"NL": "nl",
+	"JP": "ja",
+	"KR": "ko",
+	"CN": "zh", "TW": "zh",
+	"AE": "ar", "SA": "ar",
+}
+
+// CountryLocale returns the trending bucket of a country code such as ES, the bucket of the
+// country's main language, or GlobalLocale for countries without one.
+func CountryLocale(country string) string {
+	if language, ok := countryLanguages[strings.ToUpper(strings.TrimSpace(country))]; ok {
+		return language
+	}
+	return GlobalLocale
+}
+
+// NormalizeTrendingQuery folds case and spacing, so that "Beach  Resorts" and "beach resorts"
+// count as one query. It returns "" for queries not worth counting.
+func NormalizeTrendingQuery(query string) string {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if len(query) > maxTrendingQueryLength {
+		return ""
+	}
+	return query
+}
@@ -0,0 +1,24 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "es", NormalizeLocale("es-ES"))
+	assert.Equal(t, "pt", NormalizeLocale("pt_BR"))
+	assert.Equal(t, "fr", NormalizeLocale("fr-CH, fr;q=0.9, en;q=0.8"))
+	assert.Equal(t, GlobalLocale, NormalizeLocale("*"))
+	assert.Equal(t, GlobalLocale, NormalizeLocale(""))
+}
+
+func TestCountryLocale(t *testing.T) {
+	assert.Equal(t, "es", CountryLocale("ES"))
+	assert.Equal(t, "es", CountryLocale("mx"))
+	assert.Equal(t, "en", CountryLocale(" US "))
+	assert.Equal(t, "pt", CountryLocale("BR"))
+	assert.Equal(t, GlobalLocale, CountryLocale("ZZ"))
+	assert.Equal(t, GlobalLocale, CountryLocale(""))
+}
@@ -0,0 +1,179 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FacetFields are the index fields search facets can be computed on.
+var FacetFields = []string{"city", "country", "star_rating", "amenities", "price_range", "chain", "languages_available"}
+
+const MaxFacetValues = 250
+
+var ErrInvalidConfigBundle = errors.New("invalid search config bundle")
+
+var synonymIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Synonym is a set of interchangeable terms. With a Root the set is one-way: the root expands
+// to the synonyms but not the other way around.
+type Synonym struct {
+	ID       string   `json:"id"`
+	Root     string   `json:"root,omitempty"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// RankingProfileSettings tunes how a ranking profile matches text queries. QueryByWeights
+// weights the name and description fields, e.g. "4,1"; empty keeps the default "5,3". Policy
+// text in important_info always weighs less than both.
+type RankingProfileSettings struct {
+	QueryByWeights       string `json:"query_by_weights"`
+	PrioritizeExactMatch bool   `json:"prioritize_exact_match"`
+}
+
+type FacetSettings struct {
+	Fields []string `json:"fields"`
+	// MaxValues is the number of values returned per facet, the engine default when zero.
+	MaxValues int `json:"max_values"`
+}
+
+// Tuning is the runtime search configuration applied on top of the index.
+type Tuning struct {
+	RankingProfiles map[string]RankingProfileSettings `json:"ranking_profiles"`
+	Facets          FacetSettings                     `json:"facets"`
+}
+
+// DefaultTuning is the tuning the service used before it became configurable.
+func DefaultTuning() Tuning {
+	return Tuning{
+		RankingProfiles: map[string]RankingProfileSettings{
+			RankingProfileRelevance: {QueryByWeights: "4,1", PrioritizeExactMatch: true},
+			RankingProfileClassic:   {},
+		},
+		Facets: FacetSettings{Fields: slices.Clone(FacetFields)},
+	}
+}
+
+// Profile returns the settings of a ranking profile, falling back to the defaults.
+func (t Tuning) Profile(name string) RankingProfileSettings {
+	if settings, ok := t.RankingProfiles[name]; ok {
+		return settings
+	}
+	return DefaultTuning().RankingProfiles[name]
+}
+
+func (t Tuning) Validate() error {
+	for name, settings := range t.RankingProfiles {
+		if !IsRankingProfile(name) {
+			return fmt.Errorf("unknown ranking profile %q", name)
+		}
+		if err := validateQueryByWeights(settings.QueryByWeights); err != nil {
+			return fmt.Errorf("ranking profile %q: %w", name, err)
+		}
+	}
+
+	if len(t.Facets.Fields) == 0 {
+		return fmt.Errorf("facets.fields must not be empty")
+	}
+	for _, field := range t.Facets.Fields {
+		if !slices.Contains(FacetFields, field) {
+			return fmt.Errorf("facet field %q is not facetable", field)
+		}
+	}
+	if t.Facets.MaxValues < 0 || t.Facets.MaxValues > MaxFacetValues {
+		return fmt.Errorf("facets.max_values must be between 0 and %d", MaxFacetValues)
+	}
+
+	return nil
+}
+
+func validateQueryByWeights(weights string) error {
+	if weights == "" {
+		return nil
+	}
+	parts := strings.Split(weights, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("query_by_weights must have a weight for name and description")
+	}
+	for _, part := range parts {
+		weight, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || weight < 0 || weight > 127 {
+			return fmt.Errorf("query_by_weights must be integers between 0 and 127")
+		}
+	}
+	return nil
+}
+
+// ConfigBundle is the exportable search configuration. Version is a hash of the content, so
+// two environments with the same configuration report the same version.
+type ConfigBundle struct {
+	Version  string    `json:"version"`
+	Synonyms []Synonym `json:"synonyms"`
+	Tuning
+}
+
+// Normalize sorts synonyms by id so equal bundles hash equally.
+func (b *ConfigBundle) Normalize() {
+	if b.Synonyms == nil {
+		b.Synonyms = []Synonym{}
+	}
+	slices.SortFunc(b.Synonyms, func(a, c Synonym) int { return strings.Compare(a.ID, c.ID) })
+}
+
+func (b ConfigBundle) Hash() string {
+	b.Version = ""
+	b.Normalize()
+	data, _ := json.Marshal(b)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Validate checks the whole bundle so nothing is applied when any part of it is invalid.
+func (b ConfigBundle) Validate() error {
+	seen := make(map[string]bool, len(b.Synonyms))
+	for _, synonym := range b.Synonyms {
+		if !synonymIDPattern.MatchString(synonym.ID) {
+			return fmt.Errorf("%w: synonym id %q must match %s", ErrInvalidConfigBundle, synonym.ID, synonymIDPattern)
+		}
+		if seen[synonym.ID] {
+			return fmt.Errorf("%w: duplicate synonym id %q", ErrInvalidConfigBundle, synonym.ID)
+		}
+		seen[synonym.ID] = true
+
+		minTerms := 2
+		if synonym.Root != "" {
+			minTerms = 1
+		}
+		if len(synonym.Synonyms) < minTerms {
+			return fmt.Errorf("%w: synonym %q needs at least %d terms", ErrInvalidConfigBundle, synonym.ID, minTerms)
+		}
+		if slices.ContainsFunc(synonym.Synonyms, func(term string) bool { return strings.TrimSpace(term) == "" }) {
+			return fmt.Errorf("%w: synonym %q has an empty term", ErrInvalidConfigBundle, synonym.ID)
+		}
+	}
+
+	if err := b.Tuning.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfigBundle, err)
+	}
+
+	return nil
+}
+
+// SynonymStore manages the synonyms of the search index.
+type SynonymStore interface {
+	ListSynonyms(ctx context.Context) ([]Synonym, error)
+	// ReplaceSynonyms makes synonyms the complete set, deleting any other synonym.
+	ReplaceSynonyms(ctx context.Context, synonyms []Synonym) error
+}
+
+// TuningApplier is implemented by engines whose queries follow the runtime tuning.
+type TuningApplier interface {
+	ApplyTuning(tuning Tuning)
+}
@@ -0,0 +1,80 @@
+package synchistory
+
+import (
+	"context"
+	"time"
+)
+
+// MaxErrors and MaxErrorLength bound the error summaries kept with a run.
+const (
+	MaxErrors      = 10
+	MaxErrorLength = 500
+)
+
+const (
+	ModeFull        = "full"
+	ModeIncremental = "incremental"
+	ModeChain       = "chain"
+)
+
+const (
+	TriggerInitial  = "initial"
+	TriggerPeriodic = "periodic"
+	TriggerManual   = "manual"
+)
+
+const (
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+	StatusSuperseded = "superseded"
+)
+
+// Run is the compact record of one finished sync.
+type Run struct {
+	ID            int64     `json:"id"`
+	Mode          string    `json:"mode"`
+	Trigger       string    `json:"trigger"`
+	Chain         string    `json:"chain,omitempty"`
+	Status        string    `json:"status"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	DurationMs    int64     `json:"duration_ms"`
+	TotalHotels   int       `json:"total_hotels"`
+	IndexedHotels int       `json:"indexed_hotels"`
+	FailedHotels  int       `json:"failed_hotels"`
+	RemovedHotels int       `json:"removed_hotels"`
+	// ErrorCount counts every error of the run; Errors keeps the first MaxErrors of them,
+	// truncated to MaxErrorLength characters.
+	ErrorCount int      `json:"error_count"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Summary aggregates the runs started within a window.
+type Summary struct {
+	Since         time.Time `json:"since"`
+	Runs          int64     `json:"runs"`
+	FailedRuns    int64     `json:"failed_runs"`
+	AvgDurationMs float64   `json:"avg_duration_ms"`
+	// FailureRate is the share of runs that failed or failed to index at least one hotel.
+	FailureRate   float64 `json:"failure_rate"`
+	IndexedHotels int64   `json:"indexed_hotels"`
+	FailedHotels  int64   `json:"failed_hotels"`
+}
+
+// ListOptions pages through the runs started since Since, most recent first.
+type ListOptions struct {
+	Since  time.Time
+	Limit  int
+	Offset int
+}
+
+// Repository persists the sync history.
+type Repository interface {
+	Save(ctx context.Context, run *Run) error
+	List(ctx context.Context, options ListOptions) ([]Run, error)
+	Summarize(ctx context.Context, since time.Time) (Summary, error)
+	// Last returns the most recent run, or nil when none was recorded.
+	Last(ctx context.Context) (*Run, error)
+	// DeleteBefore deletes the runs started before cutoff and returns how many it deleted.
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,98 @@
+package usage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// AnonymousClient is the client id of requests without an API key.
+const AnonymousClient = "anonymous"
+
+const DayLayout = "2006-01-02"
+
+// Event is a single served request.
+type Event struct {
+	ClientID string
+	Endpoint string
+	Day      time.Time
+	Error    bool
+	Results  int64
+}
+
+// DailyUsage is the usage of one client on one endpoint during one UTC day.
+type DailyUsage struct {
+	ClientID string    `json:"client_id"`
+	Endpoint string    `json:"endpoint"`
+	Day      time.Time `json:"day"`
+	Requests int64     `json:"requests"`
+	Errors   int64     `json:"errors"`
+	Results  int64     `json:"results"`
+}
+
+func (u DailyUsage) ErrorRate() float64 {
+	if u.Requests == 0 {
+		return 0
+	}
+	return float64(u.Errors) / float64(u.Requests)
+}
+
+// Counter accumulates events in a fast store on the request path.
+type Counter interface {
+	Record(event Event)
+	Collect(ctx context.Context, day time.Time) ([]DailyUsage, error)
+}
+
+// Repository persists rolled up daily usage.
+type Repository interface {
+	UpsertDailyUsage(ctx context.Context, usages []DailyUsage) error
+	FindDailyUsage(ctx context.Context, clientID string, from, to time.Time) ([]DailyUsage, error)
+}
+
+// ClientID identifies the caller of a request without keeping the API key itself: it is a
+// truncated SHA-256 of the key, or AnonymousClient when there is none.
+func ClientID(apiKey string) string {
+	if apiKey == "" {
+		return AnonymousClient
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Keys are the API keys issued to clients. Requests carrying any other key are counted as
+// anonymous, so made-up keys cannot grow the usage counters without bound.
+type Keys struct {
+	clientIDs map[string]struct{}
+}
+
+func NewKeys(apiKeys []string) Keys {
+	keys := Keys{clientIDs: make(map[string]struct{}, len(apiKeys))}
+	for _, apiKey := range apiKeys {
+		if apiKey != "" {
+			keys.clientIDs[ClientID(apiKey)] = struct{}{}
+		}
+	}
+	return keys
+}
+
+// ClientID returns the client id of apiKey once it is verified as an issued key, and
+// AnonymousClient otherwise.
+func (k Keys) ClientID(apiKey string) string {
+	clientID := ClientID(apiKey)
+	if _, ok := k.clientIDs[clientID]; !ok {
+		return AnonymousClient
+	}
+	return clientID
+}
+
+// Day truncates t to the start of its UTC day.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// ResultRecorder is implemented by response writers that account usage, so handlers can
+// report how many results a response carried.
+type ResultRecorder interface {
+	RecordResults(n int64)
+}
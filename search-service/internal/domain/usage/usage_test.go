@@ -0,0 +1,32 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysCountOnlyIssuedKeys(t *testing.T) {
+	keys := NewKeys([]string{"partner-a", "partner-b", ""})
+
+	assert.Equal(t, ClientID("partner-a"), keys.ClientID("partner-a"))
+	assert.Equal(t, ClientID("partner-b"), keys.ClientID("partner-b"))
+	assert.Equal(t, AnonymousClient, keys.ClientID(""))
+	assert.Equal(t, AnonymousClient, keys.ClientID("partner-c"), "an unknown key is not verified")
+	assert.Equal(t, AnonymousClient, keys.ClientID("PARTNER-A"))
+}
+
+func TestKeysWithoutIssuedKeys(t *testing.T) {
+	var keys Keys
+
+	assert.Equal(t, AnonymousClient, keys.ClientID("partner-a"))
+	assert.Equal(t, AnonymousClient, NewKeys(nil).ClientID("partner-a"))
+}
+
+func TestClientIDDoesNotKeepTheKey(t *testing.T) {
+	clientID := ClientID("partner-a")
+
+	assert.Len(t, clientID, 16)
+	assert.NotContains(t, clientID, "partner-a")
+	assert.Equal(t, AnonymousClient, ClientID(""))
+}
@@ -7,33 +7,42 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	apimodels "github.com/victoragudo/hotel-management-system/pkg/api-models"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/httpclient"
 
 	"github.com/google/uuid"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
 )
 
 type CupidAPIAdapter struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	logger     *slog.Logger
+	baseURL          string
+	apiKey           string
+	httpClient       *http.Client
+	maxResponseBytes int64
+	taxonomy         *facilities.Taxonomy
+	logger           *slog.Logger
 }
 
-func NewCupidAPIAdapter(baseURL, apiKey string, timeout time.Duration, logger *slog.Logger) *CupidAPIAdapter {
+// NewCupidAPIAdapter bounds every request by timeout and every response body by
+// maxResponseBytes, so a slow or oversized upstream response cannot pin a request goroutine
+// or its memory. A maxResponseBytes of zero uses httpclient.DefaultMaxResponseBytes.
+func NewCupidAPIAdapter(baseURL, apiKey string, timeout time.Duration, maxResponseBytes int64, taxonomy *facilities.Taxonomy, logger *slog.Logger) *CupidAPIAdapter {
 	if timeout == 0 {
 		timeout = 30 * time.Second
 	}
 
 	return &CupidAPIAdapter{
-		baseURL: baseURL,
-		apiKey:  apiKey,
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
-		logger: logger,
+		baseURL:          baseURL,
+		apiKey:           apiKey,
+		httpClient:       httpclient.NewClient(timeout, httpclient.DefaultTransportConfig()),
+		maxResponseBytes: maxResponseBytes,
+		taxonomy:         taxonomy,
+		logger:           logger,
 	}
 }
 
@@ -50,6 +59,7 @@ func (cupidAPI *CupidAPIAdapter) makeAPIRequest(ctx context.Context, url string)
 	if err != nil {
 		return nil, fmt.Errorf("API request failed: %w", err)
 	}
+	httpclient.LimitBody(resp, cupidAPI.maxResponseBytes)
 
 	return resp, nil
 }
@@ -71,23 +81,19 @@ func (cupidAPI *CupidAPIAdapter) GetHotelByID(ctx context.Context, hotelID int64
 
 	cupidAPI.logger.Debug("Cupid API response received", "hotel_id", hotelID, "status_code", resp.StatusCode, "duration", time.Since(startTime))
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	if resp.StatusCode == http.StatusNotFound {
 		cupidAPI.logger.Warn("Hotel not found in Cupid API", "hotel_id", hotelID)
 		return nil, fmt.Errorf("hotel %d not found in Cupid API", hotelID)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		cupidAPI.logger.Error("Cupid API returned error", "hotel_id", hotelID, "status_code", resp.StatusCode, "body", string(body))
-		return nil, fmt.Errorf("cupid API returned status %d: %s", resp.StatusCode, string(body))
+		body := httpclient.ErrorBody(resp.Body)
+		cupidAPI.logger.Error("Cupid API returned error", "hotel_id", hotelID, "status_code", resp.StatusCode, "body", body)
+		return nil, fmt.Errorf("cupid API returned status %d: %s", resp.StatusCode, body)
 	}
 
 	var apiResponse apimodels.HotelAPIResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
+	if err := apimodels.Decode(resp.Body, &apiResponse); err != nil {
 		cupidAPI.logger.Error("Failed to unmarshal Cupid API response", "hotel_id", hotelID, "error", err)
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
@@ -121,7 +127,7 @@ func (cupidAPI *CupidAPIAdapter) GetHotelReviews(ctx context.Context, hotelID in
 	}
 
 	var reviewsResponse []apimodels.ReviewAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&reviewsResponse); err != nil {
+	if err := apimodels.Decode(resp.Body, &reviewsResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode reviews response: %w", err)
 	}
 
@@ -149,27 +155,22 @@ func (cupidAPI *CupidAPIAdapter) GetHotelTranslations(ctx context.Context, hotel
 
 		cupidAPI.logger.Debug("Fetching hotel translations from Cupid API", "hotel_id", hotelID, "language", language)
 
-		request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		request.Header.Set("accept", "application/json")
-		request.Header.Set("x-api-key", cupidAPI.apiKey)
-
-		resp, err := cupidAPI.httpClient.Do(request)
+		resp, err := cupidAPI.makeAPIRequest(ctx, url)
 		if err != nil {
 			cupidAPI.logger.Error("Failed to call Cupid API for translations", "hotel_id", hotelID, "language", language, "error", err)
 			return nil, fmt.Errorf("cupid API translations request failed: %w", err)
 		}
 
 		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
 			cupidAPI.logger.Warn("Cupid API returned non-OK status for translations", "hotel_id", hotelID, "language", language, "status_code", resp.StatusCode)
 			continue
 		}
 
 		var translationsResponse apimodels.TranslationAPIResponse
-		if err := json.NewDecoder(resp.Body).Decode(&translationsResponse); err != nil {
+		err = apimodels.Decode(resp.Body, &translationsResponse)
+		_ = resp.Body.Close()
+		if err != nil {
 			cupidAPI.logger.Warn("Failed to decode translations response", "hotel_id", hotelID, "language", language, "error", err)
 			continue
 		}
@@ -191,12 +192,23 @@ func (cupidAPI *CupidAPIAdapter) convertFacilities(apiFacilities []apimodels.Fac
 	for _, facility := range apiFacilities {
 		facilities = append(facilities, hotel.Facility{
 			Name: facility.Name,
-			ID:   facility.ID,
+			ID:   facility.FacilityID,
 		})
 	}
 	return facilities
 }
 
+// normalizeFacilities sets the canonical slug of every facility and returns the distinct slugs
+// as the hotel's amenities.
+func (cupidAPI *CupidAPIAdapter) normalizeFacilities(hotelFacilities []hotel.Facility) []string {
+	names := make([]string, len(hotelFacilities))
+	for i := range hotelFacilities {
+		hotelFacilities[i].Slug, _ = cupidAPI.taxonomy.Normalize(hotelFacilities[i].Name)
+		names[i] = hotelFacilities[i].Name
+	}
+	return cupidAPI.taxonomy.Slugs(names)
+}
+
 func (cupidAPI *CupidAPIAdapter) convertPolicies(apiPolicies []apimodels.Policy) []hotel.Policy {
 	policies := make([]hotel.Policy, 0, len(apiPolicies))
 	for _, policy := range apiPolicies {
@@ -319,9 +331,6 @@ func (cupidAPI *CupidAPIAdapter) convertCupidToHotel(hotelAPIResponse apimodels.
 		HotelType:           hotelAPIResponse.HotelType,
 		Chain:               hotelAPIResponse.Chain,
 		ChainID:             int32(hotelAPIResponse.ChainID),
-		Phone:               hotelAPIResponse.Phone,
-		Fax:                 hotelAPIResponse.Fax,
-		Email:               hotelAPIResponse.Email,
 		AirportCode:         hotelAPIResponse.AirportCode,
 		ReviewCount:         int32(hotelAPIResponse.ReviewCount),
 		Parking:             hotelAPIResponse.Parking,
@@ -344,6 +353,8 @@ func (cupidAPI *CupidAPIAdapter) convertCupidToHotel(hotelAPIResponse apimodels.
 		Fax:   hotelAPIResponse.Fax,
 		Email: hotelAPIResponse.Email,
 	}
+	h.ContactInfo.NormalizeNumbers(hotelAPIResponse.Address.Country)
+	h.Timezone = h.TimezoneName()
 
 	h.CheckinInfo = hotel.CheckinInfo{
 		CheckinStart:        cupidAPI.parseTimeString(hotelAPIResponse.Checkin.CheckinStart),
@@ -352,12 +363,21 @@ func (cupidAPI *CupidAPIAdapter) convertCupidToHotel(hotelAPIResponse apimodels.
 		Instructions:        hotelAPIResponse.Checkin.Instructions,
 		SpecialInstructions: hotelAPIResponse.Checkin.SpecialInstructions,
 	}
+	h.CheckinWindow = h.CheckinInfo.Window()
+
+	if hotelAPIResponse.GroupRoomMin != nil {
+		if groupRoomMinJSON, err := json.Marshal(hotelAPIResponse.GroupRoomMin); err == nil {
+			h.GroupRoomMin, _ = hotel.ParseGroupRoomMin(groupRoomMinJSON)
+		}
+	}
 
 	h.Facilities = cupidAPI.convertFacilities(hotelAPIResponse.Facilities)
+	h.Amenities = cupidAPI.normalizeFacilities(h.Facilities)
 	h.Policies = cupidAPI.convertPolicies(hotelAPIResponse.Policies)
 	h.Images = cupidAPI.convertImages(hotelAPIResponse.Photos)
 	h.Rooms = cupidAPI.convertRooms(hotelAPIResponse.Rooms)
 	h.Photos = cupidAPI.convertPhotos(hotelAPIResponse.Photos)
+	h.SourceMappings = map[string]string{entities.SourceCupid: strconv.Itoa(hotelAPIResponse.CupidID)}
 
 	return h, nil
 }
@@ -390,6 +410,7 @@ func (cupidAPI *CupidAPIAdapter) convertCupidToTranslation(translationAPIRespons
 		Fax:   translationAPIResponse.Fax,
 		Email: translationAPIResponse.Email,
 	}
+	translation.ContactInfo.NormalizeNumbers(translationAPIResponse.Address.Country)
 
 	translation.CheckinInfo = hotel.CheckinInfo{
 		CheckinStart:        cupidAPI.parseTimeString(translationAPIResponse.Checkin.CheckinStart),
@@ -409,27 +430,29 @@ func (cupidAPI *CupidAPIAdapter) convertCupidToTranslation(translationAPIRespons
 }
 
 func (cupidAPI *CupidAPIAdapter) convertCupidToReview(hotelId int64, cupidReview apimodels.ReviewAPIResponse) (*hotel.Review, error) {
-	var reviewDate time.Time
-	if cupidReview.Date != "" {
-		if t, err := time.Parse(time.RFC3339, cupidReview.Date); err == nil {
-			reviewDate = t
-		}
+	reviewDate, ok := entities.ParseReviewDate(cupidReview.Date)
+	if !ok && cupidReview.Date != "" {
+		cupidAPI.logger.Warn("Unparseable review date", "hotel_id", hotelId, "review_id", cupidReview.ReviewID, "raw_date", cupidReview.Date)
 	}
 
 	return &hotel.Review{
-		ID:           uuid.NewString(),
-		HotelID:      hotelId,
-		ReviewID:     cupidReview.ReviewID,
-		AverageScore: cupidReview.AverageScore,
-		Country:      cupidReview.Country,
-		Type:         cupidReview.Type,
-		Name:         cupidReview.Name,
-		Date:         reviewDate,
-		Headline:     cupidReview.Headline,
-		Language:     cupidReview.Language,
-		Pros:         cupidReview.Pros,
-		Cons:         cupidReview.Cons,
-		Source:       cupidReview.Source,
+		ID:              uuid.NewString(),
+		HotelID:         hotelId,
+		ReviewID:        cupidReview.ReviewID,
+		AverageScore:    cupidReview.AverageScore,
+		Country:         cupidReview.Country,
+		Type:            cupidReview.Type,
+		Name:            cupidReview.Name,
+		Date:            reviewDate,
+		Headline:        cupidReview.Headline,
+		Language:        cupidReview.Language,
+		Pros:            cupidReview.Pros,
+		Cons:            cupidReview.Cons,
+		Source:          cupidReview.Source,
+		ScoreLocation:   cupidReview.ScoreLocation,
+		ScoreService:    cupidReview.ScoreService,
+		ScoreValue:      cupidReview.ScoreValue,
+		ScoreFacilities: cupidReview.ScoreFacilities,
 	}, nil
 }
 
@@ -0,0 +1,273 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimodels "github.com/victoragudo/hotel-management-system/pkg/api-models"
+	"github.com/victoragudo/hotel-management-system/pkg/api-models/cupidtest"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/pkg/httpclient"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+// newContractCupidAPI returns an adapter reading the recorded fixtures of dir, decoding them
+// strictly so fields the API models do not know fail the test.
+func newContractCupidAPI(t *testing.T, dir string) *CupidAPIAdapter {
+	t.Helper()
+	t.Setenv(apimodels.StrictDecodeEnv, "true")
+	server := cupidtest.NewServer(t, dir)
+	return NewCupidAPIAdapter(server.URL, cupidtest.APIKey, 5*time.Second, 0, facilities.Default(), slog.New(slog.DiscardHandler))
+}
+
+func clockTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("15:04")
+}
+
+func TestCupidContractGetHotelByID(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	h, err := cupidAPI.GetHotelByID(context.Background(), 1641879)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1641879), h.HotelID, "hotel_id")
+	assert.Equal(t, int64(1641879), h.CupidID, "cupid_id")
+	assert.Equal(t, int64(204), h.HotelTypeID, "hotel_type_id")
+	assert.Equal(t, "Hotels", h.HotelType, "hotel_type")
+	assert.Equal(t, "Hotel Le Marais", h.Name, "hotel_name")
+	assert.Equal(t, "<p>A boutique hotel in the heart of the Marais.</p>", h.Description, "description")
+	assert.Equal(t, "A boutique hotel in the heart of the Marais.", h.MarkdownDescription, "markdown_description")
+	assert.Equal(t, "Adults only. Guests must be 18 or older to check in.", h.ImportantInfo, "important_info")
+	assert.Equal(t, 8.6, h.Rating, "rating")
+	assert.Equal(t, int32(4), h.StarRating, "stars")
+	assert.Equal(t, 48.86486, h.Latitude, "latitude")
+	assert.Equal(t, 2.37089, h.Longitude, "longitude")
+	assert.Equal(t, "Europe/Paris", h.Timezone, "timezone from latitude and longitude")
+	assert.Equal(t, "Independent", h.Chain, "chain")
+	assert.Equal(t, "CDG", h.AirportCode, "airport_code")
+	assert.Equal(t, int32(1240), h.ReviewCount, "review_count")
+	assert.Equal(t, "Paid parking nearby", h.Parking, "parking")
+	assert.True(t, h.ChildAllowed, "child_allowed")
+	assert.False(t, h.PetsAllowed, "pets_allowed")
+	assert.Equal(t, map[string]string{"cupid": "1641879"}, h.SourceMappings, "cupid_id")
+
+	assert.Equal(t, hotel.Address{Street: "31 Rue de la Folie Méricourt", City: "Paris", Country: "fr", PostalCode: "75011"}, h.Address, "address")
+	assert.Equal(t, hotel.ContactInfo{Phone: "REDACTED", Fax: "REDACTED", Email: "REDACTED"}, h.ContactInfo, "contact")
+
+	assert.Equal(t, "15:00", clockTime(h.CheckinInfo.CheckinStart), "checkin.checkin_start")
+	assert.Equal(t, "23:00", clockTime(h.CheckinInfo.CheckinEnd), "checkin.checkin_end")
+	assert.Equal(t, "11:00", clockTime(h.CheckinInfo.Checkout), "checkin.checkout")
+	assert.Equal(t, []string{"Extra-person charges may apply and vary depending on property policy"}, h.CheckinInfo.Instructions, "checkin.instructions")
+	assert.Equal(t, "Front desk staff will greet guests on arrival.", h.CheckinInfo.SpecialInstructions, "checkin.special_instructions")
+	assert.Equal(t, "15:00", h.CheckinWindow.Start, "checkin window")
+	assert.Equal(t, "23:00", h.CheckinWindow.End, "checkin window")
+
+	assert.Equal(t, &hotel.GroupRoomMin{MinNights: 5}, h.GroupRoomMin, "group_room_min")
+
+	assert.Equal(t, []hotel.Facility{
+		{ID: 47, Name: "Free WiFi", Slug: "wifi"},
+		{ID: 107, Name: "Fitness centre", Slug: "fitness_center"},
+		{ID: 3, Name: "24-hour front desk", Slug: "front_desk_24h"},
+		{ID: 999, Name: "Rooftop terrace", Slug: "rooftop_terrace"},
+	}, h.Facilities, "facilities")
+	assert.Equal(t, []string{"wifi", "fitness_center", "front_desk_24h", "rooftop_terrace"}, h.Amenities, "facilities")
+
+	assert.Equal(t, []hotel.Policy{
+		{PolicyType: "pets", Name: "Pets", Description: "Pets are not allowed.", PetsAllowed: "N"},
+		{PolicyType: "children", Name: "Children and extra beds", Description: "Children of all ages are welcome.", ChildAllowed: "Y"},
+	}, h.Policies, "policies")
+
+	assert.Equal(t, []string{
+		"https://static.cupid.travel/hotels/1641879/1.jpg",
+		"https://static.cupid.travel/hotels/1641879/2.jpg",
+	}, h.Images, "photos")
+	require.Len(t, h.Photos, 2, "photos")
+	assert.Equal(t, hotel.Photo{
+		URL:              "https://static.cupid.travel/hotels/1641879/1.jpg",
+		HDURL:            "https://static.cupid.travel/hotels/1641879/1_hd.jpg",
+		ImageDescription: "Lobby",
+		ImageClass1:      "Interior",
+		MainPhoto:        true,
+		Score:            4.5,
+		ClassID:          3,
+		ClassOrder:       1,
+	}, h.Photos[0], "photos")
+
+	require.Len(t, h.Rooms, 1, "rooms")
+	room := h.Rooms[0]
+	assert.Equal(t, 1132460, room.ID, "rooms.id")
+	assert.Equal(t, "Superior Double Room", room.RoomName, "rooms.room_name")
+	assert.Equal(t, float32(18), room.RoomSizeSquare, "rooms.room_size_square")
+	assert.Equal(t, "m2", room.RoomSizeUnit, "rooms.room_size_unit")
+	assert.Equal(t, "1641879", room.HotelID, "rooms.hotel_id")
+	assert.Equal(t, []int{2, 1, 3}, []int{room.MaxAdults, room.MaxChildren, room.MaxOccupancy}, "rooms occupancy")
+	assert.Equal(t, "OR", room.BedRelation, "rooms.bed_relation")
+	assert.Equal(t, []hotel.BedType{{Quantity: 1, BedType: "Double bed", BedSize: "140-160 cm wide", ID: 3}}, room.BedTypes, "rooms.bed_types")
+	assert.Equal(t, []hotel.Amenity{{AmenitiesID: 28, Name: "Air conditioning", Sort: 1}}, room.RoomAmenities, "rooms.room_amenities")
+	require.Len(t, room.Photos, 1, "rooms.photos")
+	assert.Equal(t, "https://static.cupid.travel/rooms/1132460/1.jpg", room.Photos[0].URL, "rooms.photos")
+	assert.Equal(t, []any{"Courtyard view"}, room.Views, "rooms.views")
+}
+
+func TestCupidContractGetHotelByIDEdgeCases(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	h, err := cupidAPI.GetHotelByID(context.Background(), 317597)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Casa do Largo", h.Name)
+	assert.Equal(t, "Europe/Lisbon", h.Timezone)
+	assert.Nil(t, h.GroupRoomMin, "a null group_room_min gives no restriction")
+	assert.Empty(t, h.Rooms)
+	assert.Empty(t, h.Photos)
+	assert.Empty(t, h.Images)
+	assert.Empty(t, h.Facilities)
+	assert.Empty(t, h.Amenities)
+	assert.Empty(t, h.Policies)
+	assert.True(t, h.CheckinInfo.CheckinStart.IsZero())
+	assert.True(t, h.CheckinWindow.Unknown, "no check-in times")
+}
+
+func TestCupidContractGetHotelByIDNotFound(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	_, err := cupidAPI.GetHotelByID(context.Background(), 42)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCupidContractGetHotelReviews(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	reviews, err := cupidAPI.GetHotelReviews(context.Background(), 1641879, 10)
+	require.NoError(t, err)
+	require.Len(t, reviews, 3)
+
+	first := reviews[0]
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, int64(1641879), first.HotelID, "hotel_id")
+	assert.Equal(t, int64(88120031), first.ReviewID, "review_id")
+	assert.Equal(t, int32(9), first.AverageScore, "average_score")
+	assert.Equal(t, "gb", first.Country, "country")
+	assert.Equal(t, "couple", first.Type, "type")
+	assert.Equal(t, "Emma", first.Name, "name")
+	assert.Equal(t, time.Date(2024, 3, 15, 10, 22, 31, 0, time.UTC), first.Date, "date")
+	assert.Equal(t, "Lovely stay", first.Headline, "headline")
+	assert.Equal(t, "en", first.Language, "language")
+	assert.Equal(t, "Great location and friendly staff.", first.Pros, "pros")
+	assert.Equal(t, "Small room.", first.Cons, "cons")
+	assert.Equal(t, "Nuitee", first.Source, "source")
+	assert.Equal(t, []int32{10, 9, 8, 8},
+		[]int32{first.ScoreLocation, first.ScoreService, first.ScoreValue, first.ScoreFacilities}, "category scores")
+
+	assert.Equal(t, time.Date(2023, 11, 2, 0, 0, 0, 0, time.UTC), reviews[1].Date, "date without a time")
+	assert.True(t, reviews[2].Date.IsZero(), "an unknown date format")
+
+	limited, err := cupidAPI.GetHotelReviews(context.Background(), 1641879, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	none, err := cupidAPI.GetHotelReviews(context.Background(), 317597, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestCupidContractGetHotelTranslations(t *testing.T) {
+	cupidAPI := newContractCupidAPI(t, cupidtest.Dir())
+
+	translations, err := cupidAPI.GetHotelTranslations(context.Background(), 1641879, []string{"fr", "es"})
+	require.NoError(t, err)
+	require.Len(t, translations, 1, "only the recorded language")
+
+	translation := translations[0]
+	assert.Equal(t, "fr", translation.Lang, "lang")
+	assert.Equal(t, int64(1641879), translation.HotelID, "hotel_id")
+	assert.Equal(t, "Hôtel Le Marais", translation.Name, "hotel_name")
+	assert.Equal(t, "<p>Un hôtel de charme au cœur du Marais.</p>", translation.Description, "description")
+	assert.Equal(t, "Réservé aux adultes.", translation.ImportantInfo, "important_info")
+	assert.Equal(t, "Parking payant à proximité", translation.Parking, "parking")
+	assert.Equal(t, "Paris", translation.Address.City, "address")
+	assert.Equal(t, 47, translation.Facilities[0].ID, "facilities")
+	assert.Equal(t, "WiFi gratuit", translation.Facilities[0].Name, "facilities")
+	assert.Equal(t, "Les animaux ne sont pas admis.", translation.Policies[0].Description, "policies")
+	assert.Equal(t, "15:00", clockTime(translation.CheckinInfo.CheckinStart), "checkin")
+	assert.Len(t, translation.Rooms, 1, "rooms")
+	assert.Len(t, translation.Photos, 2, "photos")
+}
+
+func TestCupidContractFieldRename(t *testing.T) {
+	dir := cupidtest.RenameField(t, cupidtest.Dir(), "property_1641879.json", "facilities", "hotel_facilities")
+	cupidAPI := newContractCupidAPI(t, dir)
+
+	_, err := cupidAPI.GetHotelByID(context.Background(), 1641879)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "hotel_facilities"`)
+}
+
+func TestCupidAPIBoundsUpstreamResponses(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		tooLarge bool
+	}{
+		{
+			name: "oversized body",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = io.WriteString(w, `{"hotel_id":1641879,"description":"`+strings.Repeat("x", 4096)+`"}`)
+			},
+			tooLarge: true,
+		},
+		{
+			name: "slow headers",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			},
+		},
+		{
+			name: "slow body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				for {
+					if _, err := io.WriteString(w, " "); err != nil {
+						return
+					}
+					w.(http.Flusher).Flush()
+					select {
+					case <-r.Context().Done():
+						return
+					case <-time.After(20 * time.Millisecond):
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			t.Cleanup(server.Close)
+			cupidAPI := NewCupidAPIAdapter(server.URL, cupidtest.APIKey, 300*time.Millisecond, 1024, facilities.Default(), slog.New(slog.DiscardHandler))
+
+			start := time.Now()
+			_, err := cupidAPI.GetHotelByID(context.Background(), 1641879)
+			require.Error(t, err)
+			assert.Less(t, time.Since(start), 2*time.Second)
+			if tt.tooLarge {
+				assert.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+			}
+		})
+	}
+}
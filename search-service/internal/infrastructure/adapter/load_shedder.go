@@ -0,0 +1,185 @@
+package adapter
+
+import (
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+type LoadShedderConfig struct {
+	Enabled          bool
+	WindowSize       int
+	Window           time.Duration
+	MinSamples       int
+	MaxErrorRate     float64
+	LatencyThreshold time.Duration
+	Cooldown         time.Duration
+}
+
+type callSample struct {
+	at       time.Time
+	duration time.Duration
+	failed   bool
+}
+
+// LoadShedder keeps a ring buffer of recent engine calls. When the error rate or p95 latency of
+// the samples inside the window crosses a threshold it sheds new calls for the cooldown period,
+// after which the (by then expired) window is evaluated again.
+type LoadShedder struct {
+	config        LoadShedderConfig
+	logger        *slog.Logger
+	mu            sync.Mutex
+	samples       []callSample
+	next          int
+	sheddingSince time.Time
+	shedUntil     time.Time
+	shedRequests  int64
+}
+
+func NewLoadShedder(config LoadShedderConfig, logger *slog.Logger) *LoadShedder {
+	if config.WindowSize <= 0 {
+		config.WindowSize = 200
+	}
+	if config.Window <= 0 {
+		config.Window = 30 * time.Second
+	}
+	if config.MinSamples <= 0 {
+		config.MinSamples = 20
+	}
+	if config.MaxErrorRate <= 0 {
+		config.MaxErrorRate = 0.5
+	}
+	if config.LatencyThreshold <= 0 {
+		config.LatencyThreshold = 2 * time.Second
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = config.Window
+	}
+
+	return &LoadShedder{
+		config:  config,
+		logger:  logger,
+		samples: make([]callSample, 0, config.WindowSize),
+	}
+}
+
+func (s *LoadShedder) Record(duration time.Duration, err error) {
+	if s == nil || !s.config.Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sample := callSample{at: time.Now(), duration: duration, failed: err != nil}
+	if len(s.samples) < s.config.WindowSize {
+		s.samples = append(s.samples, sample)
+	} else {
+		s.samples[s.next] = sample
+	}
+	s.next = (s.next + 1) % s.config.WindowSize
+}
+
+func (s *LoadShedder) ShouldShed() (bool, time.Duration) {
+	if s == nil || !s.config.Enabled {
+		return false, 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(s.shedUntil) {
+		return true, s.shedUntil.Sub(now)
+	}
+
+	count, errorRate, p95 := s.windowStats(now)
+	overloaded := count >= s.config.MinSamples &&
+		(errorRate >= s.config.MaxErrorRate || p95 >= s.config.LatencyThreshold)
+
+	if !overloaded {
+		if !s.sheddingSince.IsZero() {
+			s.logger.Info("Search engine recovered, accepting requests again",
+				"shed_for", now.Sub(s.sheddingSince))
+			s.sheddingSince = time.Time{}
+		}
+		return false, 0
+	}
+
+	if s.sheddingSince.IsZero() {
+		s.sheddingSince = now
+		s.logger.Warn("Search engine degraded, shedding requests",
+			"error_rate", errorRate,
+			"p95_latency", p95,
+			"samples", count,
+			"cooldown", s.config.Cooldown)
+	}
+	s.shedUntil = now.Add(s.config.Cooldown)
+
+	return true, s.config.Cooldown
+}
+
+func (s *LoadShedder) RecordShed() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.shedRequests++
+	s.mu.Unlock()
+}
+
+func (s *LoadShedder) LoadStatus() search.LoadStatus {
+	if s == nil {
+		return search.LoadStatus{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	count, errorRate, p95 := s.windowStats(now)
+	status := search.LoadStatus{
+		Enabled:      s.config.Enabled,
+		Shedding:     now.Before(s.shedUntil),
+		Samples:      count,
+		ErrorRate:    errorRate,
+		P95Latency:   p95,
+		ShedRequests: s.shedRequests,
+	}
+	if status.Shedding {
+		since := s.sheddingSince.UTC()
+		status.SheddingSince = &since
+	}
+
+	return status
+}
+
+func (s *LoadShedder) windowStats(now time.Time) (count int, errorRate float64, p95 time.Duration) {
+	cutoff := now.Add(-s.config.Window)
+	durations := make([]time.Duration, 0, len(s.samples))
+	failed := 0
+
+	for _, sample := range s.samples {
+		if sample.at.Before(cutoff) {
+			continue
+		}
+		durations = append(durations, sample.duration)
+		if sample.failed {
+			failed++
+		}
+	}
+
+	count = len(durations)
+	if count == 0 {
+		return 0, 0, 0
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	index := (count*95+99)/100 - 1
+
+	return count, float64(failed) / float64(count), durations[index]
+}
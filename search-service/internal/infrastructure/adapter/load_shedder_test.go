@@ -0,0 +1,92 @@
+package adapter
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLoadShedder() *LoadShedder {
+	return NewLoadShedder(LoadShedderConfig{
+		Enabled:          true,
+		WindowSize:       10,
+		Window:           50 * time.Millisecond,
+		MinSamples:       4,
+		MaxErrorRate:     0.5,
+		LatencyThreshold: 100 * time.Millisecond,
+		Cooldown:         20 * time.Millisecond,
+	}, slog.New(slog.DiscardHandler))
+}
+
+func recordCalls(shedder *LoadShedder, count int, duration time.Duration, err error) {
+	for range count {
+		shedder.Record(duration, err)
+	}
+}
+
+func TestLoadShedderShedsDuringErrorPhase(t *testing.T) {
+	shedder := newTestLoadShedder()
+
+	recordCalls(shedder, 4, time.Millisecond, nil)
+	shed, _ := shedder.ShouldShed()
+	assert.False(t, shed)
+
+	recordCalls(shedder, 4, time.Millisecond, errors.New("unavailable"))
+	shed, retryAfter := shedder.ShouldShed()
+	assert.True(t, shed)
+	assert.Equal(t, 20*time.Millisecond, retryAfter)
+
+	status := shedder.LoadStatus()
+	assert.True(t, status.Shedding)
+	assert.Equal(t, 0.5, status.ErrorRate)
+	assert.NotNil(t, status.SheddingSince)
+}
+
+func TestLoadShedderShedsDuringSlowPhase(t *testing.T) {
+	shedder := newTestLoadShedder()
+
+	recordCalls(shedder, 4, 150*time.Millisecond, nil)
+	shed, _ := shedder.ShouldShed()
+	assert.True(t, shed)
+	assert.Equal(t, 150*time.Millisecond, shedder.LoadStatus().P95Latency)
+}
+
+func TestLoadShedderIgnoresTooFewSamples(t *testing.T) {
+	shedder := newTestLoadShedder()
+
+	recordCalls(shedder, 3, time.Millisecond, errors.New("unavailable"))
+	shed, _ := shedder.ShouldShed()
+	assert.False(t, shed)
+}
+
+func TestLoadShedderRecoversAfterCooldown(t *testing.T) {
+	shedder := newTestLoadShedder()
+
+	recordCalls(shedder, 4, time.Millisecond, errors.New("unavailable"))
+	shed, _ := shedder.ShouldShed()
+	assert.True(t, shed)
+	shedder.RecordShed()
+
+	// Once the failed calls leave the window and the cooldown is over, requests go through.
+	time.Sleep(60 * time.Millisecond)
+	recordCalls(shedder, 4, time.Millisecond, nil)
+	shed, _ = shedder.ShouldShed()
+	assert.False(t, shed)
+
+	status := shedder.LoadStatus()
+	assert.False(t, status.Shedding)
+	assert.Nil(t, status.SheddingSince)
+	assert.Equal(t, int64(1), status.ShedRequests)
+}
+
+func TestLoadShedderDisabled(t *testing.T) {
+	shedder := NewLoadShedder(LoadShedderConfig{MinSamples: 1}, slog.New(slog.DiscardHandler))
+
+	recordCalls(shedder, 10, time.Second, errors.New("unavailable"))
+	shed, _ := shedder.ShouldShed()
+	assert.False(t, shed)
+	assert.Zero(t, shedder.LoadStatus().Samples)
+}
@@ -0,0 +1,168 @@
+package adapter
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+type LocalCacheConfig struct {
+	MaxEntries int
+	TTL        time.Duration
+	// KeyPrefixes selects the keys kept in process. Every other key, such as admin job state
+	// and cursors, bypasses the local layer.
+	KeyPrefixes []string
+	// OnLookup is called with the outcome of every local lookup, to export the hit ratio.
+	OnLookup func(hit bool)
+}
+
+type localCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LocalCache is a size-bounded LRU in front of another cache, so the hottest hotels and
+// searches skip the Redis round trip. Entries live for at most TTL: a Delete on this instance
+// clears both layers, while other instances keep serving their copy until it expires.
+type LocalCache struct {
+	next   hotel.CacheRepository
+	config LocalCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func NewLocalCache(next hotel.CacheRepository, config LocalCacheConfig) *LocalCache {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 1000
+	}
+	if config.TTL <= 0 {
+		config.TTL = 5 * time.Second
+	}
+	if config.OnLookup == nil {
+		config.OnLookup = func(bool) {}
+	}
+
+	return &LocalCache{
+		next:    next,
+		config:  config,
+		now:     time.Now,
+		entries: make(map[string]*list.Element, config.MaxEntries),
+		order:   list.New(),
+	}
+}
+
+func (c *LocalCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if !c.cacheable(key) {
+		return c.next.Get(ctx, key)
+	}
+
+	if value, ok := c.lookup(key); ok {
+		c.config.OnLookup(true)
+		return value, nil
+	}
+	c.config.OnLookup(false)
+
+	value, err := c.next.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, value, c.config.TTL)
+	return value, nil
+}
+
+func (c *LocalCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.next.Set(ctx, key, value, ttl); err != nil {
+		c.remove(key)
+		return err
+	}
+
+	if c.cacheable(key) {
+		localTTL := c.config.TTL
+		if ttl > 0 {
+			localTTL = min(localTTL, ttl)
+		}
+		c.store(key, value, localTTL)
+	}
+	return nil
+}
+
+// Delete clears the key from both layers.
+func (c *LocalCache) Delete(ctx context.Context, key string) error {
+	c.remove(key)
+	return c.next.Delete(ctx, key)
+}
+
+func (c *LocalCache) Exists(ctx context.Context, key string) (bool, error) {
+	if c.cacheable(key) {
+		if _, ok := c.lookup(key); ok {
+			return true, nil
+		}
+	}
+	return c.next.Exists(ctx, key)
+}
+
+func (c *LocalCache) cacheable(key string) bool {
+	for _, prefix := range c.config.KeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LocalCache) lookup(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*localCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *LocalCache) store(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*localCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&localCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.config.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*localCacheEntry).key)
+	}
+}
+
+func (c *LocalCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
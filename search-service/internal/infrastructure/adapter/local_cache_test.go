@@ -0,0 +1,230 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+var errCacheMiss = errors.New("cache miss")
+
+// remoteCache is an in-memory hotel.CacheRepository standing in for Redis. It counts the
+// lookups that reach it and waits latency on each call.
+type remoteCache struct {
+	latency time.Duration
+	failSet bool
+
+	mu     sync.Mutex
+	values map[string][]byte
+	gets   int
+}
+
+func newRemoteCache() *remoteCache {
+	return &remoteCache{values: map[string][]byte{}}
+}
+
+func (c *remoteCache) Get(_ context.Context, key string) ([]byte, error) {
+	time.Sleep(c.latency)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gets++
+	value, ok := c.values[key]
+	if !ok {
+		return nil, errCacheMiss
+	}
+	return value, nil
+}
+
+func (c *remoteCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	time.Sleep(c.latency)
+	if c.failSet {
+		return errors.New("redis unavailable")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *remoteCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	return nil
+}
+
+func (c *remoteCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+// newTestLocalCache returns a local cache of hotel and search keys over remote, with a clock
+// the test moves.
+func newTestLocalCache(remote *remoteCache, config LocalCacheConfig) (*LocalCache, *time.Time) {
+	config.KeyPrefixes = []string{"hotel:", "search:"}
+	cache := NewLocalCache(remote, config)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestLocalCacheServesRepeatedLookupsInProcess(t *testing.T) {
+	remote := newRemoteCache()
+	remote.values["hotel:1"] = []byte(`{"hotel_id":1}`)
+	var hits, misses int
+	cache, _ := newTestLocalCache(remote, LocalCacheConfig{TTL: 5 * time.Second, OnLookup: func(hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	}})
+	ctx := context.Background()
+
+	for range 3 {
+		value, err := cache.Get(ctx, "hotel:1")
+		require.NoError(t, err)
+		assert.Equal(t, `{"hotel_id":1}`, string(value))
+	}
+
+	assert.Equal(t, 1, remote.gets, "only the first lookup reaches Redis")
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, misses)
+}
+
+func TestLocalCacheExpiresEntriesAfterTheTTL(t *testing.T) {
+	remote := newRemoteCache()
+	cache, now := newTestLocalCache(remote, LocalCacheConfig{TTL: 5 * time.Second})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "hotel:1", []byte("v1"), time.Minute))
+	// Another instance updates Redis; this one keeps its copy until the TTL ends.
+	remote.values["hotel:1"] = []byte("v2")
+
+	*now = now.Add(4 * time.Second)
+	value, err := cache.Get(ctx, "hotel:1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(value))
+
+	*now = now.Add(time.Second)
+	value, err = cache.Get(ctx, "hotel:1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(value), "an expired entry is read from Redis again")
+}
+
+func TestLocalCacheNeverOutlivesTheRedisTTL(t *testing.T) {
+	remote := newRemoteCache()
+	cache, now := newTestLocalCache(remote, LocalCacheConfig{TTL: 5 * time.Second})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "search:abc", []byte("results"), 2*time.Second))
+	delete(remote.values, "search:abc")
+
+	*now = now.Add(2 * time.Second)
+	_, err := cache.Get(ctx, "search:abc")
+	assert.ErrorIs(t, err, errCacheMiss)
+}
+
+func TestLocalCacheDeleteClearsBothLayers(t *testing.T) {
+	remote := newRemoteCache()
+	cache, _ := newTestLocalCache(remote, LocalCacheConfig{})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "hotel:1", []byte("cached"), time.Minute))
+	require.NoError(t, cache.Delete(ctx, "hotel:1"))
+
+	_, err := cache.Get(ctx, "hotel:1")
+	assert.ErrorIs(t, err, errCacheMiss)
+	assert.NotContains(t, remote.values, "hotel:1")
+	exists, err := cache.Exists(ctx, "hotel:1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestLocalCacheBypassesOtherKeys(t *testing.T) {
+	remote := newRemoteCache()
+	cache, _ := newTestLocalCache(remote, LocalCacheConfig{})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "index_backfill:cursor:name", []byte("10"), time.Hour))
+	remote.values["index_backfill:cursor:name"] = []byte("20")
+
+	value, err := cache.Get(ctx, "index_backfill:cursor:name")
+	require.NoError(t, err)
+	assert.Equal(t, "20", string(value), "admin state is always read from Redis")
+	assert.Empty(t, cache.entries)
+}
+
+func TestLocalCacheDropsTheEntryWhenRedisRejectsAWrite(t *testing.T) {
+	remote := newRemoteCache()
+	cache, _ := newTestLocalCache(remote, LocalCacheConfig{})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "hotel:1", []byte("v1"), time.Minute))
+	remote.failSet = true
+	require.Error(t, cache.Set(ctx, "hotel:1", []byte("v2"), time.Minute))
+
+	value, err := cache.Get(ctx, "hotel:1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(value), "the value Redis holds is served")
+	assert.Equal(t, 1, remote.gets)
+}
+
+func TestLocalCacheEvictsTheLeastRecentlyUsedEntry(t *testing.T) {
+	remote := newRemoteCache()
+	cache, _ := newTestLocalCache(remote, LocalCacheConfig{MaxEntries: 2})
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "hotel:1", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "hotel:2", []byte("2"), time.Minute))
+	_, err := cache.Get(ctx, "hotel:1")
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, "hotel:3", []byte("3"), time.Minute))
+
+	assert.Len(t, cache.entries, 2)
+	assert.Contains(t, cache.entries, "hotel:1")
+	assert.Contains(t, cache.entries, "hotel:3")
+	assert.NotContains(t, cache.entries, "hotel:2")
+}
+
+// BenchmarkHotelDetailCache reads and decodes a cached hotel the way the hotel detail endpoint
+// does, from Redis alone and through the local layer. The simulated Redis round trip is 200µs.
+func BenchmarkHotelDetailCache(b *testing.B) {
+	detail := &hotel.Hotel{HotelID: 1641879, Name: "Harbour Hotel", Photos: make([]hotel.Photo, 40), Reviews: make([]hotel.Review, 50)}
+	value, err := json.Marshal(detail)
+	require.NoError(b, err)
+
+	remote := newRemoteCache()
+	remote.latency = 200 * time.Microsecond
+	remote.values["hotel:1641879"] = value
+
+	caches := map[string]hotel.CacheRepository{
+		"redis": remote,
+		"local": NewLocalCache(remote, LocalCacheConfig{TTL: time.Minute, KeyPrefixes: []string{"hotel:"}}),
+	}
+	for _, name := range []string{"redis", "local"} {
+		b.Run(name, func(b *testing.B) {
+			ctx := context.Background()
+			for b.Loop() {
+				data, err := caches[name].Get(ctx, "hotel:1641879")
+				if err != nil {
+					b.Fatal(err)
+				}
+				var cached hotel.Hotel
+				if err := json.Unmarshal(data, &cached); err != nil {
+					b.Fatal(fmt.Errorf("decode: %w", err))
+				}
+			}
+		})
+	}
+}
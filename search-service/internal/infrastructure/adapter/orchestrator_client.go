@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/grpcjson"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/pipeline"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const getQueueStatsMethod = "/orchestrator.OrchestratorService/GetQueueStats"
+
+// queueStatsResponse mirrors orchestrator.QueueStatsResponse. The orchestrator speaks the JSON
+// codec, so plain structs with the proto field names are enough on this side.
+type queueStatsResponse struct {
+	MainQueue     string `json:"main_queue"`
+	MainDepth     int64  `json:"main_depth"`
+	DLQQueue      string `json:"dlq_queue"`
+	DLQDepth      int64  `json:"dlq_depth"`
+	LastEnqueueAt int64  `json:"last_enqueue_at"`
+	Timestamp     int64  `json:"timestamp"`
+	DelayQueue    string `json:"delay_queue"`
+	DelayDepth    int64  `json:"delay_depth"`
+}
+
+// OrchestratorClient reads the fetcher pipeline backlog from the orchestrator's gRPC API.
+type OrchestratorClient struct {
+	conn    *grpc.ClientConn
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// NewOrchestratorClient does not connect; the connection is made on the first call, so an
+// orchestrator that is down at startup does not stop the search service.
+func NewOrchestratorClient(address string, timeout time.Duration, logger *slog.Logger) (*OrchestratorClient, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(grpcjson.Codec{})))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator client: %w", err)
+	}
+
+	return &OrchestratorClient{
+		conn:    conn,
+		timeout: timeout,
+		logger:  logger,
+	}, nil
+}
+
+func (c *OrchestratorClient) PipelineStats(ctx context.Context) (*pipeline.Stats, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var response queueStatsResponse
+	if err := c.conn.Invoke(ctx, getQueueStatsMethod, struct{}{}, &response); err != nil {
+		return nil, fmt.Errorf("failed to get orchestrator queue stats: %w", err)
+	}
+
+	stats := &pipeline.Stats{MainDepth: response.MainDepth}
+	if response.DelayQueue != "" {
+		delayDepth := response.DelayDepth
+		stats.DelayDepth = &delayDepth
+	}
+	if response.DLQQueue != "" {
+		dlqDepth := response.DLQDepth
+		stats.DLQDepth = &dlqDepth
+	}
+	if response.LastEnqueueAt > 0 {
+		lastEnqueueAt := time.Unix(response.LastEnqueueAt, 0).UTC()
+		stats.LastEnqueueAt = &lastEnqueueAt
+	}
+
+	return stats, nil
+}
+
+func (c *OrchestratorClient) Close() error {
+	return c.conn.Close()
+}
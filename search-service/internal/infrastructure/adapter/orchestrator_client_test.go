@@ -0,0 +1,144 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/grpcjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOrchestrator answers GetQueueStats over the JSON codec, as the orchestrator does.
+type fakeOrchestrator struct {
+	response queueStatsResponse
+	err      error
+	delay    time.Duration
+}
+
+func (f *fakeOrchestrator) getQueueStats(_ any, ctx context.Context, decode func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+	var request struct{}
+	if err := decode(&request); err != nil {
+		return nil, err
+	}
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.response, nil
+}
+
+// startFakeOrchestrator serves fake on a local port and returns its address.
+func startFakeOrchestrator(t *testing.T, fake *fakeOrchestrator) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.ForceServerCodec(grpcjson.Codec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "orchestrator.OrchestratorService",
+		HandlerType: (*any)(nil),
+		Methods:     []grpc.MethodDesc{{MethodName: "GetQueueStats", Handler: fake.getQueueStats}},
+	}, fake)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func newTestOrchestratorClient(t *testing.T, address string, timeout time.Duration) *OrchestratorClient {
+	t.Helper()
+	client, err := NewOrchestratorClient(address, timeout, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestOrchestratorClientReadsPipelineStats(t *testing.T) {
+	address := startFakeOrchestrator(t, &fakeOrchestrator{response: queueStatsResponse{
+		MainQueue:     "hotel_jobs",
+		MainDepth:     4_200,
+		DelayQueue:    "hotel_jobs.delay",
+		DelayDepth:    35,
+		DLQQueue:      "hotel.dlq.queue",
+		DLQDepth:      7,
+		LastEnqueueAt: 1_790_000_000,
+		Timestamp:     1_790_000_060,
+	}})
+	client := newTestOrchestratorClient(t, address, time.Second)
+
+	stats, err := client.PipelineStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4_200), stats.MainDepth)
+	require.NotNil(t, stats.DelayDepth)
+	assert.Equal(t, int64(35), *stats.DelayDepth)
+	require.NotNil(t, stats.DLQDepth)
+	assert.Equal(t, int64(7), *stats.DLQDepth)
+	require.NotNil(t, stats.LastEnqueueAt)
+	assert.Equal(t, time.Unix(1_790_000_000, 0).UTC(), *stats.LastEnqueueAt)
+}
+
+func TestOrchestratorClientLeavesOutUnreportedQueues(t *testing.T) {
+	// An empty queue that is reported keeps its zero depth; an unreported one is left out.
+	address := startFakeOrchestrator(t, &fakeOrchestrator{response: queueStatsResponse{
+		MainQueue:  "hotel_jobs",
+		MainDepth:  0,
+		DelayQueue: "hotel_jobs.delay",
+	}})
+	client := newTestOrchestratorClient(t, address, time.Second)
+
+	stats, err := client.PipelineStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Zero(t, stats.MainDepth)
+	require.NotNil(t, stats.DelayDepth)
+	assert.Zero(t, *stats.DelayDepth)
+	assert.Nil(t, stats.DLQDepth)
+	assert.Nil(t, stats.LastEnqueueAt)
+}
+
+func TestOrchestratorClientReturnsServerErrors(t *testing.T) {
+	address := startFakeOrchestrator(t, &fakeOrchestrator{err: status.Error(codes.Unavailable, "failed to inspect main queue")})
+	client := newTestOrchestratorClient(t, address, time.Second)
+
+	_, err := client.PipelineStats(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestOrchestratorClientTimesOutOnSlowServer(t *testing.T) {
+	address := startFakeOrchestrator(t, &fakeOrchestrator{delay: 5 * time.Second})
+	client := newTestOrchestratorClient(t, address, 100*time.Millisecond)
+
+	start := time.Now()
+	_, err := client.PipelineStats(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestOrchestratorClientFailsWhenUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	client := newTestOrchestratorClient(t, address, 200*time.Millisecond)
+
+	start := time.Now()
+	_, err = client.PipelineStats(context.Background())
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
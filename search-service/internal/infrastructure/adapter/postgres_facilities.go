@@ -0,0 +1,144 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/gorm"
+)
+
+// The columns are checked to be arrays first: hotels without facilities store NULL or an
+// empty document, which jsonb_array_elements rejects.
+const (
+	countAmenitiesQuery = `
+SELECT amenity AS slug, COUNT(*) AS hotel_count
+FROM hotels,
+     jsonb_array_elements_text(CASE WHEN jsonb_typeof(amenities) = 'array' THEN amenities ELSE '[]'::jsonb END) AS amenity
+WHERE status = 'active' AND deleted_at IS NULL
+GROUP BY amenity
+ORDER BY hotel_count DESC, amenity ASC`
+
+	countRawFacilitiesQuery = `
+SELECT facility->>'name' AS name, COUNT(DISTINCT hotels.id) AS hotel_count
+FROM hotels,
+     jsonb_array_elements(CASE WHEN jsonb_typeof(facilities) = 'array' THEN facilities ELSE '[]'::jsonb END) AS facility
+WHERE status = 'active' AND deleted_at IS NULL AND COALESCE(facility->>'name', '') <> ''
+GROUP BY name
+ORDER BY hotel_count DESC, name ASC`
+)
+
+// CountAmenities counts the active hotels listing each canonical facility slug.
+func (r *PostgresHotelRepository) CountAmenities(ctx context.Context) ([]hotel.FacilityCount, error) {
+	var counts []hotel.FacilityCount
+	if err := r.db.WithContext(ctx).Raw(countAmenitiesQuery).Scan(&counts).Error; err != nil {
+		r.logger.Error("Failed to count amenities", "error", err)
+		return nil, fmt.Errorf("failed to count amenities: %w", err)
+	}
+	return counts, nil
+}
+
+// CountRawFacilities counts the active hotels listing each facility name as Cupid sent it.
+func (r *PostgresHotelRepository) CountRawFacilities(ctx context.Context) ([]hotel.FacilityCount, error) {
+	var counts []hotel.FacilityCount
+	if err := r.db.WithContext(ctx).Raw(countRawFacilitiesQuery).Scan(&counts).Error; err != nil {
+		r.logger.Error("Failed to count raw facilities", "error", err)
+		return nil, fmt.Errorf("failed to count raw facilities: %w", err)
+	}
+	return counts, nil
+}
+
+// NormalizeFacilities sets the slug of every facility of the next hotelLimit active hotels
+// after afterHotelID, and their amenities to the distinct slugs, in one transaction. Only the
+// slug of each stored facility is rewritten; its other keys are kept as Cupid sent them.
+// Hotels that are already normalized are left untouched, so re-running it updates nothing.
+func (r *PostgresHotelRepository) NormalizeFacilities(ctx context.Context, afterHotelID int64, hotelLimit int, normalize func(name string) string) (hotel.FacilityNormalizeBatch, error) {
+	batch := hotel.FacilityNormalizeBatch{Updated: make(map[int64][]string)}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var models []entities.HotelData
+		err := tx.Select("id", "hotel_id", "facilities", "amenities").
+			Where("hotel_id > ? AND status = ?", afterHotelID, "active").
+			Order("hotel_id ASC").
+			Limit(hotelLimit).
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+		batch.Hotels = len(models)
+		batch.LastHotelID = models[len(models)-1].HotelID
+
+		for _, model := range models {
+			facilities, amenities, err := normalizeStoredFacilities(model.Facilities, normalize)
+			if err != nil {
+				r.logger.Warn("Skipping hotel with unreadable facilities", "hotel_id", model.HotelID, "error", err)
+				continue
+			}
+
+			var stored []string
+			_ = json.Unmarshal(model.Amenities, &stored)
+			if facilities == nil && slices.Equal(amenities, stored) {
+				continue
+			}
+
+			amenitiesJSON, err := json.Marshal(amenities)
+			if err != nil {
+				return err
+			}
+			columns := map[string]any{"amenities": amenitiesJSON}
+			if facilities != nil {
+				columns["facilities"] = facilities
+			}
+			if err := tx.Model(&entities.HotelData{}).Where("id = ?", model.ID).UpdateColumns(columns).Error; err != nil {
+				return err
+			}
+			batch.Updated[model.HotelID] = amenities
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to normalize facilities", "after_hotel_id", afterHotelID, "error", err)
+		return hotel.FacilityNormalizeBatch{}, fmt.Errorf("failed to normalize facilities after hotel %d: %w", afterHotelID, err)
+	}
+
+	return batch, nil
+}
+
+// normalizeStoredFacilities sets the slug of every facility of the stored facilities document.
+// It returns the rewritten document, nil when no slug changed, and the distinct slugs in
+// facility order.
+func normalizeStoredFacilities(data []byte, normalize func(name string) string) ([]byte, []string, error) {
+	amenities := make([]string, 0)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, amenities, nil
+	}
+
+	var facilities []map[string]any
+	if err := json.Unmarshal(data, &facilities); err != nil {
+		return nil, nil, err
+	}
+	changed := false
+	for _, facility := range facilities {
+		name, _ := facility["name"].(string)
+		slug := normalize(name)
+		if stored, _ := facility["slug"].(string); stored != slug {
+			changed = true
+		}
+		if slug == "" {
+			delete(facility, "slug")
+			continue
+		}
+		facility["slug"] = slug
+		if !slices.Contains(amenities, slug) {
+			amenities = append(amenities, slug)
+		}
+	}
+
+	if !changed {
+		return nil, amenities, nil
+	}
+	normalized, err := json.Marshal(facilities)
+	return normalized, amenities, err
+}
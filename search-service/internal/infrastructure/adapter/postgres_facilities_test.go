@@ -0,0 +1,89 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"gorm.io/datatypes"
+)
+
+func storeHotelWithFacilities(t *testing.T, repository *PostgresHotelRepository, hotelID int64, status, facilitiesJSON, amenitiesJSON string) {
+	t.Helper()
+	model := &entities.HotelData{
+		HotelID:    hotelID,
+		CupidID:    hotelID,
+		Name:       "Hotel",
+		Status:     status,
+		Facilities: datatypes.JSON(facilitiesJSON),
+		Amenities:  datatypes.JSON(amenitiesJSON),
+	}
+	require.NoError(t, repository.db.Create(model).Error)
+}
+
+func TestNormalizeFacilitiesSetsSlugsAndAmenities(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	ctx := context.Background()
+	taxonomy := facilities.Default()
+	normalize := func(name string) string {
+		slug, _ := taxonomy.Normalize(name)
+		return slug
+	}
+
+	storeHotelWithFacilities(t, repository, 1, "active",
+		`[{"facility_id": 47, "name": "Free WiFi"}, {"facility_id": 3, "name": "wi-fi"}, {"facility_id": 9, "name": "Rooftop Garden"}]`, `[]`)
+	storeHotelWithFacilities(t, repository, 2, "active",
+		`[{"facility_id": 5, "name": "Sauna", "slug": "spa"}]`, `["spa"]`)
+	storeHotelWithFacilities(t, repository, 3, "inactive", `[{"facility_id": 47, "name": "Free WiFi"}]`, `[]`)
+	storeHotelWithFacilities(t, repository, 4, "active", ``, ``)
+
+	batch, err := repository.NormalizeFacilities(ctx, 0, 10, normalize)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, batch.Hotels, "inactive hotels are not normalized")
+	assert.Equal(t, int64(4), batch.LastHotelID)
+	assert.Equal(t, map[int64][]string{1: {"wifi", "rooftop_garden"}}, batch.Updated,
+		"hotels already normalized, or without facilities, are left untouched")
+
+	rows := loadHotelRows(t, db, 1)
+	require.Len(t, rows, 1)
+	assert.JSONEq(t, `[
+		{"facility_id": 47, "name": "Free WiFi", "slug": "wifi"},
+		{"facility_id": 3, "name": "wi-fi", "slug": "wifi"},
+		{"facility_id": 9, "name": "Rooftop Garden", "slug": "rooftop_garden"}
+	]`, string(rows[0].Facilities), "the raw names and other keys are kept")
+	assert.JSONEq(t, `["wifi", "rooftop_garden"]`, string(rows[0].Amenities))
+
+	inactive := loadHotelRows(t, db, 3)
+	assert.JSONEq(t, `[]`, string(inactive[0].Amenities))
+
+	batch, err = repository.NormalizeFacilities(ctx, 0, 10, normalize)
+	require.NoError(t, err)
+	assert.Empty(t, batch.Updated, "a second run updates nothing")
+}
+
+func TestNormalizeFacilitiesPagesByHotelID(t *testing.T) {
+	repository, _ := newSQLiteHotelRepository(t)
+	ctx := context.Background()
+	for hotelID := int64(1); hotelID <= 3; hotelID++ {
+		storeHotelWithFacilities(t, repository, hotelID, "active", `[{"name": "Gym"}]`, `[]`)
+	}
+	normalize := func(name string) string { return facilities.Slugify(name) }
+
+	batch, err := repository.NormalizeFacilities(ctx, 0, 2, normalize)
+	require.NoError(t, err)
+	assert.Equal(t, 2, batch.Hotels)
+	assert.Equal(t, int64(2), batch.LastHotelID)
+
+	batch, err = repository.NormalizeFacilities(ctx, batch.LastHotelID, 2, normalize)
+	require.NoError(t, err)
+	assert.Equal(t, 1, batch.Hotels)
+	assert.Equal(t, map[int64][]string{3: {"gym"}}, batch.Updated)
+
+	batch, err = repository.NormalizeFacilities(ctx, batch.LastHotelID, 2, normalize)
+	require.NoError(t, err)
+	assert.Zero(t, batch.Hotels)
+}
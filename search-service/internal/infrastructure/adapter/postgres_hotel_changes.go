@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/gorm"
+)
+
+// UpdateWithChange stores the hotel and records the change in one transaction.
+func (r *PostgresHotelRepository) UpdateWithChange(ctx context.Context, h *hotel.Hotel, change hotel.Change) error {
+	hotelModel, err := r.convertDomainToModel(h)
+	if err != nil {
+		return fmt.Errorf("failed to convert domain to model: %w", err)
+	}
+	hotelModel.UpdatedAt = change.ChangedAt
+
+	changes, err := json.Marshal(change.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes of hotel %d: %w", h.HotelID, err)
+	}
+	row := &entities.HotelChange{
+		HotelID:         h.HotelID,
+		Changes:         changes,
+		SourceMessageID: change.SourceMessageID,
+		Actor:           change.Actor,
+		ChangedAt:       change.ChangedAt,
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := mergeStoredSourceMappings(tx, hotelModel); err != nil {
+			return err
+		}
+		if err := tx.Save(hotelModel).Error; err != nil {
+			return err
+		}
+		return tx.Create(row).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to update hotel with change", "hotel_id", h.HotelID, "error", err)
+		return fmt.Errorf("failed to update hotel %d: %w", h.HotelID, err)
+	}
+
+	return nil
+}
+
+// ListChanges returns the recorded field changes of a hotel, most recent first.
+func (r *PostgresHotelRepository) ListChanges(ctx context.Context, hotelID int64, options hotel.ListChangesOptions) ([]hotel.Change, error) {
+	var rows []entities.HotelChange
+	err := r.db.WithContext(ctx).
+		Where("hotel_id = ?", hotelID).
+		Order("changed_at DESC, id DESC").
+		Limit(options.Limit).
+		Offset(options.Offset).
+		Find(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to list hotel changes", "hotel_id", hotelID, "error", err)
+		return nil, fmt.Errorf("failed to list changes of hotel %d: %w", hotelID, err)
+	}
+
+	changes := make([]hotel.Change, 0, len(rows))
+	for _, row := range rows {
+		change := hotel.Change{
+			HotelID:         row.HotelID,
+			SourceMessageID: row.SourceMessageID,
+			Actor:           row.Actor,
+			ChangedAt:       row.ChangedAt.UTC(),
+		}
+		if err := json.Unmarshal(row.Changes, &change.Changes); err != nil {
+			r.logger.Warn("Failed to decode hotel change", "id", row.ID, "error", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,76 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/datatypes"
+)
+
+func TestListChangesPagesMostRecentFirst(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	require.NoError(t, db.Migrator().CreateTable(&entities.HotelChange{}))
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		require.NoError(t, db.Create(&entities.HotelChange{
+			HotelID:         7,
+			Changes:         datatypes.JSON(`{"rating":{"old":4.5,"new":3.1}}`),
+			SourceMessageID: "message-" + string(rune('a'+i)),
+			ChangedAt:       start.Add(time.Duration(i) * time.Hour),
+		}).Error)
+	}
+	require.NoError(t, db.Create(&entities.HotelChange{HotelID: 8, Changes: datatypes.JSON(`{}`), ChangedAt: start}).Error)
+
+	ctx := context.Background()
+	first, err := repository.ListChanges(ctx, 7, hotel.ListChangesOptions{Limit: 2})
+	require.NoError(t, err)
+	second, err := repository.ListChanges(ctx, 7, hotel.ListChangesOptions{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	last, err := repository.ListChanges(ctx, 7, hotel.ListChangesOptions{Limit: 2, Offset: 4})
+	require.NoError(t, err)
+
+	var messageIDs []string
+	for _, page := range [][]hotel.Change{first, second, last} {
+		for _, change := range page {
+			messageIDs = append(messageIDs, change.SourceMessageID)
+		}
+	}
+	assert.Equal(t, []string{"message-e", "message-d", "message-c", "message-b", "message-a"}, messageIDs)
+	assert.Equal(t, map[string]hotel.FieldChange{"rating": {Old: 4.5, New: 3.1}}, first[0].Changes)
+	assert.Equal(t, start.Add(4*time.Hour), first[0].ChangedAt)
+}
+
+func TestUpdateWithChangeRecordsTheCorrection(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	require.NoError(t, db.Migrator().CreateTable(&entities.HotelChange{}))
+	stored := storeWorkerHotel(t, db, 7, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC))
+	h, err := repository.convertModelToDomain(stored)
+	require.NoError(t, err)
+	h.Name = "Corrected name"
+	changedAt := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+
+	err = repository.UpdateWithChange(context.Background(), h, hotel.Change{
+		HotelID:   7,
+		Changes:   map[string]hotel.FieldChange{"name": {Old: "Worker name", New: "Corrected name"}},
+		Actor:     "support@example.com",
+		ChangedAt: changedAt,
+	})
+	require.NoError(t, err)
+
+	rows := loadHotelRows(t, db, 7)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Corrected name", rows[0].Name)
+	changes, err := repository.ListChanges(context.Background(), 7, hotel.ListChangesOptions{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []hotel.Change{{
+		HotelID:   7,
+		Changes:   map[string]hotel.FieldChange{"name": {Old: "Worker name", New: "Corrected name"}},
+		Actor:     "support@example.com",
+		ChangedAt: changedAt,
+	}}, changes)
+}
@@ -0,0 +1,150 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/gorm"
+)
+
+const (
+	// kmPerDegree is the length of a degree of latitude, used to bound the pair search.
+	kmPerDegree = 111.32
+	// maxDuplicatePairs caps the pairs a single search returns.
+	maxDuplicatePairs = 10000
+)
+
+// findDuplicatePairsQuery pairs every active hotel with the hotels after it that lie in a box
+// around it and then checks the great-circle distance and trigram name similarity (pg_trgm).
+// The box keeps the self-join on the latitude index. Its longitude half-width widens with the
+// latitude, capped near the poles.
+const findDuplicatePairsQuery = `
+SELECT hotel_id, other_hotel_id, name_similarity, distance_km
+FROM (
+    SELECT a.hotel_id,
+           b.hotel_id AS other_hotel_id,
+           similarity(a.name, b.name) AS name_similarity,
+           6371 * 2 * ASIN(SQRT(
+               POWER(SIN(RADIANS(b.latitude - a.latitude) / 2), 2) +
+               COS(RADIANS(a.latitude)) * COS(RADIANS(b.latitude)) *
+               POWER(SIN(RADIANS(b.longitude - a.longitude) / 2), 2)
+           )) AS distance_km
+    FROM hotels a
+    JOIN hotels b
+      ON b.hotel_id > a.hotel_id
+     AND b.latitude BETWEEN a.latitude - ? AND a.latitude + ?
+     AND ABS(b.longitude - a.longitude) <= ? / GREATEST(COS(RADIANS(a.latitude)), 0.01)
+     AND b.status = 'active' AND b.deleted_at IS NULL
+    WHERE a.status = 'active' AND a.deleted_at IS NULL
+      AND NOT (ABS(a.latitude) < 0.001 AND ABS(a.longitude) < 0.001)
+      AND (NOT ? OR (COALESCE(a.chain, '') <> '' AND a.chain = b.chain))
+) pairs
+WHERE name_similarity >= ? AND distance_km <= ?
+ORDER BY hotel_id, other_hotel_id
+LIMIT ?`
+
+// FindDuplicates groups the active hotels within criteria.MaxDistanceKm of each other whose
+// names are at least criteria.NameSimilarityThreshold similar.
+func (r *PostgresHotelRepository) FindDuplicates(ctx context.Context, criteria hotel.DuplicateCriteria) ([]hotel.DuplicateGroup, error) {
+	degrees := criteria.MaxDistanceKm / kmPerDegree
+
+	var pairs []hotel.DuplicatePair
+	err := r.db.WithContext(ctx).
+		Raw(findDuplicatePairsQuery,
+			degrees, degrees, degrees,
+			criteria.SameChainOnly,
+			criteria.NameSimilarityThreshold, criteria.MaxDistanceKm,
+			maxDuplicatePairs).
+		Scan(&pairs).Error
+	if err != nil {
+		r.logger.Error("Failed to find duplicate hotels", "error", err)
+		return nil, fmt.Errorf("failed to find duplicate hotels: %w", err)
+	}
+	if len(pairs) == maxDuplicatePairs {
+		r.logger.Warn("Duplicate hotel search hit its pair limit, tighten the criteria to see the rest",
+			"limit", maxDuplicatePairs,
+			"name_similarity_threshold", criteria.NameSimilarityThreshold,
+			"max_distance_km", criteria.MaxDistanceKm)
+	}
+
+	for i := range pairs {
+		pairs[i].NameSimilarity = math.Round(pairs[i].NameSimilarity*1000) / 1000
+	}
+	return hotel.GroupDuplicates(pairs, criteria.MaxDistanceKm), nil
+}
+
+// MergeHotels moves the reviews, archived reviews and translations of duplicateID to
+// primaryID and soft-deletes duplicateID. Translations in a language the primary already has
+// are soft-deleted with it. The primary's review count is recounted from its reviews, and it
+// takes over the duplicate's archived review count and the source mappings it lacks.
+func (r *PostgresHotelRepository) MergeHotels(ctx context.Context, primaryID, duplicateID int64) (hotel.MergeResult, error) {
+	result := hotel.MergeResult{PrimaryID: primaryID, DuplicateID: duplicateID}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hotelIDs []int64
+		err := tx.Raw(`SELECT hotel_id FROM hotels WHERE hotel_id IN (?, ?) AND deleted_at IS NULL FOR UPDATE`, primaryID, duplicateID).
+			Scan(&hotelIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(hotelIDs) != 2 {
+			return hotel.ErrMergeHotelNotFound
+		}
+
+		moved := tx.Exec(`UPDATE reviews SET hotel_id = ?, updated_at = NOW() WHERE hotel_id = ? AND deleted_at IS NULL`, primaryID, duplicateID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.MovedReviews = moved.RowsAffected
+
+		archived := tx.Exec(`UPDATE reviews_archive SET hotel_id = ? WHERE hotel_id = ?`, primaryID, duplicateID)
+		if archived.Error != nil {
+			return archived.Error
+		}
+
+		// The (hotel_id, lang) index also covers soft-deleted translations, so a language the
+		// primary ever had is dropped rather than moved.
+		moved = tx.Exec(`
+UPDATE translations t SET hotel_id = ?, updated_at = NOW()
+WHERE t.hotel_id = ? AND t.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM translations p WHERE p.hotel_id = ? AND p.lang = t.lang)`,
+			primaryID, duplicateID, primaryID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.MovedTranslations = moved.RowsAffected
+
+		dropped := tx.Exec(`UPDATE translations SET deleted_at = NOW(), updated_at = NOW() WHERE hotel_id = ? AND deleted_at IS NULL`, duplicateID)
+		if dropped.Error != nil {
+			return dropped.Error
+		}
+		result.DroppedTranslations = dropped.RowsAffected
+
+		err = tx.Exec(`
+UPDATE hotels p
+SET archived_review_count = p.archived_review_count + ?,
+    source_mappings = COALESCE(d.source_mappings, '{}'::jsonb) || COALESCE(p.source_mappings, '{}'::jsonb),
+    updated_at = NOW()
+FROM hotels d
+WHERE p.hotel_id = ? AND d.hotel_id = ?`,
+			archived.RowsAffected, primaryID, duplicateID).Error
+		if err != nil {
+			return err
+		}
+
+		// Both review counts come from upstream and may count the same reviews, so the
+		// primary's is recounted from the reviews it now has rather than added up.
+		if err := tx.Exec(recountReviewsQuery, []int64{primaryID}).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`UPDATE hotels SET deleted_at = NOW(), updated_at = NOW() WHERE hotel_id = ?`, duplicateID).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to merge hotels", "primary_id", primaryID, "duplicate_id", duplicateID, "error", err)
+		return hotel.MergeResult{}, fmt.Errorf("failed to merge hotel %d into %d: %w", duplicateID, primaryID, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+func TestMergeHotelsRecountsThePrimaryReviews(t *testing.T) {
+	db := openTestPostgres(t)
+	repository := NewPostgresHotelRepository(db, nil, slog.New(slog.DiscardHandler))
+
+	// The upstream counts include reviews that were never fetched.
+	require.NoError(t, db.Create(&entities.HotelData{HotelID: 1, Name: "Hotel Arts", ReviewCount: 250}).Error)
+	require.NoError(t, db.Create(&entities.HotelData{HotelID: 2, Name: "Hotel Arts Barcelona", ReviewCount: 240}).Error)
+	seedDedupReview(t, db, 1, 100, "Ana", "Lovely stay", 8)
+	seedDedupReview(t, db, 1, 101, "Luc", "Noisy", 5)
+	seedDedupReview(t, db, 2, 200, "Marta", "Great views", 9)
+
+	result, err := repository.MergeHotels(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.MovedReviews)
+
+	assert.Equal(t, []int64{100, 101, 200}, liveReviewIDs(t, db, 1))
+	assert.Equal(t, int32(3), reviewCount(t, db, 1), "the review count is recounted from the stored reviews, not added up")
+	var duplicates int64
+	require.NoError(t, db.Model(&entities.HotelData{}).Where("hotel_id = ?", 2).Count(&duplicates).Error)
+	assert.Zero(t, duplicates, "the duplicate is soft-deleted")
+}
+
+func TestMergeHotelsOfAMissingHotel(t *testing.T) {
+	db := openTestPostgres(t)
+	repository := NewPostgresHotelRepository(db, nil, slog.New(slog.DiscardHandler))
+	require.NoError(t, db.Create(&entities.HotelData{HotelID: 1, Name: "Hotel Arts"}).Error)
+
+	_, err := repository.MergeHotels(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, hotel.ErrMergeHotelNotFound)
+}
@@ -6,24 +6,54 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/victoragudo/hotel-management-system/pkg/entities"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const HOTEL_ID = "hotel_id"
 
+const translationSnippetLength = 200
+
+// hotelUpsertColumns are overwritten when Save finds the hotel already stored. The row keeps
+// its id, creation date, status and update schedule, which belong to whoever stored it first.
+var hotelUpsertColumns = []string{
+	"cupid_id", "hotel_type_id", "name", "description", "address", "rating", "star_rating",
+	"latitude", "longitude", "amenities", "policies", "contact_info", "source", "main_image_th",
+	"hotel_type", "chain", "chain_id", "phone", "fax", "email", "airport_code", "review_count",
+	"checkin", "parking", "group_room_min", "child_allowed", "pets_allowed", "photos",
+	"markdown_description", "important_info", "facilities", "rooms", "timezone", "sources",
+	"updated_at",
+}
+
+var translationUpsertColumns = []string{
+	"name", "description", "address", "policies", "contact_info", "source", "chain", "checkin",
+	"parking", "group_room_min", "photos", "markdown_description", "important_info",
+	"facilities", "rooms", "updated_at",
+}
+
 type PostgresHotelRepository struct {
 	db     *gorm.DB
 	logger *slog.Logger
+
+	// sourcePriorities ranks the fallback writes of Save against the other sources of hotels.
+	sourcePriorities entities.SourcePriorities
 }
 
-func NewPostgresHotelRepository(db *gorm.DB, logger *slog.Logger) *PostgresHotelRepository {
+// likePrefixEscaper escapes LIKE wildcards so user input is matched literally.
+var likePrefixEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func NewPostgresHotelRepository(db *gorm.DB, sourcePriorities entities.SourcePriorities, logger *slog.Logger) *PostgresHotelRepository {
 	return &PostgresHotelRepository{
-		db:     db,
-		logger: logger,
+		db:               db,
+		logger:           logger,
+		sourcePriorities: sourcePriorities,
 	}
 }
 
@@ -55,11 +85,40 @@ func (r *PostgresHotelRepository) Save(ctx context.Context, h *hotel.Hotel) erro
 	hotelModel.CreatedAt = now
 	hotelModel.UpdatedAt = now
 
-	if err := r.db.WithContext(ctx).Create(hotelModel).Error; err != nil {
+	write := entities.SourceWrite{Source: entities.DataSourceFallback, Priorities: r.sourcePriorities}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := lockStoredHotel(tx, h.HotelID)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			if _, err := hotelModel.MergeSources(nil, write, now); err != nil {
+				return err
+			}
+			// A fetcher worker may store the same hotel concurrently; the unique hotel_id index
+			// turns the insert that loses the race into a no-op, and the hotel is merged into the
+			// row the worker stored instead.
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: HOTEL_ID}}, DoNothing: true}).Create(hotelModel)
+			if result.Error != nil || result.RowsAffected > 0 {
+				return result.Error
+			}
+			if existing, err = lockStoredHotel(tx, h.HotelID); err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("hotel %d conflicted on insert but is not stored", h.HotelID)
+			}
+		}
+
+		return r.mergeIntoStoredHotel(tx, hotelModel, existing, write, now)
+	})
+	if err != nil {
 		r.logger.Error("Failed to save hotel", "hotel_id", h.HotelID, "error", err)
 		return fmt.Errorf("failed to save hotel %d: %w", h.HotelID, err)
 	}
 	h.ID = hotelModel.ID
+	h.DataSources = toDomainDataSources(hotelModel.GetSources())
 	r.logger.Debug("Hotel saved successfully", "hotel_id", h.HotelID)
 	return nil
 }
@@ -73,7 +132,13 @@ func (r *PostgresHotelRepository) Update(ctx context.Context, h *hotel.Hotel) er
 	now := time.Now()
 	hotelModel.UpdatedAt = now
 
-	if err := r.db.WithContext(ctx).Save(hotelModel).Error; err != nil {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := mergeStoredSources(tx, hotelModel); err != nil {
+			return err
+		}
+		return tx.Save(hotelModel).Error
+	})
+	if err != nil {
 		r.logger.Error("Failed to update hotel", "hotel_id", h.HotelID, "error", err)
 		return fmt.Errorf("failed to update hotel %d: %w", h.HotelID, err)
 	}
@@ -82,13 +147,96 @@ func (r *PostgresHotelRepository) Update(ctx context.Context, h *hotel.Hotel) er
 	return nil
 }
 
-func (r *PostgresHotelRepository) FindAll(ctx context.Context, limit, offset int) ([]*hotel.Hotel, error) {
+// lockStoredHotel returns the stored row of hotelID, or nil when there is none. The row stays
+// locked until tx ends.
+func lockStoredHotel(tx *gorm.DB, hotelID int64) (*entities.HotelData, error) {
+	var stored entities.HotelData
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(HOTEL_ID+" = ?", hotelID).First(&stored).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// mergeIntoStoredHotel writes model, saved by write, over the stored row of its hotel. Field
+// groups a higher-priority source such as the workers wrote keep their values, the source
+// mappings of both are kept, and the row keeps its id, creation date, status and update
+// schedule, which belong to whoever stored it first.
+func (r *PostgresHotelRepository) mergeIntoStoredHotel(tx *gorm.DB, model, stored *entities.HotelData, write entities.SourceWrite, now time.Time) error {
+	kept, err := model.MergeSources(stored, write, now)
+	if err != nil {
+		return err
+	}
+	if len(kept) > 0 {
+		r.logger.Debug("Kept hotel fields written by a higher-priority source", "hotel_id", model.HotelID, "field_groups", kept)
+	}
+
+	sourceMappings := stored.GetSourceMappings()
+	maps.Copy(sourceMappings, model.GetSourceMappings())
+	if err := model.SetSourceMappings(sourceMappings); err != nil {
+		return err
+	}
+
+	model.ID = stored.ID
+	model.CreatedAt = stored.CreatedAt
+	columns := append(slices.Clone(hotelUpsertColumns), "source_mappings")
+	return tx.Model(model).Select(columns).Updates(model).Error
+}
+
+// mergeStoredSourceMappings adds the source ids stored for the hotel of model to its own, so an
+// update keeps the ids other sources registered since the hotel was read. The row stays locked
+// until tx ends.
+func mergeStoredSourceMappings(tx *gorm.DB, model *entities.HotelData) error {
+	var stored entities.HotelData
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("source_mappings").
+		Where(HOTEL_ID+" = ?", model.HotelID).
+		First(&stored).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	sourceMappings := stored.GetSourceMappings()
+	maps.Copy(sourceMappings, model.GetSourceMappings())
+	return model.SetSourceMappings(sourceMappings)
+}
+
+// mergeStoredSources merges the stored row of the hotel of model into it, so an update keeps
+// the ids other sources registered and the field groups they wrote since the hotel was read.
+// The row stays locked until tx ends.
+func mergeStoredSources(tx *gorm.DB, model *entities.HotelData) error {
+	stored, err := lockStoredHotel(tx, model.HotelID)
+	if err != nil || stored == nil {
+		return err
+	}
+
+	if _, err := model.KeepNewerSources(stored); err != nil {
+		return err
+	}
+
+	sourceMappings := stored.GetSourceMappings()
+	maps.Copy(sourceMappings, model.GetSourceMappings())
+	return model.SetSourceMappings(sourceMappings)
+}
+
+func (r *PostgresHotelRepository) FindAll(ctx context.Context, limit, offset int, filter ...hotel.FindFilter) ([]*hotel.Hotel, error) {
 	var hotelModels []entities.HotelData
 
 	query := r.db.WithContext(ctx).
 		Preload("ReviewsData").
 		Preload("TranslationsData").
 		Where("status = ?", "active")
+	for _, f := range filter {
+		if f.Chain != "" {
+			query = query.Where("chain = ?", f.Chain)
+		}
+	}
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -118,6 +266,7 @@ func (r *PostgresHotelRepository) FindUpdatedAfter(ctx context.Context, timestam
 	var hotelModels []entities.HotelData
 
 	err := r.db.WithContext(ctx).
+		Preload("ReviewsData").
 		Preload("TranslationsData").
 		Where("updated_at > ? AND status = ?", timestamp, "active").
 		Order("updated_at ASC").
@@ -139,6 +288,326 @@ func (r *PostgresHotelRepository) FindUpdatedAfter(ctx context.Context, timestam
 	return hotels, nil
 }
 
+// FindActiveVersionsAfter returns the hotel_id and latest updated_at of up to limit active hotels
+// with a hotel_id above afterHotelID, ordered by hotel_id.
+func (r *PostgresHotelRepository) FindActiveVersionsAfter(ctx context.Context, afterHotelID int64, limit int) ([]hotel.Version, error) {
+	var rows []struct {
+		HotelID   int64
+		UpdatedAt time.Time
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Select("hotel_id, MAX(updated_at) AS updated_at").
+		Where("hotel_id > ? AND status = ?", afterHotelID, "active").
+		Group("hotel_id").
+		Order("hotel_id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to find hotel versions after cursor", "after_hotel_id", afterHotelID, "error", err)
+		return nil, fmt.Errorf("failed to find hotel versions after %d: %w", afterHotelID, err)
+	}
+
+	versions := make([]hotel.Version, len(rows))
+	for i, row := range rows {
+		versions[i] = hotel.Version{HotelID: row.HotelID, UpdatedAt: row.UpdatedAt.UTC()}
+	}
+
+	return versions, nil
+}
+
+// FindAfterHotelID pages active hotels by hotel_id so long-running scans can resume from a cursor.
+func (r *PostgresHotelRepository) FindAfterHotelID(ctx context.Context, afterHotelID int64, limit int) ([]*hotel.Hotel, error) {
+	var hotelModels []entities.HotelData
+
+	query := r.db.WithContext(ctx).
+		Preload("ReviewsData").
+		Preload("TranslationsData").
+		Where("hotel_id > ? AND status = ?", afterHotelID, "active").
+		Order("hotel_id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&hotelModels).Error; err != nil {
+		r.logger.Error("Failed to find hotels after cursor", "after_hotel_id", afterHotelID, "error", err)
+		return nil, fmt.Errorf("failed to find hotels after %d: %w", afterHotelID, err)
+	}
+
+	hotels := make([]*hotel.Hotel, 0, len(hotelModels))
+	for _, model := range hotelModels {
+		if h, err := r.convertModelToDomain(&model); err == nil {
+			hotels = append(hotels, h)
+		} else {
+			r.logger.Warn("Failed to convert hotel model to domain", "hotel_id", model.HotelID, "error", err)
+		}
+	}
+
+	return hotels, nil
+}
+
+func (r *PostgresHotelRepository) FindByHotelIDs(ctx context.Context, hotelIDs []int64) ([]*hotel.Hotel, error) {
+	if len(hotelIDs) == 0 {
+		return []*hotel.Hotel{}, nil
+	}
+
+	var hotelModels []entities.HotelData
+	err := r.db.WithContext(ctx).
+		Preload("ReviewsData").
+		Preload("TranslationsData").
+		Where("hotel_id IN ?", hotelIDs).
+		Order("hotel_id ASC").
+		Find(&hotelModels).Error
+	if err != nil {
+		r.logger.Error("Failed to find hotels by hotel ids", "count", len(hotelIDs), "error", err)
+		return nil, fmt.Errorf("failed to find hotels by hotel ids: %w", err)
+	}
+
+	hotels := make([]*hotel.Hotel, 0, len(hotelModels))
+	for _, model := range hotelModels {
+		if h, err := r.convertModelToDomain(&model); err == nil {
+			hotels = append(hotels, h)
+		} else {
+			r.logger.Warn("Failed to convert hotel model to domain", "hotel_id", model.HotelID, "error", err)
+		}
+	}
+
+	return hotels, nil
+}
+
+// FindBySourceID returns the hotels that source knows as sourceID. The lookup uses JSONB
+// containment so it is served by the GIN index on source_mappings.
+func (r *PostgresHotelRepository) FindBySourceID(ctx context.Context, source, sourceID string) ([]*hotel.Hotel, error) {
+	mapping, err := json.Marshal(map[string]string{source: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal source mapping: %w", err)
+	}
+
+	var hotelModels []entities.HotelData
+	err = r.db.WithContext(ctx).
+		Where("source_mappings @> ?::jsonb", string(mapping)).
+		Order("hotel_id ASC").
+		Find(&hotelModels).Error
+	if err != nil {
+		r.logger.Error("Failed to find hotels by source id", "source", source, "source_id", sourceID, "error", err)
+		return nil, fmt.Errorf("failed to find hotels by source id: %w", err)
+	}
+
+	hotels := make([]*hotel.Hotel, 0, len(hotelModels))
+	for _, model := range hotelModels {
+		if h, err := r.convertModelToDomain(&model); err == nil {
+			hotels = append(hotels, h)
+		} else {
+			r.logger.Warn("Failed to convert hotel model to domain", "hotel_id", model.HotelID, "error", err)
+		}
+	}
+
+	return hotels, nil
+}
+
+// FindRemovedHotelIDsAfter returns the hotel_id of every hotel the workers marked removed
+// upstream after timestamp.
+func (r *PostgresHotelRepository) FindRemovedHotelIDsAfter(ctx context.Context, timestamp time.Time) ([]int64, error) {
+	var hotelIDs []int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Where("updated_at > ? AND status = ?", timestamp, entities.HotelStatusRemovedUpstream).
+		Order("hotel_id ASC").
+		Pluck("hotel_id", &hotelIDs).Error
+	if err != nil {
+		r.logger.Error("Failed to list removed hotel ids", "timestamp", timestamp, "error", err)
+		return nil, fmt.Errorf("failed to list hotels removed after %v: %w", timestamp, err)
+	}
+
+	return hotelIDs, nil
+}
+
+// CountHotels counts active hotels. With estimate set, the planner's row estimate for the table
+// is returned when available; it is instant on large tables but includes inactive rows.
+func (r *PostgresHotelRepository) CountHotels(ctx context.Context, estimate bool) (int64, error) {
+	if estimate {
+		if count, ok := r.estimateRows(ctx, (&entities.HotelData{}).TableName()); ok {
+			return count, nil
+		}
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Where("status = ?", "active").
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count hotels", "error", err)
+		return 0, fmt.Errorf("failed to count hotels: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresHotelRepository) CountReviews(ctx context.Context, estimate bool) (int64, error) {
+	if estimate {
+		if count, ok := r.estimateRows(ctx, (&entities.ReviewData{}).TableName()); ok {
+			return count, nil
+		}
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entities.ReviewData{}).Count(&count).Error; err != nil {
+		r.logger.Error("Failed to count reviews", "error", err)
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresHotelRepository) CountTranslations(ctx context.Context, estimate bool) (int64, error) {
+	if estimate {
+		if count, ok := r.estimateRows(ctx, (&entities.HotelTranslation{}).TableName()); ok {
+			return count, nil
+		}
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entities.HotelTranslation{}).Count(&count).Error; err != nil {
+		r.logger.Error("Failed to count translations", "error", err)
+		return 0, fmt.Errorf("failed to count translations: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresHotelRepository) CountHotelsUpdatedAfter(ctx context.Context, timestamp time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Where("updated_at > ? AND status = ?", timestamp, "active").
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count updated hotels", "timestamp", timestamp, "error", err)
+		return 0, fmt.Errorf("failed to count hotels updated after %v: %w", timestamp, err)
+	}
+
+	return count, nil
+}
+
+// GetDistinctCities returns the cities whose name starts with prefix, case-insensitively,
+// ordered by the number of active hotels in them.
+func (r *PostgresHotelRepository) GetDistinctCities(ctx context.Context, prefix string, limit int) ([]hotel.CityResult, error) {
+	pattern := likePrefixEscaper.Replace(prefix) + "%"
+
+	var rows []struct {
+		City       string
+		Country    string
+		HotelCount int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelData{}).
+		Select("address->>'city' AS city, address->>'country' AS country, COUNT(*) AS hotel_count").
+		Where("address->>'city' ILIKE ? AND status = ?", pattern, "active").
+		Group("city, country").
+		Order("hotel_count DESC, city ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get distinct cities", "prefix", prefix, "error", err)
+		return nil, fmt.Errorf("failed to get distinct cities: %w", err)
+	}
+
+	cities := make([]hotel.CityResult, 0, len(rows))
+	for _, row := range rows {
+		cities = append(cities, hotel.CityResult{City: row.City, Country: row.Country, HotelCount: row.HotelCount})
+	}
+
+	return cities, nil
+}
+
+// FindTranslation loads a single language of a hotel without preloading the others. It returns
+// nil when the hotel has no translation in that language.
+func (r *PostgresHotelRepository) FindTranslation(ctx context.Context, hotelID int64, lang string) (*hotel.Translation, error) {
+	var translationModel entities.HotelTranslation
+
+	err := r.db.WithContext(ctx).
+		Where(HOTEL_ID+" = ? AND lang = ?", hotelID, lang).
+		First(&translationModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to find translation", "hotel_id", hotelID, "lang", lang, "error", err)
+		return nil, fmt.Errorf("failed to find translation %s of hotel %d: %w", lang, hotelID, err)
+	}
+
+	translation := r.convertTranslationModelToDomain(&translationModel)
+	return &translation, nil
+}
+
+// ListTranslations returns the languages a hotel is translated into, with the translated name
+// and the start of the description.
+func (r *PostgresHotelRepository) ListTranslations(ctx context.Context, hotelID int64) ([]hotel.TranslationSummary, error) {
+	var rows []struct {
+		Lang    string
+		Name    string
+		Snippet string
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entities.HotelTranslation{}).
+		Select("lang, name, LEFT(description, ?) AS snippet", translationSnippetLength).
+		Where(HOTEL_ID+" = ?", hotelID).
+		Order("lang ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to list translations", "hotel_id", hotelID, "error", err)
+		return nil, fmt.Errorf("failed to list translations of hotel %d: %w", hotelID, err)
+	}
+
+	summaries := make([]hotel.TranslationSummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, hotel.TranslationSummary{Lang: row.Lang, Name: row.Name, DescriptionSnippet: row.Snippet})
+	}
+
+	return summaries, nil
+}
+
+func (r *PostgresHotelRepository) SaveTranslation(ctx context.Context, t *hotel.Translation) error {
+	translationModel := r.convertTranslationDomainToModel(t)
+
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: HOTEL_ID}, {Name: "lang"}},
+			DoUpdates: clause.AssignmentColumns(translationUpsertColumns),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "id"}}},
+	).Create(translationModel).Error
+	if err != nil {
+		r.logger.Error("Failed to save translation", "hotel_id", t.HotelID, "lang", t.Lang, "error", err)
+		return fmt.Errorf("failed to save translation %s of hotel %d: %w", t.Lang, t.HotelID, err)
+	}
+	t.ID = translationModel.ID
+
+	r.logger.Debug("Translation saved successfully", "hotel_id", t.HotelID, "lang", t.Lang)
+	return nil
+}
+
+// estimateRows reads pg_class.reltuples for the table. The boolean result is false when the
+// estimate is unavailable, e.g. the table has never been analyzed.
+func (r *PostgresHotelRepository) estimateRows(ctx context.Context, table string) (int64, bool) {
+	var estimate float64
+	err := r.db.WithContext(ctx).
+		Raw("SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)", table).
+		Scan(&estimate).Error
+	if err != nil {
+		r.logger.Warn("Failed to estimate table rows", "table", table, "error", err)
+		return 0, false
+	}
+
+	if estimate < 0 {
+		return 0, false
+	}
+
+	return int64(estimate), true
+}
+
 func (r *PostgresHotelRepository) Delete(ctx context.Context, id string) error {
 	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.HotelData{}).Error
 	if err != nil {
@@ -160,25 +629,26 @@ func (r *PostgresHotelRepository) convertModelToDomain(model *entities.HotelData
 		Rating:              model.Rating,
 		StarRating:          model.StarRating,
 		Location:            hotel.Location{Latitude: model.Latitude, Longitude: model.Longitude},
+		Latitude:            model.Latitude,
+		Longitude:           model.Longitude,
+		Timezone:            model.Timezone,
 		Status:              model.Status,
 		Source:              model.Source,
 		MainImageTh:         model.MainImageTh,
 		HotelType:           model.HotelType,
 		Chain:               model.Chain,
 		ChainID:             model.ChainID,
-		Phone:               model.Phone,
-		Fax:                 model.Fax,
-		Email:               model.Email,
 		AirportCode:         model.AirportCode,
 		ReviewCount:         model.ReviewCount,
+		ArchivedReviewCount: model.ArchivedReviewCount,
 		Parking:             model.Parking,
 		ChildAllowed:        model.ChildAllowed,
 		PetsAllowed:         model.PetsAllowed,
 		MarkdownDescription: model.MarkdownDescription,
 		ImportantInfo:       model.ImportantInfo,
-		CreatedAt:           model.CreatedAt,
-		UpdatedAt:           model.UpdatedAt,
-		NextUpdateAt:        model.NextUpdateAt,
+		CreatedAt:           model.CreatedAt.UTC(),
+		UpdatedAt:           model.UpdatedAt.UTC(),
+		NextUpdateAt:        model.NextUpdateAt.UTC(),
 	}
 
 	if len(model.Address) > 0 {
@@ -202,19 +672,28 @@ func (r *PostgresHotelRepository) convertModelToDomain(model *entities.HotelData
 		}
 	}
 
+	h.ContactInfo = hotel.ContactInfo{Phone: model.Phone, Fax: model.Fax, Email: model.Email}
 	if len(model.ContactInfo) > 0 {
 		var contactInfo hotel.ContactInfo
-		if err := json.Unmarshal(model.ContactInfo, &contactInfo); err == nil {
+		if err := json.Unmarshal(model.ContactInfo, &contactInfo); err == nil && contactInfo != (hotel.ContactInfo{}) {
 			h.ContactInfo = contactInfo
 		}
 	}
+	// Hotels stored before phone normalization hold only the raw numbers.
+	h.ContactInfo.NormalizeNumbers(h.Address.Country)
 
 	if len(model.Checkin) > 0 {
-		var checkinInfo hotel.CheckinInfo
-		if err := json.Unmarshal(model.Checkin, &checkinInfo); err == nil {
+		if checkinInfo, err := hotel.ParseCheckinInfo(model.Checkin); err == nil {
 			h.CheckinInfo = checkinInfo
 		}
 	}
+	h.CheckinWindow = h.CheckinInfo.Window()
+
+	if len(model.GroupRoomMin) > 0 {
+		if groupRoomMin, err := hotel.ParseGroupRoomMin(model.GroupRoomMin); err == nil {
+			h.GroupRoomMin = groupRoomMin
+		}
+	}
 
 	if len(model.Photos) > 0 {
 		var photos []hotel.Photo
@@ -237,26 +716,28 @@ func (r *PostgresHotelRepository) convertModelToDomain(model *entities.HotelData
 		}
 	}
 
+	if len(model.SourceMappings) > 0 {
+		h.SourceMappings = model.GetSourceMappings()
+	}
+
+	if len(model.Sources) > 0 {
+		h.DataSources = toDomainDataSources(model.GetSources())
+	}
+
+	if len(model.NearbyAttractions) > 0 {
+		var attractions []hotel.Attraction
+		if err := json.Unmarshal(model.NearbyAttractions, &attractions); err == nil {
+			h.NearbyAttractions = attractions
+		}
+	}
+
 	if len(model.ReviewsData) > 0 {
 		var reviews []hotel.Review
 
 		for _, reviewData := range model.ReviewsData {
-			reviews = append(reviews, hotel.Review{
-				ID:           reviewData.ID,
-				HotelID:      reviewData.HotelID,
-				ReviewID:     reviewData.ReviewID,
-				AverageScore: reviewData.AverageScore,
-				Country:      reviewData.Country,
-				Type:         reviewData.Type,
-				Name:         reviewData.Name,
-				Date:         reviewData.Date,
-				Headline:     reviewData.Headline,
-				Language:     reviewData.Language,
-				Pros:         reviewData.Pros,
-				Cons:         reviewData.Cons,
-				Source:       reviewData.Source,
-			})
+			reviews = append(reviews, r.convertReviewModelToDomain(&reviewData))
 		}
+		hotel.SortReviewsByDate(reviews)
 		h.Reviews = reviews
 	}
 
@@ -264,81 +745,119 @@ func (r *PostgresHotelRepository) convertModelToDomain(model *entities.HotelData
 		var translations []hotel.Translation
 
 		for _, translationData := range model.TranslationsData {
-			translation := hotel.Translation{
-				ID:                  translationData.ID,
-				HotelID:             translationData.HotelID,
-				Name:                translationData.Name,
-				Description:         translationData.Description,
-				Status:              translationData.Status,
-				Source:              translationData.Source,
-				Chain:               translationData.Chain,
-				Parking:             translationData.Parking,
-				MarkdownDescription: translationData.MarkdownDescription,
-				ImportantInfo:       translationData.ImportantInfo,
-				CreatedAt:           translationData.CreatedAt,
-				UpdatedAt:           translationData.UpdatedAt,
-				NextUpdateAt:        translationData.NextUpdateAt,
-				Lang:                translationData.Lang,
-			}
+			translations = append(translations, r.convertTranslationModelToDomain(&translationData))
+		}
+		h.Translations = translations
+	}
 
-			if len(translationData.Address) > 0 {
-				var address hotel.Address
-				if err := json.Unmarshal(translationData.Address, &address); err == nil {
-					translation.Address = address
-				}
-			}
+	return h, nil
+}
 
-			if len(translationData.Policies) > 0 {
-				var policies []hotel.Policy
-				if err := json.Unmarshal(translationData.Policies, &policies); err == nil {
-					translation.Policies = policies
-				}
-			}
+func toDomainDataSources(sources map[string]entities.FieldSource) map[string]hotel.DataSource {
+	dataSources := make(map[string]hotel.DataSource, len(sources))
+	for group, source := range sources {
+		dataSources[group] = hotel.DataSource{Source: source.Source, UpdatedAt: source.UpdatedAt.UTC()}
+	}
+	return dataSources
+}
 
-			if len(translationData.ContactInfo) > 0 {
-				var contactInfo hotel.ContactInfo
-				if err := json.Unmarshal(translationData.ContactInfo, &contactInfo); err == nil {
-					translation.ContactInfo = contactInfo
-				}
-			}
+func (r *PostgresHotelRepository) convertReviewModelToDomain(reviewData *entities.ReviewData) hotel.Review {
+	return hotel.Review{
+		ID:              reviewData.ID,
+		HotelID:         reviewData.HotelID,
+		ReviewID:        reviewData.ReviewID,
+		AverageScore:    reviewData.AverageScore,
+		Country:         reviewData.Country,
+		Type:            reviewData.Type,
+		Name:            reviewData.Name,
+		Date:            reviewData.Date.UTC(),
+		Headline:        reviewData.Headline,
+		Language:        reviewData.Language,
+		Pros:            reviewData.Pros,
+		Cons:            reviewData.Cons,
+		Source:          reviewData.Source,
+		ScoreLocation:   reviewData.ScoreLocation,
+		ScoreService:    reviewData.ScoreService,
+		ScoreValue:      reviewData.ScoreValue,
+		ScoreFacilities: reviewData.ScoreFacilities,
+	}
+}
 
-			if len(translationData.Checkin) > 0 {
-				var checkinInfo hotel.CheckinInfo
-				if err := json.Unmarshal(translationData.Checkin, &checkinInfo); err == nil {
-					translation.CheckinInfo = checkinInfo
-				}
-			}
+func (r *PostgresHotelRepository) convertTranslationModelToDomain(translationData *entities.HotelTranslation) hotel.Translation {
+	translation := hotel.Translation{
+		ID:                  translationData.ID,
+		HotelID:             translationData.HotelID,
+		Name:                translationData.Name,
+		Description:         translationData.Description,
+		Status:              translationData.Status,
+		Source:              translationData.Source,
+		Chain:               translationData.Chain,
+		Parking:             translationData.Parking,
+		MarkdownDescription: translationData.MarkdownDescription,
+		ImportantInfo:       translationData.ImportantInfo,
+		CreatedAt:           translationData.CreatedAt.UTC(),
+		UpdatedAt:           translationData.UpdatedAt.UTC(),
+		NextUpdateAt:        translationData.NextUpdateAt.UTC(),
+		Lang:                translationData.Lang,
+	}
+
+	if len(translationData.Address) > 0 {
+		var address hotel.Address
+		if err := json.Unmarshal(translationData.Address, &address); err == nil {
+			translation.Address = address
+		}
+	}
 
-			if len(translationData.Photos) > 0 {
-				var photos []hotel.Photo
-				if err := json.Unmarshal(translationData.Photos, &photos); err == nil {
-					translation.Photos = photos
-				}
-			}
+	if len(translationData.Policies) > 0 {
+		var policies []hotel.Policy
+		if err := json.Unmarshal(translationData.Policies, &policies); err == nil {
+			translation.Policies = policies
+		}
+	}
 
-			if len(translationData.Facilities) > 0 {
-				var facilities []hotel.Facility
-				if err := json.Unmarshal(translationData.Facilities, &facilities); err == nil {
-					translation.Facilities = facilities
-				}
-			}
+	if len(translationData.ContactInfo) > 0 {
+		var contactInfo hotel.ContactInfo
+		if err := json.Unmarshal(translationData.ContactInfo, &contactInfo); err == nil {
+			translation.ContactInfo = contactInfo
+		}
+	}
 
-			if len(translationData.Rooms) > 0 {
-				var rooms []hotel.Room
-				if err := json.Unmarshal(translationData.Rooms, &rooms); err == nil {
-					translation.Rooms = rooms
-				}
-			}
+	if len(translationData.Checkin) > 0 {
+		if checkinInfo, err := hotel.ParseCheckinInfo(translationData.Checkin); err == nil {
+			translation.CheckinInfo = checkinInfo
+		}
+	}
 
-			translations = append(translations, translation)
+	if len(translationData.Photos) > 0 {
+		var photos []hotel.Photo
+		if err := json.Unmarshal(translationData.Photos, &photos); err == nil {
+			translation.Photos = photos
 		}
-		h.Translations = translations
 	}
 
-	return h, nil
+	if len(translationData.Facilities) > 0 {
+		var facilities []hotel.Facility
+		if err := json.Unmarshal(translationData.Facilities, &facilities); err == nil {
+			translation.Facilities = facilities
+		}
+	}
+
+	if len(translationData.Rooms) > 0 {
+		var rooms []hotel.Room
+		if err := json.Unmarshal(translationData.Rooms, &rooms); err == nil {
+			translation.Rooms = rooms
+		}
+	}
+
+	return translation
 }
 
 func (r *PostgresHotelRepository) convertDomainToModel(h *hotel.Hotel) (*entities.HotelData, error) {
+	latitude, longitude := h.Latitude, h.Longitude
+	if latitude == 0 && longitude == 0 {
+		latitude, longitude = h.Location.Latitude, h.Location.Longitude
+	}
+
 	model := &entities.HotelData{
 		ID:                  h.ID,
 		HotelID:             h.HotelID,
@@ -347,19 +866,21 @@ func (r *PostgresHotelRepository) convertDomainToModel(h *hotel.Hotel) (*entitie
 		Description:         h.Description,
 		Rating:              h.Rating,
 		StarRating:          h.StarRating,
-		Latitude:            h.Location.Latitude,
-		Longitude:           h.Location.Longitude,
+		Latitude:            latitude,
+		Longitude:           longitude,
+		Timezone:            h.Timezone,
 		Status:              h.Status,
 		Source:              h.Source,
 		MainImageTh:         h.MainImageTh,
 		HotelType:           h.HotelType,
 		Chain:               h.Chain,
 		ChainID:             h.ChainID,
-		Phone:               h.Phone,
-		Fax:                 h.Fax,
-		Email:               h.Email,
+		Phone:               h.ContactInfo.Phone,
+		Fax:                 h.ContactInfo.Fax,
+		Email:               h.ContactInfo.Email,
 		AirportCode:         h.AirportCode,
 		ReviewCount:         h.ReviewCount,
+		ArchivedReviewCount: h.ArchivedReviewCount,
 		Parking:             h.Parking,
 		ChildAllowed:        h.ChildAllowed,
 		PetsAllowed:         h.PetsAllowed,
@@ -386,10 +907,16 @@ func (r *PostgresHotelRepository) convertDomainToModel(h *hotel.Hotel) (*entitie
 		model.ContactInfo = contactInfoJSON
 	}
 
-	if checkinInfoJSON, err := json.Marshal(h.CheckinInfo); err == nil {
+	if checkinInfoJSON, err := hotel.MarshalCheckinInfo(h.CheckinInfo); err == nil {
 		model.Checkin = checkinInfoJSON
 	}
 
+	if h.GroupRoomMin != nil {
+		if groupRoomMinJSON, err := json.Marshal(h.GroupRoomMin); err == nil {
+			model.GroupRoomMin = groupRoomMinJSON
+		}
+	}
+
 	if photosJSON, err := json.Marshal(h.Photos); err == nil {
 		model.Photos = photosJSON
 	}
@@ -402,5 +929,71 @@ func (r *PostgresHotelRepository) convertDomainToModel(h *hotel.Hotel) (*entitie
 		model.Rooms = roomsJSON
 	}
 
+	if err := model.SetSourceMappings(h.SourceMappings); err != nil {
+		return nil, fmt.Errorf("failed to marshal source mappings: %w", err)
+	}
+
+	sources := make(map[string]entities.FieldSource, len(h.DataSources))
+	for group, source := range h.DataSources {
+		sources[group] = entities.FieldSource{Source: source.Source, UpdatedAt: source.UpdatedAt}
+	}
+	if err := model.SetSources(sources); err != nil {
+		return nil, fmt.Errorf("failed to marshal data sources: %w", err)
+	}
+
+	// Attractions are only written by the worker's geo enrichment; a hotel that has none is
+	// stored without the column.
+	if h.NearbyAttractions != nil {
+		if attractionsJSON, err := json.Marshal(h.NearbyAttractions); err == nil {
+			model.NearbyAttractions = attractionsJSON
+		}
+	}
+
 	return model, nil
 }
+
+func (r *PostgresHotelRepository) convertTranslationDomainToModel(t *hotel.Translation) *entities.HotelTranslation {
+	model := &entities.HotelTranslation{
+		ID:                  t.ID,
+		HotelID:             t.HotelID,
+		Name:                t.Name,
+		Description:         t.Description,
+		Status:              t.Status,
+		Source:              t.Source,
+		Chain:               t.Chain,
+		Parking:             t.Parking,
+		MarkdownDescription: t.MarkdownDescription,
+		ImportantInfo:       t.ImportantInfo,
+		Lang:                t.Lang,
+	}
+
+	if addressJSON, err := json.Marshal(t.Address); err == nil {
+		model.Address = addressJSON
+	}
+
+	if policiesJSON, err := json.Marshal(t.Policies); err == nil {
+		model.Policies = policiesJSON
+	}
+
+	if contactInfoJSON, err := json.Marshal(t.ContactInfo); err == nil {
+		model.ContactInfo = contactInfoJSON
+	}
+
+	if checkinInfoJSON, err := hotel.MarshalCheckinInfo(t.CheckinInfo); err == nil {
+		model.Checkin = checkinInfoJSON
+	}
+
+	if photosJSON, err := json.Marshal(t.Photos); err == nil {
+		model.Photos = photosJSON
+	}
+
+	if facilitiesJSON, err := json.Marshal(t.Facilities); err == nil {
+		model.Facilities = facilitiesJSON
+	}
+
+	if roomsJSON, err := json.Marshal(t.Rooms); err == nil {
+		model.Rooms = roomsJSON
+	}
+
+	return model
+}
@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+)
+
+func TestConvertModelToDomainSetsCoordinates(t *testing.T) {
+	repository := &PostgresHotelRepository{}
+
+	h, err := repository.convertModelToDomain(&entities.HotelData{HotelID: 1, Latitude: 35.6762, Longitude: 139.6503})
+	require.NoError(t, err)
+
+	assert.Equal(t, 35.6762, h.Latitude)
+	assert.Equal(t, 139.6503, h.Longitude)
+	assert.Equal(t, hotel.Location{Latitude: 35.6762, Longitude: 139.6503}, h.Location)
+	assert.True(t, h.HasCoordinates())
+}
+
+func TestConvertDomainToModelCoordinates(t *testing.T) {
+	repository := &PostgresHotelRepository{}
+
+	tests := []struct {
+		name  string
+		hotel *hotel.Hotel
+	}{
+		{name: "latitude and longitude", hotel: &hotel.Hotel{Latitude: 35.6762, Longitude: 139.6503}},
+		{name: "location only", hotel: &hotel.Hotel{Location: hotel.Location{Latitude: 35.6762, Longitude: 139.6503}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := repository.convertDomainToModel(tt.hotel)
+			require.NoError(t, err)
+			assert.Equal(t, 35.6762, model.Latitude)
+			assert.Equal(t, 139.6503, model.Longitude)
+		})
+	}
+}
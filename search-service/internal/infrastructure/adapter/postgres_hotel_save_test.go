@@ -0,0 +1,190 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSQLiteHotelRepository backs the repository with a SQLite database holding the hotels table
+// and its unique hotel_id index. SQLite has no row locks; immediate transactions serialize the
+// writers instead.
+func newSQLiteHotelRepository(t *testing.T) (*PostgresHotelRepository, *gorm.DB) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate", filepath.Join(t.TempDir(), "hotels.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard, DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().CreateTable(&entities.HotelData{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return NewPostgresHotelRepository(db, nil, slog.New(slog.DiscardHandler)), db
+}
+
+// storeWorkerHotel stores the hotel as a fetcher worker does, attributing every field group to it.
+func storeWorkerHotel(t *testing.T, db *gorm.DB, hotelID int64, writtenAt time.Time) *entities.HotelData {
+	t.Helper()
+	model := &entities.HotelData{HotelID: hotelID, CupidID: hotelID, Name: "Worker name", Description: "From the worker"}
+	require.NoError(t, model.SetSourceMappings(map[string]string{entities.SourceCupid: "1001"}))
+	_, err := model.MergeSources(nil, entities.SourceWrite{Source: entities.DataSourceCupidFetcher}, writtenAt)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(model).Error)
+	return model
+}
+
+func loadHotelRows(t *testing.T, db *gorm.DB, hotelID int64) []entities.HotelData {
+	t.Helper()
+	var rows []entities.HotelData
+	require.NoError(t, db.Where(HOTEL_ID+" = ?", hotelID).Find(&rows).Error)
+	return rows
+}
+
+func TestSaveMergesIntoHotelStoredByRacingWorker(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	workerWrite := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	// The worker stores the hotel after Save found none and before its insert.
+	var raced bool
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:race_worker", func(tx *gorm.DB) {
+		if raced || tx.Statement.Table != "hotels" {
+			return
+		}
+		raced = true
+		worker := &entities.HotelData{HotelID: 7, CupidID: 7, Name: "Worker name"}
+		require.NoError(t, worker.SetSourceMappings(map[string]string{entities.SourceCupid: "1001"}))
+		_, err := worker.MergeSources(nil, entities.SourceWrite{Source: entities.DataSourceCupidFetcher}, workerWrite)
+		require.NoError(t, err)
+		require.NoError(t, tx.Session(&gorm.Session{NewDB: true}).Table("hotels").Create(worker).Error)
+	}))
+
+	h := &hotel.Hotel{HotelID: 7, CupidID: 7, Name: "Fallback name", SourceMappings: map[string]string{"expedia": "E-7"}}
+	require.NoError(t, repository.Save(context.Background(), h))
+	require.True(t, raced)
+
+	rows := loadHotelRows(t, db, 7)
+	require.Len(t, rows, 1)
+	stored := rows[0]
+	assert.Equal(t, stored.ID, h.ID)
+	assert.Equal(t, "Worker name", stored.Name, "the worker's fields outrank the fallback")
+	assert.Equal(t, map[string]string{entities.SourceCupid: "1001", "expedia": "E-7"}, stored.GetSourceMappings())
+	for _, group := range entities.FieldGroups {
+		source := stored.GetSources()[group]
+		assert.Equal(t, entities.DataSourceCupidFetcher, source.Source, group)
+		assert.True(t, workerWrite.Equal(source.UpdatedAt), group)
+	}
+}
+
+func TestSaveConcurrentWritersLeaveOneMergedRow(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, source := range []string{"expedia", "booking"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repository.Save(ctx, &hotel.Hotel{
+				HotelID:        9,
+				CupidID:        9,
+				Name:           "Hotel from " + source,
+				SourceMappings: map[string]string{source: source + "-9"},
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	rows := loadHotelRows(t, db, 9)
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]string{"expedia": "expedia-9", "booking": "booking-9"}, rows[0].GetSourceMappings())
+	assert.Len(t, rows[0].GetSources(), len(entities.FieldGroups))
+}
+
+func TestSaveKeepsStoredRowIdentity(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	worker := storeWorkerHotel(t, db, 11, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC))
+
+	h := &hotel.Hotel{HotelID: 11, CupidID: 11, Name: "Fallback name"}
+	require.NoError(t, repository.Save(context.Background(), h))
+
+	rows := loadHotelRows(t, db, 11)
+	require.Len(t, rows, 1)
+	assert.Equal(t, worker.ID, rows[0].ID)
+	assert.Equal(t, worker.ID, h.ID)
+	assert.True(t, worker.CreatedAt.Equal(rows[0].CreatedAt))
+	assert.Equal(t, "From the worker", rows[0].Description)
+	assert.Equal(t, map[string]string{entities.SourceCupid: "1001"}, rows[0].GetSourceMappings())
+}
+
+func TestUpdateMergesSourcesWrittenSinceRead(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	ctx := context.Background()
+	storeWorkerHotel(t, db, 13, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC))
+
+	var stored entities.HotelData
+	require.NoError(t, db.Where(HOTEL_ID+" = ?", 13).First(&stored).Error)
+	read, err := repository.convertModelToDomain(&stored)
+	require.NoError(t, err)
+
+	// A worker rewrites the hotel after it was read.
+	rewrite := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	stored.Name = "Renamed by the worker"
+	require.NoError(t, stored.SetSourceMappings(map[string]string{entities.SourceCupid: "1001", "booking": "B-13"}))
+	_, err = stored.MergeSources(&stored, entities.SourceWrite{Source: entities.DataSourceCupidFetcher}, rewrite)
+	require.NoError(t, err)
+	require.NoError(t, db.Save(&stored).Error)
+
+	read.Name = "Stale name"
+	require.NoError(t, repository.Update(ctx, read))
+
+	rows := loadHotelRows(t, db, 13)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Renamed by the worker", rows[0].Name)
+	assert.Equal(t, map[string]string{entities.SourceCupid: "1001", "booking": "B-13"}, rows[0].GetSourceMappings())
+	for _, group := range entities.FieldGroups {
+		assert.True(t, rewrite.Equal(rows[0].GetSources()[group].UpdatedAt), group)
+	}
+}
+
+func TestSaveStoresTheTimezone(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repository.Save(ctx, &hotel.Hotel{HotelID: 13, CupidID: 13, Name: "Harbour Hotel"}))
+	require.NoError(t, repository.Save(ctx, &hotel.Hotel{HotelID: 13, CupidID: 13, Name: "Harbour Hotel", Timezone: "Asia/Tokyo"}))
+
+	rows := loadHotelRows(t, db, 13)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Asia/Tokyo", rows[0].Timezone, "a save over a stored hotel writes its timezone")
+}
+
+func TestStoredHotelsWithoutATimezoneDeriveIt(t *testing.T) {
+	repository, db := newSQLiteHotelRepository(t)
+	model := &entities.HotelData{HotelID: 17, CupidID: 17, Name: "Opera Hotel", Latitude: 48.8681, Longitude: 2.3292, Address: []byte(`{"country":"fr"}`)}
+	require.NoError(t, db.Create(model).Error)
+
+	rows := loadHotelRows(t, db, 17)
+	require.Len(t, rows, 1)
+	h, err := repository.convertModelToDomain(&rows[0])
+	require.NoError(t, err)
+	assert.Empty(t, h.Timezone)
+	assert.Equal(t, "Europe/Paris", h.TimezoneName(), "the timezone of rows stored before the column is found from their coordinates")
+}
@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/gorm"
+)
+
+// reviewColumns are the columns shared by reviews and reviews_archive.
+const reviewColumns = `id, hotel_id, review_id, average_score, country, type, name, date, raw_date, headline,
+language, pros, cons, source, score_location, score_service, score_value, score_facilities,
+created_at, updated_at, deleted_at, next_update_at`
+
+// archiveReviewsQuery moves the expired reviews of one batch of hotels in a single statement,
+// so a batch is archived completely or not at all. Reviews are ranked newest first per hotel;
+// those ranked past the retention and older than the cutoff are deleted from reviews, inserted
+// into reviews_archive and counted into hotels.archived_review_count. Re-running it archives
+// nothing new, and ON CONFLICT keeps it safe if a review id is already in the archive.
+const archiveReviewsQuery = `
+WITH batch AS (
+    SELECT hotel_id FROM hotels WHERE hotel_id > ? ORDER BY hotel_id LIMIT ?
+), ranked AS (
+    SELECT r.id, ROW_NUMBER() OVER (PARTITION BY r.hotel_id ORDER BY r.date DESC, r.id) AS position
+    FROM reviews r
+    JOIN batch b ON b.hotel_id = r.hotel_id
+    WHERE r.deleted_at IS NULL
+), moved AS (
+    DELETE FROM reviews r
+    USING ranked
+    WHERE r.id = ranked.id AND ranked.position > ? AND r.date < ?
+    RETURNING r.*
+), archived AS (
+    INSERT INTO reviews_archive (` + reviewColumns + `)
+    SELECT ` + reviewColumns + ` FROM moved
+    ON CONFLICT (id) DO NOTHING
+), counted AS (
+    SELECT hotel_id, COUNT(*) AS archived FROM moved GROUP BY hotel_id
+), bookkeeping AS (
+    UPDATE hotels h
+    SET archived_review_count = h.archived_review_count + counted.archived
+    FROM counted
+    WHERE h.hotel_id = counted.hotel_id
+)
+SELECT COALESCE((SELECT MAX(hotel_id) FROM batch), 0) AS last_hotel_id,
+       (SELECT COUNT(*) FROM batch) AS hotels,
+       (SELECT COUNT(*) FROM moved) AS archived_reviews`
+
+func (r *PostgresHotelRepository) ArchiveReviews(ctx context.Context, afterHotelID int64, hotelLimit int, retention hotel.ReviewRetention) (hotel.ReviewArchiveBatch, error) {
+	var batch struct {
+		LastHotelID     int64
+		Hotels          int
+		ArchivedReviews int64
+	}
+
+	cutoff := time.Now().Add(-retention.MinAge)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Raw(archiveReviewsQuery, afterHotelID, hotelLimit, retention.KeepNewest, cutoff).Scan(&batch).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to archive reviews", "after_hotel_id", afterHotelID, "error", err)
+		return hotel.ReviewArchiveBatch{}, fmt.Errorf("failed to archive reviews after hotel %d: %w", afterHotelID, err)
+	}
+
+	return hotel.ReviewArchiveBatch{
+		LastHotelID:     batch.LastHotelID,
+		Hotels:          batch.Hotels,
+		ArchivedReviews: batch.ArchivedReviews,
+	}, nil
+}
+
+// ListReviews returns the reviews of a hotel most recent first, with reviews without a known
+// date last. Archived reviews are included on request.
+func (r *PostgresHotelRepository) ListReviews(ctx context.Context, hotelID int64, options hotel.ListReviewsOptions) ([]hotel.Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE hotel_id = ? AND deleted_at IS NULL`
+	args := []any{hotelID}
+	if options.IncludeArchived {
+		query += ` UNION ALL SELECT ` + reviewColumns + ` FROM reviews_archive WHERE hotel_id = ? AND deleted_at IS NULL`
+		args = append(args, hotelID)
+	}
+	query += ` ORDER BY date DESC, id LIMIT ? OFFSET ?`
+	args = append(args, options.Limit, options.Offset)
+
+	var rows []entities.ReviewData
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		r.logger.Error("Failed to list reviews", "hotel_id", hotelID, "include_archived", options.IncludeArchived, "error", err)
+		return nil, fmt.Errorf("failed to list reviews of hotel %d: %w", hotelID, err)
+	}
+
+	reviews := make([]hotel.Review, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, r.convertReviewModelToDomain(&row))
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,90 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"gorm.io/gorm"
+)
+
+// mergeDuplicateReviewsQuery soft-deletes every review of the batch that shares its hotel and
+// fingerprint with an older review, and returns the hotel of every merged review. Re-running
+// it merges nothing new.
+const mergeDuplicateReviewsQuery = `
+WITH ranked AS (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY hotel_id, fingerprint ORDER BY created_at, id) AS position
+    FROM reviews
+    WHERE hotel_id IN ? AND deleted_at IS NULL AND fingerprint IS NOT NULL AND fingerprint <> ''
+)
+UPDATE reviews r
+SET deleted_at = NOW(), updated_at = NOW()
+FROM ranked
+WHERE r.id = ranked.id AND ranked.position > 1
+RETURNING r.hotel_id`
+
+// recountReviewsQuery sets the review count of the hotels to the reviews they have stored.
+const recountReviewsQuery = `
+UPDATE hotels h
+SET review_count = (SELECT COUNT(*) FROM reviews r WHERE r.hotel_id = h.hotel_id AND r.deleted_at IS NULL)
+WHERE h.hotel_id IN ?`
+
+func (r *PostgresHotelRepository) DeduplicateReviews(ctx context.Context, afterHotelID int64, hotelLimit int) (hotel.ReviewDedupBatch, error) {
+	var batch hotel.ReviewDedupBatch
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hotelIDs []int64
+		err := tx.Raw(`SELECT hotel_id FROM hotels WHERE hotel_id > ? ORDER BY hotel_id LIMIT ?`, afterHotelID, hotelLimit).
+			Scan(&hotelIDs).Error
+		if err != nil || len(hotelIDs) == 0 {
+			return err
+		}
+		batch.Hotels = len(hotelIDs)
+		batch.LastHotelID = hotelIDs[len(hotelIDs)-1]
+
+		if batch.Fingerprinted, err = fingerprintReviews(tx, hotelIDs); err != nil {
+			return err
+		}
+		var mergedHotelIDs []int64
+		if err := tx.Raw(mergeDuplicateReviewsQuery, hotelIDs).Scan(&mergedHotelIDs).Error; err != nil || len(mergedHotelIDs) == 0 {
+			return err
+		}
+		batch.MergedReviews = int64(len(mergedHotelIDs))
+
+		// The merged reviews were part of the count the hotel was imported with, so it is
+		// recounted from the reviews left rather than decremented.
+		slices.Sort(mergedHotelIDs)
+		return tx.Exec(recountReviewsQuery, slices.Compact(mergedHotelIDs)).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to deduplicate reviews", "after_hotel_id", afterHotelID, "error", err)
+		return hotel.ReviewDedupBatch{}, fmt.Errorf("failed to deduplicate reviews after hotel %d: %w", afterHotelID, err)
+	}
+
+	return batch, nil
+}
+
+// fingerprintReviews fills in the fingerprint of the hotels' reviews stored before fingerprints
+// existed. The hash is computed in Go so it matches the one the worker computes on ingestion.
+func fingerprintReviews(tx *gorm.DB, hotelIDs []int64) (int64, error) {
+	var reviews []entities.ReviewData
+	err := tx.Select("id", "name", "country", "type", "date", "raw_date", "average_score",
+		"score_location", "score_service", "score_value", "score_facilities", "headline", "pros", "cons").
+		Where("hotel_id IN ? AND (fingerprint IS NULL OR fingerprint = '')", hotelIDs).
+		Find(&reviews).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, review := range reviews {
+		err := tx.Model(&entities.ReviewData{}).
+			Where("id = ?", review.ID).
+			UpdateColumn("fingerprint", review.ContentFingerprint()).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(reviews)), nil
+}
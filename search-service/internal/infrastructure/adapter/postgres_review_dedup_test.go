@@ -0,0 +1,140 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/database"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"gorm.io/gorm"
+)
+
+// testPostgresDSNEnv names the database the review dedupe tests run against. The merge query
+// uses PostgreSQL features, so the tests are skipped without one.
+const testPostgresDSNEnv = "HMS_TEST_POSTGRES_DSN"
+
+// openTestPostgres connects to the test database with a single connection whose search_path is
+// a migrated schema of its own, dropped when the test ends.
+func openTestPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testPostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresDSNEnv)
+	}
+
+	db, err := database.GormOpen(dsn)
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("review_dedup_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE SCHEMA "+schema).Error)
+	require.NoError(t, db.Exec("SET search_path TO "+schema).Error)
+	t.Cleanup(func() {
+		_ = db.Exec("DROP SCHEMA " + schema + " CASCADE").Error
+		_ = sqlDB.Close()
+	})
+	require.NoError(t, database.RunMigrations(db))
+	return db
+}
+
+func seedDedupReview(t *testing.T, db *gorm.DB, hotelID, reviewID int64, name, headline string, score int32) *entities.ReviewData {
+	t.Helper()
+	review := &entities.ReviewData{
+		HotelID:      hotelID,
+		ReviewID:     reviewID,
+		AverageScore: score,
+		Country:      "es",
+		Type:         "couple",
+		Name:         name,
+		Date:         time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
+		Headline:     headline,
+		Source:       "booking",
+	}
+	require.NoError(t, db.Create(review).Error)
+	// Rows are merged into the oldest one, so every review is created a little later.
+	time.Sleep(time.Millisecond)
+	return review
+}
+
+func liveReviewIDs(t *testing.T, db *gorm.DB, hotelID int64) []int64 {
+	t.Helper()
+	var reviewIDs []int64
+	require.NoError(t, db.Model(&entities.ReviewData{}).Where("hotel_id = ?", hotelID).Order("review_id").Pluck("review_id", &reviewIDs).Error)
+	return reviewIDs
+}
+
+func reviewCount(t *testing.T, db *gorm.DB, hotelID int64) int32 {
+	t.Helper()
+	var stored entities.HotelData
+	require.NoError(t, db.Where("hotel_id = ?", hotelID).First(&stored).Error)
+	return stored.ReviewCount
+}
+
+func TestDeduplicateReviewsMergesSeededDuplicates(t *testing.T) {
+	db := openTestPostgres(t)
+	ctx := context.Background()
+	repository := NewPostgresHotelRepository(db, nil, slog.New(slog.DiscardHandler))
+
+	require.NoError(t, db.Create(&entities.HotelData{HotelID: 1, Name: "Hotel Arts", ReviewCount: 250}).Error)
+	require.NoError(t, db.Create(&entities.HotelData{HotelID: 2, Name: "Hotel Colón", ReviewCount: 99}).Error)
+
+	seedDedupReview(t, db, 1, 100, "Ana", "Lovely stay", 8)
+	seedDedupReview(t, db, 1, 101, " ANA ", "lovely   STAY", 8)
+	seedDedupReview(t, db, 1, 102, "ana", "Lovely stay", 8)
+	seedDedupReview(t, db, 1, 110, "Luc", "Noisy", 5)
+	// Reviews without text on the same day by other guests, or with other scores, are kept.
+	seedDedupReview(t, db, 1, 120, "Marta", "", 9)
+	seedDedupReview(t, db, 1, 121, "Jordi", "", 9)
+	seedDedupReview(t, db, 1, 122, "Jordi", "", 7)
+	// A duplicate stored before fingerprints existed is fingerprinted, then merged.
+	unfingerprinted := seedDedupReview(t, db, 1, 111, "Luc", "noisy", 5)
+	require.NoError(t, db.Model(unfingerprinted).UpdateColumn("fingerprint", nil).Error)
+	seedDedupReview(t, db, 2, 200, "Ana", "Lovely stay", 8)
+
+	batch, err := repository.DeduplicateReviews(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, batch.Hotels)
+	assert.Equal(t, int64(2), batch.LastHotelID)
+	assert.Equal(t, int64(1), batch.Fingerprinted)
+	assert.Equal(t, int64(3), batch.MergedReviews)
+
+	assert.Equal(t, []int64{100, 110, 120, 121, 122}, liveReviewIDs(t, db, 1), "the oldest review of every fingerprint is kept")
+	assert.Equal(t, []int64{200}, liveReviewIDs(t, db, 2), "reviews of other hotels are not merged")
+	var softDeleted int64
+	require.NoError(t, db.Unscoped().Model(&entities.ReviewData{}).Where("review_id IN ? AND deleted_at IS NOT NULL", []int64{101, 102, 111}).Count(&softDeleted).Error)
+	assert.Equal(t, int64(3), softDeleted)
+
+	assert.Equal(t, int32(5), reviewCount(t, db, 1), "the review count is recounted from the reviews left")
+	assert.Equal(t, int32(99), reviewCount(t, db, 2), "a hotel without duplicates keeps its review count")
+
+	again, err := repository.DeduplicateReviews(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, again.Fingerprinted)
+	assert.Zero(t, again.MergedReviews, "a second run merges nothing new")
+	assert.Equal(t, int32(5), reviewCount(t, db, 1))
+}
+
+func TestDeduplicateReviewsPagesHotels(t *testing.T) {
+	db := openTestPostgres(t)
+	repository := NewPostgresHotelRepository(db, nil, slog.New(slog.DiscardHandler))
+	for hotelID := int64(1); hotelID <= 3; hotelID++ {
+		require.NoError(t, db.Create(&entities.HotelData{HotelID: hotelID, Name: fmt.Sprintf("Hotel %d", hotelID)}).Error)
+	}
+
+	batch, err := repository.DeduplicateReviews(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, batch.Hotels)
+	assert.Equal(t, int64(2), batch.LastHotelID)
+
+	batch, err = repository.DeduplicateReviews(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Zero(t, batch.Hotels)
+}
@@ -0,0 +1,158 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"gorm.io/gorm"
+)
+
+type PostgresSyncHistoryRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPostgresSyncHistoryRepository(db *gorm.DB, logger *slog.Logger) *PostgresSyncHistoryRepository {
+	return &PostgresSyncHistoryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgresSyncHistoryRepository) Save(ctx context.Context, run *synchistory.Run) error {
+	errorsJSON, err := json.Marshal(run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync errors: %w", err)
+	}
+
+	model := entities.SyncRun{
+		Mode:          run.Mode,
+		TriggerSource: run.Trigger,
+		Chain:         run.Chain,
+		Status:        run.Status,
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+		DurationMs:    run.DurationMs,
+		TotalHotels:   run.TotalHotels,
+		IndexedHotels: run.IndexedHotels,
+		FailedHotels:  run.FailedHotels,
+		RemovedHotels: run.RemovedHotels,
+		ErrorCount:    run.ErrorCount,
+		Errors:        errorsJSON,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to save sync run: %w", err)
+	}
+
+	run.ID = model.ID
+	return nil
+}
+
+// List returns one page of the runs started since options.Since, most recent first.
+func (r *PostgresSyncHistoryRepository) List(ctx context.Context, options synchistory.ListOptions) ([]synchistory.Run, error) {
+	var models []entities.SyncRun
+	err := r.db.WithContext(ctx).
+		Where("started_at >= ?", options.Since).
+		Order("started_at DESC, id DESC").
+		Limit(options.Limit).
+		Offset(options.Offset).
+		Find(&models).Error
+	if err != nil {
+		r.logger.Error("Failed to list sync runs", "error", err)
+		return nil, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+
+	runs := make([]synchistory.Run, len(models))
+	for i := range models {
+		runs[i] = r.toRun(&models[i])
+	}
+	return runs, nil
+}
+
+func (r *PostgresSyncHistoryRepository) Summarize(ctx context.Context, since time.Time) (synchistory.Summary, error) {
+	var row struct {
+		Runs          int64
+		FailedRuns    int64
+		AvgDurationMs float64
+		IndexedHotels int64
+		FailedHotels  int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entities.SyncRun{}).
+		Select(`COUNT(*) AS runs,
+			COUNT(*) FILTER (WHERE status = ? OR failed_hotels > 0) AS failed_runs,
+			COALESCE(AVG(duration_ms), 0) AS avg_duration_ms,
+			COALESCE(SUM(indexed_hotels), 0) AS indexed_hotels,
+			COALESCE(SUM(failed_hotels), 0) AS failed_hotels`, synchistory.StatusFailed).
+		Where("started_at >= ?", since).
+		Scan(&row).Error
+	if err != nil {
+		r.logger.Error("Failed to summarize sync runs", "error", err)
+		return synchistory.Summary{}, fmt.Errorf("failed to summarize sync runs: %w", err)
+	}
+
+	summary := synchistory.Summary{
+		Since:         since.UTC(),
+		Runs:          row.Runs,
+		FailedRuns:    row.FailedRuns,
+		AvgDurationMs: row.AvgDurationMs,
+		IndexedHotels: row.IndexedHotels,
+		FailedHotels:  row.FailedHotels,
+	}
+	if row.Runs > 0 {
+		summary.FailureRate = float64(row.FailedRuns) / float64(row.Runs)
+	}
+	return summary, nil
+}
+
+func (r *PostgresSyncHistoryRepository) Last(ctx context.Context) (*synchistory.Run, error) {
+	var model entities.SyncRun
+	err := r.db.WithContext(ctx).Order("started_at DESC, id DESC").First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find last sync run: %w", err)
+	}
+
+	run := r.toRun(&model)
+	return &run, nil
+}
+
+func (r *PostgresSyncHistoryRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("started_at < ?", cutoff).Delete(&entities.SyncRun{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete sync runs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *PostgresSyncHistoryRepository) toRun(model *entities.SyncRun) synchistory.Run {
+	run := synchistory.Run{
+		ID:            model.ID,
+		Mode:          model.Mode,
+		Trigger:       model.TriggerSource,
+		Chain:         model.Chain,
+		Status:        model.Status,
+		StartedAt:     model.StartedAt.UTC(),
+		FinishedAt:    model.FinishedAt.UTC(),
+		DurationMs:    model.DurationMs,
+		TotalHotels:   model.TotalHotels,
+		IndexedHotels: model.IndexedHotels,
+		FailedHotels:  model.FailedHotels,
+		RemovedHotels: model.RemovedHotels,
+		ErrorCount:    model.ErrorCount,
+	}
+	if len(model.Errors) > 0 {
+		if err := json.Unmarshal(model.Errors, &run.Errors); err != nil {
+			r.logger.Warn("Failed to decode sync run errors", "id", model.ID, "error", err)
+		}
+	}
+	return run
+}
@@ -0,0 +1,147 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newSQLiteSyncHistoryRepository(t *testing.T) *PostgresSyncHistoryRepository {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s", filepath.Join(t.TempDir(), "sync_runs.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().CreateTable(&entities.SyncRun{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return NewPostgresSyncHistoryRepository(db, slog.New(slog.DiscardHandler))
+}
+
+func saveSyncRun(t *testing.T, repository *PostgresSyncHistoryRepository, startedAt time.Time, status string, durationMs int64, indexed, failed int) *synchistory.Run {
+	t.Helper()
+	run := &synchistory.Run{
+		Mode:          synchistory.ModeIncremental,
+		Trigger:       synchistory.TriggerPeriodic,
+		Status:        status,
+		StartedAt:     startedAt,
+		FinishedAt:    startedAt.Add(time.Duration(durationMs) * time.Millisecond),
+		DurationMs:    durationMs,
+		TotalHotels:   indexed + failed,
+		IndexedHotels: indexed,
+		FailedHotels:  failed,
+	}
+	require.NoError(t, repository.Save(context.Background(), run))
+	return run
+}
+
+func TestSyncHistoryRepositoryListsRunsMostRecentFirst(t *testing.T) {
+	repository := newSQLiteSyncHistoryRepository(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	saveSyncRun(t, repository, now.AddDate(0, 0, -40), synchistory.StatusCompleted, 1000, 10, 0)
+	first := saveSyncRun(t, repository, now.AddDate(0, 0, -2), synchistory.StatusCompleted, 1000, 10, 0)
+	second := &synchistory.Run{
+		Mode:       synchistory.ModeFull,
+		Trigger:    synchistory.TriggerManual,
+		Status:     synchistory.StatusFailed,
+		StartedAt:  now.AddDate(0, 0, -1),
+		FinishedAt: now.AddDate(0, 0, -1).Add(time.Second),
+		ErrorCount: 12,
+		Errors:     []string{"index unavailable"},
+	}
+	require.NoError(t, repository.Save(ctx, second))
+	assert.NotZero(t, second.ID)
+
+	runs, err := repository.List(ctx, synchistory.ListOptions{Since: now.AddDate(0, 0, -30), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, second.ID, runs[0].ID)
+	assert.Equal(t, synchistory.ModeFull, runs[0].Mode)
+	assert.Equal(t, synchistory.TriggerManual, runs[0].Trigger)
+	assert.Equal(t, 12, runs[0].ErrorCount)
+	assert.Equal(t, []string{"index unavailable"}, runs[0].Errors)
+	assert.Equal(t, first.ID, runs[1].ID)
+	assert.Equal(t, first.StartedAt, runs[1].StartedAt)
+
+	page, err := repository.List(ctx, synchistory.ListOptions{Since: now.AddDate(0, 0, -30), Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first.ID, page[0].ID)
+
+	last, err := repository.Last(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, second.ID, last.ID)
+}
+
+func TestSyncHistoryRepositorySummarizesTheWindow(t *testing.T) {
+	repository := newSQLiteSyncHistoryRepository(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	saveSyncRun(t, repository, now.AddDate(0, 0, -40), synchistory.StatusFailed, 9000, 0, 0)
+	saveSyncRun(t, repository, now.AddDate(0, 0, -3), synchistory.StatusCompleted, 1000, 100, 0)
+	saveSyncRun(t, repository, now.AddDate(0, 0, -2), synchistory.StatusCompleted, 2000, 90, 10)
+	saveSyncRun(t, repository, now.AddDate(0, 0, -1), synchistory.StatusFailed, 3000, 0, 0)
+	saveSyncRun(t, repository, now, synchistory.StatusSuperseded, 4000, 50, 0)
+
+	since := now.AddDate(0, 0, -30)
+	summary, err := repository.Summarize(ctx, since)
+	require.NoError(t, err)
+
+	assert.Equal(t, since, summary.Since)
+	assert.Equal(t, int64(4), summary.Runs)
+	assert.Equal(t, int64(2), summary.FailedRuns, "a run that failed or failed to index a hotel counts as failed")
+	assert.InDelta(t, 2500, summary.AvgDurationMs, 0.001)
+	assert.InDelta(t, 0.5, summary.FailureRate, 0.001)
+	assert.Equal(t, int64(240), summary.IndexedHotels)
+	assert.Equal(t, int64(10), summary.FailedHotels)
+}
+
+func TestSyncHistoryRepositoryDeletesRunsBeforeTheCutoff(t *testing.T) {
+	repository := newSQLiteSyncHistoryRepository(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	saveSyncRun(t, repository, now.AddDate(0, 0, -45), synchistory.StatusCompleted, 1000, 1, 0)
+	saveSyncRun(t, repository, now.AddDate(0, 0, -31), synchistory.StatusCompleted, 1000, 1, 0)
+	kept := saveSyncRun(t, repository, now.AddDate(0, 0, -29), synchistory.StatusCompleted, 1000, 1, 0)
+
+	deleted, err := repository.DeleteBefore(ctx, now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	runs, err := repository.List(ctx, synchistory.ListOptions{Since: time.Time{}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, kept.ID, runs[0].ID)
+}
+
+func TestSyncHistoryRepositoryWithoutRuns(t *testing.T) {
+	repository := newSQLiteSyncHistoryRepository(t)
+	ctx := context.Background()
+
+	last, err := repository.Last(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	summary, err := repository.Summarize(ctx, time.Now().UTC().AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Zero(t, summary.Runs)
+	assert.Zero(t, summary.FailureRate)
+}
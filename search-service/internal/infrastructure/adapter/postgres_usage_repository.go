@@ -0,0 +1,92 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresUsageRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPostgresUsageRepository(db *gorm.DB, logger *slog.Logger) *PostgresUsageRepository {
+	return &PostgresUsageRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// UpsertDailyUsage stores the counters, keeping the highest value seen for each one. Redis
+// counters only grow during a day, so re-running a rollup is a no-op, and counters reset by a
+// Redis restart cannot lower what was already rolled up.
+func (r *PostgresUsageRepository) UpsertDailyUsage(ctx context.Context, usages []usage.DailyUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	models := make([]entities.APIUsageDaily, len(usages))
+	for i, u := range usages {
+		models[i] = entities.APIUsageDaily{
+			ClientID:  u.ClientID,
+			Endpoint:  u.Endpoint,
+			Day:       usage.Day(u.Day),
+			Requests:  u.Requests,
+			Errors:    u.Errors,
+			Results:   u.Results,
+			UpdatedAt: now,
+		}
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "client_id"}, {Name: "endpoint"}, {Name: "day"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "requests"}, Value: gorm.Expr("GREATEST(api_usage_daily.requests, EXCLUDED.requests)")},
+			{Column: clause.Column{Name: "errors"}, Value: gorm.Expr("GREATEST(api_usage_daily.errors, EXCLUDED.errors)")},
+			{Column: clause.Column{Name: "results"}, Value: gorm.Expr("GREATEST(api_usage_daily.results, EXCLUDED.results)")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("EXCLUDED.updated_at")},
+		},
+	}).CreateInBatches(models, 500).Error
+	if err != nil {
+		r.logger.Error("Failed to upsert daily usage", "count", len(models), "error", err)
+		return fmt.Errorf("failed to upsert daily usage: %w", err)
+	}
+
+	return nil
+}
+
+// FindDailyUsage returns the usage of clientID between from and to, both days inclusive.
+func (r *PostgresUsageRepository) FindDailyUsage(ctx context.Context, clientID string, from, to time.Time) ([]usage.DailyUsage, error) {
+	var models []entities.APIUsageDaily
+
+	err := r.db.WithContext(ctx).
+		Where("client_id = ? AND day BETWEEN ? AND ?", clientID, usage.Day(from), usage.Day(to)).
+		Order("day ASC, endpoint ASC").
+		Find(&models).Error
+	if err != nil {
+		r.logger.Error("Failed to find daily usage", "client_id", clientID, "error", err)
+		return nil, fmt.Errorf("failed to find daily usage: %w", err)
+	}
+
+	usages := make([]usage.DailyUsage, len(models))
+	for i, model := range models {
+		usages[i] = usage.DailyUsage{
+			ClientID: model.ClientID,
+			Endpoint: model.Endpoint,
+			Day:      model.Day.UTC(),
+			Requests: model.Requests,
+			Errors:   model.Errors,
+			Results:  model.Results,
+		}
+	}
+
+	return usages, nil
+}
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/favorites"
+)
+
+const favoritesKeyPrefix = "favorites:"
+
+// RedisFavoritesStore keeps the favorites of each session in a set.
+type RedisFavoritesStore struct {
+	client *redis.Client
+	logger *slog.Logger
+}
+
+func NewRedisFavoritesStore(client *redis.Client, logger *slog.Logger) *RedisFavoritesStore {
+	return &RedisFavoritesStore{client: client, logger: logger}
+}
+
+func (s *RedisFavoritesStore) Add(ctx context.Context, sessionID string, hotelID int64) error {
+	key := favoritesKeyPrefix + sessionID
+	member := strconv.FormatInt(hotelID, 10)
+
+	var isMember *redis.BoolCmd
+	var count *redis.IntCmd
+	if _, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		isMember = pipe.SIsMember(ctx, key, member)
+		count = pipe.SCard(ctx, key)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to read favorites: %w", err)
+	}
+	if !isMember.Val() && count.Val() >= favorites.MaxPerSession {
+		return favorites.ErrLimitReached
+	}
+
+	if _, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, member)
+		pipe.Expire(ctx, key, favorites.TTL)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to add favorite: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisFavoritesStore) Remove(ctx context.Context, sessionID string, hotelID int64) error {
+	key := favoritesKeyPrefix + sessionID
+	if _, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SRem(ctx, key, strconv.FormatInt(hotelID, 10))
+		pipe.Expire(ctx, key, favorites.TTL)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to remove favorite: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisFavoritesStore) List(ctx context.Context, sessionID string) ([]int64, error) {
+	key := favoritesKeyPrefix + sessionID
+
+	var members *redis.StringSliceCmd
+	if _, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		members = pipe.SMembers(ctx, key)
+		pipe.Expire(ctx, key, favorites.TTL)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list favorites: %w", err)
+	}
+
+	hotelIDs := make([]int64, 0, len(members.Val()))
+	for _, member := range members.Val() {
+		hotelID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			s.logger.Warn("Skipping malformed favorite", "key", key, "member", member)
+			continue
+		}
+		hotelIDs = append(hotelIDs, hotelID)
+	}
+	slices.Sort(hotelIDs)
+	return hotelIDs, nil
+}
@@ -0,0 +1,116 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/pkg/redislock"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/leader"
+)
+
+const syncLeaderKey = "sync:leader"
+
+// RedisLeaderElector elects one replica as sync leader through a Redis key set with SETNX and
+// a TTL. The leader renews the key every third of the TTL; when it stops, the key lapses and
+// the next replica to campaign takes over. A replica that cannot reach Redis gives up the
+// leadership rather than risk two leaders, and a leader that has not renewed the key within
+// the TTL stops leading, as another replica may already have taken over.
+type RedisLeaderElector struct {
+	client   *redis.Client
+	replica  string
+	ttl      time.Duration
+	isLeader atomic.Bool
+	// leaseUntil is when the key, as last set or renewed by this replica, expires, in Unix
+	// nanoseconds.
+	leaseUntil atomic.Int64
+	logger     *slog.Logger
+}
+
+func NewRedisLeaderElector(client *redis.Client, replica string, ttl time.Duration, logger *slog.Logger) *RedisLeaderElector {
+	return &RedisLeaderElector{
+		client:  client,
+		replica: replica,
+		ttl:     ttl,
+		logger:  logger,
+	}
+}
+
+func (e *RedisLeaderElector) IsLeader() bool {
+	return e.isLeader.Load() && time.Now().UnixNano() < e.leaseUntil.Load()
+}
+
+// Status reports the current leader as stored in Redis.
+func (e *RedisLeaderElector) Status(ctx context.Context) leader.Status {
+	status := leader.Status{Replica: e.replica, IsLeader: e.IsLeader(), Elected: true}
+
+	current, err := redislock.Owner(ctx, e.client, syncLeaderKey)
+	if err != nil {
+		e.logger.Warn("Failed to read sync leader", "error", err)
+	}
+	status.Leader = current
+	return status
+}
+
+// Campaign renews the leadership of this replica, or tries to take it when it has none.
+func (e *RedisLeaderElector) Campaign(ctx context.Context) {
+	// The lease is counted from before the request, so the replica never believes it leads
+	// for longer than the key lives in Redis.
+	campaignedAt := time.Now()
+	if e.isLeader.Load() {
+		renewed, err := redislock.Renew(ctx, e.client, syncLeaderKey, e.replica, e.ttl)
+		if err == nil && renewed {
+			e.leaseUntil.Store(campaignedAt.Add(e.ttl).UnixNano())
+			return
+		}
+		e.isLeader.Store(false)
+		e.logger.Warn("Lost sync leadership", "replica", e.replica, "error", err)
+		if err != nil {
+			return
+		}
+	}
+
+	acquired, err := redislock.Acquire(ctx, e.client, syncLeaderKey, e.replica, e.ttl)
+	if err != nil {
+		e.logger.Warn("Failed to campaign for sync leadership", "replica", e.replica, "error", err)
+		return
+	}
+	if acquired {
+		e.leaseUntil.Store(campaignedAt.Add(e.ttl).UnixNano())
+		e.isLeader.Store(true)
+		e.logger.Info("Became sync leader", "replica", e.replica, "ttl", e.ttl)
+	}
+}
+
+// Run campaigns every third of the TTL until ctx is done, then resigns.
+func (e *RedisLeaderElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.Resign()
+			return
+		case <-ticker.C:
+			e.Campaign(ctx)
+		}
+	}
+}
+
+// Resign releases the leadership so another replica takes over without waiting for the TTL.
+func (e *RedisLeaderElector) Resign() {
+	if !e.isLeader.Swap(false) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := redislock.Release(ctx, e.client, syncLeaderKey, e.replica); err != nil {
+		e.logger.Warn("Failed to resign sync leadership", "replica", e.replica, "error", err)
+		return
+	}
+	e.logger.Info("Resigned sync leadership", "replica", e.replica)
+}
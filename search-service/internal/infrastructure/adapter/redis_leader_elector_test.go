@@ -0,0 +1,173 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestElectors returns one elector per replica, all campaigning on the same Redis.
+func newTestElectors(t *testing.T, ttl time.Duration, replicas ...string) (*miniredis.Miniredis, *redis.Client, []*RedisLeaderElector) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	electors := make([]*RedisLeaderElector, len(replicas))
+	for i, replica := range replicas {
+		electors[i] = NewRedisLeaderElector(client, replica, ttl, slog.New(slog.DiscardHandler))
+	}
+	return server, client, electors
+}
+
+func TestRedisLeaderElectorElectsOneReplica(t *testing.T) {
+	_, _, electors := newTestElectors(t, time.Minute, "replica-a", "replica-b")
+	ctx := context.Background()
+
+	electors[0].Campaign(ctx)
+	electors[1].Campaign(ctx)
+
+	assert.True(t, electors[0].IsLeader())
+	assert.False(t, electors[1].IsLeader())
+	for _, elector := range electors {
+		assert.Equal(t, "replica-a", elector.Status(ctx).Leader)
+	}
+
+	// Renewing keeps the leadership with the same replica.
+	electors[1].Campaign(ctx)
+	electors[0].Campaign(ctx)
+	assert.True(t, electors[0].IsLeader())
+	assert.False(t, electors[1].IsLeader())
+}
+
+func TestRedisLeaderElectorFailsOverAfterTheLeaseExpires(t *testing.T) {
+	server, _, electors := newTestElectors(t, time.Minute, "replica-a", "replica-b")
+	ctx := context.Background()
+	electors[0].Campaign(ctx)
+	require.True(t, electors[0].IsLeader())
+
+	// replica-a stops renewing, as a stalled or crashed leader would.
+	server.FastForward(time.Minute + time.Second)
+	electors[1].Campaign(ctx)
+	assert.True(t, electors[1].IsLeader(), "the follower takes over the lapsed leadership")
+
+	electors[0].Campaign(ctx)
+	assert.False(t, electors[0].IsLeader(), "the previous leader cannot renew a leadership taken over")
+	assert.Equal(t, "replica-b", electors[0].Status(ctx).Leader)
+}
+
+func TestRedisLeaderElectorStopsLeadingWhenItsLeaseLapses(t *testing.T) {
+	_, _, electors := newTestElectors(t, 50*time.Millisecond, "replica-a")
+	ctx := context.Background()
+	electors[0].Campaign(ctx)
+	require.True(t, electors[0].IsLeader())
+
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, electors[0].IsLeader(), "a leader that has not renewed within the TTL stops leading")
+
+	electors[0].Campaign(ctx)
+	assert.True(t, electors[0].IsLeader(), "a lease still held is renewed")
+}
+
+func TestRedisLeaderElectorResignHandsOverWithoutWaitingForTheTTL(t *testing.T) {
+	_, _, electors := newTestElectors(t, time.Minute, "replica-a", "replica-b")
+	ctx := context.Background()
+	electors[0].Campaign(ctx)
+
+	electors[0].Resign()
+	electors[1].Campaign(ctx)
+
+	assert.False(t, electors[0].IsLeader())
+	assert.True(t, electors[1].IsLeader())
+}
+
+// discardSyncHistory records nothing; the syncs under test are checked through the engine.
+type discardSyncHistory struct {
+	synchistory.Repository
+}
+
+func (discardSyncHistory) Save(context.Context, *synchistory.Run) error {
+	return nil
+}
+
+// newLeaderTestSync returns the sync use case of one replica, indexing into engine.
+func newLeaderTestSync(client *redis.Client, repository hotel.Repository, engine *mocks.MockEngine, elector *RedisLeaderElector) *usecase.SyncHotelsUseCase {
+	logger := slog.New(slog.DiscardHandler)
+	cache := NewRedisCacheAdapterWithClient(client, logger)
+	return usecase.NewSyncHotelsUseCase(repository, engine, cache, cache, nil, elector, false, discardSyncHistory{}, 0, logger)
+}
+
+func TestScheduledSyncRunsOnTheLeaderOnly(t *testing.T) {
+	_, client, electors := newTestElectors(t, time.Minute, "replica-a", "replica-b")
+	ctx := context.Background()
+	for _, elector := range electors {
+		elector.Campaign(ctx)
+	}
+
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	hotels := []*hotel.Hotel{{HotelID: 1}, {HotelID: 2}}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil).Times(1)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil).Times(1)
+
+	options := usecase.SyncOptions{FullSync: true, BatchSize: 10, Trigger: synchistory.TriggerPeriodic}
+	synced := 0
+	for _, elector := range electors {
+		_, err := newLeaderTestSync(client, repository, engine, elector).Execute(ctx, options)
+		if err == nil {
+			synced++
+			continue
+		}
+		assert.ErrorIs(t, err, usecase.ErrSyncLeadershipLost)
+	}
+	assert.Equal(t, 1, synced, "exactly one replica runs the scheduled sync")
+
+	// A sync triggered through the admin API runs on a follower too.
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	engine.EXPECT().Index(gomock.Any(), hotels).Return(nil)
+	_, err := newLeaderTestSync(client, repository, engine, electors[1]).Execute(ctx, usecase.SyncOptions{FullSync: true, BatchSize: 10})
+	assert.NoError(t, err)
+}
+
+func TestScheduledSyncStopsWhenTheLeadershipIsLostMidSync(t *testing.T) {
+	server, client, electors := newTestElectors(t, time.Minute, "replica-a", "replica-b")
+	ctx := context.Background()
+	electors[0].Campaign(ctx)
+
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	hotels := []*hotel.Hotel{{HotelID: 1}, {HotelID: 2}, {HotelID: 3}}
+	repository.EXPECT().FindAll(gomock.Any(), 1000, 0).Return(hotels, nil)
+	// While the first batch is indexed the leader's lease lapses and replica-b takes over.
+	engine.EXPECT().Index(gomock.Any(), hotels[:1]).DoAndReturn(func(ctx context.Context, _ []*hotel.Hotel) error {
+		server.FastForward(time.Minute + time.Second)
+		electors[1].Campaign(ctx)
+		electors[0].Campaign(ctx)
+		return nil
+	})
+
+	result, err := newLeaderTestSync(client, repository, engine, electors[0]).Execute(ctx, usecase.SyncOptions{
+		FullSync:  true,
+		BatchSize: 1,
+		Trigger:   synchistory.TriggerPeriodic,
+	})
+
+	require.ErrorIs(t, err, usecase.ErrSyncLeadershipLost)
+	assert.ErrorIs(t, err, usecase.ErrSyncSuperseded)
+	assert.True(t, result.Superseded)
+	assert.Equal(t, 1, result.IndexedHotels, "no batch is indexed after the leadership is lost")
+	assert.True(t, electors[1].IsLeader())
+}
@@ -0,0 +1,97 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const (
+	queryLogKeyPrefix = "trending_queries:"
+	// maxTrackedQueries bounds each bucket; the least recently searched queries are dropped past
+	// it.
+	maxTrackedQueries = 1000
+)
+
+// recordQueryScript counts a search of ARGV[1] in the bucket KEYS[1], records in KEYS[2] when
+// the query was last searched and counts the search in KEYS[3]. Past ARGV[3] queries, the least
+// recently searched ones are dropped rather than the least searched, so that a new query is not
+// dropped the moment it is counted and a full bucket keeps following recent searches. Keys
+// without a TTL get ARGV[4] milliseconds.
+var recordQueryScript = redis.NewScript(`
+redis.call("ZINCRBY", KEYS[1], 1, ARGV[1])
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[1])
+redis.call("INCR", KEYS[3])
+local excess = redis.call("ZCARD", KEYS[2]) - tonumber(ARGV[3])
+if excess > 0 then
+	local stale = redis.call("ZRANGE", KEYS[2], 0, excess - 1)
+	redis.call("ZREM", KEYS[1], unpack(stale))
+	redis.call("ZREM", KEYS[2], unpack(stale))
+end
+for _, key in ipairs(KEYS) do
+	if redis.call("PTTL", key) == -1 then
+		redis.call("PEXPIRE", key, ARGV[4])
+	end
+end
+return 0
+`)
+
+// RedisQueryLog counts the searches of each locale in a sorted set, next to a counter of the
+// searches recorded. A bucket expires bucketTTL after its first search and then starts over,
+// so trending lists follow recent searches.
+type RedisQueryLog struct {
+	client    *redis.Client
+	bucketTTL time.Duration
+	now       func() time.Time
+}
+
+func NewRedisQueryLog(client *redis.Client, bucketTTL time.Duration) *RedisQueryLog {
+	return &RedisQueryLog{client: client, bucketTTL: bucketTTL, now: time.Now}
+}
+
+func (l *RedisQueryLog) Record(ctx context.Context, locale, query string) error {
+	locales := []string{search.GlobalLocale}
+	if locale != search.GlobalLocale {
+		locales = append(locales, locale)
+	}
+
+	searchedAt := l.now().UnixMilli()
+	for _, locale := range locales {
+		queriesKey, samplesKey := queryLogKeys(locale)
+		keys := []string{queriesKey, queriesKey + ":last_searched", samplesKey}
+		if err := recordQueryScript.Run(ctx, l.client, keys, query, searchedAt, maxTrackedQueries, l.bucketTTL.Milliseconds()).Err(); err != nil {
+			return fmt.Errorf("failed to record search query: %w", err)
+		}
+	}
+	return nil
+}
+
+func (l *RedisQueryLog) Top(ctx context.Context, locale string, limit int) ([]search.QueryCount, int64, error) {
+	queriesKey, samplesKey := queryLogKeys(locale)
+
+	var queries *redis.ZSliceCmd
+	var samples *redis.StringCmd
+	if _, err := l.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		queries = pipe.ZRevRangeWithScores(ctx, queriesKey, 0, int64(limit)-1)
+		samples = pipe.Get(ctx, samplesKey)
+		return nil
+	}); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, fmt.Errorf("failed to read trending queries: %w", err)
+	}
+
+	sampleCount, _ := strconv.ParseInt(samples.Val(), 10, 64)
+	counts := make([]search.QueryCount, 0, len(queries.Val()))
+	for _, query := range queries.Val() {
+		counts = append(counts, search.QueryCount{Query: fmt.Sprint(query.Member), Count: int64(query.Score)})
+	}
+	return counts, sampleCount, nil
+}
+
+func queryLogKeys(locale string) (string, string) {
+	return queryLogKeyPrefix + locale, queryLogKeyPrefix + locale + ":samples"
+}
@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+func newTestQueryLog(t *testing.T) (*RedisQueryLog, *miniredis.Miniredis, *time.Time) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	log := NewRedisQueryLog(client, time.Hour)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	log.now = func() time.Time { return now }
+	return log, server, &now
+}
+
+func TestRedisQueryLogCountsPerLocaleAndGlobally(t *testing.T) {
+	log, server, _ := newTestQueryLog(t)
+	ctx := context.Background()
+
+	for _, searched := range []struct{ locale, query string }{
+		{"es", "playa"}, {"es", "playa"}, {"es", "madrid"}, {"en", "london"}, {search.GlobalLocale, "paris"},
+	} {
+		require.NoError(t, log.Record(ctx, searched.locale, searched.query))
+	}
+
+	spanish, samples, err := log.Top(ctx, "es", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []search.QueryCount{{Query: "playa", Count: 2}, {Query: "madrid", Count: 1}}, spanish)
+	assert.Equal(t, int64(3), samples)
+
+	global, samples, err := log.Top(ctx, search.GlobalLocale, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []search.QueryCount{{Query: "playa", Count: 2}}, global)
+	assert.Equal(t, int64(5), samples)
+
+	queriesKey, samplesKey := queryLogKeys("es")
+	for _, key := range []string{queriesKey, queriesKey + ":last_searched", samplesKey} {
+		assert.Equal(t, time.Hour, server.TTL(key), key)
+	}
+
+	unknown, samples, err := log.Top(ctx, "de", 10)
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+	assert.Zero(t, samples)
+}
+
+func TestRedisQueryLogDropsTheLeastRecentlySearchedQueries(t *testing.T) {
+	log, _, now := newTestQueryLog(t)
+	ctx := context.Background()
+
+	// A popular query searched long ago, then enough other queries to fill the bucket.
+	for range 5 {
+		require.NoError(t, log.Record(ctx, "es", "old favourite"))
+	}
+	for i := range maxTrackedQueries - 1 {
+		*now = now.Add(time.Second)
+		require.NoError(t, log.Record(ctx, "es", fmt.Sprintf("query %d", i)))
+	}
+
+	*now = now.Add(time.Second)
+	require.NoError(t, log.Record(ctx, "es", "new query"))
+	*now = now.Add(time.Second)
+	require.NoError(t, log.Record(ctx, "es", "new query"))
+
+	queries, _, err := log.Top(ctx, "es", maxTrackedQueries+1)
+	require.NoError(t, err)
+	assert.Len(t, queries, maxTrackedQueries)
+	assert.Equal(t, search.QueryCount{Query: "new query", Count: 2}, queries[0], "a new query is kept once the bucket is full")
+	assert.NotContains(t, queries, search.QueryCount{Query: "old favourite", Count: 5})
+}
@@ -0,0 +1,196 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+const (
+	usageKeyPrefix = "usage:"
+	// usageKeyTTL keeps a day's counters long enough for a missed nightly rollup to be re-run.
+	usageKeyTTL        = 72 * time.Hour
+	usageQueueSize     = 4096
+	usageFlushBatch    = 512
+	usageFlushInterval = time.Second
+	usageFlushTimeout  = 5 * time.Second
+)
+
+const (
+	usageFieldRequests = "requests"
+	usageFieldErrors   = "errors"
+	usageFieldResults  = "results"
+)
+
+// RedisUsageCounter keeps one hash per client and day, with a requests, errors and results
+// field per endpoint. Record only enqueues; Run aggregates the queued events and writes them in
+// a single pipeline, so the request path never waits on Redis.
+type RedisUsageCounter struct {
+	client  *redis.Client
+	events  chan usage.Event
+	dropped atomic.Int64
+	logger  *slog.Logger
+}
+
+func NewRedisUsageCounter(client *redis.Client, logger *slog.Logger) *RedisUsageCounter {
+	return &RedisUsageCounter{
+		client: client,
+		events: make(chan usage.Event, usageQueueSize),
+		logger: logger,
+	}
+}
+
+// Record enqueues event and drops it when the queue is full.
+func (c *RedisUsageCounter) Record(event usage.Event) {
+	select {
+	case c.events <- event:
+	default:
+		c.dropped.Add(1)
+	}
+}
+
+// Run flushes queued events until ctx is done, then flushes what is left.
+func (c *RedisUsageCounter) Run(ctx context.Context) {
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]usage.Event, 0, usageFlushBatch)
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-c.events:
+					batch = append(batch, event)
+				default:
+					c.flush(batch)
+					return
+				}
+			}
+		case event := <-c.events:
+			batch = append(batch, event)
+			if len(batch) >= usageFlushBatch {
+				c.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			c.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (c *RedisUsageCounter) flush(batch []usage.Event) {
+	if dropped := c.dropped.Swap(0); dropped > 0 {
+		c.logger.Warn("Usage events dropped, queue full", "count", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	increments := make(map[string]map[string]int64)
+	for _, event := range batch {
+		key := usageKey(event.Day, event.ClientID)
+		fields, ok := increments[key]
+		if !ok {
+			fields = make(map[string]int64)
+			increments[key] = fields
+		}
+		fields[usageField(usageFieldRequests, event.Endpoint)]++
+		if event.Error {
+			fields[usageField(usageFieldErrors, event.Endpoint)]++
+		}
+		if event.Results > 0 {
+			fields[usageField(usageFieldResults, event.Endpoint)] += event.Results
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), usageFlushTimeout)
+	defer cancel()
+
+	pipe := c.client.Pipeline()
+	for key, fields := range increments {
+		for field, value := range fields {
+			pipe.HIncrBy(ctx, key, field, value)
+		}
+		pipe.Expire(ctx, key, usageKeyTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Warn("Failed to flush usage counters", "events", len(batch), "error", err)
+	}
+}
+
+// Collect reads the counters of every client for day.
+func (c *RedisUsageCounter) Collect(ctx context.Context, day time.Time) ([]usage.DailyUsage, error) {
+	pattern := usageKeyPrefix + day.Format(usage.DayLayout) + ":*"
+
+	usages := make(map[[2]string]*usage.DailyUsage)
+	iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		clientID := key[len(pattern)-1:]
+
+		fields, err := c.client.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read usage counters %s: %w", key, err)
+		}
+
+		for field, value := range fields {
+			metric, endpoint, ok := strings.Cut(field, "|")
+			if !ok {
+				continue
+			}
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			id := [2]string{clientID, endpoint}
+			entry, ok := usages[id]
+			if !ok {
+				entry = &usage.DailyUsage{ClientID: clientID, Endpoint: endpoint, Day: day}
+				usages[id] = entry
+			}
+			switch metric {
+			case usageFieldRequests:
+				entry.Requests = count
+			case usageFieldErrors:
+				entry.Errors = count
+			case usageFieldResults:
+				entry.Results = count
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan usage counters: %w", err)
+	}
+
+	result := make([]usage.DailyUsage, 0, len(usages))
+	for _, entry := range usages {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ClientID != result[j].ClientID {
+			return result[i].ClientID < result[j].ClientID
+		}
+		return result[i].Endpoint < result[j].Endpoint
+	})
+
+	return result, nil
+}
+
+func usageKey(day time.Time, clientID string) string {
+	return usageKeyPrefix + day.Format(usage.DayLayout) + ":" + clientID
+}
+
+func usageField(metric, endpoint string) string {
+	return metric + "|" + endpoint
+}
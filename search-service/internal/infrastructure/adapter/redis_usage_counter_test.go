@@ -0,0 +1,64 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+func newTestUsageCounter(t *testing.T) (*RedisUsageCounter, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisUsageCounter(client, slog.New(slog.DiscardHandler)), server
+}
+
+// flushUsage runs counter until every queued event is written.
+func flushUsage(counter *RedisUsageCounter) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	counter.Run(ctx)
+}
+
+func TestRedisUsageCounterIncrementsPerClientEndpointAndDay(t *testing.T) {
+	counter, server := newTestUsageCounter(t)
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	partner := usage.ClientID("partner-key")
+
+	counter.Record(usage.Event{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Results: 20})
+	counter.Record(usage.Event{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Results: 5})
+	counter.Record(usage.Event{ClientID: partner, Endpoint: "GET /api/v1/hotels/{id}", Day: day, Error: true})
+	counter.Record(usage.Event{ClientID: usage.AnonymousClient, Endpoint: "GET /api/v1/hotels/search", Day: day})
+	counter.Record(usage.Event{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day.AddDate(0, 0, 1)})
+	flushUsage(counter)
+
+	// A second flush adds to the counters already in Redis.
+	counter.Record(usage.Event{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Results: 1})
+	flushUsage(counter)
+
+	usages, err := counter.Collect(context.Background(), day)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []usage.DailyUsage{
+		{ClientID: usage.AnonymousClient, Endpoint: "GET /api/v1/hotels/search", Day: day, Requests: 1},
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/search", Day: day, Requests: 3, Results: 26},
+		{ClientID: partner, Endpoint: "GET /api/v1/hotels/{id}", Day: day, Requests: 1, Errors: 1},
+	}, usages)
+
+	assert.Positive(t, server.TTL(usageKey(day, partner)), "day counters expire")
+}
+
+func TestRedisUsageCounterCollectsNothingForAnEmptyDay(t *testing.T) {
+	counter, _ := newTestUsageCounter(t)
+
+	usages, err := counter.Collect(context.Background(), time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, usages)
+}
@@ -0,0 +1,198 @@
+package adapter
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"gorm.io/datatypes"
+)
+
+var (
+	rfc3339UTC    = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)
+	timestampLike = regexp.MustCompile(`^[+-]?\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}`)
+)
+
+// responseContract is what a walk over a JSON response found.
+type responseContract struct {
+	timestamps map[string]bool
+	durations  map[string]bool
+}
+
+// checkResponseContract walks the JSON encoding of response, asserting that every timestamp is
+// UTC RFC 3339 and that every _ms field has a duration string of the same length beside it.
+// It returns the keys of the timestamps and durations found.
+func checkResponseContract(t *testing.T, response any) responseContract {
+	t.Helper()
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	var decoded any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	contract := responseContract{timestamps: map[string]bool{}, durations: map[string]bool{}}
+	contract.walk(t, "$", "", decoded)
+	return contract
+}
+
+func (c responseContract) walk(t *testing.T, path, key string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for field, nested := range value {
+			if base, ok := strings.CutSuffix(field, "_ms"); ok {
+				c.checkDuration(t, path+"."+base, value[base], nested)
+				c.durations[base] = true
+			}
+			c.walk(t, path+"."+field, field, nested)
+		}
+	case []any:
+		for _, nested := range value {
+			c.walk(t, path+"[]", key, nested)
+		}
+	case string:
+		if timestampLike.MatchString(value) {
+			assert.Regexp(t, rfc3339UTC, value, "%s is not UTC RFC 3339", path)
+			c.timestamps[key] = true
+		}
+	}
+}
+
+func (c responseContract) checkDuration(t *testing.T, path string, text, milliseconds any) {
+	s, ok := text.(string)
+	if !assert.True(t, ok, "%s has a _ms field but is %v rather than a duration string", path, text) {
+		return
+	}
+	duration, err := time.ParseDuration(s)
+	if assert.NoError(t, err, path) {
+		assert.EqualValues(t, duration.Milliseconds(), milliseconds, "%s_ms", path)
+	}
+}
+
+// timeFieldKeys returns the JSON keys of the time.Time fields reachable from typ.
+func timeFieldKeys(typ reflect.Type) map[string]bool {
+	keys := map[string]bool{}
+	collectTimeFieldKeys(typ, keys, map[reflect.Type]bool{})
+	return keys
+}
+
+func collectTimeFieldKeys(typ reflect.Type, keys map[string]bool, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	timeType := reflect.TypeFor[time.Time]()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType == timeType {
+			keys[name] = true
+			continue
+		}
+		collectTimeFieldKeys(field.Type, keys, seen)
+	}
+}
+
+// inZone sets the local time zone to one east of UTC for the test, so timestamps that are not
+// converted to UTC serialize with an offset.
+func inZone(t *testing.T) *time.Location {
+	t.Helper()
+	local := time.Local
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	time.Local = zone
+	t.Cleanup(func() { time.Local = local })
+	return zone
+}
+
+func TestSearchResponseContract(t *testing.T) {
+	inZone(t)
+	adapter := &TypesenseAdapter{}
+	checkinStart, checkinEnd := 900, 1380
+	hits := []api.SearchResultHit{{
+		Document: &map[string]any{
+			"hotel_id":              1,
+			"name":                  "Harbour Hotel",
+			"created_at":            time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC).Unix(),
+			"updated_at":            time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC).Unix(),
+			"checkin_start_minutes": checkinStart,
+			"checkin_end_minutes":   checkinEnd,
+		},
+	}}
+
+	result := adapter.convertSearchResult(&api.SearchResult{Hits: &hits}, search.Params{}, 1, 20)
+	result.ProcessingTime = 1234567 * time.Microsecond
+	result.SearchEngineTime = 42 * time.Millisecond
+	contract := checkResponseContract(t, result)
+
+	assert.Equal(t, map[string]bool{"CreatedAt": true, "UpdatedAt": true, "NextUpdateAt": true, "CheckinStart": true,
+		"CheckinEnd": true, "Checkout": true}, contract.timestamps)
+	assert.Equal(t, map[string]bool{"processing_time": true, "search_engine_time": true}, contract.durations)
+	assert.Equal(t, time.UTC, result.Hotels[0].CreatedAt.Location())
+}
+
+func TestDetailResponseContract(t *testing.T) {
+	zone := inZone(t)
+	repository := &PostgresHotelRepository{}
+	stored := time.Date(2026, 1, 2, 12, 0, 0, 0, zone)
+	model := &entities.HotelData{
+		HotelID:      1,
+		Name:         "Harbour Hotel",
+		Checkin:      datatypes.JSON(`{"checkin_start":"15:00","checkin_end":"23:00","checkout":"11:00"}`),
+		CreatedAt:    stored,
+		UpdatedAt:    stored.Add(time.Hour),
+		NextUpdateAt: stored.Add(24 * time.Hour),
+		ReviewsData: []entities.ReviewData{
+			{ID: "review", HotelID: 1, ReviewID: 7, Date: stored.Add(-24 * time.Hour)},
+		},
+		TranslationsData: []entities.HotelTranslation{
+			{ID: "translation", HotelID: 1, Lang: "es", Name: "Hotel del Puerto", Checkin: datatypes.JSON(`{"checkin_start":"15:00"}`),
+				CreatedAt: stored, UpdatedAt: stored, NextUpdateAt: stored},
+		},
+	}
+
+	require.NoError(t, model.SetSources(map[string]entities.FieldSource{
+		entities.FieldGroupCore: {Source: entities.DataSourceCupidFetcher, UpdatedAt: stored},
+	}))
+
+	h, err := repository.convertModelToDomain(model)
+	require.NoError(t, err)
+	contract := checkResponseContract(t, h)
+
+	assert.Equal(t, timeFieldKeys(reflect.TypeFor[hotel.Hotel]()), contract.timestamps,
+		"every timestamp of a populated hotel is in the response")
+	assert.Equal(t, "2026-01-02T10:00:00Z", h.CreatedAt.Format(time.RFC3339))
+}
+
+func TestLoadStatusResponseContract(t *testing.T) {
+	inZone(t)
+	shedder := newTestLoadShedder()
+	recordCalls(shedder, 4, 250*time.Millisecond, nil)
+	shed, _ := shedder.ShouldShed()
+	require.True(t, shed)
+
+	contract := checkResponseContract(t, shedder.LoadStatus())
+
+	assert.Equal(t, map[string]bool{"shedding_since": true}, contract.timestamps)
+	assert.Equal(t, map[string]bool{"p95_latency": true}, contract.durations)
+}
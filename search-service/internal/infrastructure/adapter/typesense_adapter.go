@@ -1,27 +1,85 @@
 package adapter
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
 	"log/slog"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/typesense/typesense-go/typesense"
 	"github.com/typesense/typesense-go/typesense/api"
 	"github.com/typesense/typesense-go/typesense/api/pointer"
+	"github.com/victoragudo/hotel-management-system/pkg/phone"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/cdn"
 )
 
+const (
+	EngineTypesense = "typesense"
+
+	// typesenseMaxPerPage is the maximum per_page Typesense accepts.
+	typesenseMaxPerPage = 250
+
+	// typesenseMaxResultWindow caps how deep searches can page. Typesense ranks page*per_page
+	// hits to serve a page, so deep pages get slow long before they are useful.
+	typesenseMaxResultWindow = 10000
+)
+
+const exportIDsPageSize = typesenseMaxPerPage
+
+// typesensePagingErrors maps fragments of the messages of Typesense paging errors, such as
+// "Only upto 250 hits can be fetched per page.", to the domain error.
+var typesensePagingErrors = map[string]error{
+	"hits can be fetched per page":     search.ErrPerPageTooLarge,
+	"Page must be an integer":          search.ErrPageOutOfRange,
+	"hits can be fetched. Ensure that": search.ErrPageOutOfRange,
+}
+
+// searchFieldWeights rank matches in the hotel name above the description, and both above
+// policy text in important_info, which is searched so hotels can be found by rules such as
+// "adults only" without those mentions outranking a matching name.
+var searchFieldWeights = map[string]int{
+	"name":           5,
+	"description":    3,
+	"important_info": 1,
+}
+
+const nodeMetricsTimeout = 5 * time.Second
+
 type TypesenseAdapter struct {
 	client         *typesense.Client
 	collectionName string
-	logger         *slog.Logger
+	apiKey         string
+	metricsNodes   []string
+	httpClient     *http.Client
+	languages      []search.Language
+	importConfig   ImportConfig
+	// importer sends one import request. Nil sends it to the collection.
+	importer    func(documents []interface{}, params *api.ImportDocumentsParams) ([]*api.ImportDocumentResponse, error)
+	photoURLs   cdn.PhotoURLRewriter
+	loadShedder *LoadShedder
+	logger      *slog.Logger
+
+	// nameInfix is set when the collection indexes infixes of name. Collections created before
+	// the field was enabled must be recreated before infix matching is requested.
+	nameInfix atomic.Bool
+
+	tuning atomic.Pointer[search.Tuning]
 }
 
-func NewTypesenseAdapter(hostURL, apiKey, collectionName string, logger *slog.Logger) (*TypesenseAdapter, error) {
+func NewTypesenseAdapter(hostURL string, clusterNodes []string, apiKey, collectionName string, languages []search.Language, importConfig ImportConfig, photoURLs cdn.PhotoURLRewriter, loadShedder *LoadShedder, logger *slog.Logger) (*TypesenseAdapter, error) {
 	client := typesense.NewClient(
 		typesense.WithServer(hostURL),
 		typesense.WithAPIKey(apiKey),
@@ -30,8 +88,16 @@ func NewTypesenseAdapter(hostURL, apiKey, collectionName string, logger *slog.Lo
 	adapter := &TypesenseAdapter{
 		client:         client,
 		collectionName: collectionName,
+		apiKey:         apiKey,
+		metricsNodes:   metricsNodes(hostURL, clusterNodes),
+		httpClient:     &http.Client{Timeout: nodeMetricsTimeout},
+		languages:      languages,
+		importConfig:   importConfig,
+		photoURLs:      photoURLs,
+		loadShedder:    loadShedder,
 		logger:         logger,
 	}
+	adapter.ApplyTuning(search.DefaultTuning())
 
 	if err := adapter.initializeCollection(); err != nil {
 		return nil, fmt.Errorf("failed to initialize collection: %w", err)
@@ -41,24 +107,99 @@ func NewTypesenseAdapter(hostURL, apiKey, collectionName string, logger *slog.Lo
 }
 
 type TypesenseDocument struct {
-	HotelID      int64   `json:"hotel_id"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Phone        string  `json:"phone"`
-	Chain        string  `json:"chain"`
-	Rating       float64 `json:"rating"`
-	StarRating   int32   `json:"star_rating"`
-	Latitude     float64 `json:"latitude"`
-	Longitude    float64 `json:"longitude"`
-	Fax          string  `json:"fax"`
-	Email        string  `json:"email"`
-	AirportCode  string  `json:"airport_code"`
-	ReviewCount  int32   `json:"review_count"`
-	ChildAllowed bool    `json:"child_allowed"`
-	PetsAllowed  bool    `json:"pets_allowed"`
-	CreatedAt    int64   `json:"created_at"`
-	Parking      string  `json:"parking"`
-	UpdatedAt    int64   `json:"updated_at"`
+	ID           string    `json:"id"`
+	HotelID      int64     `json:"hotel_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Phone        string    `json:"phone"`
+	Chain        string    `json:"chain"`
+	Rating       float64   `json:"rating"`
+	StarRating   int32     `json:"star_rating"`
+	Latitude     float64   `json:"latitude"`
+	Longitude    float64   `json:"longitude"`
+	Fax          string    `json:"fax"`
+	Email        string    `json:"email"`
+	AirportCode  string    `json:"airport_code"`
+	ReviewCount  int32     `json:"review_count"`
+	ChildAllowed bool      `json:"child_allowed"`
+	PetsAllowed  bool      `json:"pets_allowed"`
+	CreatedAt    int64     `json:"created_at"`
+	Parking      string    `json:"parking"`
+	Amenities    []string  `json:"amenities"`
+	Location     []float64 `json:"location,omitempty"`
+	UpdatedAt    int64     `json:"updated_at"`
+
+	// CoordinatesValid is false when the hotel's coordinates are implausible, such as 0,0, and
+	// the document was indexed without a location.
+	CoordinatesValid bool `json:"coordinates_valid"`
+
+	ImportantInfo string `json:"important_info"`
+
+	// PhoneE164 and FaxE164 are the numbers in E.164 form, matched by the phone and fax filters.
+	PhoneE164 string `json:"phone_e164,omitempty"`
+	FaxE164   string `json:"fax_e164,omitempty"`
+
+	CheckinStartMinutes *int `json:"checkin_start_minutes,omitempty"`
+	CheckinEndMinutes   *int `json:"checkin_end_minutes,omitempty"`
+	Checkin24h          bool `json:"checkin_24h"`
+	CheckinUnknown      bool `json:"checkin_unknown"`
+
+	AirportDistanceKm *float32 `json:"airport_distance_km,omitempty"`
+
+	RoomTypesCount int32 `json:"room_types_count"`
+	TotalCapacity  int32 `json:"total_capacity"`
+
+	LanguagesAvailable []string `json:"languages_available"`
+
+	// NearbyAttractions and NearbyAttractionCategories are the names and categories of the
+	// attractions around the hotel, matched by the near_attraction filter.
+	NearbyAttractions          []string `json:"nearby_attractions,omitempty"`
+	NearbyAttractionCategories []string `json:"nearby_attraction_categories,omitempty"`
+
+	// PolicySummary is the one-line smoking, pet and parking summary, matched by the
+	// policy_keyword filter.
+	PolicySummary string `json:"policy_summary,omitempty"`
+
+	// MinNights is the minimum stay the hotel requires, 0 when it has none. It is always
+	// written, as the max_min_nights filter would otherwise skip unrestricted hotels.
+	MinNights int32 `json:"min_nights"`
+
+	// Timezone is the hotel's IANA timezone, in which its check-in times are given.
+	Timezone string `json:"timezone,omitempty"`
+
+	// MainImageTh is the original URL of the hotel's thumbnail. It is stored but not indexed.
+	MainImageTh string `json:"main_image_th,omitempty"`
+
+	AvgScoreLocation   *float32 `json:"avg_score_location,omitempty"`
+	AvgScoreService    *float32 `json:"avg_score_service,omitempty"`
+	AvgScoreValue      *float32 `json:"avg_score_value,omitempty"`
+	AvgScoreFacilities *float32 `json:"avg_score_facilities,omitempty"`
+
+	// TranslatedNames holds the hotel name per configured language, indexed as name_<lang>.
+	TranslatedNames map[string]string `json:"-"`
+}
+
+// MarshalJSON adds the translated names as name_<lang> fields of the document.
+func (d TypesenseDocument) MarshalJSON() ([]byte, error) {
+	type document TypesenseDocument
+	data, err := json.Marshal(document(d))
+	if err != nil || len(d.TranslatedNames) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for lang, name := range d.TranslatedNames {
+		encoded, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		fields[search.Language{Code: lang}.NameField()] = encoded
+	}
+
+	return json.Marshal(fields)
 }
 
 func (t *TypesenseAdapter) initializeCollection() error {
@@ -70,8 +211,9 @@ func (t *TypesenseAdapter) initializeCollection() error {
 				Type: "int64",
 			},
 			{
-				Name: "name",
-				Type: "string",
+				Name:  "name",
+				Type:  "string",
+				Infix: pointer.True(),
 			},
 			{
 				Name: "description",
@@ -106,6 +248,11 @@ func (t *TypesenseAdapter) initializeCollection() error {
 				Name: "longitude",
 				Type: "float",
 			},
+			{
+				Name:     "location",
+				Type:     "geopoint",
+				Optional: pointer.True(),
+			},
 			{
 				Name:     "fax",
 				Type:     "string",
@@ -122,11 +269,36 @@ func (t *TypesenseAdapter) initializeCollection() error {
 				Facet:    pointer.True(),
 				Optional: pointer.True(),
 			},
+			{
+				Name:     "airport_distance_km",
+				Type:     "float",
+				Optional: pointer.True(),
+			},
 			{
 				Name:  "review_count",
 				Type:  "int32",
 				Facet: pointer.True(),
 			},
+			{
+				Name:     "avg_score_location",
+				Type:     "float",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "avg_score_service",
+				Type:     "float",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "avg_score_value",
+				Type:     "float",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "avg_score_facilities",
+				Type:     "float",
+				Optional: pointer.True(),
+			},
 			{
 				Name:  "child_allowed",
 				Type:  "bool",
@@ -148,49 +320,297 @@ func (t *TypesenseAdapter) initializeCollection() error {
 				Facet:    pointer.True(),
 				Optional: pointer.True(),
 			},
+			{
+				Name:     "amenities",
+				Type:     "string[]",
+				Facet:    pointer.True(),
+				Optional: pointer.True(),
+			},
 			{
 				Name:  "updated_at",
 				Type:  "int64",
 				Facet: pointer.True(),
 			},
+			{
+				Name:     "checkin_start_minutes",
+				Type:     "int32",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "checkin_end_minutes",
+				Type:     "int32",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "checkin_24h",
+				Type:     "bool",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "checkin_unknown",
+				Type:     "bool",
+				Optional: pointer.True(),
+			},
 		},
 		DefaultSortingField: pointer.String("rating"),
 	}
+	collectionSchema.Fields = append(collectionSchema.Fields, t.addedFields()...)
 
 	_, err := t.client.Collections().Create(collectionSchema)
 	if err != nil {
 		t.logger.Warn("Collection creation result", "error", err)
 	}
 
+	t.detectNameInfix()
+	t.migrateAddedFields()
+	t.migrateDocumentIDs()
+
 	t.logger.Info("Typesense collection initialized", "collection_name", t.collectionName)
 	return nil
 }
 
+func (t *TypesenseAdapter) detectNameInfix() {
+	collection, err := t.client.Collection(t.collectionName).Retrieve()
+	if err != nil {
+		t.logger.Warn("Failed to retrieve collection schema", "error", err)
+		return
+	}
+
+	for _, field := range collection.Fields {
+		if field.Name == "name" {
+			t.nameInfix.Store(field.Infix != nil && *field.Infix)
+		}
+	}
+	if !t.nameInfix.Load() {
+		t.logger.Warn("Collection does not index name infixes, clear and re-sync the index to enable infix matching")
+	}
+}
+
+// addedFields are the fields added to the schema after collections were first created, which
+// migrateAddedFields adds to existing collections.
+func (t *TypesenseAdapter) addedFields() []api.Field {
+	fields := []api.Field{
+		{
+			Name:     "important_info",
+			Type:     "string",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "room_types_count",
+			Type:     "int32",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "total_capacity",
+			Type:     "int32",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "languages_available",
+			Type:     "string[]",
+			Facet:    pointer.True(),
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "nearby_attractions",
+			Type:     "string[]",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "nearby_attraction_categories",
+			Type:     "string[]",
+			Facet:    pointer.True(),
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "coordinates_valid",
+			Type:     "bool",
+			Facet:    pointer.True(),
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "policy_summary",
+			Type:     "string",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "min_nights",
+			Type:     "int32",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "timezone",
+			Type:     "string",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "phone_e164",
+			Type:     "string",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "fax_e164",
+			Type:     "string",
+			Optional: pointer.True(),
+		},
+		{
+			Name:     "main_image_th",
+			Type:     "string",
+			Index:    pointer.False(),
+			Optional: pointer.True(),
+		},
+	}
+	return append(fields, t.languageFields()...)
+}
+
+// languageFields are the translated name fields, tokenized with the locale of their language.
+func (t *TypesenseAdapter) languageFields() []api.Field {
+	fields := make([]api.Field, 0, len(t.languages))
+	for _, language := range t.languages {
+		field := api.Field{
+			Name:     language.NameField(),
+			Type:     "string",
+			Optional: pointer.True(),
+		}
+		if language.Locale != "" {
+			field.Locale = pointer.String(language.Locale)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// migrateAddedFields adds the added fields missing from an existing collection, such as the name
+// fields of newly configured languages. A field whose locale changed cannot be altered in place
+// and is only reported.
+func (t *TypesenseAdapter) migrateAddedFields() {
+	collection, err := t.client.Collection(t.collectionName).Retrieve()
+	if err != nil {
+		t.logger.Warn("Failed to retrieve collection schema", "error", err)
+		return
+	}
+
+	existing := make(map[string]api.Field, len(collection.Fields))
+	for _, field := range collection.Fields {
+		existing[field.Name] = field
+	}
+
+	missing := make([]api.Field, 0)
+	for _, field := range t.addedFields() {
+		current, ok := existing[field.Name]
+		if !ok {
+			missing = append(missing, field)
+			continue
+		}
+		if stringValue(current.Locale) != stringValue(field.Locale) {
+			t.logger.Warn("Language field locale differs from config, clear and re-sync the index to apply it",
+				"field", field.Name,
+				"indexed_locale", stringValue(current.Locale),
+				"configured_locale", stringValue(field.Locale))
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	if _, err := t.client.Collection(t.collectionName).Update(&api.CollectionUpdateSchema{Fields: missing}); err != nil {
+		t.logger.Warn("Failed to add fields to collection", "error", err)
+		return
+	}
+	t.logger.Info("Added fields to collection, re-sync to index them for existing hotels", "fields", len(missing))
+}
+
 func (t *TypesenseAdapter) convertHotelToDocument(h *hotel.Hotel) *TypesenseDocument {
 	document := &TypesenseDocument{
-		HotelID:      h.HotelID,
-		Name:         h.Name,
-		Description:  h.Description,
-		Phone:        h.Phone,
-		Chain:        h.Chain,
-		Rating:       h.Rating,
-		StarRating:   h.StarRating,
-		Latitude:     h.Latitude,
-		Longitude:    h.Longitude,
-		Fax:          h.Fax,
-		Email:        h.Email,
-		AirportCode:  h.AirportCode,
-		ReviewCount:  h.ReviewCount,
-		ChildAllowed: h.ChildAllowed,
-		PetsAllowed:  h.PetsAllowed,
-		UpdatedAt:    h.UpdatedAt.UTC().Unix(),
-		Parking:      h.Parking,
-		CreatedAt:    h.CreatedAt.UTC().Unix(),
-	}
+		ID:            strconv.FormatInt(h.HotelID, 10),
+		HotelID:       h.HotelID,
+		Name:          h.Name,
+		Description:   h.Description,
+		Phone:         h.ContactInfo.Phone,
+		Chain:         h.Chain,
+		Rating:        h.Rating,
+		StarRating:    h.StarRating,
+		Latitude:      h.Latitude,
+		Longitude:     h.Longitude,
+		Fax:           h.ContactInfo.Fax,
+		Email:         h.ContactInfo.Email,
+		AirportCode:   h.AirportCode,
+		ReviewCount:   h.ReviewCount,
+		ChildAllowed:  h.ChildAllowed,
+		PetsAllowed:   h.PetsAllowed,
+		UpdatedAt:     h.UpdatedAt.UTC().Unix(),
+		Parking:       h.Parking,
+		ImportantInfo: h.ImportantInfo,
+		Amenities:     h.Amenities,
+		CreatedAt:     h.CreatedAt.UTC().Unix(),
+		PhoneE164:     h.ContactInfo.PhoneE164,
+		FaxE164:       h.ContactInfo.FaxE164,
+	}
+
+	document.RoomTypesCount = int32(h.RoomTypesCount())
+	document.TotalCapacity = h.TotalCapacity()
+	document.LanguagesAvailable = h.LanguagesAvailable()
+	document.NearbyAttractions = h.AttractionNames()
+	document.NearbyAttractionCategories = h.AttractionCategories()
+	document.PolicySummary = h.PolicySummary()
+	document.MinNights = h.MinNights()
+	document.Timezone = h.TimezoneName()
+	document.MainImageTh = h.MainImageTh
+
+	window := h.CheckinInfo.Window()
+	document.CheckinStartMinutes = window.StartMinutes
+	document.CheckinEndMinutes = window.EndMinutes
+	document.Checkin24h = window.Is24h
+	document.CheckinUnknown = window.Unknown
+
+	document.CoordinatesValid = h.HasCoordinates()
+	if document.CoordinatesValid {
+		document.Location = []float64{h.Latitude, h.Longitude}
+	}
+
+	if distance, ok := h.AirportDistanceKm(); ok {
+		airportDistance := float32(distance)
+		document.AirportDistanceKm = &airportDistance
+	}
+
+	breakdown := h.ReviewScoreBreakdown()
+	document.AvgScoreLocation = float32Pointer(breakdown.Location)
+	document.AvgScoreService = float32Pointer(breakdown.Service)
+	document.AvgScoreValue = float32Pointer(breakdown.Value)
+	document.AvgScoreFacilities = float32Pointer(breakdown.Facilities)
+
+	document.TranslatedNames = t.translatedNames(h)
 
 	return document
 }
 
+func (t *TypesenseAdapter) translatedNames(h *hotel.Hotel) map[string]string {
+	if len(t.languages) == 0 {
+		return nil
+	}
+
+	names := make(map[string]string)
+	for _, translation := range h.Translations {
+		if translation.Name == "" {
+			continue
+		}
+		for _, language := range t.languages {
+			if language.Code == translation.Lang {
+				names[language.Code] = translation.Name
+			}
+		}
+	}
+	return names
+}
+
+func float32Pointer(value *float64) *float32 {
+	if value == nil {
+		return nil
+	}
+	converted := float32(*value)
+	return &converted
+}
+
 func (t *TypesenseAdapter) Index(_ context.Context, hotels []*hotel.Hotel) error {
 	if len(hotels) == 0 {
 		return nil
@@ -203,28 +623,110 @@ func (t *TypesenseAdapter) Index(_ context.Context, hotels []*hotel.Hotel) error
 		documents[i] = *t.convertHotelToDocument(h)
 	}
 
-	documentsInterface := make([]interface{}, len(documents))
-	for i, doc := range documents {
-		documentsInterface[i] = doc
+	result, err := t.importDocuments(documents)
+	if err != nil {
+		t.logger.Error("Failed to import documents", "indexed", result.indexed, "error", err)
+		return fmt.Errorf("failed to index hotels: %w", err)
+	}
+	if result.failed > 0 {
+		t.logger.Warn("Typesense rejected some hotel documents", "failed", result.failed, "error", result.firstError)
 	}
 
-	params := &api.ImportDocumentsParams{
-		Action:    pointer.String("upsert"),
-		BatchSize: pointer.Int(100),
+	t.logger.Info("Hotels indexed successfully", "count", result.indexed)
+	return nil
+}
+
+func (t *TypesenseAdapter) Search(_ context.Context, params search.Params) (*search.Result, error) {
+	searchParams := t.buildSearchParams(params)
+
+	t.logger.Debug("Executing Typesense search",
+		"query", searchParams.Q,
+		"filters", stringValue(searchParams.FilterBy),
+		"sort", stringValue(searchParams.SortBy))
+
+	startTime := time.Now()
+	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+	t.loadShedder.Record(time.Since(startTime), err)
+	if err != nil {
+		if pagingErr := t.pagingError(err, params); pagingErr != nil {
+			return nil, pagingErr
+		}
+		t.logger.Error("Typesense search failed", "error", err)
+		return nil, fmt.Errorf("typesense search error: %w", err)
 	}
 
-	_, err := t.client.Collection(t.collectionName).Documents().Import(documentsInterface, params)
+	return t.convertSearchResult(searchResponse, params, *searchParams.Page, *searchParams.PerPage), nil
+}
+
+func (t *TypesenseAdapter) MultiSearch(_ context.Context, params search.Params, suggestionQuery string, suggestionLimit int) (*search.Result, []*search.Suggestion, error) {
+	searchParams := t.buildSearchParams(params)
+	suggestionParams := t.buildSuggestionParams(suggestionQuery, suggestionLimit)
+
+	searches := api.MultiSearchSearchesParameter{
+		Searches: []api.MultiSearchCollectionParameters{
+			t.toMultiSearchParameters(searchParams),
+			t.toMultiSearchParameters(suggestionParams),
+		},
+	}
+
+	t.logger.Debug("Executing Typesense multi search",
+		"query", searchParams.Q,
+		"suggestion_query", suggestionQuery,
+		"suggestion_limit", suggestionLimit)
+
+	startTime := time.Now()
+	multiSearchResponse, err := t.client.MultiSearch.Perform(&api.MultiSearchParams{}, searches)
+	t.loadShedder.Record(time.Since(startTime), err)
 	if err != nil {
-		t.logger.Error("Failed to import documents", "error", err)
-		return fmt.Errorf("failed to index hotels: %w", err)
+		if pagingErr := t.pagingError(err, params); pagingErr != nil {
+			return nil, nil, pagingErr
+		}
+		t.logger.Error("Typesense multi search failed", "error", err)
+		return nil, nil, fmt.Errorf("typesense multi search error: %w", err)
+	}
+
+	if len(multiSearchResponse.Results) != len(searches.Searches) {
+		return nil, nil, fmt.Errorf("typesense multi search returned %d results, expected %d",
+			len(multiSearchResponse.Results), len(searches.Searches))
 	}
 
-	t.logger.Info("Hotels indexed successfully", "count", len(hotels))
+	result := t.convertSearchResult(&multiSearchResponse.Results[0], params, *searchParams.Page, *searchParams.PerPage)
+	suggestions := t.convertSuggestionResult(&multiSearchResponse.Results[1], suggestionQuery)
+
+	return result, suggestions, nil
+}
+
+func (t *TypesenseAdapter) Capabilities() search.Capabilities {
+	return search.Capabilities{
+		MaxPerPage:      typesenseMaxPerPage,
+		MaxResultWindow: typesenseMaxResultWindow,
+	}
+}
+
+func (t *TypesenseAdapter) Info() search.EngineInfo {
+	return search.EngineInfo{Name: EngineTypesense, Collection: t.collectionName}
+}
+
+// pagingError turns the Typesense errors for pages it does not serve into a PageLimitError, or
+// returns nil for other errors.
+func (t *TypesenseAdapter) pagingError(err error, params search.Params) error {
+	message := err.Error()
+	for fragment, pagingErr := range typesensePagingErrors {
+		if strings.Contains(message, fragment) {
+			capabilities := t.Capabilities()
+			t.logger.Warn("Typesense rejected the requested page", "page", params.Page, "limit", params.Limit, "error", err)
+			return &search.PageLimitError{
+				Err:        pagingErr,
+				Page:       params.Page,
+				MaxPage:    capabilities.MaxPage(min(params.Limit, capabilities.MaxPerPage)),
+				MaxPerPage: capabilities.MaxPerPage,
+			}
+		}
+	}
 	return nil
 }
 
-func (t *TypesenseAdapter) Search(_ context.Context, params search.Params) (*search.Result, error) {
-	queryBy := "name,description"
+func (t *TypesenseAdapter) buildSearchParams(params search.Params) *api.SearchCollectionParams {
 	query := "*"
 	if params.Query != "" {
 		query = params.Query
@@ -240,14 +742,35 @@ func (t *TypesenseAdapter) Search(_ context.Context, params search.Params) (*sea
 		limit = 20
 	}
 
+	// QueryBy and its weights are set by the ranking profile.
 	searchParams := &api.SearchCollectionParams{
 		Q:       query,
-		QueryBy: queryBy,
 		Page:    &page,
 		PerPage: &limit,
+		// Only fall back to typo matches when exact matches find nothing, and never correct
+		// short tokens such as "NY".
+		TypoTokensThreshold: pointer.Int(1),
+		MinLen1typo:         pointer.Int(4),
+		MinLen2typo:         pointer.Int(8),
+	}
+	if params.NumTypos != nil {
+		searchParams.NumTypos = pointer.String(strconv.Itoa(*params.NumTypos))
+	}
+	if params.RankingProfile != search.RankingProfileClassic {
+		t.applyRelevanceProfile(searchParams)
+	} else {
+		t.applyProfileSettings(searchParams, search.RankingProfileClassic)
 	}
 
 	filters := t.buildFilters(params)
+	if params.HasLocationFilter() {
+		geoFilter := fmt.Sprintf("location:(%f, %f, %f km)", params.Latitude, params.Longitude, params.Radius)
+		if filters != "" {
+			filters = filters + " && " + geoFilter
+		} else {
+			filters = geoFilter
+		}
+	}
 	if filters != "" {
 		searchParams.FilterBy = &filters
 	}
@@ -257,30 +780,149 @@ func (t *TypesenseAdapter) Search(_ context.Context, params search.Params) (*sea
 		searchParams.SortBy = &sortBy
 	}
 
-	if params.HasLocationFilter() {
-		geoFilter := fmt.Sprintf("location:(%f, %f, %f km)", params.Latitude, params.Longitude, params.Radius)
-		if filters != "" {
-			combinedFilter := filters + " && " + geoFilter
-			searchParams.FilterBy = &combinedFilter
-		} else {
-			searchParams.FilterBy = &geoFilter
+	return searchParams
+}
+
+// applyRelevanceProfile normalizes quoted phrases into Typesense phrase queries and favors exact
+// and prefix matches on the hotel name over matches in the description.
+func (t *TypesenseAdapter) applyRelevanceProfile(searchParams *api.SearchCollectionParams) {
+	if searchParams.Q != "*" {
+		if parsed := search.ParseQuery(searchParams.Q); parsed.String() != "" {
+			searchParams.Q = parsed.String()
 		}
 	}
 
-	t.logger.Debug("Executing Typesense search", "query", query, "filters", filters, "sort", sortBy)
+	t.applyProfileSettings(searchParams, search.RankingProfileRelevance)
 
-	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
-	if err != nil {
-		t.logger.Error("Typesense search failed", "error", err)
-		return nil, fmt.Errorf("typesense search error: %w", err)
+	// Typesense requires one prefix and infix value per query_by field. Names, translated
+	// ones included, match by prefix; only name indexes infixes.
+	fields := strings.Split(searchParams.QueryBy, ",")
+	searchParams.Prefix = pointer.String(perField(fields, func(field string) string {
+		return strconv.FormatBool(field == "name" || strings.HasPrefix(field, "name_"))
+	}))
+	if t.nameInfix.Load() {
+		searchParams.Infix = pointer.String(perField(fields, func(field string) string {
+			if field == "name" {
+				return "fallback"
+			}
+			return "off"
+		}))
 	}
+}
 
-	hotels := make([]*hotel.Hotel, 0, len(*searchResponse.Hits))
-	for _, hit := range *searchResponse.Hits {
-		if h, err := t.convertDocumentToHotel(hit.Document); err == nil {
+// perField returns the comma separated value of option for each field.
+func perField(fields []string, option func(field string) string) string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = option(field)
+	}
+	return strings.Join(values, ",")
+}
+
+// applyProfileSettings applies the tunable settings of a ranking profile.
+func (t *TypesenseAdapter) applyProfileSettings(searchParams *api.SearchCollectionParams, profile string) {
+	settings := t.tuning.Load().Profile(profile)
+	queryBy, queryByWeights := t.searchQueryBy(settings.QueryByWeights)
+	searchParams.QueryBy = queryBy
+	searchParams.QueryByWeights = pointer.String(queryByWeights)
+	if settings.PrioritizeExactMatch {
+		searchParams.PrioritizeExactMatch = pointer.True()
+	}
+}
+
+// ApplyTuning replaces the runtime tuning used by subsequent queries.
+func (t *TypesenseAdapter) ApplyTuning(tuning search.Tuning) {
+	t.tuning.Store(&tuning)
+}
+
+// queryBy appends the translated name fields to fields, so queries in any configured language
+// match hotel names.
+func (t *TypesenseAdapter) queryBy(fields ...string) string {
+	for _, language := range t.languages {
+		fields = append(fields, language.NameField())
+	}
+	return strings.Join(fields, ",")
+}
+
+// searchQueryBy returns the fields and weights of a hotel search. profileWeights, the "name,
+// description" weights of a ranking profile, replace the defaults when set, and important_info
+// then weighs less than either of them. Translated names weigh as much as the name.
+func (t *TypesenseAdapter) searchQueryBy(profileWeights string) (string, string) {
+	weights := maps.Clone(searchFieldWeights)
+	if nameWeight, descriptionWeight, ok := strings.Cut(profileWeights, ","); ok {
+		weights["name"], _ = strconv.Atoi(strings.TrimSpace(nameWeight))
+		weights["description"], _ = strconv.Atoi(strings.TrimSpace(descriptionWeight))
+		weights["important_info"] = max(min(weights["name"], weights["description"])-1, 0)
+	}
+
+	fields := []string{"name", "description", "important_info"}
+	for _, language := range t.languages {
+		fields = append(fields, language.NameField())
+		weights[language.NameField()] = weights["name"]
+	}
+
+	return buildQueryBy(fields, weights)
+}
+
+// buildQueryBy returns the query_by and query_by_weights parameters for fields. Weights are
+// omitted when none are given; otherwise fields without a weight get 0, the lowest.
+func buildQueryBy(fields []string, weights map[string]int) (string, string) {
+	queryBy := strings.Join(fields, ",")
+	if len(weights) == 0 {
+		return queryBy, ""
+	}
+
+	fieldWeights := make([]string, 0, len(fields))
+	for _, field := range fields {
+		fieldWeights = append(fieldWeights, strconv.Itoa(weights[field]))
+	}
+
+	return queryBy, strings.Join(fieldWeights, ",")
+}
+
+func (t *TypesenseAdapter) buildSuggestionParams(query string, limit int) *api.SearchCollectionParams {
+	return &api.SearchCollectionParams{
+		Q:       query,
+		QueryBy: t.queryBy("name", "city", "country"),
+		PerPage: pointer.Int(limit),
+		Page:    pointer.Int(1),
+	}
+}
+
+func (t *TypesenseAdapter) toMultiSearchParameters(params *api.SearchCollectionParams) api.MultiSearchCollectionParameters {
+	return api.MultiSearchCollectionParameters{
+		Collection:           t.collectionName,
+		Q:                    pointer.String(params.Q),
+		QueryBy:              pointer.String(params.QueryBy),
+		QueryByWeights:       params.QueryByWeights,
+		Prefix:               params.Prefix,
+		Infix:                params.Infix,
+		NumTypos:             params.NumTypos,
+		TypoTokensThreshold:  params.TypoTokensThreshold,
+		MinLen1typo:          params.MinLen1typo,
+		MinLen2typo:          params.MinLen2typo,
+		PrioritizeExactMatch: params.PrioritizeExactMatch,
+		FilterBy:             params.FilterBy,
+		SortBy:               params.SortBy,
+		Page:                 params.Page,
+		PerPage:              params.PerPage,
+	}
+}
+
+func (t *TypesenseAdapter) convertSearchResult(searchResponse *api.SearchResult, params search.Params, page, limit int) *search.Result {
+	hotels := make([]*hotel.Hotel, 0)
+	if searchResponse.Hits != nil {
+		for _, hit := range *searchResponse.Hits {
+			h, err := t.convertDocumentToHotel(hit.Document)
+			if err != nil {
+				t.logger.Warn("Failed to convert document to hotel", "error", err)
+				continue
+			}
+			if params.HasReferencePoint() {
+				h.DistanceKm = hitDistanceKm(hit, h, params)
+			}
+			h.DescriptionSnippet = highlightSnippet(hit, "description")
 			hotels = append(hotels, h)
-		} else {
-			t.logger.Warn("Failed to convert document to hotel", "error", err)
 		}
 	}
 
@@ -295,8 +937,69 @@ func (t *TypesenseAdapter) Search(_ context.Context, params search.Params) (*sea
 		Page:      page,
 		Limit:     limit,
 	}
+	if searchResponse.SearchTimeMs != nil {
+		result.SearchEngineTime = time.Duration(*searchResponse.SearchTimeMs) * time.Millisecond
+	}
+
+	if params.HasReferencePoint() {
+		result.ReferencePoint = &search.GeoPoint{
+			Latitude:  params.Latitude,
+			Longitude: params.Longitude,
+		}
+	}
+
+	return result
+}
+
+// hitDistanceKm prefers the distance Typesense computed for the geo sort, so the reported
+// value matches the ordering, and falls back to haversine when it was not returned.
+func hitDistanceKm(hit api.SearchResultHit, h *hotel.Hotel, params search.Params) *float64 {
+	if !h.HasCoordinates() {
+		return nil
+	}
 
-	return result, nil
+	var distance float64
+	if hit.GeoDistanceMeters != nil {
+		if meters, ok := (*hit.GeoDistanceMeters)["location"]; ok {
+			distance = search.RoundDistanceKm(float64(meters) / 1000)
+			return &distance
+		}
+	}
+
+	distance = search.RoundDistanceKm(search.DistanceKm(params.Latitude, params.Longitude, h.Latitude, h.Longitude))
+	return &distance
+}
+
+// highlightSnippet returns the highlighted fragment Typesense produced for field, empty when
+// the field did not match the query.
+func highlightSnippet(hit api.SearchResultHit, field string) string {
+	if hit.Highlights == nil {
+		return ""
+	}
+	for _, highlight := range *hit.Highlights {
+		if stringValue(highlight.Field) == field {
+			return stringValue(highlight.Snippet)
+		}
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// phoneFilter matches number against the E.164 form of field, so any format of the same number
+// matches. A number that cannot be normalized falls back to an exact match of the raw field.
+func (t *TypesenseAdapter) phoneFilter(field, number, country string) string {
+	normalized, err := phone.Normalize(number, country)
+	if err != nil {
+		t.logger.Debug("Phone filter is not a valid phone number, matching it exactly", "field", field, "number", number)
+		return fmt.Sprintf("%s:=%s", field, number)
+	}
+	return fmt.Sprintf("%s_e164:=`%s`", field, normalized)
 }
 
 func (t *TypesenseAdapter) buildFilters(params search.Params) string {
@@ -311,7 +1014,7 @@ func (t *TypesenseAdapter) buildFilters(params search.Params) string {
 	}
 
 	if params.Phone != "" {
-		filters = append(filters, fmt.Sprintf("phone:=%s", params.Phone))
+		filters = append(filters, t.phoneFilter("phone", params.Phone, params.Country))
 	}
 
 	if params.Chain != "" {
@@ -323,13 +1026,34 @@ func (t *TypesenseAdapter) buildFilters(params search.Params) string {
 	}
 
 	if params.Fax != "" {
-		filters = append(filters, fmt.Sprintf("fax:=%s", params.Fax))
+		filters = append(filters, t.phoneFilter("fax", params.Fax, params.Country))
 	}
 
 	if params.AirportCode != "" {
 		filters = append(filters, fmt.Sprintf("airport_code:=%s", params.AirportCode))
 	}
 
+	if params.MaxAirportDistanceKm > 0 {
+		filters = append(filters, fmt.Sprintf("airport_distance_km:<=%f", params.MaxAirportDistanceKm))
+	}
+
+	if params.MinValueScore > 0 {
+		filters = append(filters, fmt.Sprintf("avg_score_value:>=%f", params.MinValueScore))
+	}
+
+	if params.CreatedAfter != nil {
+		filters = append(filters, fmt.Sprintf("created_at:>=%d", params.CreatedAfter.Unix()))
+	}
+	if params.CreatedBefore != nil {
+		filters = append(filters, fmt.Sprintf("created_at:<=%d", params.CreatedBefore.Unix()))
+	}
+	if params.UpdatedAfter != nil {
+		filters = append(filters, fmt.Sprintf("updated_at:>=%d", params.UpdatedAfter.Unix()))
+	}
+	if params.UpdatedBefore != nil {
+		filters = append(filters, fmt.Sprintf("updated_at:<=%d", params.UpdatedBefore.Unix()))
+	}
+
 	if params.Parking != "" {
 		filters = append(filters, fmt.Sprintf("parking:=%s", params.Parking))
 	}
@@ -357,6 +1081,33 @@ func (t *TypesenseAdapter) buildFilters(params search.Params) string {
 		filters = append(filters, fmt.Sprintf("review_count:>=%d", params.ReviewCount))
 	}
 
+	if params.MinRoomTypes != nil {
+		filters = append(filters, fmt.Sprintf("room_types_count:>=%d", *params.MinRoomTypes))
+	}
+	if params.MinTotalCapacity != nil {
+		filters = append(filters, fmt.Sprintf("total_capacity:>=%d", *params.MinTotalCapacity))
+	}
+	if params.MaxMinNights != nil {
+		filters = append(filters, fmt.Sprintf("min_nights:<=%d", *params.MaxMinNights))
+	}
+
+	for _, language := range params.RequiredLanguages {
+		filters = append(filters, fmt.Sprintf("languages_available:=[%s]", language))
+	}
+
+	if params.NearAttraction != "" {
+		// Matches a category such as beach, or the exact name of an attraction.
+		attraction := strings.ReplaceAll(params.NearAttraction, "`", "")
+		filters = append(filters, fmt.Sprintf("(nearby_attraction_categories:=[`%s`] || nearby_attractions:=[`%s`])",
+			strings.ToLower(attraction), attraction))
+	}
+
+	if params.PolicyKeyword != "" {
+		// A token match, so "parking" keeps every hotel whose summary mentions parking.
+		keyword := strings.ReplaceAll(params.PolicyKeyword, "`", "")
+		filters = append(filters, fmt.Sprintf("policy_summary:`%s`", keyword))
+	}
+
 	if params.ChildAllowed != nil {
 		filters = append(filters, fmt.Sprintf("child_allowed:=%t", *params.ChildAllowed))
 	}
@@ -368,38 +1119,52 @@ func (t *TypesenseAdapter) buildFilters(params search.Params) string {
 	if len(params.Amenities) > 0 {
 		amenityFilters := make([]string, len(params.Amenities))
 		for i, amenity := range params.Amenities {
-			amenityFilters[i] = fmt.Sprintf("amenities:=%s", amenity)
+			amenityFilters[i] = "amenities:=" + quoteFilterValue(amenity)
 		}
 		filters = append(filters, fmt.Sprintf("(%s)", strings.Join(amenityFilters, " || ")))
 	}
 
-	if len(params.Tags) > 0 {
-		tagFilters := make([]string, len(params.Tags))
-		for i, tag := range params.Tags {
-			tagFilters[i] = fmt.Sprintf("tags:=%s", tag)
+	if params.HasGeoPolygon() {
+		coordinates := make([]string, 0, 2*len(params.GeoPolygon))
+		for _, vertex := range params.GeoPolygon {
+			coordinates = append(coordinates, fmt.Sprintf("%f", vertex[0]), fmt.Sprintf("%f", vertex[1]))
 		}
-		filters = append(filters, fmt.Sprintf("(%s)", strings.Join(tagFilters, " || ")))
+		filters = append(filters, fmt.Sprintf("location:(%s)", strings.Join(coordinates, ", ")))
 	}
 
-	if params.PriceMin > 0 && params.PriceMax > 0 {
-		filters = append(filters,
-			fmt.Sprintf("price_max:>=%f", params.PriceMin),
-			fmt.Sprintf("price_min:<=%f", params.PriceMax),
-		)
-	} else if params.PriceMin > 0 {
-		filters = append(filters, fmt.Sprintf("price_max:>=%f", params.PriceMin))
-	} else if params.PriceMax > 0 {
-		filters = append(filters, fmt.Sprintf("price_min:<=%f", params.PriceMax))
-	}
-
-	if params.Currency != "" {
-		filters = append(filters, fmt.Sprintf("currency:=%s", params.Currency))
+	if params.HasArrivalFilter() {
+		checkinFilters := []string{
+			fmt.Sprintf("checkin_end_minutes:>=%d", params.ArrivalMinutes),
+			"checkin_24h:=true",
+		}
+		if !params.StrictCheckin {
+			checkinFilters = append(checkinFilters, "checkin_unknown:=true")
+		}
+		filters = append(filters, fmt.Sprintf("(%s)", strings.Join(checkinFilters, " || ")))
 	}
 
 	return strings.Join(filters, " && ")
 }
 
+// sortTiebreaker is appended to every sort so hotels with equal sort values keep the same order
+// across requests and pages neither repeat nor skip documents.
+const sortTiebreaker = "hotel_id:asc"
+
+const defaultSort = "rating:desc"
+
 func (t *TypesenseAdapter) buildSort(params search.Params) string {
+	sortBy := t.buildPrimarySort(params)
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	return sortBy + "," + sortTiebreaker
+}
+
+func (t *TypesenseAdapter) buildPrimarySort(params search.Params) string {
+	if len(params.AmenityWeights) > 0 && (params.SortBy == "" || params.SortBy == "relevance") {
+		return buildWeightedAmenityEvalSort(params.AmenityWeights)
+	}
+
 	if params.SortBy == "" {
 		return ""
 	}
@@ -410,10 +1175,13 @@ func (t *TypesenseAdapter) buildSort(params search.Params) string {
 	}
 
 	switch params.SortBy {
-	case "price":
-		return fmt.Sprintf("price_min:%s", sortOrder)
+	case "relevance":
+		if len(params.Amenities) > 0 {
+			return buildAmenityEvalSort(params.Amenities)
+		}
+		return fmt.Sprintf("_text_match:%s,%s", sortOrder, defaultSort)
 	case "distance":
-		if params.HasLocationFilter() {
+		if params.HasReferencePoint() {
 			return fmt.Sprintf("location(%f, %f):%s", params.Latitude, params.Longitude, sortOrder)
 		}
 		return ""
@@ -422,6 +1190,35 @@ func (t *TypesenseAdapter) buildSort(params search.Params) string {
 	}
 }
 
+// buildAmenityEvalSort scores each hotel by the number of requested amenities it has,
+// so hotels matching more of them rank first, with rating as the tiebreaker.
+func buildAmenityEvalSort(amenities []string) string {
+	conditions := make([]string, len(amenities))
+	for i, amenity := range amenities {
+		conditions[i] = fmt.Sprintf("amenities:=[%s]:1", quoteFilterValue(amenity))
+	}
+	return fmt.Sprintf("_eval([%s]):desc,rating:desc", strings.Join(conditions, ", "))
+}
+
+// quoteFilterValue backtick-quotes value for a filter or _eval expression, so commas, brackets
+// and colons in it are matched literally. Typesense cannot escape a backtick inside a quoted
+// value, so backticks are dropped.
+func quoteFilterValue(value string) string {
+	return "`" + strings.ReplaceAll(value, "`", "") + "`"
+}
+
+// buildWeightedAmenityEvalSort scores each hotel by the sum of the weights of the amenities it
+// has. Typesense only accepts integer scores, so weights are scaled to 0-100. Conditions are
+// listed by descending weight.
+func buildWeightedAmenityEvalSort(weights map[string]float64) string {
+	conditions := make([]string, 0, len(weights))
+	for _, amenity := range search.SortedAmenityWeights(weights) {
+		score := int(math.Round(weights[amenity] * 100))
+		conditions = append(conditions, fmt.Sprintf("amenities:=[%s]:%d", quoteFilterValue(amenity), score))
+	}
+	return fmt.Sprintf("_eval([%s]):desc,rating:desc", strings.Join(conditions, ", "))
+}
+
 func (t *TypesenseAdapter) convertDocumentToHotel(hit any) (*hotel.Hotel, error) {
 	data, err := json.Marshal(hit)
 	if err != nil {
@@ -434,33 +1231,90 @@ func (t *TypesenseAdapter) convertDocumentToHotel(hit any) (*hotel.Hotel, error)
 	}
 
 	h := &hotel.Hotel{
-		HotelID:      typesenseDocument.HotelID,
-		Name:         typesenseDocument.Name,
-		Description:  typesenseDocument.Description,
-		Phone:        typesenseDocument.Phone,
-		Chain:        typesenseDocument.Chain,
-		Rating:       typesenseDocument.Rating,
-		StarRating:   typesenseDocument.StarRating,
-		Latitude:     typesenseDocument.Latitude,
-		Longitude:    typesenseDocument.Longitude,
-		Fax:          typesenseDocument.Fax,
-		Email:        typesenseDocument.Email,
-		AirportCode:  typesenseDocument.AirportCode,
-		ReviewCount:  typesenseDocument.ReviewCount,
-		ChildAllowed: typesenseDocument.ChildAllowed,
-		PetsAllowed:  typesenseDocument.PetsAllowed,
-		CreatedAt:    time.Unix(typesenseDocument.CreatedAt, 0),
-		Parking:      typesenseDocument.Parking,
-		UpdatedAt:    time.Unix(typesenseDocument.UpdatedAt, 0),
+		HotelID:       typesenseDocument.HotelID,
+		Name:          typesenseDocument.Name,
+		Description:   typesenseDocument.Description,
+		Chain:         typesenseDocument.Chain,
+		Rating:        typesenseDocument.Rating,
+		StarRating:    typesenseDocument.StarRating,
+		Latitude:      typesenseDocument.Latitude,
+		Longitude:     typesenseDocument.Longitude,
+		Timezone:      typesenseDocument.Timezone,
+		MainImageTh:   typesenseDocument.MainImageTh,
+		AirportCode:   typesenseDocument.AirportCode,
+		ReviewCount:   typesenseDocument.ReviewCount,
+		ChildAllowed:  typesenseDocument.ChildAllowed,
+		PetsAllowed:   typesenseDocument.PetsAllowed,
+		CreatedAt:     time.Unix(typesenseDocument.CreatedAt, 0).UTC(),
+		Parking:       typesenseDocument.Parking,
+		ImportantInfo: typesenseDocument.ImportantInfo,
+		Amenities:     typesenseDocument.Amenities,
+		UpdatedAt:     time.Unix(typesenseDocument.UpdatedAt, 0).UTC(),
+		ContactInfo: hotel.ContactInfo{
+			Phone:     typesenseDocument.Phone,
+			Fax:       typesenseDocument.Fax,
+			Email:     typesenseDocument.Email,
+			PhoneE164: typesenseDocument.PhoneE164,
+			FaxE164:   typesenseDocument.FaxE164,
+		},
+		CheckinWindow: hotel.CheckinWindow{
+			Start:        formatWindowMinutes(typesenseDocument.CheckinStartMinutes),
+			End:          formatWindowMinutes(typesenseDocument.CheckinEndMinutes),
+			StartMinutes: typesenseDocument.CheckinStartMinutes,
+			EndMinutes:   typesenseDocument.CheckinEndMinutes,
+			Is24h:        typesenseDocument.Checkin24h,
+			Unknown:      typesenseDocument.CheckinUnknown,
+		},
+	}
+	if t.photoURLs != nil {
+		h.MainImageTh = t.photoURLs.Rewrite(h.MainImageTh)
 	}
 
 	return h, nil
 }
 
+func formatWindowMinutes(minutes *int) string {
+	if minutes == nil {
+		return ""
+	}
+	m := *minutes % hotel.MinutesPerDay
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
 func (t *TypesenseAdapter) UpdateHotel(ctx context.Context, h *hotel.Hotel) error {
 	return t.Index(ctx, []*hotel.Hotel{h})
 }
 
+// PartialUpdate sets only the given fields on an existing document, leaving the rest untouched.
+func (t *TypesenseAdapter) PartialUpdate(_ context.Context, hotelID int64, fields map[string]any) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	document := make(map[string]any, len(fields)+1)
+	for name, value := range fields {
+		document[name] = value
+	}
+	document["id"] = strconv.FormatInt(hotelID, 10)
+
+	params := &api.ImportDocumentsParams{
+		Action: pointer.String("update"),
+	}
+
+	responses, err := t.client.Collection(t.collectionName).Documents().Import([]interface{}{document}, params)
+	if err != nil {
+		return fmt.Errorf("failed to update hotel %d: %w", hotelID, err)
+	}
+
+	for _, response := range responses {
+		if response != nil && !response.Success {
+			return fmt.Errorf("failed to update hotel %d: %s", hotelID, response.Error)
+		}
+	}
+
+	return nil
+}
+
 func (t *TypesenseAdapter) DeleteHotel(ctx context.Context, hotelID string) error {
 	_, err := t.client.Collection(t.collectionName).Document(hotelID).Delete()
 	if err != nil {
@@ -471,30 +1325,91 @@ func (t *TypesenseAdapter) DeleteHotel(ctx context.Context, hotelID string) erro
 	return nil
 }
 
-func (t *TypesenseAdapter) GetSuggestions(ctx context.Context, query string, limit int) ([]*search.Suggestion, error) {
-	searchParams := &api.SearchCollectionParams{
-		Q:       query,
-		QueryBy: "name,city,country",
-		PerPage: pointer.Int(limit),
-		Page:    pointer.Int(1),
+// ExportIDs pages through the index with a hotel_id cursor. The export endpoint would be cheaper
+// but returns documents unordered, and callers merge this stream against the database.
+func (t *TypesenseAdapter) ExportIDs(_ context.Context) iter.Seq2[hotel.Version, error] {
+	return func(yield func(hotel.Version, error) bool) {
+		var lastHotelID int64
+		for {
+			searchParams := &api.SearchCollectionParams{
+				Q:             "*",
+				QueryBy:       "name",
+				FilterBy:      pointer.String(fmt.Sprintf("hotel_id:>%d", lastHotelID)),
+				SortBy:        pointer.String("hotel_id:asc"),
+				IncludeFields: pointer.String("hotel_id,updated_at"),
+				Page:          pointer.Int(1),
+				PerPage:       pointer.Int(exportIDsPageSize),
+			}
+
+			searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+			if err != nil {
+				yield(hotel.Version{}, fmt.Errorf("failed to export indexed hotels after %d: %w", lastHotelID, err))
+				return
+			}
+
+			if searchResponse.Hits == nil || len(*searchResponse.Hits) == 0 {
+				return
+			}
+
+			for _, hit := range *searchResponse.Hits {
+				if hit.Document == nil {
+					continue
+				}
+				document := *hit.Document
+				hotelID, ok := document["hotel_id"].(float64)
+				if !ok {
+					continue
+				}
+				updatedAt, _ := document["updated_at"].(float64)
+
+				lastHotelID = int64(hotelID)
+				if !yield(hotel.Version{HotelID: lastHotelID, UpdatedAt: time.Unix(int64(updatedAt), 0).UTC()}, nil) {
+					return
+				}
+			}
+
+			if len(*searchResponse.Hits) < exportIDsPageSize {
+				return
+			}
+		}
 	}
+}
+
+func (t *TypesenseAdapter) GetSuggestions(ctx context.Context, query string, limit int) ([]*search.Suggestion, error) {
+	searchParams := t.buildSuggestionParams(query, limit)
 
+	startTime := time.Now()
 	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+	t.loadShedder.Record(time.Since(startTime), err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get suggestions: %w", err)
 	}
 
-	suggestions := make([]*search.Suggestion, 0, len(*searchResponse.Hits))
+	return t.convertSuggestionResult(searchResponse, query), nil
+}
+
+func (t *TypesenseAdapter) convertSuggestionResult(searchResponse *api.SearchResult, query string) []*search.Suggestion {
+	suggestions := make([]*search.Suggestion, 0)
+	if searchResponse.Hits == nil {
+		return suggestions
+	}
+
 	for _, hit := range *searchResponse.Hits {
-		if suggestion := t.convertHitToSuggestion(hit.Document); suggestion != nil {
+		if suggestion := t.convertHitToSuggestion(hit.Document, query); suggestion != nil {
 			suggestions = append(suggestions, suggestion)
 		}
 	}
 
-	return suggestions, nil
+	slices.SortStableFunc(suggestions, func(a, b *search.Suggestion) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return suggestions
 }
 
-func (t *TypesenseAdapter) convertHitToSuggestion(hit any) *search.Suggestion {
+// convertHitToSuggestion suggests the hotel under the name that best matches the query, which
+// is a translated name when the query is written in one of the configured languages.
+func (t *TypesenseAdapter) convertHitToSuggestion(hit any, query string) *search.Suggestion {
 	data, err := json.Marshal(hit)
 	if err != nil {
 		return nil
@@ -512,7 +1427,17 @@ func (t *TypesenseAdapter) convertHitToSuggestion(hit any) *search.Suggestion {
 	suggestion := &search.Suggestion{
 		Text:  name,
 		Type:  "hotel",
-		Score: 1.0,
+		Score: search.SuggestionScore(name, query),
+	}
+
+	lang := ""
+	for _, language := range t.languages {
+		translated, _ := doc[language.NameField()].(string)
+		if score := search.SuggestionScore(translated, query); score > suggestion.Score {
+			suggestion.Text = translated
+			suggestion.Score = score
+			lang = language.Code
+		}
 	}
 
 	if hotelIDFloat, ok := doc["hotel_id"].(float64); ok {
@@ -527,15 +1452,26 @@ func (t *TypesenseAdapter) convertHitToSuggestion(hit any) *search.Suggestion {
 		}
 	}
 
+	if lang != "" {
+		if suggestion.Metadata == nil {
+			suggestion.Metadata = make(map[string]any)
+		}
+		suggestion.Metadata["lang"] = lang
+	}
+
 	return suggestion
 }
 
 func (t *TypesenseAdapter) GetFacets(ctx context.Context) (*search.Facets, error) {
+	facetSettings := t.tuning.Load().Facets
 	searchParams := &api.SearchCollectionParams{
 		Q:       "*",
 		QueryBy: "name",
 		PerPage: pointer.Int(0),
-		FacetBy: pointer.String("city,country,star_rating,amenities,price_range,chain"),
+		FacetBy: pointer.String(strings.Join(facetSettings.Fields, ",")),
+	}
+	if facetSettings.MaxValues > 0 {
+		searchParams.MaxFacetValues = pointer.Int(facetSettings.MaxValues)
 	}
 
 	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
@@ -551,6 +1487,7 @@ func (t *TypesenseAdapter) GetFacets(ctx context.Context) (*search.Facets, error
 		PriceRanges:  make([]search.FacetItem, 0),
 		HotelChains:  make([]search.FacetItem, 0),
 		RatingRanges: make([]search.FacetItem, 0),
+		Languages:    make([]search.FacetItem, 0),
 	}
 
 	if searchResponse.FacetCounts != nil {
@@ -598,6 +1535,13 @@ func (t *TypesenseAdapter) GetFacets(ctx context.Context) (*search.Facets, error
 						Count: int64(*count.Count),
 					})
 				}
+			case "languages_available":
+				for _, count := range *facetCount.Counts {
+					facets.Languages = append(facets.Languages, search.FacetItem{
+						Value: *count.Value,
+						Count: int64(*count.Count),
+					})
+				}
 			}
 		}
 	}
@@ -605,6 +1549,44 @@ func (t *TypesenseAdapter) GetFacets(ctx context.Context) (*search.Facets, error
 	return facets, nil
 }
 
+// GetFacetValues searches the values of a facet field: Typesense matches query against the
+// start of every word of the values and counts the hotels per value.
+func (t *TypesenseAdapter) GetFacetValues(_ context.Context, field, query string, limit int) ([]search.FacetItem, error) {
+	searchParams := &api.SearchCollectionParams{
+		Q:              "*",
+		QueryBy:        "name",
+		PerPage:        pointer.Int(0),
+		FacetBy:        pointer.String(field),
+		FacetQuery:     pointer.String(field + ":" + query),
+		MaxFacetValues: pointer.Int(limit),
+	}
+
+	startTime := time.Now()
+	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+	t.loadShedder.Record(time.Since(startTime), err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s facet values: %w", field, err)
+	}
+
+	values := make([]search.FacetItem, 0)
+	if searchResponse.FacetCounts == nil {
+		return values, nil
+	}
+	for _, facetCount := range *searchResponse.FacetCounts {
+		if facetCount.FieldName == nil || *facetCount.FieldName != field || facetCount.Counts == nil {
+			continue
+		}
+		for _, count := range *facetCount.Counts {
+			values = append(values, search.FacetItem{
+				Value: *count.Value,
+				Count: int64(*count.Count),
+			})
+		}
+	}
+
+	return values, nil
+}
+
 func (t *TypesenseAdapter) ClearIndex(ctx context.Context) error {
 	_, err := t.client.Collection(t.collectionName).Retrieve()
 	if err == nil {
@@ -628,20 +1610,135 @@ func (t *TypesenseAdapter) GetIndexStats(ctx context.Context) (*search.IndexStat
 		return nil, fmt.Errorf("failed to get collection stats: %w", err)
 	}
 
-	collectionResponses, _ := t.client.Collections().Retrieve()
+	stats := &search.IndexStats{
+		TotalDocuments: int64(*collection.NumDocuments),
+		LastUpdated:    time.Now().UTC(),
+		Version:        "typesense",
+	}
 
-	_ = collectionResponses
+	stats.DiskSizeBytes, stats.MemorySizeBytes = t.clusterSize(ctx)
+	stats.DiskSizeHuman = search.FormatBytes(stats.DiskSizeBytes)
+	stats.MemorySizeHuman = search.FormatBytes(stats.MemorySizeBytes)
 
-	for _, document := range collectionResponses {
-		println(document.NumDocuments)
+	newest, err := t.newestDocumentTime()
+	if err != nil {
+		t.logger.Warn("Failed to get newest indexed document", "error", err)
+	} else {
+		stats.NewestDocumentAt = newest
 	}
 
-	return &search.IndexStats{
-		TotalDocuments: int64(*collection.NumDocuments),
-		IndexSize:      0,
-		LastUpdated:    time.Now(),
-		Version:        "typesense",
-	}, nil
+	return stats, nil
+}
+
+// clusterSize reads /metrics.json from every node. Typesense does not report sizes per
+// collection, so these are the node totals: the largest disk usage and the summed memory.
+// Nodes that cannot be reached are skipped.
+func (t *TypesenseAdapter) clusterSize(ctx context.Context) (diskBytes, memoryBytes int64) {
+	for _, node := range t.metricsNodes {
+		metrics, err := t.nodeMetrics(ctx, node)
+		if err != nil {
+			t.logger.Warn("Failed to read Typesense node metrics", "node", node, "error", err)
+			continue
+		}
+
+		diskBytes = max(diskBytes, metricBytes(metrics, "system_disk_used_bytes"))
+		memoryBytes += metricBytes(metrics, "typesense_memory_active_bytes")
+	}
+	return diskBytes, memoryBytes
+}
+
+func (t *TypesenseAdapter) nodeMetrics(ctx context.Context, node string) (map[string]any, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, node+"/metrics.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-TYPESENSE-API-KEY", t.apiKey)
+
+	response, err := t.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", response.StatusCode)
+	}
+
+	var metrics map[string]any
+	if err := json.NewDecoder(response.Body).Decode(&metrics); err != nil {
+		return nil, fmt.Errorf("failed to decode metrics: %w", err)
+	}
+	return metrics, nil
+}
+
+// metricBytes reads a metric that Typesense reports either as a number or a numeric string.
+func metricBytes(metrics map[string]any, name string) int64 {
+	switch value := metrics[name].(type) {
+	case float64:
+		return int64(value)
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return parsed
+	default:
+		return 0
+	}
+}
+
+// metricsNodes returns hostURL followed by the other cluster nodes, without duplicates or
+// trailing slashes.
+func metricsNodes(hostURL string, clusterNodes []string) []string {
+	nodes := make([]string, 0, len(clusterNodes)+1)
+	for _, node := range append([]string{hostURL}, clusterNodes...) {
+		node = strings.TrimSuffix(strings.TrimSpace(node), "/")
+		if node != "" && !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func (t *TypesenseAdapter) newestDocumentTime() (*time.Time, error) {
+	searchParams := &api.SearchCollectionParams{
+		Q:             "*",
+		QueryBy:       "name",
+		SortBy:        pointer.String("updated_at:desc"),
+		IncludeFields: pointer.String("updated_at"),
+		PerPage:       pointer.Int(1),
+	}
+
+	searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search newest document: %w", err)
+	}
+
+	if searchResponse.Hits == nil || len(*searchResponse.Hits) == 0 || (*searchResponse.Hits)[0].Document == nil {
+		return nil, nil
+	}
+
+	updatedAt, ok := (*(*searchResponse.Hits)[0].Document)["updated_at"].(float64)
+	if !ok {
+		return nil, nil
+	}
+
+	newest := time.Unix(int64(updatedAt), 0).UTC()
+	return &newest, nil
+}
+
+func (t *TypesenseAdapter) ShouldShed() (bool, time.Duration) {
+	return t.loadShedder.ShouldShed()
+}
+
+func (t *TypesenseAdapter) RecordShed() {
+	t.loadShedder.RecordShed()
+}
+
+func (t *TypesenseAdapter) LoadStatus() search.LoadStatus {
+	return t.loadShedder.LoadStatus()
 }
 
 func (t *TypesenseAdapter) HealthCheck(ctx context.Context) error {
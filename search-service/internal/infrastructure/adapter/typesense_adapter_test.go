@@ -0,0 +1,386 @@
+package adapter
+
+import (
+	"errors"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+func TestBuildSortBoostsMatchedAmenityCount(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	tests := []struct {
+		name     string
+		params   search.Params
+		expected string
+	}{
+		{
+			name:     "relevance with amenities",
+			params:   search.Params{SortBy: "relevance", Amenities: []string{"wifi", "pool", "spa"}},
+			expected: "_eval([amenities:=[`wifi`]:1, amenities:=[`pool`]:1, amenities:=[`spa`]:1]):desc,rating:desc,hotel_id:asc",
+		},
+		{
+			name:     "amenities with filter syntax are quoted",
+			params:   search.Params{SortBy: "relevance", Amenities: []string{"wifi, pool", "spa]:100", "gym`"}},
+			expected: "_eval([amenities:=[`wifi, pool`]:1, amenities:=[`spa]:100`]:1, amenities:=[`gym`]:1]):desc,rating:desc,hotel_id:asc",
+		},
+		{
+			name:     "relevance without amenities",
+			params:   search.Params{SortBy: "relevance"},
+			expected: "_text_match:desc,rating:desc,hotel_id:asc",
+		},
+		{
+			name:     "amenities sorted by another field",
+			params:   search.Params{SortBy: "rating", Amenities: []string{"wifi"}},
+			expected: "rating:desc,hotel_id:asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, adapter.buildSort(tt.params))
+		})
+	}
+}
+
+func TestHitDistanceKm(t *testing.T) {
+	paris := search.Params{Latitude: 48.8566, Longitude: 2.3522}
+
+	tests := []struct {
+		name     string
+		hit      api.SearchResultHit
+		hotel    *hotel.Hotel
+		expected *float64
+	}{
+		{
+			name:     "haversine fallback for London",
+			hotel:    &hotel.Hotel{Latitude: 51.5074, Longitude: -0.1278},
+			expected: floatPointer(343.6),
+		},
+		{
+			name:     "geo distance returned by Typesense",
+			hit:      api.SearchResultHit{GeoDistanceMeters: &map[string]int{"location": 12345}},
+			hotel:    &hotel.Hotel{Latitude: 48.9, Longitude: 2.4},
+			expected: floatPointer(12.3),
+		},
+		{
+			name:  "hotel without coordinates",
+			hotel: &hotel.Hotel{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance := hitDistanceKm(tt.hit, tt.hotel, paris)
+			if tt.expected == nil {
+				assert.Nil(t, distance)
+				return
+			}
+			require.NotNil(t, distance)
+			assert.InDelta(t, *tt.expected, *distance, 0.5)
+		})
+	}
+}
+
+func TestHitDistanceKmOrdersLikeGeoSort(t *testing.T) {
+	params := search.Params{Latitude: 40.4168, Longitude: -3.7038}
+	hotels := []*hotel.Hotel{
+		{Latitude: 40.4200, Longitude: -3.7000},
+		{Latitude: 40.4530, Longitude: -3.6883},
+		{Latitude: 41.3874, Longitude: 2.1686},
+	}
+
+	previous := -1.0
+	for _, h := range hotels {
+		distance := hitDistanceKm(api.SearchResultHit{}, h, params)
+		require.NotNil(t, distance)
+		assert.GreaterOrEqual(t, *distance, previous)
+		previous = *distance
+	}
+}
+
+func floatPointer(value float64) *float64 {
+	return &value
+}
+
+func TestBuildFiltersArrivalTime(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	params := search.Params{ArrivalTime: "23:30"}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, "(checkin_end_minutes:>=1410 || checkin_24h:=true || checkin_unknown:=true)", adapter.buildFilters(params))
+
+	params.StrictCheckin = true
+	assert.Equal(t, "(checkin_end_minutes:>=1410 || checkin_24h:=true)", adapter.buildFilters(params))
+
+	assert.Empty(t, adapter.buildFilters(search.Params{StrictCheckin: true}))
+}
+
+func TestBuildFiltersAmenities(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	params := search.Params{Amenities: []string{"wifi", "pool"}}
+	assert.Equal(t, "(amenities:=`wifi` || amenities:=`pool`)", adapter.buildFilters(params))
+
+	params = search.Params{Amenities: []string{"wifi) || rating:>0 || (pool"}}
+	assert.Equal(t, "(amenities:=`wifi) || rating:>0 || (pool`)", adapter.buildFilters(params), "a value cannot add filters")
+}
+
+func TestBuildSortOrdersAmenityWeightsByDescendingWeight(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+	weights := map[string]float64{"wifi": 0.3, "pool": 0.9, "spa": 0.7, "gym": 0.7}
+
+	expected := "_eval([amenities:=[`pool`]:90, amenities:=[`gym`]:70, amenities:=[`spa`]:70, amenities:=[`wifi`]:30]):desc,rating:desc,hotel_id:asc"
+	assert.Equal(t, expected, adapter.buildSort(search.Params{AmenityWeights: weights}))
+	assert.Equal(t, expected, adapter.buildSort(search.Params{SortBy: "relevance", AmenityWeights: weights}))
+
+	// An explicit sort field takes precedence over the weights.
+	assert.Equal(t, "name:asc,hotel_id:asc", adapter.buildSort(search.Params{SortBy: "name", SortOrder: "asc", AmenityWeights: weights}))
+}
+
+// sortDocuments orders documents by a Typesense sort_by of plain field:order components, the way
+// the engine applies it, taking _text_match from textMatch by hotel ID. The order of documents
+// equal on every component is left as it is.
+func sortDocuments(t *testing.T, documents []TypesenseDocument, sortBy string, textMatch map[int64]float64) {
+	t.Helper()
+	components := strings.Split(sortBy, ",")
+	sort.SliceStable(documents, func(i, j int) bool {
+		for _, component := range components {
+			field, order, _ := strings.Cut(component, ":")
+			var a, b float64
+			switch field {
+			case "rating":
+				a, b = documents[i].Rating, documents[j].Rating
+			case "hotel_id":
+				a, b = float64(documents[i].HotelID), float64(documents[j].HotelID)
+			case "_text_match":
+				a, b = textMatch[documents[i].HotelID], textMatch[documents[j].HotelID]
+			default:
+				t.Fatalf("unexpected sort field %q", field)
+			}
+			if a != b {
+				return (a < b) == (order == "asc")
+			}
+		}
+		return false
+	})
+}
+
+func TestDefaultSortPagesThroughTiedRatingsWithoutDuplicatesOrGaps(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	const hotels = 95
+	documents := make([]TypesenseDocument, hotels)
+	for i := range documents {
+		documents[i] = TypesenseDocument{HotelID: int64(i + 1), Rating: 4.5}
+		if i%10 == 0 {
+			documents[i].Rating = 4.8
+		}
+	}
+
+	params := search.Params{Limit: 20}
+	require.NoError(t, params.Validate())
+	sortBy := adapter.buildSort(params)
+	assert.Equal(t, "rating:desc,hotel_id:asc", sortBy)
+
+	random := rand.New(rand.NewSource(1))
+	seen := make(map[int64]bool)
+	var previous TypesenseDocument
+	for page := 1; (page-1)*params.Limit < hotels; page++ {
+		// Each request finds the documents in a different order before sorting, as tied documents
+		// may come back from the engine.
+		random.Shuffle(len(documents), func(i, j int) { documents[i], documents[j] = documents[j], documents[i] })
+		sortDocuments(t, documents, sortBy, nil)
+
+		start := (page - 1) * params.Limit
+		for _, document := range documents[start:min(start+params.Limit, hotels)] {
+			assert.False(t, seen[document.HotelID], "hotel %d returned twice", document.HotelID)
+			seen[document.HotelID] = true
+			if previous.HotelID != 0 && previous.Rating == document.Rating {
+				assert.Less(t, previous.HotelID, document.HotelID)
+			}
+			previous = document
+		}
+	}
+	assert.Len(t, seen, hotels)
+}
+
+func TestBuildSortAppendsTiebreaker(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	for _, params := range []search.Params{{}, {SortBy: "rating"}, {SortBy: "relevance", SortOrder: "desc"}} {
+		require.NoError(t, params.Validate())
+		assert.Equal(t, "rating:desc,hotel_id:asc", adapter.buildSort(params))
+	}
+
+	params := search.Params{SortBy: "name", SortOrder: "asc"}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, "name:asc,hotel_id:asc", adapter.buildSort(params))
+}
+
+func TestConvertSearchResultPrefersHighlightedSnippet(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+	field, snippet := "description", "rooftop <mark>terrace</mark> overlooking the river"
+	hits := []api.SearchResultHit{
+		{
+			Document:   &map[string]any{"hotel_id": 1, "name": "Riverside Hotel", "description": "A hotel with a rooftop terrace overlooking the river."},
+			Highlights: &[]api.SearchHighlight{{Field: &field, Snippet: &snippet}},
+		},
+		{
+			Document: &map[string]any{"hotel_id": 2, "name": "Harbour Hotel", "description": "A hotel by the harbour."},
+		},
+	}
+
+	result := adapter.convertSearchResult(&api.SearchResult{Hits: &hits}, search.Params{}, 1, 20)
+	result.ApplySnippets(search.DefaultSnippetLength, false)
+
+	require.Len(t, result.Hotels, 2)
+	assert.Equal(t, snippet, result.Hotels[0].DescriptionSnippet)
+	assert.Equal(t, "A hotel by the harbour.", result.Hotels[1].DescriptionSnippet)
+	assert.Empty(t, result.Hotels[0].Description)
+}
+
+func TestConvertHotelToDocumentAirportDistance(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	document := adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 1, Latitude: 48.8681, Longitude: 2.3292, AirportCode: "CDG"})
+	require.NotNil(t, document.AirportDistanceKm)
+	assert.InDelta(t, 22.6, *document.AirportDistanceKm, 0.3)
+
+	document = adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 2, Latitude: 48.8681, Longitude: 2.3292, AirportCode: "XXX"})
+	assert.Nil(t, document.AirportDistanceKm, "hotels near an unknown airport are not given a distance")
+}
+
+func TestConvertHotelToDocumentOmitsImplausibleCoordinates(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	document := adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 1, Latitude: 48.8681, Longitude: 2.3292})
+	assert.True(t, document.CoordinatesValid)
+	assert.Equal(t, []float64{48.8681, 2.3292}, document.Location)
+
+	for _, coordinates := range [][2]float64{{0, 0}, {0.0009, -0.0009}, {-90.5, 2.3}, {48.8, 180.01}} {
+		document := adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 2, Latitude: coordinates[0], Longitude: coordinates[1], AirportCode: "CDG"})
+		assert.False(t, document.CoordinatesValid, "%v", coordinates)
+		assert.Nil(t, document.Location, "%v", coordinates)
+		assert.Nil(t, document.AirportDistanceKm, "%v", coordinates)
+	}
+}
+
+func TestBuildFiltersMaxAirportDistance(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	assert.Equal(t, "airport_distance_km:<=10.000000", adapter.buildFilters(search.Params{MaxAirportDistanceKm: 10}))
+	assert.Equal(t, "airport_code:=JFK && airport_distance_km:<=2.500000",
+		adapter.buildFilters(search.Params{AirportCode: "JFK", MaxAirportDistanceKm: 2.5}))
+}
+
+func TestRoomInventoryDocumentFieldsAndFilters(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	document := adapter.convertHotelToDocument(&hotel.Hotel{
+		HotelID: 1,
+		Rooms:   []hotel.Room{{MaxOccupancy: 2}, {MaxOccupancy: 4}, {MaxOccupancy: 6}},
+	})
+	assert.Equal(t, int32(3), document.RoomTypesCount)
+	assert.Equal(t, int32(12), document.TotalCapacity)
+
+	minRoomTypes, minTotalCapacity := int32(3), int32(10)
+	assert.Equal(t, "room_types_count:>=3 && total_capacity:>=10",
+		adapter.buildFilters(search.Params{MinRoomTypes: &minRoomTypes, MinTotalCapacity: &minTotalCapacity}))
+}
+
+func TestPagingErrorMapsTypesensePagingErrors(t *testing.T) {
+	adapter := &TypesenseAdapter{logger: slog.New(slog.DiscardHandler)}
+	params := search.Params{Page: 300, Limit: 100}
+
+	tests := []struct {
+		message  string
+		expected error
+		code     string
+	}{
+		{"status: 422 response: {\"message\": \"Only upto 250 hits can be fetched per page.\"}", search.ErrPerPageTooLarge, "per_page_too_large"},
+		{"status: 422 response: {\"message\": \"Page must be an integer of value greater than 0.\"}", search.ErrPageOutOfRange, "page_out_of_range"},
+		{"status: 422 response: {\"message\": \"Only the first 10000 hits can be fetched. Ensure that `page` and `per_page` parameters are within this range.\"}", search.ErrPageOutOfRange, "page_out_of_range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			err := adapter.pagingError(errors.New(tt.message), params)
+
+			var pageLimitErr *search.PageLimitError
+			require.ErrorAs(t, err, &pageLimitErr)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.code, pageLimitErr.Code())
+			assert.Equal(t, typesenseMaxResultWindow/100, pageLimitErr.MaxPage)
+			assert.Equal(t, typesenseMaxPerPage, pageLimitErr.MaxPerPage)
+		})
+	}
+
+	assert.NoError(t, adapter.pagingError(errors.New("status: 503 response: not ready"), params))
+}
+
+func TestCapabilitiesReportTypesenseLimits(t *testing.T) {
+	capabilities := (&TypesenseAdapter{}).Capabilities()
+
+	assert.Equal(t, typesenseMaxPerPage, capabilities.MaxPerPage)
+	assert.Equal(t, typesenseMaxResultWindow, capabilities.MaxResultWindow)
+	assert.Equal(t, typesenseMaxResultWindow/100, capabilities.MaxPage(100))
+}
+
+func TestBuildFiltersNormalizesPhoneNumbers(t *testing.T) {
+	adapter := &TypesenseAdapter{logger: slog.New(slog.DiscardHandler)}
+
+	for _, phone := range []string{"+1 212-555-0100", "(212) 555-0100", "12125550100"} {
+		params := search.Params{Phone: phone, Country: "us"}
+		assert.Equal(t, "phone_e164:=`+12125550100` && country:=us", adapter.buildFilters(params), phone)
+	}
+
+	params := search.Params{Fax: "01 42 68 53 00", Country: "fr"}
+	assert.Equal(t, "fax_e164:=`+33142685300` && country:=fr", adapter.buildFilters(params))
+
+	params = search.Params{Phone: "reception"}
+	assert.Equal(t, "phone:=reception", adapter.buildFilters(params), "input that is not a number matches the raw field")
+}
+
+func TestAddedFieldsIncludeNormalizedContactNumbers(t *testing.T) {
+	names := make(map[string]bool)
+	for _, field := range (&TypesenseAdapter{}).addedFields() {
+		assert.False(t, names[field.Name], "duplicate field %s", field.Name)
+		names[field.Name] = true
+	}
+
+	assert.True(t, names["phone_e164"])
+	assert.True(t, names["fax_e164"])
+}
+
+func TestConvertHotelToDocumentMinNightsFromBareInteger(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+
+	groupRoomMin, err := hotel.ParseGroupRoomMin([]byte(`3`))
+	require.NoError(t, err)
+
+	document := adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 1, GroupRoomMin: groupRoomMin})
+	assert.Equal(t, int32(3), document.MinNights)
+
+	document = adapter.convertHotelToDocument(&hotel.Hotel{HotelID: 2})
+	assert.Equal(t, int32(0), document.MinNights)
+}
+
+func TestBuildFiltersCreatedAtRange(t *testing.T) {
+	adapter := &TypesenseAdapter{}
+	after := time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "created_at:>=1789560000", adapter.buildFilters(search.Params{CreatedAfter: &after}))
+	assert.Equal(t, "created_at:>=1789560000 && created_at:<=1792152000",
+		adapter.buildFilters(search.Params{CreatedAfter: &after, CreatedBefore: &before}))
+}
@@ -0,0 +1,119 @@
+package adapter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/typesense/typesense-go/typesense/api/pointer"
+)
+
+// documentIDPageSize is the number of documents migrateDocumentIDs reads at a time.
+const documentIDPageSize = 250
+
+// migrateDocumentIDs re-keys the documents indexed before document ids were the hotel_id, to
+// which Typesense gave generated ids. Partial updates and deletes address documents by hotel_id
+// and would miss them.
+func (t *TypesenseAdapter) migrateDocumentIDs() {
+	migrated, err := t.rekeyDocuments()
+	if err != nil {
+		t.logger.Warn("Failed to migrate document ids, clear and re-sync the index to apply them",
+			"migrated", migrated,
+			"error", err)
+		return
+	}
+	if migrated > 0 {
+		t.logger.Info("Migrated documents to hotel_id document ids", "documents", migrated)
+	}
+}
+
+// rekeyDocuments upserts every document whose id is not its hotel_id under its hotel_id and
+// deletes the old one, which also drops the copies of a hotel indexed more than once. It
+// returns the number of documents re-keyed.
+func (t *TypesenseAdapter) rekeyDocuments() (int, error) {
+	migrated := 0
+	var fromHotelID int64
+	for {
+		searchParams := &api.SearchCollectionParams{
+			Q:        "*",
+			QueryBy:  "name",
+			FilterBy: pointer.String(fmt.Sprintf("hotel_id:>=%d", fromHotelID)),
+			SortBy:   pointer.String("hotel_id:asc"),
+			Page:     pointer.Int(1),
+			PerPage:  pointer.Int(documentIDPageSize),
+		}
+
+		searchResponse, err := t.client.Collection(t.collectionName).Documents().Search(searchParams)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to list documents from hotel %d: %w", fromHotelID, err)
+		}
+		if searchResponse.Hits == nil || len(*searchResponse.Hits) == 0 {
+			return migrated, nil
+		}
+		hits := *searchResponse.Hits
+
+		var rekeyed []interface{}
+		var staleIDs []string
+		lastHotelID := fromHotelID
+		for _, hit := range hits {
+			if hit.Document == nil {
+				continue
+			}
+			document := *hit.Document
+			hotelID, ok := document["hotel_id"].(float64)
+			if !ok {
+				continue
+			}
+			lastHotelID = int64(hotelID)
+
+			id := strconv.FormatInt(lastHotelID, 10)
+			if currentID, _ := document["id"].(string); currentID != id {
+				staleIDs = append(staleIDs, currentID)
+				document["id"] = id
+				rekeyed = append(rekeyed, document)
+			}
+		}
+
+		if len(rekeyed) > 0 {
+			if err := t.replaceDocuments(rekeyed, staleIDs); err != nil {
+				return migrated, err
+			}
+			migrated += len(rekeyed)
+		}
+
+		if len(hits) < documentIDPageSize {
+			return migrated, nil
+		}
+		// Hotels of the last page may have more stale copies on the next one, so it is read
+		// again until a page needs no re-keying.
+		if len(rekeyed) == 0 {
+			fromHotelID = lastHotelID + 1
+		} else {
+			fromHotelID = lastHotelID
+		}
+	}
+}
+
+// replaceDocuments upserts the re-keyed documents, then deletes their stale copies.
+func (t *TypesenseAdapter) replaceDocuments(documents []interface{}, staleIDs []string) error {
+	params := &api.ImportDocumentsParams{
+		Action: pointer.String("upsert"),
+	}
+
+	responses, err := t.client.Collection(t.collectionName).Documents().Import(documents, params)
+	if err != nil {
+		return fmt.Errorf("failed to upsert re-keyed documents: %w", err)
+	}
+	for _, response := range responses {
+		if response != nil && !response.Success {
+			return fmt.Errorf("failed to upsert re-keyed document: %s", response.Error)
+		}
+	}
+
+	for _, staleID := range staleIDs {
+		if _, err := t.client.Collection(t.collectionName).Document(staleID).Delete(); err != nil {
+			return fmt.Errorf("failed to delete document %s: %w", staleID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/typesense/typesense-go/typesense/api/pointer"
+)
+
+// ImportConfig sizes the document imports of Index. Its defaults are set by the configuration.
+type ImportConfig struct {
+	// BatchSize is the number of documents Typesense processes at a time within an import.
+	BatchSize int
+	// MaxPayloadBytes caps the JSONL body of one import request. Larger imports are split
+	// into several requests, and a document larger than the cap on its own is truncated.
+	MaxPayloadBytes int
+}
+
+// importDocument is a document to import and its size as a JSONL line.
+type importDocument struct {
+	document TypesenseDocument
+	size     int
+}
+
+// importResult counts the documents of an import Typesense accepted and rejected.
+type importResult struct {
+	indexed    int
+	failed     int
+	firstError string
+}
+
+func (r *importResult) add(other importResult) {
+	r.indexed += other.indexed
+	r.failed += other.failed
+	if r.firstError == "" {
+		r.firstError = other.firstError
+	}
+}
+
+// importDocuments upserts the documents in as many requests as it takes to keep each body
+// under the payload cap.
+func (t *TypesenseAdapter) importDocuments(documents []TypesenseDocument) (importResult, error) {
+	sized := make([]importDocument, len(documents))
+	for i := range documents {
+		size, err := documentSize(documents[i])
+		if err != nil {
+			return importResult{}, fmt.Errorf("failed to encode hotel %d: %w", documents[i].HotelID, err)
+		}
+		if size > t.importConfig.MaxPayloadBytes {
+			originalSize := size
+			size = fitDocument(&documents[i], size, t.importConfig.MaxPayloadBytes)
+			t.logger.Warn("Truncated oversized hotel document to fit the import payload cap",
+				"hotel_id", documents[i].HotelID,
+				"size_bytes", originalSize,
+				"truncated_size_bytes", size,
+				"max_payload_bytes", t.importConfig.MaxPayloadBytes)
+		}
+		sized[i] = importDocument{document: documents[i], size: size}
+	}
+
+	var result importResult
+	for _, batch := range splitImportBatches(sized, t.importConfig.MaxPayloadBytes) {
+		batchResult, err := t.importBatch(batch)
+		result.add(batchResult)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// importBatch sends one import request. When Typesense still finds the body too large, as
+// the estimate is off or its limit is below the cap, the batch is halved and sent again down
+// to single documents.
+func (t *TypesenseAdapter) importBatch(batch []importDocument) (importResult, error) {
+	documents := make([]interface{}, len(batch))
+	for i := range batch {
+		documents[i] = batch[i].document
+	}
+
+	params := &api.ImportDocumentsParams{
+		Action:    pointer.String("upsert"),
+		BatchSize: pointer.Int(t.importConfig.BatchSize),
+	}
+
+	responses, err := t.sendImport(documents, params)
+	if err != nil {
+		if len(batch) > 1 && isPayloadTooLarge(err) {
+			t.logger.Warn("Typesense rejected the import payload as too large, splitting it", "documents", len(batch))
+			half := len(batch) / 2
+			result, err := t.importBatch(batch[:half])
+			if err != nil {
+				return result, err
+			}
+			second, err := t.importBatch(batch[half:])
+			result.add(second)
+			return result, err
+		}
+		return importResult{}, err
+	}
+
+	var result importResult
+	for _, response := range responses {
+		if response != nil && !response.Success {
+			result.failed++
+			if result.firstError == "" {
+				result.firstError = response.Error
+			}
+			continue
+		}
+		result.indexed++
+	}
+	return result, nil
+}
+
+func (t *TypesenseAdapter) sendImport(documents []interface{}, params *api.ImportDocumentsParams) ([]*api.ImportDocumentResponse, error) {
+	if t.importer != nil {
+		return t.importer(documents, params)
+	}
+	return t.client.Collection(t.collectionName).Documents().Import(documents, params)
+}
+
+// splitImportBatches groups the documents, in order, into batches whose JSONL body stays
+// under maxBytes. A document as large as the cap goes alone.
+func splitImportBatches(documents []importDocument, maxBytes int) [][]importDocument {
+	var batches [][]importDocument
+	var batch []importDocument
+	batchBytes := 0
+	for _, document := range documents {
+		if len(batch) > 0 && batchBytes+document.size > maxBytes {
+			batches = append(batches, batch)
+			batch, batchBytes = nil, 0
+		}
+		batch = append(batch, document)
+		batchBytes += document.size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// documentSize is the size of the document as a line of the JSONL import body.
+func documentSize(document TypesenseDocument) (int, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return 0, err
+	}
+	return len(data) + 1, nil
+}
+
+// fitDocument shortens the longest of the description and important info of a document
+// larger than maxBytes until it fits or both are empty, and returns its new size. The
+// document carries no rooms, so its free texts are what make it large.
+func fitDocument(document *TypesenseDocument, size, maxBytes int) int {
+	for size > maxBytes {
+		field := &document.Description
+		if len(document.ImportantInfo) > len(document.Description) {
+			field = &document.ImportantInfo
+		}
+		if *field == "" {
+			return size
+		}
+
+		*field = truncateBytes(*field, len(*field)-(size-maxBytes))
+		newSize, err := documentSize(*document)
+		if err != nil {
+			return size
+		}
+		size = newSize
+	}
+	return size
+}
+
+// truncateBytes cuts s to at most n bytes without splitting a character.
+func truncateBytes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if n >= len(s) {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// isPayloadTooLarge reports whether Typesense refused an import for the size of its body.
+func isPayloadTooLarge(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "status: 413") || strings.Contains(message, "too large")
+}
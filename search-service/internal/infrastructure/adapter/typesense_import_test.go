@@ -0,0 +1,168 @@
+package adapter
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/typesense/typesense-go/typesense/api"
+)
+
+// importRecorder stands in for the import endpoint. It records the hotel ids of each request,
+// rejects requests with more than maxDocuments documents as too large, and fails the
+// documents of the failing hotels.
+type importRecorder struct {
+	maxDocuments int
+	failing      map[int64]bool
+	requests     [][]int64
+}
+
+func (r *importRecorder) importDocuments(documents []interface{}, params *api.ImportDocumentsParams) ([]*api.ImportDocumentResponse, error) {
+	if r.maxDocuments > 0 && len(documents) > r.maxDocuments {
+		return nil, errors.New("status: 413 request entity too large")
+	}
+	ids := make([]int64, len(documents))
+	responses := make([]*api.ImportDocumentResponse, len(documents))
+	for i, document := range documents {
+		ids[i] = document.(TypesenseDocument).HotelID
+		responses[i] = &api.ImportDocumentResponse{Success: true}
+		if r.failing[ids[i]] {
+			responses[i] = &api.ImportDocumentResponse{Error: "bad document"}
+		}
+	}
+	r.requests = append(r.requests, ids)
+	return responses, nil
+}
+
+func newImportTestAdapter(recorder *importRecorder, maxPayloadBytes int) *TypesenseAdapter {
+	return &TypesenseAdapter{
+		importConfig: ImportConfig{BatchSize: 100, MaxPayloadBytes: maxPayloadBytes},
+		importer:     recorder.importDocuments,
+		logger:       slog.New(slog.DiscardHandler),
+	}
+}
+
+func sizedDocuments(sizes ...int) []importDocument {
+	documents := make([]importDocument, len(sizes))
+	for i, size := range sizes {
+		documents[i] = importDocument{document: TypesenseDocument{HotelID: int64(i + 1)}, size: size}
+	}
+	return documents
+}
+
+func batchSizes(batches [][]importDocument) [][]int {
+	sizes := make([][]int, len(batches))
+	for i, batch := range batches {
+		for _, document := range batch {
+			sizes[i] = append(sizes[i], document.size)
+		}
+	}
+	return sizes
+}
+
+func TestSplitImportBatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		sizes    []int
+		expected [][]int
+	}{
+		{name: "under the cap", sizes: []int{30, 30, 30}, expected: [][]int{{30, 30, 30}}},
+		{name: "exactly the cap", sizes: []int{40, 60, 1}, expected: [][]int{{40, 60}, {1}}},
+		{name: "one byte over the cap", sizes: []int{40, 61, 20}, expected: [][]int{{40}, {61, 20}}},
+		{name: "document as large as the cap goes alone", sizes: []int{10, 100, 10}, expected: [][]int{{10}, {100}, {10}}},
+		{name: "document larger than the cap goes alone", sizes: []int{150, 10}, expected: [][]int{{150}, {10}}},
+		{name: "no documents", sizes: nil, expected: [][]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, batchSizes(splitImportBatches(sizedDocuments(tt.sizes...), 100)))
+		})
+	}
+}
+
+func TestImportDocumentsSplitsByPayloadSize(t *testing.T) {
+	recorder := &importRecorder{}
+	documents := make([]TypesenseDocument, 5)
+	for i := range documents {
+		documents[i] = TypesenseDocument{HotelID: int64(i + 1), Description: strings.Repeat("x", 200)}
+	}
+	size, err := documentSize(documents[0])
+	require.NoError(t, err)
+	adapter := newImportTestAdapter(recorder, 2*size)
+
+	result, err := adapter.importDocuments(documents)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}, {5}}, recorder.requests, "documents are sent in order, two per request")
+	assert.Equal(t, importResult{indexed: 5}, result)
+}
+
+func TestImportDocumentsTruncatesOversizedDocuments(t *testing.T) {
+	recorder := &importRecorder{}
+	oversized := TypesenseDocument{
+		HotelID:       1,
+		Name:          "Harbour Hotel",
+		Description:   strings.Repeat("é", 3000),
+		ImportantInfo: strings.Repeat("i", 500),
+	}
+	adapter := newImportTestAdapter(recorder, 2048)
+
+	result, err := adapter.importDocuments([]TypesenseDocument{oversized, {HotelID: 2}})
+
+	require.NoError(t, err)
+	assert.Equal(t, importResult{indexed: 2}, result)
+	require.Len(t, recorder.requests, 2)
+	assert.Equal(t, []int64{1}, recorder.requests[0])
+
+	fitted := oversized
+	size, err := documentSize(oversized)
+	require.NoError(t, err)
+	newSize := fitDocument(&fitted, size, 2048)
+	assert.LessOrEqual(t, newSize, 2048)
+	assert.Less(t, len(fitted.Description), len(oversized.Description), "the longest text is shortened")
+	assert.True(t, utf8.ValidString(fitted.Description), "characters are not split")
+	assert.Equal(t, oversized.ImportantInfo, fitted.ImportantInfo, "the shorter text is kept while the longer one is enough")
+	assert.Equal(t, "Harbour Hotel", fitted.Name)
+}
+
+func TestImportDocumentsCountsAcceptedAndRejectedDocuments(t *testing.T) {
+	recorder := &importRecorder{failing: map[int64]bool{2: true, 4: true}}
+	adapter := newImportTestAdapter(recorder, 1<<20)
+	documents := []TypesenseDocument{{HotelID: 1}, {HotelID: 2}, {HotelID: 3}, {HotelID: 4}}
+
+	result, err := adapter.importDocuments(documents)
+
+	require.NoError(t, err)
+	assert.Equal(t, importResult{indexed: 2, failed: 2, firstError: "bad document"}, result)
+}
+
+func TestImportBatchHalvesPayloadsTypesenseRejects(t *testing.T) {
+	recorder := &importRecorder{maxDocuments: 2, failing: map[int64]bool{5: true}}
+	adapter := newImportTestAdapter(recorder, 1<<20)
+	documents := make([]TypesenseDocument, 5)
+	for i := range documents {
+		documents[i] = TypesenseDocument{HotelID: int64(i + 1)}
+	}
+
+	result, err := adapter.importDocuments(documents)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{1, 2}, {3}, {4, 5}}, recorder.requests)
+	assert.Equal(t, importResult{indexed: 4, failed: 1, firstError: "bad document"}, result)
+}
+
+func TestImportBatchGivesUpOnASingleRejectedDocument(t *testing.T) {
+	adapter := newImportTestAdapter(&importRecorder{}, 1<<20)
+	adapter.importer = func([]interface{}, *api.ImportDocumentsParams) ([]*api.ImportDocumentResponse, error) {
+		return nil, errors.New("status: 413 request entity too large")
+	}
+
+	_, err := adapter.importDocuments([]TypesenseDocument{{HotelID: 1}})
+
+	assert.True(t, isPayloadTooLarge(err))
+}
@@ -0,0 +1,74 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+// newMetricsNode serves body as the /metrics.json of a Typesense node.
+func newMetricsNode(t *testing.T, body string) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics.json" || r.Header.Get("X-TYPESENSE-API-KEY") != "typesense-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestClusterSizeAggregatesNodeMetrics(t *testing.T) {
+	leader := newMetricsNode(t, `{"system_disk_used_bytes":"1288490189","typesense_memory_active_bytes":"536870912"}`)
+	follower := newMetricsNode(t, `{"system_disk_used_bytes":1073741824,"typesense_memory_active_bytes":268435456}`)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	adapter := &TypesenseAdapter{
+		apiKey:       "typesense-key",
+		httpClient:   http.DefaultClient,
+		metricsNodes: metricsNodes(leader+"/", []string{follower, leader, unreachable.URL}),
+		logger:       slog.New(slog.DiscardHandler),
+	}
+
+	diskBytes, memoryBytes := adapter.clusterSize(context.Background())
+
+	assert.Equal(t, int64(1288490189), diskBytes, "replicas hold the same data, so the largest disk is reported")
+	assert.Equal(t, int64(805306368), memoryBytes, "memory is summed over the nodes")
+	assert.Equal(t, "1.2 GB", search.FormatBytes(diskBytes))
+	assert.Equal(t, "768.0 MB", search.FormatBytes(memoryBytes))
+}
+
+func TestClusterSizeIgnoresMissingMetrics(t *testing.T) {
+	adapter := &TypesenseAdapter{
+		apiKey:       "typesense-key",
+		httpClient:   http.DefaultClient,
+		metricsNodes: []string{newMetricsNode(t, `{"system_disk_used_bytes":"n/a"}`)},
+		logger:       slog.New(slog.DiscardHandler),
+	}
+
+	diskBytes, memoryBytes := adapter.clusterSize(context.Background())
+
+	assert.Zero(t, diskBytes)
+	assert.Zero(t, memoryBytes)
+}
+
+func TestMetricsNodes(t *testing.T) {
+	assert.Equal(t,
+		[]string{"http://typesense-1:8108", "http://typesense-2:8108"},
+		metricsNodes("http://typesense-1:8108/", []string{" http://typesense-2:8108 ", "http://typesense-1:8108", ""}))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", search.FormatBytes(0))
+	assert.Equal(t, "1023 B", search.FormatBytes(1023))
+	assert.Equal(t, "1.0 KB", search.FormatBytes(1024))
+	assert.Equal(t, "1.5 MB", search.FormatBytes(1572864))
+}
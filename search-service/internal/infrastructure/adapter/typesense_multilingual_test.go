@@ -0,0 +1,186 @@
+package adapter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+func newMultilingualTestAdapter() *TypesenseAdapter {
+	adapter := &TypesenseAdapter{languages: []search.Language{
+		{Code: "es"},
+		{Code: "ja", Locale: "ja"},
+		{Code: "ar", Locale: "ar"},
+	}}
+	adapter.ApplyTuning(search.DefaultTuning())
+	adapter.nameInfix.Store(true)
+	return adapter
+}
+
+// multilingualCorpus indexes hotels with Japanese and Arabic translated names.
+func multilingualCorpus(t *testing.T, adapter *TypesenseAdapter) []TypesenseDocument {
+	t.Helper()
+	hotels := []*hotel.Hotel{
+		{HotelID: 1, Name: "Tokyo Station Hotel", Rating: 4.5, Translations: []hotel.Translation{
+			{Lang: "ja", Name: "東京ステーションホテル"},
+			{Lang: "es", Name: "Hotel Estación de Tokio"},
+		}},
+		{HotelID: 2, Name: "Kyoto Garden Inn", Rating: 4.7, Translations: []hotel.Translation{
+			{Lang: "ja", Name: "京都ガーデンイン"},
+		}},
+		{HotelID: 3, Name: "Palace Hotel Dubai", Rating: 4.9, Translations: []hotel.Translation{
+			{Lang: "ar", Name: "فندق القصر دبي"},
+		}},
+		{HotelID: 4, Name: "Marina Suites", Rating: 4.2, Translations: []hotel.Translation{
+			{Lang: "ar", Name: "أجنحة المارينا"},
+			{Lang: "fr", Name: "Suites de la Marina"},
+		}},
+	}
+
+	documents := make([]TypesenseDocument, len(hotels))
+	for i, h := range hotels {
+		documents[i] = *adapter.convertHotelToDocument(h)
+	}
+	return documents
+}
+
+func TestRelevanceProfilePrefixAndInfixFollowQueryBy(t *testing.T) {
+	tests := []struct {
+		name           string
+		adapter        *TypesenseAdapter
+		expectedFields string
+		expectedPrefix string
+		expectedInfix  string
+	}{
+		{
+			name:           "no languages",
+			adapter:        newRelevanceTestAdapter(),
+			expectedFields: "name,description,important_info",
+			expectedPrefix: "true,false,false",
+		},
+		{
+			name:           "translated names",
+			adapter:        newMultilingualTestAdapter(),
+			expectedFields: "name,description,important_info,name_es,name_ja,name_ar",
+			expectedPrefix: "true,false,false,true,true,true",
+			expectedInfix:  "fallback,off,off,off,off,off",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := search.Params{Query: "hotel"}
+			require.NoError(t, params.Validate())
+			searchParams := tt.adapter.buildSearchParams(params)
+
+			fieldCount := len(strings.Split(searchParams.QueryBy, ","))
+			assert.Equal(t, tt.expectedFields, searchParams.QueryBy)
+			assert.Len(t, strings.Split(*searchParams.QueryByWeights, ","), fieldCount)
+			assert.Equal(t, tt.expectedPrefix, *searchParams.Prefix)
+			if tt.expectedInfix == "" {
+				assert.Nil(t, searchParams.Infix, "infix needs a collection indexing name infixes")
+				return
+			}
+			assert.Equal(t, tt.expectedInfix, *searchParams.Infix)
+		})
+	}
+}
+
+func TestLanguageFieldsUseTheLanguageLocale(t *testing.T) {
+	fields := newMultilingualTestAdapter().languageFields()
+	require.Len(t, fields, 3)
+
+	assert.Equal(t, "name_es", fields[0].Name)
+	assert.Nil(t, fields[0].Locale, "Latin languages keep the default tokenizer")
+	assert.Equal(t, "name_ja", fields[1].Name)
+	assert.Equal(t, "ja", *fields[1].Locale)
+	assert.Equal(t, "name_ar", fields[2].Name)
+	assert.Equal(t, "ar", *fields[2].Locale)
+	for _, field := range fields {
+		assert.True(t, *field.Optional, "hotels without a translation omit %s", field.Name)
+	}
+}
+
+func TestConvertHotelToDocumentKeepsJapaneseAndArabicNames(t *testing.T) {
+	adapter := newMultilingualTestAdapter()
+	documents := multilingualCorpus(t, adapter)
+
+	data, err := json.Marshal(documents[0])
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "東京ステーションホテル", fields["name_ja"])
+	assert.Equal(t, "Hotel Estación de Tokio", fields["name_es"], "diacritics are indexed as written")
+
+	data, err = json.Marshal(documents[3])
+	require.NoError(t, err)
+	fields = nil
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "أجنحة المارينا", fields["name_ar"])
+	assert.NotContains(t, fields, "name_fr", "only configured languages are indexed")
+}
+
+func TestJapaneseAndArabicNameSearch(t *testing.T) {
+	adapter := newMultilingualTestAdapter()
+	documents := multilingualCorpus(t, adapter)
+
+	tests := []struct {
+		query    string
+		expected []int64
+	}{
+		{query: "東京", expected: []int64{1}},
+		{query: "ホテル", expected: []int64{1}},
+		{query: "京都", expected: []int64{2}},
+		{query: "فندق", expected: []int64{3}},
+		{query: "المارينا", expected: []int64{4}},
+		{query: "Marina", expected: []int64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			params := search.Params{Query: tt.query}
+			require.NoError(t, params.Validate())
+			searchParams := adapter.buildSearchParams(params)
+			assert.Equal(t, tt.query, searchParams.Q, "queries in non-Latin scripts are sent unchanged")
+
+			assert.Equal(t, tt.expected, hotelIDs(searchDocuments(t, documents, searchParams)))
+		})
+	}
+}
+
+func TestSuggestionsKeepJapaneseAndArabicNames(t *testing.T) {
+	adapter := newMultilingualTestAdapter()
+	documents := multilingualCorpus(t, adapter)
+
+	tests := []struct {
+		name         string
+		document     TypesenseDocument
+		query        string
+		expectedText string
+		expectedLang string
+	}{
+		{name: "Japanese prefix", document: documents[0], query: "東京", expectedText: "東京ステーションホテル", expectedLang: "ja"},
+		{name: "Japanese substring", document: documents[0], query: "ステーション", expectedText: "東京ステーションホテル", expectedLang: "ja"},
+		{name: "Arabic word", document: documents[2], query: "القصر", expectedText: "فندق القصر دبي", expectedLang: "ar"},
+		{name: "Latin query", document: documents[2], query: "Palace", expectedText: "Palace Hotel Dubai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion := adapter.convertHitToSuggestion(tt.document, tt.query)
+			require.NotNil(t, suggestion)
+			assert.Equal(t, tt.expectedText, suggestion.Text)
+			assert.Greater(t, suggestion.Score, 0.5, "the name contains the query")
+			if tt.expectedLang == "" {
+				assert.NotContains(t, suggestion.Metadata, "lang")
+				return
+			}
+			assert.Equal(t, tt.expectedLang, suggestion.Metadata["lang"])
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package adapter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+// relevanceCorpus holds an exact-name hotel among higher-rated hotels matching part of its name.
+func relevanceCorpus() []TypesenseDocument {
+	return []TypesenseDocument{
+		{HotelID: 1, Name: "Hotel Le Meurice", Description: "Palace facing the Tuileries Garden.", Rating: 4.3},
+		{HotelID: 2, Name: "Hotel Le Marais", Description: "Boutique hotel in the Marais.", Rating: 4.9},
+		{HotelID: 3, Name: "Le Meurice Garden Hotel", Description: "Quiet rooms near the Louvre.", Rating: 4.8},
+		{HotelID: 4, Name: "Grand Hotel du Louvre", Description: "A short walk from Hotel Le Meurice.", Rating: 4.7},
+		{HotelID: 5, Name: "Meurice Suites", Description: "Apartments with a kitchenette.", Rating: 4.6},
+	}
+}
+
+// searchDocuments returns the documents matching searchParams in the order Typesense ranks them.
+// Text matching is a simplified model of the engine: quoted phrases must appear verbatim in one of
+// the query_by fields, and a document scores by the field matching the most query tokens, exact
+// field matches first when prioritize_exact_match is set, then by the field weight. A token of a
+// script written without spaces matches anywhere in a field, as the locale tokenizer segments it.
+func searchDocuments(t *testing.T, documents []TypesenseDocument, searchParams *api.SearchCollectionParams) []TypesenseDocument {
+	t.Helper()
+
+	fields := strings.Split(searchParams.QueryBy, ",")
+	weights := make([]int, len(fields))
+	if searchParams.QueryByWeights != nil {
+		for i, weight := range strings.Split(*searchParams.QueryByWeights, ",") {
+			weights[i], _ = strconv.Atoi(weight)
+		}
+	}
+	prioritizeExact := searchParams.PrioritizeExactMatch != nil && *searchParams.PrioritizeExactMatch
+	parsed := search.ParseQuery(searchParams.Q)
+	queryTokens := tokenize(strings.Join(append(parsed.Phrases, parsed.Terms...), " "))
+
+	textMatch := make(map[int64]float64)
+	var matched []TypesenseDocument
+	for _, document := range documents {
+		values := map[string]string{
+			"name":           document.Name,
+			"description":    document.Description,
+			"important_info": document.ImportantInfo,
+		}
+		for lang, name := range document.TranslatedNames {
+			values[search.Language{Code: lang}.NameField()] = name
+		}
+		if !containsPhrases(values, fields, parsed.Phrases) {
+			continue
+		}
+
+		// Like Typesense, query tokens may match in different fields: the tokens matched across
+		// all fields rank first, then the weight of the best field.
+		matchedTokens := make(map[string]bool)
+		best := 0.0
+		for i, field := range fields {
+			fieldTokens := tokenize(values[field])
+			matches := 0
+			for _, token := range queryTokens {
+				if slices.Contains(fieldTokens, token) || !search.IsSpaceDelimited(token) && strings.Contains(values[field], token) {
+					matchedTokens[token] = true
+					matches++
+				}
+			}
+			if matches == 0 {
+				continue
+			}
+			score := float64(weights[i])
+			if prioritizeExact && strings.Join(fieldTokens, " ") == strings.Join(queryTokens, " ") {
+				score += 50
+			}
+			best = max(best, score)
+		}
+		if len(matchedTokens) > 0 {
+			textMatch[document.HotelID] = float64(len(matchedTokens)*100) + best
+			matched = append(matched, document)
+		}
+	}
+
+	sortDocuments(t, matched, *searchParams.SortBy, textMatch)
+	return matched
+}
+
+func containsPhrases(values map[string]string, fields, phrases []string) bool {
+	for _, phrase := range phrases {
+		found := false
+		for _, field := range fields {
+			if strings.Contains(" "+strings.Join(tokenize(values[field]), " ")+" ", " "+strings.Join(tokenize(phrase), " ")+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func tokenize(text string) []string {
+	return strings.Fields(strings.ToLower(strings.ReplaceAll(text, ".", " ")))
+}
+
+func hotelIDs(documents []TypesenseDocument) []int64 {
+	ids := make([]int64, len(documents))
+	for i, document := range documents {
+		ids[i] = document.HotelID
+	}
+	return ids
+}
+
+func newRelevanceTestAdapter() *TypesenseAdapter {
+	adapter := &TypesenseAdapter{}
+	adapter.ApplyTuning(search.DefaultTuning())
+	return adapter
+}
+
+func TestRelevanceProfileRanksExactNameFirst(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int64
+	}{
+		{
+			name:     "unquoted exact name",
+			query:    "Hotel Le Meurice",
+			expected: []int64{1, 3, 4, 2, 5},
+		},
+		{
+			name:     "quoted exact name",
+			query:    `"Hotel Le Meurice"`,
+			expected: []int64{1, 4},
+		},
+		{
+			name:     "quoted name with extra whitespace",
+			query:    `  "Hotel   Le Meurice" `,
+			expected: []int64{1, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := search.Params{Query: tt.query}
+			require.NoError(t, params.Validate())
+
+			searchParams := adapter.buildSearchParams(params)
+			assert.Equal(t, "_text_match:desc,rating:desc,hotel_id:asc", *searchParams.SortBy,
+				"a text query without sort_by sorts by text match, then rating")
+
+			assert.Equal(t, tt.expected, hotelIDs(searchDocuments(t, relevanceCorpus(), searchParams)))
+		})
+	}
+}
+
+func TestRelevanceProfileTranslatesQuotedPhrases(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+
+	params := search.Params{Query: `spa "Hotel  Le Meurice" paris "x"`}
+	require.NoError(t, params.Validate())
+	searchParams := adapter.buildSearchParams(params)
+
+	assert.Equal(t, `"Hotel Le Meurice" spa paris x`, searchParams.Q)
+	assert.Equal(t, "name,description,important_info", searchParams.QueryBy)
+	assert.Equal(t, "4,1,0", *searchParams.QueryByWeights, "name weighs more than description")
+	require.NotNil(t, searchParams.PrioritizeExactMatch)
+	assert.True(t, *searchParams.PrioritizeExactMatch)
+}
+
+func TestClassicProfileKeepsRatingOrder(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+
+	params := search.Params{Query: "Hotel Le Meurice", RankingProfile: search.RankingProfileClassic}
+	require.NoError(t, params.Validate())
+	searchParams := adapter.buildSearchParams(params)
+
+	assert.Equal(t, "rating:desc,hotel_id:asc", *searchParams.SortBy)
+	assert.Equal(t, `Hotel Le Meurice`, searchParams.Q)
+	assert.Nil(t, searchParams.PrioritizeExactMatch)
+	assert.Equal(t, []int64{2, 3, 4, 5, 1}, hotelIDs(searchDocuments(t, relevanceCorpus(), searchParams)))
+
+	// An explicit sort_by still applies under the relevance profile.
+	params = search.Params{Query: "Hotel Le Meurice", SortBy: "rating"}
+	require.NoError(t, params.Validate())
+	assert.Equal(t, "rating:desc,hotel_id:asc", *adapter.buildSearchParams(params).SortBy)
+}
+
+func TestApplyTuningChangesRankingProfileWeights(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+	params := search.Params{Query: "Hotel Le Meurice"}
+	require.NoError(t, params.Validate())
+
+	tuning := search.DefaultTuning()
+	tuning.RankingProfiles[search.RankingProfileRelevance] = search.RankingProfileSettings{QueryByWeights: "6,2"}
+	adapter.ApplyTuning(tuning)
+	searchParams := adapter.buildSearchParams(params)
+	assert.Equal(t, "6,2,1", *searchParams.QueryByWeights)
+	assert.Nil(t, searchParams.PrioritizeExactMatch)
+
+	adapter.ApplyTuning(search.DefaultTuning())
+	searchParams = adapter.buildSearchParams(params)
+	assert.Equal(t, "4,1,0", *searchParams.QueryByWeights)
+	require.NotNil(t, searchParams.PrioritizeExactMatch)
+	assert.True(t, *searchParams.PrioritizeExactMatch)
+}
+
+func TestImportantInfoMatchRanksWithNameMatch(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+	documents := []TypesenseDocument{
+		{HotelID: 1, Name: "Family Cove", Description: "A serenity spa, adults only after 8pm", Rating: 4.9},
+		{HotelID: 2, Name: "Serenity Palms", Description: "Beachfront villas", ImportantInfo: "Adults only. No pets allowed.", Rating: 4.1},
+		{HotelID: 3, Name: "Harbour Lodge", ImportantInfo: "Adults only", Rating: 5},
+		{HotelID: 4, Name: "Serenity Inn", Description: "Family rooms", Rating: 3.5},
+	}
+
+	params := search.Params{Query: "serenity adults only"}
+	require.NoError(t, params.Validate())
+	searchParams := adapter.buildSearchParams(params)
+	require.Contains(t, strings.Split(searchParams.QueryBy, ","), "important_info")
+	assert.Len(t, strings.Split(*searchParams.Prefix, ","), len(strings.Split(searchParams.QueryBy, ",")),
+		"Typesense rejects a search without one prefix value per query_by field")
+
+	assert.Equal(t, []int64{2, 1, 3, 4}, hotelIDs(searchDocuments(t, documents, searchParams)),
+		"a policy match with a name match outranks the same words in the description")
+}
+
+func TestImportantInfoWeighsLessThanDescription(t *testing.T) {
+	adapter := newRelevanceTestAdapter()
+	documents := []TypesenseDocument{
+		{HotelID: 1, Name: "Harbour Lodge", ImportantInfo: "Adults only. Check-in from 3pm.", Rating: 5},
+		{HotelID: 2, Name: "Family Cove", Description: "Adults only pool", Rating: 3},
+		{HotelID: 3, Name: "Adults Only Retreat", Rating: 2},
+	}
+
+	params := search.Params{Query: "adults only"}
+	require.NoError(t, params.Validate())
+	searchParams := adapter.buildSearchParams(params)
+
+	assert.Equal(t, []int64{3, 2, 1}, hotelIDs(searchDocuments(t, documents, searchParams)))
+}
+
+func TestBuildQueryBy(t *testing.T) {
+	tests := []struct {
+		name            string
+		fields          []string
+		weights         map[string]int
+		expectedQueryBy string
+		expectedWeights string
+	}{
+		{
+			name:            "weighted fields",
+			fields:          []string{"name", "description", "important_info"},
+			weights:         map[string]int{"name": 5, "description": 3, "important_info": 1},
+			expectedQueryBy: "name,description,important_info",
+			expectedWeights: "5,3,1",
+		},
+		{
+			name:            "unweighted field gets the lowest weight",
+			fields:          []string{"name", "name_es"},
+			weights:         map[string]int{"name": 5},
+			expectedQueryBy: "name,name_es",
+			expectedWeights: "5,0",
+		},
+		{
+			name:            "no weights",
+			fields:          []string{"name", "city", "country"},
+			expectedQueryBy: "name,city,country",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryBy, weights := buildQueryBy(tt.fields, tt.weights)
+			assert.Equal(t, tt.expectedQueryBy, queryBy)
+			assert.Equal(t, tt.expectedWeights, weights)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/typesense/typesense-go/typesense/api/pointer"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+func (t *TypesenseAdapter) ListSynonyms(_ context.Context) ([]search.Synonym, error) {
+	existing, err := t.client.Collection(t.collectionName).Synonyms().Retrieve()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list synonyms: %w", err)
+	}
+
+	synonyms := make([]search.Synonym, 0, len(existing))
+	for _, synonym := range existing {
+		if synonym == nil || synonym.Id == nil {
+			continue
+		}
+		converted := search.Synonym{ID: *synonym.Id, Synonyms: synonym.Synonyms}
+		if synonym.Root != nil {
+			converted.Root = *synonym.Root
+		}
+		synonyms = append(synonyms, converted)
+	}
+
+	return synonyms, nil
+}
+
+// ReplaceSynonyms upserts synonyms and then deletes the ones that are no longer wanted, so a
+// failure half way never leaves the collection without synonyms it had before.
+func (t *TypesenseAdapter) ReplaceSynonyms(ctx context.Context, synonyms []search.Synonym) error {
+	existing, err := t.ListSynonyms(ctx)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(synonyms))
+	for _, synonym := range synonyms {
+		wanted[synonym.ID] = true
+
+		schema := &api.SearchSynonymSchema{Synonyms: synonym.Synonyms}
+		if synonym.Root != "" {
+			schema.Root = pointer.String(synonym.Root)
+		}
+		if _, err := t.client.Collection(t.collectionName).Synonyms().Upsert(synonym.ID, schema); err != nil {
+			return fmt.Errorf("failed to upsert synonym %s: %w", synonym.ID, err)
+		}
+	}
+
+	for _, synonym := range existing {
+		if wanted[synonym.ID] {
+			continue
+		}
+		if _, err := t.client.Collection(t.collectionName).Synonym(synonym.ID).Delete(); err != nil {
+			return fmt.Errorf("failed to delete synonym %s: %w", synonym.ID, err)
+		}
+	}
+
+	t.logger.Info("Replaced search synonyms", "count", len(synonyms), "previous", len(existing))
+	return nil
+}
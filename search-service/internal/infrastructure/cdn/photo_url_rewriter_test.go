@@ -0,0 +1,72 @@
+package cdn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPhotoURLs = []string{
+	"https://static.cupid.travel/hotels/1641879/1.jpg",
+	"https://static.cupid.travel/hotels/hd/1.jpg?w=2048&h=1536",
+	"https://static.cupid.travel/hotels/1.jpg?sig=origin&v=2",
+	"https://static.cupid.travel/hotels/Hôtel de la Plage/façade 1.jpg",
+	"https://static.cupid.travel/hotels/1.jpg#main",
+}
+
+func TestSignedURLsDecodeToTheOriginalURL(t *testing.T) {
+	for _, baseURL := range []string{"https://cdn.example.com/proxy?url=", "https://cdn.example.com/"} {
+		rewriter := NewCDNPhotoURLRewriter(CDNConfig{BaseURL: baseURL, SigningKey: "secret"})
+		for _, originalURL := range testPhotoURLs {
+			rewritten := rewriter.Rewrite(originalURL)
+
+			assert.True(t, strings.HasPrefix(rewritten, baseURL), rewritten)
+			decoded, ok := rewriter.Verify(rewritten)
+			assert.True(t, ok, rewritten)
+			assert.Equal(t, originalURL, decoded)
+		}
+	}
+}
+
+func TestUnsignedURLsDecodeToTheOriginalURL(t *testing.T) {
+	rewriter := NewCDNPhotoURLRewriter(CDNConfig{BaseURL: "https://cdn.example.com/proxy?url="})
+	for _, originalURL := range testPhotoURLs {
+		rewritten := rewriter.Rewrite(originalURL)
+
+		assert.NotContains(t, rewritten, "&sig=")
+		decoded, ok := rewriter.Verify(rewritten)
+		assert.True(t, ok, rewritten)
+		assert.Equal(t, originalURL, decoded)
+	}
+}
+
+func TestVerifyRejectsURLsTheServiceDidNotIssue(t *testing.T) {
+	rewriter := NewCDNPhotoURLRewriter(CDNConfig{BaseURL: "https://cdn.example.com/proxy?url=", SigningKey: "secret"})
+	rewritten := rewriter.Rewrite(testPhotoURLs[1])
+	otherKey := NewCDNPhotoURLRewriter(CDNConfig{BaseURL: "https://cdn.example.com/proxy?url=", SigningKey: "other"})
+	signature := rewritten[strings.LastIndex(rewritten, "sig=")+len("sig="):]
+
+	tests := map[string]string{
+		"tampered photo URL":   strings.Replace(rewritten, "hd", "sd", 1),
+		"tampered signature":   strings.TrimSuffix(rewritten, signature) + strings.Repeat("0", len(signature)),
+		"signature not in hex": strings.TrimSuffix(rewritten, signature) + "zz",
+		"missing signature":    strings.TrimSuffix(rewritten, "&sig="+signature),
+		"signed with another":  otherKey.Rewrite(testPhotoURLs[1]),
+		"another CDN":          strings.Replace(rewritten, "cdn.example.com", "cdn.example.org", 1),
+	}
+	for name, url := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ok := rewriter.Verify(url)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestRewriteKeepsEmptyAndCDNURLs(t *testing.T) {
+	rewriter := NewCDNPhotoURLRewriter(CDNConfig{BaseURL: "https://cdn.example.com/proxy?url=", SigningKey: "secret"})
+	rewritten := rewriter.Rewrite(testPhotoURLs[0])
+
+	assert.Empty(t, rewriter.Rewrite(""))
+	assert.Equal(t, rewritten, rewriter.Rewrite(rewritten), "a URL is not rewritten twice")
+}
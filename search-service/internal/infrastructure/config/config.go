@@ -2,12 +2,77 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+const defaultMaxConcurrentRequests = 100
+
+const defaultSlowRequestThreshold = 500 * time.Millisecond
+
+const minSessionSecretLength = 32
+
+const defaultAuthTokenURL = "/auth/token"
+
+const (
+	defaultServerTimeout      = 30 * time.Second
+	defaultServerIdleTimeout  = 120 * time.Second
+	defaultMaxOpenConnections = 25
+	defaultMaxIdleConnections = 5
+	defaultSyncBatchSize      = 100
+
+	defaultSyncHistoryRetentionDays = 30
+	defaultNewHotelsMaxDays         = 90
+	defaultNewHotelsCacheMaxAge     = time.Hour
+
+	defaultImportBatchSize       = 100
+	defaultImportMaxPayloadBytes = 4 << 20
+)
+
+// defaultOrchestratorTimeout keeps the sync stats responsive when the orchestrator is slow.
+const defaultOrchestratorTimeout = 2 * time.Second
+
+const (
+	defaultReviewArchiveKeepNewest = 200
+	defaultReviewArchiveMinAgeDays = 365
+	defaultReviewArchiveBatchSize  = 100
+)
+
+const defaultMaxResponseBytes = 2 << 20
+
+const (
+	defaultHotelCacheMaxAge     = time.Hour
+	defaultReferenceCacheMaxAge = 15 * time.Minute
+)
+
+const (
+	defaultLocalCacheMaxEntries = 1000
+	defaultLocalCacheTTL        = 5 * time.Second
+)
+
+const (
+	defaultTrendingBucketTTL  = 24 * time.Hour
+	defaultTrendingMinSamples = 50
+)
+
+// defaultLocalCacheKeyPrefixes are the hotel detail and search result keys.
+var defaultLocalCacheKeyPrefixes = []string{"hotel:", "search:"}
+
+// defaultCollectionLimits and maxCollectionLimits cap the embedded collections of a hotel
+// detail response when the config leaves them unset.
+var (
+	defaultCollectionLimits = CollectionLimitsConfig{Photos: 50, Rooms: 30, Reviews: 50, Translations: 20}
+	maxCollectionLimits     = CollectionLimitsConfig{Photos: 500, Rooms: 200, Reviews: 500, Translations: 50}
 )
 
 type Config struct {
@@ -16,7 +81,29 @@ type Config struct {
 	Redis     RedisConfig     `mapstructure:"redis"`
 	Typesense TypesenseConfig `mapstructure:"typesense"`
 	CupidAPI  CupidAPIConfig  `mapstructure:"cupid_api"`
-	Sync      SyncConfig      `mapstructure:"sync"`
+	// Orchestrator is the fetcher orchestrator whose queue backlog is shown in the sync stats.
+	Orchestrator OrchestratorConfig `mapstructure:"orchestrator"`
+	Sync         SyncConfig         `mapstructure:"sync"`
+	Results      ResultsConfig      `mapstructure:"results"`
+	Tuning       TuningConfig       `mapstructure:"tuning"`
+	// Languages are the translation languages whose hotel names are indexed for search.
+	Languages []LanguageConfig `mapstructure:"languages"`
+
+	ReviewArchive ReviewArchiveConfig `mapstructure:"review_archive"`
+
+	ResponseLimits ResponseLimitsConfig `mapstructure:"response_limits"`
+
+	LocalCache LocalCacheConfig `mapstructure:"local_cache"`
+
+	Trending TrendingConfig `mapstructure:"trending"`
+
+	// FacilityAliases extends the facility taxonomy, mapping raw facility names to slugs. It is
+	// read from the top-level facility_aliases key shared with the workers.
+	FacilityAliases map[string]string `mapstructure:"-"`
+
+	// SourcePriorities ranks the sources writing hotels, lowest first. It is read from the
+	// top-level source_priorities key shared with the workers.
+	SourcePriorities entities.SourcePriorities `mapstructure:"-"`
 }
 
 type ServerConfig struct {
@@ -27,6 +114,55 @@ type ServerConfig struct {
 	IdleTimeout    time.Duration `mapstructure:"idle_timeout"`
 	EnableCORS     bool          `mapstructure:"enable_cors"`
 	TrustedProxies []string      `mapstructure:"trusted_proxies"`
+	EnablePprof    bool          `mapstructure:"enable_pprof"`
+
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+
+	// SlowRequestThreshold is the duration above which requests are logged in detail.
+	SlowRequestThreshold time.Duration `mapstructure:"slow_request_threshold"`
+
+	// EnableFavorites serves the guest favorites API. SessionSecret signs the session cookie
+	// that identifies guests.
+	EnableFavorites bool   `mapstructure:"enable_favorites"`
+	SessionSecret   string `mapstructure:"session_secret"`
+
+	// ExposeDataSources includes data_sources, the source of each field group, in hotel
+	// details. It is always served by the admin sources endpoint.
+	ExposeDataSources bool `mapstructure:"expose_data_sources"`
+
+	// ValidateRequests rejects requests whose parameters do not match the OpenAPI document.
+	ValidateRequests bool `mapstructure:"validate_requests"`
+
+	CacheControl CacheControlConfig `mapstructure:"cache_control"`
+
+	// CDNBaseURL serves hotel photos through a CDN by prefixing it to their escaped URL, such as
+	// https://cdn.example.com/proxy?url=. CDNSigningKey signs the rewritten URLs.
+	CDNBaseURL    string `mapstructure:"cdn_base_url"`
+	CDNSigningKey string `mapstructure:"cdn_signing_key"`
+
+	// AuthTokenURL is the POST /auth/token endpoint issuing the Bearer tokens of the admin
+	// routes, which /swagger/token redirects to.
+	AuthTokenURL string `mapstructure:"auth_token_url"`
+
+	// UsageAPIKeys are the API keys issued to partners, whose usage is reported per key. Requests
+	// with any other key are counted as anonymous.
+	UsageAPIKeys []string `mapstructure:"usage_api_keys"`
+}
+
+// CacheControlConfig sets the Cache-Control of the public endpoints by how volatile their data
+// is: search results and suggestions, hotel details, and reference data such as facets,
+// facilities and trending lists. Admin, health and debug responses are never cached.
+type CacheControlConfig struct {
+	Search    CachePolicyConfig `mapstructure:"search"`
+	Hotel     CachePolicyConfig `mapstructure:"hotel"`
+	Reference CachePolicyConfig `mapstructure:"reference"`
+}
+
+// CachePolicyConfig is how long clients cache a response. SharedMaxAge, when set, lets shared
+// caches such as a trusted CDN keep it for that long, which makes the response public.
+type CachePolicyConfig struct {
+	MaxAge       time.Duration `mapstructure:"max_age"`
+	SharedMaxAge time.Duration `mapstructure:"shared_max_age"`
 }
 
 type DatabaseConfig struct {
@@ -58,12 +194,43 @@ type TypesenseConfig struct {
 	ApiKey         string `mapstructure:"api_key"`
 	Host           string `mapstructure:"host"`
 	CollectionName string `mapstructure:"collection_name"`
+	// Nodes lists the other nodes of a Typesense cluster, used to aggregate index stats.
+	Nodes []string `mapstructure:"nodes"`
+
+	Import       TypesenseImportConfig `mapstructure:"import"`
+	LoadShedding LoadSheddingConfig    `mapstructure:"load_shedding"`
+}
+
+type TypesenseImportConfig struct {
+	// BatchSize is the number of documents Typesense processes at a time within an import.
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxPayloadBytes caps the body of one import request; larger imports are split.
+	MaxPayloadBytes int `mapstructure:"max_payload_bytes"`
+}
+
+type LoadSheddingConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	WindowSize       int           `mapstructure:"window_size"`
+	Window           time.Duration `mapstructure:"window"`
+	MinSamples       int           `mapstructure:"min_samples"`
+	MaxErrorRate     float64       `mapstructure:"max_error_rate"`
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+	Cooldown         time.Duration `mapstructure:"cooldown"`
 }
 
 type CupidAPIConfig struct {
 	BaseURL string        `mapstructure:"base_url"`
 	APIKey  string        `mapstructure:"api_key"`
 	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxResponseBytes caps the size of a Cupid API response body. Zero uses the default.
+	MaxResponseBytes int64 `mapstructure:"max_response_bytes"`
+}
+
+// OrchestratorConfig points at the orchestrator gRPC server. An empty Address leaves the fetcher
+// pipeline out of the sync stats.
+type OrchestratorConfig struct {
+	Address string        `mapstructure:"address"`
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 type SyncConfig struct {
@@ -72,6 +239,136 @@ type SyncConfig struct {
 	IncrementalInterval time.Duration `mapstructure:"incremental_interval"`
 	FullSyncInterval    time.Duration `mapstructure:"full_sync_interval"`
 	ConcurrentWorkers   int           `mapstructure:"concurrent_workers"`
+	// AlwaysRun runs the initial and periodic syncs on every replica instead of on the elected
+	// leader only. Meant for single-instance deployments.
+	AlwaysRun     bool          `mapstructure:"always_run"`
+	LeaderLockTTL time.Duration `mapstructure:"leader_lock_ttl"`
+	// DeleteRemovedHotels makes incremental syncs delete the hotels the workers marked
+	// removed_upstream from the search index.
+	DeleteRemovedHotels bool `mapstructure:"delete_removed_hotels"`
+	// HistoryRetentionDays is how long the records of finished syncs are kept in sync_runs.
+	HistoryRetentionDays int `mapstructure:"history_retention_days"`
+}
+
+type ResultsConfig struct {
+	// SnippetLength is the maximum length in characters of description snippets in search results.
+	SnippetLength int `mapstructure:"snippet_length"`
+	// CacheMaxAge is how long a cached search result is served as fresh. For
+	// CacheStaleWhileRevalidate after that it is still served while a background search
+	// refreshes it.
+	CacheMaxAge               time.Duration `mapstructure:"cache_max_age"`
+	CacheStaleWhileRevalidate time.Duration `mapstructure:"cache_stale_while_revalidate"`
+	// NewHotelsMaxDays is the longest window /hotels/new lists and the furthest back a
+	// created_after filter reaches. Hotels of the initial import all share a created_at near
+	// it, so longer windows would list the whole catalog.
+	NewHotelsMaxDays int `mapstructure:"new_hotels_max_days"`
+	// NewHotelsCacheMaxAge is how long the cached /hotels/new results are served as fresh.
+	// Hotels are added at most once per sync, so it is longer than CacheMaxAge.
+	NewHotelsCacheMaxAge time.Duration `mapstructure:"new_hotels_cache_max_age"`
+}
+
+// TuningConfig is the search tuning used until a config bundle is applied at runtime. Unset
+// values keep the built-in defaults.
+type TuningConfig struct {
+	RankingProfiles map[string]RankingProfileConfig `mapstructure:"ranking_profiles"`
+	FacetFields     []string                        `mapstructure:"facet_fields"`
+	FacetMaxValues  int                             `mapstructure:"facet_max_values"`
+	// RefreshInterval is how often bundles applied through other instances are picked up.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+type RankingProfileConfig struct {
+	QueryByWeights       string `mapstructure:"query_by_weights"`
+	PrioritizeExactMatch bool   `mapstructure:"prioritize_exact_match"`
+}
+
+func (c TuningConfig) SearchTuning() search.Tuning {
+	tuning := search.DefaultTuning()
+	for name, profile := range c.RankingProfiles {
+		tuning.RankingProfiles[name] = search.RankingProfileSettings{
+			QueryByWeights:       profile.QueryByWeights,
+			PrioritizeExactMatch: profile.PrioritizeExactMatch,
+		}
+	}
+	if len(c.FacetFields) > 0 {
+		tuning.Facets.Fields = c.FacetFields
+	}
+	tuning.Facets.MaxValues = c.FacetMaxValues
+	return tuning
+}
+
+// LanguageConfig maps a translation language to the Typesense locale that tokenizes it, e.g.
+// "ja" for Japanese. An empty locale uses the default tokenizer for Latin scripts.
+type LanguageConfig struct {
+	Code   string `mapstructure:"code"`
+	Locale string `mapstructure:"locale"`
+}
+
+func (c *Config) SearchLanguages() []search.Language {
+	languages := make([]search.Language, 0, len(c.Languages))
+	for _, language := range c.Languages {
+		languages = append(languages, search.Language{Code: language.Code, Locale: language.Locale})
+	}
+	return languages
+}
+
+// ReviewArchiveConfig is the default review retention. Reviews past the newest KeepNewest of a
+// hotel and older than MinAgeDays are archived. Interval schedules archival; zero only runs it
+// when triggered through the admin API.
+type ReviewArchiveConfig struct {
+	KeepNewest int           `mapstructure:"keep_newest"`
+	MinAgeDays int           `mapstructure:"min_age_days"`
+	BatchSize  int           `mapstructure:"batch_size"`
+	Interval   time.Duration `mapstructure:"interval"`
+}
+
+// ResponseLimitsConfig governs the size of hotel detail responses. Embedded collections are cut
+// to Default items, and a request can raise its limits up to Max. A response still larger than
+// MaxResponseBytes after truncation is logged.
+type ResponseLimitsConfig struct {
+	Default          CollectionLimitsConfig `mapstructure:"default"`
+	Max              CollectionLimitsConfig `mapstructure:"max"`
+	MaxResponseBytes int                    `mapstructure:"max_response_bytes"`
+}
+
+type CollectionLimitsConfig struct {
+	Photos       int `mapstructure:"photos"`
+	Rooms        int `mapstructure:"rooms"`
+	Reviews      int `mapstructure:"reviews"`
+	Translations int `mapstructure:"translations"`
+}
+
+func (c CollectionLimitsConfig) CollectionLimits() hotel.CollectionLimits {
+	return hotel.CollectionLimits{
+		Photos:       c.Photos,
+		Rooms:        c.Rooms,
+		Reviews:      c.Reviews,
+		Translations: c.Translations,
+	}
+}
+
+func (c *CollectionLimitsConfig) validate(report *configcheck.Report, key string, defaults CollectionLimitsConfig) {
+	configcheck.Default(report, key+".photos", &c.Photos, defaults.Photos)
+	configcheck.Default(report, key+".rooms", &c.Rooms, defaults.Rooms)
+	configcheck.Default(report, key+".reviews", &c.Reviews, defaults.Reviews)
+	configcheck.Default(report, key+".translations", &c.Translations, defaults.Translations)
+}
+
+// LocalCacheConfig is the in-process LRU in front of Redis for the hottest hotel and search
+// keys. Entries are kept for TTL, so other instances see an invalidation at most TTL late.
+type LocalCacheConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	MaxEntries  int           `mapstructure:"max_entries"`
+	TTL         time.Duration `mapstructure:"ttl"`
+	KeyPrefixes []string      `mapstructure:"key_prefixes"`
+}
+
+// TrendingConfig buckets the recorded searches per locale for the trending and popular lists.
+// A bucket starts over BucketTTL after its first search, and a locale with fewer than
+// MinSamples searches is served the global list.
+type TrendingConfig struct {
+	BucketTTL  time.Duration `mapstructure:"bucket_ttl"`
+	MinSamples int64         `mapstructure:"min_samples"`
 }
 
 type LoggingConfig struct {
@@ -80,6 +377,8 @@ type LoggingConfig struct {
 	OutputFile string `mapstructure:"output_file"`
 }
 
+// LoadConfig reads the configuration without validating it, so that the caller can report every
+// problem through Validate.
 func LoadConfig() (*Config, error) {
 	var err error
 	if err = gotenv.Load("../.env"); err != nil {
@@ -101,18 +400,23 @@ func LoadConfig() (*Config, error) {
 	if err := viper.UnmarshalKey("search", &config); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	if err := viper.UnmarshalKey("facility_aliases", &config.FacilityAliases); err != nil {
+		return nil, fmt.Errorf("error unmarshaling facility aliases: %w", err)
+	}
+	if err := viper.UnmarshalKey("source_priorities", &config.SourcePriorities); err != nil {
+		return nil, fmt.Errorf("error unmarshaling source priorities: %w", err)
+	}
 
 	expandConfigEnvVars(&config)
 
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
-	}
-
 	return &config, nil
 }
 
 func expandConfigEnvVars(config *Config) {
 	config.Server.Host = os.ExpandEnv(config.Server.Host)
+	config.Server.SessionSecret = os.ExpandEnv(config.Server.SessionSecret)
+	config.Server.CDNBaseURL = os.ExpandEnv(config.Server.CDNBaseURL)
+	config.Server.CDNSigningKey = os.ExpandEnv(config.Server.CDNSigningKey)
 
 	config.Database.Host = os.ExpandEnv(config.Database.Host)
 	config.Database.Username = os.ExpandEnv(config.Database.Username)
@@ -125,9 +429,14 @@ func expandConfigEnvVars(config *Config) {
 
 	config.Typesense.ApiKey = os.ExpandEnv(config.Typesense.ApiKey)
 	config.Typesense.Host = os.ExpandEnv(config.Typesense.Host)
+	for i, node := range config.Typesense.Nodes {
+		config.Typesense.Nodes[i] = os.ExpandEnv(node)
+	}
 
 	config.CupidAPI.BaseURL = os.ExpandEnv(config.CupidAPI.BaseURL)
 	config.CupidAPI.APIKey = os.ExpandEnv(config.CupidAPI.APIKey)
+
+	config.Orchestrator.Address = os.ExpandEnv(config.Orchestrator.Address)
 }
 
 func (c *DatabaseConfig) DSN() string {
@@ -143,37 +452,158 @@ func (c *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
-func (c *Config) Validate() error {
-	if c.CupidAPI.APIKey == "" {
-		return fmt.Errorf("cupid API key is required")
+// Validate applies the defaults of unset keys and reports them, together with every invalid key.
+func (c *Config) Validate() *configcheck.Report {
+	report := &configcheck.Report{}
+
+	configcheck.Range(report, "search.server.port", c.Server.Port, 1, 65535)
+	configcheck.Default(report, "search.server.read_timeout", &c.Server.ReadTimeout, defaultServerTimeout)
+	configcheck.Default(report, "search.server.write_timeout", &c.Server.WriteTimeout, defaultServerTimeout)
+	configcheck.Default(report, "search.server.idle_timeout", &c.Server.IdleTimeout, defaultServerIdleTimeout)
+	configcheck.Default(report, "search.server.max_concurrent_requests", &c.Server.MaxConcurrentRequests, defaultMaxConcurrentRequests)
+	configcheck.Default(report, "search.server.slow_request_threshold", &c.Server.SlowRequestThreshold, defaultSlowRequestThreshold)
+	if c.Server.EnableFavorites && len(c.Server.SessionSecret) < minSessionSecretLength {
+		report.Errorf("search.server.session_secret", "must be at least %d characters when enable_favorites is set", minSessionSecretLength)
+	}
+	if c.Server.CDNBaseURL != "" && !strings.HasPrefix(c.Server.CDNBaseURL, "http://") && !strings.HasPrefix(c.Server.CDNBaseURL, "https://") {
+		report.Errorf("search.server.cdn_base_url", "must be an http or https URL, got %q", c.Server.CDNBaseURL)
+	}
+	if c.Server.CDNSigningKey != "" && c.Server.CDNBaseURL == "" {
+		report.Errorf("search.server.cdn_signing_key", "requires cdn_base_url")
+	}
+	configcheck.Default(report, "search.server.auth_token_url", &c.Server.AuthTokenURL, defaultAuthTokenURL)
+	if _, err := url.Parse(c.Server.AuthTokenURL); err != nil {
+		report.Errorf("search.server.auth_token_url", "must be a URL: %v", err)
 	}
 
-	if c.CupidAPI.BaseURL == "" {
-		return fmt.Errorf("cupid API base URL is required")
+	report.Required("search.database.host", c.Database.Host)
+	report.Required("search.database.username", c.Database.Username)
+	report.Required("search.database.database", c.Database.Database)
+	configcheck.Default(report, "search.database.port", &c.Database.Port, 5432)
+	configcheck.Range(report, "search.database.port", c.Database.Port, 1, 65535)
+	configcheck.Default(report, "search.database.max_open_connections", &c.Database.MaxOpenConnections, defaultMaxOpenConnections)
+	configcheck.Default(report, "search.database.max_idle_connections", &c.Database.MaxIdleConnections, defaultMaxIdleConnections)
+	if c.Database.MaxIdleConnections > c.Database.MaxOpenConnections {
+		report.Errorf("search.database.max_idle_connections", "must not exceed max_open_connections (%d), got %d",
+			c.Database.MaxOpenConnections, c.Database.MaxIdleConnections)
+	}
+	configcheck.Default(report, "search.database.conn_max_life", &c.Database.ConnMaxLife, time.Hour)
+
+	report.Required("search.redis.host", c.Redis.Host)
+	configcheck.Default(report, "search.redis.port", &c.Redis.Port, 6379)
+	configcheck.Range(report, "search.redis.port", c.Redis.Port, 1, 65535)
+	configcheck.Range(report, "search.redis.database", c.Redis.Database, 0, 15)
+	configcheck.Default(report, "search.redis.pool_size", &c.Redis.PoolSize, 10)
+	configcheck.Default(report, "search.redis.dial_timeout", &c.Redis.DialTimeout, 5*time.Second)
+	configcheck.Default(report, "search.redis.read_timeout", &c.Redis.ReadTimeout, 3*time.Second)
+	configcheck.Default(report, "search.redis.write_timeout", &c.Redis.WriteTimeout, 3*time.Second)
+	configcheck.Default(report, "search.redis.idle_timeout", &c.Redis.IdleTimeout, 5*time.Minute)
+
+	report.Required("search.typesense.api_key", c.Typesense.ApiKey)
+	report.Required("search.typesense.host", c.Typesense.Host)
+	report.Required("search.typesense.collection_name", c.Typesense.CollectionName)
+	configcheck.Default(report, "search.typesense.import.batch_size", &c.Typesense.Import.BatchSize, defaultImportBatchSize)
+	configcheck.Default(report, "search.typesense.import.max_payload_bytes", &c.Typesense.Import.MaxPayloadBytes, defaultImportMaxPayloadBytes)
+	if shedding := c.Typesense.LoadShedding; shedding.Enabled {
+		configcheck.Range(report, "search.typesense.load_shedding.max_error_rate", shedding.MaxErrorRate, 0, 1)
 	}
 
-	if !strings.HasPrefix(c.CupidAPI.BaseURL, "http://") && !strings.HasPrefix(c.CupidAPI.BaseURL, "https://") {
+	report.Required("search.cupid_api.api_key", c.CupidAPI.APIKey)
+	report.Required("search.cupid_api.base_url", c.CupidAPI.BaseURL)
+	if c.CupidAPI.BaseURL != "" && !strings.HasPrefix(c.CupidAPI.BaseURL, "http://") && !strings.HasPrefix(c.CupidAPI.BaseURL, "https://") {
 		c.CupidAPI.BaseURL = "https://" + c.CupidAPI.BaseURL
 	}
+	configcheck.Default(report, "search.cupid_api.timeout", &c.CupidAPI.Timeout, 30*time.Second)
+	if c.CupidAPI.MaxResponseBytes < 0 {
+		report.Errorf("search.cupid_api.max_response_bytes", "must not be negative, got %d", c.CupidAPI.MaxResponseBytes)
+	}
+
+	configcheck.Default(report, "search.orchestrator.timeout", &c.Orchestrator.Timeout, defaultOrchestratorTimeout)
+
+	configcheck.Default(report, "search.sync.batch_size", &c.Sync.BatchSize, defaultSyncBatchSize)
+	if !c.Sync.AlwaysRun {
+		configcheck.Default(report, "search.sync.leader_lock_ttl", &c.Sync.LeaderLockTTL, 30*time.Second)
+		if c.Sync.LeaderLockTTL < 3*time.Second {
+			report.Errorf("search.sync.leader_lock_ttl", "must be at least 3s, got %s", c.Sync.LeaderLockTTL)
+		}
+	}
+	configcheck.Default(report, "search.sync.history_retention_days", &c.Sync.HistoryRetentionDays, defaultSyncHistoryRetentionDays)
+	configcheck.Range(report, "search.sync.history_retention_days", c.Sync.HistoryRetentionDays, 1, 3650)
+	if c.Sync.IncrementalInterval < 0 {
+		report.Errorf("search.sync.incremental_interval", "must not be negative, got %s", c.Sync.IncrementalInterval)
+	}
 
-	if c.Database.Host == "" {
-		return fmt.Errorf("database host is required")
+	configcheck.Default(report, "search.results.snippet_length", &c.Results.SnippetLength, search.DefaultSnippetLength)
+	configcheck.Default(report, "search.results.cache_max_age", &c.Results.CacheMaxAge, 5*time.Minute)
+	configcheck.Default(report, "search.results.cache_stale_while_revalidate", &c.Results.CacheStaleWhileRevalidate, time.Minute)
+	configcheck.Default(report, "search.results.new_hotels_max_days", &c.Results.NewHotelsMaxDays, defaultNewHotelsMaxDays)
+	configcheck.Range(report, "search.results.new_hotels_max_days", c.Results.NewHotelsMaxDays, 1, 3650)
+	configcheck.Default(report, "search.results.new_hotels_cache_max_age", &c.Results.NewHotelsCacheMaxAge, defaultNewHotelsCacheMaxAge)
+
+	// Clients cache search results for as long as the server serves them fresh.
+	configcheck.Default(report, "search.server.cache_control.search.max_age", &c.Server.CacheControl.Search.MaxAge, c.Results.CacheMaxAge)
+	configcheck.Default(report, "search.server.cache_control.hotel.max_age", &c.Server.CacheControl.Hotel.MaxAge, defaultHotelCacheMaxAge)
+	configcheck.Default(report, "search.server.cache_control.reference.max_age", &c.Server.CacheControl.Reference.MaxAge, defaultReferenceCacheMaxAge)
+	for key, policy := range map[string]CachePolicyConfig{
+		"search":    c.Server.CacheControl.Search,
+		"hotel":     c.Server.CacheControl.Hotel,
+		"reference": c.Server.CacheControl.Reference,
+	} {
+		if policy.SharedMaxAge < 0 {
+			report.Errorf("search.server.cache_control."+key+".shared_max_age", "must not be negative, got %s", policy.SharedMaxAge)
+		}
 	}
 
-	if c.Typesense.ApiKey == "" {
-		return fmt.Errorf("typesense API key is required")
+	if err := c.Tuning.SearchTuning().Validate(); err != nil {
+		report.Errorf("search.tuning", "is invalid: %v", err)
+	}
+	configcheck.Default(report, "search.tuning.refresh_interval", &c.Tuning.RefreshInterval, 30*time.Second)
+
+	seenLanguages := make(map[string]bool, len(c.Languages))
+	for i, language := range c.SearchLanguages() {
+		key := fmt.Sprintf("search.languages[%d]", i)
+		if err := language.Validate(); err != nil {
+			report.Errorf(key, "is invalid: %v", err)
+		}
+		if seenLanguages[language.Code] {
+			report.Errorf(key, "duplicates language %q", language.Code)
+		}
+		seenLanguages[language.Code] = true
 	}
 
-	if c.Typesense.Host == "" {
-		return fmt.Errorf("typesense index name is required")
+	configcheck.Default(report, "search.review_archive.keep_newest", &c.ReviewArchive.KeepNewest, defaultReviewArchiveKeepNewest)
+	configcheck.Default(report, "search.review_archive.min_age_days", &c.ReviewArchive.MinAgeDays, defaultReviewArchiveMinAgeDays)
+	configcheck.Default(report, "search.review_archive.batch_size", &c.ReviewArchive.BatchSize, defaultReviewArchiveBatchSize)
+	if c.ReviewArchive.Interval < 0 {
+		report.Errorf("search.review_archive.interval", "must not be negative, got %s", c.ReviewArchive.Interval)
 	}
 
-	if c.Typesense.CollectionName == "" {
-		return fmt.Errorf("typesense index name is required")
+	c.ResponseLimits.Default.validate(report, "search.response_limits.default", defaultCollectionLimits)
+	c.ResponseLimits.Max.validate(report, "search.response_limits.max", maxCollectionLimits)
+	defaults, maxima := c.ResponseLimits.Default, c.ResponseLimits.Max
+	configcheck.Range(report, "search.response_limits.default.photos", defaults.Photos, 1, maxima.Photos)
+	configcheck.Range(report, "search.response_limits.default.rooms", defaults.Rooms, 1, maxima.Rooms)
+	configcheck.Range(report, "search.response_limits.default.reviews", defaults.Reviews, 1, maxima.Reviews)
+	configcheck.Range(report, "search.response_limits.default.translations", defaults.Translations, 1, maxima.Translations)
+	configcheck.Default(report, "search.response_limits.max_response_bytes", &c.ResponseLimits.MaxResponseBytes, defaultMaxResponseBytes)
+
+	if c.LocalCache.Enabled {
+		configcheck.Default(report, "search.local_cache.max_entries", &c.LocalCache.MaxEntries, defaultLocalCacheMaxEntries)
+		configcheck.Default(report, "search.local_cache.ttl", &c.LocalCache.TTL, defaultLocalCacheTTL)
+		if len(c.LocalCache.KeyPrefixes) == 0 {
+			c.LocalCache.KeyPrefixes = defaultLocalCacheKeyPrefixes
+		}
 	}
 
-	if c.Server.Port <= 0 || c.Server.Port > 65535 {
-		return fmt.Errorf("invalid server port: %d", c.Server.Port)
+	configcheck.Default(report, "search.trending.bucket_ttl", &c.Trending.BucketTTL, defaultTrendingBucketTTL)
+	configcheck.Default(report, "search.trending.min_samples", &c.Trending.MinSamples, defaultTrendingMinSamples)
+
+	if _, err := facilities.New(c.FacilityAliases); err != nil {
+		report.Errorf("facility_aliases", "%v", err)
+	}
+	if err := c.SourcePriorities.Validate(); err != nil {
+		report.Errorf("source_priorities", "%v", err)
 	}
-	return nil
+
+	return report
 }
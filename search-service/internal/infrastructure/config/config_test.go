@@ -0,0 +1,80 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/configcheck"
+)
+
+// validConfig returns a configuration with every required key set and the rest left to defaults.
+func validConfig() *Config {
+	return &Config{
+		Server:    ServerConfig{Port: 8080},
+		Database:  DatabaseConfig{Host: "postgres", Username: "hotels", Database: "hotels"},
+		Redis:     RedisConfig{Host: "redis"},
+		Typesense: TypesenseConfig{ApiKey: "key", Host: "http://typesense:8108", CollectionName: "hotels"},
+		CupidAPI:  CupidAPIConfig{APIKey: "key", BaseURL: "content-api.cupid.travel"},
+	}
+}
+
+func TestValidateAppliesDefaultsToAValidConfig(t *testing.T) {
+	c := validConfig()
+
+	report := c.Validate()
+
+	require.NoError(t, report.Err())
+	assert.Equal(t, 5432, c.Database.Port)
+	assert.Equal(t, 6379, c.Redis.Port)
+	assert.Equal(t, defaultServerTimeout, c.Server.ReadTimeout)
+	assert.Equal(t, 5*time.Minute, c.Results.CacheMaxAge)
+	assert.Equal(t, c.Results.CacheMaxAge, c.Server.CacheControl.Search.MaxAge)
+	assert.Equal(t, "https://content-api.cupid.travel", c.CupidAPI.BaseURL)
+	assert.Empty(t, c.LocalCache.KeyPrefixes, "the local cache keeps its defaults while disabled")
+
+	assert.Contains(t, report.Defaults, configcheck.Issue{Key: "search.database.port", Message: "5432"})
+	assert.Contains(t, report.Defaults, configcheck.Issue{Key: "search.sync.leader_lock_ttl", Message: "30s"})
+	assert.NotContains(t, report.Defaults, configcheck.Issue{Key: "search.server.port", Message: "8080"})
+}
+
+func TestValidateReportsEveryProblemAtOnce(t *testing.T) {
+	c := validConfig()
+	c.Server.Port = 70000
+	c.Server.EnableFavorites = true
+	c.Server.SessionSecret = "short"
+	c.Server.CDNBaseURL = "cdn.example.com"
+	c.Database.Host = ""
+	c.Database.MaxOpenConnections = 4
+	c.Database.MaxIdleConnections = 8
+	c.Redis.Database = 16
+	c.Redis.PoolSize = -1
+	c.Typesense.ApiKey = ""
+	c.Sync.LeaderLockTTL = time.Second
+	c.Sync.IncrementalInterval = -time.Minute
+	c.Server.CacheControl.Hotel.SharedMaxAge = -time.Second
+
+	report := c.Validate()
+
+	assert.Equal(t, []configcheck.Issue{
+		{Key: "search.server.port", Message: "must be between 1 and 65535, got 70000"},
+		{Key: "search.server.session_secret", Message: "must be at least 32 characters when enable_favorites is set"},
+		{Key: "search.server.cdn_base_url", Message: `must be an http or https URL, got "cdn.example.com"`},
+		{Key: "search.database.host", Message: "is required"},
+		{Key: "search.database.max_idle_connections", Message: "must not exceed max_open_connections (4), got 8"},
+		{Key: "search.redis.database", Message: "must be between 0 and 15, got 16"},
+		{Key: "search.typesense.api_key", Message: "is required"},
+		{Key: "search.sync.leader_lock_ttl", Message: "must be at least 3s, got 1s"},
+		{Key: "search.sync.incremental_interval", Message: "must not be negative, got -1m0s"},
+		{Key: "search.server.cache_control.hotel.shared_max_age", Message: "must not be negative, got -1s"},
+	}, report.Errors)
+	assert.Contains(t, report.Defaults, configcheck.Issue{Key: "search.redis.pool_size", Message: "10 (was -1)"})
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "search.database.max_idle_connections")
+	assert.Contains(t, out.String(), "\n10 error(s), ")
+	assert.ErrorContains(t, report.Err(), "10 configuration error(s): search.server.port must be between 1 and 65535, got 70000; ")
+}
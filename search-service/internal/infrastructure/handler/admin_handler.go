@@ -0,0 +1,1194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+type AdminHandler struct {
+	responder
+	getHotelByIDUseCase     *usecase.GetHotelByIDUseCase
+	updateHotelUseCase      *usecase.UpdateHotelUseCase
+	hotelChangesUseCase     *usecase.HotelChangesUseCase
+	hotelDuplicatesUseCase  *usecase.HotelDuplicatesUseCase
+	syncHotelsUseCase       *usecase.SyncHotelsUseCase
+	indexBackfillUseCase    *usecase.IndexBackfillUseCase
+	reconcileUseCase        *usecase.ReconcileUseCase
+	reviewArchivalUseCase   *usecase.ReviewArchivalUseCase
+	reviewDedupUseCase      *usecase.ReviewDedupUseCase
+	usageReportUseCase      *usecase.UsageReportUseCase
+	searchConfigUseCase     *usecase.SearchConfigUseCase
+	facilitiesUseCase       *usecase.FacilitiesUseCase
+	facilityBackfillUseCase *usecase.FacilityBackfillUseCase
+}
+
+// NewAdminHandler returns the handler of the /api/v1/admin endpoints: hotel corrections and
+// merges, sync, index maintenance, review archival and dedupe, usage reports and search config.
+func NewAdminHandler(
+	getHotelByIDUseCase *usecase.GetHotelByIDUseCase,
+	updateHotelUseCase *usecase.UpdateHotelUseCase,
+	hotelChangesUseCase *usecase.HotelChangesUseCase,
+	hotelDuplicatesUseCase *usecase.HotelDuplicatesUseCase,
+	syncHotelsUseCase *usecase.SyncHotelsUseCase,
+	indexBackfillUseCase *usecase.IndexBackfillUseCase,
+	reconcileUseCase *usecase.ReconcileUseCase,
+	reviewArchivalUseCase *usecase.ReviewArchivalUseCase,
+	reviewDedupUseCase *usecase.ReviewDedupUseCase,
+	usageReportUseCase *usecase.UsageReportUseCase,
+	searchConfigUseCase *usecase.SearchConfigUseCase,
+	facilitiesUseCase *usecase.FacilitiesUseCase,
+	facilityBackfillUseCase *usecase.FacilityBackfillUseCase,
+	logger *slog.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		responder:               responder{logger: logger},
+		getHotelByIDUseCase:     getHotelByIDUseCase,
+		updateHotelUseCase:      updateHotelUseCase,
+		hotelChangesUseCase:     hotelChangesUseCase,
+		hotelDuplicatesUseCase:  hotelDuplicatesUseCase,
+		syncHotelsUseCase:       syncHotelsUseCase,
+		indexBackfillUseCase:    indexBackfillUseCase,
+		reconcileUseCase:        reconcileUseCase,
+		reviewArchivalUseCase:   reviewArchivalUseCase,
+		reviewDedupUseCase:      reviewDedupUseCase,
+		usageReportUseCase:      usageReportUseCase,
+		searchConfigUseCase:     searchConfigUseCase,
+		facilitiesUseCase:       facilitiesUseCase,
+		facilityBackfillUseCase: facilityBackfillUseCase,
+	}
+}
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	maxPatchBodyBytes     = 64 << 10
+
+	maxSearchConfigBodyBytes = 1 << 20
+
+	defaultUsageReportDays = 30
+)
+
+type CustomSyncOptions struct {
+	usecase.SyncOptions
+}
+
+func (c *CustomSyncOptions) UnmarshalJSON(data []byte) error {
+	type Alias struct {
+		FullSync         bool            `json:"fullSync"`
+		BatchSize        int             `json:"batchSize"`
+		UpdateCacheAfter bool            `json:"updateCacheAfter"`
+		ClearIndexFirst  bool            `json:"clearIndexFirst"`
+		SinceTimestamp   json.RawMessage `json:"sinceTimestamp"`
+	}
+
+	var aux Alias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.FullSync = aux.FullSync
+	c.BatchSize = aux.BatchSize
+	c.UpdateCacheAfter = aux.UpdateCacheAfter
+	c.ClearIndexFirst = aux.ClearIndexFirst
+
+	defaultTime := time.Now().AddDate(0, -1, 0)
+
+	if len(aux.SinceTimestamp) == 0 || string(aux.SinceTimestamp) == "null" {
+		c.SinceTimestamp = defaultTime
+		return nil
+	}
+
+	var timeStr string
+	if err := json.Unmarshal(aux.SinceTimestamp, &timeStr); err == nil {
+		parsedTime, err := parseTimestamp(timeStr)
+		if err != nil {
+			c.SinceTimestamp = defaultTime
+		} else {
+			c.SinceTimestamp = parsedTime
+		}
+		return nil
+	}
+
+	var timestamp int64
+	if err := json.Unmarshal(aux.SinceTimestamp, &timestamp); err == nil {
+		if timestamp > 0 {
+			c.SinceTimestamp = time.Unix(timestamp, 0).UTC()
+		} else {
+			c.SinceTimestamp = defaultTime
+		}
+		return nil
+	}
+
+	c.SinceTimestamp = defaultTime
+	return nil
+}
+
+// TriggerSync manually triggers hotel data synchronization
+// @Summary Trigger manual sync
+// @Description Manually trigger synchronization of hotel data from external sources. When a newer sync starts before this one finishes, this sync stops before its next batch and the result is returned with Superseded set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param options body usecase.SyncOptions false "Synchronization options"
+// @Success 200 {object} APIResponse "Synchronization result with statistics"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/sync [post]
+// CustomSyncOptions wraps SyncOptions to handle unmarshalling
+func (h *AdminHandler) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	var customOptions CustomSyncOptions
+	customOptions.SinceTimestamp = time.Now().AddDate(0, -1, 0)
+
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&customOptions); err != nil {
+			h.logger.Warn("Failed to decode sync options, using defaults", "error", err)
+		}
+	}
+
+	options := customOptions.SyncOptions
+
+	if options.BatchSize == 0 {
+		options.BatchSize = 100
+	}
+	options.UpdateCacheAfter = true
+
+	h.logger.Info("Triggering manual sync",
+		"full_sync", options.FullSync,
+		"batch_size", options.BatchSize,
+		"since_timestamp", options.SinceTimestamp.Format(time.RFC3339),
+		"remote_addr", r.RemoteAddr)
+
+	result, err := h.syncHotelsUseCase.Execute(r.Context(), options)
+	if errors.Is(err, usecase.ErrSyncSuperseded) {
+		h.logger.Info("Manual sync superseded by a newer sync", "indexed_hotels", result.IndexedHotels)
+		err = nil
+	}
+	if err != nil {
+		h.logger.Error("Sync failed", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, result, nil, NoStore)
+}
+
+// PatchHotel applies a manual correction to a hotel
+// @Summary Correct hotel fields
+// @Description Apply a JSON merge patch to a hotel. Only name, description, rating, star_rating, parking, child_allowed and pets_allowed can be changed; hotel_id and cupid_id are rejected. The database, cache and search index are updated, and the old and new value of each changed field is recorded in the hotel's change history with the caller's address
+// @Tags admin
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path integer true "Hotel ID"
+// @Param patch body hotel.Patch true "Fields to change"
+// @Success 200 {object} APIResponse{data=usecase.UpdateHotelResult} "Patched hotel and changed fields"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid or disallowed fields"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
+// @Failure 415 {object} APIResponse "Unsupported Media Type"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels/{id} [patch]
+func (h *AdminHandler) PatchHotel(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+		h.writeErrorResponse(w, "content type must be "+mergePatchContentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchBodyBytes))
+	if err != nil {
+		h.writeErrorResponse(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	patch, err := hotel.ParsePatch(body)
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.updateHotelUseCase.Patch(r.Context(), hotelID, patch, r.RemoteAddr)
+	if err != nil {
+		if errors.Is(err, usecase.ErrHotelNotFound) {
+			h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to patch hotel", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, result, nil, NoStore)
+}
+
+// TriggerChainSync starts an asynchronous re-sync of every hotel in a chain
+// @Summary Trigger chain sync
+// @Description Start a background job that re-indexes every active hotel belonging to the given chain and return its job ID
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param chain_name path string true "Chain name"
+// @Param options body usecase.SyncOptions false "Synchronization options"
+// @Success 200 {object} APIResponse{data=usecase.SyncProgress} "Chain sync job created"
+// @Failure 400 {object} APIResponse "Bad Request"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/chains/{chain_name}/sync [post]
+func (h *AdminHandler) TriggerChainSync(w http.ResponseWriter, r *http.Request) {
+	chain := strings.TrimSpace(mux.Vars(r)["chain_name"])
+	if chain == "" {
+		h.writeErrorResponse(w, "Chain name is required", http.StatusBadRequest)
+		return
+	}
+
+	var customOptions CustomSyncOptions
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&customOptions); err != nil && !errors.Is(err, io.EOF) {
+			h.logger.Warn("Failed to decode sync options, using defaults", "error", err)
+		}
+	}
+
+	progress, err := h.syncHotelsUseCase.StartChainSync(r.Context(), chain, customOptions.SyncOptions)
+	if err != nil {
+		h.logger.Error("Failed to start chain sync", "chain", chain, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Chain sync started",
+		"job_id", progress.JobID,
+		"chain", chain,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, progress, nil, NoStore)
+}
+
+// GetChainSyncProgress returns the progress of a chain sync job
+// @Summary Get chain sync progress
+// @Description Get the status and progress of a chain sync job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param chain_name path string true "Chain name"
+// @Param job_id path string true "Sync job ID"
+// @Success 200 {object} APIResponse{data=usecase.SyncProgress} "Chain sync progress"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/chains/{chain_name}/sync/{job_id} [get]
+func (h *AdminHandler) GetChainSyncProgress(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["job_id"]
+
+	progress, err := h.syncHotelsUseCase.GetSyncProgress(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrSyncJobNotFound) {
+			h.writeErrorResponse(w, "Sync job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get sync progress", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if progress.Chain != strings.TrimSpace(vars["chain_name"]) {
+		h.writeErrorResponse(w, "Sync job not found", http.StatusNotFound)
+		return
+	}
+
+	h.writeSuccessResponse(w, progress, nil, NoStore)
+}
+
+// parseTimeParam returns nil for an empty value. An unparseable value is an ErrInvalidTimeRange
+// rather than a dropped filter, which would widen the search to every hotel.
+func parseTimeParam(key, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := parseTimestamp(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a date, a Unix timestamp or a time relative to now such as -30d, got %q",
+			search.ErrInvalidTimeRange, key, value)
+	}
+	return &parsed, nil
+}
+
+// parseTimestamp parses an absolute time, a Unix timestamp or a time relative to now such as
+// -30d.
+func parseTimestamp(s string) (time.Time, error) {
+	if t, ok := parseRelativeTime(s, time.Now()); ok {
+		return t, nil
+	}
+
+	formats := []string{
+		time.RFC3339,
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05Z",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		"02/01/2006 15:04:05",
+		"02/01/2006",
+		"2006-01-02T15:04:05-07:00",
+	}
+
+	for _, format := range formats {
+		if t, err := time.Parse(format, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if timestamp, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if timestamp > 0 {
+			return time.Unix(timestamp, 0).UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse timestamp: %s", s)
+}
+
+// relativeTimeUnits are the units of relative times, in addition to those of time.ParseDuration.
+var relativeTimeUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseRelativeTime parses a time before now such as -30d, -2w or -12h. It is rounded down to
+// the minute, so repeated searches with the same relative time share a cache entry.
+func parseRelativeTime(s string, now time.Time) (time.Time, bool) {
+	if len(s) < 3 || s[0] != '-' {
+		return time.Time{}, false
+	}
+
+	var ago time.Duration
+	if unit, ok := relativeTimeUnits[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err != nil || n < 0 || n > math.MaxInt64/int(unit) {
+			return time.Time{}, false
+		}
+		ago = time.Duration(n) * unit
+	} else {
+		d, err := time.ParseDuration(s[1:])
+		if err != nil || d < 0 {
+			return time.Time{}, false
+		}
+		ago = d
+	}
+
+	return now.Add(-ago).UTC().Truncate(time.Minute), true
+}
+
+// GetSyncStats returns current synchronization statistics
+// @Summary Get sync statistics
+// @Description Get current statistics about hotel data synchronization: index document count, newest indexed document, PostgreSQL table counts and the lag between them, and a summary of the last recorded sync. Counts are cached for 30 seconds
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param estimate query boolean false "Use planner row estimates instead of exact counts"
+// @Success 200 {object} APIResponse{data=usecase.SyncStats} "Synchronization statistics"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/sync/stats [get]
+func (h *AdminHandler) GetSyncStats(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("Getting sync stats")
+
+	estimate := false
+	if estimateStr := r.URL.Query().Get("estimate"); estimateStr != "" {
+		if val, err := strconv.ParseBool(estimateStr); err == nil {
+			estimate = val
+		}
+	}
+
+	stats, err := h.syncHotelsUseCase.GetSyncStats(r.Context(), estimate)
+	if err != nil {
+		h.logger.Error("Failed to get sync stats", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, stats, nil, NoStore)
+}
+
+// GetSyncHistory lists the recorded syncs with their aggregates
+// @Summary Get sync history
+// @Description List the recorded syncs (initial, periodic and manual) started in the last days, most recent first, with the totals, failed counts and error summaries of each. The summary aggregates every sync of the window: run count, average duration and failure rate, where a run fails when it errors or fails to index a hotel
+// @Tags admin
+// @Produce json
+// @Param days query integer false "Window in days (default and max: sync.history_retention_days)"
+// @Param page query integer false "Page number (default: 1)"
+// @Param limit query integer false "Runs per page (max: 100, default: 20)"
+// @Success 200 {object} APIResponse{data=usecase.SyncHistory,meta=object} "Sync history"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/sync/history [get]
+func (h *AdminHandler) GetSyncHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	days, _ := strconv.Atoi(query.Get("days"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	history, err := h.syncHotelsUseCase.GetSyncHistory(r.Context(), days, page, limit)
+	if err != nil {
+		h.logger.Error("Failed to get sync history", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, history, map[string]interface{}{
+		"page": max(page, 1),
+	}, NoStore)
+}
+
+// TriggerIndexBackfill starts a background backfill of search document fields
+// @Summary Backfill search index fields
+// @Description Start an asynchronous job that repopulates the given fields on existing search documents from the database using partial updates. The job resumes from the last processed hotel_id unless restart is set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param options body usecase.BackfillOptions true "Fields to backfill"
+// @Success 200 {object} APIResponse "Backfill job created"
+// @Failure 400 {object} APIResponse "Bad Request"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/index/backfill [post]
+func (h *AdminHandler) TriggerIndexBackfill(w http.ResponseWriter, r *http.Request) {
+	var options usecase.BackfillOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.writeErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.indexBackfillUseCase.Start(r.Context(), options)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidBackfillFields) {
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("Failed to start index backfill", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Index backfill started",
+		"job_id", job.ID,
+		"fields", job.Fields,
+		"resumed_from", job.ResumedFrom,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetIndexBackfillJob returns the progress of a backfill job
+// @Summary Get backfill job status
+// @Description Get the status and progress of an index backfill job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Backfill job ID"
+// @Success 200 {object} APIResponse "Backfill job status"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/index/backfill/{id} [get]
+func (h *AdminHandler) GetIndexBackfillJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	job, err := h.indexBackfillUseCase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrBackfillJobNotFound) {
+			h.writeErrorResponse(w, "Backfill job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get backfill job", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// TriggerReviewArchival starts a background archival of old reviews
+// @Summary Archive old reviews
+// @Description Start an asynchronous job that moves reviews past the newest keep_newest of each hotel and older than min_age_days into the reviews archive. Omitted options use the configured retention. The job resumes from the last processed hotel_id unless restart is set, and running it again archives nothing new
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param options body usecase.ReviewArchiveOptions false "Retention overrides"
+// @Success 200 {object} APIResponse{data=usecase.ReviewArchiveJob} "Review archive job created"
+// @Failure 400 {object} APIResponse "Bad Request"
+// @Failure 409 {object} APIResponse "Review archival is already running"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reviews/archive [post]
+func (h *AdminHandler) TriggerReviewArchival(w http.ResponseWriter, r *http.Request) {
+	var options usecase.ReviewArchiveOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil && !errors.Is(err, io.EOF) {
+		h.writeErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.reviewArchivalUseCase.Start(r.Context(), options)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidReviewArchive):
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, usecase.ErrReviewArchiveRunning):
+			h.writeErrorResponse(w, err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("Failed to start review archival", "error", err)
+			h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.logger.Info("Review archival started",
+		"job_id", job.ID,
+		"keep_newest", job.KeepNewest,
+		"min_age_days", job.MinAgeDays,
+		"resumed_from", job.ResumedFrom,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetReviewArchiveJob returns the progress of a review archive job
+// @Summary Get review archive job status
+// @Description Get the status and progress of a review archive job
+// @Tags admin
+// @Produce json
+// @Param id path string true "Review archive job ID"
+// @Success 200 {object} APIResponse{data=usecase.ReviewArchiveJob} "Review archive job status"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reviews/archive/{id} [get]
+func (h *AdminHandler) GetReviewArchiveJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	job, err := h.reviewArchivalUseCase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrReviewArchiveJobNotFound) {
+			h.writeErrorResponse(w, "Review archive job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get review archive job", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// TriggerReviewDedup starts a background merge of duplicate reviews
+// @Summary Merge duplicate reviews
+// @Description Start an asynchronous maintenance job that fingerprints reviews stored without one and merges the reviews of a hotel with the same fingerprint: the oldest is kept, the others are soft-deleted and the review_count of the hotel is recounted from its stored reviews. The job resumes from the last processed hotel_id unless restart is set, and running it again merges nothing new
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param options body usecase.ReviewDedupOptions false "Dedupe options"
+// @Success 200 {object} APIResponse{data=usecase.ReviewDedupJob} "Review dedupe job created"
+// @Failure 400 {object} APIResponse "Bad Request"
+// @Failure 409 {object} APIResponse "Review dedupe is already running"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reviews/dedupe [post]
+func (h *AdminHandler) TriggerReviewDedup(w http.ResponseWriter, r *http.Request) {
+	var options usecase.ReviewDedupOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil && !errors.Is(err, io.EOF) {
+		h.writeErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.reviewDedupUseCase.Start(r.Context(), options)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidReviewDedup):
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, usecase.ErrReviewDedupRunning):
+			h.writeErrorResponse(w, err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("Failed to start review dedupe", "error", err)
+			h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.logger.Info("Review dedupe started",
+		"job_id", job.ID,
+		"resumed_from", job.ResumedFrom,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetReviewDedupJob returns the progress of a review dedupe job
+// @Summary Get review dedupe job status
+// @Description Get the status and progress of a review dedupe job
+// @Tags admin
+// @Produce json
+// @Param id path string true "Review dedupe job ID"
+// @Success 200 {object} APIResponse{data=usecase.ReviewDedupJob} "Review dedupe job status"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reviews/dedupe/{id} [get]
+func (h *AdminHandler) GetReviewDedupJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	job, err := h.reviewDedupUseCase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrReviewDedupJobNotFound) {
+			h.writeErrorResponse(w, "Review dedupe job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get review dedupe job", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetReconcileDiff reports differences between the database and the search index
+// @Summary Preview index reconciliation
+// @Description Run the index reconciliation of POST /api/v1/admin/index/reconcile synchronously without changing anything, and return its report: the missing, stale and orphaned counts with a sample of hotel_ids for each
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=usecase.ReconcileJob} "Reconciliation report"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reconcile/diff [get]
+func (h *AdminHandler) GetReconcileDiff(w http.ResponseWriter, r *http.Request) {
+	job, err := h.reconcileUseCase.Run(r.Context(), usecase.ReconcileJobOptions{})
+	if err != nil {
+		h.logger.Error("Failed to compute reconciliation diff", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// TriggerReconcile applies the differences between the database and the search index
+// @Summary Reconcile search index
+// @Description Run the index reconciliation of POST /api/v1/admin/index/reconcile?repair=true synchronously: missing and stale hotels are reindexed and orphaned documents deleted. The report counts the reindexed, deleted and failed hotels
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=usecase.ReconcileJob} "Reconciliation report"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/reconcile [post]
+func (h *AdminHandler) TriggerReconcile(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("Index reconciliation triggered", "remote_addr", r.RemoteAddr)
+
+	job, err := h.reconcileUseCase.Run(r.Context(), usecase.ReconcileJobOptions{Repair: true})
+	if err != nil {
+		h.logger.Error("Failed to reconcile index", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetUsage reports the daily usage of an API client
+// @Summary Get API usage
+// @Description Get the daily request, error and result counts of an API client per endpoint, rolled up nightly from live counters. Use key=anonymous for requests made without an API key or with a key not in usage_api_keys. Days are UTC and both bounds are inclusive
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param key query string true "API key of the client, or anonymous"
+// @Param from query string false "First day, YYYY-MM-DD (default: 30 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default: today)"
+// @Success 200 {object} APIResponse{data=usecase.UsageReport} "Usage report"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid key or date range"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/usage [get]
+func (h *AdminHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	apiKey := query.Get("key")
+	if apiKey == "" {
+		h.writeErrorResponse(w, "query parameter 'key' is required, use 'anonymous' for unauthenticated traffic", http.StatusBadRequest)
+		return
+	}
+
+	to := time.Now().UTC()
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse(usage.DayLayout, value)
+		if err != nil {
+			h.writeErrorResponse(w, "invalid 'to' date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -defaultUsageReportDays+1)
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse(usage.DayLayout, value)
+		if err != nil {
+			h.writeErrorResponse(w, "invalid 'from' date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+
+	report, err := h.usageReportUseCase.Report(r.Context(), apiKey, from, to)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidUsageRange) {
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("Failed to get usage report", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, report, nil, NoStore)
+}
+
+// GetHotelChanges returns a page of the field changes recorded for a hotel
+// @Summary List hotel changes
+// @Description List the tracked fields (rating, stars, name, status, child/pets flags, parking) that worker updates changed for a hotel, and the fields corrected by hand, most recent first, with the old and new value of each field and the message or caller that caused the change
+// @Tags admin
+// @Produce json
+// @Param id path integer true "Hotel ID"
+// @Param page query integer false "Page number (default: 1)"
+// @Param limit query integer false "Changes per page (max: 100, default: 20)"
+// @Success 200 {object} APIResponse{data=[]hotel.Change,meta=object} "Hotel changes"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels/{id}/changes [get]
+func (h *AdminHandler) GetHotelChanges(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	changes, err := h.hotelChangesUseCase.List(r.Context(), hotelID, page, limit)
+	if err != nil {
+		h.logger.Error("Failed to list hotel changes", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, changes, map[string]interface{}{
+		"page": max(page, 1),
+	}, NoStore)
+}
+
+// GetHotelSources returns which source last wrote each field group of a hotel
+// @Summary Get hotel data sources
+// @Description Attribute the core, photos, policies and rooms field groups of a hotel to the source that last wrote them (import, fallback or cupid_fetcher) and when. A source does not overwrite a group written by a higher-priority source, as ranked by source_priorities. Hotels stored before sources were recorded return no groups
+// @Tags admin
+// @Produce json
+// @Param id path integer true "Hotel ID"
+// @Success 200 {object} APIResponse{data=usecase.HotelSources} "Hotel data sources"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels/{id}/sources [get]
+func (h *AdminHandler) GetHotelSources(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
+	}
+
+	sources, err := h.hotelChangesUseCase.Sources(r.Context(), hotelID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrHotelNotFound) {
+			h.writeErrorResponse(w, "Hotel not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get hotel sources", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, sources, nil, NoStore)
+}
+
+// GetUnmappedFacilities lists the raw facility names the taxonomy does not map
+// @Summary List unmapped facilities
+// @Description List the facility names received from Cupid that no facility alias maps to a canonical facility, with the slug each falls through to and its hotel count, most common first. Add them to facility_aliases to merge them into a canonical facility
+// @Tags admin
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]usecase.UnmappedFacility} "Unmapped facility names"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/facilities/unmapped [get]
+func (h *AdminHandler) GetUnmappedFacilities(w http.ResponseWriter, r *http.Request) {
+	unmapped, err := h.facilitiesUseCase.Unmapped(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list unmapped facilities", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, unmapped, map[string]interface{}{
+		"count": len(unmapped),
+	}, NoStore)
+}
+
+// TriggerFacilityBackfill starts a background normalization of the stored hotel facilities
+// @Summary Start facility backfill
+// @Description Start a background job that sets the canonical slug of every stored facility and the hotels' amenities from the facility taxonomy, and updates the amenities of the search index. Run it after deploying the taxonomy or changing facility_aliases so /api/v1/facilities and the amenities filter see every hotel without waiting for it to be refetched. The job resumes from its last position unless restart is set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param options body usecase.FacilityBackfillOptions false "Backfill options"
+// @Success 200 {object} APIResponse{data=usecase.FacilityBackfillJob} "Facility backfill job created"
+// @Failure 400 {object} APIResponse "Bad Request"
+// @Failure 409 {object} APIResponse "Facility backfill is already running"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/facilities/backfill [post]
+func (h *AdminHandler) TriggerFacilityBackfill(w http.ResponseWriter, r *http.Request) {
+	var options usecase.FacilityBackfillOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil && !errors.Is(err, io.EOF) {
+		h.writeErrorResponse(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.facilityBackfillUseCase.Start(r.Context(), options)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidFacilityBackfill):
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, usecase.ErrFacilityBackfillRunning):
+			h.writeErrorResponse(w, err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("Failed to start facility backfill", "error", err)
+			h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.logger.Info("Facility backfill started",
+		"job_id", job.ID,
+		"resumed_from", job.ResumedFrom,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetFacilityBackfillJob returns the progress of a facility backfill job
+// @Summary Get facility backfill job status
+// @Description Get the status and progress of a facility backfill job
+// @Tags admin
+// @Produce json
+// @Param id path string true "Facility backfill job ID"
+// @Success 200 {object} APIResponse{data=usecase.FacilityBackfillJob} "Facility backfill job status"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/facilities/backfill/{id} [get]
+func (h *AdminHandler) GetFacilityBackfillJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	job, err := h.facilityBackfillUseCase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrFacilityBackfillJobNotFound) {
+			h.writeErrorResponse(w, "Facility backfill job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get facility backfill job", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// FindHotelsBySource looks up hotels by the id an external data source uses for them
+// @Summary Find hotels by source id
+// @Description Find the hotels mapped to the given hotel id of an external data source, e.g. source=cupid&source_id=12345
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param source query string true "Data source name (e.g. cupid, booking)"
+// @Param source_id query string true "Hotel id in the data source"
+// @Success 200 {object} APIResponse "Matching hotels"
+// @Failure 400 {object} APIResponse "Bad Request - Missing source or source_id"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels [get]
+func (h *AdminHandler) FindHotelsBySource(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	hotels, err := h.getHotelByIDUseCase.FindBySource(r.Context(), queryParams.Get("source"), queryParams.Get("source_id"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidSourceLookup) {
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("Failed to find hotels by source id", "source", queryParams.Get("source"), "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, hotels, nil, NoStore)
+}
+
+// FindDuplicateHotels lists groups of hotels that look like the same property
+// @Summary Find duplicate hotels
+// @Description Find active hotels imported more than once under different ids: hotels within max_distance_km of each other whose names have at least name_similarity trigram similarity. Pairs sharing a hotel form one group. Confidence is the weakest pair's name similarity, discounted by up to half with distance. Merge a group with POST /api/v1/admin/hotels/merge
+// @Tags admin
+// @Produce json
+// @Param name_similarity query number false "Lowest name similarity, from 0 to 1 (default: 0.6)" minimum(0) maximum(1)
+// @Param max_distance_km query number false "Largest distance between the hotels in km (default: 0.5)" minimum(0) maximum(50)
+// @Param same_chain_only query boolean false "Only pair hotels of the same chain"
+// @Success 200 {object} APIResponse{data=[]hotel.DuplicateGroup} "Duplicate groups, most confident first"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid criteria"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels/duplicates [get]
+func (h *AdminHandler) FindDuplicateHotels(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var criteria hotel.DuplicateCriteria
+	if value := query.Get("name_similarity"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			h.writeErrorResponse(w, "invalid name_similarity", http.StatusBadRequest)
+			return
+		}
+		criteria.NameSimilarityThreshold = parsed
+	}
+	if value := query.Get("max_distance_km"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			h.writeErrorResponse(w, "invalid max_distance_km", http.StatusBadRequest)
+			return
+		}
+		criteria.MaxDistanceKm = parsed
+	}
+	criteria.SameChainOnly, _ = strconv.ParseBool(query.Get("same_chain_only"))
+
+	groups, err := h.hotelDuplicatesUseCase.Find(r.Context(), criteria)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidDuplicateCriteria) {
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("Failed to find duplicate hotels", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, groups, map[string]interface{}{
+		"count": len(groups),
+	}, NoStore)
+}
+
+// MergeHotels folds a duplicate hotel into its primary
+// @Summary Merge duplicate hotels
+// @Description Move the reviews and translations of duplicate_id to primary_id and soft-delete duplicate_id. Translations in a language the primary already has are deleted with the duplicate, and the review_count of the primary is recounted from its stored reviews. The duplicate is removed from the search index and the primary reindexed
+// @Tags admin
+// @Produce json
+// @Param primary_id query integer true "Hotel ID to keep" minimum(1)
+// @Param duplicate_id query integer true "Hotel ID to merge into the primary and delete" minimum(1)
+// @Success 200 {object} APIResponse{data=hotel.MergeResult} "Merge result"
+// @Failure 400 {object} APIResponse "Bad Request - Missing or identical hotel IDs"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/hotels/merge [post]
+func (h *AdminHandler) MergeHotels(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	primaryID, err := strconv.ParseInt(query.Get("primary_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid primary_id", http.StatusBadRequest)
+		return
+	}
+	duplicateID, err := strconv.ParseInt(query.Get("duplicate_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid duplicate_id", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.hotelDuplicatesUseCase.Merge(r.Context(), primaryID, duplicateID, r.RemoteAddr)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidMerge):
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, usecase.ErrHotelNotFound):
+			h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+		default:
+			h.logger.Error("Failed to merge hotels", "primary_id", primaryID, "duplicate_id", duplicateID, "error", err)
+			h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeSuccessResponse(w, result, nil, NoStore)
+}
+
+// TriggerReconcileJob starts a background reconciliation of the search index
+// @Summary Start index reconciliation job
+// @Description Start an asynchronous job that streams hotel_id and updated_at from the database and the search index, merges them in hotel_id order and counts missing, stale and orphaned documents. With repair=true missing and stale hotels are reindexed and orphaned documents deleted; otherwise the job only reports
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param repair query boolean false "Apply the fixes instead of only reporting them"
+// @Success 200 {object} APIResponse "Reconciliation job created"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/index/reconcile [post]
+func (h *AdminHandler) TriggerReconcileJob(w http.ResponseWriter, r *http.Request) {
+	repair, _ := strconv.ParseBool(r.URL.Query().Get("repair"))
+
+	job, err := h.reconcileUseCase.StartJob(r.Context(), usecase.ReconcileJobOptions{Repair: repair})
+	if err != nil {
+		h.logger.Error("Failed to start index reconciliation job", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Index reconciliation job started",
+		"job_id", job.ID,
+		"repair", job.Repair,
+		"remote_addr", r.RemoteAddr)
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// GetReconcileJob returns the progress of a reconciliation job
+// @Summary Get index reconciliation job status
+// @Description Get the status, progress and findings of an index reconciliation job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Reconciliation job ID"
+// @Success 200 {object} APIResponse "Reconciliation job status"
+// @Failure 404 {object} APIResponse "Job not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/index/reconcile/{id} [get]
+func (h *AdminHandler) GetReconcileJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	job, err := h.reconcileUseCase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrReconcileJobNotFound) {
+			h.writeErrorResponse(w, "Reconcile job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get reconcile job", "job_id", jobID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, job, nil, NoStore)
+}
+
+// ExportSearchConfig exports the active search configuration
+// @Summary Export search config
+// @Description Export the active synonyms, ranking profiles and facet settings as one bundle. The version is a hash of the content, so environments with the same configuration report the same version
+// @Tags admin
+// @Produce json
+// @Success 200 {object} APIResponse{data=search.ConfigBundle} "Active search config bundle"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/search/config [get]
+func (h *AdminHandler) ExportSearchConfig(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.searchConfigUseCase.Export(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to export search config", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, bundle, nil, NoStore)
+}
+
+// ApplySearchConfig applies a search configuration bundle
+// @Summary Apply search config
+// @Description Validate and apply a search config bundle as a whole: nothing is changed when any part is invalid, and applied parts are reverted when a later step fails. The replaced bundle is kept for rollback. The version in the body is ignored
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param bundle body search.ConfigBundle true "Search config bundle"
+// @Success 200 {object} APIResponse{data=search.ConfigBundle} "Applied search config bundle"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid bundle"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/search/config [put]
+func (h *AdminHandler) ApplySearchConfig(w http.ResponseWriter, r *http.Request) {
+	var bundle search.ConfigBundle
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSearchConfigBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&bundle); err != nil {
+		h.writeErrorResponse(w, "invalid search config bundle: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	applied, err := h.searchConfigUseCase.Apply(r.Context(), bundle)
+	if err != nil {
+		h.writeSearchConfigError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, applied, nil, NoStore)
+}
+
+// RollbackSearchConfig restores the search configuration replaced by the last apply
+// @Summary Roll back search config
+// @Description Re-apply the search config bundle replaced by the last apply or rollback
+// @Tags admin
+// @Produce json
+// @Success 200 {object} APIResponse{data=search.ConfigBundle} "Restored search config bundle"
+// @Failure 404 {object} APIResponse "Not Found - No previous bundle"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Security Bearer
+// @Router /api/v1/admin/search/config/rollback [post]
+func (h *AdminHandler) RollbackSearchConfig(w http.ResponseWriter, r *http.Request) {
+	restored, err := h.searchConfigUseCase.Rollback(r.Context())
+	if err != nil {
+		h.writeSearchConfigError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, restored, nil, NoStore)
+}
+
+func (h *AdminHandler) writeSearchConfigError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, search.ErrInvalidConfigBundle):
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrNoPreviousSearchConfig):
+		h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+	default:
+		h.logger.Error("Failed to apply search config", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+	}
+}
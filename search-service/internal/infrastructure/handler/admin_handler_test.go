@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/entities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/synchistory"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/adapter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newSyncHistoryTestHandler(t *testing.T) (*AdminHandler, synchistory.Repository) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s", filepath.Join(t.TempDir(), "sync_runs.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().CreateTable(&entities.SyncRun{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	log := slog.New(slog.DiscardHandler)
+	history := adapter.NewPostgresSyncHistoryRepository(db, log)
+	syncHotels := usecase.NewSyncHotelsUseCase(nil, nil, nil, nil, nil, nil, false, history, 30*24*time.Hour, log)
+	return &AdminHandler{responder: responder{logger: log}, syncHotelsUseCase: syncHotels}, history
+}
+
+func TestGetSyncHistoryReturnsRunsAndAggregates(t *testing.T) {
+	h, history := newSyncHistoryTestHandler(t)
+	now := time.Now().UTC()
+	for _, run := range []synchistory.Run{
+		{Mode: synchistory.ModeFull, Trigger: synchistory.TriggerInitial, Status: synchistory.StatusCompleted, StartedAt: now.Add(-72 * time.Hour), DurationMs: 4000, IndexedHotels: 100},
+		{Mode: synchistory.ModeIncremental, Trigger: synchistory.TriggerPeriodic, Status: synchistory.StatusCompleted, StartedAt: now.Add(-48 * time.Hour), DurationMs: 1000, IndexedHotels: 8, FailedHotels: 2},
+		{Mode: synchistory.ModeIncremental, Trigger: synchistory.TriggerPeriodic, Status: synchistory.StatusFailed, StartedAt: now.Add(-24 * time.Hour), DurationMs: 1000, ErrorCount: 1, Errors: []string{"index unavailable"}},
+		{Mode: synchistory.ModeIncremental, Trigger: synchistory.TriggerPeriodic, Status: synchistory.StatusCompleted, StartedAt: now.AddDate(0, 0, -60), DurationMs: 9000},
+	} {
+		run.FinishedAt = run.StartedAt.Add(time.Duration(run.DurationMs) * time.Millisecond)
+		require.NoError(t, history.Save(context.Background(), &run))
+	}
+
+	recorder := httptest.NewRecorder()
+	h.GetSyncHistory(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/admin/sync/history?page=1&limit=2", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	var response struct {
+		Data usecase.SyncHistory `json:"data"`
+		Meta map[string]any      `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	require.Len(t, response.Data.Runs, 2)
+	assert.Equal(t, synchistory.StatusFailed, response.Data.Runs[0].Status)
+	assert.Equal(t, []string{"index unavailable"}, response.Data.Runs[0].Errors)
+	assert.Equal(t, 2, response.Data.Runs[1].FailedHotels)
+
+	summary := response.Data.Summary
+	assert.Equal(t, int64(3), summary.Runs, "the run older than the retention is outside the window")
+	assert.Equal(t, int64(2), summary.FailedRuns)
+	assert.InDelta(t, 2000, summary.AvgDurationMs, 0.001)
+	assert.InDelta(t, 2.0/3.0, summary.FailureRate, 0.001)
+	assert.Equal(t, int64(108), summary.IndexedHotels)
+	assert.Equal(t, int64(2), summary.FailedHotels)
+	assert.EqualValues(t, 1, response.Meta["page"])
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "-30d", expected: time.Date(2026, 9, 16, 12, 34, 0, 0, time.UTC)},
+		{value: "-2w", expected: time.Date(2026, 10, 2, 12, 34, 0, 0, time.UTC)},
+		{value: "-12h", expected: time.Date(2026, 10, 16, 0, 34, 0, 0, time.UTC)},
+		{value: "-90m", expected: time.Date(2026, 10, 16, 11, 4, 0, 0, time.UTC)},
+		{value: "-1h30m", expected: time.Date(2026, 10, 16, 11, 4, 0, 0, time.UTC)},
+		{value: "-0d", expected: time.Date(2026, 10, 16, 12, 34, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, ok := parseRelativeTime(tt.value, now)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, parsed, "relative times are rounded down to the minute")
+		})
+	}
+
+	for _, value := range []string{"30d", "+30d", "-d", "-1.5d", "-30x", "-d30", "-99999999999999999999d", "--30d", "-"} {
+		t.Run(value, func(t *testing.T) {
+			_, ok := parseRelativeTime(value, now)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestParseTimeParam(t *testing.T) {
+	parsed, err := parseTimeParam("created_after", "-30d")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), *parsed, time.Minute)
+
+	parsed, err = parseTimeParam("created_after", "2024-01-01T10:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), parsed.UTC())
+
+	parsed, err = parseTimeParam("created_after", "")
+	require.NoError(t, err)
+	assert.Nil(t, parsed, "an empty value sets no filter")
+
+	for _, value := range []string{"-30days", "30d", "yesterday", "2024-02-30"} {
+		_, err := parseTimeParam("created_after", value)
+		assert.ErrorIs(t, err, search.ErrInvalidTimeRange, value)
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CachePolicy is the Cache-Control header of a successful response.
+type CachePolicy struct {
+	// NoStore keeps the response out of every cache, whatever the other fields.
+	NoStore bool
+	MaxAge  time.Duration
+	// SharedMaxAge lets shared caches, such as a trusted CDN, keep the response for that long.
+	// Without it the response is private to the client.
+	SharedMaxAge time.Duration
+	// MustRevalidate forbids caches from serving the response once it is stale.
+	MustRevalidate bool
+	// Vary names the request headers the response depends on, so caches keep one copy per
+	// value of them.
+	Vary []string
+}
+
+// NoStore is the policy of responses that must never be cached, such as admin and health.
+var NoStore = CachePolicy{NoStore: true}
+
+// CachePolicies are the policies of the public endpoints, by how volatile their data is.
+type CachePolicies struct {
+	// Search covers search results and suggestions, which follow the search result cache.
+	Search CachePolicy
+	// Hotel covers hotel details, comparisons, reviews and translations. A hotel detail is
+	// never cached past its next scheduled update.
+	Hotel CachePolicy
+	// Reference covers facets, facilities and trending lists, which change slowly.
+	Reference CachePolicy
+}
+
+func (p CachePolicy) String() string {
+	if p.NoStore {
+		return "no-store"
+	}
+
+	directives := []string{"private"}
+	if p.SharedMaxAge > 0 {
+		directives = []string{"public"}
+	}
+	directives = append(directives, "max-age="+cacheSeconds(p.MaxAge))
+	if p.SharedMaxAge > 0 {
+		directives = append(directives, "s-maxage="+cacheSeconds(p.SharedMaxAge))
+	}
+	if p.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	return strings.Join(directives, ", ")
+}
+
+// capped returns the policy with its ages lowered to maxAge.
+func (p CachePolicy) capped(maxAge time.Duration) CachePolicy {
+	maxAge = max(maxAge, 0)
+	p.MaxAge = min(p.MaxAge, maxAge)
+	p.SharedMaxAge = min(p.SharedMaxAge, maxAge)
+	return p
+}
+
+// aged returns the policy of a response computed age ago, such as a cached search result,
+// whose freshness counts from when it was computed.
+func (p CachePolicy) aged(age time.Duration) CachePolicy {
+	if age <= 0 {
+		return p
+	}
+	p.MaxAge = max(p.MaxAge-age, 0)
+	p.SharedMaxAge = max(p.SharedMaxAge-age, 0)
+	return p
+}
+
+// varying returns the policy of a response that depends on the given request headers.
+func (p CachePolicy) varying(headers ...string) CachePolicy {
+	p.Vary = append(slices.Clip(p.Vary), headers...)
+	return p
+}
+
+func (p CachePolicy) apply(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", p.String())
+	if len(p.Vary) > 0 && !p.NoStore {
+		w.Header().Set("Vary", strings.Join(p.Vary, ", "))
+	}
+}
+
+func cacheSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(max(d, 0)/time.Second), 10)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var testCachePolicies = CachePolicies{
+	Search:    CachePolicy{MaxAge: 5 * time.Minute},
+	Hotel:     CachePolicy{MaxAge: time.Hour},
+	Reference: CachePolicy{MaxAge: 15 * time.Minute, SharedMaxAge: time.Hour},
+}
+
+func TestCachePolicyString(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CachePolicy
+		expected string
+	}{
+		{name: "private", policy: CachePolicy{MaxAge: 5 * time.Minute}, expected: "private, max-age=300"},
+		{name: "shared", policy: CachePolicy{MaxAge: 5 * time.Minute, SharedMaxAge: time.Hour}, expected: "public, max-age=300, s-maxage=3600"},
+		{name: "must revalidate", policy: CachePolicy{MaxAge: time.Hour, MustRevalidate: true}, expected: "private, max-age=3600, must-revalidate"},
+		{name: "no store", policy: CachePolicy{NoStore: true, MaxAge: time.Hour, SharedMaxAge: time.Hour}, expected: "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.String())
+		})
+	}
+}
+
+func TestCachePolicyAged(t *testing.T) {
+	policy := CachePolicy{MaxAge: 5 * time.Minute, SharedMaxAge: 10 * time.Minute}
+
+	assert.Equal(t, "public, max-age=180, s-maxage=480", policy.aged(2*time.Minute).String())
+	assert.Equal(t, "public, max-age=0, s-maxage=240", policy.aged(6*time.Minute).String())
+	assert.Equal(t, "private, max-age=0", policy.aged(time.Hour).String(), "an entry older than every age is not cached")
+	assert.Equal(t, policy, policy.aged(0))
+}
+
+func TestCachePolicyVaryIsNotSharedBetweenResponses(t *testing.T) {
+	base := CachePolicy{MaxAge: time.Minute, Vary: make([]string, 0, 4)}
+
+	first := base.varying("Accept-Language")
+	second := base.varying("X-Country-Code")
+
+	assert.Equal(t, []string{"Accept-Language"}, first.Vary)
+	assert.Equal(t, []string{"X-Country-Code"}, second.Vary)
+	assert.Empty(t, base.Vary)
+}
+
+func TestSearchHotelsCacheHitsCountTheEntryAge(t *testing.T) {
+	controller := gomock.NewController(t)
+	engine := mocks.NewMockEngine(controller)
+	cache := mocks.NewMockCacheRepository(controller)
+	stored := map[string][]byte{}
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) ([]byte, error) {
+		if value, ok := stored[key]; ok {
+			return value, nil
+		}
+		return nil, errors.New("cache miss")
+	}).AnyTimes()
+	cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, value []byte, _ time.Duration) error {
+		stored[key] = value
+		return nil
+	}).AnyTimes()
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}).AnyTimes()
+	engine.EXPECT().Info().Return(search.EngineInfo{Name: "typesense", Collection: "hotels"}).AnyTimes()
+	engine.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&search.Result{TotalHits: 1, Hotels: []*hotel.Hotel{}}, nil).Times(1)
+	h := newParamsTestHandler()
+	h.cachePolicies = CachePolicies{Search: CachePolicy{MaxAge: 5 * time.Minute, SharedMaxAge: 10 * time.Minute}}
+	h.searchHotelsUseCase = usecase.NewSearchHotelsUseCase(engine, cache, nil, nil, search.DefaultSnippetLength, 5*time.Minute, time.Minute, nil, slog.New(slog.DiscardHandler))
+
+	searchParis := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=paris", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		return recorder
+	}
+
+	recorder := searchParis()
+	assert.Equal(t, "MISS", recorder.Header().Get("X-Cache"))
+	assert.Equal(t, "public, max-age=300, s-maxage=600", recorder.Header().Get("Cache-Control"))
+
+	// Backdate the cached entry by two minutes.
+	require.Len(t, stored, 1)
+	for key, value := range stored {
+		var entry map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(value, &entry))
+		generatedAt, err := json.Marshal(time.Now().Add(-2 * time.Minute).UTC())
+		require.NoError(t, err)
+		entry["cache_generated_at"] = generatedAt
+		stored[key], err = json.Marshal(entry)
+		require.NoError(t, err)
+	}
+
+	recorder = searchParis()
+	assert.Equal(t, "HIT", recorder.Header().Get("X-Cache"))
+	// The time spent since the backdating is truncated off the remaining seconds.
+	assert.Regexp(t, `^public, max-age=1(79|80), s-maxage=4(79|80)$`, recorder.Header().Get("Cache-Control"), "a cache hit is fresh only for the rest of its entry's age")
+}
+
+func TestTrendingListsVaryByTheLocaleHeaders(t *testing.T) {
+	controller := gomock.NewController(t)
+	cache := mocks.NewMockCacheRepository(controller)
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) ([]byte, error) {
+		if key == "popular_searches:es:8" {
+			return []byte(`["playa"]`), nil
+		}
+		return []byte(`[{"text":"playa"}]`), nil
+	}).AnyTimes()
+	logger := slog.New(slog.DiscardHandler)
+	h := newParamsTestHandler()
+	h.cachePolicies = testCachePolicies
+	h.searchHotelsUseCase = usecase.NewSearchHotelsUseCase(nil, cache, nil, nil, search.DefaultSnippetLength, time.Minute, time.Minute, nil, logger)
+	h.getHotelSuggestionsUseCase = usecase.NewGetHotelSuggestionsUseCase(nil, nil, cache, nil, logger)
+
+	for name, endpoint := range map[string]http.HandlerFunc{
+		"trending": h.GetTrendingSuggestions,
+		"popular":  h.GetPopularSearches,
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/search/"+name, nil)
+			r.Header.Set("Accept-Language", "es-ES")
+			endpoint(recorder, r)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "public, max-age=900, s-maxage=3600", recorder.Header().Get("Cache-Control"))
+			assert.Equal(t, "Accept-Language, X-Country-Code", recorder.Header().Get("Vary"), "shared caches keep one list per locale")
+		})
+	}
+}
+
+func TestHotelCachePolicy(t *testing.T) {
+	h := &HotelHandler{cachePolicies: testCachePolicies}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	nextUpdate := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name     string
+		meta     usecase.HotelMeta
+		expected string
+	}{
+		{name: "fresh", meta: usecase.HotelMeta{Freshness: usecase.Freshness{DataFreshness: usecase.DataFresh}}, expected: "private, max-age=3600, must-revalidate"},
+		{name: "update due soon", meta: usecase.HotelMeta{Freshness: usecase.Freshness{DataFreshness: usecase.DataFresh, NextUpdateAt: &nextUpdate}}, expected: "private, max-age=600, must-revalidate"},
+		{name: "stale", meta: usecase.HotelMeta{Freshness: usecase.Freshness{DataFreshness: usecase.DataStale, NextUpdateAt: &nextUpdate}}, expected: "private, max-age=0, must-revalidate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, h.hotelCachePolicy(tt.meta, now).String())
+		})
+	}
+}
+
+func TestErrorResponsesAreNotCached(t *testing.T) {
+	h := newParamsTestHandler()
+	h.cachePolicies = testCachePolicies
+	recorder := httptest.NewRecorder()
+
+	h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=yesterday", nil))
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	assert.Empty(t, recorder.Header().Get("Vary"))
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+)
+
+const serviceName = "search-service"
+
+type DebugHandler struct {
+	config       any
+	slowRequests *SlowRequestLog
+	logger       *slog.Logger
+}
+
+// NewDebugHandler returns a handler exposing build and runtime diagnostics. config is echoed
+// back with secrets redacted.
+func NewDebugHandler(config any, slowRequests *SlowRequestLog, logger *slog.Logger) *DebugHandler {
+	return &DebugHandler{
+		config:       config,
+		slowRequests: slowRequests,
+		logger:       logger,
+	}
+}
+
+// GetDebugInfo returns build metadata and runtime diagnostics
+// @Summary Build and runtime diagnostics
+// @Description Get the build version, commit and build time, Go runtime stats, uptime and the redacted service configuration with its checksum. Only reachable from internal networks
+// @Tags debug
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=buildinfo.DebugInfo} "Build and runtime diagnostics"
+// @Failure 403 {object} APIResponse "Forbidden"
+// @Router /debug/info [get]
+func (h *DebugHandler) GetDebugInfo(w http.ResponseWriter, _ *http.Request) {
+	response := APIResponse{
+		Success: true,
+		Data:    buildinfo.Collect(serviceName, h.config),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	NoStore.apply(w)
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+}
+
+// GetSlowRequests lists the most recent slow requests
+// @Summary List slow requests
+// @Description List the most recent requests (up to 100) that took longer than server.slow_request_threshold, most recent first, with their duration breakdown and the start of their response body. Kept in memory by each instance, so only this instance's requests since it started are returned
+// @Tags admin
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]SlowRequest} "Slow requests"
+// @Security Bearer
+// @Router /api/v1/admin/slow-requests [get]
+func (h *DebugHandler) GetSlowRequests(w http.ResponseWriter, _ *http.Request) {
+	requests := h.slowRequests.List()
+	response := APIResponse{
+		Success: true,
+		Data:    requests,
+		Meta: map[string]interface{}{
+			"count": len(requests),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	NoStore.apply(w)
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/config"
+)
+
+func TestGetDebugInfoRedactsSecrets(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Port = 8080
+	cfg.Server.SessionSecret = "session-secret-value"
+	cfg.Database.Host = "postgres"
+	cfg.Database.Password = "database-password-value"
+	cfg.Redis.Password = "redis-password-value"
+	cfg.Typesense.ApiKey = "typesense-key-value"
+	cfg.CupidAPI.APIKey = "cupid-key-value"
+
+	debugHandler := NewDebugHandler(cfg, NewSlowRequestLog(10), slog.New(slog.DiscardHandler))
+	recorder := httptest.NewRecorder()
+	debugHandler.GetDebugInfo(recorder, httptest.NewRequest(http.MethodGet, "/debug/info", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	body := recorder.Body.String()
+	for _, secret := range []string{"session-secret-value", "database-password-value", "redis-password-value", "typesense-key-value", "cupid-key-value"} {
+		assert.False(t, strings.Contains(body, secret), "response leaks %s", secret)
+	}
+
+	var response struct {
+		Data buildinfo.DebugInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	info := response.Data
+	assert.Equal(t, serviceName, info.Build.Service)
+	assert.Equal(t, buildinfo.Version, info.Build.Version)
+	assert.Equal(t, buildinfo.Commit, info.Build.Commit)
+	assert.Equal(t, buildinfo.ConfigChecksum(cfg), info.ConfigChecksum)
+	assert.Positive(t, info.Runtime.Goroutines)
+
+	server := info.Config["Server"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", server["SessionSecret"])
+	assert.Equal(t, float64(8080), server["Port"])
+	database := info.Config["Database"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", database["Password"])
+	assert.Equal(t, "postgres", database["Host"])
+}
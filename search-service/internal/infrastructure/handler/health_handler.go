@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+)
+
+type HealthHandler struct {
+	responder
+	searchHotelsUseCase *usecase.SearchHotelsUseCase
+	syncHotelsUseCase   *usecase.SyncHotelsUseCase
+}
+
+// NewHealthHandler returns the handler of the health check, which reports search load
+// shedding and sync leadership.
+func NewHealthHandler(searchHotelsUseCase *usecase.SearchHotelsUseCase, syncHotelsUseCase *usecase.SyncHotelsUseCase, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		responder:           responder{logger: logger},
+		searchHotelsUseCase: searchHotelsUseCase,
+		syncHotelsUseCase:   syncHotelsUseCase,
+	}
+}
+
+// HealthCheck returns the health status of the search service
+// @Summary Health check
+// @Description Get the current health status of the search service
+// @Tags health
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=object} "Service health status with timestamp and version"
+// @Router /health [get]
+// @BasePath /
+func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	loadStatus := h.searchHotelsUseCase.LoadStatus()
+
+	status := "healthy"
+	if loadStatus.Shedding {
+		status = "degraded"
+	}
+
+	health := map[string]interface{}{
+		"status":      status,
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		"service":     serviceName,
+		"version":     buildinfo.Version,
+		"commit":      buildinfo.Commit,
+		"build_time":  buildinfo.BuildTime,
+		"uptime":      buildinfo.Uptime().Round(time.Second).String(),
+		"uptime_ms":   buildinfo.Uptime().Milliseconds(),
+		"search_load": loadStatus,
+		"sync_leader": h.syncHotelsUseCase.LeaderStatus(r.Context()),
+	}
+
+	h.writeSuccessResponse(w, health, nil, NoStore)
+}
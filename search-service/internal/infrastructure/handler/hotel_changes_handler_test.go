@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func serveHotelChanges(t *testing.T, repo *mocks.MockRepository, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+	adminHandler := &AdminHandler{
+		responder:           responder{logger: logger},
+		hotelChangesUseCase: usecase.NewHotelChangesUseCase(repo, logger),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/admin/hotels/{id}/changes", adminHandler.GetHotelChanges)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func TestGetHotelChangesPages(t *testing.T) {
+	changedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	change := hotel.Change{
+		HotelID:         7,
+		Changes:         map[string]hotel.FieldChange{"rating": {Old: 4.5, New: 3.1}},
+		SourceMessageID: "message-1",
+		ChangedAt:       changedAt,
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedOptions hotel.ListChangesOptions
+		expectedPage    float64
+	}{
+		{name: "first page by default", query: "", expectedOptions: hotel.ListChangesOptions{Limit: 20}, expectedPage: 1},
+		{name: "requested page", query: "?page=3&limit=5", expectedOptions: hotel.ListChangesOptions{Limit: 5, Offset: 10}, expectedPage: 3},
+		{name: "limit capped", query: "?page=2&limit=500", expectedOptions: hotel.ListChangesOptions{Limit: 100, Offset: 100}, expectedPage: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(gomock.NewController(t))
+			repo.EXPECT().ListChanges(gomock.Any(), int64(7), tt.expectedOptions).Return([]hotel.Change{change}, nil)
+
+			recorder := serveHotelChanges(t, repo, "/api/v1/admin/hotels/7/changes"+tt.query)
+
+			require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+			var response struct {
+				Data []hotel.Change `json:"data"`
+				Meta map[string]any `json:"meta"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, []hotel.Change{change}, response.Data)
+			assert.Equal(t, tt.expectedPage, response.Meta["page"])
+			assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestGetHotelChangesErrors(t *testing.T) {
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	recorder := serveHotelChanges(t, repo, "/api/v1/admin/hotels/harbour/changes")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	repo.EXPECT().ListChanges(gomock.Any(), int64(7), gomock.Any()).Return(nil, errors.New("connection refused"))
+	recorder = serveHotelChanges(t, repo, "/api/v1/admin/hotels/7/changes")
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -1,58 +1,91 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
-	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/favorites"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/cdn"
 )
 
 type HotelHandler struct {
-	getHotelByIDUseCase        *usecase.GetHotelByIDUseCase
-	searchHotelsUseCase        *usecase.SearchHotelsUseCase
-	getHotelSuggestionsUseCase *usecase.GetHotelSuggestionsUseCase
-	syncHotelsUseCase          *usecase.SyncHotelsUseCase
-	logger                     *slog.Logger
+	responder
+	getHotelByIDUseCase      *usecase.GetHotelByIDUseCase
+	compareHotelsUseCase     *usecase.CompareHotelsUseCase
+	hotelTranslationsUseCase *usecase.GetHotelTranslationsUseCase
+	hotelReviewsUseCase      *usecase.HotelReviewsUseCase
+	favoritesUseCase         *usecase.FavoritesUseCase
+	sessions                 *SessionSigner
+	responseLimits           ResponseLimits
+	cachePolicies            CachePolicies
+	// exposeDataSources keeps the attribution of field groups in hotel responses.
+	exposeDataSources bool
+	// photoURLs serves the photos of hotel responses through the CDN. Nil keeps their origin
+	// URLs.
+	photoURLs cdn.PhotoURLRewriter
 }
 
+// ResponseLimits caps the embedded collections of hotel detail responses. Requests get Default
+// and can raise each limit up to Max. Responses larger than MaxBytes after truncation are
+// logged.
+type ResponseLimits struct {
+	Default  hotel.CollectionLimits
+	Max      hotel.CollectionLimits
+	MaxBytes int
+}
+
+// NewHotelHandler returns the handler of the hotel detail, review, translation and favorites
+// endpoints.
 func NewHotelHandler(
 	getHotelByIDUseCase *usecase.GetHotelByIDUseCase,
-	searchHotelsUseCase *usecase.SearchHotelsUseCase,
-	getHotelSuggestionsUseCase *usecase.GetHotelSuggestionsUseCase,
-	syncHotelsUseCase *usecase.SyncHotelsUseCase,
+	compareHotelsUseCase *usecase.CompareHotelsUseCase,
+	hotelTranslationsUseCase *usecase.GetHotelTranslationsUseCase,
+	hotelReviewsUseCase *usecase.HotelReviewsUseCase,
+	favoritesUseCase *usecase.FavoritesUseCase,
+	sessions *SessionSigner,
+	responseLimits ResponseLimits,
+	cachePolicies CachePolicies,
+	exposeDataSources bool,
+	photoURLs cdn.PhotoURLRewriter,
 	logger *slog.Logger,
 ) *HotelHandler {
 	return &HotelHandler{
-		getHotelByIDUseCase:        getHotelByIDUseCase,
-		searchHotelsUseCase:        searchHotelsUseCase,
-		getHotelSuggestionsUseCase: getHotelSuggestionsUseCase,
-		syncHotelsUseCase:          syncHotelsUseCase,
-		logger:                     logger,
+		responder:                responder{logger: logger},
+		getHotelByIDUseCase:      getHotelByIDUseCase,
+		compareHotelsUseCase:     compareHotelsUseCase,
+		hotelTranslationsUseCase: hotelTranslationsUseCase,
+		hotelReviewsUseCase:      hotelReviewsUseCase,
+		favoritesUseCase:         favoritesUseCase,
+		sessions:                 sessions,
+		responseLimits:           responseLimits,
+		cachePolicies:            cachePolicies,
+		exposeDataSources:        exposeDataSources,
+		photoURLs:                photoURLs,
 	}
 }
 
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-	Meta    interface{} `json:"meta,omitempty"`
-}
-
 // GetHotelByID retrieves a hotel by its ID
 // @Summary Get hotel by ID
-// @Description Get detailed information about a specific hotel by its ID with optional reviews limit
+// @Description Get detailed information about a specific hotel by its ID. Photos, rooms, reviews and translations are capped to configured defaults, which the *Limit parameters raise up to configured maxima; meta reports each collection's total and whether it was truncated. meta.data_freshness is stale when the hotel is overdue for a refresh from the Cupid API, with meta.last_updated, and fresh otherwise, with meta.next_update_at when an update is scheduled. data_sources, which source last wrote each field group, is included when server.expose_data_sources is set
 // @Tags hotels
 // @Accept json
 // @Produce json
 // @Param id path integer true "Hotel ID"
-// @Param reviewsLimit query integer false "Limit the number of reviews to return" minimum(1)
-// @Success 200 {object} APIResponse "Hotel details"
+// @Param photosLimit query integer false "Limit the number of photos to return, up to the configured maximum" minimum(1)
+// @Param roomsLimit query integer false "Limit the number of rooms to return, up to the configured maximum" minimum(1)
+// @Param reviewsLimit query integer false "Limit the number of reviews to return, up to the configured maximum" minimum(1)
+// @Param translationsLimit query integer false "Limit the number of translations to return, up to the configured maximum" minimum(1)
+// @Success 200 {object} APIResponse{meta=usecase.HotelMeta} "Hotel details"
 // @Failure 400 {object} APIResponse "Bad Request - Invalid parameters"
 // @Failure 404 {object} APIResponse "Not Found - Hotel not found"
 // @Failure 500 {object} APIResponse "Internal Server Error"
@@ -72,510 +105,426 @@ func (h *HotelHandler) GetHotelByID(w http.ResponseWriter, r *http.Request) {
 		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	queryParams := r.URL.Query()
-	reviewsLimitStr := queryParams.Get("reviewsLimit")
-	var reviewsCountInt int
-	if reviewsLimitStr != "" {
-		reviewsCountInt, err = strconv.Atoi(reviewsLimitStr)
-		if err != nil {
-			h.logger.Error("Failed to convert hotel_id to int", "hotel_id", hotelID)
-			h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	limits, err := h.collectionLimits(r.URL.Query())
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	hotel, err := h.getHotelByIDUseCase.Execute(r.Context(), hotelIDInt, reviewsCountInt)
+	hotel, meta, err := h.getHotelByIDUseCase.Execute(r.Context(), hotelIDInt, limits)
 	if err != nil {
 		h.logger.Error("Failed to get hotel by ID", "hotel_id", hotelID, "error", err)
 		h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
-	h.writeSuccessResponse(w, hotel, nil)
+	h.redactDataSources(hotel)
+	h.rewritePhotoURLs(hotel)
+	counter := &byteCountingWriter{ResponseWriter: w}
+	h.writeSuccessResponse(counter, hotel, meta, h.hotelCachePolicy(meta, time.Now()))
+	if h.responseLimits.MaxBytes > 0 && counter.written > h.responseLimits.MaxBytes {
+		h.logger.Warn("Hotel response exceeds the size threshold after truncation",
+			"hotel_id", hotelIDInt,
+			"bytes", counter.written,
+			"threshold", h.responseLimits.MaxBytes)
+	}
 }
 
-// SearchHotels searches for hotels based on various criteria
-// @Summary Search hotels
-// @Description Search for hotels using various filters based on TypesenseDocument fields
-// @Tags search
-// @Accept json
-// @Produce json
-// @Param q query string false "Search query for hotel name, description, or location"
-// @Param name query string false "Filter by hotel name"
-// @Param description query string false "Filter by hotel description"
-// @Param phone query string false "Filter by hotel phone number"
-// @Param chain query string false "Filter by hotel chain"
-// @Param email query string false "Filter by hotel email"
-// @Param fax query string false "Filter by hotel fax number"
-// @Param airport_code query string false "Filter by airport code"
-// @Param parking query string false "Filter by parking information"
-// @Param city query string false "Filter by city"
-// @Param country query string false "Filter by country"
-// @Param rating_min query number false "Minimum rating (0-5)"
-// @Param rating_max query number false "Maximum rating (0-5)"
-// @Param star_rating query integer false "Minimum star rating (1-5)"
-// @Param review_count query integer false "Filter by review count"
-// @Param child_allowed query boolean false "Filter by child allowed status"
-// @Param pets_allowed query boolean false "Filter by pets allowed status"
-// @Param amenities query array false "Filter by amenities" collectionFormat(multi)
-// @Param tags query array false "Filter by tags" collectionFormat(multi)
-// @Param price_min query number false "Minimum price"
-// @Param price_max query number false "Maximum price"
-// @Param currency query string false "Price currency (e.g., USD, EUR)"
-// @Param sort_by query string false "Sort by field (rating, price, distance, relevance, name, created_at)"
-// @Param sort_order query string false "Sort order (asc, desc)"
-// @Param page query integer false "Page number (default: 1)"
-// @Param limit query integer false "Results per page (max: 100, default: 20)"
-// @Param latitude query number false "Latitude for location-based search"
-// @Param longitude query number false "Longitude for location-based search"
-// @Param radius query number false "Search radius in kilometers"
-// @Success 200 {object} APIResponse{data=[]hotel.Hotel,meta=object} "Search results with hotels and pagination"
-// @Failure 400 {object} APIResponse "Bad Request - Invalid search parameters"
-// @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/search/hotels [get]
-func (h *HotelHandler) SearchHotels(w http.ResponseWriter, r *http.Request) {
-	params := h.parseSearchParams(r)
+// hotelCachePolicy keeps a hotel detail cached no longer than until its next scheduled update,
+// and not at all once it is overdue for one, as a refresh may land at any moment.
+func (h *HotelHandler) hotelCachePolicy(meta usecase.HotelMeta, now time.Time) CachePolicy {
+	policy := h.cachePolicies.Hotel
+	policy.MustRevalidate = true
+	switch {
+	case meta.DataFreshness == usecase.DataStale:
+		policy = policy.capped(0)
+	case meta.NextUpdateAt != nil:
+		policy = policy.capped(meta.NextUpdateAt.Sub(now))
+	}
+	return policy
+}
 
-	result, err := h.searchHotelsUseCase.Execute(r.Context(), params)
-	if err != nil {
-		h.logger.Error("Failed to search hotels", "error", err)
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+// redactDataSources drops the field group attribution from hotel responses unless it is
+// exposed. It is always available from the admin sources endpoint.
+func (h *HotelHandler) redactDataSources(hotels ...*hotel.Hotel) {
+	if h.exposeDataSources {
 		return
 	}
-
-	meta := map[string]interface{}{
-		"total_hits":      result.TotalHits,
-		"page":            result.Page,
-		"limit":           result.Limit,
-		"total_pages":     result.TotalPages,
-		"processing_time": result.ProcessingTime.String(),
-		"query":           result.Query,
-	}
-
-	if result.Facets != nil {
-		meta["facets"] = result.Facets
+	for _, found := range hotels {
+		found.DataSources = nil
 	}
-
-	h.writeSuccessResponse(w, result.Hotels, meta)
 }
 
-// GetHotelSuggestions provides search suggestions based on query input
-// @Summary Get hotel search suggestions
-// @Description Get autocomplete suggestions for hotel search based on partial query input
-// @Tags search
-// @Accept json
-// @Produce json
-// @Param q query string true "Search query for suggestions"
-// @Param limit query integer false "Maximum number of suggestions to return (default: 10)"
-// @Success 200 {object} APIResponse "List of search suggestions"
-// @Failure 400 {object} APIResponse "Bad Request - Query parameter is required"
-// @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/search/suggestions [get]
-func (h *HotelHandler) GetHotelSuggestions(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("q")
-	if query == "" {
-		h.writeErrorResponse(w, "query parameter 'q' is required", http.StatusBadRequest)
+// rewritePhotoURLs points the thumbnail, photos and room photos of hotel responses at the CDN.
+// The photo slices are copied first, as the use cases share them with the indexing they start.
+func (h *HotelHandler) rewritePhotoURLs(hotels ...*hotel.Hotel) {
+	if h.photoURLs == nil {
 		return
 	}
-
-	limitStr := r.URL.Query().Get("limit")
-	limit := 10
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+	for _, found := range hotels {
+		found.MainImageTh = h.photoURLs.Rewrite(found.MainImageTh)
+		found.Photos = slices.Clone(found.Photos)
+		for i := range found.Photos {
+			found.Photos[i].URL = h.photoURLs.Rewrite(found.Photos[i].URL)
+			found.Photos[i].HDURL = h.photoURLs.Rewrite(found.Photos[i].HDURL)
+		}
+		found.Rooms = slices.Clone(found.Rooms)
+		for i := range found.Rooms {
+			room := &found.Rooms[i]
+			room.Photos = slices.Clone(room.Photos)
+			for j := range room.Photos {
+				room.Photos[j].URL = h.photoURLs.Rewrite(room.Photos[j].URL)
+				room.Photos[j].HDURL = h.photoURLs.Rewrite(room.Photos[j].HDURL)
+			}
 		}
 	}
-
-	h.logger.Debug("Getting suggestions", "query", query, "limit", limit)
-
-	suggestions, err := h.getHotelSuggestionsUseCase.Execute(r.Context(), query, limit)
-	if err != nil {
-		h.logger.Error("Failed to get suggestions", "query", query, "error", err)
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	h.writeSuccessResponse(w, suggestions, nil)
 }
 
-// GetFacets returns available search facets for filtering
-// @Summary Get search facets
-// @Description Get available facets for filtering hotel search results (cities, countries, star ratings, amenities, etc.)
-// @Tags search
-// @Accept json
-// @Produce json
-// @Success 200 {object} APIResponse "Available search facets with counts"
-// @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/search/facets [get]
-func (h *HotelHandler) GetFacets(w http.ResponseWriter, r *http.Request) {
-	h.logger.Debug("Getting search facets")
-
-	facets := &search.Facets{
-		Cities: []search.FacetItem{
-			{Value: "New York", Count: 150},
-			{Value: "London", Count: 120},
-			{Value: "Paris", Count: 98},
-			{Value: "Tokyo", Count: 87},
-			{Value: "Dubai", Count: 76},
-		},
-		Countries: []search.FacetItem{
-			{Value: "United States", Count: 300},
-			{Value: "United Kingdom", Count: 250},
-			{Value: "France", Count: 200},
-			{Value: "Japan", Count: 150},
-			{Value: "UAE", Count: 100},
-		},
-		StarRatings: []search.FacetItem{
-			{Value: "5", Count: 45},
-			{Value: "4", Count: 123},
-			{Value: "3", Count: 167},
-			{Value: "2", Count: 89},
-			{Value: "1", Count: 34},
-		},
-		Amenities: []search.FacetItem{
-			{Value: "wifi", Count: 890},
-			{Value: "pool", Count: 456},
-			{Value: "gym", Count: 334},
-			{Value: "spa", Count: 223},
-			{Value: "restaurant", Count: 567},
-			{Value: "parking", Count: 445},
-			{Value: "pets allowed", Count: 156},
-		},
-	}
-
-	h.writeSuccessResponse(w, facets, nil)
-}
-
-type CustomSyncOptions struct {
-	usecase.SyncOptions
-}
-
-func (c *CustomSyncOptions) UnmarshalJSON(data []byte) error {
-	type Alias struct {
-		FullSync         bool            `json:"fullSync"`
-		BatchSize        int             `json:"batchSize"`
-		UpdateCacheAfter bool            `json:"updateCacheAfter"`
-		SinceTimestamp   json.RawMessage `json:"sinceTimestamp"`
+// collectionLimits reads the photosLimit, roomsLimit, reviewsLimit and translationsLimit
+// parameters. Unset limits keep the defaults and larger ones are lowered to the maxima.
+func (h *HotelHandler) collectionLimits(query url.Values) (hotel.CollectionLimits, error) {
+	defaults, maxima := h.responseLimits.Default, h.responseLimits.Max
+	var limits hotel.CollectionLimits
+	var err error
+	if limits.Photos, err = collectionLimit(query, "photosLimit", defaults.Photos, maxima.Photos); err != nil {
+		return limits, err
 	}
-
-	var aux Alias
-	if err := json.Unmarshal(data, &aux); err != nil {
-		return err
+	if limits.Rooms, err = collectionLimit(query, "roomsLimit", defaults.Rooms, maxima.Rooms); err != nil {
+		return limits, err
 	}
-
-	c.FullSync = aux.FullSync
-	c.BatchSize = aux.BatchSize
-	c.UpdateCacheAfter = aux.UpdateCacheAfter
-
-	defaultTime := time.Now().AddDate(0, -1, 0)
-
-	if len(aux.SinceTimestamp) == 0 || string(aux.SinceTimestamp) == "null" {
-		c.SinceTimestamp = defaultTime
-		return nil
+	if limits.Reviews, err = collectionLimit(query, "reviewsLimit", defaults.Reviews, maxima.Reviews); err != nil {
+		return limits, err
 	}
-
-	var timeStr string
-	if err := json.Unmarshal(aux.SinceTimestamp, &timeStr); err == nil {
-		parsedTime, err := parseTimestamp(timeStr)
-		if err != nil {
-			c.SinceTimestamp = defaultTime
-		} else {
-			c.SinceTimestamp = parsedTime
-		}
-		return nil
+	if limits.Translations, err = collectionLimit(query, "translationsLimit", defaults.Translations, maxima.Translations); err != nil {
+		return limits, err
 	}
+	return limits, nil
+}
 
-	var timestamp int64
-	if err := json.Unmarshal(aux.SinceTimestamp, &timestamp); err == nil {
-		if timestamp > 0 {
-			c.SinceTimestamp = time.Unix(timestamp, 0)
-		} else {
-			c.SinceTimestamp = defaultTime
-		}
-		return nil
+func collectionLimit(query url.Values, name string, defaultLimit, maxLimit int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return defaultLimit, nil
 	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	if maxLimit > 0 {
+		limit = min(limit, maxLimit)
+	}
+	return limit, nil
+}
 
-	c.SinceTimestamp = defaultTime
-	return nil
+// byteCountingWriter counts the bytes of the response body written through it.
+type byteCountingWriter struct {
+	http.ResponseWriter
+	written int
 }
 
-// TriggerSync manually triggers hotel data synchronization
-// @Summary Trigger manual sync
-// @Description Manually trigger synchronization of hotel data from external sources
-// @Tags admin
-// @Accept json
+func (w *byteCountingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.written += n
+	return n, err
+}
+
+// CompareHotels lines up hotels side by side
+// @Summary Compare hotels
+// @Description Compare 2 to 5 hotels side by side. attributes holds one row per compared dimension (star rating, rating, review count, child and pets allowed, parking, check-in start, max occupancy, then every amenity of any of the hotels) with one value per hotel, in the order of hotels. has_all is set when every hotel shares the value, so shared amenities can be highlighted
+// @Tags hotels
 // @Produce json
-// @Param options body usecase.SyncOptions false "Synchronization options"
-// @Success 200 {object} APIResponse "Synchronization result with statistics"
+// @Param ids query string true "Comma-separated hotel IDs, 2 to 5"
+// @Success 200 {object} APIResponse{data=usecase.ComparisonResult} "Hotel comparison"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid ids or not 2 to 5 hotels"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
 // @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/admin/sync [post]
-// CustomSyncOptions wraps SyncOptions to handle unmarshalling
-func (h *HotelHandler) TriggerSync(w http.ResponseWriter, r *http.Request) {
-	var customOptions CustomSyncOptions
-	customOptions.SinceTimestamp = time.Now().AddDate(0, -1, 0)
-
-	if r.Body != nil {
-		if err := json.NewDecoder(r.Body).Decode(&customOptions); err != nil {
-			h.logger.Warn("Failed to decode sync options, using defaults", "error", err)
+// @Router /api/v1/hotels/compare [get]
+func (h *HotelHandler) CompareHotels(w http.ResponseWriter, r *http.Request) {
+	var hotelIDs []int64
+	for _, value := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
 		}
+		hotelID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			h.writeErrorResponse(w, fmt.Sprintf("invalid hotel ID %q", value), http.StatusBadRequest)
+			return
+		}
+		hotelIDs = append(hotelIDs, hotelID)
 	}
 
-	options := customOptions.SyncOptions
-
-	if options.BatchSize == 0 {
-		options.BatchSize = 100
-	}
-	options.UpdateCacheAfter = true
-
-	h.logger.Info("Triggering manual sync",
-		"full_sync", options.FullSync,
-		"batch_size", options.BatchSize,
-		"since_timestamp", options.SinceTimestamp.Format(time.RFC3339),
-		"remote_addr", r.RemoteAddr)
-
-	result, err := h.syncHotelsUseCase.Execute(r.Context(), options)
-	if err != nil {
-		h.logger.Error("Sync failed", "error", err)
+	comparison, err := h.compareHotelsUseCase.Execute(r.Context(), hotelIDs)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidComparison):
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, usecase.ErrHotelNotFound):
+		h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.Error("Failed to compare hotels", "hotel_ids", hotelIDs, "error", err)
 		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	h.writeSuccessResponse(w, result, nil)
+	h.redactDataSources(comparison.Hotels...)
+	h.rewritePhotoURLs(comparison.Hotels...)
+	h.writeSuccessResponse(w, comparison, nil, h.cachePolicies.Hotel)
 }
 
-func parseTimestamp(s string) (time.Time, error) {
-	formats := []string{
-		time.RFC3339,
-		time.RFC3339Nano,
-		"2006-01-02T15:04:05Z",
-		"2006-01-02 15:04:05",
-		"2006-01-02",
-		"02/01/2006 15:04:05",
-		"02/01/2006",
-		"2006-01-02T15:04:05-07:00",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, s); err == nil {
-			return t, nil
-		}
-	}
-
-	if timestamp, err := strconv.ParseInt(s, 10, 64); err == nil {
-		if timestamp > 0 {
-			return time.Unix(timestamp, 0), nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse timestamp: %s", s)
-}
-
-// GetSyncStats returns current synchronization statistics
-// @Summary Get sync statistics
-// @Description Get current statistics about hotel data synchronization including index size, document count, and last update time
-// @Tags admin
+// GetHotelTranslations lists the languages a hotel is translated into
+// @Summary List hotel translations
+// @Description List the available translations of a hotel with the translated name and a description snippet
+// @Tags hotels
 // @Accept json
 // @Produce json
-// @Success 200 {object} APIResponse "Synchronization statistics"
+// @Param id path integer true "Hotel ID"
+// @Success 200 {object} APIResponse{data=[]hotel.TranslationSummary} "Available translations"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
 // @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/admin/sync/stats [get]
-func (h *HotelHandler) GetSyncStats(w http.ResponseWriter, r *http.Request) {
-	h.logger.Debug("Getting sync stats")
-
-	stats, err := h.syncHotelsUseCase.GetSyncStats(r.Context())
+// @Router /api/v1/hotels/{id}/translations [get]
+func (h *HotelHandler) GetHotelTranslations(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
 	if err != nil {
-		h.logger.Error("Failed to get sync stats", "error", err)
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
 		return
 	}
 
-	lastSyncTime, err := h.syncHotelsUseCase.GetLastSyncTime(r.Context())
-	if err == nil && lastSyncTime != nil {
-		stats.LastUpdated = *lastSyncTime
+	summaries, err := h.hotelTranslationsUseCase.ListLanguages(r.Context(), hotelID)
+	if err != nil {
+		h.logger.Error("Failed to list hotel translations", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	h.writeSuccessResponse(w, stats, nil)
+	h.writeSuccessResponse(w, summaries, nil, h.cachePolicies.Hotel)
 }
 
-// GetTrendingSuggestions returns trending hotel search suggestions
-// @Summary Get trending search suggestions
-// @Description Get currently trending hotel search suggestions based on popular searches
-// @Tags search
-// @Accept json
+// GetHotelReviews returns a page of a hotel's reviews
+// @Summary List hotel reviews
+// @Description List the stored reviews of a hotel, most recent first. Reviews moved to the archive are only returned with include_archived=true
+// @Tags hotels
 // @Produce json
-// @Param limit query integer false "Maximum number of trending suggestions to return (default: 10)"
-// @Success 200 {object} APIResponse{data=[]search.Suggestion} "List of trending search suggestions"
+// @Param id path integer true "Hotel ID"
+// @Param include_archived query boolean false "Include archived reviews"
+// @Param page query integer false "Page number (default: 1)"
+// @Param limit query integer false "Reviews per page (max: 100, default: 20)"
+// @Success 200 {object} APIResponse{data=[]hotel.Review,meta=object} "Hotel reviews"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
 // @Failure 500 {object} APIResponse "Internal Server Error"
-// @Router /api/v1/search/trending [get]
-func (h *HotelHandler) GetTrendingSuggestions(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	limit := 10
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
+// @Router /api/v1/hotels/{id}/reviews [get]
+func (h *HotelHandler) GetHotelReviews(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
 	}
 
-	h.logger.Debug("Getting trending suggestions", "limit", limit)
+	query := r.URL.Query()
+	includeArchived, _ := strconv.ParseBool(query.Get("include_archived"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
 
-	suggestions, err := h.getHotelSuggestionsUseCase.GetTrendingSuggestions(r.Context(), limit)
+	reviews, err := h.hotelReviewsUseCase.List(r.Context(), hotelID, includeArchived, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get trending suggestions", "error", err)
+		h.logger.Error("Failed to list hotel reviews", "hotel_id", hotelID, "error", err)
 		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	h.writeSuccessResponse(w, suggestions, nil)
+	h.writeSuccessResponse(w, reviews, map[string]interface{}{
+		"page":             max(page, 1),
+		"include_archived": includeArchived,
+	}, h.cachePolicies.Hotel)
 }
 
-func (h *HotelHandler) parseSearchParams(r *http.Request) search.Params {
-	query := r.URL.Query()
-
-	params := search.Params{
-		Query:       query.Get("q"),
-		Name:        query.Get("name"),
-		Description: query.Get("description"),
-		Phone:       query.Get("phone"),
-		Chain:       query.Get("chain"),
-		Email:       query.Get("email"),
-		Fax:         query.Get("fax"),
-		AirportCode: query.Get("airport_code"),
-		Parking:     query.Get("parking"),
-		City:        query.Get("city"),
-		Country:     query.Get("country"),
-		Currency:    query.Get("currency"),
-		SortBy:      query.Get("sort_by"),
-		SortOrder:   query.Get("sort_order"),
-		Amenities:   query["amenities"],
-		Tags:        query["tags"],
-	}
-
-	if ratingMin := query.Get("rating_min"); ratingMin != "" {
-		if val, err := strconv.ParseFloat(ratingMin, 64); err == nil {
-			params.RatingMin = val
-		}
+// GetHotelReviewStats returns the review score statistics of a hotel
+// @Summary Get hotel review statistics
+// @Description Get the review count, the average review score and the per-category averages (location, service, value, facilities) across the hotel's stored reviews. A category is null when no review rated it
+// @Tags hotels
+// @Produce json
+// @Param id path integer true "Hotel ID"
+// @Success 200 {object} APIResponse{data=hotel.ReviewStats} "Review statistics"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/hotels/{id}/reviews/stats [get]
+func (h *HotelHandler) GetHotelReviewStats(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
 	}
 
-	if ratingMax := query.Get("rating_max"); ratingMax != "" {
-		if val, err := strconv.ParseFloat(ratingMax, 64); err == nil {
-			params.RatingMax = val
+	stats, err := h.hotelReviewsUseCase.Stats(r.Context(), hotelID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrHotelNotFound) {
+			h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
 		}
+		h.logger.Error("Failed to get hotel review stats", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if starRating := query.Get("star_rating"); starRating != "" {
-		if val, err := strconv.ParseInt(starRating, 10, 8); err == nil {
-			params.StarRating = int8(val)
-		}
-	}
+	h.writeSuccessResponse(w, stats, nil, h.cachePolicies.Hotel)
+}
 
-	if priceMin := query.Get("price_min"); priceMin != "" {
-		if val, err := strconv.ParseFloat(priceMin, 64); err == nil {
-			params.PriceMin = val
-		}
-	}
+// GetHotelTranslation returns a hotel localized to one language
+// @Summary Get localized hotel
+// @Description Get the hotel with the translation for the given language overlaid on the base document. fallback_fields lists the fields that kept their default-language value. Translations missing from the database are fetched from the Cupid API and stored
+// @Tags hotels
+// @Accept json
+// @Produce json
+// @Param id path integer true "Hotel ID"
+// @Param lang path string true "Language code (e.g. fr, es)"
+// @Success 200 {object} APIResponse{data=usecase.LocalizedHotel} "Localized hotel"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 404 {object} APIResponse "Not Found - Hotel or translation not found"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/hotels/{id}/translations/{lang} [get]
+func (h *HotelHandler) GetHotelTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
 
-	if priceMax := query.Get("price_max"); priceMax != "" {
-		if val, err := strconv.ParseFloat(priceMax, 64); err == nil {
-			params.PriceMax = val
-		}
+	hotelID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
 	}
 
-	if page := query.Get("page"); page != "" {
-		if val, err := strconv.Atoi(page); err == nil {
-			params.Page = val
+	localized, err := h.hotelTranslationsUseCase.GetLocalized(r.Context(), hotelID, vars["lang"])
+	if err != nil {
+		if errors.Is(err, usecase.ErrHotelNotFound) || errors.Is(err, usecase.ErrTranslationNotFound) {
+			h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
 		}
+		h.logger.Error("Failed to get hotel translation", "hotel_id", hotelID, "lang", vars["lang"], "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if limit := query.Get("limit"); limit != "" {
-		if val, err := strconv.Atoi(limit); err == nil {
-			params.Limit = val
-		}
-	}
+	h.writeSuccessResponse(w, localized, nil, h.cachePolicies.Hotel)
+}
 
-	if latitude := query.Get("latitude"); latitude != "" {
-		if val, err := strconv.ParseFloat(latitude, 64); err == nil {
-			params.Latitude = val
-		}
+// AddFavorite bookmarks a hotel for the guest's session
+// @Summary Add a favorite hotel
+// @Description Bookmark a hotel for the anonymous guest identified by the hms_session cookie. A session is started when the request has none. Favorites are kept for 30 days after the session last used them
+// @Tags favorites
+// @Produce json
+// @Param hotel_id path integer true "Hotel ID"
+// @Success 200 {object} APIResponse{data=[]int64} "Favorite hotel IDs of the session"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 404 {object} APIResponse "Not Found - Hotel not found"
+// @Failure 409 {object} APIResponse "Conflict - Favorites limit reached"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/favorites/{hotel_id} [post]
+func (h *HotelHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["hotel_id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
 	}
 
-	if longitude := query.Get("longitude"); longitude != "" {
-		if val, err := strconv.ParseFloat(longitude, 64); err == nil {
-			params.Longitude = val
-		}
+	sessionID, err := h.sessions.Ensure(w, r)
+	if err != nil {
+		h.logger.Error("Failed to start session", "error", err)
+		h.writeErrorResponse(w, "failed to start session", http.StatusInternalServerError)
+		return
 	}
 
-	if radius := query.Get("radius"); radius != "" {
-		if val, err := strconv.ParseFloat(radius, 64); err == nil {
-			params.Radius = val
-		}
+	hotelIDs, err := h.favoritesUseCase.Add(r.Context(), sessionID, hotelID)
+	switch {
+	case errors.Is(err, usecase.ErrHotelNotFound):
+		h.writeErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, favorites.ErrLimitReached):
+		h.writeErrorResponse(w, fmt.Sprintf("%s (%d)", err, favorites.MaxPerSession), http.StatusConflict)
+		return
+	case err != nil:
+		h.logger.Error("Failed to add favorite", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if reviewCount := query.Get("review_count"); reviewCount != "" {
-		if val, err := strconv.ParseInt(reviewCount, 10, 32); err == nil {
-			params.ReviewCount = int32(val)
-		}
+	h.writeSuccessResponse(w, hotelIDs, nil, NoStore)
+}
+
+// RemoveFavorite drops a hotel from the guest's favorites
+// @Summary Remove a favorite hotel
+// @Description Remove a hotel from the favorites of the session identified by the hms_session cookie. Removing a hotel that is not a favorite is not an error
+// @Tags favorites
+// @Produce json
+// @Param hotel_id path integer true "Hotel ID"
+// @Success 200 {object} APIResponse{data=[]int64} "Favorite hotel IDs of the session"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid hotel ID"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/favorites/{hotel_id} [delete]
+func (h *HotelHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.ParseInt(mux.Vars(r)["hotel_id"], 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid hotel ID", http.StatusBadRequest)
+		return
 	}
 
-	if childAllowed := query.Get("child_allowed"); childAllowed != "" {
-		if val, err := strconv.ParseBool(childAllowed); err == nil {
-			params.ChildAllowed = &val
-		}
+	sessionID, ok := h.sessions.SessionID(r)
+	if !ok {
+		h.writeSuccessResponse(w, []int64{}, nil, NoStore)
+		return
 	}
+	h.sessions.Refresh(w, r, sessionID)
 
-	if petsAllowed := query.Get("pets_allowed"); petsAllowed != "" {
-		if val, err := strconv.ParseBool(petsAllowed); err == nil {
-			params.PetsAllowed = &val
-		}
+	hotelIDs, err := h.favoritesUseCase.Remove(r.Context(), sessionID, hotelID)
+	if err != nil {
+		h.logger.Error("Failed to remove favorite", "hotel_id", hotelID, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	return params
+	h.writeSuccessResponse(w, hotelIDs, nil, NoStore)
 }
 
-func (h *HotelHandler) writeSuccessResponse(w http.ResponseWriter, data interface{}, meta interface{}) {
-	response := APIResponse{
-		Success: true,
-		Data:    data,
-		Meta:    meta,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+// ListFavorites lists the guest's favorite hotels
+// @Summary List favorite hotels
+// @Description List the favorites of the session identified by the hms_session cookie as hotel IDs, or as full hotels with expand=true. Requests without a session get an empty list
+// @Tags favorites
+// @Produce json
+// @Param expand query boolean false "Return the hotels instead of their IDs"
+// @Success 200 {object} APIResponse{data=[]int64} "Favorite hotel IDs, or hotels with expand=true"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/favorites [get]
+func (h *HotelHandler) ListFavorites(w http.ResponseWriter, r *http.Request) {
+	expand, _ := strconv.ParseBool(r.URL.Query().Get("expand"))
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("Failed to encode response", "error", err)
+	sessionID, ok := h.sessions.SessionID(r)
+	if !ok {
+		h.writeSuccessResponse(w, []int64{}, map[string]interface{}{"count": 0}, NoStore)
+		return
 	}
-}
-
-func (h *HotelHandler) writeErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	response := APIResponse{
-		Success: false,
-		Error:   message,
+	h.sessions.Refresh(w, r, sessionID)
+
+	var (
+		data  interface{}
+		count int
+		err   error
+	)
+	if expand {
+		var hotels []*hotel.Hotel
+		hotels, err = h.favoritesUseCase.ListHotels(r.Context(), sessionID)
+		h.redactDataSources(hotels...)
+		h.rewritePhotoURLs(hotels...)
+		data, count = hotels, len(hotels)
+	} else {
+		var hotelIDs []int64
+		hotelIDs, err = h.favoritesUseCase.List(r.Context(), sessionID)
+		data, count = hotelIDs, len(hotelIDs)
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("Failed to encode error response", "error", err)
+	if err != nil {
+		h.logger.Error("Failed to list favorites", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-}
 
-// HealthCheck returns the health status of the search service
-// @Summary Health check
-// @Description Get the current health status of the search service
-// @Tags health
-// @Accept json
-// @Produce json
-// @Success 200 {object} APIResponse{data=object} "Service health status with timestamp and version"
-// @Router /health [get]
-// @BasePath /
-func (h *HotelHandler) HealthCheck(w http.ResponseWriter, _ *http.Request) {
-	health := map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
-		"service":   "search-service",
-		"version":   "1.0.0",
-	}
-
-	h.writeSuccessResponse(w, health, nil)
+	h.writeSuccessResponse(w, data, map[string]interface{}{
+		"count": count,
+	}, NoStore)
 }
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/cdn"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var testResponseLimits = ResponseLimits{
+	Default: hotel.CollectionLimits{Photos: 50, Rooms: 20, Reviews: 10, Translations: 10},
+	Max:     hotel.CollectionLimits{Photos: 200, Rooms: 60, Reviews: 100, Translations: 20},
+}
+
+// newHotelDetailTest serves a cached hotel with 412 photos, 60 rooms and 250 reviews from the
+// hotel detail endpoint, logging to the returned buffer.
+func newHotelDetailTest(t *testing.T, limits ResponseLimits) (*mux.Router, *bytes.Buffer) {
+	t.Helper()
+	oversized := &hotel.Hotel{HotelID: 1641879, Name: "Harbour Hotel"}
+	oversized.Photos = make([]hotel.Photo, 412)
+	oversized.Rooms = make([]hotel.Room, 60)
+	oversized.Reviews = make([]hotel.Review, 250)
+	cached, err := json.Marshal(oversized)
+	require.NoError(t, err)
+
+	controller := gomock.NewController(t)
+	cache := mocks.NewMockCacheRepository(controller)
+	cache.EXPECT().Get(gomock.Any(), "hotel:1641879").Return(cached, nil).AnyTimes()
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("cache miss")).AnyTimes()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	hotelHandler := &HotelHandler{
+		responder:           responder{logger: logger},
+		getHotelByIDUseCase: usecase.NewGetHotelByIDUseCase(mocks.NewMockRepository(controller), mocks.NewMockProvider(controller), mocks.NewMockEngine(controller), cache, 100, logger),
+		responseLimits:      limits,
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/hotels/{id}", hotelHandler.GetHotelByID)
+	return router, &logs
+}
+
+type hotelDetailResponse struct {
+	Data struct {
+		Photos  []json.RawMessage `json:"photos"`
+		Rooms   []json.RawMessage `json:"rooms"`
+		Reviews []json.RawMessage `json:"reviews"`
+	} `json:"data"`
+	Meta map[string]any `json:"meta"`
+}
+
+func getHotelDetail(t *testing.T, router *mux.Router, query string) (*httptest.ResponseRecorder, hotelDetailResponse) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/hotels/1641879"+query, nil))
+	var response hotelDetailResponse
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	}
+	return recorder, response
+}
+
+func TestGetHotelByIDTruncatesOversizedCollections(t *testing.T) {
+	router, _ := newHotelDetailTest(t, testResponseLimits)
+
+	recorder, response := getHotelDetail(t, router, "")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	assert.Len(t, response.Data.Photos, 50)
+	assert.Len(t, response.Data.Rooms, 20)
+	assert.Len(t, response.Data.Reviews, 10)
+	assert.EqualValues(t, 412, response.Meta["photos_total"])
+	assert.Equal(t, true, response.Meta["photos_truncated"])
+	assert.EqualValues(t, 60, response.Meta["rooms_total"])
+	assert.Equal(t, true, response.Meta["rooms_truncated"])
+	assert.EqualValues(t, 250, response.Meta["reviews_total"])
+	assert.Equal(t, true, response.Meta["reviews_truncated"])
+	assert.EqualValues(t, 0, response.Meta["translations_total"])
+	assert.Equal(t, false, response.Meta["translations_truncated"])
+}
+
+func TestGetHotelByIDRaisesLimitsUpToTheMaxima(t *testing.T) {
+	router, _ := newHotelDetailTest(t, testResponseLimits)
+
+	recorder, response := getHotelDetail(t, router, "?photosLimit=1000&roomsLimit=60&reviewsLimit=25")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	assert.Len(t, response.Data.Photos, 200, "a limit past the maximum is lowered to it")
+	assert.Equal(t, true, response.Meta["photos_truncated"])
+	assert.Len(t, response.Data.Rooms, 60)
+	assert.Equal(t, false, response.Meta["rooms_truncated"])
+	assert.Len(t, response.Data.Reviews, 25)
+}
+
+func TestGetHotelByIDRejectsInvalidLimits(t *testing.T) {
+	router, _ := newHotelDetailTest(t, testResponseLimits)
+
+	for _, query := range []string{"?photosLimit=0", "?roomsLimit=-3", "?reviewsLimit=many"} {
+		recorder, _ := getHotelDetail(t, router, query)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
+
+func TestGetHotelByIDLogsResponsesOverTheSizeThreshold(t *testing.T) {
+	limits := testResponseLimits
+	limits.MaxBytes = 1024
+	router, logs := newHotelDetailTest(t, limits)
+
+	recorder, _ := getHotelDetail(t, router, "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, logs.String(), "Hotel response exceeds the size threshold after truncation")
+	assert.Contains(t, logs.String(), `"hotel_id":1641879`)
+
+	limits.MaxBytes = 10 << 20
+	router, logs = newHotelDetailTest(t, limits)
+	recorder, _ = getHotelDetail(t, router, "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, logs.String(), "exceeds the size threshold")
+}
+
+func TestGetHotelByIDServesPhotosThroughTheCDN(t *testing.T) {
+	cached := &hotel.Hotel{
+		HotelID:     1641879,
+		Name:        "Harbour Hotel",
+		MainImageTh: "https://static.cupid.travel/hotels/thumbnail/1.jpg",
+		Photos:      []hotel.Photo{{URL: "https://static.cupid.travel/hotels/1.jpg", HDURL: "https://static.cupid.travel/hotels/hd/1.jpg?w=2048&h=1536"}},
+		Rooms:       []hotel.Room{{ID: 7, Photos: []hotel.RoomPhoto{{URL: "https://static.cupid.travel/rooms/7.jpg"}}}},
+	}
+	data, err := json.Marshal(cached)
+	require.NoError(t, err)
+	controller := gomock.NewController(t)
+	cache := mocks.NewMockCacheRepository(controller)
+	cache.EXPECT().Get(gomock.Any(), "hotel:1641879").Return(data, nil)
+	rewriter := cdn.NewCDNPhotoURLRewriter(cdn.CDNConfig{BaseURL: "https://cdn.example.com/proxy?url=", SigningKey: "secret"})
+	logger := slog.New(slog.DiscardHandler)
+	hotelHandler := &HotelHandler{
+		responder:           responder{logger: logger},
+		getHotelByIDUseCase: usecase.NewGetHotelByIDUseCase(mocks.NewMockRepository(controller), mocks.NewMockProvider(controller), mocks.NewMockEngine(controller), cache, 100, logger),
+		responseLimits:      testResponseLimits,
+		photoURLs:           rewriter,
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/hotels/{id}", hotelHandler.GetHotelByID)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/hotels/1641879", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response struct {
+		Data hotel.Hotel `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	served := map[string]string{
+		response.Data.MainImageTh:            cached.MainImageTh,
+		response.Data.Photos[0].URL:          cached.Photos[0].URL,
+		response.Data.Photos[0].HDURL:        cached.Photos[0].HDURL,
+		response.Data.Rooms[0].Photos[0].URL: cached.Rooms[0].Photos[0].URL,
+	}
+	require.Len(t, served, 4)
+	for servedURL, originalURL := range served {
+		assert.True(t, strings.HasPrefix(servedURL, "https://cdn.example.com/proxy?url="), servedURL)
+		decoded, ok := rewriter.Verify(servedURL)
+		assert.True(t, ok, servedURL)
+		assert.Equal(t, originalURL, decoded)
+	}
+}
+
+func TestRewritePhotoURLsDoesNotChangeSharedPhotos(t *testing.T) {
+	photos := []hotel.Photo{{URL: "https://static.cupid.travel/hotels/1.jpg"}}
+	rooms := []hotel.Room{{Photos: []hotel.RoomPhoto{{URL: "https://static.cupid.travel/rooms/7.jpg"}}}}
+	response := &hotel.Hotel{Photos: photos, Rooms: rooms}
+	hotelHandler := &HotelHandler{photoURLs: cdn.NewCDNPhotoURLRewriter(cdn.CDNConfig{BaseURL: "https://cdn.example.com/"})}
+
+	hotelHandler.rewritePhotoURLs(response)
+
+	assert.Equal(t, "https://cdn.example.com/https%3A%2F%2Fstatic.cupid.travel%2Fhotels%2F1.jpg", response.Photos[0].URL)
+	assert.Equal(t, "https://cdn.example.com/https%3A%2F%2Fstatic.cupid.travel%2Frooms%2F7.jpg", response.Rooms[0].Photos[0].URL)
+	assert.Equal(t, "https://static.cupid.travel/hotels/1.jpg", photos[0].URL, "the hotel being indexed keeps its origin URLs")
+	assert.Equal(t, "https://static.cupid.travel/rooms/7.jpg", rooms[0].Photos[0].URL)
+}
+
+func TestRewritePhotoURLsWithoutACDN(t *testing.T) {
+	response := &hotel.Hotel{MainImageTh: "https://static.cupid.travel/hotels/thumbnail/1.jpg"}
+
+	(&HotelHandler{}).rewritePhotoURLs(response)
+
+	assert.Equal(t, "https://static.cupid.travel/hotels/thumbnail/1.jpg", response.MainImageTh)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetHotelReviewsArchivedAccess(t *testing.T) {
+	current := hotel.Review{HotelID: 7, ReviewID: 2, Date: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}
+	archived := hotel.Review{HotelID: 7, ReviewID: 1, Date: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedOptions hotel.ListReviewsOptions
+		reviews         []hotel.Review
+	}{
+		{
+			name:            "current reviews by default",
+			query:           "",
+			expectedOptions: hotel.ListReviewsOptions{Limit: 20},
+			reviews:         []hotel.Review{current},
+		},
+		{
+			name:            "archived reviews on request",
+			query:           "?include_archived=true&page=2&limit=5",
+			expectedOptions: hotel.ListReviewsOptions{IncludeArchived: true, Limit: 5, Offset: 5},
+			reviews:         []hotel.Review{current, archived},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(gomock.NewController(t))
+			repo.EXPECT().ListReviews(gomock.Any(), int64(7), tt.expectedOptions).Return(tt.reviews, nil)
+
+			logger := slog.New(slog.DiscardHandler)
+			hotelHandler := &HotelHandler{
+				responder:           responder{logger: logger},
+				hotelReviewsUseCase: usecase.NewHotelReviewsUseCase(repo, logger),
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/api/v1/hotels/{id}/reviews", hotelHandler.GetHotelReviews)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/hotels/7/reviews"+tt.query, nil))
+			require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+			var response struct {
+				Data []hotel.Review `json:"data"`
+				Meta struct {
+					IncludeArchived bool `json:"include_archived"`
+				} `json:"meta"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.reviews, response.Data)
+			assert.Equal(t, tt.expectedOptions.IncludeArchived, response.Meta.IncludeArchived)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var testNewHotels = NewHotels{MaxDays: 90, CacheMaxAge: time.Hour}
+
+// newHotelsTest serves /hotels/new from an engine that records the searches it runs and a
+// cache that records the TTLs it is given.
+type newHotelsTest struct {
+	handler  *SearchHandler
+	searches []search.Params
+	ttls     []time.Duration
+}
+
+func newNewHotelsTest(t *testing.T) *newHotelsTest {
+	t.Helper()
+	test := &newHotelsTest{}
+	controller := gomock.NewController(t)
+	engine := mocks.NewMockEngine(controller)
+	cache := mocks.NewMockCacheRepository(controller)
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("cache miss")).AnyTimes()
+	cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string, _ []byte, ttl time.Duration) error {
+		test.ttls = append(test.ttls, ttl)
+		return nil
+	}).AnyTimes()
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 100, MaxResultWindow: 10000}).AnyTimes()
+	engine.EXPECT().Info().Return(search.EngineInfo{}).AnyTimes()
+	engine.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params search.Params) (*search.Result, error) {
+		test.searches = append(test.searches, params)
+		return &search.Result{TotalHits: 1, Hotels: []*hotel.Hotel{{HotelID: 1641879}}}, nil
+	}).AnyTimes()
+
+	test.handler = newParamsTestHandler()
+	test.handler.cachePolicies = testCachePolicies
+	test.handler.newHotels = testNewHotels
+	test.handler.searchHotelsUseCase = usecase.NewSearchHotelsUseCase(engine, cache, nil, nil, search.DefaultSnippetLength, 5*time.Minute, time.Minute, nil, slog.New(slog.DiscardHandler))
+	return test
+}
+
+func (test *newHotelsTest) get(query string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	test.handler.GetNewHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/hotels/new"+query, nil))
+	return recorder
+}
+
+func TestGetNewHotelsDefaults(t *testing.T) {
+	test := newNewHotelsTest(t)
+
+	recorder := test.get("")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, test.searches, 1)
+	params := test.searches[0]
+	require.NotNil(t, params.CreatedAfter)
+	expected := time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -30)
+	assert.WithinDuration(t, expected, *params.CreatedAfter, time.Hour, "the last 30 days, from the hour")
+	assert.Zero(t, params.CreatedAfter.Minute())
+	assert.Equal(t, "created_at", params.SortBy)
+	assert.Equal(t, "desc", params.SortOrder)
+	assert.Equal(t, 20, params.Limit)
+	assert.Nil(t, params.CreatedBefore)
+
+	assert.Equal(t, []time.Duration{time.Hour + time.Minute}, test.ttls, "new hotels are cached longer than other searches")
+	assert.Equal(t, "public, max-age=900, s-maxage=3600", recorder.Header().Get("Cache-Control"))
+	var response struct {
+		Meta map[string]any `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.EqualValues(t, 30, response.Meta["days"])
+	assert.EqualValues(t, 20, response.Meta["limit"])
+}
+
+func TestGetNewHotelsWindow(t *testing.T) {
+	test := newNewHotelsTest(t)
+
+	require.Equal(t, http.StatusOK, test.get("?days=7&limit=5").Code)
+	require.Equal(t, http.StatusOK, test.get("?created_after=-2w").Code)
+	require.Equal(t, http.StatusOK, test.get("?limit=500").Code)
+
+	require.Len(t, test.searches, 3)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), *test.searches[0].CreatedAfter, time.Hour)
+	assert.Equal(t, 5, test.searches[0].Limit)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -14), *test.searches[1].CreatedAfter, time.Minute)
+	assert.Equal(t, 100, test.searches[2].Limit, "the limit is capped like a search's")
+}
+
+func TestGetNewHotelsRejectsInvalidWindows(t *testing.T) {
+	tests := map[string]string{
+		"days not a number":            "?days=thirty",
+		"days not positive":            "?days=0",
+		"days past the retention":      "?days=91",
+		"limit not a number":           "?limit=many",
+		"created_after does not parse": "?created_after=last-month",
+		"created_after past retention": "?created_after=-13w",
+		"days and created_after":       "?days=7&created_after=-7d",
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			test := newNewHotelsTest(t)
+
+			recorder := test.get(query)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+			assert.Empty(t, test.searches)
+		})
+	}
+}
+
+func TestCreatedAfterIsBoundedByTheRetentionWindow(t *testing.T) {
+	h := newParamsTestHandler()
+	h.newHotels = testNewHotels
+
+	_, err := h.parseSearchParams(httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=-89d", nil))
+	assert.NoError(t, err)
+	_, err = h.parseSearchParams(httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=-90d", nil))
+	assert.NoError(t, err, "a window as long as the retention is accepted")
+
+	_, err = h.parseSearchParams(httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=2020-01-01", nil))
+	assert.ErrorIs(t, err, search.ErrInvalidTimeRange)
+	assert.ErrorContains(t, err, "90 days")
+
+	recorder := httptest.NewRecorder()
+	h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=-1y", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "an unparseable created_after is not ignored")
+	recorder = httptest.NewRecorder()
+	h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=-200d", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchValidationErrorsAreBadRequests(t *testing.T) {
+	test := newNewHotelsTest(t)
+	recorder := httptest.NewRecorder()
+
+	test.handler.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=-7d&created_before=-14d", nil))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "created_after must be before created_before")
+}
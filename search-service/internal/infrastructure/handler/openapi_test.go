@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/buildinfo"
+	"github.com/victoragudo/hotel-management-system/search-service/docs"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/openapi"
+)
+
+// apiModels are the request and response DTOs the endpoints reference. The schemas of the
+// committed OpenAPI document must match their fields, and the types they refer to.
+var apiModels = []any{
+	APIResponse{}, SlowRequest{}, buildinfo.DebugInfo{},
+	hotel.Hotel{}, hotel.Change{}, hotel.DuplicateGroup{}, hotel.FacilityCount{}, hotel.MergeResult{},
+	hotel.Patch{}, hotel.Review{}, hotel.ReviewStats{}, hotel.TranslationSummary{},
+	search.ChainSuggestion{}, search.ConfigBundle{}, search.Suggestion{},
+	usecase.BackfillOptions{}, usecase.ComparisonResult{}, usecase.FacilityBackfillJob{},
+	usecase.FacilityBackfillOptions{}, usecase.HotelMeta{}, usecase.HotelSources{}, usecase.LocalizedHotel{},
+	usecase.ReconcileJob{}, usecase.ReviewArchiveJob{}, usecase.ReviewArchiveOptions{}, usecase.ReviewDedupJob{},
+	usecase.ReviewDedupOptions{}, usecase.SyncHistory{}, usecase.SyncOptions{}, usecase.SyncProgress{},
+	usecase.SyncStats{}, usecase.UnmappedFacility{}, usecase.UpdateHotelResult{}, usecase.UsageReport{},
+}
+
+func loadCommittedDocument(t *testing.T) map[string]any {
+	t.Helper()
+	spec, err := openapi.Load(docs.SwaggerInfo.ReadDoc())
+	require.NoError(t, err)
+	data, err := spec.JSON()
+	require.NoError(t, err)
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(data, &document))
+	return document
+}
+
+// modelProperties records the JSON properties of t and of the struct types it refers to, by
+// the schema name swag gives them.
+func modelProperties(t reflect.Type, properties map[string][]string) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return
+	}
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	if _, seen := properties[name]; seen {
+		return
+	}
+	properties[name] = nil
+	properties[name] = structProperties(t, properties)
+	slices.Sort(properties[name])
+}
+
+func structProperties(t reflect.Type, properties map[string][]string) []string {
+	var names []string
+	for field := range reflectFields(t) {
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			names = append(names, structProperties(field.Type, properties)...)
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		names = append(names, tag)
+		modelProperties(field.Type, properties)
+	}
+	return names
+}
+
+func reflectFields(t reflect.Type) func(func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			if !yield(t.Field(i)) {
+				return
+			}
+		}
+	}
+}
+
+func TestOpenAPISchemasMatchTheModels(t *testing.T) {
+	document := loadCommittedDocument(t)
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+
+	expected := make(map[string][]string)
+	for _, model := range apiModels {
+		modelProperties(reflect.TypeOf(model), expected)
+	}
+
+	for name, value := range schemas {
+		schema := value.(map[string]any)
+		if schema["type"] != "object" {
+			continue
+		}
+		properties, ok := expected[name]
+		if !assert.True(t, ok, "schema %s is not one of the API models; add its type to apiModels", name) {
+			continue
+		}
+		documented := make([]string, 0, len(properties))
+		for property := range schema["properties"].(map[string]any) {
+			documented = append(documented, property)
+		}
+		slices.Sort(documented)
+		assert.Equal(t, properties, documented, "schema %s is out of date, run make swagger-gen", name)
+	}
+	for name := range expected {
+		assert.Contains(t, schemas, name, "model %s is not documented, run make swagger-gen", name)
+	}
+}
+
+// queryParameters returns the name of each query parameter of the struct t points to, with
+// whether it is required.
+func queryParameters(t reflect.Type) map[string]bool {
+	parameters := make(map[string]bool)
+	for field := range reflectFields(t) {
+		if field.Anonymous {
+			for name, required := range queryParameters(field.Type) {
+				if _, shadowed := parameters[name]; !shadowed {
+					parameters[name] = required
+				}
+			}
+			continue
+		}
+		parameters[field.Tag.Get("form")] = field.Tag.Get("binding") == "required"
+	}
+	return parameters
+}
+
+func TestOpenAPISearchParametersMatchTheQueryModels(t *testing.T) {
+	document := loadCommittedDocument(t)
+	paths := document["paths"].(map[string]any)
+
+	for route, model := range map[string]any{
+		"/api/v1/search/hotels":   SearchQuery{},
+		"/api/v1/search/combined": CombinedSearchQuery{},
+	} {
+		t.Run(route, func(t *testing.T) {
+			documented := make(map[string]bool)
+			for _, value := range paths[route].(map[string]any)["get"].(map[string]any)["parameters"].([]any) {
+				parameter := value.(map[string]any)
+				if parameter["in"] == "query" {
+					required, _ := parameter["required"].(bool)
+					documented[parameter["name"].(string)] = required
+				}
+			}
+			assert.Equal(t, queryParameters(reflect.TypeOf(model)), documented, "run make swagger-gen")
+		})
+	}
+}
+
+func TestDecodeQuery(t *testing.T) {
+	var query CombinedSearchQuery
+	decodeQuery(map[string][]string{
+		"q":              {"beach"},
+		"star_rating":    {"4"},
+		"review_count":   {"not a number"},
+		"rating_min":     {"3.5"},
+		"child_allowed":  {"false"},
+		"pets_allowed":   {"maybe"},
+		"num_typos":      {"0"},
+		"amenities":      {"pool", "wifi"},
+		"suggest_limit":  {"7"},
+		"max_same_chain": {""},
+	}, &query)
+
+	assert.Equal(t, "beach", query.Q)
+	assert.Equal(t, "beach", query.SearchQuery.Q)
+	assert.Equal(t, int8(4), query.StarRating)
+	assert.Zero(t, query.ReviewCount, "malformed values are dropped")
+	assert.Equal(t, 3.5, query.RatingMin)
+	require.NotNil(t, query.ChildAllowed)
+	assert.False(t, *query.ChildAllowed)
+	assert.Nil(t, query.PetsAllowed)
+	require.NotNil(t, query.NumTypos)
+	assert.Zero(t, *query.NumTypos)
+	assert.Equal(t, []string{"pool", "wifi"}, query.Amenities)
+	assert.Equal(t, 7, query.SuggestLimit)
+	assert.Zero(t, query.MaxSameChain)
+}
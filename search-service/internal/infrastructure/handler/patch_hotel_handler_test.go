@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// fakePatchCache records the keys a patch invalidates.
+type fakePatchCache struct {
+	hotel.CacheRepository
+	deleted []string
+}
+
+func (c *fakePatchCache) Delete(_ context.Context, key string) error {
+	c.deleted = append(c.deleted, key)
+	return nil
+}
+
+func servePatchHotel(t *testing.T, repo *mocks.MockRepository, engine *mocks.MockEngine, cache hotel.CacheRepository, target, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+	adminHandler := &AdminHandler{
+		responder:          responder{logger: logger},
+		updateHotelUseCase: usecase.NewUpdateHotelUseCase(repo, engine, cache, logger),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/admin/hotels/{id}", adminHandler.PatchHotel).Methods(http.MethodPatch)
+
+	request := httptest.NewRequest(http.MethodPatch, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	request.RemoteAddr = "203.0.113.9:51234"
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestPatchHotelRecordsTheCorrection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	engine := mocks.NewMockEngine(ctrl)
+	cache := &fakePatchCache{}
+
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(&hotel.Hotel{
+		HotelID:      7,
+		Name:         "Harbour Hotel",
+		Description:  "By the sea",
+		Translations: []hotel.Translation{{Lang: "es"}},
+	}, nil)
+	var recorded hotel.Change
+	repo.EXPECT().UpdateWithChange(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *hotel.Hotel, change hotel.Change) error {
+		recorded = change
+		return nil
+	})
+	engine.EXPECT().UpdateHotel(gomock.Any(), gomock.Any()).Return(nil)
+
+	recorder := servePatchHotel(t, repo, engine, cache, "/api/v1/admin/hotels/7", mergePatchContentType,
+		`{"name": " Harbour Hotel & Spa ", "description": null}`)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response struct {
+		Data struct {
+			Hotel         map[string]any `json:"hotel"`
+			ChangedFields []string       `json:"changed_fields"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, []string{"description", "name"}, response.Data.ChangedFields)
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+
+	assert.Equal(t, "203.0.113.9:51234", recorded.Actor, "the caller's address is recorded")
+	assert.Equal(t, map[string]hotel.FieldChange{
+		"name":        {Old: "Harbour Hotel", New: "Harbour Hotel & Spa"},
+		"description": {Old: "By the sea", New: ""},
+	}, recorded.Changes)
+	assert.Equal(t, []string{"hotel:7", "hotel_translation:7:es"}, cache.deleted)
+}
+
+func TestPatchHotelRejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		contentType  string
+		body         string
+		expectedCode int
+		expectedText string
+	}{
+		{name: "hotel_id", body: `{"hotel_id": 8}`, expectedCode: http.StatusBadRequest, expectedText: "hotel_id"},
+		{name: "cupid_id", body: `{"name": "Harbour", "cupid_id": 8}`, expectedCode: http.StatusBadRequest, expectedText: "cupid_id"},
+		{name: "unknown field", body: `{"chain": "Harbour Group"}`, expectedCode: http.StatusBadRequest, expectedText: "unknown field"},
+		{name: "empty patch", body: `{}`, expectedCode: http.StatusBadRequest, expectedText: "no fields to update"},
+		{name: "null name", body: `{"name": null}`, expectedCode: http.StatusBadRequest, expectedText: "name cannot be null"},
+		{name: "blank name", body: `{"name": "  "}`, expectedCode: http.StatusBadRequest, expectedText: "name cannot be empty"},
+		{name: "rating out of range", body: `{"rating": 6}`, expectedCode: http.StatusBadRequest, expectedText: "rating must be between 0 and 5"},
+		{name: "malformed body", body: `{"name": `, expectedCode: http.StatusBadRequest, expectedText: "invalid hotel patch"},
+		{name: "invalid hotel ID", target: "/api/v1/admin/hotels/harbour", body: `{"name": "Harbour"}`, expectedCode: http.StatusBadRequest, expectedText: "invalid hotel ID"},
+		{name: "content type", contentType: "text/plain", body: `{"name": "Harbour"}`, expectedCode: http.StatusUnsupportedMediaType, expectedText: mergePatchContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			target, contentType := tt.target, tt.contentType
+			if target == "" {
+				target = "/api/v1/admin/hotels/7"
+			}
+			if contentType == "" {
+				contentType = mergePatchContentType
+			}
+
+			recorder := servePatchHotel(t, mocks.NewMockRepository(ctrl), mocks.NewMockEngine(ctrl), &fakePatchCache{}, target, contentType, tt.body)
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tt.expectedText)
+		})
+	}
+}
+
+func TestPatchHotelNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().FindByHotelID(gomock.Any(), int64(7)).Return(nil, nil)
+	cache := &fakePatchCache{}
+
+	recorder := servePatchHotel(t, repo, mocks.NewMockEngine(ctrl), cache, "/api/v1/admin/hotels/7", "application/json", `{"name": "Harbour"}`)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Empty(t, cache.deleted)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/openapi"
+)
+
+// RequestValidationMiddleware rejects requests whose parameters do not match the OpenAPI
+// document with 400 and code invalid_parameters, listing the problems in meta.problems.
+// Routes the document does not describe are passed through.
+func RequestValidationMiddleware(spec *openapi.Spec, logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			pathTemplate, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			problems := spec.Validate(r, pathTemplate, mux.Vars(r))
+			if len(problems) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.Debug("Rejected invalid request", "path", pathTemplate, "problems", problems)
+			w.Header().Set("Content-Type", "application/json")
+			NoStore.apply(w)
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(APIResponse{
+				Success: false,
+				Error:   "invalid request parameters",
+				Code:    "invalid_parameters",
+				Meta:    map[string]interface{}{"problems": problems},
+			}); err != nil {
+				logger.Error("Failed to encode error response", "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/search-service/docs"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/infrastructure/openapi"
+	"go.uber.org/mock/gomock"
+)
+
+// newValidationTestRouter routes hotel searches to a handler over a mock engine, validating
+// requests against the committed OpenAPI document when validate is set.
+func newValidationTestRouter(t *testing.T, validate bool) (*mux.Router, *gomock.Call) {
+	t.Helper()
+	h, engine := newPagingTestHandler(t)
+	searches := engine.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&search.Result{Hotels: []*hotel.Hotel{}}, nil)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/search/hotels", h.SearchHotels).Methods(http.MethodGet)
+	if validate {
+		spec, err := openapi.Load(docs.SwaggerInfo.ReadDoc())
+		require.NoError(t, err)
+		router.Use(RequestValidationMiddleware(spec, slog.New(slog.DiscardHandler)))
+	}
+	return router, searches
+}
+
+func TestSearchIgnoresUnsupportedFiltersWithoutValidation(t *testing.T) {
+	router, searches := newValidationTestRouter(t, false)
+	searches.Times(1)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=spa&tags=romantic&price_min=100&currency=EUR", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRequestValidationRejectsParametersTheDocumentDoesNotList(t *testing.T) {
+	router, searches := newValidationTestRouter(t, true)
+	searches.Times(0)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=spa&tags=romantic&price_min=100&currency=EUR&star_rating=four", nil))
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	var response APIResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "invalid_parameters", response.Code)
+	assert.ElementsMatch(t, []any{
+		"query parameter currency is not supported",
+		"query parameter price_min is not supported",
+		"query parameter tags is not supported",
+		`query parameter star_rating must be an integer, got "four"`,
+	}, response.Meta.(map[string]any)["problems"])
+}
+
+func TestRequestValidationPassesValidSearches(t *testing.T) {
+	router, searches := newValidationTestRouter(t, true)
+	searches.Times(1)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=spa&star_rating=4&amenities=pool&amenities=wifi&ranking_profile=classic&lang=es", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
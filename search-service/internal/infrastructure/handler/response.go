@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+type APIResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// responder writes the JSON envelope every handler responds with.
+type responder struct {
+	logger *slog.Logger
+}
+
+func (h *responder) writeSuccessResponse(w http.ResponseWriter, data interface{}, meta interface{}, policy CachePolicy) {
+	response := APIResponse{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+	}
+
+	if recorder, ok := w.(usage.ResultRecorder); ok {
+		if value := reflect.ValueOf(data); value.Kind() == reflect.Slice {
+			recorder.RecordResults(int64(value.Len()))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	policy.apply(w)
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+}
+
+func (h *responder) writeOverloadedResponse(w http.ResponseWriter, err *search.OverloadedError) {
+	retryAfter := int(math.Ceil(err.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	h.writeErrorResponse(w, "search is temporarily unavailable, please retry later", http.StatusServiceUnavailable)
+}
+
+// writePageLimitResponse rejects a page the search engine does not serve, telling the client the
+// limits it can page within.
+func (h *responder) writePageLimitResponse(w http.ResponseWriter, err *search.PageLimitError) {
+	response := APIResponse{
+		Success: false,
+		Error:   err.Error(),
+		Code:    err.Code(),
+		Meta: map[string]interface{}{
+			"max_page":     err.MaxPage,
+			"max_per_page": err.MaxPerPage,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	NoStore.apply(w)
+	w.WriteHeader(http.StatusBadRequest)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode error response", "error", err)
+	}
+}
+
+func (h *responder) writeErrorResponse(w http.ResponseWriter, message string, statusCode int) {
+	response := APIResponse{
+		Success: false,
+		Error:   message,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	NoStore.apply(w)
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode error response", "error", err)
+	}
+}
@@ -0,0 +1,677 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+)
+
+type SearchHandler struct {
+	responder
+	searchHotelsUseCase        *usecase.SearchHotelsUseCase
+	getHotelSuggestionsUseCase *usecase.GetHotelSuggestionsUseCase
+	getChainSuggestionsUseCase *usecase.GetChainSuggestionsUseCase
+	combinedSearchUseCase      *usecase.CombinedSearchUseCase
+	facilitiesUseCase          *usecase.FacilitiesUseCase
+	cachePolicies              CachePolicies
+	newHotels                  NewHotels
+}
+
+// NewHotels configures the listing of recently added hotels.
+type NewHotels struct {
+	// MaxDays bounds how far back GetNewHotels and the created_after filter look. Zero leaves
+	// them unbounded.
+	MaxDays int
+	// CacheMaxAge is how long GetNewHotels serves a cached listing as fresh.
+	CacheMaxAge time.Duration
+}
+
+// NewSearchHandler returns the handler of the search, suggestion and facet endpoints.
+func NewSearchHandler(
+	searchHotelsUseCase *usecase.SearchHotelsUseCase,
+	getHotelSuggestionsUseCase *usecase.GetHotelSuggestionsUseCase,
+	getChainSuggestionsUseCase *usecase.GetChainSuggestionsUseCase,
+	combinedSearchUseCase *usecase.CombinedSearchUseCase,
+	facilitiesUseCase *usecase.FacilitiesUseCase,
+	cachePolicies CachePolicies,
+	newHotels NewHotels,
+	logger *slog.Logger,
+) *SearchHandler {
+	return &SearchHandler{
+		responder:                  responder{logger: logger},
+		searchHotelsUseCase:        searchHotelsUseCase,
+		getHotelSuggestionsUseCase: getHotelSuggestionsUseCase,
+		getChainSuggestionsUseCase: getChainSuggestionsUseCase,
+		combinedSearchUseCase:      combinedSearchUseCase,
+		facilitiesUseCase:          facilitiesUseCase,
+		cachePolicies:              cachePolicies,
+		newHotels:                  newHotels,
+	}
+}
+
+// SearchHotels searches for hotels based on various criteria
+// @Summary Search hotels
+// @Description Search for hotels using various filters based on TypesenseDocument fields. Ordering is deterministic: every sort ends with hotel_id ascending as a tiebreaker, and without sort_by results are ordered by relevance, then rating descending, so paging never repeats or skips hotels. meta.served_from is cache or engine, with meta.cache_age_seconds for cached results, and meta.engine and meta.collection name what computed them
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param request query SearchQuery false "Search filters, sorting and paging"
+// @Success 200 {object} APIResponse{data=[]github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel,meta=object} "Search results with hotels and pagination"
+// @Header 200 {string} X-Cache "HIT when served from the search cache, MISS otherwise"
+// @Failure 400 {object} APIResponse "Bad Request - Invalid search parameters, a page deeper than the engine serves (code page_out_of_range; pages past meta.total_pages are empty)"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Failure 503 {object} APIResponse "Search temporarily unavailable, see Retry-After"
+// @Router /api/v1/search/hotels [get]
+func (h *SearchHandler) SearchHotels(w http.ResponseWriter, r *http.Request) {
+	params, err := h.parseSearchParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.searchHotelsUseCase.Execute(r.Context(), params)
+	if err != nil {
+		if h.writeSearchError(w, err) {
+			return
+		}
+		h.logger.Error("Failed to search hotels", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	meta := map[string]interface{}{
+		"total_hits":            result.TotalHits,
+		"page":                  result.Page,
+		"limit":                 result.Limit,
+		"total_pages":           result.TotalPages,
+		"max_page":              result.MaxPage,
+		"processing_time":       result.ProcessingTime.String(),
+		"processing_time_ms":    result.ProcessingTime.Milliseconds(),
+		"search_engine_time":    result.SearchEngineTime.String(),
+		"search_engine_time_ms": result.SearchEngineTime.Milliseconds(),
+		"query":                 result.Query,
+		"served_from":           result.Provenance.ServedFrom,
+		"engine":                result.Provenance.Engine.Name,
+		"collection":            result.Provenance.Engine.Collection,
+	}
+
+	if result.Provenance.ServedFrom == search.ServedFromCache {
+		meta["cache_age_seconds"] = int64(result.Provenance.CacheAge.Seconds())
+		w.Header().Set("X-Cache", "HIT")
+	} else {
+		w.Header().Set("X-Cache", "MISS")
+	}
+
+	if result.Facets != nil {
+		meta["facets"] = result.Facets
+	}
+
+	if result.ReferencePoint != nil {
+		meta["reference_point"] = result.ReferencePoint
+	}
+
+	h.writeSuccessResponse(w, result.Hotels, meta, h.cachePolicies.Search.aged(result.Provenance.CacheAge))
+}
+
+const (
+	defaultNewHotelsDays  = 30
+	defaultNewHotelsLimit = 20
+)
+
+// GetNewHotels lists the hotels added recently
+// @Summary List new hotels
+// @Description List the hotels first indexed in the last days days, or since created_after, newest first, for "new on the platform" carousels. It is a search with created_after set to days ago, rounded down to the hour, and sort_by=created_at, whose results are cached for results.new_hotels_cache_max_age, longer than other searches
+// @Tags search
+// @Produce json
+// @Param days query integer false "Window in days (default: 30, max: results.new_hotels_max_days)" minimum(1)
+// @Param created_after query string false "Start of the window instead of days, as a date, RFC 3339 or relative to now such as -30d; at most results.new_hotels_max_days ago"
+// @Param limit query integer false "Maximum number of hotels to return (max: 100, default: 20)" minimum(1)
+// @Success 200 {object} APIResponse{data=[]github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.Hotel,meta=object} "Newest hotels first"
+// @Failure 400 {object} APIResponse "Bad Request - days or limit is not a positive integer, created_after does not parse, both days and created_after are given, or the window exceeds the retention window"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Failure 503 {object} APIResponse "Search temporarily unavailable, see Retry-After"
+// @Router /api/v1/hotels/new [get]
+func (h *SearchHandler) GetNewHotels(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	now := time.Now()
+
+	limit, err := positiveIntParam(query, "limit", defaultNewHotelsLimit)
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	createdAfter, err := parseTimeParam("created_after", query.Get("created_after"))
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	meta := map[string]interface{}{}
+	if createdAfter == nil {
+		days, err := positiveIntParam(query, "days", defaultNewHotelsDays)
+		if err != nil {
+			h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if h.newHotels.MaxDays > 0 && days > h.newHotels.MaxDays {
+			h.writeErrorResponse(w, fmt.Sprintf("days must not exceed %d, the retention window of new hotels", h.newHotels.MaxDays), http.StatusBadRequest)
+			return
+		}
+		// Rounding the window to the hour keeps the cache key stable between requests.
+		windowStart := now.UTC().Truncate(time.Hour).AddDate(0, 0, -days)
+		createdAfter = &windowStart
+		meta["days"] = days
+	} else if query.Get("days") != "" {
+		h.writeErrorResponse(w, "days and created_after cannot be combined", http.StatusBadRequest)
+		return
+	}
+	if err := h.checkRetention(createdAfter, now); err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := search.Params{
+		CreatedAfter: createdAfter,
+		SortBy:       "created_at",
+		SortOrder:    "desc",
+		Limit:        limit,
+	}
+
+	result, err := h.searchHotelsUseCase.ExecuteWithMaxAge(r.Context(), params, h.newHotels.CacheMaxAge)
+	if err != nil {
+		if h.writeSearchError(w, err) {
+			return
+		}
+		h.logger.Error("Failed to list new hotels", "created_after", createdAfter, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	meta["total_hits"] = result.TotalHits
+	meta["limit"] = result.Limit
+	meta["created_after"] = createdAfter
+	meta["served_from"] = result.Provenance.ServedFrom
+	h.writeSuccessResponse(w, result.Hotels, meta, h.cachePolicies.Reference)
+}
+
+// positiveIntParam reads the query parameter key as a positive integer, defaulting to def when
+// it is not given.
+func positiveIntParam(query url.Values, key string, def int) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return def, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", key)
+	}
+	return parsed, nil
+}
+
+// checkRetention rejects a created_after further back than the retention window of new hotels.
+// The hotels of the initial import share a created_at about that old, so an earlier bound would
+// match the whole catalog. The window starts on the hour, like the one of GetNewHotels.
+func (h *SearchHandler) checkRetention(createdAfter *time.Time, now time.Time) error {
+	if createdAfter == nil || h.newHotels.MaxDays <= 0 {
+		return nil
+	}
+	if createdAfter.Before(now.UTC().Truncate(time.Hour).AddDate(0, 0, -h.newHotels.MaxDays)) {
+		return fmt.Errorf("%w: created_after must not be more than %d days ago, the retention window of new hotels",
+			search.ErrInvalidTimeRange, h.newHotels.MaxDays)
+	}
+	return nil
+}
+
+// writeSearchError answers a search that failed because the engine is shedding load, the page
+// is deeper than it serves or the parameters are invalid, and reports whether it did. Other
+// errors are left to the caller.
+func (h *SearchHandler) writeSearchError(w http.ResponseWriter, err error) bool {
+	var overloadedErr *search.OverloadedError
+	if errors.As(err, &overloadedErr) {
+		h.writeOverloadedResponse(w, overloadedErr)
+		return true
+	}
+	var pageLimitErr *search.PageLimitError
+	if errors.As(err, &pageLimitErr) {
+		h.writePageLimitResponse(w, pageLimitErr)
+		return true
+	}
+	if errors.Is(err, search.ErrInvalidParams) || errors.Is(err, search.ErrInvalidArrivalTime) ||
+		errors.Is(err, search.ErrInvalidTimeRange) || errors.Is(err, search.ErrInvalidGeoPolygon) {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return true
+	}
+	return false
+}
+
+// GetHotelSuggestions provides search suggestions based on query input
+// @Summary Get hotel search suggestions
+// @Description Get autocomplete suggestions for hotel search based on partial query input. When nothing matches, misspelled words of five or more characters are corrected against the indexed hotel names and cities and the suggestions of the corrected query are returned with corrected_from set
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query for suggestions"
+// @Param limit query integer false "Maximum number of suggestions to return (default: 10)"
+// @Success 200 {object} APIResponse "List of search suggestions"
+// @Failure 400 {object} APIResponse "Bad Request - Query parameter is required"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/search/suggestions [get]
+func (h *SearchHandler) GetHotelSuggestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		h.writeErrorResponse(w, "query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	limitStr := r.URL.Query().Get("limit")
+	limit := 10
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	h.logger.Debug("Getting suggestions", "query", query, "limit", limit)
+
+	suggestions, err := h.getHotelSuggestionsUseCase.Execute(r.Context(), query, limit)
+	if err != nil {
+		h.logger.Error("Failed to get suggestions", "query", query, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, suggestions, nil, h.cachePolicies.Search)
+}
+
+// GetChainSuggestions suggests hotel chains matching a partial query
+// @Summary Get hotel chain suggestions
+// @Description Get autocomplete suggestions for hotel chains, so a partial query can be turned into a chain filter instead of a single hotel. Chains whose name or one of its words starts with the query are returned, largest first
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Partial chain name"
+// @Param limit query integer false "Maximum number of chains to return (default: 10, max: 50)"
+// @Success 200 {object} APIResponse{data=[]search.ChainSuggestion} "Matching chains with their hotel counts"
+// @Failure 400 {object} APIResponse "Bad Request - Query parameter is required"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/search/chain-suggestions [get]
+func (h *SearchHandler) GetChainSuggestions(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		h.writeErrorResponse(w, "query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	suggestions, err := h.getChainSuggestionsUseCase.Execute(r.Context(), query, limit)
+	if err != nil {
+		h.logger.Error("Failed to get chain suggestions", "query", query, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, suggestions, nil, h.cachePolicies.Search)
+}
+
+// CombinedSearch returns search results and autocomplete suggestions in a single round-trip
+// @Summary Combined search and suggestions
+// @Description Run a hotel search and fetch autocomplete suggestions for the same query in one request. Accepts the same filters as /api/v1/search/hotels
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param request query CombinedSearchQuery false "Search filters, sorting and paging, and the number of suggestions"
+// @Success 200 {object} APIResponse "Search results and suggestions"
+// @Failure 400 {object} APIResponse "Bad Request - Query parameter is required, invalid search parameters, a page deeper than the engine serves (code page_out_of_range; pages past meta.total_pages are empty)"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Failure 503 {object} APIResponse "Search temporarily unavailable, see Retry-After"
+// @Router /api/v1/search/combined [get]
+func (h *SearchHandler) CombinedSearch(w http.ResponseWriter, r *http.Request) {
+	params, err := h.parseSearchParams(r)
+	if err != nil {
+		h.writeErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if params.Query == "" {
+		h.writeErrorResponse(w, "query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	var query CombinedSearchQuery
+	decodeQuery(r.URL.Query(), &query)
+	suggestLimit := 5
+	if query.SuggestLimit > 0 {
+		suggestLimit = query.SuggestLimit
+	}
+
+	result, err := h.combinedSearchUseCase.Execute(r.Context(), params, suggestLimit)
+	if err != nil {
+		if h.writeSearchError(w, err) {
+			return
+		}
+		h.logger.Error("Failed to run combined search", "query", params.Query, "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, result, nil, h.cachePolicies.Search)
+}
+
+// GetFacets returns available search facets for filtering
+// @Summary Get search facets
+// @Description Get available facets for filtering hotel search results (cities, countries, star ratings, amenities, etc.)
+// @Tags search
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse "Available search facets with counts"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/search/facets [get]
+func (h *SearchHandler) GetFacets(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debug("Getting search facets")
+
+	facets := &search.Facets{
+		Cities: []search.FacetItem{
+			{Value: "New York", Count: 150},
+			{Value: "London", Count: 120},
+			{Value: "Paris", Count: 98},
+			{Value: "Tokyo", Count: 87},
+			{Value: "Dubai", Count: 76},
+		},
+		Countries: []search.FacetItem{
+			{Value: "United States", Count: 300},
+			{Value: "United Kingdom", Count: 250},
+			{Value: "France", Count: 200},
+			{Value: "Japan", Count: 150},
+			{Value: "UAE", Count: 100},
+		},
+		StarRatings: []search.FacetItem{
+			{Value: "5", Count: 45},
+			{Value: "4", Count: 123},
+			{Value: "3", Count: 167},
+			{Value: "2", Count: 89},
+			{Value: "1", Count: 34},
+		},
+		Amenities: []search.FacetItem{
+			{Value: "wifi", Count: 890},
+			{Value: "pool", Count: 456},
+			{Value: "fitness_center", Count: 334},
+			{Value: "spa", Count: 223},
+			{Value: "restaurant", Count: 567},
+			{Value: "parking", Count: 445},
+			{Value: "pet_friendly", Count: 156},
+		},
+	}
+
+	h.writeSuccessResponse(w, facets, nil, h.cachePolicies.Reference)
+}
+
+// GetFacilities lists the canonical facilities with their hotel counts
+// @Summary List facilities
+// @Description List the canonical facilities offered by at least one active hotel with the number of hotels offering each, most common first. The slugs are the values accepted by the amenities and amenity_weights search parameters
+// @Tags search
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]github_com_victoragudo_hotel-management-system_search-service_internal_domain_hotel.FacilityCount} "Canonical facilities"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/facilities [get]
+func (h *SearchHandler) GetFacilities(w http.ResponseWriter, r *http.Request) {
+	list, err := h.facilitiesUseCase.List(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list facilities", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, list, map[string]interface{}{
+		"count": len(list),
+	}, h.cachePolicies.Reference)
+}
+
+// GetTrendingSuggestions returns trending hotel search suggestions
+// @Summary Get trending search suggestions
+// @Description Get the most searched queries of a locale. Locales with too few recorded searches get the global ones, and a static list is returned until enough searches are recorded.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param limit query integer false "Maximum number of trending suggestions to return (default: 10)"
+// @Param locale query string false "Locale whose searches are ranked, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header"
+// @Param lang query string false "Language whose searches are ranked when locale is not given"
+// @Success 200 {object} APIResponse{data=[]search.Suggestion} "List of trending search suggestions"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/search/trending [get]
+func (h *SearchHandler) GetTrendingSuggestions(w http.ResponseWriter, r *http.Request) {
+	limit := trendingLimit(r, 10)
+	locale := requestLocale(r)
+
+	h.logger.Debug("Getting trending suggestions", "limit", limit, "locale", locale)
+
+	suggestions, err := h.getHotelSuggestionsUseCase.GetTrendingSuggestions(r.Context(), locale, limit)
+	if err != nil {
+		h.logger.Error("Failed to get trending suggestions", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, suggestions, nil, h.cachePolicies.Reference.varying(localeHeaders...))
+}
+
+// GetPopularSearches returns the most searched queries
+// @Summary Get popular searches
+// @Description Get the most searched queries of a locale. Locales with too few recorded searches get the global ones, and a static list is returned until enough searches are recorded.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param limit query integer false "Maximum number of searches to return (default: 8)"
+// @Param locale query string false "Locale whose searches are ranked, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header"
+// @Param lang query string false "Language whose searches are ranked when locale is not given"
+// @Success 200 {object} APIResponse{data=[]string} "List of popular searches"
+// @Failure 500 {object} APIResponse "Internal Server Error"
+// @Router /api/v1/search/popular [get]
+func (h *SearchHandler) GetPopularSearches(w http.ResponseWriter, r *http.Request) {
+	limit := trendingLimit(r, 8)
+	locale := requestLocale(r)
+
+	searches, err := h.searchHotelsUseCase.GetPopularSearches(r.Context(), locale, limit)
+	if err != nil {
+		h.logger.Error("Failed to get popular searches", "error", err)
+		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeSuccessResponse(w, searches, nil, h.cachePolicies.Reference.varying(localeHeaders...))
+}
+
+// maxTrendingLimit bounds the trending and popular lists.
+const maxTrendingLimit = 50
+
+func trendingLimit(r *http.Request, defaultLimit int) int {
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		return min(l, maxTrendingLimit)
+	}
+	return defaultLimit
+}
+
+// localeHeaders are the request headers requestLocale reads, which the responses of a locale's
+// trending lists vary by.
+var localeHeaders = []string{"Accept-Language", "X-Country-Code"}
+
+// requestLocale is the locale whose trending bucket a request reads and records into: the
+// locale or lang parameter, then the Accept-Language header, then the language of the country
+// hint set by the edge proxy, and otherwise the global bucket.
+func requestLocale(r *http.Request) string {
+	query := r.URL.Query()
+	for _, value := range []string{
+		query.Get("locale"),
+		query.Get("lang"),
+		r.Header.Get("Accept-Language"),
+	} {
+		if locale := search.NormalizeLocale(value); locale != search.GlobalLocale {
+			return locale
+		}
+	}
+	return search.CountryLocale(r.Header.Get("X-Country-Code"))
+}
+
+// parseSearchParams reads the search parameters of r. Most malformed values are dropped, but a
+// malformed time range filter is an error wrapping search.ErrInvalidTimeRange.
+func (h *SearchHandler) parseSearchParams(r *http.Request) (search.Params, error) {
+	var query SearchQuery
+	decodeQuery(r.URL.Query(), &query)
+
+	params := search.Params{
+		Query:         query.Q,
+		Name:          query.Name,
+		Description:   query.Description,
+		Phone:         query.Phone,
+		Chain:         query.Chain,
+		Email:         query.Email,
+		Fax:           query.Fax,
+		AirportCode:   query.AirportCode,
+		Parking:       query.Parking,
+		City:          query.City,
+		Country:       query.Country,
+		SortBy:        query.SortBy,
+		SortOrder:     query.SortOrder,
+		Amenities:     h.facilitiesUseCase.Slugs(query.Amenities),
+		ArrivalTime:   strings.TrimSpace(query.ArrivalTime),
+		StrictCheckin: query.StrictCheckin,
+
+		NearAttraction: strings.TrimSpace(query.NearAttraction),
+		PolicyKeyword:  strings.TrimSpace(query.PolicyKeyword),
+
+		RankingProfile: strings.ToLower(query.RankingProfile),
+
+		RatingMin:    query.RatingMin,
+		RatingMax:    query.RatingMax,
+		StarRating:   query.StarRating,
+		ReviewCount:  query.ReviewCount,
+		Page:         query.Page,
+		Limit:        query.Limit,
+		Diversify:    query.Diversify,
+		MaxSameChain: query.MaxSameChain,
+		Latitude:     query.Latitude,
+		Longitude:    query.Longitude,
+		Radius:       query.Radius,
+		ChildAllowed: query.ChildAllowed,
+		PetsAllowed:  query.PetsAllowed,
+
+		Locale: requestLocale(r),
+	}
+
+	for field := range strings.SplitSeq(query.Fields, ",") {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			params.Fields = append(params.Fields, field)
+		}
+	}
+
+	if query.GeoPolygon != "" {
+		params.GeoPolygon = parseGeoPolygon(query.GeoPolygon)
+	}
+	if query.MaxAirportDistanceKm > 0 {
+		params.MaxAirportDistanceKm = query.MaxAirportDistanceKm
+	}
+	if query.MinValueScore > 0 {
+		params.MinValueScore = query.MinValueScore
+	}
+	if query.NumTypos != nil && *query.NumTypos >= 0 {
+		numTypos := min(*query.NumTypos, search.MaxNumTypos)
+		params.NumTypos = &numTypos
+	}
+
+	for language := range strings.SplitSeq(query.Languages, ",") {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if (search.Language{Code: language}).Validate() == nil && !slices.Contains(params.RequiredLanguages, language) {
+			params.RequiredLanguages = append(params.RequiredLanguages, language)
+		}
+	}
+
+	for _, param := range []struct {
+		value int32
+		dst   **int32
+	}{
+		{query.MinRoomTypes, &params.MinRoomTypes},
+		{query.MinTotalCapacity, &params.MinTotalCapacity},
+		{query.MaxMinNights, &params.MaxMinNights},
+	} {
+		if param.value > 0 {
+			*param.dst = &param.value
+		}
+	}
+
+	if query.AmenityWeights != "" {
+		params.AmenityWeights = make(map[string]float64)
+		for amenity, weight := range parseAmenityWeights(query.AmenityWeights) {
+			params.AmenityWeights[h.facilitiesUseCase.Slug(amenity)] = weight
+		}
+	}
+
+	for _, param := range []struct {
+		key   string
+		value string
+		dst   **time.Time
+	}{
+		{"created_after", query.CreatedAfter, &params.CreatedAfter},
+		{"created_before", query.CreatedBefore, &params.CreatedBefore},
+		{"updated_after", query.UpdatedAfter, &params.UpdatedAfter},
+		{"updated_before", query.UpdatedBefore, &params.UpdatedBefore},
+	} {
+		parsed, err := parseTimeParam(param.key, param.value)
+		if err != nil {
+			return params, err
+		}
+		*param.dst = parsed
+	}
+	if err := h.checkRetention(params.CreatedAfter, time.Now()); err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// parseGeoPolygon reads alternating latitudes and longitudes, "51.5,-0.12,51.6,-0.12,...".
+// Unlike other filters a malformed polygon is not dropped, as that would widen the search to
+// everywhere: unparseable or missing coordinates are read as NaN so Validate rejects them.
+func parseGeoPolygon(value string) [][2]float64 {
+	parts := strings.Split(value, ",")
+	polygon := make([][2]float64, 0, (len(parts)+1)/2)
+	for i := 0; i < len(parts); i += 2 {
+		vertex := [2]float64{math.NaN(), math.NaN()}
+		for j := 0; j < 2 && i+j < len(parts); j++ {
+			if val, err := strconv.ParseFloat(strings.TrimSpace(parts[i+j]), 64); err == nil {
+				vertex[j] = val
+			}
+		}
+		polygon = append(polygon, vertex)
+	}
+	return polygon
+}
+
+// parseAmenityWeights reads "pool:0.9,wifi:0.3". Malformed pairs are skipped.
+func parseAmenityWeights(value string) map[string]float64 {
+	weights := make(map[string]float64)
+	for pair := range strings.SplitSeq(value, ",") {
+		amenity, weight, found := strings.Cut(pair, ":")
+		amenity = strings.TrimSpace(amenity)
+		if !found || amenity == "" {
+			continue
+		}
+		if val, err := strconv.ParseFloat(strings.TrimSpace(weight), 64); err == nil {
+			weights[amenity] = val
+		}
+	}
+	return weights
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/victoragudo/hotel-management-system/pkg/facilities"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/search"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseAmenityWeights(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected map[string]float64
+	}{
+		{"pool:0.9,wifi:0.3", map[string]float64{"pool": 0.9, "wifi": 0.3}},
+		{" pool : 0.9 , wifi:1 ", map[string]float64{"pool": 0.9, "wifi": 1}},
+		{"pool:high,wifi,:0.5,spa:0.7", map[string]float64{"spa": 0.7}},
+		{"", map[string]float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseAmenityWeights(tt.value))
+		})
+	}
+}
+
+// newParamsTestHandler returns a search handler that can parse and reject requests, without
+// the use cases that would run them.
+func newParamsTestHandler() *SearchHandler {
+	logger := slog.New(slog.DiscardHandler)
+	return &SearchHandler{
+		responder:         responder{logger: logger},
+		facilitiesUseCase: usecase.NewFacilitiesUseCase(nil, nil, facilities.Default(), logger),
+	}
+}
+
+func TestParseSearchParamsNormalizesAmenities(t *testing.T) {
+	h := newParamsTestHandler()
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?amenities=Free%20WiFi&amenities=wi-fi&amenities=Outdoor%20Swimming%20Pool&amenity_weights=Free%20WiFi:0.5", nil)
+
+	params, err := h.parseSearchParams(r)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wifi", "pool"}, params.Amenities, "names that vary only in wording filter by one slug")
+	assert.Equal(t, map[string]float64{"wifi": 0.5}, params.AmenityWeights)
+}
+
+func TestParseSearchParamsTimeRange(t *testing.T) {
+	h := newParamsTestHandler()
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?created_after=2024-01-01&updated_before=1735689600", nil)
+
+	params, err := h.parseSearchParams(r)
+
+	require.NoError(t, err)
+	require.NotNil(t, params.CreatedAfter)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), params.CreatedAfter.UTC())
+	require.NotNil(t, params.UpdatedBefore)
+	assert.Equal(t, int64(1735689600), params.UpdatedBefore.Unix())
+	assert.Nil(t, params.CreatedBefore)
+	assert.Nil(t, params.UpdatedAfter)
+}
+
+func TestParseSearchParamsRejectsInvalidTimes(t *testing.T) {
+	for _, key := range []string{"created_after", "created_before", "updated_after", "updated_before"} {
+		t.Run(key, func(t *testing.T) {
+			h := newParamsTestHandler()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?"+key+"=last-tuesday", nil)
+
+			_, err := h.parseSearchParams(r)
+
+			assert.ErrorIs(t, err, search.ErrInvalidTimeRange)
+			assert.ErrorContains(t, err, key)
+		})
+	}
+}
+
+func TestSearchEndpointsRejectInvalidTimes(t *testing.T) {
+	h := newParamsTestHandler()
+	for target, handler := range map[string]http.HandlerFunc{
+		"/api/v1/search/hotels?created_after=2024-13-45":     h.SearchHotels,
+		"/api/v1/search/combined?q=rome&updated_after=never": h.CombinedSearch,
+	} {
+		t.Run(target, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			handler(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Contains(t, response.Error, search.ErrInvalidTimeRange.Error())
+		})
+	}
+}
+
+func TestCombinedSearchRejectsInvalidParameters(t *testing.T) {
+	h := newParamsTestHandler()
+	// The engine is never called: the parameters are rejected first.
+	h.combinedSearchUseCase = usecase.NewCombinedSearchUseCase(mocks.NewMockEngine(gomock.NewController(t)), nil, search.DefaultSnippetLength, slog.New(slog.DiscardHandler))
+
+	for name, test := range map[string]struct {
+		query    string
+		expected error
+	}{
+		"arrival time":          {query: "q=rome&arrival_time=25:99", expected: search.ErrInvalidArrivalTime},
+		"polygon with a radius": {query: "q=rome&geo_polygon=41.9,12.4,41.8,12.5,41.8,12.4&latitude=41.9&longitude=12.5&radius=5", expected: search.ErrInvalidGeoPolygon},
+		"empty time range":      {query: "q=rome&created_after=2024-06-01&created_before=2024-01-01", expected: search.ErrInvalidTimeRange},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			h.CombinedSearch(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/combined?"+test.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Contains(t, response.Error, search.ErrInvalidParams.Error())
+			assert.Contains(t, response.Error, test.expected.Error())
+		})
+	}
+}
+
+func newPagingTestHandler(t *testing.T) (*SearchHandler, *mocks.MockEngine) {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+	controller := gomock.NewController(t)
+	engine := mocks.NewMockEngine(controller)
+	cache := mocks.NewMockCacheRepository(controller)
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("cache miss")).AnyTimes()
+	cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}).AnyTimes()
+	engine.EXPECT().Info().Return(search.EngineInfo{}).AnyTimes()
+	h := newParamsTestHandler()
+	h.searchHotelsUseCase = usecase.NewSearchHotelsUseCase(engine, cache, nil, nil, search.DefaultSnippetLength, time.Minute, time.Minute, nil, logger)
+	return h, engine
+}
+
+func TestSearchHotelsServesAnEmptyPagePastTheResults(t *testing.T) {
+	h, engine := newPagingTestHandler(t)
+	engine.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&search.Result{TotalHits: 30, Hotels: []*hotel.Hotel{}}, nil)
+	recorder := httptest.NewRecorder()
+
+	h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?page=5&limit=20", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response struct {
+		Data []json.RawMessage `json:"data"`
+		Meta map[string]any    `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.NotNil(t, response.Data)
+	assert.Empty(t, response.Data)
+	assert.EqualValues(t, 2, response.Meta["total_pages"])
+	assert.EqualValues(t, 2, response.Meta["max_page"])
+}
+
+func TestSearchHotelsRejectsPagesTheEngineDoesNotServe(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		engineErr error
+		code      string
+	}{
+		{
+			name:   "page past the result window",
+			target: "/api/v1/search/hotels?page=300&limit=100",
+			code:   "page_out_of_range",
+		},
+		{
+			name:      "engine paging error",
+			target:    "/api/v1/search/hotels?page=2&limit=100",
+			engineErr: &search.PageLimitError{Err: search.ErrPerPageTooLarge, Page: 2, MaxPage: 100, MaxPerPage: 250},
+			code:      "per_page_too_large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, engine := newPagingTestHandler(t)
+			if tt.engineErr != nil {
+				engine.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, tt.engineErr)
+			}
+			recorder := httptest.NewRecorder()
+
+			h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Code)
+			meta, ok := response.Meta.(map[string]any)
+			require.True(t, ok)
+			assert.EqualValues(t, 100, meta["max_page"])
+			assert.EqualValues(t, 250, meta["max_per_page"])
+		})
+	}
+}
+
+func TestSearchHotelsReportsCacheProvenance(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	controller := gomock.NewController(t)
+	engine := mocks.NewMockEngine(controller)
+	cache := mocks.NewMockCacheRepository(controller)
+	stored := map[string][]byte{}
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) ([]byte, error) {
+		if value, ok := stored[key]; ok {
+			return value, nil
+		}
+		return nil, errors.New("cache miss")
+	}).AnyTimes()
+	cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, value []byte, _ time.Duration) error {
+		stored[key] = value
+		return nil
+	}).AnyTimes()
+	engine.EXPECT().Capabilities().Return(search.Capabilities{MaxPerPage: 250, MaxResultWindow: 10000}).AnyTimes()
+	engine.EXPECT().Info().Return(search.EngineInfo{Name: "typesense", Collection: "hotels"}).AnyTimes()
+	engine.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&search.Result{TotalHits: 1, Hotels: []*hotel.Hotel{}}, nil).Times(1)
+	h := newParamsTestHandler()
+	h.searchHotelsUseCase = usecase.NewSearchHotelsUseCase(engine, cache, nil, nil, search.DefaultSnippetLength, time.Minute, time.Minute, nil, logger)
+
+	searchParis := func() (*httptest.ResponseRecorder, map[string]any) {
+		recorder := httptest.NewRecorder()
+		h.SearchHotels(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=paris", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response struct {
+			Meta map[string]any `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		return recorder, response.Meta
+	}
+
+	recorder, meta := searchParis()
+	assert.Equal(t, "MISS", recorder.Header().Get("X-Cache"))
+	assert.Equal(t, "engine", meta["served_from"])
+	assert.Equal(t, "typesense", meta["engine"])
+	assert.Equal(t, "hotels", meta["collection"])
+	assert.NotContains(t, meta, "cache_age_seconds")
+
+	recorder, meta = searchParis()
+	assert.Equal(t, "HIT", recorder.Header().Get("X-Cache"))
+	assert.Equal(t, "cache", meta["served_from"])
+	assert.Equal(t, "typesense", meta["engine"])
+	assert.Contains(t, meta, "cache_age_seconds")
+	assert.EqualValues(t, 0, meta["search_engine_time_ms"])
+}
+
+func TestRequestLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		expected string
+	}{
+		{name: "locale parameter", target: "/?locale=es-ES&lang=en", expected: "es"},
+		{name: "lang parameter", target: "/?lang=fr", headers: map[string]string{"Accept-Language": "de"}, expected: "fr"},
+		{name: "accept language", target: "/", headers: map[string]string{"Accept-Language": "pt-BR,pt;q=0.9", "X-Country-Code": "US"}, expected: "pt"},
+		{name: "country hint", target: "/", headers: map[string]string{"X-Country-Code": "US"}, expected: "en"},
+		{name: "spanish speaking country", target: "/", headers: map[string]string{"X-Country-Code": "MX"}, expected: "es"},
+		{name: "country without a bucket", target: "/", headers: map[string]string{"X-Country-Code": "ZZ"}, expected: search.GlobalLocale},
+		{name: "no hint", target: "/", expected: search.GlobalLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			assert.Equal(t, tt.expected, requestLocale(r))
+		})
+	}
+}
+
+func TestParseSearchParamsReadsDiversification(t *testing.T) {
+	h := newParamsTestHandler()
+
+	params, err := h.parseSearchParams(httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=paris&diversify=true&max_same_chain=3", nil))
+	require.NoError(t, err)
+	assert.True(t, params.Diversify)
+	assert.Equal(t, 3, params.MaxSameChain)
+
+	params, err = h.parseSearchParams(httptest.NewRequest(http.MethodGet, "/api/v1/search/hotels?q=paris&diversify=yes&max_same_chain=two", nil))
+	require.NoError(t, err)
+	assert.False(t, params.Diversify, "malformed values are dropped")
+	assert.Zero(t, params.MaxSameChain)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// SearchQuery holds the query parameters of a hotel search. The swag annotations expand it
+// into the documented parameters and parseSearchParams decodes requests into it, so the
+// documentation lists exactly the parameters a search reads.
+type SearchQuery struct {
+	// Search query for hotel name, description, or location. Double-quoted words are matched as a phrase
+	Q string `form:"q"`
+	// Filter by hotel name
+	Name string `form:"name"`
+	// Filter by hotel description
+	Description string `form:"description"`
+	// Filter by hotel phone number, in any format. Numbers without a country code are read as numbers of country
+	Phone string `form:"phone"`
+	// Filter by hotel chain
+	Chain string `form:"chain"`
+	// Filter by hotel email
+	Email string `form:"email"`
+	// Filter by hotel fax number, in any format. Numbers without a country code are read as numbers of country
+	Fax string `form:"fax"`
+	// Filter by airport code
+	AirportCode string `form:"airport_code"`
+	// Filter by parking information
+	Parking string `form:"parking"`
+	// Filter by city
+	City string `form:"city"`
+	// Filter by country
+	Country string `form:"country"`
+	// Minimum rating (0-5)
+	RatingMin float64 `form:"rating_min"`
+	// Maximum rating (0-5)
+	RatingMax float64 `form:"rating_max"`
+	// Minimum star rating (1-5)
+	StarRating int8 `form:"star_rating"`
+	// Filter by review count
+	ReviewCount int32 `form:"review_count"`
+	// Keep hotels whose content is available in every listed language, comma-separated (e.g. es,fr)
+	Languages string `form:"languages"`
+	// Keep hotels with at least this many room types
+	MinRoomTypes int32 `form:"min_room_types"`
+	// Keep hotels whose room types host at least this many guests in total (sum of max occupancy)
+	MinTotalCapacity int32 `form:"min_total_capacity"`
+	// Keep hotels whose minimum stay is at most this many nights; hotels without a minimum stay always match
+	MaxMinNights int32 `form:"max_min_nights" minimum:"1"`
+	// Keep hotels near an attraction of this category (museum, restaurant, beach, park) or with this exact name
+	NearAttraction string `form:"near_attraction"`
+	// Keep hotels whose policy summary mentions this keyword, such as parking or free parking
+	PolicyKeyword string `form:"policy_keyword"`
+	// Filter by child allowed status
+	ChildAllowed *bool `form:"child_allowed"`
+	// Filter by pets allowed status
+	PetsAllowed *bool `form:"pets_allowed"`
+	// Filter by facility slugs from /api/v1/facilities; raw facility names are normalized
+	Amenities []string `form:"amenities" collectionFormat:"multi"`
+	// Rank by weighted amenities, e.g. pool:0.9,wifi:0.3 (weights 0-1, at most 10)
+	AmenityWeights string `form:"amenity_weights"`
+	// Sort by field (rating, distance, relevance, name, created_at). Defaults to relevance, then rating descending; ties are always broken by hotel_id ascending
+	SortBy string `form:"sort_by"`
+	// Sort order (asc, desc)
+	SortOrder string `form:"sort_order"`
+	// Ranking profile: relevance (default) boosts exact name and phrase matches, classic sorts by rating unless sort_by is given
+	RankingProfile string `form:"ranking_profile" enums:"relevance,classic"`
+	// Optional fields to include; description returns the full description, markdown description and important info instead of only description_snippet
+	Fields string `form:"fields"`
+	// Page number (default: 1)
+	Page int `form:"page"`
+	// Results per page (max: 100, default: 20)
+	Limit int `form:"limit"`
+	// Reorder each page of relevance-ranked results so that no more than max_same_chain consecutive hotels belong to the same chain. Ignored with other sorts
+	Diversify bool `form:"diversify"`
+	// Consecutive hotels of one chain allowed when diversify is set (default: 2)
+	MaxSameChain int `form:"max_same_chain" minimum:"1"`
+	// Latitude for location-based search
+	Latitude float64 `form:"latitude"`
+	// Longitude for location-based search
+	Longitude float64 `form:"longitude"`
+	// Search radius in kilometers
+	Radius float64 `form:"radius"`
+	// Only hotels inside this polygon, as comma-separated alternating latitudes and longitudes of at least 3 vertices; cannot be combined with radius
+	GeoPolygon string `form:"geo_polygon"`
+	// Maximum distance in kilometers to the hotel's nearest airport
+	MaxAirportDistanceKm float64 `form:"max_airport_distance_km"`
+	// Minimum average value-for-money review score
+	MinValueScore float64 `form:"min_value_score"`
+	// Typos tolerated per query word, from 0 for exact matching up to 2. Values above 2 are capped; omit for the engine default
+	NumTypos *int `form:"num_typos" enums:"0,1,2"`
+	// Expected arrival time (e.g. 23:30 or 11:30 PM); only hotels still checking guests in are returned
+	ArrivalTime string `form:"arrival_time"`
+	// Exclude hotels without check-in hours when filtering by arrival_time
+	StrictCheckin bool `form:"strict_checkin"`
+	// Only hotels indexed at or after this time (e.g. 2024-01-01, RFC 3339, or relative to now such as -30d, -2w or -12h), at most results.new_hotels_max_days ago
+	CreatedAfter string `form:"created_after"`
+	// Only hotels indexed at or before this time
+	CreatedBefore string `form:"created_before"`
+	// Only hotels updated at or after this time
+	UpdatedAfter string `form:"updated_after"`
+	// Only hotels updated at or before this time
+	UpdatedBefore string `form:"updated_before"`
+	// Locale the search is counted under for the trending lists, such as es. Defaults to lang, then the Accept-Language header, then the language of the X-Country-Code header
+	Locale string `form:"locale"`
+	// Language of the search, used as its locale when locale is not given
+	Lang string `form:"lang"`
+}
+
+// CombinedSearchQuery holds the query parameters of a combined search: those of a hotel search,
+// with the query required, and the number of suggestions.
+type CombinedSearchQuery struct {
+	SearchQuery
+	// Search query used for both results and suggestions
+	Q string `form:"q" binding:"required"`
+	// Maximum number of suggestions to return (default: 5)
+	SuggestLimit int `form:"suggest_limit"`
+}
+
+// decodeQuery sets the fields of the struct dst points to from the query parameters named by
+// their form tags, recursing into embedded structs. Like the handlers did parameter by
+// parameter, values that do not parse are dropped and leave the field unset; the request
+// validation middleware is what rejects them.
+func decodeQuery(values url.Values, dst any) {
+	decodeQueryFields(values, reflect.ValueOf(dst).Elem())
+}
+
+func decodeQueryFields(values url.Values, target reflect.Value) {
+	for i := range target.NumField() {
+		field, structField := target.Field(i), target.Type().Field(i)
+		if structField.Anonymous && field.Kind() == reflect.Struct {
+			decodeQueryFields(values, field)
+			continue
+		}
+		name := structField.Tag.Get("form")
+		if name == "" || len(values[name]) == 0 {
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			field.Set(reflect.ValueOf(values[name]))
+			continue
+		}
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			parsed := reflect.New(field.Type().Elem())
+			if setQueryValue(parsed.Elem(), value) {
+				field.Set(parsed)
+			}
+			continue
+		}
+		setQueryValue(field, value)
+	}
+}
+
+// setQueryValue parses value into field and reports whether it parsed.
+func setQueryValue(field reflect.Value, value string) bool {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return false
+		}
+		field.SetInt(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return false
+		}
+		field.SetFloat(parsed)
+	default:
+		return false
+	}
+	return true
+}
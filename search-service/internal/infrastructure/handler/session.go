@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	sessionCookieName = "hms_session"
+	sessionIDBytes    = 16
+)
+
+// SessionSigner issues and verifies the cookie that identifies anonymous guests. The cookie value
+// is "<session id>.<HMAC-SHA256 of the id>", so clients cannot pick another guest's session.
+type SessionSigner struct {
+	secret []byte
+	maxAge time.Duration
+}
+
+func NewSessionSigner(secret string, maxAge time.Duration) *SessionSigner {
+	return &SessionSigner{secret: []byte(secret), maxAge: maxAge}
+}
+
+// SessionID returns the session of the request, or false when it has no cookie or the
+// signature does not match.
+func (s *SessionSigner) SessionID(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return "", false
+	}
+
+	sessionID, signature, found := strings.Cut(cookie.Value, ".")
+	if !found || len(sessionID) != hex.EncodedLen(sessionIDBytes) {
+		return "", false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.sign(sessionID)) {
+		return "", false
+	}
+	return sessionID, true
+}
+
+// Ensure returns the session of the request, starting a new one when it has none. The cookie is
+// written again either way, so it expires maxAge after the last visit.
+func (s *SessionSigner) Ensure(w http.ResponseWriter, r *http.Request) (string, error) {
+	sessionID, ok := s.SessionID(r)
+	if !ok {
+		buf := make([]byte, sessionIDBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		sessionID = hex.EncodeToString(buf)
+	}
+	s.Refresh(w, r, sessionID)
+	return sessionID, nil
+}
+
+// Refresh writes the session cookie with a new expiry.
+func (s *SessionSigner) Refresh(w http.ResponseWriter, r *http.Request, sessionID string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sessionID + "." + hex.EncodeToString(s.sign(sessionID)),
+		Path:     "/",
+		MaxAge:   int(s.maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *SessionSigner) sign(sessionID string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(sessionID))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"sync"
+	"time"
+)
+
+// SlowRequest is a request that took longer than the slow request threshold.
+type SlowRequest struct {
+	RequestID  string    `json:"request_id"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Query      string    `json:"query,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	StatusCode int       `json:"status_code"`
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// DurationMs is split into the time the handler took to write the response headers and the
+	// time spent writing the body after them.
+	DurationMs     int64 `json:"duration_ms"`
+	TimeToHeaderMs int64 `json:"time_to_header_ms"`
+	WriteBodyMs    int64 `json:"write_body_ms"`
+
+	// ResponseBody holds the start of the body, up to the first write made once the request was
+	// already slow.
+	ResponseBody          string `json:"response_body,omitempty"`
+	ResponseBodyTruncated bool   `json:"response_body_truncated,omitempty"`
+}
+
+// SlowRequestLog keeps the most recent slow requests in memory, overwriting the oldest once full.
+type SlowRequestLog struct {
+	mu      sync.Mutex
+	entries []SlowRequest
+	next    int
+	full    bool
+}
+
+func NewSlowRequestLog(capacity int) *SlowRequestLog {
+	return &SlowRequestLog{entries: make([]SlowRequest, capacity)}
+}
+
+func (l *SlowRequestLog) Record(request SlowRequest) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = request
+	l.next = (l.next + 1) % len(l.entries)
+	l.full = l.full || l.next == 0
+}
+
+// List returns the recorded slow requests, most recent first.
+func (l *SlowRequestLog) List() []SlowRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.entries)
+	}
+
+	requests := make([]SlowRequest, 0, count)
+	for i := 1; i <= count; i++ {
+		requests = append(requests, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return requests
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/application/usecase"
+	"github.com/victoragudo/hotel-management-system/search-service/internal/domain/usage"
+)
+
+// rangeUsageRepository records the range of the last usage query.
+type rangeUsageRepository struct {
+	clientID string
+	from, to time.Time
+}
+
+func (r *rangeUsageRepository) UpsertDailyUsage(context.Context, []usage.DailyUsage) error {
+	return nil
+}
+
+func (r *rangeUsageRepository) FindDailyUsage(_ context.Context, clientID string, from, to time.Time) ([]usage.DailyUsage, error) {
+	r.clientID, r.from, r.to = clientID, from, to
+	return nil, nil
+}
+
+func TestGetUsageDateRange(t *testing.T) {
+	today := usage.Day(time.Now())
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{
+			name:         "explicit range",
+			query:        "key=partner-key&from=2026-03-10&to=2026-03-12",
+			expectedCode: http.StatusOK,
+			expectedFrom: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "defaults to the last 30 days",
+			query:        "key=partner-key",
+			expectedCode: http.StatusOK,
+			expectedFrom: today.AddDate(0, 0, -29),
+			expectedTo:   today,
+		},
+		{
+			name:         "from defaults to 30 days before to",
+			query:        "key=partner-key&to=2026-03-31",
+			expectedCode: http.StatusOK,
+			expectedFrom: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "missing key", query: "from=2026-03-10", expectedCode: http.StatusBadRequest},
+		{name: "invalid from", query: "key=partner-key&from=10/03/2026", expectedCode: http.StatusBadRequest},
+		{name: "invalid to", query: "key=partner-key&to=yesterday", expectedCode: http.StatusBadRequest},
+		{name: "from after to", query: "key=partner-key&from=2026-03-12&to=2026-03-10", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &rangeUsageRepository{}
+			logger := slog.New(slog.DiscardHandler)
+			adminHandler := &AdminHandler{
+				responder:          responder{logger: logger},
+				usageReportUseCase: usecase.NewUsageReportUseCase(nil, repo, logger),
+			}
+
+			recorder := httptest.NewRecorder()
+			adminHandler.GetUsage(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/admin/usage?"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedCode, recorder.Code, recorder.Body.String())
+			if tt.expectedCode != http.StatusOK {
+				assert.Empty(t, repo.clientID, "invalid requests are not queried")
+				return
+			}
+			assert.Equal(t, usage.ClientID("partner-key"), repo.clientID)
+			assert.Equal(t, tt.expectedFrom, repo.from)
+			assert.Equal(t, tt.expectedTo, repo.to)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Package openapi converts the Swagger 2 document swag generates from the handler annotations
+// into the OpenAPI 3 document served at /openapi.json, and validates requests against it.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Version is the OpenAPI version of the converted document.
+const Version = "3.0.3"
+
+const (
+	swaggerRefPrefix = "#/definitions/"
+	schemaRefPrefix  = "#/components/schemas/"
+)
+
+// Spec is the OpenAPI 3 document of the API.
+type Spec struct {
+	document map[string]any
+	schemas  map[string]any
+	// aliases maps the schema names swag qualifies with the import path of their package, such
+	// as github_com_..._domain_hotel.Hotel, to the package-qualified hotel.Hotel.
+	aliases map[string]string
+	// operations holds the parameters of each operation by path template and method.
+	operations map[string]map[string]*operation
+}
+
+type operation struct {
+	parameters []parameter
+	body       *requestBody
+}
+
+type parameter struct {
+	name     string
+	in       string
+	required bool
+	// explode is set for array parameters repeated once per value rather than comma-separated.
+	explode bool
+	schema  map[string]any
+}
+
+type requestBody struct {
+	required bool
+	schema   map[string]any
+}
+
+// Load converts the Swagger 2 document swagger into OpenAPI 3.
+func Load(swagger string) (*Spec, error) {
+	var source map[string]any
+	if err := json.Unmarshal([]byte(swagger), &source); err != nil {
+		return nil, fmt.Errorf("failed to decode swagger document: %w", err)
+	}
+
+	definitions := object(source["definitions"])
+	spec := &Spec{
+		aliases:    schemaAliases(definitions),
+		schemas:    make(map[string]any, len(definitions)),
+		operations: make(map[string]map[string]*operation),
+	}
+	for name, definition := range definitions {
+		spec.schemas[spec.schemaName(name)] = spec.rewriteRefs(definition)
+	}
+
+	basePath, _ := source["basePath"].(string)
+	if basePath == "" {
+		basePath = "/"
+	}
+	spec.document = map[string]any{
+		"openapi": Version,
+		"info":    source["info"],
+		"servers": []any{map[string]any{"url": basePath}},
+		"paths":   spec.convertPaths(object(source["paths"]), source),
+		"components": map[string]any{
+			"schemas":         spec.schemas,
+			"securitySchemes": convertSecuritySchemes(object(source["securityDefinitions"])),
+		},
+	}
+	if tags, ok := source["tags"]; ok {
+		spec.document["tags"] = tags
+	}
+
+	return spec, nil
+}
+
+// JSON returns the document indented, with its keys sorted so that it diffs cleanly.
+func (s *Spec) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s.document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openapi document: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func (s *Spec) convertPaths(paths map[string]any, source map[string]any) map[string]any {
+	converted := make(map[string]any, len(paths))
+	for path, item := range paths {
+		methods := make(map[string]any)
+		s.operations[path] = make(map[string]*operation)
+		for method, value := range object(item) {
+			op := object(value)
+			consumes := firstString(op["consumes"], source["consumes"], "application/json")
+			produces := firstString(op["produces"], source["produces"], "application/json")
+			methods[method] = s.convertOperation(path, strings.ToUpper(method), op, consumes, produces)
+		}
+		converted[path] = methods
+	}
+	return converted
+}
+
+func (s *Spec) convertOperation(path, method string, source map[string]any, consumes, produces string) map[string]any {
+	converted := make(map[string]any, len(source))
+	for key, value := range source {
+		switch key {
+		case "consumes", "produces", "parameters", "responses":
+		default:
+			converted[key] = value
+		}
+	}
+
+	op := &operation{}
+	var parameters []any
+	for _, value := range array(source["parameters"]) {
+		param := object(value)
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+
+		if in == "body" {
+			schema := s.rewriteRefs(object(param["schema"])).(map[string]any)
+			body := map[string]any{"content": map[string]any{consumes: map[string]any{"schema": schema}}}
+			copyKeys(body, param, "description")
+			if required {
+				body["required"] = true
+			}
+			converted["requestBody"] = body
+			op.body = &requestBody{required: required, schema: schema}
+			continue
+		}
+
+		schema := parameterSchema(param)
+		convertedParam := map[string]any{"name": name, "in": in, "schema": schema}
+		copyKeys(convertedParam, param, "description")
+		if required || in == "path" {
+			convertedParam["required"] = true
+		}
+		explode := param["collectionFormat"] == "multi"
+		if schema["type"] == "array" {
+			convertedParam["style"] = "form"
+			convertedParam["explode"] = explode
+		}
+		parameters = append(parameters, convertedParam)
+		op.parameters = append(op.parameters, parameter{name: name, in: in, required: required || in == "path", explode: explode, schema: schema})
+	}
+	if len(parameters) > 0 {
+		converted["parameters"] = parameters
+	}
+
+	responses := make(map[string]any)
+	for code, value := range object(source["responses"]) {
+		response := object(value)
+		convertedResponse := map[string]any{"description": response["description"]}
+		if schema, ok := response["schema"]; ok {
+			convertedResponse["content"] = map[string]any{produces: map[string]any{"schema": s.rewriteRefs(schema)}}
+		}
+		if headers := object(response["headers"]); len(headers) > 0 {
+			convertedHeaders := make(map[string]any, len(headers))
+			for name, value := range headers {
+				header := object(value)
+				convertedHeader := map[string]any{"schema": parameterSchema(header)}
+				copyKeys(convertedHeader, header, "description")
+				convertedHeaders[name] = convertedHeader
+			}
+			convertedResponse["headers"] = convertedHeaders
+		}
+		responses[code] = convertedResponse
+	}
+	converted["responses"] = responses
+
+	s.operations[path][method] = op
+	return converted
+}
+
+// parameterSchema moves the type of a Swagger 2 parameter or header into a schema.
+func parameterSchema(param map[string]any) map[string]any {
+	schema := make(map[string]any)
+	copyKeys(schema, param, "type", "format", "enum", "default", "minimum", "maximum", "minLength", "maxLength", "pattern")
+	if items, ok := param["items"]; ok {
+		schema["items"] = parameterSchema(object(items))
+	}
+	return schema
+}
+
+func convertSecuritySchemes(definitions map[string]any) map[string]any {
+	schemes := make(map[string]any, len(definitions))
+	for name, value := range definitions {
+		definition := object(value)
+		scheme := make(map[string]any)
+		switch definition["type"] {
+		case "basic":
+			scheme["type"], scheme["scheme"] = "http", "basic"
+		default:
+			copyKeys(scheme, definition, "type", "name", "in")
+		}
+		copyKeys(scheme, definition, "description")
+		schemes[name] = scheme
+	}
+	return schemes
+}
+
+// schemaAliases shortens the schema names swag qualifies with the import path of their package
+// to the package name, unless two schemas would then share a name.
+func schemaAliases(definitions map[string]any) map[string]string {
+	aliased := make(map[string][]string)
+	for name := range definitions {
+		qualifier, typeName, ok := cutLast(name, ".")
+		if !ok {
+			continue
+		}
+		if _, pkg, ok := cutLast(qualifier, "_"); ok {
+			alias := pkg + "." + typeName
+			aliased[alias] = append(aliased[alias], name)
+		}
+	}
+
+	aliases := make(map[string]string)
+	for alias, names := range aliased {
+		if _, taken := definitions[alias]; len(names) == 1 && !taken {
+			aliases[names[0]] = alias
+		}
+	}
+	return aliases
+}
+
+func (s *Spec) schemaName(name string) string {
+	if alias, ok := s.aliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// rewriteRefs returns value with its Swagger 2 definition references pointing to the
+// component schemas.
+func (s *Spec) rewriteRefs(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		rewritten := make(map[string]any, len(value))
+		for key, item := range value {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				rewritten[key] = schemaRefPrefix + s.schemaName(strings.TrimPrefix(ref, swaggerRefPrefix))
+				continue
+			}
+			rewritten[key] = s.rewriteRefs(item)
+		}
+		return rewritten
+	case []any:
+		rewritten := make([]any, len(value))
+		for i, item := range value {
+			rewritten[i] = s.rewriteRefs(item)
+		}
+		return rewritten
+	}
+	return value
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func copyKeys(dst, src map[string]any, keys ...string) {
+	for _, key := range keys {
+		if value, ok := src[key]; ok {
+			dst[key] = value
+		}
+	}
+}
+
+func firstString(values ...any) string {
+	for _, value := range values {
+		switch value := value.(type) {
+		case []any:
+			if len(value) > 0 {
+				if s, ok := value[0].(string); ok {
+					return s
+				}
+			}
+		case string:
+			return value
+		}
+	}
+	return ""
+}
+
+func object(value any) map[string]any {
+	if m, ok := value.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
+
+func array(value any) []any {
+	a, _ := value.([]any)
+	return a
+}
@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aliasSwagger = `{
+	"swagger": "2.0",
+	"paths": {
+		"/hotels/{id}": {"get": {"responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/github_com_acme_api_internal_domain_hotel.Hotel"}}}}}
+	},
+	"definitions": {
+		"github_com_acme_api_internal_domain_hotel.Hotel": {"type": "object", "properties": {"Address": {"$ref": "#/definitions/github_com_acme_api_internal_domain_hotel.Address"}}},
+		"github_com_acme_api_internal_domain_hotel.Address": {"type": "object"},
+		"github_com_acme_api_internal_domain_search.Suggestion": {"type": "object"},
+		"github_com_acme_api_internal_legacy_search.Suggestion": {"type": "object"},
+		"github_com_acme_api_internal_domain_usecase.Job": {"type": "object"},
+		"usecase.Job": {"type": "object"}
+	}
+}`
+
+func TestLoadShortensSchemaNames(t *testing.T) {
+	spec, err := Load(aliasSwagger)
+	require.NoError(t, err)
+	data, err := spec.JSON()
+	require.NoError(t, err)
+
+	var document struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &document))
+
+	schemas := document.Components.Schemas
+	assert.Contains(t, schemas, "hotel.Hotel")
+	assert.Contains(t, schemas, "hotel.Address")
+	assert.Equal(t, "#/components/schemas/hotel.Address", schemas["hotel.Hotel"]["properties"].(map[string]any)["Address"].(map[string]any)["$ref"])
+	assert.Contains(t, schemas, "github_com_acme_api_internal_domain_search.Suggestion", "names shared by two packages stay qualified")
+	assert.Contains(t, schemas, "github_com_acme_api_internal_legacy_search.Suggestion")
+	assert.Contains(t, schemas, "github_com_acme_api_internal_domain_usecase.Job", "names already taken stay qualified")
+	assert.Contains(t, schemas, "usecase.Job")
+
+	response := document.Paths["/hotels/{id}"]["get"]["responses"].(map[string]any)["200"].(map[string]any)
+	assert.Equal(t, "#/components/schemas/hotel.Hotel", response["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)["$ref"])
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxSchemaDepth stops the body validation of recursive schemas.
+const maxSchemaDepth = 16
+
+// Validate checks the path, query and JSON body parameters of r against the operation of
+// r.Method on pathTemplate and returns one problem per invalid parameter. The body is read and
+// put back for the handler. Query parameters the operation does not list are problems too, as
+// the handler ignores them. Routes the document does not describe are not checked.
+func (s *Spec) Validate(r *http.Request, pathTemplate string, pathParams map[string]string) []string {
+	op, ok := s.operations[pathTemplate][r.Method]
+	if !ok {
+		return nil
+	}
+
+	var problems []string
+	query := r.URL.Query()
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		if !slices.ContainsFunc(op.parameters, func(param parameter) bool { return param.in == "query" && param.name == name }) {
+			problems = append(problems, fmt.Sprintf("query parameter %s is not supported", name))
+		}
+	}
+	for _, param := range op.parameters {
+		var values []string
+		switch param.in {
+		case "query":
+			values = query[param.name]
+		case "path":
+			if value, ok := pathParams[param.name]; ok {
+				values = []string{value}
+			}
+		case "header":
+			values = r.Header.Values(param.name)
+		default:
+			continue
+		}
+		problems = append(problems, param.check(values)...)
+	}
+
+	if op.body != nil {
+		problems = append(problems, s.checkBody(r, op.body)...)
+	}
+	return problems
+}
+
+func (p parameter) check(values []string) []string {
+	if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+		if p.required {
+			return []string{fmt.Sprintf("%s parameter %s is required", p.in, p.name)}
+		}
+		return nil
+	}
+
+	schema := p.schema
+	if schema["type"] == "array" {
+		if !p.explode {
+			values = strings.Split(strings.Join(values, ","), ",")
+		}
+		schema = object(schema["items"])
+	} else {
+		values = values[:1]
+	}
+
+	var problems []string
+	for _, value := range values {
+		if err := checkScalar(schema, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s parameter %s %v", p.in, p.name, err))
+		}
+	}
+	return problems
+}
+
+// checkScalar checks a parameter value, which arrives as text, against a primitive schema.
+func checkScalar(schema map[string]any, value string) error {
+	var number float64
+	var err error
+	switch schema["type"] {
+	case "integer":
+		var integer int64
+		integer, err = strconv.ParseInt(value, 10, 64)
+		number = float64(integer)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
+	case "number":
+		if number, err = strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+	}
+
+	if enum := array(schema["enum"]); len(enum) > 0 && !slices.ContainsFunc(enum, func(allowed any) bool {
+		return strings.EqualFold(formatValue(allowed), value)
+	}) {
+		return fmt.Errorf("must be one of %s, got %q", formatValues(enum), value)
+	}
+	if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+		return fmt.Errorf("must be at least %s, got %s", formatValue(minimum), value)
+	}
+	if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+		return fmt.Errorf("must be at most %s, got %s", formatValue(maximum), value)
+	}
+	return nil
+}
+
+func (s *Spec) checkBody(r *http.Request, body *requestBody) []string {
+	if r.Body == nil || r.Body == http.NoBody {
+		if body.required {
+			return []string{"request body is required"}
+		}
+		return nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read request body: %v", err)}
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.required {
+			return []string{"request body is required"}
+		}
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []string{fmt.Sprintf("request body is not valid JSON: %v", err)}
+	}
+
+	var problems []string
+	s.checkValue(body.schema, value, "body", 0, &problems)
+	return problems
+}
+
+// checkValue checks a decoded JSON value against schema. Null values are accepted, as the
+// handlers read them as zero values.
+func (s *Spec) checkValue(schema map[string]any, value any, path string, depth int, problems *[]string) {
+	if value == nil || depth > maxSchemaDepth {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = object(s.schemas[strings.TrimPrefix(ref, schemaRefPrefix)])
+	}
+	for _, part := range array(schema["allOf"]) {
+		s.checkValue(object(part), value, path, depth+1, problems)
+	}
+
+	switch schema["type"] {
+	case "object":
+		fields, ok := value.(map[string]any)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s must be an object", path))
+			return
+		}
+		for _, name := range array(schema["required"]) {
+			if name, ok := name.(string); ok {
+				if _, present := fields[name]; !present {
+					*problems = append(*problems, fmt.Sprintf("%s.%s is required", path, name))
+				}
+			}
+		}
+		properties := object(schema["properties"])
+		for name, field := range fields {
+			if property, ok := properties[name]; ok {
+				s.checkValue(object(property), field, path+"."+name, depth+1, problems)
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s must be an array", path))
+			return
+		}
+		for i, item := range items {
+			s.checkValue(object(schema["items"]), item, fmt.Sprintf("%s[%d]", path, i), depth+1, problems)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s must be a string", path))
+			return
+		}
+		if enum := array(schema["enum"]); len(enum) > 0 && !slices.Contains(enum, any(text)) {
+			*problems = append(*problems, fmt.Sprintf("%s must be one of %s, got %q", path, formatValues(enum), text))
+		}
+	case "integer":
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			*problems = append(*problems, fmt.Sprintf("%s must be an integer", path))
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			*problems = append(*problems, fmt.Sprintf("%s must be a number", path))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*problems = append(*problems, fmt.Sprintf("%s must be true or false", path))
+		}
+	}
+}
+
+func formatValue(value any) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatValue(value)
+	}
+	return strings.Join(formatted, ", ")
+}
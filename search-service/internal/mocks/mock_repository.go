@@ -42,6 +42,126 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ArchiveReviews mocks base method.
+func (m *MockRepository) ArchiveReviews(ctx context.Context, afterHotelID int64, hotelLimit int, retention hotel.ReviewRetention) (hotel.ReviewArchiveBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveReviews", ctx, afterHotelID, hotelLimit, retention)
+	ret0, _ := ret[0].(hotel.ReviewArchiveBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveReviews indicates an expected call of ArchiveReviews.
+func (mr *MockRepositoryMockRecorder) ArchiveReviews(ctx, afterHotelID, hotelLimit, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveReviews", reflect.TypeOf((*MockRepository)(nil).ArchiveReviews), ctx, afterHotelID, hotelLimit, retention)
+}
+
+// CountAmenities mocks base method.
+func (m *MockRepository) CountAmenities(ctx context.Context) ([]hotel.FacilityCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAmenities", ctx)
+	ret0, _ := ret[0].([]hotel.FacilityCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAmenities indicates an expected call of CountAmenities.
+func (mr *MockRepositoryMockRecorder) CountAmenities(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAmenities", reflect.TypeOf((*MockRepository)(nil).CountAmenities), ctx)
+}
+
+// CountHotels mocks base method.
+func (m *MockRepository) CountHotels(ctx context.Context, estimate bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHotels", ctx, estimate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHotels indicates an expected call of CountHotels.
+func (mr *MockRepositoryMockRecorder) CountHotels(ctx, estimate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHotels", reflect.TypeOf((*MockRepository)(nil).CountHotels), ctx, estimate)
+}
+
+// CountHotelsUpdatedAfter mocks base method.
+func (m *MockRepository) CountHotelsUpdatedAfter(ctx context.Context, timestamp time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHotelsUpdatedAfter", ctx, timestamp)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHotelsUpdatedAfter indicates an expected call of CountHotelsUpdatedAfter.
+func (mr *MockRepositoryMockRecorder) CountHotelsUpdatedAfter(ctx, timestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHotelsUpdatedAfter", reflect.TypeOf((*MockRepository)(nil).CountHotelsUpdatedAfter), ctx, timestamp)
+}
+
+// CountRawFacilities mocks base method.
+func (m *MockRepository) CountRawFacilities(ctx context.Context) ([]hotel.FacilityCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRawFacilities", ctx)
+	ret0, _ := ret[0].([]hotel.FacilityCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRawFacilities indicates an expected call of CountRawFacilities.
+func (mr *MockRepositoryMockRecorder) CountRawFacilities(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRawFacilities", reflect.TypeOf((*MockRepository)(nil).CountRawFacilities), ctx)
+}
+
+// CountReviews mocks base method.
+func (m *MockRepository) CountReviews(ctx context.Context, estimate bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviews", ctx, estimate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviews indicates an expected call of CountReviews.
+func (mr *MockRepositoryMockRecorder) CountReviews(ctx, estimate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviews", reflect.TypeOf((*MockRepository)(nil).CountReviews), ctx, estimate)
+}
+
+// CountTranslations mocks base method.
+func (m *MockRepository) CountTranslations(ctx context.Context, estimate bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTranslations", ctx, estimate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTranslations indicates an expected call of CountTranslations.
+func (mr *MockRepositoryMockRecorder) CountTranslations(ctx, estimate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTranslations", reflect.TypeOf((*MockRepository)(nil).CountTranslations), ctx, estimate)
+}
+
+// DeduplicateReviews mocks base method.
+func (m *MockRepository) DeduplicateReviews(ctx context.Context, afterHotelID int64, hotelLimit int) (hotel.ReviewDedupBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeduplicateReviews", ctx, afterHotelID, hotelLimit)
+	ret0, _ := ret[0].(hotel.ReviewDedupBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeduplicateReviews indicates an expected call of DeduplicateReviews.
+func (mr *MockRepositoryMockRecorder) DeduplicateReviews(ctx, afterHotelID, hotelLimit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeduplicateReviews", reflect.TypeOf((*MockRepository)(nil).DeduplicateReviews), ctx, afterHotelID, hotelLimit)
+}
+
 // Delete mocks base method.
 func (m *MockRepository) Delete(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -56,19 +176,54 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
 }
 
+// FindActiveVersionsAfter mocks base method.
+func (m *MockRepository) FindActiveVersionsAfter(ctx context.Context, afterHotelID int64, limit int) ([]hotel.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveVersionsAfter", ctx, afterHotelID, limit)
+	ret0, _ := ret[0].([]hotel.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveVersionsAfter indicates an expected call of FindActiveVersionsAfter.
+func (mr *MockRepositoryMockRecorder) FindActiveVersionsAfter(ctx, afterHotelID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveVersionsAfter", reflect.TypeOf((*MockRepository)(nil).FindActiveVersionsAfter), ctx, afterHotelID, limit)
+}
+
+// FindAfterHotelID mocks base method.
+func (m *MockRepository) FindAfterHotelID(ctx context.Context, afterHotelID int64, limit int) ([]*hotel.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfterHotelID", ctx, afterHotelID, limit)
+	ret0, _ := ret[0].([]*hotel.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfterHotelID indicates an expected call of FindAfterHotelID.
+func (mr *MockRepositoryMockRecorder) FindAfterHotelID(ctx, afterHotelID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfterHotelID", reflect.TypeOf((*MockRepository)(nil).FindAfterHotelID), ctx, afterHotelID, limit)
+}
+
 // FindAll mocks base method.
-func (m *MockRepository) FindAll(ctx context.Context, limit, offset int) ([]*hotel.Hotel, error) {
+func (m *MockRepository) FindAll(ctx context.Context, limit, offset int, filter ...hotel.FindFilter) ([]*hotel.Hotel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, limit, offset)
+	varargs := []any{ctx, limit, offset}
+	for _, a := range filter {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAll", varargs...)
 	ret0, _ := ret[0].([]*hotel.Hotel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockRepositoryMockRecorder) FindAll(ctx, limit, offset any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindAll(ctx, limit, offset any, filter ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx, limit, offset)
+	varargs := append([]any{ctx, limit, offset}, filter...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), varargs...)
 }
 
 // FindByHotelID mocks base method.
@@ -86,6 +241,81 @@ func (mr *MockRepositoryMockRecorder) FindByHotelID(ctx, hotelID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHotelID", reflect.TypeOf((*MockRepository)(nil).FindByHotelID), ctx, hotelID)
 }
 
+// FindByHotelIDs mocks base method.
+func (m *MockRepository) FindByHotelIDs(ctx context.Context, hotelIDs []int64) ([]*hotel.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHotelIDs", ctx, hotelIDs)
+	ret0, _ := ret[0].([]*hotel.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHotelIDs indicates an expected call of FindByHotelIDs.
+func (mr *MockRepositoryMockRecorder) FindByHotelIDs(ctx, hotelIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHotelIDs", reflect.TypeOf((*MockRepository)(nil).FindByHotelIDs), ctx, hotelIDs)
+}
+
+// FindBySourceID mocks base method.
+func (m *MockRepository) FindBySourceID(ctx context.Context, source, sourceID string) ([]*hotel.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySourceID", ctx, source, sourceID)
+	ret0, _ := ret[0].([]*hotel.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySourceID indicates an expected call of FindBySourceID.
+func (mr *MockRepositoryMockRecorder) FindBySourceID(ctx, source, sourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySourceID", reflect.TypeOf((*MockRepository)(nil).FindBySourceID), ctx, source, sourceID)
+}
+
+// FindDuplicates mocks base method.
+func (m *MockRepository) FindDuplicates(ctx context.Context, criteria hotel.DuplicateCriteria) ([]hotel.DuplicateGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", ctx, criteria)
+	ret0, _ := ret[0].([]hotel.DuplicateGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockRepositoryMockRecorder) FindDuplicates(ctx, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockRepository)(nil).FindDuplicates), ctx, criteria)
+}
+
+// FindRemovedHotelIDsAfter mocks base method.
+func (m *MockRepository) FindRemovedHotelIDsAfter(ctx context.Context, timestamp time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRemovedHotelIDsAfter", ctx, timestamp)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRemovedHotelIDsAfter indicates an expected call of FindRemovedHotelIDsAfter.
+func (mr *MockRepositoryMockRecorder) FindRemovedHotelIDsAfter(ctx, timestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRemovedHotelIDsAfter", reflect.TypeOf((*MockRepository)(nil).FindRemovedHotelIDsAfter), ctx, timestamp)
+}
+
+// FindTranslation mocks base method.
+func (m *MockRepository) FindTranslation(ctx context.Context, hotelID int64, lang string) (*hotel.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTranslation", ctx, hotelID, lang)
+	ret0, _ := ret[0].(*hotel.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTranslation indicates an expected call of FindTranslation.
+func (mr *MockRepositoryMockRecorder) FindTranslation(ctx, hotelID, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTranslation", reflect.TypeOf((*MockRepository)(nil).FindTranslation), ctx, hotelID, lang)
+}
+
 // FindUpdatedAfter mocks base method.
 func (m *MockRepository) FindUpdatedAfter(ctx context.Context, timestamp time.Time) ([]*hotel.Hotel, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +331,96 @@ func (mr *MockRepositoryMockRecorder) FindUpdatedAfter(ctx, timestamp any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUpdatedAfter", reflect.TypeOf((*MockRepository)(nil).FindUpdatedAfter), ctx, timestamp)
 }
 
+// GetDistinctCities mocks base method.
+func (m *MockRepository) GetDistinctCities(ctx context.Context, prefix string, limit int) ([]hotel.CityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDistinctCities", ctx, prefix, limit)
+	ret0, _ := ret[0].([]hotel.CityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDistinctCities indicates an expected call of GetDistinctCities.
+func (mr *MockRepositoryMockRecorder) GetDistinctCities(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistinctCities", reflect.TypeOf((*MockRepository)(nil).GetDistinctCities), ctx, prefix, limit)
+}
+
+// ListChanges mocks base method.
+func (m *MockRepository) ListChanges(ctx context.Context, hotelID int64, options hotel.ListChangesOptions) ([]hotel.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, hotelID, options)
+	ret0, _ := ret[0].([]hotel.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockRepositoryMockRecorder) ListChanges(ctx, hotelID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockRepository)(nil).ListChanges), ctx, hotelID, options)
+}
+
+// ListReviews mocks base method.
+func (m *MockRepository) ListReviews(ctx context.Context, hotelID int64, options hotel.ListReviewsOptions) ([]hotel.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, hotelID, options)
+	ret0, _ := ret[0].([]hotel.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockRepositoryMockRecorder) ListReviews(ctx, hotelID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockRepository)(nil).ListReviews), ctx, hotelID, options)
+}
+
+// ListTranslations mocks base method.
+func (m *MockRepository) ListTranslations(ctx context.Context, hotelID int64) ([]hotel.TranslationSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTranslations", ctx, hotelID)
+	ret0, _ := ret[0].([]hotel.TranslationSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTranslations indicates an expected call of ListTranslations.
+func (mr *MockRepositoryMockRecorder) ListTranslations(ctx, hotelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTranslations", reflect.TypeOf((*MockRepository)(nil).ListTranslations), ctx, hotelID)
+}
+
+// MergeHotels mocks base method.
+func (m *MockRepository) MergeHotels(ctx context.Context, primaryID, duplicateID int64) (hotel.MergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeHotels", ctx, primaryID, duplicateID)
+	ret0, _ := ret[0].(hotel.MergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeHotels indicates an expected call of MergeHotels.
+func (mr *MockRepositoryMockRecorder) MergeHotels(ctx, primaryID, duplicateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeHotels", reflect.TypeOf((*MockRepository)(nil).MergeHotels), ctx, primaryID, duplicateID)
+}
+
+// NormalizeFacilities mocks base method.
+func (m *MockRepository) NormalizeFacilities(ctx context.Context, afterHotelID int64, hotelLimit int, normalize func(string) string) (hotel.FacilityNormalizeBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeFacilities", ctx, afterHotelID, hotelLimit, normalize)
+	ret0, _ := ret[0].(hotel.FacilityNormalizeBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeFacilities indicates an expected call of NormalizeFacilities.
+func (mr *MockRepositoryMockRecorder) NormalizeFacilities(ctx, afterHotelID, hotelLimit, normalize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeFacilities", reflect.TypeOf((*MockRepository)(nil).NormalizeFacilities), ctx, afterHotelID, hotelLimit, normalize)
+}
+
 // Save mocks base method.
 func (m *MockRepository) Save(ctx context.Context, arg1 *hotel.Hotel) error {
 	m.ctrl.T.Helper()
@@ -115,6 +435,20 @@ func (mr *MockRepositoryMockRecorder) Save(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, arg1)
 }
 
+// SaveTranslation mocks base method.
+func (m *MockRepository) SaveTranslation(ctx context.Context, translation *hotel.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTranslation", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTranslation indicates an expected call of SaveTranslation.
+func (mr *MockRepositoryMockRecorder) SaveTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTranslation", reflect.TypeOf((*MockRepository)(nil).SaveTranslation), ctx, translation)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, arg1 *hotel.Hotel) error {
 	m.ctrl.T.Helper()
@@ -129,6 +463,20 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, arg1)
 }
 
+// UpdateWithChange mocks base method.
+func (m *MockRepository) UpdateWithChange(ctx context.Context, arg1 *hotel.Hotel, change hotel.Change) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithChange", ctx, arg1, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithChange indicates an expected call of UpdateWithChange.
+func (mr *MockRepositoryMockRecorder) UpdateWithChange(ctx, arg1, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithChange", reflect.TypeOf((*MockRepository)(nil).UpdateWithChange), ctx, arg1, change)
+}
+
 // MockProvider is a mock of Provider interface.
 type MockProvider struct {
 	ctrl     *gomock.Controller
@@ -198,6 +546,45 @@ func (mr *MockProviderMockRecorder) GetHotelTranslations(ctx, hotelID, languages
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelTranslations", reflect.TypeOf((*MockProvider)(nil).GetHotelTranslations), ctx, hotelID, languages)
 }
 
+// MockCounter is a mock of Counter interface.
+type MockCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockCounterMockRecorder is the mock recorder for MockCounter.
+type MockCounterMockRecorder struct {
+	mock *MockCounter
+}
+
+// NewMockCounter creates a new mock instance.
+func NewMockCounter(ctrl *gomock.Controller) *MockCounter {
+	mock := &MockCounter{ctrl: ctrl}
+	mock.recorder = &MockCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounter) EXPECT() *MockCounterMockRecorder {
+	return m.recorder
+}
+
+// Increment mocks base method.
+func (m *MockCounter) Increment(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCounterMockRecorder) Increment(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCounter)(nil).Increment), ctx, key)
+}
+
 // MockCacheRepository is a mock of CacheRepository interface.
 type MockCacheRepository struct {
 	ctrl     *gomock.Controller
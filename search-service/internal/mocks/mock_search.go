@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	hotel "github.com/victoragudo/hotel-management-system/search-service/internal/domain/hotel"
@@ -42,6 +43,20 @@ func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
 	return m.recorder
 }
 
+// Capabilities mocks base method.
+func (m *MockEngine) Capabilities() search.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(search.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockEngineMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockEngine)(nil).Capabilities))
+}
+
 // ClearIndex mocks base method.
 func (m *MockEngine) ClearIndex(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -70,6 +85,35 @@ func (mr *MockEngineMockRecorder) DeleteHotel(ctx, hotelID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHotel", reflect.TypeOf((*MockEngine)(nil).DeleteHotel), ctx, hotelID)
 }
 
+// ExportIDs mocks base method.
+func (m *MockEngine) ExportIDs(ctx context.Context) iter.Seq2[hotel.Version, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportIDs", ctx)
+	ret0, _ := ret[0].(iter.Seq2[hotel.Version, error])
+	return ret0
+}
+
+// ExportIDs indicates an expected call of ExportIDs.
+func (mr *MockEngineMockRecorder) ExportIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportIDs", reflect.TypeOf((*MockEngine)(nil).ExportIDs), ctx)
+}
+
+// GetFacetValues mocks base method.
+func (m *MockEngine) GetFacetValues(ctx context.Context, field, query string, limit int) ([]search.FacetItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacetValues", ctx, field, query, limit)
+	ret0, _ := ret[0].([]search.FacetItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacetValues indicates an expected call of GetFacetValues.
+func (mr *MockEngineMockRecorder) GetFacetValues(ctx, field, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacetValues", reflect.TypeOf((*MockEngine)(nil).GetFacetValues), ctx, field, query, limit)
+}
+
 // GetFacets mocks base method.
 func (m *MockEngine) GetFacets(ctx context.Context) (*search.Facets, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +187,50 @@ func (mr *MockEngineMockRecorder) Index(ctx, hotels any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockEngine)(nil).Index), ctx, hotels)
 }
 
+// Info mocks base method.
+func (m *MockEngine) Info() search.EngineInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(search.EngineInfo)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockEngineMockRecorder) Info() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockEngine)(nil).Info))
+}
+
+// MultiSearch mocks base method.
+func (m *MockEngine) MultiSearch(ctx context.Context, params search.Params, suggestionQuery string, suggestionLimit int) (*search.Result, []*search.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultiSearch", ctx, params, suggestionQuery, suggestionLimit)
+	ret0, _ := ret[0].(*search.Result)
+	ret1, _ := ret[1].([]*search.Suggestion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MultiSearch indicates an expected call of MultiSearch.
+func (mr *MockEngineMockRecorder) MultiSearch(ctx, params, suggestionQuery, suggestionLimit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiSearch", reflect.TypeOf((*MockEngine)(nil).MultiSearch), ctx, params, suggestionQuery, suggestionLimit)
+}
+
+// PartialUpdate mocks base method.
+func (m *MockEngine) PartialUpdate(ctx context.Context, hotelID int64, fields map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartialUpdate", ctx, hotelID, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PartialUpdate indicates an expected call of PartialUpdate.
+func (mr *MockEngineMockRecorder) PartialUpdate(ctx, hotelID, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialUpdate", reflect.TypeOf((*MockEngine)(nil).PartialUpdate), ctx, hotelID, fields)
+}
+
 // Search mocks base method.
 func (m *MockEngine) Search(ctx context.Context, params search.Params) (*search.Result, error) {
 	m.ctrl.T.Helper()